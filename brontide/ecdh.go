@@ -0,0 +1,41 @@
+package brontide
+
+import "github.com/roasbeef/btcd/btcec"
+
+// SingleKeyECDH is an abstraction over a node's long-term static key which is
+// only capable of carrying out ECDH operations. Brontide never requires the
+// raw private key itself, which allows the key to reside outside of the
+// process, e.g. within a remote signer.
+type SingleKeyECDH interface {
+	// PubKey returns the public key of the static key.
+	PubKey() *btcec.PublicKey
+
+	// ECDH performs an ECDH operation between pub and the static key. The
+	// returned value is the sha256 of the compressed shared point.
+	ECDH(pub *btcec.PublicKey) ([]byte, error)
+}
+
+// PrivKeyECDH is an implementation of the SingleKeyECDH interface backed by a
+// private key held in memory.
+type PrivKeyECDH struct {
+	// PrivKey is the private key used for all ECDH operations.
+	PrivKey *btcec.PrivateKey
+}
+
+// A compile-time assertion to ensure that PrivKeyECDH meets the
+// SingleKeyECDH interface.
+var _ SingleKeyECDH = (*PrivKeyECDH)(nil)
+
+// PubKey returns the public key of the backing private key.
+//
+// Part of the SingleKeyECDH interface.
+func (p *PrivKeyECDH) PubKey() *btcec.PublicKey {
+	return p.PrivKey.PubKey()
+}
+
+// ECDH performs an ECDH operation between pub and the backing private key.
+//
+// Part of the SingleKeyECDH interface.
+func (p *PrivKeyECDH) ECDH(pub *btcec.PublicKey) ([]byte, error) {
+	return ecdh(pub, p.PrivKey), nil
+}
@@ -0,0 +1,283 @@
+package brontide
+
+import (
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// handshakeReadTimeout is a read timeout that will be enforced when waiting
+// for data payloads during the various acts of Brontide. If the remote party
+// fails to deliver the proper payload within this time frame, then we'll fail
+// the connection.
+var handshakeReadTimeout = time.Second * 5
+
+// defaultHandshakes is the maximum number of handshakes which may be carried
+// out concurrently. Once this number is reached, no further connections are
+// accepted until one of the pending handshakes completes.
+const defaultHandshakes = 1000
+
+const (
+	// minAcceptBackoff is the initial delay before accepting connections
+	// again after a temporary error, such as running out of file
+	// descriptors. The delay doubles with each consecutive error, up to
+	// maxAcceptBackoff.
+	minAcceptBackoff = 5 * time.Millisecond
+
+	// maxAcceptBackoff is the longest delay between attempts to accept
+	// connections after a temporary error.
+	maxAcceptBackoff = time.Second
+)
+
+// ConnFilter is consulted with the remote address of each new connection
+// before any portion of the handshake is carried out. If a non-nil error is
+// returned, then the connection is closed and the error is delivered to
+// Accept.
+type ConnFilter func(remoteAddr net.Addr) error
+
+// Listener is an implementation of a net.Listener which executes an
+// authenticated key exchange and message encryption protocol dubbed "Brontide"
+// after initial connection acceptance. See the Machine struct for additional
+// details w.r.t the handshake and encryption scheme used within the
+// connection. Each handshake is carried out within its own goroutine, so a
+// slow or unresponsive remote party never holds up other connections.
+type Listener struct {
+	localStatic SingleKeyECDH
+
+	tcp net.Listener
+
+	// filter, if non-nil, decides whether each new connection may proceed
+	// to the handshake.
+	filter ConnFilter
+
+	// handshakeSema bounds the number of handshakes in progress.
+	handshakeSema chan struct{}
+
+	// conns delivers the result of each completed handshake to Accept.
+	conns chan maybeConn
+
+	quit chan struct{}
+}
+
+// maybeConn is the result of a handshake, either the resulting connection or
+// the error encountered.
+type maybeConn struct {
+	conn net.Conn
+	err  error
+}
+
+// A compile-time assertion to ensure that Listener meets the net.Listener
+// interface.
+var _ net.Listener = (*Listener)(nil)
+
+// NewListener returns a new net.Listener which enforces the Brontide scheme
+// during both initial connection establishment and data transfer. If the
+// passed filter is non-nil, then any connection it rejects is closed without
+// carrying out the handshake.
+func NewListener(localStatic SingleKeyECDH, listenAddr string,
+	filter ConnFilter) (*Listener, error) {
+
+	addr, err := net.ResolveTCPAddr("tcp", listenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := net.ListenTCP("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	brontideListener := &Listener{
+		localStatic:   localStatic,
+		tcp:           l,
+		filter:        filter,
+		handshakeSema: make(chan struct{}, defaultHandshakes),
+		conns:         make(chan maybeConn),
+		quit:          make(chan struct{}),
+	}
+
+	go brontideListener.listen()
+
+	return brontideListener, nil
+}
+
+// listen accepts new TCP connections, handing each off to its own goroutine
+// to carry out the handshake. At most defaultHandshakes handshakes are in
+// progress at once. As with net/http, a temporary error accepting a
+// connection is retried after a backoff, while any other error stops the
+// listener.
+func (l *Listener) listen() {
+	var backoff time.Duration
+	for {
+		select {
+		case l.handshakeSema <- struct{}{}:
+		case <-l.quit:
+			return
+		}
+
+		conn, err := l.tcp.Accept()
+		if err != nil {
+			<-l.handshakeSema
+			l.rejectConn(err)
+
+			netErr, ok := err.(net.Error)
+			if !ok || !netErr.Temporary() {
+				return
+			}
+
+			if backoff == 0 {
+				backoff = minAcceptBackoff
+			} else {
+				backoff *= 2
+			}
+			if backoff > maxAcceptBackoff {
+				backoff = maxAcceptBackoff
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-l.quit:
+				return
+			}
+			continue
+		}
+		backoff = 0
+
+		if l.filter != nil {
+			if err := l.filter(conn.RemoteAddr()); err != nil {
+				conn.Close()
+				l.rejectConn(err)
+				<-l.handshakeSema
+				continue
+			}
+		}
+
+		go l.doHandshake(conn)
+	}
+}
+
+// rejectConn delivers the passed error to Accept, unless the listener is
+// closed.
+func (l *Listener) rejectConn(err error) {
+	select {
+	case l.conns <- maybeConn{err: err}:
+	case <-l.quit:
+	}
+}
+
+// acceptConn delivers the passed connection to Accept. If the listener is
+// closed in the meantime, then the connection is closed.
+func (l *Listener) acceptConn(conn net.Conn) {
+	select {
+	case l.conns <- maybeConn{conn: conn}:
+	case <-l.quit:
+		conn.Close()
+	}
+}
+
+// doHandshake carries out the three act Brontide handshake with the remote
+// party of the passed connection, delivering the authenticated connection to
+// Accept once the handshake succeeds.
+func (l *Listener) doHandshake(conn net.Conn) {
+	defer func() { <-l.handshakeSema }()
+
+	brontideConn := &Conn{
+		conn:  conn,
+		noise: NewBrontideMachine(false, l.localStatic, nil),
+	}
+
+	// We'll ensure that we get ActOne from the remote peer in a timely
+	// manner. If they don't respond within handshakeReadTimeout, then
+	// we'll kill the connection.
+	conn.SetReadDeadline(time.Now().Add(handshakeReadTimeout))
+
+	// Attempt to carry out the first act of the handshake protocol. If the
+	// connecting node doesn't know our long-term static public key, then
+	// this portion will fail with a non-nil error.
+	var actOne [ActOneSize]byte
+	if _, err := io.ReadFull(conn, actOne[:]); err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+	if err := brontideConn.noise.RecvActOne(actOne); err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+
+	// Next, progress the handshake processes by sending over our ephemeral
+	// key for the session along with an authenticating tag.
+	actTwo, err := brontideConn.noise.GenActTwo()
+	if err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+	if _, err := conn.Write(actTwo[:]); err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+
+	// Finally, finish the handshake processes by reading and decrypting
+	// the connection peer's static public key. If this succeeds then both
+	// sides have mutually authenticated each other.
+	var actThree [ActThreeSize]byte
+	if _, err := io.ReadFull(conn, actThree[:]); err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+	if err := brontideConn.noise.RecvActThree(actThree); err != nil {
+		brontideConn.conn.Close()
+		l.rejectConn(err)
+		return
+	}
+
+	// We'll reset the deadline as it's no longer critical beyond the
+	// initial handshake.
+	conn.SetReadDeadline(time.Time{})
+
+	l.acceptConn(brontideConn)
+}
+
+// Accept waits for and returns the next connection to the listener. All
+// incoming connections are authenticated via the three act Brontide
+// key-exchange scheme. This function will fail with a non-nil error in the
+// case that either the handshake breaks down, or the remote peer doesn't know
+// our static public key. Should the listener stop due to a permanent error
+// accepting connections, then that error is returned once, after which Accept
+// blocks until the listener is closed.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.conns:
+		return result.conn, result.err
+	case <-l.quit:
+		return nil, errors.New("brontide connection closed")
+	}
+}
+
+// Close closes the listener. Any blocked Accept operations will be unblocked
+// and return errors.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Close() error {
+	select {
+	case <-l.quit:
+	default:
+		close(l.quit)
+	}
+
+	return l.tcp.Close()
+}
+
+// Addr returns the listener's network address.
+//
+// Part of the net.Listener interface.
+func (l *Listener) Addr() net.Addr {
+	return l.tcp.Addr()
+}
@@ -0,0 +1,622 @@
+package brontide
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// establishTestConnection creates a listener bound to a random local port,
+// then dials out to it, returning both ends of the resulting brontide
+// connection.
+func establishTestConnection() (net.Conn, net.Conn, error) {
+	// First, generate the long-term private keys both ends of the
+	// connection within our test.
+	localPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+	remotePriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Having a port of ":0" means a random port, and interface will be
+	// chosen for our listener.
+	localKey := &PrivKeyECDH{PrivKey: localPriv}
+	listener, err := NewListener(localKey, "localhost:0", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer listener.Close()
+
+	// Accept the incoming connection within a goroutine, as Dial will
+	// block until the handshake has completed.
+	type acceptResult struct {
+		conn net.Conn
+		err  error
+	}
+	accepted := make(chan acceptResult, 1)
+	go func() {
+		conn, err := listener.Accept()
+		accepted <- acceptResult{conn, err}
+	}()
+
+	netAddr := listener.Addr().String()
+	remoteConn, err := Dial(&PrivKeyECDH{PrivKey: remotePriv},
+		localPriv.PubKey(), netAddr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := <-accepted
+	if result.err != nil {
+		remoteConn.Close()
+		return nil, nil, result.err
+	}
+
+	return result.conn, remoteConn, nil
+}
+
+func TestConnectionCorrectness(t *testing.T) {
+	localConn, remoteConn, err := establishTestConnection()
+	if err != nil {
+		t.Fatalf("unable to establish test connection: %v", err)
+	}
+	defer localConn.Close()
+	defer remoteConn.Close()
+
+	// Both sides should have learned the other's static public key.
+	local := localConn.(*Conn)
+	remote := remoteConn.(*Conn)
+	if !local.RemotePub().IsEqual(remote.LocalPub()) {
+		t.Fatalf("listener learned incorrect remote key")
+	}
+	if !remote.RemotePub().IsEqual(local.LocalPub()) {
+		t.Fatalf("dialer learned incorrect remote key")
+	}
+
+	// Test out some message full-message reads.
+	for i := 0; i < 10; i++ {
+		msg := []byte(fmt.Sprintf("hello%v", i))
+
+		if _, err := localConn.Write(msg); err != nil {
+			t.Fatalf("remote conn failed to write: %v", err)
+		}
+
+		readBuf := make([]byte, len(msg))
+		if _, err := io.ReadFull(remoteConn, readBuf); err != nil {
+			t.Fatalf("local conn failed to read: %v", err)
+		}
+
+		if !bytes.Equal(readBuf, msg) {
+			t.Fatalf("messages don't match, %v vs %v",
+				string(readBuf), string(msg))
+		}
+	}
+
+	// Now try incremental message reads. This simulates first writing a
+	// message header, then a message body.
+	outMsg := []byte("hello world")
+	if _, err := localConn.Write(outMsg); err != nil {
+		t.Fatalf("remote conn failed to write: %v", err)
+	}
+
+	readBuf := make([]byte, len(outMsg))
+	if _, err := remoteConn.Read(readBuf[:len(outMsg)/2]); err != nil {
+		t.Fatalf("local conn failed to read: %v", err)
+	}
+	if _, err := remoteConn.Read(readBuf[len(outMsg)/2:]); err != nil {
+		t.Fatalf("local conn failed to read: %v", err)
+	}
+
+	if !bytes.Equal(outMsg, readBuf) {
+		t.Fatalf("messages don't match, %v vs %v",
+			string(readBuf), string(outMsg))
+	}
+}
+
+func TestLargeMessageChunking(t *testing.T) {
+	localConn, remoteConn, err := establishTestConnection()
+	if err != nil {
+		t.Fatalf("unable to establish test connection: %v", err)
+	}
+	defer localConn.Close()
+	defer remoteConn.Close()
+
+	// A message larger than the maximum payload size should be
+	// transparently split into several encrypted records.
+	largeMessage := bytes.Repeat([]byte("kek"), math.MaxUint16)
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := localConn.Write(largeMessage)
+		errChan <- err
+	}()
+
+	readBuf := make([]byte, len(largeMessage))
+	if _, err := io.ReadFull(remoteConn, readBuf); err != nil {
+		t.Fatalf("unable to read large message: %v", err)
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("unable to write large message: %v", err)
+	}
+
+	if !bytes.Equal(largeMessage, readBuf) {
+		t.Fatalf("large message mismatch")
+	}
+}
+
+func TestHandshakeUnknownResponderKey(t *testing.T) {
+	localPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	wrongPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	// An initiator which doesn't know the responder's static key should
+	// fail to complete the very first act.
+	initiator := NewBrontideMachine(true, &PrivKeyECDH{PrivKey: localPriv},
+		wrongPriv.PubKey())
+	responder := NewBrontideMachine(false,
+		&PrivKeyECDH{PrivKey: localPriv}, nil)
+
+	actOne, err := initiator.GenActOne()
+	if err != nil {
+		t.Fatalf("unable to generate act one: %v", err)
+	}
+	if err := responder.RecvActOne(actOne); err == nil {
+		t.Fatalf("responder accepted act one for unknown static key")
+	}
+}
+
+// TestSilentConnectionDoesNotBlock tests that a connection which never
+// starts the handshake doesn't prevent the listener from accepting
+// connections made after it.
+func TestSilentConnectionDoesNotBlock(t *testing.T) {
+	localPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	remotePriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	listener, err := NewListener(&PrivKeyECDH{PrivKey: localPriv},
+		"localhost:0", nil)
+	if err != nil {
+		t.Fatalf("unable to create listener: %v", err)
+	}
+	defer listener.Close()
+	netAddr := listener.Addr().String()
+
+	// Open a raw TCP connection which never sends act one.
+	silentConn, err := net.Dial("tcp", netAddr)
+	if err != nil {
+		t.Fatalf("unable to dial listener: %v", err)
+	}
+	defer silentConn.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		accepted <- conn
+	}()
+
+	remoteConn, err := Dial(&PrivKeyECDH{PrivKey: remotePriv},
+		localPriv.PubKey(), netAddr)
+	if err != nil {
+		t.Fatalf("unable to dial listener: %v", err)
+	}
+	defer remoteConn.Close()
+
+	// The second connection should be accepted well before the silent
+	// connection's handshake times out.
+	select {
+	case conn := <-accepted:
+		conn.Close()
+	case <-time.After(handshakeReadTimeout / 2):
+		t.Fatalf("connection blocked behind silent connection")
+	}
+}
+
+// TestListenerConnFilter tests that a connection rejected by the listener's
+// filter is closed before the handshake, with the filter's error delivered to
+// Accept.
+func TestListenerConnFilter(t *testing.T) {
+	localPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	remotePriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	errRejected := errors.New("connection rejected")
+	filter := func(remoteAddr net.Addr) error {
+		return errRejected
+	}
+	listener, err := NewListener(&PrivKeyECDH{PrivKey: localPriv},
+		"localhost:0", filter)
+	if err != nil {
+		t.Fatalf("unable to create listener: %v", err)
+	}
+	defer listener.Close()
+
+	acceptErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			conn.Close()
+		}
+		acceptErr <- err
+	}()
+
+	// The handshake should fail, as the listener closes the connection
+	// without responding to act one.
+	remoteConn, err := Dial(&PrivKeyECDH{PrivKey: remotePriv},
+		localPriv.PubKey(), listener.Addr().String())
+	if err == nil {
+		remoteConn.Close()
+		t.Fatalf("handshake completed with rejected connection")
+	}
+
+	select {
+	case err := <-acceptErr:
+		if err != errRejected {
+			t.Fatalf("expected filter error, got %v", err)
+		}
+	case <-time.After(handshakeReadTimeout / 2):
+		t.Fatalf("rejected connection not reported")
+	}
+}
+
+// temporaryError is a net.Error which is temporary, as returned by Accept
+// when the process runs out of file descriptors.
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "too many open files" }
+func (temporaryError) Timeout() bool   { return false }
+func (temporaryError) Temporary() bool { return true }
+
+// mockTCPListener is a net.Listener whose Accept returns each of its errors
+// in turn, recording the time of each call.
+type mockTCPListener struct {
+	net.Listener
+
+	errs  []error
+	calls chan time.Time
+}
+
+func (m *mockTCPListener) Accept() (net.Conn, error) {
+	m.calls <- time.Now()
+
+	err := m.errs[0]
+	m.errs = m.errs[1:]
+	return nil, err
+}
+
+func (m *mockTCPListener) Close() error {
+	return nil
+}
+
+// TestListenerAcceptBackoff tests that the listener backs off after each
+// temporary error accepting a connection, doubling the delay each time, and
+// stops after a permanent error.
+func TestListenerAcceptBackoff(t *testing.T) {
+	errPermanent := errors.New("listener closed")
+	errs := []error{
+		temporaryError{}, temporaryError{}, temporaryError{},
+		errPermanent,
+	}
+	tcp := &mockTCPListener{
+		errs:  errs,
+		calls: make(chan time.Time, len(errs)+1),
+	}
+	listener := &Listener{
+		tcp:           tcp,
+		handshakeSema: make(chan struct{}, defaultHandshakes),
+		conns:         make(chan maybeConn),
+		quit:          make(chan struct{}),
+	}
+	defer listener.Close()
+	go listener.listen()
+
+	// Each of the errors should be delivered to Accept in turn.
+	for i, expectedErr := range errs {
+		_, err := listener.Accept()
+		if err != expectedErr {
+			t.Fatalf("#%d: expected error %v, got %v", i,
+				expectedErr, err)
+		}
+	}
+
+	// The delay before each retry should double, starting from
+	// minAcceptBackoff.
+	calls := make([]time.Time, len(errs))
+	for i := range calls {
+		calls[i] = <-tcp.calls
+	}
+	backoff := minAcceptBackoff
+	for i := 1; i < len(calls); i++ {
+		if delay := calls[i].Sub(calls[i-1]); delay < backoff {
+			t.Fatalf("#%d: expected backoff of at least %v, "+
+				"got %v", i, backoff, delay)
+		}
+		backoff *= 2
+	}
+
+	// Following the permanent error, no further connections should be
+	// accepted.
+	select {
+	case <-tcp.calls:
+		t.Fatalf("connection accepted after permanent error")
+	case <-time.After(backoff * 2):
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	initiatorPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	responderPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	initiator := NewBrontideMachine(true,
+		&PrivKeyECDH{PrivKey: initiatorPriv}, responderPriv.PubKey())
+	responder := NewBrontideMachine(false,
+		&PrivKeyECDH{PrivKey: responderPriv}, nil)
+
+	// Carry out the full three act handshake in memory.
+	actOne, err := initiator.GenActOne()
+	if err != nil {
+		t.Fatalf("unable to generate act one: %v", err)
+	}
+	if err := responder.RecvActOne(actOne); err != nil {
+		t.Fatalf("unable to process act one: %v", err)
+	}
+	actTwo, err := responder.GenActTwo()
+	if err != nil {
+		t.Fatalf("unable to generate act two: %v", err)
+	}
+	if err := initiator.RecvActTwo(actTwo); err != nil {
+		t.Fatalf("unable to process act two: %v", err)
+	}
+	actThree, err := initiator.GenActThree()
+	if err != nil {
+		t.Fatalf("unable to generate act three: %v", err)
+	}
+	if err := responder.RecvActThree(actThree); err != nil {
+		t.Fatalf("unable to process act three: %v", err)
+	}
+
+	// Send enough messages to force several key rotations, ensuring that
+	// both sides remain in sync across each rotation. Each message
+	// consumes two nonces: one for the length header, and one for the
+	// body.
+	initialKey := initiator.sendCipher.secretKey
+	var b bytes.Buffer
+	for i := 0; i < keyRotationInterval*2; i++ {
+		msg := []byte("ping")
+		if err := initiator.WriteMessage(&b, msg); err != nil {
+			t.Fatalf("unable to write message #%v: %v", i, err)
+		}
+
+		readMsg, err := responder.ReadMessage(&b)
+		if err != nil {
+			t.Fatalf("unable to read message #%v: %v", i, err)
+		}
+		if !bytes.Equal(msg, readMsg) {
+			t.Fatalf("message #%v mismatch", i)
+		}
+	}
+
+	if initiator.sendCipher.secretKey == initialKey {
+		t.Fatalf("send key was never rotated")
+	}
+	if initiator.sendCipher.secretKey != responder.recvCipher.secretKey {
+		t.Fatalf("keys out of sync after rotation")
+	}
+}
+
+// TestBolt0008TestVectors ensures that our implementation of brontide exactly
+// matches the handshake and message encryption test vectors of BOLT #8,
+// including those spanning the rotation of the keys.
+func TestBolt0008TestVectors(t *testing.T) {
+	// First, we'll generate the state of the initiator from the test
+	// vectors at the appendix of BOLT-0008.
+	initiatorKeyBytes, err := hex.DecodeString("1111111111111111111111" +
+		"111111111111111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("unable to decode hex: %v", err)
+	}
+	initiatorPriv, _ := btcec.PrivKeyFromBytes(btcec.S256(),
+		initiatorKeyBytes)
+
+	// We'll then do the same for the responder.
+	responderKeyBytes, err := hex.DecodeString("212121212121212121212121" +
+		"2121212121212121212121212121212121212121")
+	if err != nil {
+		t.Fatalf("unable to decode hex: %v", err)
+	}
+	responderPriv, responderPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		responderKeyBytes)
+
+	// The responder's static public key must be that of the test vectors.
+	expectedResponderPub := "028d7500dd4c12685d1f568b4c2b5048e8534b8733" +
+		"19f3a8daa612b469132ec7f7"
+	if hex.EncodeToString(responderPub.SerializeCompressed()) !=
+		expectedResponderPub {
+
+		t.Fatalf("responder public key mismatch: expected %v, got %x",
+			expectedResponderPub, responderPub.SerializeCompressed())
+	}
+
+	// With the initiator's key data parsed, we'll now define a custom
+	// ephemeral key generator, so the ephemeral keys match those of the
+	// test vectors.
+	ephemeralKey := func(keyHex string) func() (*btcec.PrivateKey, error) {
+		return func() (*btcec.PrivateKey, error) {
+			eBytes, err := hex.DecodeString(keyHex)
+			if err != nil {
+				return nil, err
+			}
+
+			priv, _ := btcec.PrivKeyFromBytes(btcec.S256(), eBytes)
+			return priv, nil
+		}
+	}
+
+	initiator := NewBrontideMachine(true,
+		&PrivKeyECDH{PrivKey: initiatorPriv}, responderPub)
+	initiator.ephemeralGen = ephemeralKey("12121212121212121212121212" +
+		"12121212121212121212121212121212121212")
+	responder := NewBrontideMachine(false,
+		&PrivKeyECDH{PrivKey: responderPriv}, nil)
+	responder.ephemeralGen = ephemeralKey("22222222222222222222222222" +
+		"22222222222222222222222222222222222222")
+
+	// We'll start with the initiator generating the initial payload for
+	// act one. This should consist of exactly 50 bytes. We'll assert that
+	// the payload returned is _exactly_ the same as what's specified
+	// within the test vectors.
+	actOne, err := initiator.GenActOne()
+	if err != nil {
+		t.Fatalf("unable to generate act one: %v", err)
+	}
+	expectedActOne := "00036360e856310ce5d294e8be33fc807077dc56ac80d95d9" +
+		"cd4ddbd21325eff73f70df6086551151f58b8afe6c195782c6a"
+	if hex.EncodeToString(actOne[:]) != expectedActOne {
+		t.Fatalf("act one mismatch: expected %v, got %x",
+			expectedActOne, actOne)
+	}
+
+	// With the assertion above passed, the responder should be able to
+	// process the act one payload, then reply with act two.
+	if err := responder.RecvActOne(actOne); err != nil {
+		t.Fatalf("responder unable to process act one: %v", err)
+	}
+	actTwo, err := responder.GenActTwo()
+	if err != nil {
+		t.Fatalf("unable to generate act two: %v", err)
+	}
+	expectedActTwo := "0002466d7fcae563e5cb09a0d1870bb580344804617879a14" +
+		"949cf22285f1bae3f276e2470b93aac583c9ef6eafca3f730ae"
+	if hex.EncodeToString(actTwo[:]) != expectedActTwo {
+		t.Fatalf("act two mismatch: expected %v, got %x",
+			expectedActTwo, actTwo)
+	}
+
+	// Moving the handshake along, the initiator processes act two and
+	// generates the final act.
+	if err := initiator.RecvActTwo(actTwo); err != nil {
+		t.Fatalf("initiator unable to process act two: %v", err)
+	}
+	actThree, err := initiator.GenActThree()
+	if err != nil {
+		t.Fatalf("unable to generate act three: %v", err)
+	}
+	expectedActThree := "00b9e3a702e93e3a9948c2ed6e5fd7590a6e1c3a0344cf" +
+		"c9d5b57357049aa22355361aa02e55a8fc28fef5bd6d71ad0c3822" +
+		"8dc68b1c466263b47fdf31e560e139ba"
+	if hex.EncodeToString(actThree[:]) != expectedActThree {
+		t.Fatalf("act three mismatch: expected %v, got %x",
+			expectedActThree, actThree)
+	}
+
+	// Finally, we'll ensure that the responder itself is able to properly
+	// process the final act.
+	if err := responder.RecvActThree(actThree); err != nil {
+		t.Fatalf("responder unable to process act three: %v", err)
+	}
+
+	// With the handshake complete, both sides should have derived the
+	// session keys of the test vectors.
+	sendingKey := "969ab31b4d288cedf6218839b27a3e2140827047f2c0f01bf5c04" +
+		"435d43511a9"
+	receivingKey := "bb9020b8965f4df047e07f955f3c4b88418984aadc5cdb3509" +
+		"6b9ea8fa5c3442"
+	chainingKey := "919219dbb2920afa8db80f9a51787a840bcf111ed8d588caf9ab" +
+		"4be716e42b01"
+	if hex.EncodeToString(initiator.sendCipher.secretKey[:]) != sendingKey {
+		t.Fatalf("sending key mismatch: expected %v, got %x",
+			sendingKey, initiator.sendCipher.secretKey[:])
+	}
+	if hex.EncodeToString(initiator.recvCipher.secretKey[:]) !=
+		receivingKey {
+
+		t.Fatalf("receiving key mismatch: expected %v, got %x",
+			receivingKey, initiator.recvCipher.secretKey[:])
+	}
+	if hex.EncodeToString(initiator.sendCipher.salt[:]) != chainingKey {
+		t.Fatalf("chaining key mismatch: expected %v, got %x",
+			chainingKey, initiator.sendCipher.salt[:])
+	}
+	if initiator.sendCipher.secretKey != responder.recvCipher.secretKey ||
+		initiator.recvCipher.secretKey != responder.sendCipher.secretKey {
+
+		t.Fatalf("responder derived different session keys")
+	}
+
+	// Now test as per section "transport-message test" in BOLT #8. The
+	// initiator repeatedly sends the same message, with the keys rotated
+	// after every 500 messages, as each message consumes two nonces.
+	var b bytes.Buffer
+	payload := []byte("hello")
+	expectedOutputs := map[int]string{
+		0: "cf2b30ddf0cf3f80e7c35a6e6730b59fe802473180f396d88a8fb0d" +
+			"b8cbcf25d2f214cf9ea1d95",
+		1: "72887022101f0b6753e0c7de21657d35a4cb2a1f5cde2650528bbc8" +
+			"f837d0f0d7ad833b1a256a1",
+		500: "178cb9d7387190fa34db9c2d50027d21793c9bc2d40b1e14dcf30e" +
+			"beeeb220f48364f7a4c68bf8",
+		501: "1b186c57d44eb6de4c057c49940d79bb838a145cb528d6e8fd26db" +
+			"e50a60ca2c104b56b60e45bd",
+		1000: "4a2f3cc3b5e78ddb83dcb426d9863d9d9a723b0337c89dd0b005d" +
+			"89f8d3c05c52b76b29b740f09",
+		1001: "2ecd8c8a5629d0d02ab457a0fdd0f7b90a192cd46be5ecb6ca570" +
+			"bfc5e268338b1a16cf4ef2d36",
+	}
+	for i := 0; i < 1002; i++ {
+		if err := initiator.WriteMessage(&b, payload); err != nil {
+			t.Fatalf("unable to write message #%v: %v", i, err)
+		}
+
+		if expected, ok := expectedOutputs[i]; ok {
+			if hex.EncodeToString(b.Bytes()) != expected {
+				t.Fatalf("message #%v mismatch: expected %v, "+
+					"got %x", i, expected, b.Bytes())
+			}
+		}
+
+		// The responder must be able to decrypt each message, keeping
+		// its keys in step with those of the initiator.
+		msg, err := responder.ReadMessage(&b)
+		if err != nil {
+			t.Fatalf("unable to read message #%v: %v", i, err)
+		}
+		if !bytes.Equal(msg, payload) {
+			t.Fatalf("message #%v mismatch: expected %s, got %s",
+				i, payload, msg)
+		}
+	}
+}
@@ -0,0 +1,625 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// chainArbitrator is the sub-system which arbitrates the on-chain resolution
+// of the contracts within each of our channels. A channelArbitrator watches
+// over each active channel, deciding when the channel must be force closed in
+// order to claim its contracts on-chain. Once a channel has been force closed,
+// the chainArbitrator persists the set of outputs which must be resolved
+// within an arbitration log, hands the outputs over to the utxoNursery to be
+// swept once mature, then tracks each of the outputs until it's been spent.
+// Resolution resumes from the arbitration log if the daemon restarts.
+type chainArbitrator struct {
+	notifier chainntnfs.ChainNotifier
+	wallet   *lnwallet.LightningWallet
+	chanDB   *channeldb.DB
+	nursery  *utxoNursery
+
+	// htlcSwitch is used to force close a channel once its arbitrator
+	// decides to go on-chain.
+	htlcSwitch *htlcSwitch
+
+	// beacon is used to determine whether we're able to claim an
+	// incoming HTLC, and is informed of each preimage revealed on-chain.
+	beacon *preimageBeacon
+
+	// paymentCtrl is informed of each of our outgoing payments settled
+	// on-chain.
+	paymentCtrl *paymentController
+
+	// incomingBroadcastDelta is the number of blocks before the expiry of
+	// an incoming HTLC whose preimage we know, at which we'll go on-chain
+	// to claim the HTLC should it still be outstanding within the
+	// channel. Past its expiry, the remote party would be able to reclaim
+	// the HTLC.
+	incomingBroadcastDelta uint32
+
+	// outgoingBroadcastDelta is the number of blocks before the expiry of
+	// an outgoing HTLC, at which we'll go on-chain to time out the HTLC
+	// should the remote party have neither settled nor failed it. Waiting
+	// any longer would eat into the window in which the corresponding
+	// upstream HTLC must be claimed.
+	outgoingBroadcastDelta uint32
+
+	// activeChannels maps the channel point of each active channel to
+	// its arbitrator.
+	activeMtx      sync.Mutex
+	activeChannels map[wire.OutPoint]*channelArbitrator
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newChainArbitrator creates a new instance of the chainArbitrator.
+func newChainArbitrator(notifier chainntnfs.ChainNotifier,
+	wallet *lnwallet.LightningWallet, chanDB *channeldb.DB,
+	nursery *utxoNursery, htlcSwitch *htlcSwitch, beacon *preimageBeacon,
+	paymentCtrl *paymentController, incomingBroadcastDelta,
+	outgoingBroadcastDelta uint32) *chainArbitrator {
+
+	return &chainArbitrator{
+		notifier:               notifier,
+		wallet:                 wallet,
+		chanDB:                 chanDB,
+		nursery:                nursery,
+		htlcSwitch:             htlcSwitch,
+		beacon:                 beacon,
+		paymentCtrl:            paymentCtrl,
+		incomingBroadcastDelta: incomingBroadcastDelta,
+		outgoingBroadcastDelta: outgoingBroadcastDelta,
+		activeChannels:         make(map[wire.OutPoint]*channelArbitrator),
+		quit:                   make(chan struct{}),
+	}
+}
+
+// Start resumes the resolution of each channel recorded within the
+// arbitration logs, then begins watching over the active channels.
+func (c *chainArbitrator) Start() error {
+	arbLogs, err := c.chanDB.FetchArbitrationLogs()
+	if err != nil {
+		return err
+	}
+	for _, arbLog := range arbLogs {
+		cnctLog.Infof("Resuming resolution of ChannelPoint(%v) in "+
+			"state %v", arbLog.ChanPoint, arbLog.State)
+
+		c.wg.Add(1)
+		go c.resolveContracts(arbLog)
+	}
+
+	newBlocks, err := c.notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.chainWatcher(newBlocks)
+
+	return nil
+}
+
+// Stop signals all goroutines of the chainArbitrator to exit, then waits for
+// them to do so.
+func (c *chainArbitrator) Stop() error {
+	close(c.quit)
+	c.wg.Wait()
+	return nil
+}
+
+// stateSnapshotter is the subset of the methods of a LightningChannel used by
+// its channelArbitrator, which only inspects the current state of the channel.
+type stateSnapshotter interface {
+	// StateSnapshot returns a snapshot of the current state of the
+	// channel.
+	StateSnapshot() *channeldb.ChannelSnapshot
+}
+
+// channelArbitrator watches over a single active channel, deciding when the
+// contracts within the channel must be resolved on-chain.
+type channelArbitrator struct {
+	channel   stateSnapshotter
+	chanPoint wire.OutPoint
+
+	// goingOnChain is set once a force close of the channel has been
+	// requested, ensuring that only a single request is made.
+	goingOnChain bool
+}
+
+// WatchChannel begins watching over the passed active channel.
+func (c *chainArbitrator) WatchChannel(channel *lnwallet.LightningChannel) {
+	chanPoint := *channel.ChannelPoint()
+
+	c.activeMtx.Lock()
+	c.activeChannels[chanPoint] = &channelArbitrator{
+		channel:   channel,
+		chanPoint: chanPoint,
+	}
+	c.activeMtx.Unlock()
+}
+
+// UnwatchChannel stops watching over the target channel, as it's either no
+// longer active, or has been closed.
+func (c *chainArbitrator) UnwatchChannel(chanPoint *wire.OutPoint) {
+	c.activeMtx.Lock()
+	delete(c.activeChannels, *chanPoint)
+	c.activeMtx.Unlock()
+}
+
+// shouldGoOnChain returns the HTLC which requires the channel to be force
+// closed at the passed height in order for it to be claimed on-chain, or nil
+// if the channel may remain open. The channel must go on-chain if it holds an
+// incoming HTLC whose preimage we know, and which is within incomingDelta
+// blocks of its expiry, or an outgoing HTLC which the remote party has
+// neither settled nor failed, and which is within outgoingDelta blocks of its
+// expiry.
+func (a *channelArbitrator) shouldGoOnChain(height uint32,
+	lookupPreimage preimageLookup, incomingDelta,
+	outgoingDelta uint32) *channeldb.HTLC {
+
+	snapshot := a.channel.StateSnapshot()
+	for i := range snapshot.Htlcs {
+		htlc := &snapshot.Htlcs[i]
+		_, preimageKnown := lookupPreimage(htlc.RHash)
+
+		if htlc.Incoming {
+			if htlc.RefundTimeout > height+incomingDelta {
+				continue
+			}
+			if preimageKnown {
+				return htlc
+			}
+			continue
+		}
+
+		if htlc.RefundTimeout > height+outgoingDelta {
+			continue
+		}
+
+		// If we know the preimage of an outgoing HTLC, then the
+		// remote party has already settled it.
+		if !preimageKnown {
+			return htlc
+		}
+	}
+
+	return nil
+}
+
+// chainWatcher checks each active channel with every new block, force closing
+// any channel whose contracts must be resolved on-chain.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *chainArbitrator) chainWatcher(newBlocks *chainntnfs.BlockEpochEvent) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case epoch, ok := <-newBlocks.Epochs:
+			if !ok {
+				return
+			}
+			height := uint32(epoch.Height)
+
+			var toClose []wire.OutPoint
+			c.activeMtx.Lock()
+			for _, arb := range c.activeChannels {
+				if arb.goingOnChain {
+					continue
+				}
+
+				htlc := arb.shouldGoOnChain(height,
+					c.beacon.LookupPreimage,
+					c.incomingBroadcastDelta,
+					c.outgoingBroadcastDelta)
+				if htlc == nil {
+					continue
+				}
+
+				action := "claim incoming"
+				if !htlc.Incoming {
+					action = "time out outgoing"
+				}
+				cnctLog.Warnf("Going on-chain to %v HTLC %x of "+
+					"ChannelPoint(%v) expiring at height %v, "+
+					"current height %v", action, htlc.RHash,
+					arb.chanPoint, htlc.RefundTimeout, height)
+
+				arb.goingOnChain = true
+				toClose = append(toClose, arb.chanPoint)
+			}
+			c.activeMtx.Unlock()
+
+			for _, chanPoint := range toClose {
+				c.goOnChain(chanPoint)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// goOnChain requests that the target channel be force closed, logging the
+// outcome of the request.
+func (c *chainArbitrator) goOnChain(chanPoint wire.OutPoint) {
+	updates, errChan := c.htlcSwitch.CloseLink(&chanPoint, true, nil)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		select {
+		case update := <-updates:
+			pending, ok := update.Update.(*lnrpc.CloseStatusUpdate_ClosePending)
+			if !ok {
+				return
+			}
+			txid, _ := wire.NewShaHash(pending.ClosePending.Txid)
+			cnctLog.Infof("Force closed ChannelPoint(%v) with txid %v",
+				chanPoint, txid)
+
+		case err := <-errChan:
+			cnctLog.Errorf("Unable to force close ChannelPoint(%v): %v",
+				chanPoint, err)
+
+		case <-c.quit:
+		}
+	}()
+}
+
+// ForceClose executes a unilateral close of the target channel by
+// broadcasting the current commitment state directly on-chain. Before the
+// commitment transaction is broadcast, the outputs which must be resolved are
+// recorded within the channel's arbitration log, after which they're sent to
+// the utxoNursery in order to ultimately be swept. Should the broadcast fail,
+// then the arbitration log is removed once again. The relative delay before
+// our output can be swept is returned along with the txid of the commitment
+// transaction.
+func (c *chainArbitrator) ForceClose(channel *lnwallet.LightningChannel) (*wire.ShaHash, uint32, error) {
+	snapshot := channel.StateSnapshot()
+
+	// Execute a unilateral close shutting down all further channel
+	// operation.
+	closeSummary, err := channel.ForceClose()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	closeTx := closeSummary.CloseTx
+	txid := closeTx.TxSha()
+
+	arbLog := &channeldb.ArbitrationLog{
+		ChanPoint:     *channel.ChannelPoint(),
+		RemoteID:      snapshot.RemoteID,
+		Capacity:      snapshot.Capacity,
+		LocalBalance:  snapshot.LocalBalance,
+		RemoteBalance: snapshot.RemoteBalance,
+		CloseTxid:     txid,
+		State:         channeldb.StateCommitBroadcast,
+		Resolutions:   closeResolutions(closeSummary),
+	}
+	if err := c.chanDB.PutArbitrationLog(arbLog); err != nil {
+		return nil, 0, err
+	}
+
+	// With the close transaction in hand, broadcast the transaction to the
+	// network, thereby entering the psot channel resolution state.
+	cnctLog.Infof("Broadcasting force close transaction of "+
+		"ChannelPoint(%v): %v", arbLog.ChanPoint,
+		newLogClosure(func() string {
+			return spew.Sdump(closeTx)
+		}))
+	if err := c.wallet.PublishTransaction(closeTx); err != nil {
+		// The commitment transaction never made it to the network,
+		// so there's nothing to resolve on-chain.
+		if err := c.chanDB.DeleteArbitrationLog(&arbLog.ChanPoint); err != nil {
+			cnctLog.Errorf("Unable to delete arbitration log of "+
+				"ChannelPoint(%v): %v", arbLog.ChanPoint, err)
+		}
+		return nil, 0, err
+	}
+	label := lnwallet.ForceCloseTxLabel(&arbLog.ChanPoint)
+	if err := c.wallet.LabelTransaction(txid, label, false); err != nil {
+		cnctLog.Warnf("unable to label force close tx %v: %v", txid, err)
+	}
+
+	c.wg.Add(1)
+	go c.resolveContracts(arbLog)
+
+	return &txid, closeSummary.SelfOutputMaturity, nil
+}
+
+// closeResolutions returns the set of outputs within the closing transaction
+// described by the passed summary which must be resolved on-chain.
+func closeResolutions(summary *lnwallet.ForceCloseSummary) []*channeldb.ContractResolution {
+	var resolutions []*channeldb.ContractResolution
+
+	if desc := summary.SelfOutputSignDesc; desc != nil {
+		resolutions = append(resolutions, &channeldb.ContractResolution{
+			Kind:          channeldb.ContractCommitOutput,
+			OutPoint:      summary.SelfOutpoint,
+			Amount:        btcutil.Amount(desc.Output.Value),
+			PubKey:        desc.PubKey,
+			WitnessScript: desc.RedeemScript,
+			PkScript:      desc.Output.PkScript,
+			CsvDelay:      summary.SelfOutputMaturity,
+			LeaseExpiry:   summary.SelfOutputLeaseExpiry,
+		})
+	}
+
+	for _, htlc := range summary.HtlcResolutions {
+		kind := channeldb.ContractOutgoingHtlc
+		if htlc.Incoming {
+			kind = channeldb.ContractIncomingHtlc
+		}
+
+		desc := htlc.SignDesc
+		resolutions = append(resolutions, &channeldb.ContractResolution{
+			Kind:          kind,
+			OutPoint:      htlc.Outpoint,
+			Amount:        btcutil.Amount(desc.Output.Value),
+			PubKey:        desc.PubKey,
+			WitnessScript: desc.RedeemScript,
+			PkScript:      desc.Output.PkScript,
+			CsvDelay:      htlc.CsvDelay,
+			Expiry:        htlc.Expiry,
+			PaymentHash:   htlc.RHash,
+		})
+	}
+
+	return resolutions
+}
+
+// resolveContracts drives the resolution of the channel described by the
+// passed arbitration log. The unresolved outputs are handed to the
+// utxoNursery, then each of them is watched until it's spent. Incoming HTLCs
+// are only handed over once their preimage is known to the beacon, while the
+// preimage of each outgoing HTLC claimed by the remote party is recorded with
+// the beacon. Once the commitment transaction has confirmed, and each output
+// has been spent, the arbitration log is removed.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *chainArbitrator) resolveContracts(arbLog *channeldb.ArbitrationLog) {
+	defer c.wg.Done()
+
+	// done signals the goroutines watching over the individual outputs
+	// that the resolution has finished.
+	done := make(chan struct{})
+	defer close(done)
+
+	var (
+		ready            []*channeldb.ContractResolution
+		awaitingPreimage []int
+	)
+	for i, resolution := range arbLog.Resolutions {
+		if resolution.Resolved {
+			continue
+		}
+
+		if resolution.Kind == channeldb.ContractIncomingHtlc {
+			_, ok := c.beacon.LookupPreimage(resolution.PaymentHash)
+			if !ok {
+				awaitingPreimage = append(awaitingPreimage, i)
+				continue
+			}
+		}
+
+		ready = append(ready, resolution)
+	}
+	c.nursery.incubateOutputs(ready, arbLog.CloseHeight)
+
+	if arbLog.State == channeldb.StateCommitBroadcast {
+		confNtfn, err := c.notifier.RegisterConfirmationsNtfn(
+			&arbLog.CloseTxid, 1)
+		if err != nil {
+			cnctLog.Errorf("Unable to register for confirmation of "+
+				"close tx %v: %v", arbLog.CloseTxid, err)
+			return
+		}
+
+		select {
+		case height, ok := <-confNtfn.Confirmed:
+			if !ok {
+				return
+			}
+
+			arbLog.State = channeldb.StateCommitConfirmed
+			arbLog.CloseHeight = uint32(height)
+			if err := c.chanDB.PutArbitrationLog(arbLog); err != nil {
+				cnctLog.Errorf("Unable to update arbitration "+
+					"log: %v", err)
+				return
+			}
+
+			cnctLog.Infof("Close tx %v of ChannelPoint(%v) "+
+				"confirmed at height %v", arbLog.CloseTxid,
+				arbLog.ChanPoint, height)
+
+		case <-c.quit:
+			return
+		}
+	}
+
+	// Wait for the preimage of each incoming HTLC we're yet unable to
+	// claim, it may still be learned off-chain.
+	learned := make(chan int)
+	for _, i := range awaitingPreimage {
+		sub := c.beacon.SubscribePreimage(arbLog.Resolutions[i].PaymentHash)
+
+		c.wg.Add(1)
+		go func(i int) {
+			defer c.wg.Done()
+			defer sub.cancel()
+
+			select {
+			case <-sub.preimage:
+			case <-done:
+				return
+			case <-c.quit:
+				return
+			}
+
+			select {
+			case learned <- i:
+			case <-done:
+			case <-c.quit:
+			}
+		}(i)
+	}
+
+	// Watch each of the unresolved outputs, it's resolved once spent
+	// either by our sweep transaction, or by the remote party.
+	spent := make(chan *chainntnfs.SpendDetail)
+	numUnresolved := 0
+	for _, resolution := range arbLog.Resolutions {
+		if resolution.Resolved {
+			continue
+		}
+		numUnresolved++
+
+		spendNtfn, err := c.notifier.RegisterSpendNtfn(&resolution.OutPoint)
+		if err != nil {
+			cnctLog.Errorf("Unable to register for spend of %v: %v",
+				resolution.OutPoint, err)
+			return
+		}
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+
+			var spend *chainntnfs.SpendDetail
+			select {
+			case detail, ok := <-spendNtfn.Spend:
+				if !ok {
+					return
+				}
+				spend = detail
+			case <-done:
+				return
+			case <-c.quit:
+				return
+			}
+
+			select {
+			case spent <- spend:
+			case <-done:
+			case <-c.quit:
+			}
+		}()
+	}
+
+	for numUnresolved > 0 {
+		select {
+		case i := <-learned:
+			resolution := arbLog.Resolutions[i]
+			if resolution.Resolved {
+				continue
+			}
+
+			cnctLog.Infof("Learned preimage of incoming HTLC %v of "+
+				"ChannelPoint(%v)", resolution.OutPoint,
+				arbLog.ChanPoint)
+
+			c.nursery.incubateOutputs(
+				[]*channeldb.ContractResolution{resolution},
+				arbLog.CloseHeight)
+
+		case spend := <-spent:
+			resolution := findResolution(arbLog, spend.SpentOutPoint)
+			if resolution == nil || resolution.Resolved {
+				continue
+			}
+
+			// If the remote party claimed one of our outgoing
+			// HTLCs, then the witness reveals its preimage.
+			if resolution.Kind == channeldb.ContractOutgoingHtlc {
+				txIn := spend.SpendingTx.TxIn[spend.SpenderInputIndex]
+				c.learnPreimage(resolution, txIn.Witness)
+			}
+
+			resolution.Resolved = true
+			if err := c.chanDB.PutArbitrationLog(arbLog); err != nil {
+				cnctLog.Errorf("Unable to update arbitration "+
+					"log: %v", err)
+				return
+			}
+			numUnresolved--
+
+			cnctLog.Infof("%v output %v of ChannelPoint(%v) "+
+				"resolved, %v remaining", resolution.Kind,
+				resolution.OutPoint, arbLog.ChanPoint,
+				numUnresolved)
+
+		case <-c.quit:
+			return
+		}
+	}
+
+	cnctLog.Infof("ChannelPoint(%v) is now fully resolved",
+		arbLog.ChanPoint)
+
+	if err := c.chanDB.DeleteArbitrationLog(&arbLog.ChanPoint); err != nil {
+		cnctLog.Errorf("Unable to delete arbitration log: %v", err)
+	}
+}
+
+// findResolution returns the resolution of the passed output within the
+// arbitration log, or nil if the output isn't part of the log.
+func findResolution(arbLog *channeldb.ArbitrationLog,
+	outPoint *wire.OutPoint) *channeldb.ContractResolution {
+
+	for _, resolution := range arbLog.Resolutions {
+		if resolution.OutPoint == *outPoint {
+			return resolution
+		}
+	}
+
+	return nil
+}
+
+// learnPreimage searches the witness which spent the passed outgoing HTLC for
+// the preimage of the HTLC. If found, the preimage is recorded with the
+// beacon, and the payment it belongs to is settled.
+func (c *chainArbitrator) learnPreimage(resolution *channeldb.ContractResolution,
+	witness wire.TxWitness) {
+
+	for _, item := range witness {
+		if len(item) != 32 {
+			continue
+		}
+		if fastsha256.Sum256(item) != resolution.PaymentHash {
+			continue
+		}
+
+		var preimage [32]byte
+		copy(preimage[:], item)
+
+		cnctLog.Infof("Learned preimage of outgoing HTLC %x on-chain",
+			resolution.PaymentHash)
+
+		if err := c.beacon.AddPreimage(preimage); err != nil {
+			cnctLog.Errorf("Unable to add preimage: %v", err)
+		}
+		c.paymentCtrl.settlePayment(preimage)
+		return
+	}
+}
+
+// PendingResolutions returns the arbitration log of each channel whose
+// contracts are still being resolved on-chain.
+func (c *chainArbitrator) PendingResolutions() ([]*channeldb.ArbitrationLog, error) {
+	return c.chanDB.FetchArbitrationLogs()
+}
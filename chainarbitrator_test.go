@@ -0,0 +1,472 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockNotifier is a mock implementation of the ChainNotifier interface whose
+// confirmation, spend and block epoch notifications are dispatched manually by
+// the test.
+// As each notification channel is buffered, a notification may be dispatched
+// before the corresponding registration.
+type mockNotifier struct {
+	sync.Mutex
+
+	confChans  map[wire.ShaHash]chan int32
+	spendChans map[wire.OutPoint]chan *chainntnfs.SpendDetail
+	epochChan  chan *chainntnfs.BlockEpoch
+}
+
+func newMockNotifier() *mockNotifier {
+	return &mockNotifier{
+		confChans:  make(map[wire.ShaHash]chan int32),
+		spendChans: make(map[wire.OutPoint]chan *chainntnfs.SpendDetail),
+		epochChan:  make(chan *chainntnfs.BlockEpoch, 1),
+	}
+}
+
+func (m *mockNotifier) confChan(txid wire.ShaHash) chan int32 {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.confChans[txid]; !ok {
+		m.confChans[txid] = make(chan int32, 1)
+	}
+	return m.confChans[txid]
+}
+
+func (m *mockNotifier) spendChan(op wire.OutPoint) chan *chainntnfs.SpendDetail {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.spendChans[op]; !ok {
+		m.spendChans[op] = make(chan *chainntnfs.SpendDetail, 1)
+	}
+	return m.spendChans[op]
+}
+
+func (m *mockNotifier) RegisterConfirmationsNtfn(txid *wire.ShaHash,
+	numConfs uint32) (*chainntnfs.ConfirmationEvent, error) {
+
+	return &chainntnfs.ConfirmationEvent{
+		Confirmed:    m.confChan(*txid),
+		NegativeConf: make(chan int32, 1),
+	}, nil
+}
+
+func (m *mockNotifier) RegisterSpendNtfn(
+	outpoint *wire.OutPoint) (*chainntnfs.SpendEvent, error) {
+
+	return &chainntnfs.SpendEvent{Spend: m.spendChan(*outpoint)}, nil
+}
+
+func (m *mockNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, error) {
+	return &chainntnfs.BlockEpochEvent{Epochs: m.epochChan}, nil
+}
+
+func (m *mockNotifier) Start() error { return nil }
+func (m *mockNotifier) Stop() error  { return nil }
+
+// confirm dispatches the confirmation of the passed transaction at the passed
+// height.
+func (m *mockNotifier) confirm(txid wire.ShaHash, height int32) {
+	m.confChan(txid) <- height
+}
+
+// spend dispatches the spend of the passed output by a transaction whose
+// sole input carries the passed witness.
+func (m *mockNotifier) spend(op wire.OutPoint, witness wire.TxWitness) {
+	spendingTx := wire.NewMsgTx()
+	spendingTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: op,
+		Witness:          witness,
+	})
+	txid := spendingTx.TxSha()
+
+	m.spendChan(op) <- &chainntnfs.SpendDetail{
+		SpentOutPoint: &op,
+		SpenderTxHash: &txid,
+		SpendingTx:    spendingTx,
+	}
+}
+
+// mockSnapshotter is a mock implementation of the stateSnapshotter interface
+// which reports a channel holding a fixed set of HTLCs.
+type mockSnapshotter struct {
+	htlcs []channeldb.HTLC
+}
+
+func (m *mockSnapshotter) StateSnapshot() *channeldb.ChannelSnapshot {
+	return &channeldb.ChannelSnapshot{Htlcs: m.htlcs}
+}
+
+// TestShouldGoOnChain tests that a channel is only force closed once it holds
+// an incoming HTLC we're able to claim, or an outgoing HTLC the remote party
+// has yet to resolve, within the respective delta of the HTLC's expiry.
+func TestShouldGoOnChain(t *testing.T) {
+	const (
+		height        = 100
+		incomingDelta = 10
+		outgoingDelta = 5
+	)
+
+	knownHash := [32]byte{1}
+	unknownHash := [32]byte{2}
+	lookupPreimage := func(paymentHash wire.ShaHash) ([32]byte, bool) {
+		return [32]byte{}, paymentHash == wire.ShaHash(knownHash)
+	}
+
+	tests := []struct {
+		name      string
+		htlc      *channeldb.HTLC
+		goOnChain bool
+	}{
+		{
+			name:      "no htlcs",
+			goOnChain: false,
+		},
+		{
+			name: "claimable incoming within delta",
+			htlc: &channeldb.HTLC{
+				Incoming:      true,
+				RHash:         knownHash,
+				RefundTimeout: height + incomingDelta,
+			},
+			goOnChain: true,
+		},
+		{
+			name: "claimable incoming beyond delta",
+			htlc: &channeldb.HTLC{
+				Incoming:      true,
+				RHash:         knownHash,
+				RefundTimeout: height + incomingDelta + 1,
+			},
+			goOnChain: false,
+		},
+		{
+			name: "unclaimable incoming within delta",
+			htlc: &channeldb.HTLC{
+				Incoming:      true,
+				RHash:         unknownHash,
+				RefundTimeout: height,
+			},
+			goOnChain: false,
+		},
+		{
+			name: "unresolved outgoing within delta",
+			htlc: &channeldb.HTLC{
+				RHash:         unknownHash,
+				RefundTimeout: height + outgoingDelta,
+			},
+			goOnChain: true,
+		},
+		{
+			name: "unresolved outgoing beyond delta",
+			htlc: &channeldb.HTLC{
+				RHash:         unknownHash,
+				RefundTimeout: height + outgoingDelta + 1,
+			},
+			goOnChain: false,
+		},
+		{
+			name: "settled outgoing within delta",
+			htlc: &channeldb.HTLC{
+				RHash:         knownHash,
+				RefundTimeout: height,
+			},
+			goOnChain: false,
+		},
+	}
+
+	for _, test := range tests {
+		channel := &mockSnapshotter{}
+		if test.htlc != nil {
+			channel.htlcs = []channeldb.HTLC{*test.htlc}
+		}
+		arb := &channelArbitrator{channel: channel}
+
+		htlc := arb.shouldGoOnChain(height, lookupPreimage,
+			incomingDelta, outgoingDelta)
+		if (htlc != nil) != test.goOnChain {
+			t.Fatalf("%s: expected go on-chain %v, got htlc %v",
+				test.name, test.goOnChain, htlc)
+		}
+	}
+}
+
+// TestResolveContracts tests that the outputs of a force closed channel are
+// handed to the utxoNursery, with each incoming HTLC only handed over once its
+// preimage is known, and that the arbitration log is removed once each output
+// has been spent.
+func TestResolveContracts(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "chainarbitrator")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	// The nursery isn't started, instead we intercept each of the
+	// incubation requests sent to it.
+	notifier := newMockNotifier()
+	beacon := newPreimageBeacon(newInvoiceRegistry(), db)
+	nursery := newUtxoNursery(notifier, nil, beacon.LookupPreimage, nil)
+	c := &chainArbitrator{
+		notifier: notifier,
+		chanDB:   db,
+		nursery:  nursery,
+		beacon:   beacon,
+		quit:     make(chan struct{}),
+	}
+	defer close(c.quit)
+
+	preimage := [32]byte{1}
+	closeTxid := wire.ShaHash{0xaa}
+	outPoint := func(index uint32) wire.OutPoint {
+		return wire.OutPoint{Hash: closeTxid, Index: index}
+	}
+	arbLog := &channeldb.ArbitrationLog{
+		ChanPoint: wire.OutPoint{Hash: wire.ShaHash{0xbb}},
+		CloseTxid: closeTxid,
+		State:     channeldb.StateCommitBroadcast,
+		Resolutions: []*channeldb.ContractResolution{
+			{
+				Kind:     channeldb.ContractCommitOutput,
+				OutPoint: outPoint(0),
+				Amount:   50000,
+				PubKey:   privKey.PubKey(),
+				CsvDelay: 144,
+			},
+			{
+				Kind:        channeldb.ContractOutgoingHtlc,
+				OutPoint:    outPoint(1),
+				Amount:      5000,
+				PubKey:      privKey.PubKey(),
+				Expiry:      500,
+				PaymentHash: [32]byte{2},
+			},
+			{
+				Kind:        channeldb.ContractIncomingHtlc,
+				OutPoint:    outPoint(2),
+				Amount:      5000,
+				PubKey:      privKey.PubKey(),
+				Expiry:      500,
+				PaymentHash: fastsha256.Sum256(preimage[:]),
+			},
+		},
+	}
+	if err := db.PutArbitrationLog(arbLog); err != nil {
+		t.Fatalf("unable to put arbitration log: %v", err)
+	}
+
+	resolved := make(chan struct{})
+	c.wg.Add(1)
+	go func() {
+		c.resolveContracts(arbLog)
+		close(resolved)
+	}()
+
+	// assertIncubated asserts that the next incubation request carries
+	// exactly the passed outputs, confirmed at the passed height.
+	assertIncubated := func(confHeight uint32, outPoints ...wire.OutPoint) {
+		var req *incubationRequest
+		select {
+		case req = <-nursery.requests:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("outputs %v not incubated", outPoints)
+		}
+
+		if req.confHeight != confHeight {
+			t.Fatalf("expected conf height %v, got %v", confHeight,
+				req.confHeight)
+		}
+		if len(req.outputs) != len(outPoints) {
+			t.Fatalf("expected %v outputs, got %v", len(outPoints),
+				len(req.outputs))
+		}
+		for i, output := range req.outputs {
+			if output.outPoint != outPoints[i] {
+				t.Fatalf("expected output %v, got %v",
+					outPoints[i], output.outPoint)
+			}
+		}
+	}
+
+	// Our commitment output and the outgoing HTLC are handed over right
+	// away, while the incoming HTLC awaits its preimage.
+	assertIncubated(0, outPoint(0), outPoint(1))
+
+	// Once the commitment transaction confirms, the log should record
+	// its confirmation height.
+	notifier.confirm(closeTxid, 100)
+	confirmed := waitFor(func() bool {
+		dbLog, err := db.FetchArbitrationLog(&arbLog.ChanPoint)
+		return err == nil &&
+			dbLog.State == channeldb.StateCommitConfirmed &&
+			dbLog.CloseHeight == 100
+	})
+	if !confirmed {
+		t.Fatalf("confirmation of close tx not recorded")
+	}
+
+	// Learning the preimage of the incoming HTLC should hand it over.
+	if err := beacon.AddPreimage(preimage); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+	assertIncubated(100, outPoint(2))
+
+	// Each output is resolved once spent, after which the log is removed.
+	for i := uint32(0); i < 3; i++ {
+		notifier.spend(outPoint(i), wire.TxWitness{{0x01}})
+	}
+	select {
+	case <-resolved:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("contracts not resolved")
+	}
+
+	_, err = db.FetchArbitrationLog(&arbLog.ChanPoint)
+	if err != channeldb.ErrArbitrationLogNotFound {
+		t.Fatalf("expected arbitration log to be removed, instead %v",
+			err)
+	}
+}
+
+// waitFor polls the passed predicate until it holds, returning false if it
+// fails to do so within a few seconds.
+func waitFor(pred func() bool) bool {
+	timeout := time.After(5 * time.Second)
+	for !pred() {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestChainWatcherOutgoingHtlc tests that the chain watcher force closes a
+// channel once an outgoing HTLC the remote party has yet to resolve comes
+// within the configured delta of its expiry, and only requests the force close
+// once.
+func TestChainWatcherOutgoingHtlc(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "chainarbitrator")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	htlcSwitch := newHtlcSwitch(newHtlcNotifier())
+	if err := htlcSwitch.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer htlcSwitch.Stop()
+
+	// The peer isn't started, instead we intercept each of the close
+	// requests sent to it.
+	chanPoint := wire.OutPoint{Hash: wire.ShaHash{1}}
+	p := &peer{
+		lightningID:        wire.ShaHash{2},
+		localCloseChanReqs: make(chan *closeLinkReq, 1),
+	}
+	htlcSwitch.RegisterLink(p, &channeldb.ChannelSnapshot{
+		ChannelPoint: &chanPoint,
+	}, make(chan *htlcPacket, 1))
+
+	const expiry = 200
+	c := &chainArbitrator{
+		htlcSwitch:             htlcSwitch,
+		beacon:                 newPreimageBeacon(newInvoiceRegistry(), db),
+		incomingBroadcastDelta: 10,
+		outgoingBroadcastDelta: 5,
+		activeChannels: map[wire.OutPoint]*channelArbitrator{
+			chanPoint: {
+				channel: &mockSnapshotter{
+					htlcs: []channeldb.HTLC{{
+						RHash:         [32]byte{3},
+						RefundTimeout: expiry,
+					}},
+				},
+				chanPoint: chanPoint,
+			},
+		},
+		quit: make(chan struct{}),
+	}
+
+	// As the epoch channel is unbuffered, each epoch has been fully
+	// processed once the next one is received.
+	epochs := make(chan *chainntnfs.BlockEpoch)
+	c.wg.Add(1)
+	go c.chainWatcher(&chainntnfs.BlockEpochEvent{Epochs: epochs})
+	defer func() {
+		close(c.quit)
+		c.wg.Wait()
+	}()
+	sendEpoch := func(height int32) {
+		select {
+		case epochs <- &chainntnfs.BlockEpoch{Height: height}:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("epoch at height %v not received", height)
+		}
+	}
+
+	// Beyond the outgoing delta, the channel should remain open.
+	sendEpoch(expiry - 6)
+	sendEpoch(expiry - 6)
+	select {
+	case <-p.localCloseChanReqs:
+		t.Fatalf("channel closed beyond outgoing delta")
+	default:
+	}
+
+	// Within the delta, the channel should be force closed.
+	sendEpoch(expiry - 5)
+	select {
+	case req := <-p.localCloseChanReqs:
+		if !req.forceClose || *req.chanPoint != chanPoint {
+			t.Fatalf("expected force close of %v", chanPoint)
+		}
+		req.err <- errors.New("unable to force close")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("channel not force closed within outgoing delta")
+	}
+
+	// The force close must only be requested once.
+	sendEpoch(expiry - 4)
+	sendEpoch(expiry - 4)
+	select {
+	case <-p.localCloseChanReqs:
+		t.Fatalf("force close requested twice")
+	default:
+	}
+}
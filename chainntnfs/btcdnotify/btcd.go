@@ -208,7 +208,8 @@ out:
 				connectedBlock.height, connectedBlock.sha)
 
 			go b.notifyBlockEpochs(connectedBlock.height,
-				connectedBlock.sha)
+				connectedBlock.sha,
+				newBlock.MsgBlock().Header.Timestamp)
 
 			newHeight := connectedBlock.height
 			for _, tx := range newBlock.Transactions() {
@@ -263,10 +264,13 @@ out:
 
 // notifyBlockEpochs notifies all registered block epoch clients of the newly
 // connected block to the main chain.
-func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *wire.ShaHash) {
+func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *wire.ShaHash,
+	timestamp time.Time) {
+
 	epoch := &chainntnfs.BlockEpoch{
-		Height: newHeight,
-		Hash:   newSha,
+		Height:    newHeight,
+		Hash:      newSha,
+		Timestamp: timestamp,
 	}
 
 	// TODO(roasbeef): spwan a new goroutine for each client instead?
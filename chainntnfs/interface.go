@@ -3,6 +3,7 @@ package chainntnfs
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/roasbeef/btcd/wire"
 )
@@ -99,6 +100,10 @@ type SpendEvent struct {
 type BlockEpoch struct {
 	Height int32
 	Hash   *wire.ShaHash
+
+	// Timestamp is the time at which the block was mined, as reported
+	// within its header.
+	Timestamp time.Time
 }
 
 // BlockEpochEvent encapsulates an on-going stream of block epoch
@@ -331,6 +331,41 @@ func testBlockEpochNotification(miner *rpctest.Harness,
 	}
 }
 
+func testBlockEpochTimestamp(miner *rpctest.Harness,
+	notifier chainntnfs.ChainNotifier, t *testing.T) {
+
+	// Each block epoch should carry the timestamp found within the header
+	// of the newly connected block.
+	epochClient, err := notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		t.Fatalf("unable to register for epoch notification")
+	}
+
+	blockHash, err := miner.Node.Generate(1)
+	if err != nil {
+		t.Fatalf("unable to generate block: %v", err)
+	}
+	block, err := miner.Node.GetBlock(blockHash[0])
+	if err != nil {
+		t.Fatalf("unable to get block: %v", err)
+	}
+
+	select {
+	case epoch := <-epochClient.Epochs:
+		if !epoch.Hash.IsEqual(blockHash[0]) {
+			t.Fatalf("epoch for block %v, expected %v", epoch.Hash,
+				blockHash[0])
+		}
+		if !epoch.Timestamp.Equal(block.Header.Timestamp) {
+			t.Fatalf("epoch timestamp %v doesn't match block "+
+				"timestamp %v", epoch.Timestamp,
+				block.Header.Timestamp)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("epoch notification never received")
+	}
+}
+
 func testMultiClientConfirmationNotification(miner *rpctest.Harness,
 	notifier chainntnfs.ChainNotifier, t *testing.T) {
 	// TODO(roasbeef): test various conf targets w/ same txid
@@ -388,6 +423,7 @@ var ntfnTests = []func(node *rpctest.Harness, notifier chainntnfs.ChainNotifier,
 	testMultiClientConfirmationNotification,
 	testSpendNotification,
 	testBlockEpochNotification,
+	testBlockEpochTimestamp,
 }
 
 // TestInterfaces tests all registered interfaces with a unified set of tests
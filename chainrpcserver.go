@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// errNotifierShuttingDown is returned to clients awaiting a notification if
+// the chain notifier shuts down before it's delivered.
+var errNotifierShuttingDown = errors.New("chain notifier shutting down")
+
+// chainRPCServer is a gRPC front end to the chain notifier backing lnd. It
+// allows external applications to watch for confirmations and spends using
+// lnd's view of the chain.
+type chainRPCServer struct {
+	notifier chainntnfs.ChainNotifier
+}
+
+// A compile time check to ensure that chainRPCServer fully implements the
+// ChainNotifierServer gRPC service.
+var _ chainrpc.ChainNotifierServer = (*chainRPCServer)(nil)
+
+// newChainRPCServer creates a new instance of the chainRPCServer backed by
+// the passed chain notifier.
+func newChainRPCServer(notifier chainntnfs.ChainNotifier) *chainRPCServer {
+	return &chainRPCServer{
+		notifier: notifier,
+	}
+}
+
+// RegisterConfirmationsNtfn streams a notification once the target
+// transaction reaches the requested number of confirmations, followed by a
+// notification each time the confirmed transaction is re-org'd out of the main
+// chain. The stream remains open until the client cancels it.
+func (c *chainRPCServer) RegisterConfirmationsNtfn(in *chainrpc.ConfRequest,
+	updateStream chainrpc.ChainNotifier_RegisterConfirmationsNtfnServer) error {
+
+	txid, err := wire.NewShaHash(in.Txid)
+	if err != nil {
+		return err
+	}
+	if in.NumConfs == 0 {
+		return fmt.Errorf("num_confs must be positive")
+	}
+
+	rpcsLog.Debugf("[registerconfirmationsntfn] txid=%v, num_confs=%v",
+		txid, in.NumConfs)
+
+	confEvent, err := c.notifier.RegisterConfirmationsNtfn(txid,
+		in.NumConfs)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case height, ok := <-confEvent.Confirmed:
+			if !ok {
+				return errNotifierShuttingDown
+			}
+
+			err := updateStream.Send(&chainrpc.ConfEvent{
+				Event: &chainrpc.ConfEvent_Conf{
+					Conf: &chainrpc.ConfDetails{
+						BlockHeight: uint32(height),
+					},
+				},
+			})
+			if err != nil {
+				return err
+			}
+		case depth, ok := <-confEvent.NegativeConf:
+			if !ok {
+				return errNotifierShuttingDown
+			}
+
+			err := updateStream.Send(&chainrpc.ConfEvent{
+				Event: &chainrpc.ConfEvent_Reorg{
+					Reorg: &chainrpc.Reorg{
+						Depth: uint32(depth),
+					},
+				},
+			})
+			if err != nil {
+				return err
+			}
+		case <-updateStream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// RegisterBlockEpochNtfn streams a notification for each new block connected
+// to the tip of the main chain until the client cancels the stream.
+func (c *chainRPCServer) RegisterBlockEpochNtfn(in *chainrpc.BlockEpochRequest,
+	updateStream chainrpc.ChainNotifier_RegisterBlockEpochNtfnServer) error {
+
+	rpcsLog.Debugf("[registerblockepochntfn] request")
+
+	epochEvent, err := c.notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case epoch, ok := <-epochEvent.Epochs:
+			if !ok {
+				return errNotifierShuttingDown
+			}
+
+			err := updateStream.Send(&chainrpc.BlockEpoch{
+				Hash:      epoch.Hash[:],
+				Height:    uint32(epoch.Height),
+				Timestamp: epoch.Timestamp.Unix(),
+			})
+			if err != nil {
+				return err
+			}
+		case <-updateStream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// RegisterSpendNtfn streams a single notification once the target outpoint
+// has been spent, then closes the stream.
+func (c *chainRPCServer) RegisterSpendNtfn(in *chainrpc.SpendRequest,
+	updateStream chainrpc.ChainNotifier_RegisterSpendNtfnServer) error {
+
+	if in.Outpoint == nil {
+		return fmt.Errorf("outpoint must be specified")
+	}
+	hash, err := wire.NewShaHash(in.Outpoint.Hash)
+	if err != nil {
+		return err
+	}
+	outpoint := wire.NewOutPoint(hash, in.Outpoint.Index)
+
+	rpcsLog.Debugf("[registerspendntfn] outpoint=%v", outpoint)
+
+	spendEvent, err := c.notifier.RegisterSpendNtfn(outpoint)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case spend, ok := <-spendEvent.Spend:
+		if !ok {
+			return errNotifierShuttingDown
+		}
+
+		var rawTx bytes.Buffer
+		if err := spend.SpendingTx.Serialize(&rawTx); err != nil {
+			return err
+		}
+
+		return updateStream.Send(&chainrpc.SpendEvent{
+			Spend: &chainrpc.SpendDetails{
+				SpendingOutpoint: &chainrpc.Outpoint{
+					Hash:  spend.SpentOutPoint.Hash[:],
+					Index: spend.SpentOutPoint.Index,
+				},
+				RawSpendingTx:      rawTx.Bytes(),
+				SpendingTxHash:     spend.SpenderTxHash[:],
+				SpendingInputIndex: spend.SpenderInputIndex,
+				SpendingHeight:     uint32(spend.SpendingHeight),
+			},
+		})
+	case <-updateStream.Context().Done():
+		return nil
+	}
+}
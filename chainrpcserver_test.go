@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockConfStream is a confirmation notification stream which delivers each
+// event sent over it to the test.
+type mockConfStream struct {
+	mockServerStream
+
+	events chan *chainrpc.ConfEvent
+}
+
+func (m *mockConfStream) Send(event *chainrpc.ConfEvent) error {
+	m.events <- event
+	return nil
+}
+
+// mockSpendStream is a spend notification stream which delivers each event
+// sent over it to the test.
+type mockSpendStream struct {
+	mockServerStream
+
+	events chan *chainrpc.SpendEvent
+}
+
+func (m *mockSpendStream) Send(event *chainrpc.SpendEvent) error {
+	m.events <- event
+	return nil
+}
+
+// mockEpochStream is a block epoch notification stream which delivers each
+// epoch sent over it to the test.
+type mockEpochStream struct {
+	mockServerStream
+
+	epochs chan *chainrpc.BlockEpoch
+}
+
+func (m *mockEpochStream) Send(epoch *chainrpc.BlockEpoch) error {
+	m.epochs <- epoch
+	return nil
+}
+
+// TestChainRPCConfirmations tests that a confirmation notification is
+// streamed to the client once the target transaction confirms, and that the
+// stream remains open until the client cancels it.
+func TestChainRPCConfirmations(t *testing.T) {
+	notifier := newMockNotifier()
+	c := newChainRPCServer(notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockConfStream{
+		mockServerStream: mockServerStream{ctx: ctx},
+		events:           make(chan *chainrpc.ConfEvent, 1),
+	}
+
+	// Requests for an invalid txid or for zero confirmations should be
+	// refused.
+	txid := wire.ShaHash{1}
+	invalidReqs := []*chainrpc.ConfRequest{
+		{Txid: txid[:16], NumConfs: 1},
+		{Txid: txid[:], NumConfs: 0},
+	}
+	for _, req := range invalidReqs {
+		if err := c.RegisterConfirmationsNtfn(req, stream); err == nil {
+			t.Fatalf("invalid request %v accepted", req)
+		}
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.RegisterConfirmationsNtfn(&chainrpc.ConfRequest{
+			Txid:     txid[:],
+			NumConfs: 3,
+		}, stream)
+	}()
+
+	notifier.confirm(txid, 100)
+	select {
+	case event := <-stream.events:
+		conf := event.GetConf()
+		if conf == nil {
+			t.Fatalf("expected confirmation, got %v", event)
+		}
+		if conf.BlockHeight != 100 {
+			t.Fatalf("expected confirmation at height 100, got %v",
+				conf.BlockHeight)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("confirmation not streamed")
+	}
+
+	select {
+	case err := <-errChan:
+		t.Fatalf("stream closed before cancellation: %v", err)
+	default:
+	}
+	cancel()
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("stream closed with error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream not closed after cancellation")
+	}
+}
+
+// TestChainRPCSpend tests that the details of the transaction spending the
+// target outpoint are streamed to the client, after which the stream is
+// closed.
+func TestChainRPCSpend(t *testing.T) {
+	notifier := newMockNotifier()
+	c := newChainRPCServer(notifier)
+
+	stream := &mockSpendStream{
+		mockServerStream: mockServerStream{ctx: context.Background()},
+		events:           make(chan *chainrpc.SpendEvent, 1),
+	}
+
+	err := c.RegisterSpendNtfn(&chainrpc.SpendRequest{}, stream)
+	if err == nil {
+		t.Fatalf("request without an outpoint accepted")
+	}
+
+	op := wire.OutPoint{Hash: wire.ShaHash{1}, Index: 2}
+	notifier.spend(op, wire.TxWitness{{3}})
+	err = c.RegisterSpendNtfn(&chainrpc.SpendRequest{
+		Outpoint: &chainrpc.Outpoint{
+			Hash:  op.Hash[:],
+			Index: op.Index,
+		},
+	}, stream)
+	if err != nil {
+		t.Fatalf("unable to register for spend: %v", err)
+	}
+
+	spend := (<-stream.events).Spend
+	if !bytes.Equal(spend.SpendingOutpoint.Hash, op.Hash[:]) ||
+		spend.SpendingOutpoint.Index != op.Index {
+
+		t.Fatalf("spend of the wrong outpoint %v",
+			spend.SpendingOutpoint)
+	}
+
+	spendingTx := wire.NewMsgTx()
+	err = spendingTx.Deserialize(bytes.NewReader(spend.RawSpendingTx))
+	if err != nil {
+		t.Fatalf("unable to decode spending tx: %v", err)
+	}
+	txid := spendingTx.TxSha()
+	if !bytes.Equal(spend.SpendingTxHash, txid[:]) {
+		t.Fatalf("spending txid %x doesn't match spending tx %v",
+			spend.SpendingTxHash, txid)
+	}
+	if spendingTx.TxIn[0].PreviousOutPoint != op {
+		t.Fatalf("spending tx doesn't spend %v", op)
+	}
+}
+
+// TestChainRPCBlockEpochs tests that each new block is streamed to the client
+// along with the timestamp within its header, until the client cancels the
+// stream.
+func TestChainRPCBlockEpochs(t *testing.T) {
+	notifier := newMockNotifier()
+	c := newChainRPCServer(notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &mockEpochStream{
+		mockServerStream: mockServerStream{ctx: ctx},
+		epochs:           make(chan *chainrpc.BlockEpoch, 1),
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- c.RegisterBlockEpochNtfn(
+			&chainrpc.BlockEpochRequest{}, stream)
+	}()
+
+	timestamp := time.Unix(1500000000, 0)
+	for height := int32(100); height < 103; height++ {
+		hash := wire.ShaHash{byte(height)}
+		notifier.epochChan <- &chainntnfs.BlockEpoch{
+			Height:    height,
+			Hash:      &hash,
+			Timestamp: timestamp,
+		}
+
+		select {
+		case epoch := <-stream.epochs:
+			if epoch.Height != uint32(height) ||
+				!bytes.Equal(epoch.Hash, hash[:]) {
+
+				t.Fatalf("expected block %v at height %v, got "+
+					"%x at height %v", hash, height,
+					epoch.Hash, epoch.Height)
+			}
+			if epoch.Timestamp != timestamp.Unix() {
+				t.Fatalf("expected timestamp %v, got %v",
+					timestamp.Unix(), epoch.Timestamp)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block epoch not streamed")
+		}
+		timestamp = timestamp.Add(10 * time.Minute)
+	}
+
+	cancel()
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("stream closed with error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("stream not closed after cancellation")
+	}
+}
@@ -0,0 +1,452 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// arbitrationBucket is the name of the bucket within the database
+	// which stores the arbitration log of each channel whose contracts are
+	// being resolved on-chain. Within the bucket, each log is keyed by the
+	// channel point of its channel.
+	arbitrationBucket = []byte("arb")
+)
+
+// ArbitratorState is the state of a channel closed on-chain, whose contracts
+// are being resolved by the daemon.
+type ArbitratorState byte
+
+const (
+	// StateCommitBroadcast denotes that our commitment transaction has
+	// been broadcast, but has yet to confirm.
+	StateCommitBroadcast ArbitratorState = 1
+
+	// StateCommitConfirmed denotes that the commitment transaction has
+	// confirmed, and that we're waiting for each of its outputs to be
+	// resolved.
+	StateCommitConfirmed ArbitratorState = 2
+)
+
+// String returns a human readable version of the arbitrator state.
+func (s ArbitratorState) String() string {
+	switch s {
+	case StateCommitBroadcast:
+		return "CommitBroadcast"
+	case StateCommitConfirmed:
+		return "CommitConfirmed"
+	default:
+		return "Unknown"
+	}
+}
+
+// ContractKind is the kind of an output of a commitment transaction which must
+// be resolved on-chain.
+type ContractKind byte
+
+const (
+	// ContractCommitOutput is our time-locked output of the commitment
+	// transaction.
+	ContractCommitOutput ContractKind = 1
+
+	// ContractOutgoingHtlc is an HTLC output offered by us, which we
+	// reclaim once the HTLC has expired.
+	ContractOutgoingHtlc ContractKind = 2
+
+	// ContractIncomingHtlc is an HTLC output offered to us, which we
+	// claim by revealing the preimage of the HTLC.
+	ContractIncomingHtlc ContractKind = 3
+)
+
+// String returns a human readable version of the contract kind.
+func (k ContractKind) String() string {
+	switch k {
+	case ContractCommitOutput:
+		return "CommitOutput"
+	case ContractOutgoingHtlc:
+		return "OutgoingHtlc"
+	case ContractIncomingHtlc:
+		return "IncomingHtlc"
+	default:
+		return "Unknown"
+	}
+}
+
+// ContractResolution describes a single output of a commitment transaction
+// which must be resolved on-chain, along with everything required to sweep
+// it once it can be claimed.
+type ContractResolution struct {
+	// Kind is the kind of the contract.
+	Kind ContractKind
+
+	// OutPoint is the output of the commitment transaction.
+	OutPoint wire.OutPoint
+
+	// Amount is the value of the output.
+	Amount btcutil.Amount
+
+	// PubKey is the key which signs for the output.
+	PubKey *btcec.PublicKey
+
+	// WitnessScript is the witness script of the output.
+	WitnessScript []byte
+
+	// PkScript is the public key script of the output.
+	PkScript []byte
+
+	// CsvDelay is the relative delay after the confirmation of the
+	// commitment transaction before the output can be swept.
+	CsvDelay uint32
+
+	// LeaseExpiry is the absolute height before which our commitment
+	// output can't be swept due to a channel lease. A value of zero
+	// indicates that the output isn't leased.
+	LeaseExpiry uint32
+
+	// Expiry is the absolute height at which an HTLC expires. It's zero
+	// for our commitment output.
+	Expiry uint32
+
+	// PaymentHash is the payment hash of an HTLC.
+	PaymentHash [32]byte
+
+	// Resolved is true once the output has been spent on-chain.
+	Resolved bool
+}
+
+// ArbitrationLog records the progress of the on-chain resolution of a closed
+// channel. A log is created once the commitment transaction is broadcast, and
+// is removed once each of its contracts has been resolved.
+type ArbitrationLog struct {
+	// ChanPoint is the channel point of the closed channel.
+	ChanPoint wire.OutPoint
+
+	// RemoteID is the lightning ID of the remote node of the channel.
+	RemoteID [wire.HashSize]byte
+
+	// Capacity is the capacity of the channel.
+	Capacity btcutil.Amount
+
+	// LocalBalance and RemoteBalance are the settled balances of the
+	// channel at the time it was closed.
+	LocalBalance  btcutil.Amount
+	RemoteBalance btcutil.Amount
+
+	// CloseTxid is the txid of the commitment transaction which closed
+	// the channel.
+	CloseTxid wire.ShaHash
+
+	// CloseHeight is the height at which the commitment transaction
+	// confirmed. It's zero while the transaction is unconfirmed.
+	CloseHeight uint32
+
+	// State is the current state of the resolution.
+	State ArbitratorState
+
+	// Resolutions is the set of outputs of the commitment transaction
+	// which must be resolved.
+	Resolutions []*ContractResolution
+}
+
+// PutArbitrationLog creates or overwrites the arbitration log of the channel
+// identified by the log's channel point.
+func (d *DB) PutArbitrationLog(log *ArbitrationLog) error {
+	return d.store.Update(func(tx *bolt.Tx) error {
+		arbBucket, err := tx.CreateBucketIfNotExists(arbitrationBucket)
+		if err != nil {
+			return err
+		}
+
+		var k bytes.Buffer
+		if err := writeOutpoint(&k, &log.ChanPoint); err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializeArbitrationLog(&b, log); err != nil {
+			return err
+		}
+
+		return arbBucket.Put(k.Bytes(), b.Bytes())
+	})
+}
+
+// FetchArbitrationLog returns the arbitration log of the target channel.
+func (d *DB) FetchArbitrationLog(chanPoint *wire.OutPoint) (*ArbitrationLog, error) {
+	var log *ArbitrationLog
+	err := d.store.View(func(tx *bolt.Tx) error {
+		arbBucket := tx.Bucket(arbitrationBucket)
+		if arbBucket == nil {
+			return ErrArbitrationLogNotFound
+		}
+
+		var k bytes.Buffer
+		if err := writeOutpoint(&k, chanPoint); err != nil {
+			return err
+		}
+
+		logBytes := arbBucket.Get(k.Bytes())
+		if logBytes == nil {
+			return ErrArbitrationLogNotFound
+		}
+
+		var err error
+		log, err = deserializeArbitrationLog(bytes.NewReader(logBytes))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// FetchArbitrationLogs returns the arbitration logs of all channels whose
+// contracts are being resolved.
+func (d *DB) FetchArbitrationLogs() ([]*ArbitrationLog, error) {
+	var logs []*ArbitrationLog
+	err := d.store.View(func(tx *bolt.Tx) error {
+		arbBucket := tx.Bucket(arbitrationBucket)
+		if arbBucket == nil {
+			return nil
+		}
+
+		return arbBucket.ForEach(func(k, v []byte) error {
+			log, err := deserializeArbitrationLog(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			logs = append(logs, log)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// DeleteArbitrationLog removes the arbitration log of the target channel.
+func (d *DB) DeleteArbitrationLog(chanPoint *wire.OutPoint) error {
+	return d.store.Update(func(tx *bolt.Tx) error {
+		arbBucket := tx.Bucket(arbitrationBucket)
+		if arbBucket == nil {
+			return ErrArbitrationLogNotFound
+		}
+
+		var k bytes.Buffer
+		if err := writeOutpoint(&k, chanPoint); err != nil {
+			return err
+		}
+		if arbBucket.Get(k.Bytes()) == nil {
+			return ErrArbitrationLogNotFound
+		}
+
+		return arbBucket.Delete(k.Bytes())
+	})
+}
+
+func serializeArbitrationLog(w io.Writer, log *ArbitrationLog) error {
+	var scratch [8]byte
+
+	if err := writeOutpoint(w, &log.ChanPoint); err != nil {
+		return err
+	}
+	if _, err := w.Write(log.RemoteID[:]); err != nil {
+		return err
+	}
+
+	for _, amt := range []btcutil.Amount{log.Capacity, log.LocalBalance,
+		log.RemoteBalance} {
+
+		byteOrder.PutUint64(scratch[:], uint64(amt))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(log.CloseTxid[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], log.CloseHeight)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{byte(log.State)}); err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(log.Resolutions))); err != nil {
+		return err
+	}
+	for _, resolution := range log.Resolutions {
+		if err := serializeContractResolution(w, resolution); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializeArbitrationLog(r io.Reader) (*ArbitrationLog, error) {
+	log := &ArbitrationLog{}
+
+	var scratch [8]byte
+
+	if err := readOutpoint(r, &log.ChanPoint); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, log.RemoteID[:]); err != nil {
+		return nil, err
+	}
+
+	for _, amt := range []*btcutil.Amount{&log.Capacity, &log.LocalBalance,
+		&log.RemoteBalance} {
+
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		*amt = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	}
+
+	if _, err := io.ReadFull(r, log.CloseTxid[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	log.CloseHeight = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	log.State = ArbitratorState(scratch[0])
+
+	numResolutions, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	log.Resolutions = make([]*ContractResolution, numResolutions)
+	for i := uint64(0); i < numResolutions; i++ {
+		log.Resolutions[i], err = deserializeContractResolution(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return log, nil
+}
+
+func serializeContractResolution(w io.Writer, c *ContractResolution) error {
+	var scratch [8]byte
+
+	if _, err := w.Write([]byte{byte(c.Kind)}); err != nil {
+		return err
+	}
+	if err := writeOutpoint(w, &c.OutPoint); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(c.Amount))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(c.PubKey.SerializeCompressed()); err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(w, 0, c.WitnessScript); err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(w, 0, c.PkScript); err != nil {
+		return err
+	}
+
+	for _, n := range []uint32{c.CsvDelay, c.LeaseExpiry, c.Expiry} {
+		byteOrder.PutUint32(scratch[:4], n)
+		if _, err := w.Write(scratch[:4]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(c.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	var resolved byte
+	if c.Resolved {
+		resolved = 1
+	}
+	if _, err := w.Write([]byte{resolved}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func deserializeContractResolution(r io.Reader) (*ContractResolution, error) {
+	c := &ContractResolution{}
+
+	var scratch [8]byte
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	c.Kind = ContractKind(scratch[0])
+
+	if err := readOutpoint(r, &c.OutPoint); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	c.Amount = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	var pubKeyBytes [33]byte
+	if _, err := io.ReadFull(r, pubKeyBytes[:]); err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes[:], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	c.PubKey = pubKey
+
+	c.WitnessScript, err = wire.ReadVarBytes(r, 0, 10000, "witness script")
+	if err != nil {
+		return nil, err
+	}
+	c.PkScript, err = wire.ReadVarBytes(r, 0, 10000, "pkscript")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range []*uint32{&c.CsvDelay, &c.LeaseExpiry, &c.Expiry} {
+		if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+			return nil, err
+		}
+		*n = byteOrder.Uint32(scratch[:4])
+	}
+
+	if _, err := io.ReadFull(r, c.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	c.Resolved = scratch[0] == 1
+
+	return c, nil
+}
@@ -0,0 +1,101 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/wire"
+)
+
+func TestArbitrationLogLifecycle(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	// Before any log has been added, none should be found.
+	if _, err := db.FetchArbitrationLog(id); err != ErrArbitrationLogNotFound {
+		t.Fatalf("expected ErrArbitrationLogNotFound, instead %v", err)
+	}
+
+	log := &ArbitrationLog{
+		ChanPoint:     *id,
+		RemoteID:      key,
+		Capacity:      100000,
+		LocalBalance:  60000,
+		RemoteBalance: 40000,
+		CloseTxid:     testTx.TxSha(),
+		State:         StateCommitBroadcast,
+		Resolutions: []*ContractResolution{
+			{
+				Kind:          ContractCommitOutput,
+				OutPoint:      wire.OutPoint{Hash: testTx.TxSha(), Index: 0},
+				Amount:        55000,
+				PubKey:        pubKey,
+				WitnessScript: []byte{0x51},
+				PkScript:      testTx.TxOut[0].PkScript,
+				CsvDelay:      144,
+				LeaseExpiry:   1000,
+			},
+			{
+				Kind:          ContractIncomingHtlc,
+				OutPoint:      wire.OutPoint{Hash: testTx.TxSha(), Index: 1},
+				Amount:        5000,
+				PubKey:        pubKey,
+				WitnessScript: []byte{0x52},
+				PkScript:      testTx.TxOut[0].PkScript,
+				CsvDelay:      144,
+				Expiry:        500,
+				PaymentHash:   rev,
+			},
+		},
+	}
+	if err := db.PutArbitrationLog(log); err != nil {
+		t.Fatalf("unable to put arbitration log: %v", err)
+	}
+
+	dbLog, err := db.FetchArbitrationLog(id)
+	if err != nil {
+		t.Fatalf("unable to fetch arbitration log: %v", err)
+	}
+	if !reflect.DeepEqual(log, dbLog) {
+		t.Fatalf("log fetched from db doesn't match original %v vs %v",
+			spew.Sdump(log), spew.Sdump(dbLog))
+	}
+
+	// Once the commitment has confirmed, and a contract is resolved, the
+	// updated log should overwrite the original.
+	log.State = StateCommitConfirmed
+	log.CloseHeight = 300
+	log.Resolutions[1].Resolved = true
+	if err := db.PutArbitrationLog(log); err != nil {
+		t.Fatalf("unable to put arbitration log: %v", err)
+	}
+
+	logs, err := db.FetchArbitrationLogs()
+	if err != nil {
+		t.Fatalf("unable to fetch arbitration logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 arbitration log, instead have %v",
+			len(logs))
+	}
+	if !reflect.DeepEqual(log, logs[0]) {
+		t.Fatalf("log fetched from db doesn't match original %v vs %v",
+			spew.Sdump(log), spew.Sdump(logs[0]))
+	}
+
+	// Finally, after the log has been deleted, it should no longer be
+	// found.
+	if err := db.DeleteArbitrationLog(id); err != nil {
+		t.Fatalf("unable to delete arbitration log: %v", err)
+	}
+	if _, err := db.FetchArbitrationLog(id); err != ErrArbitrationLogNotFound {
+		t.Fatalf("expected ErrArbitrationLogNotFound, instead %v", err)
+	}
+	if err := db.DeleteArbitrationLog(id); err != ErrArbitrationLogNotFound {
+		t.Fatalf("expected ErrArbitrationLogNotFound, instead %v", err)
+	}
+}
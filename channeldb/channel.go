@@ -9,6 +9,7 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -37,6 +38,16 @@ var (
 	// previously open, but now closed channels.
 	closedChannelBucket = []byte("ccb")
 
+	// chanPointIndexBucket is a top-level bucket which maps the funding
+	// outpoint of each open channel to the ID of the node the channel is
+	// with. This index allows a channel to be fetched directly by its
+	// channel point, without scanning the channels of every node.
+	chanPointIndexBucket = []byte("cpi")
+
+	// shortChanIDIndexBucket is a top-level bucket which maps the short
+	// channel ID of each confirmed open channel to its funding outpoint.
+	shortChanIDIndexBucket = []byte("sci")
+
 	// channelLogBucket is dedicated for storing the necessary delta state
 	// between channel updates required to re-construct a past state in
 	// order to punish a counter party attempting a non-cooperative channel
@@ -54,14 +65,21 @@ var (
 	// sequential prefix scans, and second to eliminate write amplification
 	// caused by serializing/deserializing the *entire* struct with each
 	// update.
-	chanCapacityPrefix = []byte("ccp")
-	selfBalancePrefix  = []byte("sbp")
-	theirBalancePrefix = []byte("tbp")
-	minFeePerKbPrefix  = []byte("mfp")
-	updatePrefix       = []byte("uup")
-	satSentPrefix      = []byte("ssp")
-	satRecievedPrefix  = []byte("srp")
-	netFeesPrefix      = []byte("ntp")
+	chanCapacityPrefix   = []byte("ccp")
+	selfBalancePrefix    = []byte("sbp")
+	theirBalancePrefix   = []byte("tbp")
+	minFeePerKbPrefix    = []byte("mfp")
+	updatePrefix         = []byte("uup")
+	satSentPrefix        = []byte("ssp")
+	satRecievedPrefix    = []byte("srp")
+	netFeesPrefix        = []byte("ntp")
+	shortChanIDPrefix    = []byte("scp")
+	chanPrivatePrefix    = []byte("cpp")
+	chanUptimePrefix     = []byte("cup")
+	chanLeasePrefix      = []byte("clp")
+	chanPolicyPrefix     = []byte("cfp")
+	chanHtlcLimitsPrefix = []byte("chl")
+	chanUpfrontPrefix    = []byte("cus")
 
 	// chanIDKey stores the node, and channelID for an active channel.
 	chanIDKey = []byte("cik")
@@ -107,6 +125,41 @@ type OpenChannel struct {
 	ChanID      *wire.OutPoint
 	MinFeePerKb btcutil.Amount
 
+	// ShortChanID is the location of the funding output within the chain.
+	// This field is only populated once the funding transaction has been
+	// confirmed.
+	ShortChanID lnwire.ShortChannelID
+
+	// IsPrivate indicates that the channel isn't to be announced to the
+	// rest of the network, and therefore can only be used for payments
+	// originating from, or destined to, either of its endpoints.
+	IsPrivate bool
+
+	// IsInitiator is true if we were the party that funded the channel.
+	IsInitiator bool
+
+	// LeaseExpiry is the absolute block height until which the funds of
+	// the channel initiator are locked within a leased channel. If
+	// non-zero, every commitment output paying to the initiator is
+	// additionally encumbered by a CHECKLOCKTIMEVERIFY until this height,
+	// preventing the seller of the lease from recovering their funds
+	// before the lease has expired.
+	LeaseExpiry uint32
+
+	// LocalPolicy is the forwarding policy we advertise for HTLC's sent
+	// out over this channel. If nil, the node's default policy applies.
+	LocalPolicy *ChannelEdgePolicy
+
+	// MaxPendingAmount is the maximum total value of HTLC's which may be
+	// in flight in either direction at any time. A value of zero places
+	// no limit beyond the capacity of the channel.
+	MaxPendingAmount btcutil.Amount
+
+	// MaxAcceptedHtlcs is the maximum number of HTLC's which may be in
+	// flight in either direction at any time. A value of zero indicates
+	// that the default limit applies.
+	MaxAcceptedHtlcs uint16
+
 	// Keys for both sides to be used for the commitment transactions.
 	OurCommitKey   *btcec.PublicKey
 	TheirCommitKey *btcec.PublicKey
@@ -146,12 +199,24 @@ type OpenChannel struct {
 	OurDeliveryScript   []byte
 	TheirDeliveryScript []byte
 
+	// OurUpfrontShutdown and TheirUpfrontShutdown indicate whether each
+	// side committed to its delivery script as an upfront shutdown script
+	// during the funding workflow. A committed delivery script can't be
+	// replaced when the channel is cooperatively closed.
+	OurUpfrontShutdown   bool
+	TheirUpfrontShutdown bool
+
 	NumUpdates            uint64
 	TotalSatoshisSent     uint64
 	TotalSatoshisReceived uint64
 	TotalNetFees          uint64    // TODO(roasbeef): total fees paid too?
 	CreationTime          time.Time // TODO(roasbeef): last update time?
 
+	// Uptime is the total duration the remote peer has been connected to
+	// us while this channel has been open. This field is only ever
+	// modified via DB.AddChannelUptime, and is never written by FullSync.
+	Uptime time.Duration
+
 	Htlcs []*HTLC
 
 	// TODO(roasbeef): eww
@@ -202,63 +267,150 @@ func (c *OpenChannel) FullSync() error {
 			}
 		}
 
-		return putOpenChannel(chanBucket, nodeChanBucket, c)
+		// Also index the channel by its channel point, and its short
+		// channel ID if it's already known, allowing the channel to be
+		// located without knowing the remote node's ID.
+		chanPointIndex, err := tx.CreateBucketIfNotExists(chanPointIndexBucket)
+		if err != nil {
+			return err
+		}
+		if err := chanPointIndex.Put(b.Bytes(), c.TheirLNID[:]); err != nil {
+			return err
+		}
+		if c.ShortChanID != (lnwire.ShortChannelID{}) {
+			err := putShortChanIDIndex(tx, c.ShortChanID, b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := putOpenChannel(chanBucket, nodeChanBucket, c); err != nil {
+			return err
+		}
+
+		return putChanChecksum(chanBucket, nodeChanBucket, c.ChanID)
 	})
 }
 
-// UpdateCommitment updates the on-disk state of our currently broadcastable
-// commitment state. This method is to be called once we have revoked our prior
-// commitment state, accepting the new state as defined by the passed
-// parameters.
-func (c *OpenChannel) UpdateCommitment(newCommitment *wire.MsgTx,
-	newSig []byte, delta *ChannelDelta) error {
-
+// SetShortChanID records the location of the channel's funding output within
+// the chain, adding the channel to the short channel ID index. This method is
+// to be called once the funding transaction has been confirmed.
+func (c *OpenChannel) SetShortChanID(shortChanID lnwire.ShortChannelID) error {
 	c.Lock()
 	defer c.Unlock()
 
-	return c.Db.store.Update(func(tx *bolt.Tx) error {
-		chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
-		if err != nil {
-			return err
+	err := c.Db.store.Update(func(tx *bolt.Tx) error {
+		chanBucket := tx.Bucket(openChannelBucket)
+		if chanBucket == nil {
+			return ErrNoChanDBExists
 		}
 
-		id := c.TheirLNID[:]
-		nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(id)
-		if err != nil {
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, c.ChanID); err != nil {
 			return err
 		}
 
-		// TODO(roasbeef): modify the funcs below to take values
-		// directly, otherwise need to roll back to prior state. Could
-		// also make copy above, then modify to pass in.
-		c.OurCommitTx = newCommitment
-		c.OurCommitSig = newSig
-		c.OurBalance = delta.LocalBalance
-		c.TheirBalance = delta.RemoteBalance
-		c.NumUpdates = uint64(delta.UpdateNum)
-		c.Htlcs = delta.Htlcs
-
-		// First we'll write out the current latest dynamic channel
-		// state: the current channel balance, the number of updates,
-		// and our latest commitment transaction+sig.
-		// TODO(roasbeef): re-make schema s.t this is a single put
-		if err := putChanCapacity(chanBucket, c); err != nil {
-			return err
-		}
-		if err := putChanNumUpdates(chanBucket, c); err != nil {
+		err := putChanShortID(chanBucket, b.Bytes(), shortChanID)
+		if err != nil {
 			return err
 		}
-		if err := putChanCommitTxns(nodeChanBucket, c); err != nil {
+		if err := putShortChanIDIndex(tx, shortChanID, b.Bytes()); err != nil {
 			return err
 		}
-		if err := putCurrentHtlcs(nodeChanBucket, delta.Htlcs, c.ChanID); err != nil {
-			return err
+
+		nodeChanBucket := chanBucket.Bucket(c.TheirLNID[:])
+		if nodeChanBucket == nil {
+			return ErrNoActiveChannels
 		}
+		return putChanChecksum(chanBucket, nodeChanBucket, c.ChanID)
+	})
+	if err != nil {
+		return err
+	}
 
-		return nil
+	c.ShortChanID = shortChanID
+
+	return nil
+}
+
+// putShortChanIDIndex adds an entry to the short channel ID index mapping the
+// passed short channel ID to the serialized outpoint of its channel.
+func putShortChanIDIndex(tx *bolt.Tx, shortChanID lnwire.ShortChannelID,
+	chanID []byte) error {
+
+	shortChanIDIndex, err := tx.CreateBucketIfNotExists(shortChanIDIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], shortChanID.ToUint64())
+
+	return shortChanIDIndex.Put(k[:], chanID)
+}
+
+// UpdateCommitment updates the on-disk state of our currently broadcastable
+// commitment state. This method is to be called once we have revoked our prior
+// commitment state, accepting the new state as defined by the passed
+// parameters.
+func (c *OpenChannel) UpdateCommitment(newCommitment *wire.MsgTx,
+	newSig []byte, delta *ChannelDelta) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	return c.Db.commitUpdate(func(tx *bolt.Tx) error {
+		return c.putCommitment(tx, newCommitment, newSig, delta)
 	})
 }
 
+// putCommitment writes the passed commitment state within the given
+// transaction. It may be executed once more should the transaction be rolled
+// back within a batch, so it only assigns and puts the passed values.
+func (c *OpenChannel) putCommitment(tx *bolt.Tx, newCommitment *wire.MsgTx,
+	newSig []byte, delta *ChannelDelta) error {
+
+	chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
+	if err != nil {
+		return err
+	}
+
+	id := c.TheirLNID[:]
+	nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(id)
+	if err != nil {
+		return err
+	}
+
+	// TODO(roasbeef): modify the funcs below to take values
+	// directly, otherwise need to roll back to prior state. Could
+	// also make copy above, then modify to pass in.
+	c.OurCommitTx = newCommitment
+	c.OurCommitSig = newSig
+	c.OurBalance = delta.LocalBalance
+	c.TheirBalance = delta.RemoteBalance
+	c.NumUpdates = uint64(delta.UpdateNum)
+	c.Htlcs = delta.Htlcs
+
+	// First we'll write out the current latest dynamic channel
+	// state: the current channel balance, the number of updates,
+	// and our latest commitment transaction+sig.
+	// TODO(roasbeef): re-make schema s.t this is a single put
+	if err := putChanCapacity(chanBucket, c); err != nil {
+		return err
+	}
+	if err := putChanNumUpdates(chanBucket, c); err != nil {
+		return err
+	}
+	if err := putChanCommitTxns(nodeChanBucket, c); err != nil {
+		return err
+	}
+	if err := putCurrentHtlcs(nodeChanBucket, delta.Htlcs, c.ChanID); err != nil {
+		return err
+	}
+
+	return putChanChecksum(chanBucket, nodeChanBucket, c.ChanID)
+}
+
 // HTLC is the on-disk representation of a hash time-locked contract. HTLC's
 // are contained within ChannelDeltas which encode the current state of the
 // commitment between state updates.
@@ -316,38 +468,52 @@ type ChannelDelta struct {
 // this log can be consulted in order to reconstruct the state needed to
 // rectify the situation.
 func (c *OpenChannel) AppendToRevocationLog(delta *ChannelDelta) error {
-	return c.Db.store.Update(func(tx *bolt.Tx) error {
-		chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
-		if err != nil {
-			return err
-		}
+	return c.Db.commitUpdate(func(tx *bolt.Tx) error {
+		return c.putRevocationLogEntry(tx, delta)
+	})
+}
 
-		id := c.TheirLNID[:]
-		nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(id)
-		if err != nil {
-			return err
-		}
+// putRevocationLogEntry writes the current elkrem state along with a log
+// entry for the passed delta within the given transaction. As the entry is
+// keyed by the delta's update number, executing it once more should the
+// transaction be rolled back within a batch writes the same entry.
+func (c *OpenChannel) putRevocationLogEntry(tx *bolt.Tx,
+	delta *ChannelDelta) error {
 
-		// Persist the latest elkrem state to disk as the remote peer
-		// has just added to our local elkrem receiver, and given us a
-		// new pending revocation key.
-		if err := putChanElkremState(nodeChanBucket, c); err != nil {
-			return err
-		}
+	chanBucket, err := tx.CreateBucketIfNotExists(openChannelBucket)
+	if err != nil {
+		return err
+	}
 
-		// With the current elkrem state updated, append a new log
-		// entry recording this the delta of this state transition.
-		// TODO(roasbeef): could make the deltas relative, would save
-		// space, but then tradeoff for more disk-seeks to recover the
-		// full state.
-		logKey := channelLogBucket
-		logBucket, err := nodeChanBucket.CreateBucketIfNotExists(logKey)
-		if err != nil {
-			return err
-		}
+	id := c.TheirLNID[:]
+	nodeChanBucket, err := chanBucket.CreateBucketIfNotExists(id)
+	if err != nil {
+		return err
+	}
 
-		return appendChannelLogEntry(logBucket, delta, c.ChanID)
-	})
+	// Persist the latest elkrem state to disk as the remote peer
+	// has just added to our local elkrem receiver, and given us a
+	// new pending revocation key.
+	if err := putChanElkremState(nodeChanBucket, c); err != nil {
+		return err
+	}
+
+	// With the current elkrem state updated, append a new log
+	// entry recording this the delta of this state transition.
+	// TODO(roasbeef): could make the deltas relative, would save
+	// space, but then tradeoff for more disk-seeks to recover the
+	// full state.
+	logKey := channelLogBucket
+	logBucket, err := nodeChanBucket.CreateBucketIfNotExists(logKey)
+	if err != nil {
+		return err
+	}
+
+	if err := appendChannelLogEntry(logBucket, delta, c.ChanID); err != nil {
+		return err
+	}
+
+	return putChanChecksum(chanBucket, nodeChanBucket, c.ChanID)
 }
 
 // FindPreviousState scans through the append-only log in an attempt to recover
@@ -419,6 +585,25 @@ func (c *OpenChannel) CloseChannel() error {
 			return err
 		}
 
+		// Remove the channel from the channel point and short channel
+		// ID indexes, if present.
+		chanPointIndex := tx.Bucket(chanPointIndexBucket)
+		if chanPointIndex != nil {
+			if err := chanPointIndex.Delete(outPointBytes); err != nil {
+				return err
+			}
+		}
+		shortChanIDIndex := tx.Bucket(shortChanIDIndexBucket)
+		if shortChanIDIndex != nil &&
+			c.ShortChanID != (lnwire.ShortChannelID{}) {
+
+			var k [8]byte
+			byteOrder.PutUint64(k[:], c.ShortChanID.ToUint64())
+			if err := shortChanIDIndex.Delete(k[:]); err != nil {
+				return err
+			}
+		}
+
 		// Now that the index to this channel has been deleted, purge
 		// the remaining channel meta-data from the database.
 		if err := deleteOpenChannel(chanBucket, nodeChanBucket,
@@ -439,6 +624,8 @@ type ChannelSnapshot struct {
 	RemoteID [wire.HashSize]byte
 
 	ChannelPoint *wire.OutPoint
+	ShortChanID  lnwire.ShortChannelID
+	IsPrivate    bool
 
 	Capacity      btcutil.Amount
 	LocalBalance  btcutil.Amount
@@ -461,6 +648,8 @@ func (c *OpenChannel) Snapshot() *ChannelSnapshot {
 
 	snapshot := &ChannelSnapshot{
 		ChannelPoint:          c.ChanID,
+		ShortChanID:           c.ShortChanID,
+		IsPrivate:             c.IsPrivate,
 		Capacity:              c.Capacity,
 		LocalBalance:          c.OurBalance,
 		RemoteBalance:         c.TheirBalance,
@@ -517,6 +706,38 @@ func putOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 		return err
 	}
 
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+	err := putChanShortID(openChanBucket, b.Bytes(), channel.ShortChanID)
+	if err != nil {
+		return err
+	}
+	err = putChanPrivate(openChanBucket, b.Bytes(), channel.IsPrivate)
+	if err != nil {
+		return err
+	}
+	err = putChanLease(openChanBucket, b.Bytes(), channel.IsInitiator,
+		channel.LeaseExpiry)
+	if err != nil {
+		return err
+	}
+	err = putChanPolicy(openChanBucket, b.Bytes(), channel.LocalPolicy)
+	if err != nil {
+		return err
+	}
+	err = putChanHtlcLimits(openChanBucket, b.Bytes(),
+		channel.MaxPendingAmount, channel.MaxAcceptedHtlcs)
+	if err != nil {
+		return err
+	}
+	err = putChanUpfrontShutdown(openChanBucket, b.Bytes(),
+		channel.OurUpfrontShutdown, channel.TheirUpfrontShutdown)
+	if err != nil {
+		return err
+	}
+
 	// Next, write out the fields of the channel update less frequently.
 	if err := putChannelIDs(nodeChanBucket, channel); err != nil {
 		return err
@@ -598,6 +819,27 @@ func fetchOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err = fetchChanNetFee(openChanBucket, channel); err != nil {
 		return nil, err
 	}
+	if err = fetchChanShortID(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanPrivate(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanLease(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanPolicy(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanHtlcLimits(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanUpfrontShutdown(openChanBucket, channel); err != nil {
+		return nil, err
+	}
+	if err = fetchChanUptime(openChanBucket, channel); err != nil {
+		return nil, err
+	}
 
 	return channel, nil
 }
@@ -622,6 +864,30 @@ func deleteOpenChannel(openChanBucket *bolt.Bucket, nodeChanBucket *bolt.Bucket,
 	if err := deleteChanNetFee(openChanBucket, channelID); err != nil {
 		return err
 	}
+	if err := deleteChanShortID(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanPrivate(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanLease(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanPolicy(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanHtlcLimits(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanUpfrontShutdown(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanUptime(openChanBucket, channelID); err != nil {
+		return err
+	}
+	if err := deleteChanChecksum(openChanBucket, channelID); err != nil {
+		return err
+	}
 
 	// Finally, delete all the fields directly within the node's channel
 	// bucket.
@@ -894,6 +1160,311 @@ func fetchChanNetFee(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
 	return nil
 }
 
+func putChanShortID(openChanBucket *bolt.Bucket, chanID []byte,
+	shortChanID lnwire.ShortChannelID) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, shortChanIDPrefix)
+	copy(keyPrefix[3:], chanID)
+
+	scratch := make([]byte, 8)
+	byteOrder.PutUint64(scratch, shortChanID.ToUint64())
+	return openChanBucket.Put(keyPrefix, scratch)
+}
+
+func deleteChanShortID(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, shortChanIDPrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanShortID(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, shortChanIDPrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before short channel ID's were tracked won't have
+	// this field present.
+	idBytes := openChanBucket.Get(keyPrefix)
+	if idBytes == nil {
+		return nil
+	}
+	channel.ShortChanID = lnwire.NewShortChanIDFromInt(
+		byteOrder.Uint64(idBytes),
+	)
+
+	return nil
+}
+
+func putChanPrivate(openChanBucket *bolt.Bucket, chanID []byte,
+	isPrivate bool) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanPrivatePrefix)
+	copy(keyPrefix[3:], chanID)
+
+	var private [1]byte
+	if isPrivate {
+		private[0] = 1
+	}
+	return openChanBucket.Put(keyPrefix, private[:])
+}
+
+func deleteChanPrivate(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanPrivatePrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanPrivate(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, chanPrivatePrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before private channels were supported won't have
+	// this field present, and are all public.
+	private := openChanBucket.Get(keyPrefix)
+	channel.IsPrivate = len(private) == 1 && private[0] == 1
+
+	return nil
+}
+
+func putChanLease(openChanBucket *bolt.Bucket, chanID []byte,
+	isInitiator bool, leaseExpiry uint32) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanLeasePrefix)
+	copy(keyPrefix[3:], chanID)
+
+	var lease [5]byte
+	if isInitiator {
+		lease[0] = 1
+	}
+	byteOrder.PutUint32(lease[1:], leaseExpiry)
+	return openChanBucket.Put(keyPrefix, lease[:])
+}
+
+func deleteChanLease(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanLeasePrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanLease(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, chanLeasePrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before leased channels were supported won't have
+	// this field present, and are never leased.
+	lease := openChanBucket.Get(keyPrefix)
+	if len(lease) != 5 {
+		return nil
+	}
+	channel.IsInitiator = lease[0] == 1
+	channel.LeaseExpiry = byteOrder.Uint32(lease[1:])
+
+	return nil
+}
+
+func putChanPolicy(openChanBucket *bolt.Bucket, chanID []byte,
+	policy *ChannelEdgePolicy) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanPolicyPrefix)
+	copy(keyPrefix[3:], chanID)
+
+	var b bytes.Buffer
+	if err := serializeEdgePolicy(&b, policy); err != nil {
+		return err
+	}
+	return openChanBucket.Put(keyPrefix, b.Bytes())
+}
+
+func deleteChanPolicy(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanPolicyPrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanPolicy(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, chanPolicyPrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before per-channel policies were supported won't
+	// have this field present, and use the default policy.
+	policyBytes := openChanBucket.Get(keyPrefix)
+	if policyBytes == nil {
+		return nil
+	}
+
+	policy, err := deserializeEdgePolicy(bytes.NewReader(policyBytes))
+	if err != nil {
+		return err
+	}
+	channel.LocalPolicy = policy
+
+	return nil
+}
+
+func putChanHtlcLimits(openChanBucket *bolt.Bucket, chanID []byte,
+	maxPendingAmt btcutil.Amount, maxAcceptedHtlcs uint16) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanHtlcLimitsPrefix)
+	copy(keyPrefix[3:], chanID)
+
+	var limits [10]byte
+	byteOrder.PutUint64(limits[:8], uint64(maxPendingAmt))
+	byteOrder.PutUint16(limits[8:], maxAcceptedHtlcs)
+	return openChanBucket.Put(keyPrefix, limits[:])
+}
+
+func deleteChanHtlcLimits(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanHtlcLimitsPrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanHtlcLimits(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, chanHtlcLimitsPrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before HTLC limits were configurable won't have
+	// this field present, and use the default limits.
+	limits := openChanBucket.Get(keyPrefix)
+	if len(limits) != 10 {
+		return nil
+	}
+	channel.MaxPendingAmount = btcutil.Amount(byteOrder.Uint64(limits[:8]))
+	channel.MaxAcceptedHtlcs = byteOrder.Uint16(limits[8:])
+
+	return nil
+}
+
+func putChanUpfrontShutdown(openChanBucket *bolt.Bucket, chanID []byte,
+	ours, theirs bool) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanUpfrontPrefix)
+	copy(keyPrefix[3:], chanID)
+
+	var upfront [2]byte
+	if ours {
+		upfront[0] = 1
+	}
+	if theirs {
+		upfront[1] = 1
+	}
+	return openChanBucket.Put(keyPrefix, upfront[:])
+}
+
+func deleteChanUpfrontShutdown(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanUpfrontPrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func fetchChanUpfrontShutdown(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	keyPrefix := make([]byte, 3+b.Len())
+	copy(keyPrefix, chanUpfrontPrefix)
+	copy(keyPrefix[3:], b.Bytes())
+
+	// Channels created before upfront shutdown scripts were supported
+	// won't have this field present, and committed to neither script.
+	upfront := openChanBucket.Get(keyPrefix)
+	if len(upfront) != 2 {
+		return nil
+	}
+	channel.OurUpfrontShutdown = upfront[0] == 1
+	channel.TheirUpfrontShutdown = upfront[1] == 1
+
+	return nil
+}
+
+func putChanUptime(openChanBucket *bolt.Bucket, chanID []byte,
+	uptime time.Duration) error {
+
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanUptimePrefix)
+	copy(keyPrefix[3:], chanID)
+
+	scratch := make([]byte, 8)
+	byteOrder.PutUint64(scratch, uint64(uptime))
+	return openChanBucket.Put(keyPrefix, scratch)
+}
+
+func deleteChanUptime(openChanBucket *bolt.Bucket, chanID []byte) error {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanUptimePrefix)
+	copy(keyPrefix[3:], chanID)
+	return openChanBucket.Delete(keyPrefix)
+}
+
+func readChanUptime(openChanBucket *bolt.Bucket, chanID []byte) time.Duration {
+	keyPrefix := make([]byte, 3+len(chanID))
+	copy(keyPrefix, chanUptimePrefix)
+	copy(keyPrefix[3:], chanID)
+
+	// No uptime is recorded until the remote peer has been online for
+	// the first time while the channel is open.
+	uptimeBytes := openChanBucket.Get(keyPrefix)
+	if uptimeBytes == nil {
+		return 0
+	}
+
+	return time.Duration(byteOrder.Uint64(uptimeBytes))
+}
+
+func fetchChanUptime(openChanBucket *bolt.Bucket, channel *OpenChannel) error {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, channel.ChanID); err != nil {
+		return err
+	}
+
+	channel.Uptime = readChanUptime(openChanBucket, b.Bytes())
+
+	return nil
+}
+
 func putChannelIDs(nodeChanBucket *bolt.Bucket, channel *OpenChannel) error {
 	// TODO(roabeef): just pass in chanID everywhere for puts
 	var b bytes.Buffer
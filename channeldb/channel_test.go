@@ -2,14 +2,18 @@ package channeldb
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/boltdb/bolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/txscript"
@@ -186,6 +190,19 @@ func TestOpenChannelPutGetDelete(t *testing.T) {
 			RevocationDelay: 2,
 		},
 	}
+	state.IsPrivate = true
+	state.IsInitiator = true
+	state.LeaseExpiry = 1000
+	state.MaxPendingAmount = 5000
+	state.MaxAcceptedHtlcs = 30
+	state.TheirUpfrontShutdown = true
+	state.LocalPolicy = &ChannelEdgePolicy{
+		TimeLockDelta: 40,
+		MinHTLC:       1,
+		FeeBase:       1000,
+		FeeRate:       100,
+		LastUpdate:    time.Unix(1478000000, 0),
+	}
 	if err := state.FullSync(); err != nil {
 		t.Fatalf("unable to save and serialize channel state: %v", err)
 	}
@@ -209,6 +226,34 @@ func TestOpenChannelPutGetDelete(t *testing.T) {
 	if state.MinFeePerKb != newState.MinFeePerKb {
 		t.Fatalf("fee/kb doens't match")
 	}
+	if state.IsPrivate != newState.IsPrivate {
+		t.Fatalf("private flag doesn't match")
+	}
+	if state.IsInitiator != newState.IsInitiator {
+		t.Fatalf("initiator flag doesn't match")
+	}
+	if state.LeaseExpiry != newState.LeaseExpiry {
+		t.Fatalf("lease expiry doesn't match: %v vs %v",
+			state.LeaseExpiry, newState.LeaseExpiry)
+	}
+	if state.MaxPendingAmount != newState.MaxPendingAmount {
+		t.Fatalf("max pending amount doesn't match: %v vs %v",
+			state.MaxPendingAmount, newState.MaxPendingAmount)
+	}
+	if state.MaxAcceptedHtlcs != newState.MaxAcceptedHtlcs {
+		t.Fatalf("max accepted htlcs doesn't match: %v vs %v",
+			state.MaxAcceptedHtlcs, newState.MaxAcceptedHtlcs)
+	}
+	if state.OurUpfrontShutdown != newState.OurUpfrontShutdown ||
+		state.TheirUpfrontShutdown != newState.TheirUpfrontShutdown {
+
+		t.Fatalf("upfront shutdown flags don't match")
+	}
+	if !reflect.DeepEqual(state.LocalPolicy, newState.LocalPolicy) {
+		t.Fatalf("local policy doesn't match: %v vs %v",
+			spew.Sdump(state.LocalPolicy),
+			spew.Sdump(newState.LocalPolicy))
+	}
 
 	if !bytes.Equal(state.OurCommitKey.SerializeCompressed(),
 		newState.OurCommitKey.SerializeCompressed()) {
@@ -478,3 +523,350 @@ func TestChannelStateTransition(t *testing.T) {
 		t.Fatalf("revocation state wasn't synced!")
 	}
 }
+
+func TestFetchChannelIndexes(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// The channel should be retrievable by its channel point, though not
+	// yet by a short channel ID as it hasn't been confirmed.
+	channel, err := cdb.FetchChannel(state.ChanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel by channel point: %v", err)
+	}
+	if !reflect.DeepEqual(channel.ChanID, state.ChanID) {
+		t.Fatalf("chan id's don't match: expected %v, got %v",
+			state.ChanID, channel.ChanID)
+	}
+
+	shortChanID := lnwire.ShortChannelID{
+		BlockHeight: 1000,
+		TxIndex:     22,
+		TxPosition:  1,
+	}
+	if _, err := cdb.FetchChannelByShortID(shortChanID); err != ErrChannelNoExist {
+		t.Fatalf("expected ErrChannelNoExist, instead got: %v", err)
+	}
+
+	// Once the short channel ID has been set, the channel should be
+	// retrievable by it, with the short channel ID itself persisted.
+	if err := state.SetShortChanID(shortChanID); err != nil {
+		t.Fatalf("unable to set short chan id: %v", err)
+	}
+	channel, err = cdb.FetchChannelByShortID(shortChanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel by short chan id: %v", err)
+	}
+	if !reflect.DeepEqual(channel.ChanID, state.ChanID) {
+		t.Fatalf("chan id's don't match: expected %v, got %v",
+			state.ChanID, channel.ChanID)
+	}
+	if channel.ShortChanID != shortChanID {
+		t.Fatalf("short chan id's don't match: expected %v, got %v",
+			shortChanID, channel.ShortChanID)
+	}
+
+	// After the channel is closed, it should no longer be found within
+	// either index.
+	if err := state.CloseChannel(); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+	if _, err := cdb.FetchChannel(state.ChanID); err != ErrChannelNoExist {
+		t.Fatalf("expected ErrChannelNoExist, instead got: %v", err)
+	}
+	if _, err := cdb.FetchChannelByShortID(shortChanID); err != ErrChannelNoExist {
+		t.Fatalf("expected ErrChannelNoExist, instead got: %v", err)
+	}
+}
+
+func TestChannelUptime(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+
+	// Recording uptime for a channel which hasn't yet been written to
+	// disk should fail.
+	err = cdb.AddChannelUptime(state.ChanID, time.Minute)
+	if err != ErrChannelNoExist {
+		t.Fatalf("expected ErrChannelNoExist, instead got: %v", err)
+	}
+
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Each recorded duration should be added to the channel's running
+	// uptime total.
+	if err := cdb.AddChannelUptime(state.ChanID, time.Minute); err != nil {
+		t.Fatalf("unable to add channel uptime: %v", err)
+	}
+	if err := cdb.AddChannelUptime(state.ChanID, time.Hour); err != nil {
+		t.Fatalf("unable to add channel uptime: %v", err)
+	}
+
+	// A full sync of the channel state shouldn't reset the recorded
+	// uptime.
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	channel, err := cdb.FetchChannel(state.ChanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel: %v", err)
+	}
+	if channel.Uptime != time.Hour+time.Minute {
+		t.Fatalf("uptime doesn't match: expected %v, got %v",
+			time.Hour+time.Minute, channel.Uptime)
+	}
+}
+
+func TestConcurrentChannelUpdates(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// Create a set of channels, each with a distinct channel point.
+	const numChannels = 20
+	channels := make([]*OpenChannel, numChannels)
+	for i := 0; i < numChannels; i++ {
+		state, err := createTestChannelState(cdb)
+		if err != nil {
+			t.Fatalf("unable to create channel state: %v", err)
+		}
+		state.ChanID = &wire.OutPoint{
+			Hash:  id.Hash,
+			Index: uint32(i),
+		}
+		if err := state.FullSync(); err != nil {
+			t.Fatalf("unable to save and serialize channel "+
+				"state: %v", err)
+		}
+
+		channels[i] = state
+	}
+
+	// Update the commitment state of each channel concurrently, so the
+	// updates are written together within batched transactions.
+	var wg sync.WaitGroup
+	errs := make(chan error, numChannels*2)
+	for i, channel := range channels {
+		wg.Add(1)
+		go func(i int, channel *OpenChannel) {
+			defer wg.Done()
+
+			delta := &ChannelDelta{
+				LocalBalance:  btcutil.Amount(1000 + i),
+				RemoteBalance: btcutil.Amount(2000 + i),
+				UpdateNum:     uint32(i + 1),
+			}
+			newSig := bytes.Repeat([]byte{byte(i)}, 71)
+			newTx := channel.OurCommitTx.Copy()
+			err := channel.UpdateCommitment(newTx, newSig, delta)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if err := channel.AppendToRevocationLog(delta); err != nil {
+				errs <- err
+			}
+		}(i, channel)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("unable to update channel state: %v", err)
+	}
+
+	// Each channel should have had its own state persisted, without any
+	// of the concurrent updates clobbering one another.
+	for i, state := range channels {
+		channel, err := cdb.FetchChannel(state.ChanID)
+		if err != nil {
+			t.Fatalf("unable to fetch channel: %v", err)
+		}
+		if channel.OurBalance != btcutil.Amount(1000+i) {
+			t.Fatalf("local balance doesn't match: expected %v, "+
+				"got %v", 1000+i, channel.OurBalance)
+		}
+		if channel.TheirBalance != btcutil.Amount(2000+i) {
+			t.Fatalf("remote balance doesn't match: expected %v, "+
+				"got %v", 2000+i, channel.TheirBalance)
+		}
+		if channel.NumUpdates != uint64(i+1) {
+			t.Fatalf("num updates doesn't match: expected %v, "+
+				"got %v", i+1, channel.NumUpdates)
+		}
+		if !bytes.Equal(channel.OurCommitSig,
+			bytes.Repeat([]byte{byte(i)}, 71)) {
+			t.Fatalf("commitment sig doesn't match")
+		}
+
+		delta, err := state.FindPreviousState(uint64(i + 1))
+		if err != nil {
+			t.Fatalf("unable to fetch past delta: %v", err)
+		}
+		if delta.LocalBalance != btcutil.Amount(1000+i) {
+			t.Fatalf("logged local balance doesn't match")
+		}
+	}
+}
+
+func TestCommitUpdateWithoutBatch(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := channel.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Were a lone update to wait for others to join its batch, it would
+	// now be held back for an hour.
+	cdb.store.MaxBatchDelay = time.Hour
+
+	delta := &ChannelDelta{
+		LocalBalance:  btcutil.Amount(1000),
+		RemoteBalance: btcutil.Amount(2000),
+		UpdateNum:     1,
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := channel.UpdateCommitment(channel.OurCommitTx.Copy(),
+			bytes.Repeat([]byte{1}, 71), delta)
+		if err != nil {
+			done <- err
+			return
+		}
+		done <- channel.AppendToRevocationLog(delta)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unable to update channel state: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("lone commitment update waited for a batch")
+	}
+}
+
+func TestCommitUpdateRetry(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+
+	// Write the new state within a batch which fails the first time
+	// around, as it would were another update within the batch to fail.
+	// The batch is rolled back, and the writes are executed once more.
+	delta := &ChannelDelta{
+		LocalBalance:  btcutil.Amount(1000),
+		RemoteBalance: btcutil.Amount(2000),
+		UpdateNum:     1,
+	}
+	newSig := bytes.Repeat([]byte{1}, 71)
+	newTx := state.OurCommitTx.Copy()
+	var attempts int
+	err = cdb.store.Batch(func(tx *bolt.Tx) error {
+		attempts++
+
+		err := state.putCommitment(tx, newTx, newSig, delta)
+		if err != nil {
+			return err
+		}
+		if err := state.putRevocationLogEntry(tx, delta); err != nil {
+			return err
+		}
+
+		if attempts == 1 {
+			return fmt.Errorf("batch failed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update channel state: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %v", attempts)
+	}
+
+	// The state written by the second attempt should match that of a
+	// single write.
+	channel, err := cdb.FetchChannel(state.ChanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel: %v", err)
+	}
+	if channel.OurBalance != delta.LocalBalance ||
+		channel.TheirBalance != delta.RemoteBalance {
+
+		t.Fatalf("balances don't match: expected %v/%v, got %v/%v",
+			delta.LocalBalance, delta.RemoteBalance,
+			channel.OurBalance, channel.TheirBalance)
+	}
+	if channel.NumUpdates != 1 {
+		t.Fatalf("expected 1 update, got %v", channel.NumUpdates)
+	}
+	if !bytes.Equal(channel.OurCommitSig, newSig) {
+		t.Fatalf("commitment sig doesn't match")
+	}
+
+	var numEntries int
+	err = cdb.store.View(func(tx *bolt.Tx) error {
+		nodeChanBucket := tx.Bucket(openChannelBucket).Bucket(
+			state.TheirLNID[:])
+		return nodeChanBucket.Bucket(channelLogBucket).ForEach(
+			func(_, _ []byte) error {
+				numEntries++
+				return nil
+			})
+	})
+	if err != nil {
+		t.Fatalf("unable to read revocation log: %v", err)
+	}
+	if numEntries != 1 {
+		t.Fatalf("expected 1 log entry, got %v", numEntries)
+	}
+	logged, err := state.FindPreviousState(1)
+	if err != nil {
+		t.Fatalf("unable to fetch past delta: %v", err)
+	}
+	if logged.LocalBalance != delta.LocalBalance {
+		t.Fatalf("logged local balance doesn't match")
+	}
+}
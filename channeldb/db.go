@@ -7,14 +7,22 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/wire"
 )
 
 const (
 	dbName = "channel.db"
+
+	// commitBatchDelay is the longest a commitment state update made
+	// while others are being written waits for further updates to join
+	// its database transaction, sharing a single fsync.
+	commitBatchDelay = 2 * time.Millisecond
 )
 
 var (
@@ -31,15 +39,44 @@ var bufPool = &sync.Pool{
 // information related to nodes, routing data, open/closed channels, fee
 // schedules, and reputation data.
 type DB struct {
-	store *bolt.DB
+	// pendingCommitWrites is the number of commitment state updates
+	// currently being written. It must be accessed atomically.
+	pendingCommitWrites int32
+
+	store *boltStore
 
 	netParams *chaincfg.Params
 }
 
+// boltStore is the bolt database backing the channeldb, which bounds the
+// number of read transactions open at once.
+type boltStore struct {
+	*bolt.DB
+
+	// readSlots, if non-nil, holds an entry for each open read
+	// transaction, blocking new ones once full.
+	readSlots chan struct{}
+}
+
+// View executes the passed function within a read-only transaction, waiting
+// for one of the read slots to be freed if all of them are taken.
+func (s *boltStore) View(fn func(*bolt.Tx) error) error {
+	if s.readSlots != nil {
+		s.readSlots <- struct{}{}
+		defer func() { <-s.readSlots }()
+	}
+
+	return s.DB.View(fn)
+}
+
 // Open opens an existing channeldb created under the passed namespace with
 // sensitive data encrypted by the passed EncryptorDecryptor implementation.
+// The passed modifiers set the options bounding its memory usage.
 // TODO(roasbeef): versioning?
-func Open(dbPath string, netParams *chaincfg.Params) (*DB, error) {
+func Open(dbPath string, netParams *chaincfg.Params,
+	modifiers ...OptionModifier) (*DB, error) {
+
+	opts := applyOptions(modifiers)
 	path := filepath.Join(dbPath, dbName)
 
 	if !fileExists(path) {
@@ -53,7 +90,79 @@ func Open(dbPath string, netParams *chaincfg.Params) (*DB, error) {
 		return nil, err
 	}
 
-	return &DB{store: bdb, netParams: netParams}, nil
+	bdb.MaxBatchDelay = commitBatchDelay
+	if opts.AllocSize != 0 {
+		bdb.AllocSize = opts.AllocSize
+	}
+
+	store := &boltStore{DB: bdb}
+	if opts.MaxConcurrentReads != 0 {
+		store.readSlots = make(chan struct{}, opts.MaxConcurrentReads)
+	}
+	d := &DB{store: store, netParams: netParams}
+
+	// Ensure that any channels created before the channel point index
+	// existed are present within the index.
+	if err := d.syncChanPointIndex(); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// commitUpdate writes a commitment state update within a read-write
+// transaction. An update made while no other is being written gets a
+// transaction of its own at once, leaving the latency of a single channel's
+// state transition unchanged. Updates made concurrently with others are
+// coalesced within a batch, sharing a single fsync. As a batched function is
+// executed once more should its batch be rolled back, fn must be idempotent.
+func (d *DB) commitUpdate(fn func(tx *bolt.Tx) error) error {
+	defer atomic.AddInt32(&d.pendingCommitWrites, -1)
+	if atomic.AddInt32(&d.pendingCommitWrites, 1) == 1 {
+		return d.store.Update(fn)
+	}
+
+	return d.store.Batch(fn)
+}
+
+// syncChanPointIndex adds an entry to the channel point index for each open
+// channel which isn't already indexed.
+func (d *DB) syncChanPointIndex() error {
+	return d.store.Update(func(tx *bolt.Tx) error {
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return nil
+		}
+
+		chanPointIndex, err := tx.CreateBucketIfNotExists(chanPointIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		return openChanBucket.ForEach(func(nodeID, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			nodeChanBucket := openChanBucket.Bucket(nodeID)
+			if nodeChanBucket == nil {
+				return nil
+			}
+			nodeChanIDBucket := nodeChanBucket.Bucket(chanIDBucket)
+			if nodeChanIDBucket == nil {
+				return nil
+			}
+
+			return nodeChanIDBucket.ForEach(func(chanID, _ []byte) error {
+				if chanPointIndex.Get(chanID) != nil {
+					return nil
+				}
+
+				return chanPointIndex.Put(chanID, nodeID)
+			})
+		})
+	})
 }
 
 // Wipe completely deletes all saved state within all used buckets within the
@@ -71,6 +180,16 @@ func (d *DB) Wipe() error {
 			return err
 		}
 
+		err = tx.DeleteBucket(chanPointIndexBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		err = tx.DeleteBucket(shortChanIDIndexBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -151,39 +270,212 @@ func (d *DB) FetchOpenChannels(nodeID *wire.ShaHash) ([]*OpenChannel, error) {
 			return nil
 		}
 
-		// Once we have the node's channel bucket, iterate through each
-		// item in the inner chan ID bucket. This bucket acts as an
-		// index for all channels we currently have open with this node.
-		nodeChanIDBucket := nodeChanBucket.Bucket(chanIDBucket[:])
-		if nodeChanIDBucket == nil {
+		nodeChannels, err := d.fetchNodeChannels(openChanBucket,
+			nodeChanBucket)
+		if err != nil {
+			return err
+		}
+
+		channels = nodeChannels
+		return nil
+	})
+
+	return channels, err
+}
+
+// fetchNodeChannels retrieves all active channels from the target
+// nodeChanBucket. This function is typically used to fetch all the active
+// channels related to a particular node.
+func (d *DB) fetchNodeChannels(openChanBucket,
+	nodeChanBucket *bolt.Bucket) ([]*OpenChannel, error) {
+
+	var channels []*OpenChannel
+
+	// Once we have the node's channel bucket, iterate through each item in
+	// the inner chan ID bucket. This bucket acts as an index for all
+	// channels we currently have open with this node.
+	nodeChanIDBucket := nodeChanBucket.Bucket(chanIDBucket[:])
+	if nodeChanIDBucket == nil {
+		return nil, nil
+	}
+	err := nodeChanIDBucket.ForEach(func(k, v []byte) error {
+		if k == nil {
 			return nil
 		}
-		err := nodeChanIDBucket.ForEach(func(k, v []byte) error {
-			if k == nil {
+
+		outBytes := bytes.NewReader(k)
+		chanID := &wire.OutPoint{}
+		if err := readOutpoint(outBytes, chanID); err != nil {
+			return err
+		}
+
+		oChannel, err := fetchOpenChannel(openChanBucket,
+			nodeChanBucket, chanID)
+		if err != nil {
+			return err
+		}
+		oChannel.Db = d
+
+		channels = append(channels, oChannel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// FetchChannel returns the open channel identified by the passed channel
+// point. The channel point index is consulted to locate the node the channel
+// is with, so no scan over all open channels is required. If the channel
+// isn't found, then ErrChannelNoExist is returned.
+func (d *DB) FetchChannel(chanPoint *wire.OutPoint) (*OpenChannel, error) {
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return nil, err
+	}
+
+	var channel *OpenChannel
+	err := d.store.View(func(tx *bolt.Tx) error {
+		var err error
+		channel, err = d.fetchIndexedChannel(tx, b.Bytes())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// AddChannelUptime adds the passed duration to the total time the remote peer
+// of the target channel has been connected to us while the channel has been
+// open. If the channel isn't found, then ErrChannelNoExist is returned.
+func (d *DB) AddChannelUptime(chanPoint *wire.OutPoint,
+	online time.Duration) error {
+
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+	chanID := b.Bytes()
+
+	return d.store.Update(func(tx *bolt.Tx) error {
+		chanPointIndex := tx.Bucket(chanPointIndexBucket)
+		if chanPointIndex == nil || chanPointIndex.Get(chanID) == nil {
+			return ErrChannelNoExist
+		}
+
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		uptime := readChanUptime(openChanBucket, chanID)
+		return putChanUptime(openChanBucket, chanID, uptime+online)
+	})
+}
+
+// FetchChannelByShortID returns the open channel identified by the passed
+// short channel ID. If no confirmed channel with the short channel ID is
+// found, then ErrChannelNoExist is returned.
+func (d *DB) FetchChannelByShortID(shortChanID lnwire.ShortChannelID) (*OpenChannel, error) {
+	var k [8]byte
+	byteOrder.PutUint64(k[:], shortChanID.ToUint64())
+
+	var channel *OpenChannel
+	err := d.store.View(func(tx *bolt.Tx) error {
+		shortChanIDIndex := tx.Bucket(shortChanIDIndexBucket)
+		if shortChanIDIndex == nil {
+			return ErrChannelNoExist
+		}
+
+		chanID := shortChanIDIndex.Get(k[:])
+		if chanID == nil {
+			return ErrChannelNoExist
+		}
+
+		var err error
+		channel, err = d.fetchIndexedChannel(tx, chanID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// fetchIndexedChannel reads the open channel with the passed serialized
+// channel point from disk, using the channel point index to locate the
+// bucket of the node the channel is with.
+func (d *DB) fetchIndexedChannel(tx *bolt.Tx, chanID []byte) (*OpenChannel, error) {
+	chanPointIndex := tx.Bucket(chanPointIndexBucket)
+	if chanPointIndex == nil {
+		return nil, ErrChannelNoExist
+	}
+	nodeID := chanPointIndex.Get(chanID)
+	if nodeID == nil {
+		return nil, ErrChannelNoExist
+	}
+
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return nil, ErrNoActiveChannels
+	}
+	nodeChanBucket := openChanBucket.Bucket(nodeID)
+	if nodeChanBucket == nil {
+		return nil, ErrChannelNoExist
+	}
+
+	chanPoint := &wire.OutPoint{}
+	if err := readOutpoint(bytes.NewReader(chanID), chanPoint); err != nil {
+		return nil, err
+	}
+
+	channel, err := fetchOpenChannel(openChanBucket, nodeChanBucket,
+		chanPoint)
+	if err != nil {
+		return nil, err
+	}
+	channel.Db = d
+
+	return channel, nil
+}
+
+// FetchAllChannels attempts to retrieve all open channels currently stored
+// within the database, across all nodes.
+func (d *DB) FetchAllChannels() ([]*OpenChannel, error) {
+	var channels []*OpenChannel
+	err := d.store.View(func(tx *bolt.Tx) error {
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return ErrNoActiveChannels
+		}
+
+		// Each nested bucket within the open channel bucket is keyed
+		// by a node's ID. The prefixed keys stored at the top level
+		// have non-nil values, so they're skipped.
+		return openChanBucket.ForEach(func(nodeID, v []byte) error {
+			if v != nil {
 				return nil
 			}
 
-			outBytes := bytes.NewReader(k)
-			chanID := &wire.OutPoint{}
-			if err := readOutpoint(outBytes, chanID); err != nil {
-				return err
+			nodeChanBucket := openChanBucket.Bucket(nodeID)
+			if nodeChanBucket == nil {
+				return nil
 			}
 
-			oChannel, err := fetchOpenChannel(openChanBucket,
-				nodeChanBucket, chanID)
+			nodeChannels, err := d.fetchNodeChannels(openChanBucket,
+				nodeChanBucket)
 			if err != nil {
 				return err
 			}
-			oChannel.Db = d
 
-			channels = append(channels, oChannel)
+			channels = append(channels, nodeChannels...)
 			return nil
 		})
-		if err != nil {
-			return err
-		}
-
-		return nil
 	})
 
 	return channels, err
@@ -5,6 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
 )
 
 func TestOpenWithCreate(t *testing.T) {
@@ -31,3 +34,55 @@ func TestOpenWithCreate(t *testing.T) {
 		t.Fatalf("channeldb failed to create data directory")
 	}
 }
+
+func TestOpenOptions(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	cdb, err := Open(tempDirName, netParams, OptionAllocSize(1<<20),
+		OptionMaxConcurrentReads(1))
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer cdb.Close()
+
+	if cdb.store.AllocSize != 1<<20 {
+		t.Fatalf("expected alloc size %v, got %v", 1<<20,
+			cdb.store.AllocSize)
+	}
+
+	// While the only read slot is taken, a new read transaction must wait
+	// for it to be freed.
+	release := make(chan struct{})
+	opened := make(chan struct{})
+	go cdb.store.View(func(tx *bolt.Tx) error {
+		close(opened)
+		<-release
+		return nil
+	})
+	<-opened
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := cdb.FetchAllChannels()
+		done <- err
+	}()
+	select {
+	case <-done:
+		t.Fatalf("read transaction opened beyond the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unable to fetch channels: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("read transaction not opened once slot freed")
+	}
+}
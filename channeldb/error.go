@@ -11,4 +11,16 @@ var (
 
 	ErrInvoiceNotFound  = fmt.Errorf("unable to locate invoice")
 	ErrDuplicateInvoice = fmt.Errorf("invoice with payment hash already exists")
+
+	ErrPaymentNotFound    = fmt.Errorf("unable to locate payment")
+	ErrPaymentInFlight    = fmt.Errorf("payment with payment hash is already in flight")
+	ErrPaymentNotInFlight = fmt.Errorf("payment is not in flight")
+	ErrAttemptNotFound    = fmt.Errorf("unable to locate payment attempt")
+
+	ErrArbitrationLogNotFound = fmt.Errorf("unable to locate arbitration log")
+	ErrPreimageNotFound       = fmt.Errorf("unable to locate preimage")
+
+	ErrGraphNotFound     = fmt.Errorf("graph bucket not initialized")
+	ErrGraphNodeNotFound = fmt.Errorf("unable to find node")
+	ErrEdgeNotFound      = fmt.Errorf("edge for chanID not found")
 )
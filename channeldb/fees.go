@@ -1 +1,169 @@
 package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// forwardingLogBucket is the name of the bucket within the database
+	// which stores a record of each HTLC we've forwarded. Within the
+	// bucket, each event is keyed by its timestamp in nanoseconds,
+	// followed by a sequence number which disambiguates events sharing a
+	// timestamp. Events are therefore stored in chronological order.
+	forwardingLogBucket = []byte("fwd-log")
+)
+
+// ForwardingEvent is a record of an HTLC which was forwarded from one of our
+// channels to another. The difference between the incoming and outgoing
+// amounts is the fee we earned for the forward.
+type ForwardingEvent struct {
+	// Timestamp is the time the forwarded HTLC was settled.
+	Timestamp time.Time
+
+	// IncomingChan is the channel the HTLC was offered to us over.
+	IncomingChan wire.OutPoint
+
+	// OutgoingChan is the channel we forwarded the HTLC over.
+	OutgoingChan wire.OutPoint
+
+	// AmtIn is the value of the incoming HTLC.
+	AmtIn btcutil.Amount
+
+	// AmtOut is the value of the outgoing HTLC.
+	AmtOut btcutil.Amount
+}
+
+// Fee returns the fee earned for forwarding the HTLC.
+func (f *ForwardingEvent) Fee() btcutil.Amount {
+	return f.AmtIn - f.AmtOut
+}
+
+// AddForwardingEvents appends the passed events to the forwarding log.
+func (d *DB) AddForwardingEvents(events []*ForwardingEvent) error {
+	return d.store.Update(func(tx *bolt.Tx) error {
+		fwdLog, err := tx.CreateBucketIfNotExists(forwardingLogBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			seqNo, err := fwdLog.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			var key [16]byte
+			byteOrder.PutUint64(key[:8],
+				uint64(event.Timestamp.UnixNano()))
+			byteOrder.PutUint64(key[8:], seqNo)
+
+			var b bytes.Buffer
+			if err := serializeForwardingEvent(&b, event); err != nil {
+				return err
+			}
+
+			if err := fwdLog.Put(key[:], b.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchForwardingEvents returns every event within the forwarding log with a
+// timestamp in the range [start, end), ordered from oldest to newest.
+func (d *DB) FetchForwardingEvents(start,
+	end time.Time) ([]*ForwardingEvent, error) {
+
+	var events []*ForwardingEvent
+	err := d.store.View(func(tx *bolt.Tx) error {
+		fwdLog := tx.Bucket(forwardingLogBucket)
+		if fwdLog == nil {
+			return nil
+		}
+
+		var startKey, endKey [8]byte
+		byteOrder.PutUint64(startKey[:], uint64(start.UnixNano()))
+		byteOrder.PutUint64(endKey[:], uint64(end.UnixNano()))
+
+		c := fwdLog.Cursor()
+		for k, v := c.Seek(startKey[:]); k != nil &&
+			bytes.Compare(k[:8], endKey[:]) < 0; k, v = c.Next() {
+
+			event, err := deserializeForwardingEvent(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func serializeForwardingEvent(w io.Writer, event *ForwardingEvent) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], uint64(event.Timestamp.UnixNano()))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := writeOutpoint(w, &event.IncomingChan); err != nil {
+		return err
+	}
+	if err := writeOutpoint(w, &event.OutgoingChan); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(event.AmtIn))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], uint64(event.AmtOut))
+	_, err := w.Write(scratch[:])
+
+	return err
+}
+
+func deserializeForwardingEvent(r io.Reader) (*ForwardingEvent, error) {
+	var scratch [8]byte
+	event := &ForwardingEvent{}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	event.Timestamp = time.Unix(0, int64(byteOrder.Uint64(scratch[:])))
+
+	if err := readOutpoint(r, &event.IncomingChan); err != nil {
+		return nil, err
+	}
+	if err := readOutpoint(r, &event.OutgoingChan); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	event.AmtIn = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	event.AmtOut = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	return event, nil
+}
@@ -0,0 +1,84 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+func TestForwardingLog(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	// Before any events have been added, the log should be empty.
+	now := time.Unix(time.Now().Unix(), 0)
+	events, err := db.FetchForwardingEvents(time.Unix(0, 0), now)
+	if err != nil {
+		t.Fatalf("unable to fetch forwarding events: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no forwarding events, instead have %v",
+			len(events))
+	}
+
+	// Add a series of events spaced an hour apart, two of which share a
+	// timestamp.
+	outgoingChan := wire.OutPoint{Hash: testTx.TxSha(), Index: 1}
+	var added []*ForwardingEvent
+	for i := 0; i < 5; i++ {
+		added = append(added, &ForwardingEvent{
+			Timestamp:    now.Add(time.Duration(i) * time.Hour),
+			IncomingChan: *id,
+			OutgoingChan: outgoingChan,
+			AmtIn:        btcutil.Amount(10000 + i),
+			AmtOut:       10000,
+		})
+	}
+	added = append(added, &ForwardingEvent{
+		Timestamp:    added[4].Timestamp,
+		IncomingChan: outgoingChan,
+		OutgoingChan: *id,
+		AmtIn:        5010,
+		AmtOut:       5000,
+	})
+	if err := db.AddForwardingEvents(added); err != nil {
+		t.Fatalf("unable to add forwarding events: %v", err)
+	}
+
+	// Only the events within the queried range should be returned,
+	// ordered from oldest to newest.
+	events, err = db.FetchForwardingEvents(added[1].Timestamp,
+		added[3].Timestamp)
+	if err != nil {
+		t.Fatalf("unable to fetch forwarding events: %v", err)
+	}
+	if !reflect.DeepEqual(added[1:3], events) {
+		t.Fatalf("events fetched from db don't match original %v vs %v",
+			spew.Sdump(added[1:3]), spew.Sdump(events))
+	}
+
+	events, err = db.FetchForwardingEvents(now, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unable to fetch forwarding events: %v", err)
+	}
+	if !reflect.DeepEqual(added, events) {
+		t.Fatalf("events fetched from db don't match original %v vs %v",
+			spew.Sdump(added), spew.Sdump(events))
+	}
+
+	var totalFees btcutil.Amount
+	for _, event := range events {
+		totalFees += event.Fee()
+	}
+	if totalFees != 20 {
+		t.Fatalf("expected total fees of 20, instead have %v",
+			totalFees)
+	}
+}
@@ -0,0 +1,631 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// graphDBName is the name of the database file which houses the
+	// channel graph. The graph is stored in a file distinct from the one
+	// used for channel state, as the contents of the graph are public
+	// information which can always be re-obtained from the network.
+	graphDBName = "graph.db"
+)
+
+var (
+	// nodeBucket is the top-level bucket which stores all the vertexes
+	// within the channel graph. Each node is keyed by its 32-byte
+	// lightning ID.
+	nodeBucket = []byte("graph-node")
+
+	// edgeBucket is the top-level bucket which stores all the edges within
+	// the channel graph. Each edge is keyed by the outpoint of the funding
+	// transaction for the channel it represents.
+	edgeBucket = []byte("graph-edge")
+)
+
+// ChannelGraph is a persistent, on-disk view of the channel graph. The graph
+// lives within its own database file, entirely separate from the critical
+// channel state stored within the DB. As a result, the graph can be corrupted,
+// wiped, or re-synced without ever endangering any funds held within active
+// channels.
+//
+// All writes to the graph are also applied to an in-memory cache of the graph
+// which is used to serve the reads required during path finding.
+type ChannelGraph struct {
+	store *bolt.DB
+
+	cache *graphCache
+
+	netParams *chaincfg.Params
+}
+
+// LightningNode represents a vertex within the channel graph.
+type LightningNode struct {
+	// LightningID is the sha256 of the node's identity public key.
+	LightningID wire.ShaHash
+
+	// Addresses is the set of network addresses the node is known to be
+	// reachable at.
+	Addresses []string
+
+	// Alias is a human readable name advertised by the node.
+	Alias string
+
+	// Features is the set of features advertised by the node.
+	Features *lnwire.FeatureVector
+
+	// LastUpdate is the last time the information for this node was
+	// updated within the graph.
+	LastUpdate time.Time
+}
+
+// ChannelEdge represents an open channel between two nodes within the channel
+// graph.
+type ChannelEdge struct {
+	// ChannelPoint is the outpoint of the funding transaction for this
+	// channel.
+	ChannelPoint wire.OutPoint
+
+	// Node1 and Node2 are the lightning ID's of the two endpoints of the
+	// channel.
+	Node1 wire.ShaHash
+	Node2 wire.ShaHash
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// Node1Policy and Node2Policy are the routing policies advertised by
+	// Node1 and Node2 respectively for HTLC's forwarded out over this
+	// channel. A nil policy indicates that the node hasn't yet advertised
+	// a policy for the channel.
+	Node1Policy *ChannelEdgePolicy
+	Node2Policy *ChannelEdgePolicy
+
+	// LastUpdate is the last time the information for this edge was
+	// updated within the graph.
+	LastUpdate time.Time
+}
+
+// ChannelEdgePolicy describes the conditions under which one endpoint of a
+// channel is willing to forward HTLC's out over the channel.
+type ChannelEdgePolicy struct {
+	// TimeLockDelta is the number of blocks the node subtracts from the
+	// expiry of an incoming HTLC when forwarding it over this channel.
+	TimeLockDelta uint32
+
+	// MinHTLC is the smallest HTLC the node will forward over this
+	// channel.
+	MinHTLC btcutil.Amount
+
+	// FeeBase is the fixed fee charged for each HTLC forwarded over this
+	// channel.
+	FeeBase btcutil.Amount
+
+	// FeeRate is the proportional fee charged for each HTLC forwarded
+	// over this channel, expressed in millionths of the forwarded amount.
+	FeeRate uint32
+
+	// LastUpdate is the last time the node updated this policy.
+	LastUpdate time.Time
+}
+
+// OpenGraph opens the channel graph stored within the passed directory,
+// creating it along with all required top-level buckets if it doesn't yet
+// exist. Of the options set by the passed modifiers, only AllocSize applies
+// to the graph, as reads are served from its in-memory cache.
+func OpenGraph(dbPath string, netParams *chaincfg.Params,
+	modifiers ...OptionModifier) (*ChannelGraph, error) {
+
+	opts := applyOptions(modifiers)
+	if !fileExists(dbPath) {
+		if err := os.MkdirAll(dbPath, 0700); err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(dbPath, graphDBName)
+	bdb, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.AllocSize != 0 {
+		bdb.AllocSize = opts.AllocSize
+	}
+
+	graph := &ChannelGraph{
+		store:     bdb,
+		cache:     newGraphCache(),
+		netParams: netParams,
+	}
+	if err := graph.store.Update(createGraphBuckets); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	// With the database open, load the entire graph into the in-memory
+	// cache so subsequent reads never need to touch the disk.
+	if err := graph.populateCache(); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// populateCache reads all the nodes and edges stored on disk into the graph's
+// in-memory cache.
+func (c *ChannelGraph) populateCache() error {
+	err := c.ForEachNode(func(node *LightningNode) error {
+		c.cache.addNode(node)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.ForEachChannel(func(edge *ChannelEdge) error {
+		c.cache.addEdge(edge)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Loaded %v nodes and %v edges into graph cache",
+		len(c.cache.nodes), len(c.cache.edges))
+
+	return nil
+}
+
+// createGraphBuckets creates all the top-level buckets used by the channel
+// graph if they don't already exist.
+func createGraphBuckets(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(nodeBucket); err != nil {
+		return err
+	}
+	if _, err := tx.CreateBucketIfNotExists(edgeBucket); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Close terminates the underlying database handle manually.
+func (c *ChannelGraph) Close() error {
+	return c.store.Close()
+}
+
+// Drop deletes the entire contents of the channel graph, leaving behind a
+// fresh, empty graph. The deletion is done in a single transaction, therefore
+// this operation is fully atomic. As the graph is stored separately from the
+// channel state, this never touches any information related to our own
+// channels.
+func (c *ChannelGraph) Drop() error {
+	err := c.store.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(nodeBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		err = tx.DeleteBucket(edgeBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		return createGraphBuckets(tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.cache.reset()
+
+	return nil
+}
+
+// AddLightningNode adds a new vertex to the graph, or overwrites the
+// information for the node if it's already present.
+func (c *ChannelGraph) AddLightningNode(node *LightningNode) error {
+	err := c.store.Update(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+
+		var b bytes.Buffer
+		if err := serializeLightningNode(&b, node); err != nil {
+			return err
+		}
+
+		return nodes.Put(node.LightningID[:], b.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	c.cache.addNode(node)
+
+	return nil
+}
+
+// FetchLightningNode attempts to look up the target node by its lightning ID.
+// If the node isn't found within the graph, then ErrGraphNodeNotFound is
+// returned.
+func (c *ChannelGraph) FetchLightningNode(nodeID *wire.ShaHash) (*LightningNode, error) {
+	node, ok := c.cache.fetchNode(nodeID)
+	if !ok {
+		return nil, ErrGraphNodeNotFound
+	}
+
+	return node, nil
+}
+
+// ForEachNode iterates through all the stored vertexes within the graph,
+// executing the passed callback with each node encountered. If the callback
+// returns an error, then the iteration is halted with the error propagated
+// back up to the caller.
+func (c *ChannelGraph) ForEachNode(cb func(*LightningNode) error) error {
+	return c.store.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+
+		return nodes.ForEach(func(k, v []byte) error {
+			node, err := deserializeLightningNode(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			return cb(node)
+		})
+	})
+}
+
+// AddChannelEdge adds a new edge to the graph, or overwrites the information
+// for the edge if it's already present.
+func (c *ChannelGraph) AddChannelEdge(edge *ChannelEdge) error {
+	err := c.store.Update(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNotFound
+		}
+
+		var b bytes.Buffer
+		if err := serializeChannelEdge(&b, edge); err != nil {
+			return err
+		}
+
+		var k bytes.Buffer
+		if err := writeOutpoint(&k, &edge.ChannelPoint); err != nil {
+			return err
+		}
+
+		return edges.Put(k.Bytes(), b.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	c.cache.addEdge(edge)
+
+	return nil
+}
+
+// FetchChannelEdge attempts to look up the edge identified by the passed
+// channel point. If the edge isn't found within the graph, then
+// ErrEdgeNotFound is returned.
+func (c *ChannelGraph) FetchChannelEdge(chanPoint *wire.OutPoint) (*ChannelEdge, error) {
+	edge, ok := c.cache.fetchEdge(chanPoint)
+	if !ok {
+		return nil, ErrEdgeNotFound
+	}
+
+	return edge, nil
+}
+
+// ForEachNodeChannel iterates through all the edges for which the target node
+// is one of the endpoints, executing the passed callback with each edge
+// encountered. The edges are read from the in-memory graph cache, making this
+// method suitable for use within path finding. If the callback returns an
+// error, then the iteration is halted with the error propagated back up to the
+// caller.
+func (c *ChannelGraph) ForEachNodeChannel(nodeID *wire.ShaHash,
+	cb func(*ChannelEdge) error) error {
+
+	for _, edge := range c.cache.nodeEdges(nodeID) {
+		if err := cb(edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteChannelEdge removes the edge identified by the passed channel point
+// from the graph. If the edge isn't found, then ErrEdgeNotFound is returned.
+func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, chanPoint); err != nil {
+		return err
+	}
+
+	err := c.store.Update(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNotFound
+		}
+
+		if edges.Get(k.Bytes()) == nil {
+			return ErrEdgeNotFound
+		}
+
+		return edges.Delete(k.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	c.cache.removeEdge(chanPoint)
+
+	return nil
+}
+
+// ForEachChannel iterates through all the stored edges within the graph,
+// executing the passed callback with each edge encountered. If the callback
+// returns an error, then the iteration is halted with the error propagated
+// back up to the caller.
+func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdge) error) error {
+	return c.store.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNotFound
+		}
+
+		return edges.ForEach(func(k, v []byte) error {
+			edge, err := deserializeChannelEdge(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			return cb(edge)
+		})
+	})
+}
+
+func writeTimestamp(w io.Writer, t time.Time) error {
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(t.Unix()))
+	_, err := w.Write(scratch[:])
+	return err
+}
+
+func readTimestamp(r io.Reader) (time.Time, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(int64(byteOrder.Uint64(scratch[:])), 0), nil
+}
+
+func serializeLightningNode(w io.Writer, node *LightningNode) error {
+	if _, err := w.Write(node.LightningID[:]); err != nil {
+		return err
+	}
+
+	var numAddrs [2]byte
+	byteOrder.PutUint16(numAddrs[:], uint16(len(node.Addresses)))
+	if _, err := w.Write(numAddrs[:]); err != nil {
+		return err
+	}
+	for _, addr := range node.Addresses {
+		if err := wire.WriteVarString(w, 0, addr); err != nil {
+			return err
+		}
+	}
+
+	if err := wire.WriteVarString(w, 0, node.Alias); err != nil {
+		return err
+	}
+
+	features := node.Features
+	if features == nil {
+		features = lnwire.NewFeatureVector()
+	}
+	if err := features.Encode(w); err != nil {
+		return err
+	}
+
+	return writeTimestamp(w, node.LastUpdate)
+}
+
+func deserializeLightningNode(r io.Reader) (*LightningNode, error) {
+	var err error
+	node := &LightningNode{}
+
+	if _, err := io.ReadFull(r, node.LightningID[:]); err != nil {
+		return nil, err
+	}
+
+	var numAddrs [2]byte
+	if _, err := io.ReadFull(r, numAddrs[:]); err != nil {
+		return nil, err
+	}
+	for i := uint16(0); i < byteOrder.Uint16(numAddrs[:]); i++ {
+		addr, err := wire.ReadVarString(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		node.Addresses = append(node.Addresses, addr)
+	}
+
+	node.Alias, err = wire.ReadVarString(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Features = lnwire.NewFeatureVector()
+	if err := node.Features.Decode(r); err != nil {
+		return nil, err
+	}
+
+	node.LastUpdate, err = readTimestamp(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func serializeChannelEdge(w io.Writer, edge *ChannelEdge) error {
+	if err := writeOutpoint(w, &edge.ChannelPoint); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(edge.Node1[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(edge.Node2[:]); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(edge.Capacity))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := serializeEdgePolicy(w, edge.Node1Policy); err != nil {
+		return err
+	}
+	if err := serializeEdgePolicy(w, edge.Node2Policy); err != nil {
+		return err
+	}
+
+	return writeTimestamp(w, edge.LastUpdate)
+}
+
+func deserializeChannelEdge(r io.Reader) (*ChannelEdge, error) {
+	var err error
+	edge := &ChannelEdge{}
+
+	if err := readOutpoint(r, &edge.ChannelPoint); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, edge.Node1[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, edge.Node2[:]); err != nil {
+		return nil, err
+	}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	edge.Capacity = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	edge.Node1Policy, err = deserializeEdgePolicy(r)
+	if err != nil {
+		return nil, err
+	}
+	edge.Node2Policy, err = deserializeEdgePolicy(r)
+	if err != nil {
+		return nil, err
+	}
+
+	edge.LastUpdate, err = readTimestamp(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return edge, nil
+}
+
+// serializeEdgePolicy writes the passed policy to w, prefixed by a single byte
+// indicating whether a policy is present at all.
+func serializeEdgePolicy(w io.Writer, policy *ChannelEdgePolicy) error {
+	if policy == nil {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	if _, err := w.Write([]byte{1}); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	byteOrder.PutUint32(scratch[:4], policy.TimeLockDelta)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(policy.MinHTLC))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], uint64(policy.FeeBase))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], policy.FeeRate)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	return writeTimestamp(w, policy.LastUpdate)
+}
+
+// deserializeEdgePolicy reads a policy written by serializeEdgePolicy from r.
+// If no policy was present, then nil is returned.
+func deserializeEdgePolicy(r io.Reader) (*ChannelEdgePolicy, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	if scratch[0] == 0 {
+		return nil, nil
+	}
+
+	var err error
+	policy := &ChannelEdgePolicy{}
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	policy.TimeLockDelta = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	policy.MinHTLC = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	policy.FeeBase = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	policy.FeeRate = byteOrder.Uint32(scratch[:4])
+
+	policy.LastUpdate, err = readTimestamp(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,150 @@
+package channeldb
+
+import (
+	"sync"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// graphCache is a read-optimized, in-memory view of the channel graph. The
+// cache is populated from disk once the graph is opened, and is subsequently
+// updated after each successful write to the graph. All reads required for
+// path finding are served directly from the cache, avoiding a database
+// transaction for each edge examined.
+type graphCache struct {
+	sync.RWMutex
+
+	// nodes maps a node's lightning ID to its vertex within the graph.
+	nodes map[wire.ShaHash]*LightningNode
+
+	// edges maps a channel point to its edge within the graph.
+	edges map[wire.OutPoint]*ChannelEdge
+
+	// adjacency maps a node's lightning ID to the set of all edges for
+	// which the node is one of the endpoints.
+	adjacency map[wire.ShaHash]map[wire.OutPoint]*ChannelEdge
+}
+
+// newGraphCache creates a new, empty graphCache.
+func newGraphCache() *graphCache {
+	return &graphCache{
+		nodes:     make(map[wire.ShaHash]*LightningNode),
+		edges:     make(map[wire.OutPoint]*ChannelEdge),
+		adjacency: make(map[wire.ShaHash]map[wire.OutPoint]*ChannelEdge),
+	}
+}
+
+// addNode inserts, or overwrites the passed node within the cache.
+func (g *graphCache) addNode(node *LightningNode) {
+	n := *node
+
+	g.Lock()
+	g.nodes[n.LightningID] = &n
+	g.Unlock()
+}
+
+// addEdge inserts, or overwrites the passed edge within the cache, updating
+// the adjacency list of both endpoints.
+func (g *graphCache) addEdge(edge *ChannelEdge) {
+	e := *edge
+
+	g.Lock()
+	defer g.Unlock()
+
+	// If this edge is replacing a prior version, then remove the prior
+	// version from the adjacency lists first, as the endpoints may have
+	// changed.
+	if oldEdge, ok := g.edges[e.ChannelPoint]; ok {
+		g.removeAdjacent(oldEdge)
+	}
+
+	g.edges[e.ChannelPoint] = &e
+	for _, nodeID := range []wire.ShaHash{e.Node1, e.Node2} {
+		if _, ok := g.adjacency[nodeID]; !ok {
+			g.adjacency[nodeID] = make(map[wire.OutPoint]*ChannelEdge)
+		}
+		g.adjacency[nodeID][e.ChannelPoint] = &e
+	}
+}
+
+// removeEdge removes the edge identified by the passed channel point from the
+// cache.
+func (g *graphCache) removeEdge(chanPoint *wire.OutPoint) {
+	g.Lock()
+	defer g.Unlock()
+
+	edge, ok := g.edges[*chanPoint]
+	if !ok {
+		return
+	}
+
+	g.removeAdjacent(edge)
+	delete(g.edges, *chanPoint)
+}
+
+// removeAdjacent removes the passed edge from the adjacency list of both its
+// endpoints.
+//
+// NOTE: The write lock MUST be held when calling this method.
+func (g *graphCache) removeAdjacent(edge *ChannelEdge) {
+	for _, nodeID := range []wire.ShaHash{edge.Node1, edge.Node2} {
+		delete(g.adjacency[nodeID], edge.ChannelPoint)
+		if len(g.adjacency[nodeID]) == 0 {
+			delete(g.adjacency, nodeID)
+		}
+	}
+}
+
+// reset clears the entire contents of the cache.
+func (g *graphCache) reset() {
+	g.Lock()
+	g.nodes = make(map[wire.ShaHash]*LightningNode)
+	g.edges = make(map[wire.OutPoint]*ChannelEdge)
+	g.adjacency = make(map[wire.ShaHash]map[wire.OutPoint]*ChannelEdge)
+	g.Unlock()
+}
+
+// fetchNode returns a copy of the target node if it's present within the
+// cache.
+func (g *graphCache) fetchNode(nodeID *wire.ShaHash) (*LightningNode, bool) {
+	g.RLock()
+	defer g.RUnlock()
+
+	node, ok := g.nodes[*nodeID]
+	if !ok {
+		return nil, false
+	}
+
+	n := *node
+	return &n, true
+}
+
+// fetchEdge returns a copy of the target edge if it's present within the
+// cache.
+func (g *graphCache) fetchEdge(chanPoint *wire.OutPoint) (*ChannelEdge, bool) {
+	g.RLock()
+	defer g.RUnlock()
+
+	edge, ok := g.edges[*chanPoint]
+	if !ok {
+		return nil, false
+	}
+
+	e := *edge
+	return &e, true
+}
+
+// nodeEdges returns a copy of all the edges for which the target node is one
+// of the endpoints.
+func (g *graphCache) nodeEdges(nodeID *wire.ShaHash) []*ChannelEdge {
+	g.RLock()
+	defer g.RUnlock()
+
+	edges := make([]*ChannelEdge, 0, len(g.adjacency[*nodeID]))
+	for _, edge := range g.adjacency[*nodeID] {
+		e := *edge
+		edges = append(edges, &e)
+	}
+
+	return edges
+}
@@ -0,0 +1,213 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// graphSnapshotVersion is the version of the graph snapshot format
+	// written by ExportSnapshot.
+	graphSnapshotVersion = 1
+)
+
+var (
+	// graphSnapshotMagic prefixes every graph snapshot, identifying the
+	// file as such.
+	graphSnapshotMagic = [4]byte{'l', 'n', 'g', 's'}
+)
+
+// ExportSnapshot writes a compact binary snapshot of the entire channel graph
+// to w, returning the number of nodes and edges written. The snapshot is read
+// within a single transaction, so it's a consistent view of the graph. It's
+// tagged with the genesis hash of the graph's network, so it can only be
+// imported by a node on the same network.
+//
+// The snapshot consists of a header followed by all the nodes, then all the
+// edges, each serialized as they're stored on disk:
+//
+//	magic (4 bytes) || version (1 byte) || genesis hash (32 bytes) ||
+//	num nodes (4 bytes) || nodes || num edges (4 bytes) || edges
+func (c *ChannelGraph) ExportSnapshot(w io.Writer) (int, int, error) {
+	var numNodes, numEdges int
+	err := c.store.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		edges := tx.Bucket(edgeBucket)
+		if nodes == nil || edges == nil {
+			return ErrGraphNotFound
+		}
+
+		if _, err := w.Write(graphSnapshotMagic[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{graphSnapshotVersion}); err != nil {
+			return err
+		}
+		if _, err := w.Write(c.netParams.GenesisHash[:]); err != nil {
+			return err
+		}
+
+		// As the records are stored in the same format used within
+		// the snapshot, they're copied over as is.
+		var scratch [4]byte
+		numNodes = nodes.Stats().KeyN
+		byteOrder.PutUint32(scratch[:], uint32(numNodes))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+		err := nodes.ForEach(func(k, v []byte) error {
+			_, err := w.Write(v)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		numEdges = edges.Stats().KeyN
+		byteOrder.PutUint32(scratch[:], uint32(numEdges))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+		return edges.ForEach(func(k, v []byte) error {
+			_, err := w.Write(v)
+			return err
+		})
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return numNodes, numEdges, nil
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot from r, adding the
+// nodes and edges within it to the graph, returning the number of each which
+// were added. Any node or edge already within the graph which was updated
+// more recently than its counterpart within the snapshot is left untouched.
+// The snapshot is read in full before the graph is modified, and all the
+// records are then written within a single transaction, so a malformed
+// snapshot never leaves behind a partially imported graph.
+func (c *ChannelGraph) ImportSnapshot(r io.Reader) (int, int, error) {
+	var header [4 + 1 + wire.HashSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, 0, fmt.Errorf("unable to read snapshot header: %v",
+			err)
+	}
+	if !bytes.Equal(header[:4], graphSnapshotMagic[:]) {
+		return 0, 0, fmt.Errorf("not a graph snapshot")
+	}
+	if header[4] != graphSnapshotVersion {
+		return 0, 0, fmt.Errorf("unknown graph snapshot version %v",
+			header[4])
+	}
+	if !bytes.Equal(header[5:], c.netParams.GenesisHash[:]) {
+		return 0, 0, fmt.Errorf("graph snapshot is of another " +
+			"network")
+	}
+
+	var scratch [4]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return 0, 0, err
+	}
+	var snapshotNodes []*LightningNode
+	for i := uint32(0); i < byteOrder.Uint32(scratch[:]); i++ {
+		node, err := deserializeLightningNode(r)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to read node: %v", err)
+		}
+		snapshotNodes = append(snapshotNodes, node)
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return 0, 0, err
+	}
+	var snapshotEdges []*ChannelEdge
+	for i := uint32(0); i < byteOrder.Uint32(scratch[:]); i++ {
+		edge, err := deserializeChannelEdge(r)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to read edge: %v", err)
+		}
+		snapshotEdges = append(snapshotEdges, edge)
+	}
+
+	var (
+		addedNodes []*LightningNode
+		addedEdges []*ChannelEdge
+	)
+	err := c.store.Update(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		edges := tx.Bucket(edgeBucket)
+		if nodes == nil || edges == nil {
+			return ErrGraphNotFound
+		}
+
+		for _, node := range snapshotNodes {
+			v := nodes.Get(node.LightningID[:])
+			if v != nil {
+				existing, err := deserializeLightningNode(
+					bytes.NewReader(v),
+				)
+				if err == nil &&
+					existing.LastUpdate.After(node.LastUpdate) {
+
+					continue
+				}
+			}
+
+			var b bytes.Buffer
+			if err := serializeLightningNode(&b, node); err != nil {
+				return err
+			}
+			if err := nodes.Put(node.LightningID[:], b.Bytes()); err != nil {
+				return err
+			}
+			addedNodes = append(addedNodes, node)
+		}
+
+		for _, edge := range snapshotEdges {
+			var k bytes.Buffer
+			if err := writeOutpoint(&k, &edge.ChannelPoint); err != nil {
+				return err
+			}
+
+			v := edges.Get(k.Bytes())
+			if v != nil {
+				existing, err := deserializeChannelEdge(
+					bytes.NewReader(v),
+				)
+				if err == nil &&
+					existing.LastUpdate.After(edge.LastUpdate) {
+
+					continue
+				}
+			}
+
+			var b bytes.Buffer
+			if err := serializeChannelEdge(&b, edge); err != nil {
+				return err
+			}
+			if err := edges.Put(k.Bytes(), b.Bytes()); err != nil {
+				return err
+			}
+			addedEdges = append(addedEdges, edge)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, node := range addedNodes {
+		c.cache.addNode(node)
+	}
+	for _, edge := range addedEdges {
+		c.cache.addEdge(edge)
+	}
+
+	return len(addedNodes), len(addedEdges), nil
+}
@@ -0,0 +1,344 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// makeTestGraph creates a new instance of the ChannelGraph for testing
+// purposes. A callback which cleans up the created temporary directories is
+// also returned and intended to be executed after the test completes.
+func makeTestGraph() (*ChannelGraph, func(), error) {
+	tempDirName, err := ioutil.TempDir("", "channelgraph")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graph, err := OpenGraph(tempDirName, netParams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		graph.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	return graph, cleanUp, nil
+}
+
+func TestGraphNodeEdgeStorage(t *testing.T) {
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to make test graph: %v", err)
+	}
+	defer cleanUp()
+
+	node := &LightningNode{
+		LightningID: wire.ShaHash(key),
+		Addresses: []string{
+			"127.0.0.1:10011",
+			"[::1]:10011",
+			"abcdefghijklmnop.onion:10011",
+		},
+		Alias:      "alice",
+		Features:   lnwire.NewFeatureVector(lnwire.PaymentAddrOptional),
+		LastUpdate: time.Unix(time.Now().Unix(), 0),
+	}
+	if err := graph.AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	// The node retrieved from the graph should be identical to the one
+	// which was just inserted.
+	dbNode, err := graph.FetchLightningNode(&node.LightningID)
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	if !reflect.DeepEqual(node, dbNode) {
+		t.Fatalf("nodes don't match: expected %v, got %v",
+			spew.Sdump(node), spew.Sdump(dbNode))
+	}
+
+	edge := &ChannelEdge{
+		ChannelPoint: *id,
+		Node1:        wire.ShaHash(rev),
+		Node2:        node.LightningID,
+		Capacity:     btcutil.Amount(10000),
+		LastUpdate:   time.Unix(time.Now().Unix(), 0),
+	}
+	if err := graph.AddChannelEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	dbEdge, err := graph.FetchChannelEdge(id)
+	if err != nil {
+		t.Fatalf("unable to fetch edge: %v", err)
+	}
+	if !reflect.DeepEqual(edge, dbEdge) {
+		t.Fatalf("edges don't match: expected %v, got %v",
+			spew.Sdump(edge), spew.Sdump(dbEdge))
+	}
+
+	// Once the edge is deleted, it should no longer be found within the
+	// graph.
+	if err := graph.DeleteChannelEdge(id); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	if _, err := graph.FetchChannelEdge(id); err != ErrEdgeNotFound {
+		t.Fatalf("edge should have been deleted, instead: %v", err)
+	}
+}
+
+func TestGraphDrop(t *testing.T) {
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to make test graph: %v", err)
+	}
+	defer cleanUp()
+
+	node := &LightningNode{
+		LightningID: wire.ShaHash(key),
+		LastUpdate:  time.Now(),
+	}
+	if err := graph.AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	edge := &ChannelEdge{
+		ChannelPoint: *id,
+		Node2:        node.LightningID,
+		LastUpdate:   time.Now(),
+	}
+	if err := graph.AddChannelEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	// After dropping the graph, no nodes or edges should remain, though
+	// the graph itself should still be usable.
+	if err := graph.Drop(); err != nil {
+		t.Fatalf("unable to drop graph: %v", err)
+	}
+
+	var numNodes, numEdges int
+	err = graph.ForEachNode(func(*LightningNode) error {
+		numNodes++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate nodes: %v", err)
+	}
+	err = graph.ForEachChannel(func(*ChannelEdge) error {
+		numEdges++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate edges: %v", err)
+	}
+	if numNodes != 0 || numEdges != 0 {
+		t.Fatalf("graph should be empty, instead has %v nodes and %v "+
+			"edges", numNodes, numEdges)
+	}
+
+	if err := graph.AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node after drop: %v", err)
+	}
+}
+
+func TestGraphCachePopulate(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "channelgraph")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	graph, err := OpenGraph(tempDirName, netParams)
+	if err != nil {
+		t.Fatalf("unable to open graph: %v", err)
+	}
+
+	edge := &ChannelEdge{
+		ChannelPoint: *id,
+		Node1:        wire.ShaHash(rev),
+		Node2:        wire.ShaHash(key),
+		Capacity:     btcutil.Amount(10000),
+		Node1Policy: &ChannelEdgePolicy{
+			TimeLockDelta: 144,
+			MinHTLC:       btcutil.Amount(1),
+			FeeBase:       btcutil.Amount(1000),
+			FeeRate:       1,
+			LastUpdate:    time.Unix(time.Now().Unix(), 0),
+		},
+		LastUpdate: time.Unix(time.Now().Unix(), 0),
+	}
+	if err := graph.AddChannelEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+	if err := graph.Close(); err != nil {
+		t.Fatalf("unable to close graph: %v", err)
+	}
+
+	// Re-open the graph, the cache should be populated from disk such
+	// that the edge is reachable from both of its endpoints.
+	graph, err = OpenGraph(tempDirName, netParams)
+	if err != nil {
+		t.Fatalf("unable to re-open graph: %v", err)
+	}
+	defer graph.Close()
+
+	for _, nodeID := range []wire.ShaHash{edge.Node1, edge.Node2} {
+		var nodeEdges []*ChannelEdge
+		err := graph.ForEachNodeChannel(&nodeID, func(e *ChannelEdge) error {
+			nodeEdges = append(nodeEdges, e)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to iterate node edges: %v", err)
+		}
+		if len(nodeEdges) != 1 {
+			t.Fatalf("expected 1 edge for node %v, instead have %v",
+				nodeID, len(nodeEdges))
+		}
+		if !reflect.DeepEqual(edge, nodeEdges[0]) {
+			t.Fatalf("edges don't match: expected %v, got %v",
+				spew.Sdump(edge), spew.Sdump(nodeEdges[0]))
+		}
+	}
+
+	// Once deleted, the edge should no longer be present in the adjacency
+	// list of either node.
+	if err := graph.DeleteChannelEdge(id); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	err = graph.ForEachNodeChannel(&edge.Node1, func(e *ChannelEdge) error {
+		return fmt.Errorf("edge %v should have been removed",
+			e.ChannelPoint)
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestGraphSnapshot(t *testing.T) {
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to make test graph: %v", err)
+	}
+	defer cleanUp()
+
+	node := &LightningNode{
+		LightningID: wire.ShaHash(key),
+		Addresses:   []string{"127.0.0.1:10011"},
+		Alias:       "alice",
+		Features:    lnwire.NewFeatureVector(lnwire.PaymentAddrOptional),
+		LastUpdate:  time.Unix(time.Now().Unix(), 0),
+	}
+	if err := graph.AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	edge := &ChannelEdge{
+		ChannelPoint: *id,
+		Node1:        wire.ShaHash(rev),
+		Node2:        node.LightningID,
+		Capacity:     btcutil.Amount(10000),
+		Node1Policy: &ChannelEdgePolicy{
+			TimeLockDelta: 144,
+			MinHTLC:       1,
+			FeeBase:       1000,
+			FeeRate:       1,
+			LastUpdate:    time.Unix(time.Now().Unix(), 0),
+		},
+		LastUpdate: time.Unix(time.Now().Unix(), 0),
+	}
+	if err := graph.AddChannelEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	var snapshot bytes.Buffer
+	numNodes, numEdges, err := graph.ExportSnapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("unable to export snapshot: %v", err)
+	}
+	if numNodes != 1 || numEdges != 1 {
+		t.Fatalf("expected 1 node and 1 edge to be exported, "+
+			"instead got %v and %v", numNodes, numEdges)
+	}
+
+	// Importing the snapshot into a fresh graph should reproduce the
+	// original graph.
+	freshGraph, freshCleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to make test graph: %v", err)
+	}
+	defer freshCleanUp()
+
+	numNodes, numEdges, err = freshGraph.ImportSnapshot(
+		bytes.NewReader(snapshot.Bytes()),
+	)
+	if err != nil {
+		t.Fatalf("unable to import snapshot: %v", err)
+	}
+	if numNodes != 1 || numEdges != 1 {
+		t.Fatalf("expected 1 node and 1 edge to be imported, "+
+			"instead got %v and %v", numNodes, numEdges)
+	}
+
+	dbNode, err := freshGraph.FetchLightningNode(&node.LightningID)
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	if !reflect.DeepEqual(node, dbNode) {
+		t.Fatalf("nodes don't match: expected %v, got %v",
+			spew.Sdump(node), spew.Sdump(dbNode))
+	}
+	dbEdge, err := freshGraph.FetchChannelEdge(id)
+	if err != nil {
+		t.Fatalf("unable to fetch edge: %v", err)
+	}
+	if !reflect.DeepEqual(edge, dbEdge) {
+		t.Fatalf("edges don't match: expected %v, got %v",
+			spew.Sdump(edge), spew.Sdump(dbEdge))
+	}
+
+	// An edge updated more recently than its counterpart within the
+	// snapshot shouldn't be overwritten by a re-import.
+	newerEdge := *edge
+	newerEdge.Capacity = btcutil.Amount(20000)
+	newerEdge.LastUpdate = edge.LastUpdate.Add(time.Hour)
+	if err := freshGraph.AddChannelEdge(&newerEdge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+	_, numEdges, err = freshGraph.ImportSnapshot(
+		bytes.NewReader(snapshot.Bytes()),
+	)
+	if err != nil {
+		t.Fatalf("unable to import snapshot: %v", err)
+	}
+	if numEdges != 0 {
+		t.Fatalf("expected no edges to be imported, instead got %v",
+			numEdges)
+	}
+	dbEdge, err = freshGraph.FetchChannelEdge(id)
+	if err != nil {
+		t.Fatalf("unable to fetch edge: %v", err)
+	}
+	if dbEdge.Capacity != newerEdge.Capacity {
+		t.Fatalf("newer edge was overwritten by the snapshot")
+	}
+
+	// A snapshot of another network should be rejected.
+	foreign := append([]byte(nil), snapshot.Bytes()...)
+	foreign[5] ^= 0xff
+	if _, _, err := freshGraph.ImportSnapshot(bytes.NewReader(foreign)); err == nil {
+		t.Fatalf("snapshot of another network was imported")
+	}
+}
@@ -0,0 +1,65 @@
+package channeldb
+
+import "github.com/boltdb/bolt"
+
+var (
+	// hwAddrBucket is the name of the bucket within the database which
+	// tracks the addresses issued from the account of a hardware wallet
+	// holding our on-chain funds. The bucket maps the index of each
+	// branch of the account to the number of addresses issued from it,
+	// which is also the index of the next address to derive.
+	hwAddrBucket = []byte("hw-addr")
+)
+
+// NextHWAddrIndex returns the index of the next address to derive from the
+// passed branch of the hardware wallet account, then increments it. Each
+// index is returned exactly once, ensuring addresses are never reused.
+func (d *DB) NextHWAddrIndex(branch uint32) (uint32, error) {
+	var index uint32
+	err := d.store.Update(func(tx *bolt.Tx) error {
+		hwAddrs, err := tx.CreateBucketIfNotExists(hwAddrBucket)
+		if err != nil {
+			return err
+		}
+
+		var branchKey, numAddrs [4]byte
+		byteOrder.PutUint32(branchKey[:], branch)
+		if v := hwAddrs.Get(branchKey[:]); v != nil {
+			index = byteOrder.Uint32(v)
+		}
+
+		byteOrder.PutUint32(numAddrs[:], index+1)
+		return hwAddrs.Put(branchKey[:], numAddrs[:])
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return index, nil
+}
+
+// NumHWAddrs returns the number of addresses issued from the passed branch of
+// the hardware wallet account, that is the number of indexes returned by
+// NextHWAddrIndex for the branch.
+func (d *DB) NumHWAddrs(branch uint32) (uint32, error) {
+	var numAddrs uint32
+	err := d.store.View(func(tx *bolt.Tx) error {
+		hwAddrs := tx.Bucket(hwAddrBucket)
+		if hwAddrs == nil {
+			return nil
+		}
+
+		var branchKey [4]byte
+		byteOrder.PutUint32(branchKey[:], branch)
+		if v := hwAddrs.Get(branchKey[:]); v != nil {
+			numAddrs = byteOrder.Uint32(v)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numAddrs, nil
+}
@@ -0,0 +1,42 @@
+package channeldb
+
+import "testing"
+
+func TestHWAddrIndexes(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	// Each branch should hand out its own sequence of indexes, starting
+	// from zero, with the number of issued addresses tracking it.
+	for branch := uint32(0); branch < 2; branch++ {
+		for i := uint32(0); i < 3+branch; i++ {
+			index, err := db.NextHWAddrIndex(branch)
+			if err != nil {
+				t.Fatalf("unable to fetch hw addr index: %v", err)
+			}
+			if index != i {
+				t.Fatalf("expected index %v, instead got %v", i,
+					index)
+			}
+		}
+	}
+
+	for branch := uint32(0); branch < 3; branch++ {
+		numAddrs, err := db.NumHWAddrs(branch)
+		if err != nil {
+			t.Fatalf("unable to fetch number of hw addrs: %v", err)
+		}
+
+		expected := uint32(3 + branch)
+		if branch == 2 {
+			expected = 0
+		}
+		if numAddrs != expected {
+			t.Fatalf("expected %v addrs on branch %v, instead "+
+				"have %v", expected, branch, numAddrs)
+		}
+	}
+}
@@ -26,6 +26,8 @@ func TestInvoiceWorkflow(t *testing.T) {
 	copy(fakeInvoice.Receipt[:], []byte("recipt"))
 	copy(fakeInvoice.Terms.PaymentPreimage[:], rev[:])
 	fakeInvoice.Terms.Value = btcutil.Amount(10000)
+	fakeInvoice.Terms.FinalCltvDelta = 144
+	copy(fakeInvoice.Terms.PaymentAddr[:], key[:])
 
 	// Add the invoice to the database, this should suceed as there aren't
 	// any existing invoices within the database with the same payment
@@ -91,6 +91,18 @@ type ContractTerm struct {
 	// satisfied by the above preimage.
 	Value btcutil.Amount
 
+	// FinalCltvDelta is the minimum number of blocks remaining until the
+	// expiry of an HTLC paying to this invoice at the time the HTLC is
+	// received. HTLC's arriving with less time remaining are rejected, as
+	// we may be unable to safely claim them on-chain.
+	FinalCltvDelta uint32
+
+	// PaymentAddr is a secret included within the invoice which the payer
+	// must present to the final hop alongside the HTLC. HTLC's lacking
+	// the secret are rejected, preventing intermediate nodes from probing
+	// whether the payment hash is known to us.
+	PaymentAddr [32]byte
+
 	// Settled indicates if this particular contract term has been fully
 	// settled by the payer.
 	Settled bool
@@ -266,6 +278,15 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		return err
 	}
 
+	byteOrder.PutUint32(scratch[:4], i.Terms.FinalCltvDelta)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(i.Terms.PaymentAddr[:]); err != nil {
+		return err
+	}
+
 	var settleByte [1]byte
 	if i.Terms.Settled {
 		settleByte[0] = 1
@@ -316,6 +337,15 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	}
 	invoice.Terms.Value = btcutil.Amount(byteOrder.Uint64(scratch[:]))
 
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	invoice.Terms.FinalCltvDelta = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, invoice.Terms.PaymentAddr[:]); err != nil {
+		return nil, err
+	}
+
 	var settleByte [1]byte
 	if _, err := io.ReadFull(r, settleByte[:]); err != nil {
 		return nil, err
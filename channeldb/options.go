@@ -0,0 +1,46 @@
+package channeldb
+
+// Options holds the tunables of the databases which bound their memory usage.
+type Options struct {
+	// AllocSize is the number of bytes a database file grows by once it's
+	// full. As the memory map of the database grows along with the file,
+	// a smaller size trades more frequent remapping for a smaller map.
+	// If zero, bolt's default of 16MB is used.
+	AllocSize int
+
+	// MaxConcurrentReads is the maximum number of read transactions of
+	// the channel database which may be open at once, as each pins the
+	// pages it reads in memory. If zero, the number isn't limited.
+	MaxConcurrentReads int
+}
+
+// OptionModifier is a function which modifies the options a database is
+// opened with.
+type OptionModifier func(*Options)
+
+// OptionAllocSize sets the number of bytes a database file grows by once it's
+// full.
+func OptionAllocSize(allocSize int) OptionModifier {
+	return func(o *Options) {
+		o.AllocSize = allocSize
+	}
+}
+
+// OptionMaxConcurrentReads sets the maximum number of read transactions of
+// the channel database which may be open at once.
+func OptionMaxConcurrentReads(maxReads int) OptionModifier {
+	return func(o *Options) {
+		o.MaxConcurrentReads = maxReads
+	}
+}
+
+// applyOptions returns the options resulting from applying the passed
+// modifiers in order.
+func applyOptions(modifiers []OptionModifier) Options {
+	var opts Options
+	for _, modifier := range modifiers {
+		modifier(&opts)
+	}
+
+	return opts
+}
@@ -0,0 +1,581 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/btcsuite/fastsha256"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// paymentBucket is the name of the bucket within the database that
+	// stores all outgoing payments no matter their final state. Within
+	// the payment bucket, each payment is keyed by its payment ID which is
+	// a monotonically increasing uint64.
+	paymentBucket = []byte("payments")
+
+	// paymentIndexBucket is the name of the sub-bucket within the
+	// paymentBucket which maps a payment hash to the ID of the most recent
+	// payment attempt made for that hash.
+	paymentIndexBucket = []byte("paymentindex")
+)
+
+// PaymentStatus represents the current state of an outgoing payment within
+// the payment lifecycle. A payment begins in the InFlight state, and
+// transitions exactly once into either the Succeeded or Failed state.
+type PaymentStatus byte
+
+const (
+	// StatusInFlight denotes a payment which has been dispatched, but
+	// whose final outcome isn't yet known.
+	StatusInFlight PaymentStatus = 1
+
+	// StatusSucceeded denotes a payment which has been fully settled by
+	// the destination.
+	StatusSucceeded PaymentStatus = 2
+
+	// StatusFailed denotes a payment which has failed and no longer has
+	// any HTLC's outstanding within the network.
+	StatusFailed PaymentStatus = 3
+)
+
+// String returns a human readable version of the payment status.
+func (s PaymentStatus) String() string {
+	switch s {
+	case StatusInFlight:
+		return "InFlight"
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// PaymentHop is a single hop within the route used by a payment attempt.
+type PaymentHop struct {
+	// NodeID is the lightning ID of the node at the far end of the hop.
+	NodeID [32]byte
+
+	// ChannelPoint is the funding outpoint of the channel traversed by
+	// the hop.
+	ChannelPoint wire.OutPoint
+
+	// AmtToForward is the amount sent across the hop.
+	AmtToForward btcutil.Amount
+}
+
+// PaymentAttempt is a single attempt to complete a payment by sending an HTLC
+// along a particular route. A payment may be made up of several attempts.
+type PaymentAttempt struct {
+	// AttemptID uniquely identifies the attempt within its payment.
+	AttemptID uint32
+
+	// Hops is the route used by this attempt.
+	Hops []PaymentHop
+
+	// Status is the current state of the attempt.
+	Status PaymentStatus
+
+	// FailureSourceIndex is the index of the hop within the route which
+	// reported the failure. An index of zero denotes that the failure
+	// occurred locally. This field is only meaningful if the attempt has
+	// failed.
+	FailureSourceIndex uint32
+
+	// FailureCode is the wire failure code reported by the failing hop, as
+	// defined by lnwire.FailCode. A code of zero denotes that no specific
+	// code was reported.
+	FailureCode uint16
+
+	// FailureReason is a description of why the attempt failed.
+	FailureReason string
+}
+
+// OutgoingPayment represents a payment dispatched by the daemon. Each payment
+// is persisted before its HTLC is sent out, so that the outcome of a payment
+// which was in flight when the daemon shut down can be reconciled once the
+// daemon restarts.
+type OutgoingPayment struct {
+	// PaymentID uniquely identifies this payment attempt. The ID is
+	// assigned once the payment is added to the database.
+	PaymentID uint64
+
+	// PaymentHash is the hash the HTLC for this payment is locked to.
+	PaymentHash [32]byte
+
+	// Dest is the lightning ID of the payment's destination.
+	Dest [32]byte
+
+	// Amt is the amount sent to the destination.
+	Amt btcutil.Amount
+
+	// CreationDate is the time the payment was first dispatched.
+	CreationDate time.Time
+
+	// Status is the current state of the payment.
+	Status PaymentStatus
+
+	// Preimage is the preimage revealed by the destination once the
+	// payment has succeeded.
+	Preimage [32]byte
+
+	// Attempts is the list of all the HTLC attempts made for this
+	// payment, in the order they were made.
+	Attempts []*PaymentAttempt
+}
+
+// InitPayment persists a new outgoing payment in the InFlight state, assigning
+// it a fresh payment ID. If a prior payment for the same payment hash is still
+// in flight, then ErrPaymentInFlight is returned.
+func (d *DB) InitPayment(p *OutgoingPayment) error {
+	return d.store.Update(func(tx *bolt.Tx) error {
+		payments, err := tx.CreateBucketIfNotExists(paymentBucket)
+		if err != nil {
+			return err
+		}
+		paymentIndex, err := payments.CreateBucketIfNotExists(paymentIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		// If we've already made a payment for this hash, then ensure
+		// that it isn't still outstanding.
+		if paymentID := paymentIndex.Get(p.PaymentHash[:]); paymentID != nil {
+			prior, err := fetchPayment(payments, paymentID)
+			if err != nil {
+				return err
+			}
+
+			if prior.Status == StatusInFlight {
+				return ErrPaymentInFlight
+			}
+		}
+
+		seqNum, err := payments.NextSequence()
+		if err != nil {
+			return err
+		}
+		p.PaymentID = seqNum
+		p.Status = StatusInFlight
+
+		var paymentKey [8]byte
+		byteOrder.PutUint64(paymentKey[:], p.PaymentID)
+		if err := paymentIndex.Put(p.PaymentHash[:], paymentKey[:]); err != nil {
+			return err
+		}
+
+		return putPayment(payments, p)
+	})
+}
+
+// SucceedPayment transitions the in flight payment for the passed preimage's
+// payment hash into the Succeeded state, recording the preimage.
+func (d *DB) SucceedPayment(preimage [32]byte) error {
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	return d.updatePayment(paymentHash, func(p *OutgoingPayment) error {
+		p.Status = StatusSucceeded
+		p.Preimage = preimage
+
+		for _, attempt := range p.Attempts {
+			if attempt.Status == StatusInFlight {
+				attempt.Status = StatusSucceeded
+			}
+		}
+
+		return nil
+	})
+}
+
+// FailPayment transitions the in flight payment for the passed payment hash
+// into the Failed state.
+func (d *DB) FailPayment(paymentHash [32]byte) error {
+	return d.updatePayment(paymentHash, func(p *OutgoingPayment) error {
+		p.Status = StatusFailed
+		return nil
+	})
+}
+
+// RegisterAttempt records a new HTLC attempt for the in flight payment
+// identified by the passed payment hash. The attempt is assigned an ID, and
+// begins in the InFlight state.
+func (d *DB) RegisterAttempt(paymentHash [32]byte, attempt *PaymentAttempt) error {
+	return d.updatePayment(paymentHash, func(p *OutgoingPayment) error {
+		attempt.AttemptID = uint32(len(p.Attempts))
+		attempt.Status = StatusInFlight
+
+		p.Attempts = append(p.Attempts, attempt)
+		return nil
+	})
+}
+
+// FailAttempt marks the target attempt of an in flight payment as failed,
+// recording the index of the hop which reported the failure along with the
+// failure code and reason for the failure.
+func (d *DB) FailAttempt(paymentHash [32]byte, attemptID uint32,
+	sourceIndex uint32, failureCode uint16, reason string) error {
+
+	return d.updatePayment(paymentHash, func(p *OutgoingPayment) error {
+		if attemptID >= uint32(len(p.Attempts)) {
+			return ErrAttemptNotFound
+		}
+
+		attempt := p.Attempts[attemptID]
+		attempt.Status = StatusFailed
+		attempt.FailureSourceIndex = sourceIndex
+		attempt.FailureCode = failureCode
+		attempt.FailureReason = reason
+
+		return nil
+	})
+}
+
+// updatePayment applies the passed modifier to the most recent payment made
+// for the target payment hash. Only in flight payments may be modified, if
+// the payment has already reached a final state then ErrPaymentNotInFlight is
+// returned.
+func (d *DB) updatePayment(paymentHash [32]byte,
+	modifier func(*OutgoingPayment) error) error {
+
+	return d.store.Update(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+		paymentIndex := payments.Bucket(paymentIndexBucket)
+		if paymentIndex == nil {
+			return ErrPaymentNotFound
+		}
+
+		paymentID := paymentIndex.Get(paymentHash[:])
+		if paymentID == nil {
+			return ErrPaymentNotFound
+		}
+
+		payment, err := fetchPayment(payments, paymentID)
+		if err != nil {
+			return err
+		}
+		if payment.Status != StatusInFlight {
+			return ErrPaymentNotInFlight
+		}
+
+		if err := modifier(payment); err != nil {
+			return err
+		}
+
+		return putPayment(payments, payment)
+	})
+}
+
+// FetchPayment returns the most recent payment made for the target payment
+// hash.
+func (d *DB) FetchPayment(paymentHash [32]byte) (*OutgoingPayment, error) {
+	var payment *OutgoingPayment
+	err := d.store.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return ErrPaymentNotFound
+		}
+		paymentIndex := payments.Bucket(paymentIndexBucket)
+		if paymentIndex == nil {
+			return ErrPaymentNotFound
+		}
+
+		paymentID := paymentIndex.Get(paymentHash[:])
+		if paymentID == nil {
+			return ErrPaymentNotFound
+		}
+
+		p, err := fetchPayment(payments, paymentID)
+		if err != nil {
+			return err
+		}
+		payment = p
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// FetchAllPayments returns all outgoing payments stored within the database,
+// ordered by their payment ID.
+func (d *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
+	var payments []*OutgoingPayment
+	err := d.store.View(func(tx *bolt.Tx) error {
+		paymentsBucket := tx.Bucket(paymentBucket)
+		if paymentsBucket == nil {
+			return nil
+		}
+
+		return paymentsBucket.ForEach(func(k, v []byte) error {
+			// The nested payment index bucket has a nil value, so
+			// we skip it.
+			if v == nil {
+				return nil
+			}
+
+			payment, err := deserializePayment(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			payments = append(payments, payment)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// FetchInFlightPayments returns all outgoing payments which are currently in
+// the InFlight state.
+func (d *DB) FetchInFlightPayments() ([]*OutgoingPayment, error) {
+	payments, err := d.FetchAllPayments()
+	if err != nil {
+		return nil, err
+	}
+
+	var inFlight []*OutgoingPayment
+	for _, payment := range payments {
+		if payment.Status == StatusInFlight {
+			inFlight = append(inFlight, payment)
+		}
+	}
+
+	return inFlight, nil
+}
+
+func putPayment(payments *bolt.Bucket, p *OutgoingPayment) error {
+	var paymentKey [8]byte
+	byteOrder.PutUint64(paymentKey[:], p.PaymentID)
+
+	var b bytes.Buffer
+	if err := serializePayment(&b, p); err != nil {
+		return err
+	}
+
+	return payments.Put(paymentKey[:], b.Bytes())
+}
+
+func fetchPayment(payments *bolt.Bucket, paymentID []byte) (*OutgoingPayment, error) {
+	paymentBytes := payments.Get(paymentID)
+	if paymentBytes == nil {
+		return nil, ErrPaymentNotFound
+	}
+
+	return deserializePayment(bytes.NewReader(paymentBytes))
+}
+
+func serializePayment(w io.Writer, p *OutgoingPayment) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint64(scratch[:], p.PaymentID)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(p.PaymentHash[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(p.Dest[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(p.Amt))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	birthBytes, err := p.CreationDate.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := wire.WriteVarBytes(w, 0, birthBytes); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte{byte(p.Status)}); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(p.Preimage[:]); err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(p.Attempts))); err != nil {
+		return err
+	}
+	for _, attempt := range p.Attempts {
+		if err := serializeAttempt(w, attempt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func serializeAttempt(w io.Writer, a *PaymentAttempt) error {
+	var scratch [8]byte
+
+	byteOrder.PutUint32(scratch[:4], a.AttemptID)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(a.Hops))); err != nil {
+		return err
+	}
+	for _, hop := range a.Hops {
+		if _, err := w.Write(hop.NodeID[:]); err != nil {
+			return err
+		}
+		if err := writeOutpoint(w, &hop.ChannelPoint); err != nil {
+			return err
+		}
+
+		byteOrder.PutUint64(scratch[:], uint64(hop.AmtToForward))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte{byte(a.Status)}); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(scratch[:4], a.FailureSourceIndex)
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint16(scratch[:2], a.FailureCode)
+	if _, err := w.Write(scratch[:2]); err != nil {
+		return err
+	}
+
+	return wire.WriteVarString(w, 0, a.FailureReason)
+}
+
+func deserializeAttempt(r io.Reader) (*PaymentAttempt, error) {
+	var err error
+	a := &PaymentAttempt{}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	a.AttemptID = byteOrder.Uint32(scratch[:4])
+
+	numHops, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	a.Hops = make([]PaymentHop, numHops)
+	for i := uint64(0); i < numHops; i++ {
+		hop := &a.Hops[i]
+
+		if _, err := io.ReadFull(r, hop.NodeID[:]); err != nil {
+			return nil, err
+		}
+		if err := readOutpoint(r, &hop.ChannelPoint); err != nil {
+			return nil, err
+		}
+
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		hop.AmtToForward = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+	}
+
+	var statusByte [1]byte
+	if _, err := io.ReadFull(r, statusByte[:]); err != nil {
+		return nil, err
+	}
+	a.Status = PaymentStatus(statusByte[0])
+
+	if _, err := io.ReadFull(r, scratch[:4]); err != nil {
+		return nil, err
+	}
+	a.FailureSourceIndex = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:2]); err != nil {
+		return nil, err
+	}
+	a.FailureCode = byteOrder.Uint16(scratch[:2])
+
+	a.FailureReason, err = wire.ReadVarString(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func deserializePayment(r io.Reader) (*OutgoingPayment, error) {
+	p := &OutgoingPayment{}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	p.PaymentID = byteOrder.Uint64(scratch[:])
+
+	if _, err := io.ReadFull(r, p.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, p.Dest[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	p.Amt = btcutil.Amount(byteOrder.Uint64(scratch[:]))
+
+	birthBytes, err := wire.ReadVarBytes(r, 0, 300, "birth")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreationDate.UnmarshalBinary(birthBytes); err != nil {
+		return nil, err
+	}
+
+	var statusByte [1]byte
+	if _, err := io.ReadFull(r, statusByte[:]); err != nil {
+		return nil, err
+	}
+	p.Status = PaymentStatus(statusByte[0])
+
+	if _, err := io.ReadFull(r, p.Preimage[:]); err != nil {
+		return nil, err
+	}
+
+	numAttempts, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < numAttempts; i++ {
+		attempt, err := deserializeAttempt(r)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Attempts = append(p.Attempts, attempt)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,177 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcutil"
+)
+
+func TestPaymentLifecycle(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	var preimage [32]byte
+	copy(preimage[:], rev[:])
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	payment := &OutgoingPayment{
+		PaymentHash:  paymentHash,
+		Dest:         key,
+		Amt:          btcutil.Amount(10000),
+		CreationDate: time.Unix(time.Now().Unix(), 0),
+	}
+	if err := db.InitPayment(payment); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	if payment.Status != StatusInFlight {
+		t.Fatalf("payment should be in flight, instead %v",
+			payment.Status)
+	}
+
+	// The payment should be retrievable from the database, and should
+	// also be returned as one of the in flight payments.
+	dbPayment, err := db.FetchPayment(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if !reflect.DeepEqual(payment, dbPayment) {
+		t.Fatalf("payment fetched from db doesn't match original %v vs %v",
+			spew.Sdump(payment), spew.Sdump(dbPayment))
+	}
+	inFlight, err := db.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in flight payments: %v", err)
+	}
+	if len(inFlight) != 1 {
+		t.Fatalf("expected 1 in flight payment, instead have %v",
+			len(inFlight))
+	}
+
+	// A second payment for the same hash should be rejected while the
+	// first is still in flight.
+	if err := db.InitPayment(&OutgoingPayment{
+		PaymentHash: paymentHash,
+	}); err != ErrPaymentInFlight {
+		t.Fatalf("duplicate payment should be rejected, instead %v", err)
+	}
+
+	// Settle the payment, the version retrieved from the database should
+	// now be marked as succeeded along with the preimage.
+	if err := db.SucceedPayment(preimage); err != nil {
+		t.Fatalf("unable to succeed payment: %v", err)
+	}
+	dbPayment, err = db.FetchPayment(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if dbPayment.Status != StatusSucceeded {
+		t.Fatalf("payment should be succeeded, instead %v",
+			dbPayment.Status)
+	}
+	if dbPayment.Preimage != preimage {
+		t.Fatalf("preimage mismatch: expected %x, got %x",
+			preimage[:], dbPayment.Preimage[:])
+	}
+
+	// Once a payment has reached a final state, it can no longer be
+	// transitioned.
+	if err := db.FailPayment(paymentHash); err != ErrPaymentNotInFlight {
+		t.Fatalf("settled payment shouldn't be failed, instead %v", err)
+	}
+
+	inFlight, err = db.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in flight payments: %v", err)
+	}
+	if len(inFlight) != 0 {
+		t.Fatalf("expected no in flight payments, instead have %v",
+			len(inFlight))
+	}
+}
+
+func TestPaymentAttempts(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	paymentHash := fastsha256.Sum256(rev[:])
+	payment := &OutgoingPayment{
+		PaymentHash:  paymentHash,
+		Dest:         key,
+		Amt:          btcutil.Amount(10000),
+		CreationDate: time.Unix(time.Now().Unix(), 0),
+	}
+	if err := db.InitPayment(payment); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	// Register two attempts for the payment, failing the first one.
+	for i := 0; i < 2; i++ {
+		attempt := &PaymentAttempt{
+			Hops: []PaymentHop{
+				{
+					NodeID:       key,
+					ChannelPoint: *id,
+					AmtToForward: payment.Amt,
+				},
+			},
+		}
+		if err := db.RegisterAttempt(paymentHash, attempt); err != nil {
+			t.Fatalf("unable to register attempt: %v", err)
+		}
+		if attempt.AttemptID != uint32(i) {
+			t.Fatalf("expected attempt id %v, got %v", i,
+				attempt.AttemptID)
+		}
+	}
+	if err := db.FailAttempt(paymentHash, 0, 1, 0x400f, "unknown hash"); err != nil {
+		t.Fatalf("unable to fail attempt: %v", err)
+	}
+	if err := db.FailAttempt(paymentHash, 5, 0, 0, ""); err != ErrAttemptNotFound {
+		t.Fatalf("expected ErrAttemptNotFound, instead %v", err)
+	}
+
+	dbPayment, err := db.FetchPayment(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if len(dbPayment.Attempts) != 2 {
+		t.Fatalf("expected 2 attempts, instead have %v",
+			len(dbPayment.Attempts))
+	}
+
+	failed := dbPayment.Attempts[0]
+	if failed.Status != StatusFailed || failed.FailureSourceIndex != 1 ||
+		failed.FailureCode != 0x400f ||
+		failed.FailureReason != "unknown hash" {
+		t.Fatalf("attempt not failed correctly: %v", spew.Sdump(failed))
+	}
+	if !reflect.DeepEqual(failed.Hops, dbPayment.Attempts[1].Hops) {
+		t.Fatalf("hops don't match: expected %v, got %v",
+			spew.Sdump(failed.Hops),
+			spew.Sdump(dbPayment.Attempts[1].Hops))
+	}
+
+	// Once the payment is settled, the outstanding attempt should be
+	// marked as succeeded.
+	if err := db.SucceedPayment(rev); err != nil {
+		t.Fatalf("unable to succeed payment: %v", err)
+	}
+	dbPayment, err = db.FetchPayment(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if dbPayment.Attempts[1].Status != StatusSucceeded {
+		t.Fatalf("attempt should be succeeded, instead %v",
+			dbPayment.Attempts[1].Status)
+	}
+}
@@ -0,0 +1,55 @@
+package channeldb
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/btcsuite/fastsha256"
+)
+
+var (
+	// preimageBucket is the name of the bucket within the database which
+	// stores each HTLC preimage learned by the daemon, either off-chain
+	// from a settled HTLC, or on-chain from the witness of a spent HTLC
+	// output. Within the bucket, each preimage is keyed by its payment
+	// hash.
+	preimageBucket = []byte("preimages")
+)
+
+// AddPreimage persists the passed preimage, indexed by its payment hash.
+// Adding a preimage which is already known is a noop.
+func (d *DB) AddPreimage(preimage [32]byte) error {
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	return d.store.Update(func(tx *bolt.Tx) error {
+		preimages, err := tx.CreateBucketIfNotExists(preimageBucket)
+		if err != nil {
+			return err
+		}
+
+		return preimages.Put(paymentHash[:], preimage[:])
+	})
+}
+
+// LookupPreimage returns the preimage of the passed payment hash. If the
+// preimage isn't known, then ErrPreimageNotFound is returned.
+func (d *DB) LookupPreimage(paymentHash [32]byte) ([32]byte, error) {
+	var preimage [32]byte
+	err := d.store.View(func(tx *bolt.Tx) error {
+		preimages := tx.Bucket(preimageBucket)
+		if preimages == nil {
+			return ErrPreimageNotFound
+		}
+
+		preimageBytes := preimages.Get(paymentHash[:])
+		if preimageBytes == nil {
+			return ErrPreimageNotFound
+		}
+		copy(preimage[:], preimageBytes)
+
+		return nil
+	})
+	if err != nil {
+		return preimage, err
+	}
+
+	return preimage, nil
+}
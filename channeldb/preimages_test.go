@@ -0,0 +1,45 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/btcsuite/fastsha256"
+)
+
+func TestPreimageStorage(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	var preimage [32]byte
+	copy(preimage[:], rev[:])
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	// Before the preimage has been added, it shouldn't be found.
+	if _, err := db.LookupPreimage(paymentHash); err != ErrPreimageNotFound {
+		t.Fatalf("expected ErrPreimageNotFound, instead %v", err)
+	}
+
+	// Once added, the preimage should be found by its payment hash.
+	// Adding it a second time shouldn't fail.
+	for i := 0; i < 2; i++ {
+		if err := db.AddPreimage(preimage); err != nil {
+			t.Fatalf("unable to add preimage: %v", err)
+		}
+	}
+	dbPreimage, err := db.LookupPreimage(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup preimage: %v", err)
+	}
+	if dbPreimage != preimage {
+		t.Fatalf("preimage mismatch: expected %x, got %x", preimage,
+			dbPreimage)
+	}
+
+	// The preimage shouldn't be found under any other hash.
+	if _, err := db.LookupPreimage(preimage); err != ErrPreimageNotFound {
+		t.Fatalf("expected ErrPreimageNotFound, instead %v", err)
+	}
+}
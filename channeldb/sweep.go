@@ -0,0 +1,41 @@
+package channeldb
+
+import "github.com/boltdb/bolt"
+
+var (
+	// sweepAddrBucket is the name of the bucket within the database which
+	// tracks the derivation of the addresses funds recovered from force
+	// closed channels are swept to, when they're derived from an external
+	// extended public key. The bucket's sequence number is the index of
+	// the next address to derive.
+	sweepAddrBucket = []byte("sweep-addr")
+)
+
+// NextSweepAddrIndex returns the index of the next address to derive from the
+// extended public key funds are swept to, then increments it. Each index is
+// returned exactly once, ensuring addresses are never reused.
+func (d *DB) NextSweepAddrIndex() (uint32, error) {
+	var index uint64
+	err := d.store.Update(func(tx *bolt.Tx) error {
+		sweepAddrs, err := tx.CreateBucketIfNotExists(sweepAddrBucket)
+		if err != nil {
+			return err
+		}
+
+		// The sequence number of a bucket starts at zero, and is
+		// incremented before it's returned, so the first index
+		// returned is zero.
+		seqNo, err := sweepAddrs.NextSequence()
+		if err != nil {
+			return err
+		}
+		index = seqNo - 1
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(index), nil
+}
@@ -0,0 +1,23 @@
+package channeldb
+
+import "testing"
+
+func TestNextSweepAddrIndex(t *testing.T) {
+	db, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	defer cleanUp()
+
+	// Each call should return the next index in sequence, starting from
+	// zero.
+	for i := uint32(0); i < 3; i++ {
+		index, err := db.NextSweepAddrIndex()
+		if err != nil {
+			t.Fatalf("unable to fetch sweep addr index: %v", err)
+		}
+		if index != i {
+			t.Fatalf("expected index %v, instead got %v", i, index)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/boltdb/bolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// chanChecksumPrefix is the prefix of the key within the
+	// openChannelBucket storing the checksum over the stored state of
+	// each open channel: key = prefix || chanID.
+	chanChecksumPrefix = []byte("ccs")
+
+	// checksummedOpenChanPrefixes are the prefixes of the fields within
+	// the openChannelBucket covered by a channel's checksum. The uptime
+	// of the channel is left out, as it's updated on its own as the peer
+	// comes and goes.
+	checksummedOpenChanPrefixes = [][]byte{
+		chanCapacityPrefix, selfBalancePrefix, theirBalancePrefix,
+		minFeePerKbPrefix, updatePrefix, satSentPrefix,
+		satRecievedPrefix, netFeesPrefix, shortChanIDPrefix,
+		chanPrivatePrefix, chanLeasePrefix, chanPolicyPrefix,
+		chanHtlcLimitsPrefix, chanUpfrontPrefix,
+	}
+
+	// checksummedNodeChanKeys are the prefixes of the fields within the
+	// node's channel bucket covered by a channel's checksum.
+	checksummedNodeChanKeys = [][]byte{
+		chanIDKey, commitKeys, commitTxnsKey, fundingTxnKey,
+		elkremStateKey, deliveryScriptsKey,
+	}
+)
+
+// CorruptChannelError is returned when the stored state of an open channel
+// violates one of the invariants every channel state must satisfy.
+type CorruptChannelError struct {
+	// ChanPoint is the channel point of the corrupt channel.
+	ChanPoint *wire.OutPoint
+
+	// Reason describes the invariant the channel state violates.
+	Reason string
+}
+
+// Error returns a human readable description of the corruption.
+func (e *CorruptChannelError) Error() string {
+	return fmt.Sprintf("ChannelPoint(%v) is corrupt: %v", e.ChanPoint,
+		e.Reason)
+}
+
+// Verify checks the channel state against the invariants which hold for
+// every valid state: both balances and all HTLC amounts are non-negative,
+// together they don't exceed the capacity of the channel, and our commitment
+// transaction spends the funding output. A CorruptChannelError is returned
+// describing the first violated invariant, if any.
+func (c *OpenChannel) Verify() error {
+	corrupt := func(format string, args ...interface{}) error {
+		return &CorruptChannelError{
+			ChanPoint: c.ChanID,
+			Reason:    fmt.Sprintf(format, args...),
+		}
+	}
+
+	if c.Capacity <= 0 {
+		return corrupt("capacity %v isn't positive", c.Capacity)
+	}
+	if c.OurBalance < 0 || c.TheirBalance < 0 {
+		return corrupt("negative balance: local=%v, remote=%v",
+			c.OurBalance, c.TheirBalance)
+	}
+
+	// The balances of both sides, along with the HTLC's in flight, are
+	// funded by the channel's capacity. Whatever remains is paid as the
+	// commitment fee.
+	total := c.OurBalance + c.TheirBalance
+	for _, htlc := range c.Htlcs {
+		if htlc.Amt <= 0 {
+			return corrupt("HTLC with payment hash %x has "+
+				"non-positive amount %v", htlc.RHash[:],
+				htlc.Amt)
+		}
+		total += htlc.Amt
+	}
+	if total > c.Capacity {
+		return corrupt("local balance %v, remote balance %v and "+
+			"%v HTLC's total %v, exceeding the capacity %v",
+			c.OurBalance, c.TheirBalance, len(c.Htlcs), total,
+			c.Capacity)
+	}
+
+	if c.OurCommitTx != nil && c.FundingOutpoint != nil {
+		if len(c.OurCommitTx.TxIn) != 1 ||
+			c.OurCommitTx.TxIn[0].PreviousOutPoint != *c.FundingOutpoint {
+
+			return corrupt("commitment transaction %v doesn't "+
+				"spend the funding output %v",
+				c.OurCommitTx.TxSha(), c.FundingOutpoint)
+		}
+	}
+
+	return nil
+}
+
+// chanChecksum computes the checksum over the stored state of the channel
+// with the passed channel point: each of its fields, in a fixed order, along
+// with its current set of HTLC's.
+func chanChecksum(openChanBucket, nodeChanBucket *bolt.Bucket,
+	chanPoint *wire.OutPoint) ([sha256.Size]byte, error) {
+
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	chanID := b.Bytes()
+
+	h := sha256.New()
+	writeField := func(bucket *bolt.Bucket, key []byte) {
+		value := bucket.Get(key)
+
+		// Each field is prefixed by its length, so that a value can't
+		// be shifted between neighbouring fields unnoticed. A missing
+		// field is distinct from an empty one.
+		var scratch [4]byte
+		if value == nil {
+			byteOrder.PutUint32(scratch[:], ^uint32(0))
+		} else {
+			byteOrder.PutUint32(scratch[:], uint32(len(value)))
+		}
+		h.Write(key)
+		h.Write(scratch[:])
+		h.Write(value)
+	}
+
+	for _, prefix := range checksummedOpenChanPrefixes {
+		writeField(openChanBucket, append(append([]byte(nil),
+			prefix...), chanID...))
+	}
+	for _, prefix := range checksummedNodeChanKeys {
+		writeField(nodeChanBucket, append(append([]byte(nil),
+			prefix...), chanID...))
+	}
+	htlcKey := makeHtlcKey(chanPoint)
+	writeField(nodeChanBucket, htlcKey[:])
+
+	var checksum [sha256.Size]byte
+	copy(checksum[:], h.Sum(nil))
+	return checksum, nil
+}
+
+// putChanChecksum stores the checksum over the current state of the passed
+// channel. It's to be called within each transaction which writes to the
+// channel's state, once all of its writes have been made.
+func putChanChecksum(openChanBucket, nodeChanBucket *bolt.Bucket,
+	chanPoint *wire.OutPoint) error {
+
+	checksum, err := chanChecksum(openChanBucket, nodeChanBucket,
+		chanPoint)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+	key := append(append([]byte(nil), chanChecksumPrefix...), b.Bytes()...)
+
+	return openChanBucket.Put(key, checksum[:])
+}
+
+// deleteChanChecksum deletes the checksum of the channel with the passed
+// serialized channel point.
+func deleteChanChecksum(openChanBucket *bolt.Bucket, chanID []byte) error {
+	key := append(append([]byte(nil), chanChecksumPrefix...), chanID...)
+	return openChanBucket.Delete(key)
+}
+
+// verifyChanChecksum ensures the stored state of the channel with the passed
+// channel point matches the checksum stored along with it, returning a
+// CorruptChannelError otherwise. Channels stored before checksums were
+// introduced have none, so they're only covered once their state is next
+// written.
+func verifyChanChecksum(openChanBucket, nodeChanBucket *bolt.Bucket,
+	chanPoint *wire.OutPoint) error {
+
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, chanPoint); err != nil {
+		return err
+	}
+	key := append(append([]byte(nil), chanChecksumPrefix...), b.Bytes()...)
+	stored := openChanBucket.Get(key)
+	if stored == nil {
+		return nil
+	}
+
+	checksum, err := chanChecksum(openChanBucket, nodeChanBucket,
+		chanPoint)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stored, checksum[:]) {
+		return &CorruptChannelError{
+			ChanPoint: chanPoint,
+			Reason: fmt.Sprintf("stored state doesn't match its "+
+				"checksum %x", stored),
+		}
+	}
+
+	return nil
+}
+
+// verifyChecksums ensures the stored state of every open channel matches its
+// checksum, returning a CorruptChannelError for the first one which doesn't.
+func (d *DB) verifyChecksums() error {
+	return d.store.View(func(tx *bolt.Tx) error {
+		openChanBucket := tx.Bucket(openChannelBucket)
+		if openChanBucket == nil {
+			return nil
+		}
+
+		// As with FetchAllChannels, the nested buckets are those of
+		// each node, while the prefixed keys have non-nil values.
+		return openChanBucket.ForEach(func(nodeID, v []byte) error {
+			if v != nil {
+				return nil
+			}
+			nodeChanBucket := openChanBucket.Bucket(nodeID)
+			if nodeChanBucket == nil {
+				return nil
+			}
+			nodeChanIDBucket := nodeChanBucket.Bucket(chanIDBucket)
+			if nodeChanIDBucket == nil {
+				return nil
+			}
+
+			return nodeChanIDBucket.ForEach(func(k, _ []byte) error {
+				chanPoint := &wire.OutPoint{}
+				err := readOutpoint(bytes.NewReader(k), chanPoint)
+				if err != nil {
+					return err
+				}
+
+				return verifyChanChecksum(openChanBucket,
+					nodeChanBucket, chanPoint)
+			})
+		})
+	})
+}
+
+// VerifyChannels checks the stored state of every open channel, returning a
+// CorruptChannelError for the first channel found to be corrupt. The raw
+// state of each channel is first checked against its checksum, catching
+// corruption which leaves the state readable yet altered, then the decoded
+// state is checked against the invariants of Verify. An error is also
+// returned if any channel state can't be read at all.
+func (d *DB) VerifyChannels() error {
+	if err := d.verifyChecksums(); err != nil {
+		return err
+	}
+
+	channels, err := d.FetchAllChannels()
+	switch {
+	case err == ErrNoActiveChannels:
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to read channel state: %v", err)
+	}
+
+	for _, channel := range channels {
+		if err := channel.Verify(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
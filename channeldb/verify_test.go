@@ -0,0 +1,257 @@
+package channeldb
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcutil"
+)
+
+// createValidChannelState creates a channel state which satisfies all the
+// invariants checked by Verify.
+func createValidChannelState(cdb *DB) (*OpenChannel, error) {
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		return nil, err
+	}
+
+	state.OurBalance = 3000
+	state.TheirBalance = 6000
+	state.Htlcs = []*HTLC{{Amt: 500, RHash: key}}
+
+	state.OurCommitTx = testTx.Copy()
+	state.OurCommitTx.TxIn[0].PreviousOutPoint = *state.FundingOutpoint
+
+	return state, nil
+}
+
+func TestChannelVerify(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	tests := []struct {
+		name    string
+		corrupt func(*OpenChannel)
+		valid   bool
+	}{
+		{
+			name:    "valid",
+			corrupt: func(c *OpenChannel) {},
+			valid:   true,
+		},
+		{
+			name: "balances below capacity",
+			corrupt: func(c *OpenChannel) {
+				c.TheirBalance -= 100
+			},
+			valid: true,
+		},
+		{
+			name: "zero capacity",
+			corrupt: func(c *OpenChannel) {
+				c.Capacity = 0
+			},
+		},
+		{
+			name: "negative balance",
+			corrupt: func(c *OpenChannel) {
+				c.OurBalance = -1
+			},
+		},
+		{
+			name: "exceeds capacity",
+			corrupt: func(c *OpenChannel) {
+				c.OurBalance += 1000
+			},
+		},
+		{
+			name: "htlcs exceed capacity",
+			corrupt: func(c *OpenChannel) {
+				c.Htlcs = append(c.Htlcs, &HTLC{Amt: 1000})
+			},
+		},
+		{
+			name: "non-positive htlc",
+			corrupt: func(c *OpenChannel) {
+				c.Htlcs[0].Amt = 0
+			},
+		},
+		{
+			name: "commitment doesn't spend funding output",
+			corrupt: func(c *OpenChannel) {
+				c.OurCommitTx.TxIn[0].PreviousOutPoint.Index++
+			},
+		},
+	}
+
+	for _, test := range tests {
+		state, err := createValidChannelState(cdb)
+		if err != nil {
+			t.Fatalf("unable to create channel state: %v", err)
+		}
+		test.corrupt(state)
+
+		err = state.Verify()
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		case !test.valid && err == nil:
+			t.Fatalf("%v: corrupt channel state passed", test.name)
+		case !test.valid:
+			if _, ok := err.(*CorruptChannelError); !ok {
+				t.Fatalf("%v: expected CorruptChannelError, "+
+					"got %T", test.name, err)
+			}
+		}
+	}
+}
+
+func TestVerifyChannels(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	// A database without any channels is trivially valid.
+	if err := cdb.VerifyChannels(); err != nil {
+		t.Fatalf("unable to verify empty database: %v", err)
+	}
+
+	state, err := createValidChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	if err := cdb.VerifyChannels(); err != nil {
+		t.Fatalf("unable to verify channels: %v", err)
+	}
+
+	// Once the stored balances no longer add up, the channel should be
+	// reported as corrupt.
+	state.OurBalance = btcutil.Amount(1e8)
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	err = cdb.VerifyChannels()
+	corruptErr, ok := err.(*CorruptChannelError)
+	if !ok {
+		t.Fatalf("expected CorruptChannelError, got %v", err)
+	}
+	if *corruptErr.ChanPoint != *state.ChanID {
+		t.Fatalf("wrong channel reported: expected %v, got %v",
+			state.ChanID, corruptErr.ChanPoint)
+	}
+}
+
+func TestVerifyChannelChecksums(t *testing.T) {
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createValidChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	if err := cdb.VerifyChannels(); err != nil {
+		t.Fatalf("unable to verify channels: %v", err)
+	}
+
+	// Each of the writes to the channel's state should keep its checksum
+	// up to date, while updating its uptime leaves the checksum intact.
+	delta := &ChannelDelta{
+		LocalBalance:  2000,
+		RemoteBalance: 7000,
+		UpdateNum:     1,
+		Htlcs:         state.Htlcs,
+	}
+	err = state.UpdateCommitment(state.OurCommitTx.Copy(),
+		bytes.Repeat([]byte{1}, 71), delta)
+	if err != nil {
+		t.Fatalf("unable to update commitment: %v", err)
+	}
+	if err := state.AppendToRevocationLog(delta); err != nil {
+		t.Fatalf("unable to append to revocation log: %v", err)
+	}
+	err = state.SetShortChanID(lnwire.ShortChannelID{BlockHeight: 1000})
+	if err != nil {
+		t.Fatalf("unable to set short channel id: %v", err)
+	}
+	if err := cdb.AddChannelUptime(state.ChanID, time.Hour); err != nil {
+		t.Fatalf("unable to add uptime: %v", err)
+	}
+	if err := cdb.VerifyChannels(); err != nil {
+		t.Fatalf("unable to verify channels: %v", err)
+	}
+
+	// Alter the stored local balance such that the channel still
+	// satisfies every invariant. Only the checksum can reveal it.
+	var b bytes.Buffer
+	if err := writeOutpoint(&b, state.ChanID); err != nil {
+		t.Fatalf("unable to write outpoint: %v", err)
+	}
+	balanceKey := append(append([]byte(nil), selfBalancePrefix...),
+		b.Bytes()...)
+	checksumKey := append(append([]byte(nil), chanChecksumPrefix...),
+		b.Bytes()...)
+	err = cdb.store.Update(func(tx *bolt.Tx) error {
+		var balance [8]byte
+		byteOrder.PutUint64(balance[:], 1999)
+		return tx.Bucket(openChannelBucket).Put(balanceKey, balance[:])
+	})
+	if err != nil {
+		t.Fatalf("unable to alter balance: %v", err)
+	}
+
+	err = cdb.VerifyChannels()
+	corruptErr, ok := err.(*CorruptChannelError)
+	if !ok {
+		t.Fatalf("expected CorruptChannelError, got %v", err)
+	}
+	if *corruptErr.ChanPoint != *state.ChanID {
+		t.Fatalf("wrong channel reported: expected %v, got %v",
+			state.ChanID, corruptErr.ChanPoint)
+	}
+
+	// A channel stored before checksums were introduced has none, so only
+	// its invariants are checked.
+	err = cdb.store.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(openChannelBucket).Delete(checksumKey)
+	})
+	if err != nil {
+		t.Fatalf("unable to delete checksum: %v", err)
+	}
+	if err := cdb.VerifyChannels(); err != nil {
+		t.Fatalf("unable to verify channels: %v", err)
+	}
+
+	// Closing the channel removes its checksum along with its state.
+	if err := state.FullSync(); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	if err := state.CloseChannel(); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+	err = cdb.store.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(openChannelBucket).Get(checksumKey) != nil {
+			t.Fatalf("checksum of closed channel remains")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read checksum: %v", err)
+	}
+}
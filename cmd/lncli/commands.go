@@ -9,11 +9,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/BitfuryLightning/tools/prefix_tree"
 	"github.com/BitfuryLightning/tools/rt"
 	"github.com/BitfuryLightning/tools/rt/graph"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/urfave/cli"
@@ -25,7 +28,26 @@ import (
 // TODO(roasbeef): cli logic for supporting both positional and unix style
 // arguments.
 
+// jsonOutput, if set via the --json flag, causes responses to be printed in
+// their canonical JSON encoding, suitable for consumption by scripts.
+var jsonOutput bool
+
+// jsonMarshaler encodes responses when jsonOutput is set. Every field is
+// emitted, even those holding default values, under its proto field name,
+// and enums are encoded as their string names. This keeps the schema of the
+// output identical regardless of the contents of a response.
+var jsonMarshaler = &jsonpb.Marshaler{
+	EmitDefaults: true,
+	OrigName:     true,
+	Indent:       "\t",
+}
+
 func printRespJson(resp interface{}) {
+	if jsonOutput {
+		printJson(resp)
+		return
+	}
+
 	b, err := json.Marshal(resp)
 	if err != nil {
 		fatal(err)
@@ -38,6 +60,27 @@ func printRespJson(resp interface{}) {
 	out.WriteTo(os.Stdout)
 }
 
+// printJson prints the passed response in its canonical JSON encoding,
+// terminated by a newline. Responses which aren't proto messages are already
+// described by an explicit set of JSON tags, so they're encoded as is.
+func printJson(resp interface{}) {
+	var out bytes.Buffer
+	if msg, ok := resp.(proto.Message); ok {
+		if err := jsonMarshaler.Marshal(&out, msg); err != nil {
+			fatal(err)
+		}
+	} else {
+		b, err := json.Marshal(resp)
+		if err != nil {
+			fatal(err)
+		}
+		json.Indent(&out, b, "", "\t")
+	}
+
+	out.WriteString("\n")
+	out.WriteTo(os.Stdout)
+}
+
 var ShellCommand = cli.Command{
 	Name:  "shell",
 	Usage: "enter interactive shell",
@@ -52,25 +95,29 @@ var NewAddressCommand = cli.Command{
 	Action: newAddress,
 }
 
-func newAddress(ctx *cli.Context) error {
-	client := getClient(ctx)
-
-	stringAddrType := ctx.Args().Get(0)
-
-	// Map the string encoded address type, to the concrete typed address
-	// type enum. An unrecognized address type will result in an error.
-	var addrType lnrpc.NewAddressRequest_AddressType
+// parseAddressType maps the string encoded address type, to the concrete typed
+// address type enum. An unrecognized address type will result in an error.
+func parseAddressType(stringAddrType string) (lnrpc.NewAddressRequest_AddressType, error) {
 	switch stringAddrType { // TODO(roasbeef): make them ints on the cli?
 	case "p2wkh":
-		addrType = lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH
+		return lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH, nil
 	case "np2wkh":
-		addrType = lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH
+		return lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH, nil
 	case "p2pkh":
-		addrType = lnrpc.NewAddressRequest_PUBKEY_HASH
+		return lnrpc.NewAddressRequest_PUBKEY_HASH, nil
 	default:
-		return fmt.Errorf("invalid address type %v, support address type "+
+		return 0, fmt.Errorf("invalid address type %v, support address type "+
 			"are: p2wkh, np2wkh, p2pkh", stringAddrType)
 	}
+}
+
+func newAddress(ctx *cli.Context) error {
+	client := getClient(ctx)
+
+	addrType, err := parseAddressType(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
 
 	ctxb := context.Background()
 	addr, err := client.NewAddress(ctxb, &lnrpc.NewAddressRequest{
@@ -87,7 +134,7 @@ func newAddress(ctx *cli.Context) error {
 var SendCoinsCommand = cli.Command{
 	Name:        "sendcoins",
 	Description: "send a specified amount of bitcoin to the passed address",
-	Usage:       "sendcoins --addr=<bitcoin addresss> --amt=<num coins in satoshis>",
+	Usage:       "sendcoins --addr=<bitcoin addresss> [--amt=<num coins in satoshis> | --sweepall]",
 	Flags: []cli.Flag{
 		cli.StringFlag{
 			Name:  "addr",
@@ -98,6 +145,15 @@ var SendCoinsCommand = cli.Command{
 			Name:  "amt",
 			Usage: "the number of bitcoin denominated in satoshis to send",
 		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "(optional) the fee rate in satoshis per byte to pay",
+		},
+		cli.BoolFlag{
+			Name: "sweepall",
+			Usage: "send all of the wallet's confirmed funds to the " +
+				"address, less the fee, rather than a set amount",
+		},
 	},
 	Action: sendCoins,
 }
@@ -106,9 +162,15 @@ func sendCoins(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
+	if ctx.Bool("sweepall") && ctx.IsSet("amt") {
+		return fmt.Errorf("amt cannot be set when sweeping all funds")
+	}
+
 	req := &lnrpc.SendCoinsRequest{
-		Addr:   ctx.String("addr"),
-		Amount: int64(ctx.Int("amt")),
+		Addr:       ctx.String("addr"),
+		Amount:     int64(ctx.Int("amt")),
+		SatPerByte: int64(ctx.Int("sat_per_byte")),
+		SendAll:    ctx.Bool("sweepall"),
 	}
 	txid, err := client.SendCoins(ctxb, req)
 	if err != nil {
@@ -119,11 +181,74 @@ func sendCoins(ctx *cli.Context) error {
 	return nil
 }
 
+var ListUnspentCommand = cli.Command{
+	Name: "listunspent",
+	Description: "list the wallet's unspent outputs, optionally " +
+		"restricted to those within a range of confirmations",
+	Usage: "listunspent [--min_confs=<confs>] [--max_confs=<confs>]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "min_confs",
+			Value: 1,
+			Usage: "the minimum number of confirmations of the listed outputs, 0 includes unconfirmed outputs",
+		},
+		cli.IntFlag{
+			Name:  "max_confs",
+			Usage: "(optional) the maximum number of confirmations of the listed outputs",
+		},
+	},
+	Action: listUnspent,
+}
+
+func listUnspent(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ListUnspentRequest{
+		MinConfs: int32(ctx.Int("min_confs")),
+		MaxConfs: int32(ctx.Int("max_confs")),
+	}
+	resp, err := client.ListUnspent(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListAddressesCommand = cli.Command{
+	Name: "listaddresses",
+	Description: "list all addresses derived by the wallet, along with " +
+		"the balance of each",
+	Usage:  "listaddresses",
+	Action: listAddresses,
+}
+
+func listAddresses(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListAddresses(ctxb, &lnrpc.ListAddressesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var SendManyCommand = cli.Command{
 	Name: "sendmany",
 	Description: "create and broadcast a transaction paying the specified " +
 		"amount(s) to the passed address(es)",
-	Usage:  `sendmany '{"ExampleAddr": NumCoinsInSatoshis, "SecondAddr": NumCoins}'`,
+	Usage: `sendmany '{"ExampleAddr": NumCoinsInSatoshis, "SecondAddr": NumCoins}'`,
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "(optional) the fee rate in satoshis per byte to pay",
+		},
+	},
 	Action: sendMany,
 }
 
@@ -138,7 +263,10 @@ func sendMany(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	txid, err := client.SendMany(ctxb, &lnrpc.SendManyRequest{amountToAddr})
+	txid, err := client.SendMany(ctxb, &lnrpc.SendManyRequest{
+		AddrToAmount: amountToAddr,
+		SatPerByte:   int64(ctx.Int("sat_per_byte")),
+	})
 	if err != nil {
 		return err
 	}
@@ -149,7 +277,7 @@ func sendMany(ctx *cli.Context) error {
 
 var ConnectCommand = cli.Command{
 	Name:   "connect",
-	Usage:  "connect to a remote lnd peer: <lnid>@host",
+	Usage:  "connect to a remote lnd peer: <pubkey>@host",
 	Action: connectPeer,
 }
 
@@ -160,7 +288,7 @@ func connectPeer(ctx *cli.Context) error {
 	targetAddress := ctx.Args().Get(0)
 	splitAddr := strings.Split(targetAddress, "@")
 	if len(splitAddr) != 2 {
-		return fmt.Errorf("target address expected in format: lnid@host:port")
+		return fmt.Errorf("target address expected in format: pubkey@host:port")
 	}
 
 	addr := &lnrpc.LightningAddress{
@@ -213,6 +341,52 @@ var OpenChannelCommand = cli.Command{
 			Name:  "block",
 			Usage: "block and wait until the channel is fully open",
 		},
+		cli.BoolFlag{
+			Name:  "private",
+			Usage: "make the channel private, such that it won't be announced to the rest of the network",
+		},
+		cli.IntFlag{
+			Name: "lease_expiry",
+			Usage: "if set, lease the channel to the peer by locking " +
+				"the committed funds until this block height",
+		},
+		cli.IntFlag{
+			Name: "fee_base",
+			Usage: "the base fee in satoshis charged for HTLCs " +
+				"forwarded over the channel, requires " +
+				"time_lock_delta to be set",
+		},
+		cli.IntFlag{
+			Name: "fee_rate",
+			Usage: "the fee rate in millionths charged for HTLCs " +
+				"forwarded over the channel, requires " +
+				"time_lock_delta to be set",
+		},
+		cli.IntFlag{
+			Name: "time_lock_delta",
+			Usage: "if set, the channel's initial forwarding policy " +
+				"uses this time lock delta along with fee_base " +
+				"and fee_rate, rather than the peer's or the " +
+				"node's default policy",
+		},
+		cli.IntFlag{
+			Name: "max_pending_amt",
+			Usage: "if set, the maximum total value in satoshis of " +
+				"HTLCs in flight within the channel, rather " +
+				"than the node's default",
+		},
+		cli.IntFlag{
+			Name: "max_accepted_htlcs",
+			Usage: "if set, the maximum number of HTLCs in flight " +
+				"within the channel, rather than the node's default",
+		},
+		cli.StringFlag{
+			Name: "close_address",
+			Usage: "if set, the address our balance is paid to when " +
+				"the channel is cooperatively closed, rather than " +
+				"the node's default -- the address is committed " +
+				"to, and can't be changed at close time",
+		},
 	},
 	Action: openChannel,
 }
@@ -231,6 +405,22 @@ func openChannel(ctx *cli.Context) error {
 		LocalFundingAmount:  int64(ctx.Int("local_amt")),
 		RemoteFundingAmount: int64(ctx.Int("remote_amt")),
 		NumConfs:            uint32(ctx.Int("num_confs")),
+		Private:             ctx.Bool("private"),
+		LeaseExpiry:         uint32(ctx.Int("lease_expiry")),
+		MaxPendingAmt:       int64(ctx.Int("max_pending_amt")),
+		MaxAcceptedHtlcs:    uint32(ctx.Int("max_accepted_htlcs")),
+		CloseAddress:        ctx.String("close_address"),
+	}
+
+	if ctx.IsSet("time_lock_delta") {
+		req.Policy = &lnrpc.RoutingPolicy{
+			TimeLockDelta: uint32(ctx.Int("time_lock_delta")),
+			FeeBase:       int64(ctx.Int("fee_base")),
+			FeeRate:       uint32(ctx.Int("fee_rate")),
+		}
+	} else if ctx.IsSet("fee_base") || ctx.IsSet("fee_rate") {
+		return fmt.Errorf("time_lock_delta must be set along with " +
+			"fee_base and fee_rate")
 	}
 
 	if ctx.Int("peer_id") != 0 {
@@ -297,6 +487,12 @@ var CloseChannelCommand = cli.Command{
 			Usage: "the output index for the funding output of the funding " +
 				"transaction",
 		},
+		cli.StringFlag{
+			Name: "chan_id",
+			Usage: "the short channel id of the channel, either in " +
+				"compact form or as height:txindex:output, used " +
+				"in place of funding_txid and output_index",
+		},
 		cli.StringFlag{
 			Name: "time_limit",
 			Usage: "a relative deadline afterwhich the attempt should be " +
@@ -307,6 +503,23 @@ var CloseChannelCommand = cli.Command{
 			Usage: "after the time limit has passed, attempt an " +
 				"uncooperative closure",
 		},
+		cli.BoolFlag{
+			Name: "allow_online",
+			Usage: "force close even though the peer is online " +
+				"and the channel could be closed cooperatively",
+		},
+		cli.BoolFlag{
+			Name: "allow_htlcs",
+			Usage: "force close even though HTLCs are in flight, " +
+				"which must then be resolved on-chain",
+		},
+		cli.StringFlag{
+			Name: "delivery_address",
+			Usage: "if set, the address our balance is paid to " +
+				"within a cooperative close, rather than the " +
+				"one negotiated when the channel was opened -- " +
+				"refused if that address was committed to",
+		},
 		cli.BoolFlag{
 			Name:  "block",
 			Usage: "block until the channel is closed",
@@ -319,18 +532,24 @@ func closeChannel(ctx *cli.Context) error {
 	ctxb := context.Background()
 	client := getClient(ctx)
 
-	txid, err := wire.NewShaHashFromStr(ctx.String("funding_txid"))
-	if err != nil {
-		return err
-	}
-
 	// TODO(roasbeef): implement time deadline within server
 	req := &lnrpc.CloseChannelRequest{
-		ChannelPoint: &lnrpc.ChannelPoint{
+		Force:             ctx.Bool("force"),
+		AllowOnlinePeer:   ctx.Bool("allow_online"),
+		AllowPendingHtlcs: ctx.Bool("allow_htlcs"),
+		DeliveryAddress:   ctx.String("delivery_address"),
+	}
+	if ctx.IsSet("chan_id") {
+		req.ChanId, req.Scid = parseChanID(ctx.String("chan_id"))
+	} else {
+		txid, err := wire.NewShaHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+		req.ChannelPoint = &lnrpc.ChannelPoint{
 			FundingTxid: txid[:],
 			OutputIndex: uint32(ctx.Int("output_index")),
-		},
-		Force: ctx.Bool("force"),
+		}
 	}
 
 	stream, err := client.CloseChannel(ctxb, req)
@@ -351,6 +570,19 @@ func closeChannel(ctx *cli.Context) error {
 		}
 
 		switch update := resp.Update.(type) {
+		case *lnrpc.CloseStatusUpdate_ClosePending:
+			txid, err := wire.NewShaHash(update.ClosePending.Txid)
+			if err != nil {
+				return err
+			}
+
+			printRespJson(struct {
+				ClosingTXID string `json:"closing_txid"`
+				CsvDelay    uint32 `json:"csv_delay"`
+			}{
+				ClosingTXID: txid.String(),
+				CsvDelay:    update.ClosePending.CsvDelay,
+			})
 		case *lnrpc.CloseStatusUpdate_ChanClose:
 			closingHash := update.ChanClose.ClosingTxid
 			txid, err := wire.NewShaHash(closingHash)
@@ -390,6 +622,50 @@ func listPeers(ctx *cli.Context) error {
 	return nil
 }
 
+var ListChannelsCommand = cli.Command{
+	Name:        "listchannels",
+	Description: "List all open channels, optionally filtered by their status.",
+	Usage:       "listchannels [--active_only|--inactive_only] [--public_only|--private_only]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "active_only",
+			Usage: "only list channels which are currently active",
+		},
+		cli.BoolFlag{
+			Name:  "inactive_only",
+			Usage: "only list channels which are currently inactive",
+		},
+		cli.BoolFlag{
+			Name:  "public_only",
+			Usage: "only list channels which are announced to the network",
+		},
+		cli.BoolFlag{
+			Name:  "private_only",
+			Usage: "only list channels which aren't announced to the network",
+		},
+	},
+	Action: listChannels,
+}
+
+func listChannels(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ListChannelsRequest{
+		ActiveOnly:   ctx.Bool("active_only"),
+		InactiveOnly: ctx.Bool("inactive_only"),
+		PublicOnly:   ctx.Bool("public_only"),
+		PrivateOnly:  ctx.Bool("private_only"),
+	}
+	resp, err := client.ListChannels(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var WalletBalanceCommand = cli.Command{
 	Name:        "walletbalance",
 	Description: "compute and display the wallet's current balance",
@@ -422,7 +698,7 @@ func walletBalance(ctx *cli.Context) error {
 
 var ChannelBalanceCommand = cli.Command{
 	Name:        "channelbalance",
-	Description: "returns the sum of the total available channel balance across all open channels",
+	Description: "returns the sum of the total available channel balance across all open channels, along with the balance of each open and pending channel",
 	Action: channelBalance,
 }
 
@@ -460,6 +736,29 @@ func getInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var UpdateConfigCommand = cli.Command{
+	Name: "updateconfig",
+	Description: "re-read the daemon's configuration file, applying any " +
+		"changes to the debug levels, per-peer forwarding " +
+		"policies and accepted channel sizes without a restart, " +
+		"just as a SIGHUP does",
+	Action: updateConfig,
+}
+
+func updateConfig(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.UpdateConfigRequest{}
+	resp, err := client.UpdateConfig(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var PendingChannelsCommand = cli.Command{
 	Name:        "pendingchannels",
 	Description: "display information pertaining to pending channels",
@@ -509,6 +808,53 @@ func pendingChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var BumpFeeCommand = cli.Command{
+	Name: "bumpfee",
+	Description: "raise the fee rate of the funding transaction of a " +
+		"pending channel by spending its change output",
+	Usage: "bumpfee --funding_txid=<txid> --output_index=<index> [--sat_per_byte=<fee rate>]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name:  "output_index",
+			Usage: "the output index for the funding output of the funding transaction",
+		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "(optional) the fee rate in satoshis per byte the funding transaction should effectively pay",
+		},
+	},
+	Action: bumpFee,
+}
+
+func bumpFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	txid, err := wire.NewShaHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.BumpFeeRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		SatPerByte: int64(ctx.Int("sat_per_byte")),
+	}
+	resp, err := client.BumpFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
 var SendPaymentCommand = cli.Command{
 	Name:        "sendpayment",
 	Description: "send a payment over lightning",
@@ -526,15 +872,113 @@ var SendPaymentCommand = cli.Command{
 			Name:  "payment_hash, r",
 			Usage: "the hash to use within the payment's HTLC",
 		},
+		cli.StringFlag{
+			Name:  "payment_addr",
+			Usage: "the payment secret contained within the invoice",
+		},
 		cli.BoolFlag{
 			Name: "fast, f",
 			Usage: "skip the HTLC trickle logic, immediately creating a " +
 				"new commitment",
 		},
+		cli.IntFlag{
+			Name: "fee_limit",
+			Usage: "the maximum fee in satoshis to pay for the " +
+				"payment, 0 for no limit",
+		},
+		cli.IntFlag{
+			Name: "timeout",
+			Usage: "the number of seconds to spend retrying the " +
+				"payment along alternate routes",
+		},
+		cli.IntFlag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the recipient requires " +
+				"to remain until the HTLC expires",
+		},
+		cli.IntFlag{
+			Name: "cltv_limit",
+			Usage: "(optional) the maximum number of blocks the " +
+				"payment may be time locked for",
+		},
+		cli.StringSliceFlag{
+			Name: "outgoing_chan_id",
+			Usage: "(optional) the compact short channel id of a " +
+				"channel the payment may depart from, can be " +
+				"repeated",
+		},
+		cli.StringFlag{
+			Name: "last_hop",
+			Usage: "(optional) the hex encoded public key or " +
+				"lightning id of the node the payment must " +
+				"arrive from",
+		},
+		cli.StringSliceFlag{
+			Name: "data",
+			Usage: "a custom record to present to the recipient, " +
+				"given as type=hexvalue, where the type is at " +
+				"least 65536, can be repeated",
+		},
 	},
 	Action: sendPaymentCommand,
 }
 
+// parseRouteRestrictions parses the outgoing channels and last hop a route is
+// restricted to from the command line.
+func parseRouteRestrictions(ctx *cli.Context) ([]uint64, []byte, error) {
+	var outgoingChanIDs []uint64
+	for _, chanID := range ctx.StringSlice("outgoing_chan_id") {
+		compact, err := strconv.ParseUint(chanID, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid outgoing chan id "+
+				"%q: %v", chanID, err)
+		}
+		outgoingChanIDs = append(outgoingChanIDs, compact)
+	}
+
+	var lastHop []byte
+	if ctx.String("last_hop") != "" {
+		var err error
+		lastHop, err = hex.DecodeString(ctx.String("last_hop"))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return outgoingChanIDs, lastHop, nil
+}
+
+// parseCustomRecords parses a set of custom records, each given in the form
+// type=hexvalue.
+func parseCustomRecords(records []string) (map[uint64][]byte, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	customRecords := make(map[uint64][]byte, len(records))
+	for _, record := range records {
+		parts := strings.SplitN(record, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid custom record %q, "+
+				"expected type=hexvalue", record)
+		}
+
+		recordType, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom record type: %v",
+				err)
+		}
+		value, err := hex.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom record value: %v",
+				err)
+		}
+		customRecords[recordType] = value
+	}
+
+	return customRecords, nil
+}
+
 func sendPaymentCommand(ctx *cli.Context) error {
 	client := getClient(ctx)
 
@@ -544,9 +988,41 @@ func sendPaymentCommand(ctx *cli.Context) error {
 	}
 	// TODO(roasbeef): remove debug payment hash
 	req := &lnrpc.SendRequest{
-		Dest:     destAddr,
-		Amt:      int64(ctx.Int("amt")),
-		FastSend: ctx.Bool("fast"),
+		Dest:           destAddr,
+		Amt:            int64(ctx.Int("amt")),
+		FastSend:       ctx.Bool("fast"),
+		FeeLimit:       int64(ctx.Int("fee_limit")),
+		TimeoutSeconds: int64(ctx.Int("timeout")),
+		FinalCltvDelta: uint32(ctx.Int("final_cltv_delta")),
+		CltvLimit:      uint32(ctx.Int("cltv_limit")),
+	}
+
+	if ctx.String("payment_hash") != "" {
+		rHash, err := hex.DecodeString(ctx.String("payment_hash"))
+		if err != nil {
+			return err
+		}
+		req.PaymentHash = rHash
+	}
+
+	if ctx.String("payment_addr") != "" {
+		paymentAddr, err := hex.DecodeString(ctx.String("payment_addr"))
+		if err != nil {
+			return err
+		}
+		req.PaymentAddr = paymentAddr
+	}
+
+	req.DestCustomRecords, err = parseCustomRecords(
+		ctx.StringSlice("data"),
+	)
+	if err != nil {
+		return err
+	}
+
+	req.OutgoingChanIds, req.LastHopPubkey, err = parseRouteRestrictions(ctx)
+	if err != nil {
+		return err
 	}
 
 	paymentStream, err := client.SendPayment(context.Background())
@@ -866,3 +1342,1160 @@ func printRTAsJSON(r *rt.RoutingTable) {
 	}
 	printRespJson(channels)
 }
+
+var DropGraphCommand = cli.Command{
+	Name: "dropgraph",
+	Description: "drop the channel graph and rebuild it from the daemon's " +
+		"own set of open channels. Channel state is never touched.",
+	Action: dropGraph,
+}
+
+func dropGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.DropGraphRequest{}
+	resp, err := client.DropGraph(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ExportGraphCommand = cli.Command{
+	Name: "exportgraph",
+	Description: "export the channel graph to a compact binary snapshot, " +
+		"which a new node on the same network can import at startup " +
+		"(--graphsnapshot) or via importgraph",
+	Usage: "exportgraph --output=[path]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "the file to write the snapshot to",
+		},
+	},
+	Action: exportGraph,
+}
+
+func exportGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if !ctx.IsSet("output") {
+		return fmt.Errorf("an output file must be specified")
+	}
+
+	req := &lnrpc.ExportGraphSnapshotRequest{}
+	resp, err := client.ExportGraphSnapshot(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(ctx.String("output"), resp.Snapshot, 0644); err != nil {
+		return err
+	}
+
+	resp.Snapshot = nil
+	printRespJson(resp)
+	return nil
+}
+
+var ImportGraphCommand = cli.Command{
+	Name: "importgraph",
+	Description: "add the nodes and edges within a snapshot exported by " +
+		"exportgraph to the channel graph",
+	Usage: "importgraph --input=[path]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "input",
+			Usage: "the file to read the snapshot from",
+		},
+	},
+	Action: importGraph,
+}
+
+func importGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if !ctx.IsSet("input") {
+		return fmt.Errorf("an input file must be specified")
+	}
+	snapshot, err := ioutil.ReadFile(ctx.String("input"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ImportGraphSnapshotRequest{
+		Snapshot: snapshot,
+	}
+	resp, err := client.ImportGraphSnapshot(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var QueryRoutesCommand = cli.Command{
+	Name:        "queryroutes",
+	Description: "query the channel graph for a route to a destination",
+	Usage:       "queryroutes --dest=[node_id] --amt=[in_satoshis]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "dest, d",
+			Usage: "lightning ID of the destination node",
+		},
+		cli.IntFlag{
+			Name:  "amt, a",
+			Usage: "number of satoshis the route must be able to carry",
+		},
+		cli.IntFlag{
+			Name: "final_cltv_delta",
+			Usage: "the number of blocks the recipient requires " +
+				"to remain until the HTLC expires",
+		},
+		cli.IntFlag{
+			Name: "cltv_limit",
+			Usage: "(optional) the maximum number of blocks the " +
+				"route may time lock the payment for",
+		},
+		cli.StringSliceFlag{
+			Name: "outgoing_chan_id",
+			Usage: "(optional) the compact short channel id of a " +
+				"channel the route may depart from, can be " +
+				"repeated",
+		},
+		cli.StringFlag{
+			Name: "last_hop",
+			Usage: "(optional) the hex encoded public key or " +
+				"lightning id of the node the route must " +
+				"arrive from",
+		},
+	},
+	Action: queryRoutes,
+}
+
+func queryRoutes(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	dest, err := hex.DecodeString(ctx.String("dest"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.QueryRoutesRequest{
+		Dest:           dest,
+		Amt:            int64(ctx.Int("amt")),
+		FinalCltvDelta: uint32(ctx.Int("final_cltv_delta")),
+		CltvLimit:      uint32(ctx.Int("cltv_limit")),
+	}
+
+	req.OutgoingChanIds, req.LastHopPubkey, err = parseRouteRestrictions(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.QueryRoutes(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var TrackPaymentCommand = cli.Command{
+	Name:        "trackpayment",
+	Description: "track the progress of an outgoing payment",
+	Usage:       "trackpayment --payment_hash=[hash]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash, r",
+			Usage: "the hash of the payment to track",
+		},
+	},
+	Action: trackPayment,
+}
+
+func trackPayment(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	rHash, err := hex.DecodeString(ctx.String("payment_hash"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHash: rHash,
+	}
+	stream, err := client.TrackPayment(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var SubscribePeerEventsCommand = cli.Command{
+	Name: "subscribepeerevents",
+	Description: "stream an event each time a peer comes online or " +
+		"goes offline",
+	Usage:  "subscribepeerevents",
+	Action: subscribePeerEvents,
+}
+
+func subscribePeerEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.PeerEventSubscription{}
+	stream, err := client.SubscribePeerEvents(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var SubscribeHtlcEventsCommand = cli.Command{
+	Name: "subscribehtlcevents",
+	Description: "stream an event each time an HTLC is forwarded, " +
+		"settled, or failed",
+	Usage:  "subscribehtlcevents",
+	Action: subscribeHtlcEvents,
+}
+
+func subscribeHtlcEvents(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.SubscribeHtlcEventsRequest{}
+	stream, err := client.SubscribeHtlcEvents(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var GetNodeInfoCommand = cli.Command{
+	Name:        "getnodeinfo",
+	Description: "look up a node within the channel graph",
+	Usage:       "getnodeinfo --pub_key=[node_key]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "pub_key",
+			Usage: "the compressed public key, or lightning ID, " +
+				"of the target node",
+		},
+	},
+	Action: getNodeInfo,
+}
+
+func getNodeInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	pubKey, err := hex.DecodeString(ctx.String("pub_key"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.NodeInfoRequest{
+		PubKey: pubKey,
+	}
+	resp, err := client.GetNodeInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var GetNodeMetricsCommand = cli.Command{
+	Name:        "getnodemetrics",
+	Description: "compute the centrality of every node within the channel graph",
+	Usage:       "getnodemetrics [--eigenvector]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "eigenvector",
+			Usage: "also compute the eigenvector centrality " +
+				"of each node",
+		},
+	},
+	Action: getNodeMetrics,
+}
+
+func getNodeMetrics(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.NodeMetricsRequest{
+		IncludeEigenvector: ctx.Bool("eigenvector"),
+	}
+	resp, err := client.GetNodeMetrics(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var GetChanInfoCommand = cli.Command{
+	Name:        "getchaninfo",
+	Description: "look up a channel within the channel graph",
+	Usage:       "getchaninfo --funding_txid=[txid] --output_index=[index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the " +
+				"funding transaction",
+		},
+	},
+	Action: getChanInfo,
+}
+
+func getChanInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	txid, err := wire.NewShaHashFromStr(ctx.String("funding_txid"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ChanInfoRequest{
+		ChanPoint: &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+	}
+	resp, err := client.GetChanInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var LookupChanIDCommand = cli.Command{
+	Name: "lookupchanid",
+	Description: "convert between the channel point and short channel id " +
+		"of one of our channels",
+	Usage: "lookupchanid --funding_txid=[txid] --output_index=[index] " +
+		"| --chan_id=[chan_id]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the " +
+				"funding transaction",
+		},
+		cli.StringFlag{
+			Name: "chan_id",
+			Usage: "the short channel id of the channel, either in " +
+				"compact form or as height:txindex:output",
+		},
+	},
+	Action: lookupChanID,
+}
+
+func lookupChanID(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ChannelIDRequest{}
+	if ctx.IsSet("chan_id") {
+		req.ChanId, req.Scid = parseChanID(ctx.String("chan_id"))
+	} else {
+		txid, err := wire.NewShaHashFromStr(ctx.String("funding_txid"))
+		if err != nil {
+			return err
+		}
+		req.ChanPoint = &lnrpc.ChannelPoint{
+			FundingTxid: txid[:],
+			OutputIndex: uint32(ctx.Int("output_index")),
+		}
+	}
+
+	resp, err := client.LookupChannelID(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+// parseChanID interprets a short channel id passed on the command line. A
+// plain integer is treated as the compact form, while anything else is passed
+// through to be parsed as height:txindex:output by the daemon.
+func parseChanID(chanID string) (uint64, string) {
+	if compact, err := strconv.ParseUint(chanID, 10, 64); err == nil {
+		return compact, ""
+	}
+	return 0, chanID
+}
+
+var FeeReportCommand = cli.Command{
+	Name: "feereport",
+	Description: "display the forwarding policy of each open channel, " +
+		"along with the fees earned forwarding HTLCs over the " +
+		"trailing day, week, and month",
+	Usage:  "feereport",
+	Action: feeReport,
+}
+
+func feeReport(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.FeeReport(ctxb, &lnrpc.FeeReportRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListMacaroonIDsCommand = cli.Command{
+	Name: "listmacaroonids",
+	Description: "list the IDs of the root keys macaroons are issued " +
+		"under -- requires the admin macaroon",
+	Usage:  "listmacaroonids",
+	Action: listMacaroonIDs,
+}
+
+func listMacaroonIDs(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListMacaroonIDs(ctxb,
+		&lnrpc.ListMacaroonIDsRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var DeleteMacaroonIDCommand = cli.Command{
+	Name: "deletemacaroonid",
+	Description: "delete the root key with the given ID, revoking every " +
+		"macaroon issued under it, while macaroons issued under other " +
+		"root keys remain valid -- the root keys 1 to 5 of the " +
+		"macaroons lnd writes to its data directory can't be " +
+		"deleted, requires the admin macaroon",
+	Usage:  "deletemacaroonid <root_key_id>",
+	Action: deleteMacaroonID,
+}
+
+func deleteMacaroonID(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("root key id argument missing")
+	}
+	rootKeyID, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid root key id: %v", err)
+	}
+
+	resp, err := client.DeleteMacaroonID(ctxb,
+		&lnrpc.DeleteMacaroonIDRequest{RootKeyId: rootKeyID})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var RotateMacaroonRootKeyCommand = cli.Command{
+	Name: "rotatemacaroonrootkey",
+	Description: "delete every macaroon root key, revoking all " +
+		"previously baked macaroons -- the macaroons lnd writes to " +
+		"its data directory remain valid, requires the admin " +
+		"macaroon",
+	Usage:  "rotatemacaroonrootkey",
+	Action: rotateMacaroonRootKey,
+}
+
+func rotateMacaroonRootKey(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.RotateMacaroonRootKey(ctxb,
+		&lnrpc.RotateMacaroonRootKeyRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var BakeMacaroonCommand = cli.Command{
+	Name: "bakemacaroon",
+	Description: "issue a new macaroon granting the given permissions, " +
+		"each of signer, rescue, lightning or admin, optionally " +
+		"restricted to expire after a timeout, to be used only from " +
+		"a range of IP addresses, and to call only certain RPC " +
+		"methods -- requires the admin macaroon",
+	Usage: "bakemacaroon [--root_key_id=N] [--timeout=S] [--ip_range=CIDR] " +
+		"[--allow_uri=U...] [--deny_uri=U...] [--save_to=F] " +
+		"<permissions...>",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name: "root_key_id",
+			Usage: "(optional) the ID of the root key to issue the " +
+				"macaroon under, allowing it to be revoked with " +
+				"deletemacaroonid, other than the reserved 1 to 5",
+		},
+		cli.IntFlag{
+			Name: "timeout",
+			Usage: "(optional) the number of seconds after which " +
+				"the macaroon expires",
+		},
+		cli.StringFlag{
+			Name: "ip_range",
+			Usage: "(optional) the CIDR range of IP addresses the " +
+				"macaroon may be used from, e.g. 192.168.1.0/24",
+		},
+		cli.StringSliceFlag{
+			Name: "allow_uri",
+			Usage: "(optional) the full URI of an RPC method the " +
+				"macaroon may be used to call, e.g. " +
+				"/lnrpc.Lightning/NewAddress -- if given, the " +
+				"macaroon may call no other methods, can be " +
+				"repeated",
+		},
+		cli.StringSliceFlag{
+			Name: "deny_uri",
+			Usage: "(optional) the full URI of an RPC method the " +
+				"macaroon may not be used to call, can be " +
+				"repeated",
+		},
+		cli.StringFlag{
+			Name: "save_to",
+			Usage: "(optional) write the macaroon to this file " +
+				"rather than printing it",
+		},
+	},
+	Action: bakeMacaroon,
+}
+
+func bakeMacaroon(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if len(ctx.Args()) == 0 {
+		return fmt.Errorf("permissions argument missing")
+	}
+	if ctx.Int("root_key_id") < 0 {
+		return fmt.Errorf("root key id must be positive")
+	}
+
+	req := &lnrpc.BakeMacaroonRequest{
+		Permissions: ctx.Args(),
+		RootKeyId:   uint64(ctx.Int("root_key_id")),
+		Timeout:     int64(ctx.Int("timeout")),
+		IpRange:     ctx.String("ip_range"),
+		AllowUris:   ctx.StringSlice("allow_uri"),
+		DenyUris:    ctx.StringSlice("deny_uri"),
+	}
+	resp, err := client.BakeMacaroon(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("save_to") {
+		macaroon, err := hex.DecodeString(resp.Macaroon)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(ctx.String("save_to"), macaroon, 0600)
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListTransactionsCommand = cli.Command{
+	Name:        "listchaintxns",
+	Description: "list transactions from the wallet",
+	Usage:       "listchaintxns",
+	Action:      listChainTxns,
+}
+
+func listChainTxns(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.GetTransactions(ctxb, &lnrpc.GetTransactionsRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SubscribeTransactionsCommand = cli.Command{
+	Name:        "subscribechaintxns",
+	Description: "stream new transactions relevant to the wallet as they're seen or confirmed",
+	Usage:       "subscribechaintxns",
+	Action:      subscribeChainTxns,
+}
+
+func subscribeChainTxns(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	stream, err := client.SubscribeTransactions(ctxb,
+		&lnrpc.GetTransactionsRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		tx, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(tx)
+	}
+}
+
+var LabelTransactionCommand = cli.Command{
+	Name:        "labeltx",
+	Description: "attach a label to a transaction relevant to the wallet",
+	Usage:       "labeltx --txid=<txid> --label=<label> [--overwrite]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "txid",
+			Usage: "the id of the transaction to label",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "the label to attach to the transaction",
+		},
+		cli.BoolFlag{
+			Name:  "overwrite",
+			Usage: "replace any existing label on the transaction",
+		},
+	},
+	Action: labelTransaction,
+}
+
+func labelTransaction(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.LabelTransactionRequest{
+		Txid:      ctx.String("txid"),
+		Label:     ctx.String("label"),
+		Overwrite: ctx.Bool("overwrite"),
+	}
+	resp, err := client.LabelTransaction(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ConsolidateUtxosCommand = cli.Command{
+	Name: "consolidateutxos",
+	Description: "merge all wallet outputs below a value into a single " +
+		"output, best done while on-chain fees are low",
+	Usage: "consolidateutxos --max_utxo_value=<satoshis> [--sat_per_byte=<fee rate>] [--min_utxos=<count>]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "max_utxo_value",
+			Usage: "only outputs valued below this amount in satoshis are consolidated",
+		},
+		cli.IntFlag{
+			Name:  "sat_per_byte",
+			Usage: "(optional) the fee rate in satoshis per byte to pay",
+		},
+		cli.IntFlag{
+			Name:  "min_utxos",
+			Usage: "(optional) the minimum number of outputs which must be eligible",
+		},
+	},
+	Action: consolidateUtxos,
+}
+
+func consolidateUtxos(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.ConsolidateUtxosRequest{
+		MaxUtxoValue: int64(ctx.Int("max_utxo_value")),
+		SatPerByte:   int64(ctx.Int("sat_per_byte")),
+		MinUtxos:     uint32(ctx.Int("min_utxos")),
+	}
+	resp, err := client.ConsolidateUtxos(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+// leaseFlags are the flags shared by the commands which lease and release
+// wallet outputs.
+var leaseFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "id",
+		Usage: "the hex-encoded 32-byte lease id",
+	},
+	cli.StringFlag{
+		Name:  "txid",
+		Usage: "the txid of the output",
+	},
+	cli.IntFlag{
+		Name:  "output_index",
+		Usage: "the output index of the output",
+	},
+}
+
+var LeaseOutputCommand = cli.Command{
+	Name: "leaseoutput",
+	Description: "lock a wallet output for a period of time, excluding " +
+		"it from channel funding and on-chain sends",
+	Usage: "leaseoutput --id=<lease id> --txid=<txid> --output_index=<index> [--expiry=<seconds>]",
+	Flags: append([]cli.Flag{
+		cli.IntFlag{
+			Name:  "expiry",
+			Usage: "(optional) the duration of the lease in seconds",
+		},
+	}, leaseFlags...),
+	Action: leaseOutput,
+}
+
+func leaseOutput(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	id, err := hex.DecodeString(ctx.String("id"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.LeaseOutputRequest{
+		Id: id,
+		Outpoint: &lnrpc.OutPoint{
+			Txid:        ctx.String("txid"),
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+		ExpirationSeconds: uint64(ctx.Int("expiry")),
+	}
+	resp, err := client.LeaseOutput(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ReleaseOutputCommand = cli.Command{
+	Name:        "releaseoutput",
+	Description: "release a wallet output previously locked via leaseoutput",
+	Usage:       "releaseoutput --id=<lease id> --txid=<txid> --output_index=<index>",
+	Flags:       leaseFlags,
+	Action:      releaseOutput,
+}
+
+func releaseOutput(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	id, err := hex.DecodeString(ctx.String("id"))
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ReleaseOutputRequest{
+		Id: id,
+		Outpoint: &lnrpc.OutPoint{
+			Txid:        ctx.String("txid"),
+			OutputIndex: uint32(ctx.Int("output_index")),
+		},
+	}
+	resp, err := client.ReleaseOutput(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ListLeasesCommand = cli.Command{
+	Name:        "listleases",
+	Description: "list all currently leased wallet outputs",
+	Usage:       "listleases",
+	Action:      listLeases,
+}
+
+func listLeases(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ListLeases(ctxb, &lnrpc.ListLeasesRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ImportPublicKeyCommand = cli.Command{
+	Name: "importpubkey",
+	Description: "import a public key as watch-only, tracking the balance " +
+		"of its corresponding address. Three address types are " +
+		"supported: p2wkh, np2wkh, p2pkh",
+	Usage: "importpubkey --pubkey=<hex pubkey> [--addr_type=<type>]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pubkey",
+			Usage: "the hex-encoded compressed public key to import",
+		},
+		cli.StringFlag{
+			Name:  "addr_type",
+			Value: "p2wkh",
+			Usage: "the type of address to derive from the key",
+		},
+	},
+	Action: importPublicKey,
+}
+
+func importPublicKey(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	pubKey, err := hex.DecodeString(ctx.String("pubkey"))
+	if err != nil {
+		return err
+	}
+	addrType, err := parseAddressType(ctx.String("addr_type"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ImportPublicKey(ctxb, &lnrpc.ImportPublicKeyRequest{
+		PublicKey:   pubKey,
+		AddressType: addrType,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var ImportAddressCommand = cli.Command{
+	Name:        "importaddress",
+	Description: "import an address as watch-only, tracking its balance",
+	Usage:       "importaddress <address>",
+	Action:      importAddress,
+}
+
+func importAddress(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	resp, err := client.ImportAddress(ctxb, &lnrpc.ImportAddressRequest{
+		Address: ctx.Args().First(),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var GetRecoveryInfoCommand = cli.Command{
+	Name: "getrecoveryinfo",
+	Description: "report the progress of the scan for the funds of a " +
+		"wallet restored from an existing seed",
+	Usage:  "getrecoveryinfo",
+	Action: getRecoveryInfo,
+}
+
+func getRecoveryInfo(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.GetRecoveryInfoRequest{}
+	resp, err := client.GetRecoveryInfo(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var RescanWalletCommand = cli.Command{
+	Name: "rescanwallet",
+	Description: "rescan the chain for transactions relevant to the " +
+		"wallet, e.g. after importing watch-only keys",
+	Usage: "rescanwallet --start_height=[height] | " +
+		"--birthday=[timestamp]",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "start_height",
+			Usage: "the height of the block to begin the rescan at",
+		},
+		cli.IntFlag{
+			Name: "birthday",
+			Usage: "the unix timestamp at which the wallet or imported " +
+				"keys were created, used in place of start_height",
+		},
+	},
+	Action: rescanWallet,
+}
+
+func rescanWallet(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.RescanRequest{
+		StartHeight:       int32(ctx.Int("start_height")),
+		BirthdayTimestamp: int64(ctx.Int("birthday")),
+	}
+	stream, err := client.RescanWallet(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var SendOnionMessageCommand = cli.Command{
+	Name: "sendonionmessage",
+	Description: "send a message to the last node along a path of node " +
+		"public keys, relayed within an onion message by each of the " +
+		"nodes before it -- the first node must be a connected peer",
+	Usage: "sendonionmessage --type=T [--data=D] <pubkeys...>",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name: "type",
+			Usage: "the type of the message, which must be at " +
+				"least 64",
+		},
+		cli.StringFlag{
+			Name:  "data",
+			Usage: "(optional) the hex-encoded content of the message",
+		},
+	},
+	Action: sendOnionMessage,
+}
+
+func sendOnionMessage(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if len(ctx.Args()) == 0 {
+		return fmt.Errorf("path argument missing")
+	}
+	if ctx.Int("type") < 0 {
+		return fmt.Errorf("message type must be positive")
+	}
+	data, err := hex.DecodeString(ctx.String("data"))
+	if err != nil {
+		return fmt.Errorf("unable to decode data: %v", err)
+	}
+
+	req := &lnrpc.SendOnionMessageRequest{
+		Path: ctx.Args(),
+		Type: uint64(ctx.Int("type")),
+		Data: data,
+	}
+	resp, err := client.SendOnionMessage(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SubscribeOnionMessagesCommand = cli.Command{
+	Name: "subscribeonionmessages",
+	Description: "stream each onion message addressed to this node as " +
+		"it's received",
+	Usage:  "subscribeonionmessages",
+	Action: subscribeOnionMessages,
+}
+
+func subscribeOnionMessages(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.SubscribeOnionMessagesRequest{}
+	stream, err := client.SubscribeOnionMessages(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
+
+var SendCustomMessageCommand = cli.Command{
+	Name: "sendcustommessage",
+	Description: "send a custom message of an application protocol to a " +
+		"connected peer -- the message type must be odd, and at " +
+		"least 32768",
+	Usage: "sendcustommessage --lightning_id=I --type=T [--data=D]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "lightning_id",
+			Usage: "the lightning ID of the peer to send the message to",
+		},
+		cli.IntFlag{
+			Name:  "type",
+			Usage: "the type of the message",
+		},
+		cli.StringFlag{
+			Name:  "data",
+			Usage: "(optional) the hex-encoded content of the message",
+		},
+	},
+	Action: sendCustomMessage,
+}
+
+func sendCustomMessage(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	if !ctx.IsSet("lightning_id") {
+		return fmt.Errorf("lightning_id argument missing")
+	}
+	if ctx.Int("type") < 0 {
+		return fmt.Errorf("message type must be positive")
+	}
+	data, err := hex.DecodeString(ctx.String("data"))
+	if err != nil {
+		return fmt.Errorf("unable to decode data: %v", err)
+	}
+
+	req := &lnrpc.SendCustomMessageRequest{
+		LightningId: ctx.String("lightning_id"),
+		Type:        uint32(ctx.Int("type")),
+		Data:        data,
+	}
+	resp, err := client.SendCustomMessage(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJson(resp)
+	return nil
+}
+
+var SubscribeCustomMessagesCommand = cli.Command{
+	Name: "subscribecustommessages",
+	Description: "stream each custom message received from a connected " +
+		"peer",
+	Usage:  "subscribecustommessages",
+	Action: subscribeCustomMessages,
+}
+
+func subscribeCustomMessages(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client := getClient(ctx)
+
+	req := &lnrpc.SubscribeCustomMessagesRequest{}
+	stream, err := client.SubscribeCustomMessages(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printRespJson(resp)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+)
+
+// TestJsonMarshaler tests that responses printed with --json carry every
+// field under its proto name, including those holding default values, and
+// encode enums as their string names.
+func TestJsonMarshaler(t *testing.T) {
+	var out bytes.Buffer
+	err := jsonMarshaler.Marshal(&out, &lnrpc.HtlcEvent{
+		EventType: lnrpc.HtlcEvent_SETTLE,
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal response: %v", err)
+	}
+
+	for _, field := range []string{
+		`"event_type": "SETTLE"`,
+		`"failure_reason": ""`,
+		`"outgoing_channel_point": ""`,
+	} {
+		if !strings.Contains(out.String(), field) {
+			t.Fatalf("expected field %v within %v", field,
+				out.String())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+var CompletionCommand = cli.Command{
+	Name: "completion",
+	Description: "print a shell completion script for lncli. Two shells " +
+		"are supported: bash, zsh. To enable completions, source the " +
+		"output within your shell's startup file, for example: " +
+		"source <(lncli completion bash)",
+	Usage:  "completion <bash|zsh>",
+	Action: completion,
+}
+
+func completion(ctx *cli.Context) error {
+	script := bashCompletion(ctx.App)
+
+	switch shell := ctx.Args().First(); shell {
+	case "bash":
+	case "zsh":
+		// zsh is able to interpret bash completion scripts once its
+		// compatibility layer has been loaded.
+		script = "autoload -U +X compinit && compinit\n" +
+			"autoload -U +X bashcompinit && bashcompinit\n" + script
+	default:
+		return fmt.Errorf("invalid shell %q, supported shells are: "+
+			"bash, zsh", shell)
+	}
+
+	fmt.Print(script)
+	return nil
+}
+
+// flagNames returns the command line forms of each of the passed flags,
+// including any aliases.
+func flagNames(flags []cli.Flag) []string {
+	var names []string
+	for _, flag := range flags {
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			name = strings.TrimSpace(name)
+			if len(name) == 1 {
+				names = append(names, "-"+name)
+			} else {
+				names = append(names, "--"+name)
+			}
+		}
+	}
+
+	return names
+}
+
+// commandWords returns the words which may follow the passed command: its
+// subcommands, and its flags.
+func commandWords(command cli.Command) []string {
+	var words []string
+	for _, sub := range command.Subcommands {
+		words = append(words, sub.Name)
+	}
+
+	return append(words, flagNames(command.Flags)...)
+}
+
+// bashCompletion generates a bash completion script for every command of the
+// passed app. The command being completed is located by scanning the words
+// typed so far for the first known command name, which allows global flags
+// to precede it.
+func bashCompletion(app *cli.App) string {
+	commands := make([]string, 0, len(app.Commands))
+	for _, command := range app.Commands {
+		commands = append(commands, command.Name)
+	}
+	sort.Strings(commands)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "_%s() {\n", app.Name)
+	b.WriteString("\tlocal cur cmd opts word\n")
+	b.WriteString("\tCOMPREPLY=()\n")
+	b.WriteString("\tcur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(&b, "\tlocal commands=\"%s\"\n\n", strings.Join(commands, " "))
+	b.WriteString("\tfor word in \"${COMP_WORDS[@]:1:COMP_CWORD-1}\"; do\n")
+	b.WriteString("\t\tcase \" ${commands} \" in\n")
+	b.WriteString("\t\t*\" ${word} \"*)\n")
+	b.WriteString("\t\t\tcmd=\"${word}\"\n")
+	b.WriteString("\t\t\tbreak\n")
+	b.WriteString("\t\t\t;;\n")
+	b.WriteString("\t\tesac\n")
+	b.WriteString("\tdone\n\n")
+
+	b.WriteString("\tcase \"${cmd}\" in\n")
+	for _, command := range app.Commands {
+		fmt.Fprintf(&b, "\t%s)\n", command.Name)
+		fmt.Fprintf(&b, "\t\topts=\"%s\"\n",
+			strings.Join(commandWords(command), " "))
+		b.WriteString("\t\t;;\n")
+	}
+	b.WriteString("\t*)\n")
+	fmt.Fprintf(&b, "\t\topts=\"${commands} %s\"\n",
+		strings.Join(flagNames(app.Flags), " "))
+	b.WriteString("\t\t;;\n")
+	b.WriteString("\tesac\n\n")
+
+	b.WriteString("\tCOMPREPLY=( $(compgen -W \"${opts}\" -- \"${cur}\") )\n")
+	b.WriteString("\treturn 0\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "complete -F _%s %s\n", app.Name, app.Name)
+
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli"
+)
+
+// TestBashCompletion tests that the completion script offers each command's
+// subcommands and flags once the command has been typed, and the commands
+// along with the global flags before then.
+func TestBashCompletion(t *testing.T) {
+	app := cli.NewApp()
+	app.Name = "lncli"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "rpcserver"},
+		cli.BoolFlag{Name: "json"},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name: "openchannel",
+			Flags: []cli.Flag{
+				cli.IntFlag{Name: "local_amt"},
+				cli.BoolFlag{Name: "block, b"},
+			},
+		},
+		{
+			Name: "macaroon",
+			Subcommands: []cli.Command{
+				{Name: "bake"},
+				{Name: "revoke"},
+			},
+		},
+	}
+
+	words := commandWords(app.Commands[0])
+	expected := []string{"--local_amt", "--block", "-b"}
+	if !reflect.DeepEqual(words, expected) {
+		t.Fatalf("expected openchannel words %v, got %v", expected,
+			words)
+	}
+	words = commandWords(app.Commands[1])
+	expected = []string{"bake", "revoke"}
+	if !reflect.DeepEqual(words, expected) {
+		t.Fatalf("expected macaroon words %v, got %v", expected, words)
+	}
+
+	script := bashCompletion(app)
+	for _, line := range []string{
+		`local commands="macaroon openchannel"`,
+		`opts="--local_amt --block -b"`,
+		`opts="bake revoke"`,
+		`opts="${commands} --rpcserver --json"`,
+		"complete -F _lncli lncli",
+	} {
+		if !strings.Contains(script, line) {
+			t.Fatalf("completion script missing %q:\n%v", line,
+				script)
+		}
+	}
+}
@@ -2,14 +2,21 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/urfave/cli"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
+// maxMsgRecvSize is the largest message lncli will accept from lnd, matching
+// the default send limit of lnd's rpc server.
+const maxMsgRecvSize = 200 * 1024 * 1024
+
 func fatal(err error) {
 	fmt.Fprintf(os.Stderr, "[lncli] %v\n", err)
 	os.Exit(1)
@@ -21,11 +28,38 @@ func getClient(ctx *cli.Context) lnrpc.LightningClient {
 }
 
 func getClientConn(ctx *cli.Context) *grpc.ClientConn {
-	// TODO(roasbeef): macaroon based auth
-	// * http://www.grpc.io/docs/guides/auth.html
-	// * http://research.google.com/pubs/pub41892.html
-	// * https://github.com/go-macaroon/macaroon
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMsgRecvSize),
+		),
+	}
+
+	// A remote lnd serving a publicly valid certificate is verified
+	// against the system's root certificates.
+	if ctx.GlobalBool("tls") {
+		creds := credentials.NewClientTLSFromCert(nil, "")
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	// If a macaroon was given, it's attached to every request, as is
+	// required should lnd gate the lnrpc.Lightning service behind
+	// macaroons.
+	if macPath := ctx.GlobalString("macaroonpath"); macPath != "" {
+		serialized, err := ioutil.ReadFile(macPath)
+		if err != nil {
+			fatal(fmt.Errorf("unable to read macaroon: %v", err))
+		}
+		m, err := macaroons.Deserialize(serialized)
+		if err != nil {
+			fatal(fmt.Errorf("unable to decode macaroon: %v", err))
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(
+			macaroons.Credential{Macaroon: m},
+		))
+	}
 
 	conn, err := grpc.Dial(ctx.GlobalString("rpcserver"), opts...)
 	if err != nil {
@@ -46,22 +80,78 @@ func main() {
 			Value: "localhost:10009",
 			Usage: "host:port of ln daemon",
 		},
+		cli.BoolFlag{
+			Name: "tls",
+			Usage: "connect over TLS, as required by an lnd serving " +
+				"a Let's Encrypt certificate via letsencryptdomain",
+		},
+		cli.StringFlag{
+			Name: "macaroonpath",
+			Usage: "path to the macaroon to present to lnd, such as " +
+				"lightning.macaroon within lnd's data directory",
+		},
+		cli.BoolFlag{
+			Name: "json",
+			Usage: "print responses in their canonical JSON encoding " +
+				"with every field present, and enums as strings",
+		},
+	}
+	app.Before = func(ctx *cli.Context) error {
+		jsonOutput = ctx.GlobalBool("json")
+		return nil
 	}
 	app.Commands = []cli.Command{
 		NewAddressCommand,
 		SendManyCommand,
 		SendCoinsCommand,
+		ListUnspentCommand,
+		ListAddressesCommand,
 		ConnectCommand,
 		OpenChannelCommand,
 		CloseChannelCommand,
 		ListPeersCommand,
+		SubscribePeerEventsCommand,
+		ListChannelsCommand,
 		WalletBalanceCommand,
 		ChannelBalanceCommand,
 		ShellCommand,
 		GetInfoCommand,
+		UpdateConfigCommand,
 		PendingChannelsCommand,
+		BumpFeeCommand,
 		SendPaymentCommand,
 		ShowRoutingTableCommand,
+		DropGraphCommand,
+		ExportGraphCommand,
+		ImportGraphCommand,
+		QueryRoutesCommand,
+		TrackPaymentCommand,
+		SubscribeHtlcEventsCommand,
+		GetNodeInfoCommand,
+		GetNodeMetricsCommand,
+		GetChanInfoCommand,
+		LookupChanIDCommand,
+		FeeReportCommand,
+		ListMacaroonIDsCommand,
+		DeleteMacaroonIDCommand,
+		RotateMacaroonRootKeyCommand,
+		BakeMacaroonCommand,
+		SendOnionMessageCommand,
+		SubscribeOnionMessagesCommand,
+		SendCustomMessageCommand,
+		SubscribeCustomMessagesCommand,
+		ListTransactionsCommand,
+		SubscribeTransactionsCommand,
+		LabelTransactionCommand,
+		ConsolidateUtxosCommand,
+		LeaseOutputCommand,
+		ReleaseOutputCommand,
+		ListLeasesCommand,
+		ImportPublicKeyCommand,
+		ImportAddressCommand,
+		RescanWalletCommand,
+		GetRecoveryInfoCommand,
+		CompletionCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math"
+	"net"
 	"os"
 	"path/filepath"
 	"sort"
@@ -9,7 +12,9 @@ import (
 	"strings"
 
 	flags "github.com/btcsuite/go-flags"
+	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
 )
 
 const (
@@ -25,6 +30,45 @@ const (
 	defaultRPCUser        = "user"
 	defaultRPCPass        = "passwd"
 	defaultSPVHostAdr     = "localhost:18333"
+	defaultTrickleDelay   = 300
+	defaultCoinSelection  = "largest-first"
+
+	defaultMaxPendingChannels = 1
+	defaultFundingMinConfs    = lnwallet.DefaultFundingMinConfs
+	defaultFundingTimeout     = 2016
+	defaultRecoveryWindow     = 2500
+
+	defaultIncomingBroadcastDelta = 10
+	defaultOutgoingBroadcastDelta = 10
+
+	defaultMaxCltvExpiry = 2016
+
+	defaultStallTimeout = 60
+
+	defaultMaxInboundPeers = 125
+	defaultMaxPeersPerIP   = 5
+
+	defaultRPCMaxRecvMsgSize   = 4 * 1024 * 1024
+	defaultRPCMaxSendMsgSize   = 200 * 1024 * 1024
+	defaultRPCKeepAliveTime    = 60
+	defaultRPCKeepAliveTimeout = 20
+	defaultRPCCacheTTL         = 5
+
+	defaultHWIBin = "hwi"
+
+	defaultLetsEncryptListen = ":80"
+
+	// The defaults applied by the low memory profile in place of those
+	// above.
+	lowMemTrickleDelay            = 2000
+	lowMemGossipMinCapacity       = 1000000
+	lowMemMaxInboundPeers         = 16
+	lowMemRPCMaxSendMsgSize       = 50 * 1024 * 1024
+	lowMemRPCMaxConcurrentStreams = 16
+	lowMemRPCCacheTTL             = 0
+	lowMemGCPercent               = 50
+	lowMemDBAllocSize             = 1024 * 1024
+	lowMemDBMaxConcurrentReads    = 4
 )
 
 var (
@@ -49,12 +93,92 @@ type config struct {
 	LogDir     string `long:"logdir" description:"Directory to log output."`
 
 	Listeners   []string `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 10011)"`
-	ExternalIPs []string `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
+	ExternalIPs []string `long:"externalip" description:"Add an ip (IPv4, IPv6, or onion) to the list of local addresses we claim to listen on to peers"`
+	NAT         bool     `long:"nat" description:"Toggle NAT traversal support (using either UPnP or NAT-PMP) to automatically discover and advertise the external IP address"`
+
+	DebugLevel string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems -- reloaded from the configuration file upon SIGHUP or the UpdateConfig RPC"`
+
+	Alias string `long:"alias" description:"The human readable name to advertise for this node"`
+
+	CoinSelectionStrategy string `long:"coinselectionstrategy" description:"The order in which unspent outputs are selected to fund channels and on-chain sends {largest-first, random, smallest-first} -- smallest-first consolidates small outputs over time and is best used when fees are low"`
+
+	RemoteSigner         string `long:"remotesigner" description:"The host:port of a remote signer's signrpc service -- if set, lnd runs watch-only, holding only public keys and forwarding all signing requests to the remote signer. The wallet must be a copy of the remote signer's wallet, it will be stripped of its private keys on first start"`
+	RemoteSignerCert     string `long:"remotesignercert" description:"The path of the remote signer's TLS certificate, signer.cert within its data directory -- the certificate is pinned, so the connection is only made to the holder of its key"`
+	RemoteSignerMacaroon string `long:"remotesignermacaroon" description:"The path of the macaroon presented to the remote signer, remotesigner.macaroon within its data directory"`
+	SignerListen         string `long:"signerlisten" description:"Serve the signrpc service on the given host:port over TLS, allowing a watch-only node to forward its signing requests to this node -- the watch-only node must be given signer.cert and remotesigner.macaroon from the data directory. NOTE the service hands out revocation secrets, so the macaroon must only be given to the watch-only node"`
+
+	TrickleDelay int `long:"trickledelay" description:"Time in milliseconds between each release of routing messages to the network"`
+
+	GossipMinCapacity int64 `long:"gossipmincapacity" description:"The smallest channel capacity in satoshis to accept from the routing tables of peers -- smaller channels are neither stored nor relayed, trimming the routing table on constrained devices, 0 accepts channels of any capacity"`
+
+	NoVerifyChannels bool `long:"noverifychannels" description:"Skip checking the stored state of every channel against its checksum and the invariants of a valid state at startup -- the check reads each channel in full, while skipping it risks acting upon a corrupt state"`
+
+	GraphSnapshot string `long:"graphsnapshot" description:"The path of a graph snapshot, as exported by the ExportGraphSnapshot RPC of another node on the same network, to import into the channel graph at startup -- skips the initial sync of the graph, with nodes and edges already known with a more recent update left untouched"`
+
+	MaxInboundPeers int `long:"maxinboundpeers" description:"The maximum number of inbound peers -- once reached, a new inbound peer evicts an existing inbound peer we have no channels with, or is refused if there is none, 0 disables the limit"`
+	MaxPeersPerIP   int `long:"maxpeersperip" description:"The maximum number of inbound peers connecting from a single IP address -- 0 disables the limit"`
+
+	AddPeers []string `long:"addpeer" description:"A peer to connect to at startup, given as <pubkey>@<host>:<port> -- the connection is re-dialed whenever it's lost, may be specified multiple times"`
+
+	PeerPolicies []string `long:"peerpolicy" description:"The default forwarding policy of new channels with a particular peer, given as <lightning_id>,<fee_base>,<fee_rate>,<time_lock_delta> -- may be specified multiple times, and is reloaded from the configuration file upon SIGHUP or the UpdateConfig RPC"`
+
+	CloseAddress string `long:"closeaddress" description:"The address, such as one of a cold wallet, our balance is delivered to upon the cooperative close of a channel -- new channels commit to the address as an upfront shutdown script, so the payout can't be redirected even should the node later be compromised. Only P2PKH, P2SH, and P2WKH addresses are supported"`
+
+	SweepAddress string `long:"sweepaddress" description:"The address, such as one of a cold wallet, the funds recovered from force closed channels are swept to, rather than the internal wallet -- the same address is used for every sweep"`
+	SweepXPub    string `long:"sweepxpub" description:"An extended public key, such as the account key of a cold wallet, the funds recovered from force closed channels are swept to, rather than the internal wallet -- each sweep pays to a fresh P2WKH address derived from the key's external branch (xpub/0/i)"`
+
+	HWIFingerprint string `long:"hwi.fingerprint" description:"The master key fingerprint of a hardware wallet which is to hold the on-chain funds of the wallet, as reported by hwi enumerate -- new addresses, including those receiving change and our balance upon cooperative close, are derived from the device's account, and spending from them must be confirmed on the device, while the keys of channels remain in software"`
+	HWIXPub        string `long:"hwi.xpub" description:"The extended public key of the hardware wallet's account, as reported by hwi getxpub for the account's derivation path"`
+	HWIPath        string `long:"hwi.path" description:"The derivation path of the hardware wallet's account (default: m/84'/<coin type>'/0')"`
+	HWIBin         string `long:"hwi.bin" description:"The path of the hwi executable used to communicate with the hardware wallet"`
+
+	MaxPendingAmt    int64 `long:"maxpendingamt" description:"The default maximum total value in satoshis of HTLC's in flight in either direction within a new channel -- 0 allows up to the channel's capacity"`
+	MaxAcceptedHtlcs int   `long:"maxacceptedhtlcs" description:"The default maximum number of HTLC's in flight in either direction within a new channel"`
+
+	MinChanSize int64 `long:"minchansize" description:"The smallest channel size in satoshis that we should accept -- incoming channels smaller than this will be rejected -- reloaded from the configuration file upon SIGHUP or the UpdateConfig RPC"`
+	MaxChanSize int64 `long:"maxchansize" description:"The largest channel size in satoshis that we should accept -- incoming channels larger than this will be rejected, 0 accepts channels of any size -- reloaded from the configuration file upon SIGHUP or the UpdateConfig RPC"`
+
+	WumboChannels bool `long:"protocol.wumbo-channels" description:"Accept and open channels larger than 2^24 - 1 satoshis with peers which also support them -- NOTE larger channels put more funds at risk should a bug or breach occur"`
+
+	MaxPendingChannels int `long:"maxpendingchannels" description:"The maximum number of channels awaiting confirmation that a single peer may have with us -- further incoming channels from the peer will be rejected"`
+
+	FundingMinConfs         int  `long:"fundingminconfs" description:"The number of confirmations an output requires before it may be used to fund a channel"`
+	AllowUnconfirmedFunding bool `long:"allow-unconfirmed-funding" description:"Allow channels to be funded from unconfirmed outputs -- NOTE the channel will never confirm should a parent of the funding transaction be double spent"`
+	FundingTimeout          int  `long:"fundingtimeout" description:"The number of blocks after which a pending channel whose funding transaction hasn't confirmed is forgotten -- if we initiated the channel, the inputs of the funding transaction are double spent back to the wallet"`
+
+	IncomingBroadcastDelta int `long:"incomingbroadcastdelta" description:"The number of blocks before an incoming HTLC whose preimage we know expires at which the channel is force closed should the HTLC still be outstanding, so the HTLC can be claimed on-chain"`
+	OutgoingBroadcastDelta int `long:"outgoingbroadcastdelta" description:"The number of blocks before an outgoing HTLC expires at which the channel is force closed should the peer have neither settled nor failed the HTLC, preserving the window in which the HTLC can be claimed upstream -- 0 waits until the HTLC has expired"`
+
+	MaxCltvExpiry int `long:"max-cltv-expiry" description:"The maximum number of blocks funds may be time locked for by an outgoing payment -- routes which would lock funds for longer are refused, as are HTLCs offered to us which expire further in the future, and each payment may set a lower limit"`
+
+	StallTimeout int `long:"stalltimeout" description:"Time in seconds to wait for the remote peer to revoke a commitment we've signed before the channel is considered stalled, and the peer is reconnected -- 0 disables stall detection"`
+
+	RestoreSeed    string `long:"restoreseed" description:"The hex encoded HD seed of an existing wallet to restore from -- only used when the wallet is first created, after which the chain is scanned for the wallet's funds"`
+	RecoveryWindow int    `long:"recoverywindow" description:"The number of addresses to derive ahead and scan the chain for when restoring a wallet with restoreseed"`
+
+	Rescue bool `long:"rescue" description:"Enable the rescuerpc service, which sweeps the funds of force closed channels given only the wallet's seed and each channel's static parameters -- intended for recovery after the channel database has been lost, access requires the rescue macaroon"`
 
-	DebugLevel string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	RequireMacaroons bool `long:"requiremacaroons" description:"Require a macaroon to call the lnrpc.Lightning service, issuing the lightning macaroon which grants full access to it -- macaroons of narrower scope, such as one restricted to certain RPC methods, can be baked with the BakeMacaroon RPC using admin.macaroon"`
+
+	LetsEncryptDomain string `long:"letsencryptdomain" description:"Serve the rpc server over TLS on all interfaces, using a publicly valid certificate for this domain obtained from Let's Encrypt and renewed automatically ahead of its expiry -- the domain must resolve to this host, and requiremacaroons must be set"`
+	LetsEncryptListen string `long:"letsencryptlisten" description:"The address on which to answer the HTTP challenges of Let's Encrypt proving our control of the domain, which must be reachable on port 80 of the domain"`
+
+	RPCMaxRecvMsgSize       int `long:"rpcmaxrecvmsgsize" description:"The maximum size in bytes of a message the rpc server will receive"`
+	RPCMaxSendMsgSize       int `long:"rpcmaxsendmsgsize" description:"The maximum size in bytes of a message the rpc server will send -- clients must be able to receive messages of this size, such as a listing of a large channel graph"`
+	RPCKeepAliveTime        int `long:"rpckeepalivetime" description:"Time in seconds a connection to the rpc server may be idle before it's pinged, keeping long-lived streams alive through proxies -- 0 disables keepalive pings"`
+	RPCKeepAliveTimeout     int `long:"rpckeepalivetimeout" description:"Time in seconds to wait for the reply to a keepalive ping before the connection is closed"`
+	RPCMaxConcurrentStreams int `long:"rpcmaxconcurrentstreams" description:"The maximum number of concurrent streams, including in-flight unary calls, over each connection to the rpc server -- 0 imposes no limit"`
+	RPCCacheTTL             int `long:"rpccachettl" description:"Time in seconds the responses of expensive read-only RPCs, such as a listing of the channel graph, are cached for -- responses are recomputed sooner should the graph be updated, 0 disables caching"`
+
+	DBAllocSize          int `long:"dballocsize" description:"The number of bytes the database files grow by once full, with the memory mapping of each growing along with it -- 0 uses bolt's default of 16MB"`
+	DBMaxConcurrentReads int `long:"dbmaxconcurrentreads" description:"The maximum number of read transactions of the channel database open at once, further reads waiting for one to finish -- 0 imposes no limit"`
 
 	Profile string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 
+	RPCReflection bool `long:"rpcreflection" description:"Enable gRPC server reflection on the rpc server, allowing debugging tools such as grpcurl to list and describe its methods without the proto files -- if requiremacaroons is set, reflection requires the lightning macaroon, otherwise any client able to reach the rpc server may use it"`
+
+	LowMem bool `long:"lowmem" description:"Run with tighter defaults suited to devices with little memory, such as a Raspberry Pi -- routing messages are released less often, small channels are dropped from the routing tables of peers, fewer inbound peers and concurrent rpc streams are admitted, rpc responses aren't cached, the databases grow in smaller increments with fewer concurrent reads, and garbage is collected more eagerly unless GOGC is set. Options set explicitly take precedence over the profile"`
+
 	PeerPort int    `long:"peerport" description:"The port to listen on for incoming p2p connections"`
 	RPCPort  int    `long:"rpcport" description:"The port for the rpc server"`
 	SPVMode  bool   `long:"spv" description:"assert to enter spv wallet mode"`
@@ -80,18 +204,42 @@ type config struct {
 // 	4) Parse CLI options and overwrite/add any specified options
 func loadConfig() (*config, error) {
 	defaultCfg := config{
-		ConfigFile: defaultConfigFile,
-		DataDir:    defaultDataDir,
-		DebugLevel: defaultLogLevel,
-		LogDir:     defaultLogDir,
-		PeerPort:   defaultPeerPort,
-		RPCPort:    defaultRPCPort,
-		SPVMode:    defaultSPVMode,
-		RPCHost:    defaultRPCHost,
-		RPCUser:    defaultRPCUser,
-		RPCPass:    defaultRPCPass,
-		RPCCert:    defaultRPCCertFile,
-		SPVHostAdr: defaultSPVHostAdr,
+		ConfigFile:   defaultConfigFile,
+		DataDir:      defaultDataDir,
+		DebugLevel:   defaultLogLevel,
+		LogDir:       defaultLogDir,
+		PeerPort:     defaultPeerPort,
+		RPCPort:      defaultRPCPort,
+		SPVMode:      defaultSPVMode,
+		RPCHost:      defaultRPCHost,
+		RPCUser:      defaultRPCUser,
+		RPCPass:      defaultRPCPass,
+		RPCCert:      defaultRPCCertFile,
+		SPVHostAdr:   defaultSPVHostAdr,
+		TrickleDelay: defaultTrickleDelay,
+
+		CoinSelectionStrategy: defaultCoinSelection,
+		MaxAcceptedHtlcs:      lnwallet.MaxPendingPayments,
+		MaxPendingChannels:    defaultMaxPendingChannels,
+		FundingMinConfs:       defaultFundingMinConfs,
+		FundingTimeout:        defaultFundingTimeout,
+		RecoveryWindow:        defaultRecoveryWindow,
+
+		IncomingBroadcastDelta: defaultIncomingBroadcastDelta,
+		OutgoingBroadcastDelta: defaultOutgoingBroadcastDelta,
+		MaxCltvExpiry:          defaultMaxCltvExpiry,
+		StallTimeout:           defaultStallTimeout,
+
+		MaxInboundPeers: defaultMaxInboundPeers,
+		MaxPeersPerIP:   defaultMaxPeersPerIP,
+
+		RPCMaxRecvMsgSize:   defaultRPCMaxRecvMsgSize,
+		RPCMaxSendMsgSize:   defaultRPCMaxSendMsgSize,
+		RPCKeepAliveTime:    defaultRPCKeepAliveTime,
+		RPCKeepAliveTimeout: defaultRPCKeepAliveTimeout,
+		RPCCacheTTL:         defaultRPCCacheTTL,
+		HWIBin:              defaultHWIBin,
+		LetsEncryptListen:   defaultLetsEncryptListen,
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -129,15 +277,10 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
-	// Next, load any additional configuration options from the file.
-	cfg := defaultCfg
-	if err := flags.IniParse(preCfg.ConfigFile, &cfg); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-	}
-
-	// Finally, parse the remaining command line options again to ensure
-	// they take precedence.
-	if _, err := flags.Parse(&cfg); err != nil {
+	// Next, load any additional configuration options from the file,
+	// followed by the remaining command line options.
+	cfg, err := parseConfig(defaultCfg, preCfg.ConfigFile, os.Args[1:])
+	if err != nil {
 		return nil, err
 	}
 
@@ -172,6 +315,307 @@ func loadConfig() (*config, error) {
 		}
 	}
 
+	// The trickle delay must be positive in order to periodically release
+	// routing messages to the network.
+	if cfg.TrickleDelay <= 0 {
+		str := "%s: The trickle delay must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the default limits on HTLC's in flight within new channels
+	// are sane.
+	if cfg.MaxPendingAmt < 0 {
+		str := "%s: The maxpendingamt option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.MaxAcceptedHtlcs <= 0 || cfg.MaxAcceptedHtlcs > math.MaxUint16 {
+		str := "%s: The maxacceptedhtlcs option must be between 1 " +
+			"and %d"
+		err := fmt.Errorf(str, funcName, math.MaxUint16)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// The accepted range of incoming channel sizes must be non-empty.
+	if err := validateChanSizes(cfg.MinChanSize, cfg.MaxChanSize); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.DBAllocSize < 0 || cfg.DBMaxConcurrentReads < 0 {
+		str := "%s: The dballocsize and dbmaxconcurrentreads " +
+			"options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.GossipMinCapacity < 0 {
+		str := "%s: The gossipmincapacity option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// At least a single pending channel per peer must be permitted in
+	// order for any incoming channels to be accepted at all.
+	if cfg.MaxPendingChannels <= 0 {
+		str := "%s: The maxpendingchannels option must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Funding from unconfirmed outputs must be explicitly opted into.
+	if cfg.FundingMinConfs <= 0 {
+		str := "%s: The fundingminconfs option must be positive -- " +
+			"use allow-unconfirmed-funding to fund channels from " +
+			"unconfirmed outputs"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.FundingTimeout <= 0 {
+		str := "%s: The fundingtimeout option must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.IncomingBroadcastDelta < 0 || cfg.OutgoingBroadcastDelta < 0 {
+		str := "%s: The incomingbroadcastdelta and " +
+			"outgoingbroadcastdelta options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.MaxCltvExpiry <= 0 {
+		str := "%s: The max-cltv-expiry option must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.StallTimeout < 0 {
+		str := "%s: The stalltimeout option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.MaxInboundPeers < 0 || cfg.MaxPeersPerIP < 0 {
+		str := "%s: The maxinboundpeers and maxpeersperip options " +
+			"must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Ensure the limits and keepalive parameters of the rpc server are
+	// sane.
+	if cfg.RPCMaxRecvMsgSize <= 0 || cfg.RPCMaxSendMsgSize <= 0 {
+		str := "%s: The rpcmaxrecvmsgsize and rpcmaxsendmsgsize " +
+			"options must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RPCKeepAliveTime < 0 || cfg.RPCMaxConcurrentStreams < 0 ||
+		cfg.RPCCacheTTL < 0 {
+
+		str := "%s: The rpckeepalivetime, rpcmaxconcurrentstreams, " +
+			"and rpccachettl options must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RPCKeepAliveTime > 0 && cfg.RPCKeepAliveTimeout <= 0 {
+		str := "%s: The rpckeepalivetimeout option must be positive " +
+			"when keepalive pings are enabled"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.RestoreSeed != "" {
+		seed, err := hex.DecodeString(cfg.RestoreSeed)
+		if err != nil || len(seed) < hdkeychain.MinSeedBytes ||
+			len(seed) > hdkeychain.MaxSeedBytes {
+
+			str := "%s: The restoreseed option must be a hex " +
+				"encoded seed of %v to %v bytes"
+			err := fmt.Errorf(str, funcName,
+				hdkeychain.MinSeedBytes, hdkeychain.MaxSeedBytes)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
+	if cfg.RecoveryWindow < 0 {
+		str := "%s: The recoverywindow option must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Sweeping funds requires private keys, which a watch-only node
+	// doesn't hold.
+	if cfg.Rescue && cfg.RemoteSigner != "" {
+		str := "%s: The rescue and remotesigner options can't be " +
+			"used together"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Once reachable beyond localhost, the rpc server must not be callable
+	// by anyone without a macaroon.
+	if cfg.LetsEncryptDomain != "" && !cfg.RequireMacaroons {
+		str := "%s: The letsencryptdomain option requires the " +
+			"requiremacaroons option"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the per-peer policy table up front, so a typo is reported
+	// at startup rather than silently ignored.
+	if _, err := parsePeerPolicies(cfg.PeerPolicies); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Likewise, every persistent peer must be dialable.
+	if _, err := parsePersistentPeers(cfg.AddPeers); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// The close address must be valid for the active network, and of a
+	// type that can be used as a delivery script.
+	if cfg.CloseAddress != "" {
+		if _, err := parseDeliveryAddress(cfg.CloseAddress); err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
+	// Funds can only be swept to a single destination.
+	if cfg.SweepAddress != "" && cfg.SweepXPub != "" {
+		str := "%s: The sweepaddress and sweepxpub options can't be " +
+			"used together"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.SweepAddress != "" {
+		if _, err := parseSweepAddress(cfg.SweepAddress); err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+	if cfg.SweepXPub != "" {
+		if _, err := parseSweepXPub(cfg.SweepXPub); err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
+	if _, err := parseHWIConfig(&cfg); err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// The remote signer is only reached over an authenticated connection,
+	// so both its certificate and our macaroon are required.
+	if cfg.RemoteSigner != "" && (cfg.RemoteSignerCert == "" ||
+		cfg.RemoteSignerMacaroon == "") {
+
+		str := "%s: The remotesigner option requires the " +
+			"remotesignercert and remotesignermacaroon options"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RemoteSigner != "" {
+		cfg.RemoteSignerCert = cleanAndExpandPath(cfg.RemoteSignerCert)
+		cfg.RemoteSignerMacaroon = cleanAndExpandPath(
+			cfg.RemoteSignerMacaroon)
+	}
+
+	// A watch-only node holds no private keys, so it's unable to sign on
+	// behalf of another node.
+	if cfg.RemoteSigner != "" && cfg.SignerListen != "" {
+		str := "%s: The remotesigner and signerlisten options can't " +
+			"be used together"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// If no listeners were specified, then we'll listen on all interfaces
+	// using the configured peer port. All listening and external
+	// addresses lacking a port are assigned the peer port.
+	peerPort := strconv.Itoa(cfg.PeerPort)
+	if len(cfg.Listeners) == 0 {
+		cfg.Listeners = []string{net.JoinHostPort("", peerPort)}
+	}
+	cfg.Listeners = normalizeAddresses(cfg.Listeners, peerPort)
+	cfg.ExternalIPs = normalizeAddresses(cfg.ExternalIPs, peerPort)
+
+	// Ensure that each of the external addresses is one that we're able
+	// to advertise to the rest of the network.
+	for _, addr := range cfg.ExternalIPs {
+		if err := validateExternalAddr(addr); err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Append the network type to the data directory so it is "namespaced"
 	// per network. In addition to the block database, there are other
 	// pieces of data that are saved to disk such as address manager state.
@@ -201,6 +645,119 @@ func loadConfig() (*config, error) {
 	return &cfg, nil
 }
 
+// lowMemConfig returns a copy of the passed configuration with the options
+// bounding our memory usage set to the defaults of the low memory profile.
+func lowMemConfig(cfg config) config {
+	cfg.TrickleDelay = lowMemTrickleDelay
+	cfg.GossipMinCapacity = lowMemGossipMinCapacity
+	cfg.MaxInboundPeers = lowMemMaxInboundPeers
+	cfg.RPCMaxSendMsgSize = lowMemRPCMaxSendMsgSize
+	cfg.RPCMaxConcurrentStreams = lowMemRPCMaxConcurrentStreams
+	cfg.RPCCacheTTL = lowMemRPCCacheTTL
+	cfg.DBAllocSize = lowMemDBAllocSize
+	cfg.DBMaxConcurrentReads = lowMemDBMaxConcurrentReads
+
+	return cfg
+}
+
+// parseConfig loads the options within the passed configuration file on top
+// of the given defaults, followed by the passed command line arguments which
+// take precedence. Errors reading the configuration file are reported, but
+// don't prevent the command line arguments from being parsed. The low memory
+// profile swaps in its own defaults, so should it be selected, the options
+// are parsed once more on top of them to ensure any set explicitly still take
+// precedence.
+func parseConfig(defaults config, configFile string,
+	args []string) (config, error) {
+
+	parse := func(cfg config) (config, error) {
+		if err := flags.IniParse(configFile, &cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		if _, err := flags.ParseArgs(&cfg, args); err != nil {
+			return config{}, err
+		}
+
+		return cfg, nil
+	}
+
+	cfg, err := parse(defaults)
+	if err != nil || !cfg.LowMem {
+		return cfg, err
+	}
+
+	return parse(lowMemConfig(defaults))
+}
+
+// normalizeAddresses returns a new slice with all the passed addresses
+// normalized with the given default port and all duplicates removed.
+func normalizeAddresses(addrs []string, defaultPort string) []string {
+	result := make([]string, 0, len(addrs))
+	seen := make(map[string]struct{})
+	for _, addr := range addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			// Strip any brackets surrounding a bare IPv6 address
+			// so they aren't doubled up when the port is added.
+			host := strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+			addr = net.JoinHostPort(host, defaultPort)
+		}
+
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		result = append(result, addr)
+	}
+
+	return result
+}
+
+// validateExternalAddr ensures that the passed address, which must already
+// include a port, is one that can be advertised to the network: either an
+// IPv4 or IPv6 address, or a Tor onion service.
+func validateExternalAddr(addr string) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid external address %v: %v", addr, err)
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port for external address %v", addr)
+	}
+
+	if net.ParseIP(host) != nil || isOnionHost(host) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid external address %v: must be an IPv4, "+
+		"IPv6, or onion address", addr)
+}
+
+// isOnionHost returns true if the passed host is a well formed Tor onion
+// service address, either of the 16 character (v2) or 56 character (v3)
+// variety.
+func isOnionHost(host string) bool {
+	const onionSuffix = ".onion"
+	if !strings.HasSuffix(host, onionSuffix) {
+		return false
+	}
+
+	service := strings.TrimSuffix(host, onionSuffix)
+	if len(service) != 16 && len(service) != 56 {
+		return false
+	}
+
+	for _, c := range service {
+		if !(c >= 'a' && c <= 'z') && !(c >= '2' && c <= '7') {
+			return false
+		}
+	}
+
+	return true
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the
 // passed path, cleans the result, and returns it.
 func cleanAndExpandPath(path string) string {
@@ -215,6 +772,21 @@ func cleanAndExpandPath(path string) string {
 	return filepath.Clean(os.ExpandEnv(path))
 }
 
+// validateChanSizes ensures the range of sizes of the incoming channels we
+// accept, as set by the minchansize and maxchansize options, is non-empty.
+func validateChanSizes(minChanSize, maxChanSize int64) error {
+	if minChanSize < 0 || maxChanSize < 0 {
+		return fmt.Errorf("the minchansize and maxchansize options " +
+			"must not be negative")
+	}
+	if maxChanSize != 0 && minChanSize > maxChanSize {
+		return fmt.Errorf("the minchansize option must not exceed " +
+			"maxchansize")
+	}
+
+	return nil
+}
+
 // parseAndSetDebugLevels attempts to parse the specified debug level and set
 // the levels accordingly. An appropriate error is returned if anything is
 // invalid.
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestLowMemConfig tests that the low memory profile swaps in its own
+// defaults for the options bounding our memory usage, leaving all others
+// untouched.
+func TestLowMemConfig(t *testing.T) {
+	defaults := config{
+		DataDir:           defaultDataDir,
+		TrickleDelay:      defaultTrickleDelay,
+		MaxInboundPeers:   defaultMaxInboundPeers,
+		RPCMaxSendMsgSize: defaultRPCMaxSendMsgSize,
+		RPCCacheTTL:       defaultRPCCacheTTL,
+	}
+
+	cfg := lowMemConfig(defaults)
+	expected := config{
+		DataDir:                 defaultDataDir,
+		TrickleDelay:            lowMemTrickleDelay,
+		GossipMinCapacity:       lowMemGossipMinCapacity,
+		MaxInboundPeers:         lowMemMaxInboundPeers,
+		RPCMaxSendMsgSize:       lowMemRPCMaxSendMsgSize,
+		RPCMaxConcurrentStreams: lowMemRPCMaxConcurrentStreams,
+		RPCCacheTTL:             lowMemRPCCacheTTL,
+		DBAllocSize:             lowMemDBAllocSize,
+		DBMaxConcurrentReads:    lowMemDBMaxConcurrentReads,
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Fatalf("expected low memory config %+v, got %+v", expected,
+			cfg)
+	}
+
+	// The passed configuration must be left as it was.
+	if defaults.MaxInboundPeers != defaultMaxInboundPeers {
+		t.Fatalf("defaults modified by the low memory profile")
+	}
+}
+
+// TestParseConfigLowMem tests that the low memory profile may be selected
+// within either the configuration file or the command line, and that options
+// set explicitly within either take precedence over the profile's defaults.
+func TestParseConfigLowMem(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	defaults := config{
+		TrickleDelay:    defaultTrickleDelay,
+		MaxInboundPeers: defaultMaxInboundPeers,
+		RPCCacheTTL:     defaultRPCCacheTTL,
+	}
+
+	tests := []struct {
+		name   string
+		file   string
+		args   []string
+		lowMem bool
+
+		trickleDelay    int
+		maxInboundPeers int
+		rpcCacheTTL     int
+	}{
+		{
+			name:            "defaults",
+			trickleDelay:    defaultTrickleDelay,
+			maxInboundPeers: defaultMaxInboundPeers,
+			rpcCacheTTL:     defaultRPCCacheTTL,
+		},
+		{
+			name:            "profile on command line",
+			args:            []string{"--lowmem"},
+			lowMem:          true,
+			trickleDelay:    lowMemTrickleDelay,
+			maxInboundPeers: lowMemMaxInboundPeers,
+			rpcCacheTTL:     lowMemRPCCacheTTL,
+		},
+		{
+			name:            "profile within file",
+			file:            "lowmem=1\n",
+			lowMem:          true,
+			trickleDelay:    lowMemTrickleDelay,
+			maxInboundPeers: lowMemMaxInboundPeers,
+			rpcCacheTTL:     lowMemRPCCacheTTL,
+		},
+		{
+			name:            "overrides within file and command line",
+			file:            "lowmem=1\nmaxinboundpeers=40\n",
+			args:            []string{"--rpccachettl=7"},
+			lowMem:          true,
+			trickleDelay:    lowMemTrickleDelay,
+			maxInboundPeers: 40,
+			rpcCacheTTL:     7,
+		},
+		{
+			name:            "command line overrides file",
+			file:            "maxinboundpeers=40\n",
+			args:            []string{"--lowmem", "--maxinboundpeers=50"},
+			lowMem:          true,
+			trickleDelay:    lowMemTrickleDelay,
+			maxInboundPeers: 50,
+			rpcCacheTTL:     lowMemRPCCacheTTL,
+		},
+	}
+
+	for i, test := range tests {
+		configFile := filepath.Join(tempDirName, "missing.conf")
+		if test.file != "" {
+			configFile = filepath.Join(tempDirName,
+				fmt.Sprintf("%d.conf", i))
+			err := ioutil.WriteFile(configFile, []byte(test.file),
+				0600)
+			if err != nil {
+				t.Fatalf("unable to write config file: %v", err)
+			}
+		}
+
+		cfg, err := parseConfig(defaults, configFile, test.args)
+		if err != nil {
+			t.Fatalf("#%d %s: unable to parse config: %v", i,
+				test.name, err)
+		}
+		if cfg.LowMem != test.lowMem {
+			t.Fatalf("#%d %s: expected lowmem %v, got %v", i,
+				test.name, test.lowMem, cfg.LowMem)
+		}
+		if cfg.TrickleDelay != test.trickleDelay {
+			t.Fatalf("#%d %s: expected trickle delay %v, got %v",
+				i, test.name, test.trickleDelay,
+				cfg.TrickleDelay)
+		}
+		if cfg.MaxInboundPeers != test.maxInboundPeers {
+			t.Fatalf("#%d %s: expected max inbound peers %v, "+
+				"got %v", i, test.name, test.maxInboundPeers,
+				cfg.MaxInboundPeers)
+		}
+		if cfg.RPCCacheTTL != test.rpcCacheTTL {
+			t.Fatalf("#%d %s: expected rpc cache ttl %v, got %v",
+				i, test.name, test.rpcCacheTTL, cfg.RPCCacheTTL)
+		}
+	}
+
+	// An invalid option on the command line must fail the parse.
+	_, err = parseConfig(defaults, filepath.Join(tempDirName,
+		"missing.conf"), []string{"--lowmem", "--bogus"})
+	if err == nil {
+		t.Fatalf("invalid option accepted")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// customMessage is a custom message received from one of our peers.
+type customMessage struct {
+	lightningID wire.ShaHash
+	peerID      int32
+
+	msg *lnwire.Custom
+}
+
+// customMessageSubscription is a client subscription to all custom messages
+// received from our peers. Each message is delivered over the messages
+// channel.
+type customMessageSubscription struct {
+	id uint64
+
+	messages chan *customMessage
+
+	cancel func()
+}
+
+// customMessageNotifier dispatches the custom messages received from our
+// peers to all subscribed clients, which implement the protocols the messages
+// belong to. Messages are delivered on a best-effort basis: slow subscribers
+// miss messages rather than stalling the peer they were received from.
+type customMessageNotifier struct {
+	subscribers   map[uint64]*customMessageSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newCustomMessageNotifier creates a new customMessageNotifier without any
+// subscribers.
+func newCustomMessageNotifier() *customMessageNotifier {
+	return &customMessageNotifier{
+		subscribers: make(map[uint64]*customMessageSubscription),
+	}
+}
+
+// subscribe creates a new subscription to all custom messages received after
+// this call returns.
+func (n *customMessageNotifier) subscribe() *customMessageSubscription {
+	n.subscriberMtx.Lock()
+	defer n.subscriberMtx.Unlock()
+
+	clientID := n.nextClientID
+	n.nextClientID++
+
+	sub := &customMessageSubscription{
+		id:       clientID,
+		messages: make(chan *customMessage, 20),
+	}
+	sub.cancel = func() {
+		n.subscriberMtx.Lock()
+		delete(n.subscribers, clientID)
+		n.subscriberMtx.Unlock()
+	}
+	n.subscribers[clientID] = sub
+
+	return sub
+}
+
+// notify delivers the passed custom message, received from the target peer,
+// to all current subscribers. As custom messages are always of an odd type,
+// they're simply ignored if there are no subscribers.
+func (n *customMessageNotifier) notify(p *peer, msg *lnwire.Custom) {
+	n.subscriberMtx.Lock()
+	defer n.subscriberMtx.Unlock()
+
+	if len(n.subscribers) == 0 {
+		peerLog.Debugf("Ignoring custom message of type %v from %v, "+
+			"no subscribers", msg.Type, p)
+		return
+	}
+
+	customMsg := &customMessage{
+		lightningID: p.lightningID,
+		peerID:      p.id,
+		msg:         msg,
+	}
+	for _, sub := range n.subscribers {
+		select {
+		case sub.messages <- customMsg:
+		default:
+			peerLog.Warnf("Dropping custom message for slow "+
+				"subscriber %v", sub.id)
+		}
+	}
+}
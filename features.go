@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// featureSet identifies the context in which a set of features is advertised.
+type featureSet uint8
+
+const (
+	// featureSetInit is the set of features advertised within the Init
+	// message sent to each peer upon connection.
+	featureSetInit featureSet = iota
+
+	// featureSetNodeAnn is the set of features advertised within our
+	// entry in the channel graph.
+	featureSetNodeAnn
+
+	// featureSetInvoice is the set of features advertised within the
+	// invoices we create.
+	featureSetInvoice
+)
+
+// defaultFeatures is the set of feature bits advertised by the daemon within
+// each feature set.
+var defaultFeatures = map[featureSet][]lnwire.FeatureBit{
+	featureSetInit: {
+		lnwire.PaymentAddrOptional,
+		lnwire.OnionMessagesOptional,
+	},
+	featureSetNodeAnn: {
+		lnwire.PaymentAddrOptional,
+		lnwire.OnionMessagesOptional,
+	},
+	featureSetInvoice: {
+		lnwire.PaymentAddrOptional,
+	},
+}
+
+// featureManager is the central source of the feature vectors advertised by
+// the daemon. Sub-systems such as the peer, the funding manager, and the
+// payment controller consult the feature manager to determine which features
+// to advertise, and whether a remote node's features are compatible with our
+// own.
+type featureManager struct {
+	fsets map[featureSet]*lnwire.FeatureVector
+}
+
+// newFeatureManager creates a new feature manager populated with the
+// daemon's default feature sets. If wumboChannels is true, then we also
+// signal our willingness to accept channels above the maximum channel size.
+func newFeatureManager(wumboChannels bool) *featureManager {
+	fsets := make(map[featureSet]*lnwire.FeatureVector)
+	for set, bits := range defaultFeatures {
+		fsets[set] = lnwire.NewFeatureVector(bits...)
+	}
+
+	if wumboChannels {
+		fsets[featureSetInit].Set(lnwire.WumboChannelsOptional)
+		fsets[featureSetNodeAnn].Set(lnwire.WumboChannelsOptional)
+	}
+
+	return &featureManager{
+		fsets: fsets,
+	}
+}
+
+// get returns a copy of the feature vector advertised within the target
+// feature set.
+func (m *featureManager) get(set featureSet) *lnwire.FeatureVector {
+	fv, ok := m.fsets[set]
+	if !ok {
+		return lnwire.NewFeatureVector()
+	}
+
+	return fv.Clone()
+}
+
+// validateRemoteFeatures ensures that we understand all the features the
+// remote node requires. An error is returned if the remote node requires any
+// features which are unknown to us.
+func validateRemoteFeatures(features *lnwire.FeatureVector) error {
+	unknown := features.UnknownRequiredFeatures()
+	if len(unknown) != 0 {
+		return fmt.Errorf("remote node requires unknown features: %v",
+			unknown)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestFeatureManagerWumbo tests that wumbo channels are only advertised when
+// enabled, and then only within the Init message and our node announcement.
+func TestFeatureManagerWumbo(t *testing.T) {
+	wumboSets := []featureSet{featureSetInit, featureSetNodeAnn}
+	for _, wumbo := range []bool{false, true} {
+		m := newFeatureManager(wumbo)
+
+		for _, set := range wumboSets {
+			fv := m.get(set)
+			if fv.HasFeature(lnwire.WumboChannelsOptional) != wumbo {
+				t.Fatalf("wumbo=%v: expected feature set %v to "+
+					"advertise wumbo channels: %v", wumbo,
+					set, wumbo)
+			}
+		}
+		fv := m.get(featureSetInvoice)
+		if fv.HasFeature(lnwire.WumboChannelsOptional) {
+			t.Fatalf("wumbo=%v: wumbo channels advertised within "+
+				"invoices", wumbo)
+		}
+	}
+
+	// The vectors returned are copies, so modifying them must leave the
+	// advertised features untouched.
+	m := newFeatureManager(false)
+	m.get(featureSetInit).Set(lnwire.WumboChannelsOptional)
+	if m.get(featureSetInit).HasFeature(lnwire.WumboChannelsOptional) {
+		t.Fatalf("advertised features modified through a copy")
+	}
+}
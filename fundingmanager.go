@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -19,6 +22,15 @@ import (
 const (
 	// TODO(roasbeef): tune
 	msgBufferSize = 50
+
+	// fundingReclaimFeeRate is the fee rate, in sat/byte, used when double
+	// spending the inputs of a funding transaction which failed to
+	// confirm. It's twice the rate used for funding transactions.
+	fundingReclaimFeeRate = 20
+
+	// maxFundingAmount is the maximum size of a channel, unless both peers
+	// support wumbo channels.
+	maxFundingAmount = btcutil.Amount(1<<24) - 1
 )
 
 // reservationWithCtx encapsulates a pending channel reservation. This wrapper
@@ -33,6 +45,26 @@ type reservationWithCtx struct {
 	reservation *lnwallet.ChannelReservation
 	peer        *peer
 
+	// policy is the forwarding policy we'll advertise for the channel
+	// once it's open.
+	policy *channeldb.ChannelEdgePolicy
+
+	// fundingDeadline is the block height by which the funding transaction
+	// must confirm, after which the pending channel is forgotten. A
+	// deadline of zero indicates the funding transaction either isn't yet
+	// known, or has already confirmed.
+	fundingDeadline int32
+
+	// timedOut is closed once the pending channel has been forgotten due
+	// to its funding transaction failing to confirm in time.
+	timedOut chan struct{}
+
+	// reclaimTxid is the txid of the transaction double spending our
+	// inputs to the funding transaction, should it have failed to confirm
+	// in time. The pending channel is only forgotten once the double spend
+	// confirms, as the funding transaction may yet confirm in its place.
+	reclaimTxid *wire.ShaHash
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -87,6 +119,44 @@ type fundingOpenMsg struct {
 	peer *peer
 }
 
+// fundingErrorMsg couples an lnwire.ErrorGeneric message concerning a pending
+// channel with the peer who sent the message. This allows the funding manager
+// to abort the funding workflow the error refers to.
+type fundingErrorMsg struct {
+	msg  *lnwire.ErrorGeneric
+	peer *peer
+}
+
+// fundingConfirmedMsg signals the funding manager that the funding
+// transaction of a pending channel has received its first confirmation, so
+// the channel is no longer at risk of timing out.
+type fundingConfirmedMsg struct {
+	peerID int32
+	chanID uint64
+}
+
+// fundingReclaimedMsg signals the funding manager that the double spend of
+// the inputs to the funding transaction of a pending channel has confirmed,
+// so the funding transaction can no longer confirm.
+type fundingReclaimedMsg struct {
+	peerID int32
+	chanID uint64
+}
+
+// fundingReclaimer is the subset of the methods of the wallet used to reclaim
+// the inputs of a funding transaction which failed to confirm in time.
+type fundingReclaimer interface {
+	// ReclaimFundingInputs double spends the inputs of the funding
+	// transaction of the passed reservation back to the wallet at the
+	// passed fee rate, returning the txid of the double spend.
+	ReclaimFundingInputs(res *lnwallet.ChannelReservation,
+		feeRate btcutil.Amount) (*wire.ShaHash, error)
+
+	// ForgetReclaimedChannel forgets the pending channel of the passed
+	// reservation once the double spend of its inputs has confirmed.
+	ForgetReclaimedChannel(res *lnwallet.ChannelReservation) error
+}
+
 // pendingChannels is a map instantiated per-peer which tracks all active
 // pending single funded channels indexed by their pending channel identifier.
 type pendingChannels map[uint64]*reservationWithCtx
@@ -112,6 +182,30 @@ type fundingManager struct {
 	// wallet is the daemon's internal Lightning enabled wallet.
 	wallet *lnwallet.LightningWallet
 
+	// reclaimer reclaims the inputs of funding transactions which fail to
+	// confirm in time, and is backed by the wallet.
+	reclaimer fundingReclaimer
+
+	// notifier is used to watch for the confirmation of funding
+	// transactions, and for new blocks in order to enforce the funding
+	// timeout.
+	notifier chainntnfs.ChainNotifier
+
+	// bio is used to query the current height of the chain.
+	bio lnwallet.BlockChainIO
+
+	// minChanSize and maxChanSize bound the size of the channels we
+	// accept from remote peers, a maximum of zero accepting channels of
+	// any size. As they may be changed by a reload of the configuration,
+	// they're guarded by sizeMtx.
+	sizeMtx     sync.RWMutex
+	minChanSize btcutil.Amount
+	maxChanSize btcutil.Amount
+
+	// newBlocks delivers a notification for each new block connected to
+	// the main chain.
+	newBlocks *chainntnfs.BlockEpochEvent
+
 	// fundingMsgs is a channel which receives wrapped wire messages
 	// related to funding workflow from outside peers.
 	fundingMsgs chan interface{}
@@ -130,10 +224,18 @@ type fundingManager struct {
 
 // newFundingManager creates and initializes a new instance of the
 // fundingManager.
-func newFundingManager(w *lnwallet.LightningWallet) *fundingManager {
+func newFundingManager(w *lnwallet.LightningWallet,
+	notifier chainntnfs.ChainNotifier,
+	bio lnwallet.BlockChainIO) *fundingManager {
+
 	return &fundingManager{
 		activeReservations: make(map[int32]pendingChannels),
 		wallet:             w,
+		reclaimer:          w,
+		notifier:           notifier,
+		bio:                bio,
+		minChanSize:        btcutil.Amount(cfg.MinChanSize),
+		maxChanSize:        btcutil.Amount(cfg.MaxChanSize),
 		fundingMsgs:        make(chan interface{}, msgBufferSize),
 		fundingRequests:    make(chan *initFundingMsg, msgBufferSize),
 		queries:            make(chan interface{}, 1),
@@ -141,6 +243,15 @@ func newFundingManager(w *lnwallet.LightningWallet) *fundingManager {
 	}
 }
 
+// setChanSizes sets the range of sizes of the channels we accept from remote
+// peers, applying to all funding requests received from now on.
+func (f *fundingManager) setChanSizes(minChanSize, maxChanSize btcutil.Amount) {
+	f.sizeMtx.Lock()
+	f.minChanSize = minChanSize
+	f.maxChanSize = maxChanSize
+	f.sizeMtx.Unlock()
+}
+
 // Start launches all helper goroutines required for handling requests sent
 // to the funding manager.
 func (f *fundingManager) Start() error {
@@ -150,6 +261,12 @@ func (f *fundingManager) Start() error {
 
 	fndgLog.Infof("funding manager running")
 
+	newBlocks, err := f.notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+	f.newBlocks = newBlocks
+
 	f.wg.Add(1) // TODO(roasbeef): tune
 	go f.reservationCoordinator()
 
@@ -231,7 +348,18 @@ out:
 				f.handleFundingSignComplete(fmsg)
 			case *fundingOpenMsg:
 				f.handleFundingOpen(fmsg)
+			case *fundingErrorMsg:
+				f.handleErrorGenericMsg(fmsg)
+			case *fundingConfirmedMsg:
+				f.handleFundingConfirmed(fmsg)
+			case *fundingReclaimedMsg:
+				f.handleFundingReclaimed(fmsg)
+			}
+		case epoch, ok := <-f.newBlocks.Epochs:
+			if !ok {
+				break out
 			}
+			f.handleFundingTimeouts(epoch.Height)
 		case req := <-f.fundingRequests:
 			f.handleInitFundingMsg(req)
 		case req := <-f.queries:
@@ -240,6 +368,8 @@ out:
 				f.handleNumPending(msg)
 			case *pendingChansReq:
 				f.handlePendingChannels(msg)
+			case *bumpFeeReq:
+				f.handleBumpFee(msg)
 			}
 		case <-f.quit:
 			break out
@@ -284,6 +414,64 @@ func (f *fundingManager) handlePendingChannels(msg *pendingChansReq) {
 	msg.resp <- pendingChannels
 }
 
+type bumpFeeReq struct {
+	chanPoint *wire.OutPoint
+	feeRate   btcutil.Amount
+	resp      chan *wire.ShaHash
+	err       chan error
+}
+
+// BumpFundingFee raises the fee rate of the unconfirmed funding transaction of
+// the pending channel identified by chanPoint to the passed fee rate in
+// sat/byte, by broadcasting a child transaction spending its change output.
+// The txid of the child transaction is returned.
+func (f *fundingManager) BumpFundingFee(chanPoint *wire.OutPoint,
+	feeRate btcutil.Amount) (*wire.ShaHash, error) {
+
+	resp := make(chan *wire.ShaHash, 1)
+	errChan := make(chan error, 1)
+
+	f.queries <- &bumpFeeReq{chanPoint, feeRate, resp, errChan}
+
+	return <-resp, <-errChan
+}
+
+// handleBumpFee locates the pending channel targeted by a fee bump request,
+// then bumps the fee of its funding transaction.
+func (f *fundingManager) handleBumpFee(msg *bumpFeeReq) {
+	f.resMtx.RLock()
+	var target *lnwallet.ChannelReservation
+	for _, peerChannels := range f.activeReservations {
+		for _, resCtx := range peerChannels {
+			fundingPoint := resCtx.reservation.FundingOutpoint()
+			if fundingPoint != nil && *fundingPoint == *msg.chanPoint {
+				target = resCtx.reservation
+			}
+		}
+	}
+	f.resMtx.RUnlock()
+
+	if target == nil {
+		msg.resp <- nil
+		msg.err <- fmt.Errorf("no pending channel with "+
+			"ChannelPoint(%v)", msg.chanPoint)
+		return
+	}
+
+	txid, err := f.wallet.BumpFundingFee(target, msg.feeRate)
+	if err != nil {
+		msg.resp <- nil
+		msg.err <- err
+		return
+	}
+
+	fndgLog.Infof("Bumped fee of ChannelPoint(%v) to %v sat/byte with "+
+		"child txid %v", msg.chanPoint, msg.feeRate, txid)
+
+	msg.resp <- txid
+	msg.err <- nil
+}
+
 // processFundingRequest sends a message to the fundingManager allowing it to
 // intiate the new funding workflow with the source peer.
 func (f *fundingManager) processFundingRequest(msg *lnwire.SingleFundingRequest, peer *peer) {
@@ -301,8 +489,58 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	delay := msg.CsvDelay
 
 	// TODO(roasbeef): error if funding flow already ongoing
-	fndgLog.Infof("Recv'd fundingRequest(amt=%v, delay=%v, pendingId=%v) "+
-		"from peerID(%v)", amt, delay, msg.ChannelID, fmsg.peer.id)
+	fndgLog.Infof("Recv'd fundingRequest(amt=%v, delay=%v, "+
+		"leaseExpiry=%v, pendingId=%v) from peerID(%v)", amt, delay,
+		msg.LeaseExpiry, msg.ChannelID, fmsg.peer.id)
+
+	// Ensure the proposed channel is within the range of channel sizes
+	// we're willing to accept, letting the initiator know why the channel
+	// was rejected otherwise.
+	f.sizeMtx.RLock()
+	minChanSize, maxChanSize := f.minChanSize, f.maxChanSize
+	f.sizeMtx.RUnlock()
+	switch {
+	case amt < minChanSize:
+		f.sendFundingError(fmsg.peer, msg.ChannelID,
+			lnwire.ErrChanTooSmall, fmt.Sprintf("channel size of "+
+				"%v is below the minimum of %v", amt,
+				minChanSize))
+		return
+	case maxChanSize != 0 && amt > maxChanSize:
+		f.sendFundingError(fmsg.peer, msg.ChannelID,
+			lnwire.ErrChanTooLarge, fmt.Sprintf("channel size of "+
+				"%v is above the maximum of %v", amt,
+				maxChanSize))
+		return
+	case amt > maxFundingAmount && !wumboSupported(fmsg.peer):
+		f.sendFundingError(fmsg.peer, msg.ChannelID,
+			lnwire.ErrChanTooLarge, fmt.Sprintf("channel size of "+
+				"%v is above the maximum of %v without wumbo "+
+				"channels", amt, maxFundingAmount))
+		return
+	}
+
+	// Each pending channel ties up resources until the funding transaction
+	// confirms, so we cap the number of pending channels a single peer may
+	// have with us. As a peer's connection ID changes each time it
+	// reconnects, pending channels are counted by the peer's lightning ID.
+	f.resMtx.RLock()
+	var numPending int
+	for _, peerChannels := range f.activeReservations {
+		for _, resCtx := range peerChannels {
+			if resCtx.peer.lightningID == fmsg.peer.lightningID {
+				numPending++
+			}
+		}
+	}
+	f.resMtx.RUnlock()
+	if numPending >= cfg.MaxPendingChannels {
+		f.sendFundingError(fmsg.peer, msg.ChannelID,
+			lnwire.ErrMaxPendingChannels, fmt.Sprintf("number of "+
+				"pending channels exceeds maximum of %v",
+				cfg.MaxPendingChannels))
+		return
+	}
 
 	// Attempt to initialize a reservation within the wallet. If the wallet
 	// has insufficient resources to create the channel, then the reservation
@@ -317,6 +555,21 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		fmsg.peer.Disconnect()
 		return
 	}
+	reservation.SetLeaseExpiry(msg.LeaseExpiry)
+	policy := fmsg.peer.server.channelPolicy(fmsg.peer.lightningID, nil)
+	reservation.SetLocalPolicy(policy)
+	reservation.SetHTLCLimits(btcutil.Amount(cfg.MaxPendingAmt),
+		uint16(cfg.MaxAcceptedHtlcs))
+
+	// If we've been configured with a close address, then we commit to it
+	// as our upfront shutdown script.
+	if closeAddr := fmsg.peer.server.closeAddress; closeAddr != nil {
+		if err := reservation.SetDeliveryAddress(closeAddr); err != nil {
+			fndgLog.Errorf("Unable to set delivery address: %v", err)
+			reservation.Cancel()
+			return
+		}
+	}
 
 	// Once the reservation has been created succesfully, we add it to this
 	// peers map of pending reservations to track this particular reservation
@@ -328,6 +581,7 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 	f.activeReservations[fmsg.peer.id][msg.ChannelID] = &reservationWithCtx{
 		reservation: reservation,
 		peer:        fmsg.peer,
+		policy:      policy,
 	}
 	f.resMtx.Unlock()
 
@@ -343,6 +597,7 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		MultiSigKey:     msg.ChannelDerivationPoint,
 		CommitKey:       msg.CommitmentKey,
 		DeliveryAddress: addrs[0],
+		UpfrontShutdown: msg.UpfrontShutdown,
 		CsvDelay:        delay,
 	}
 	if err := reservation.ProcessSingleContribution(contribution); err != nil {
@@ -365,10 +620,36 @@ func (f *fundingManager) handleFundingRequest(fmsg *fundingRequestMsg) {
 		ourContribution.RevocationKey, ourContribution.CommitKey,
 		ourContribution.MultiSigKey, ourContribution.CsvDelay,
 		deliveryScript)
+	fundingResp.UpfrontShutdown = ourContribution.UpfrontShutdown
 
 	fmsg.peer.queueMsg(fundingResp, nil)
 }
 
+// wumboSupported returns true if both we and the passed peer support channels
+// above the maximum channel size.
+func wumboSupported(p *peer) bool {
+	return cfg.WumboChannels &&
+		p.remoteFeatures.HasFeature(lnwire.WumboChannelsOptional)
+}
+
+// sendFundingError rejects the pending channel identified by chanID by sending
+// an ErrorGeneric message with the passed code and reason to the initiating
+// peer.
+func (f *fundingManager) sendFundingError(peer *peer, chanID uint64,
+	code lnwire.ErrorCode, problem string) {
+
+	fndgLog.Warnf("Rejecting pendingID(%v) from peerID(%v): %v", chanID,
+		peer.id, problem)
+
+	errMsg := &lnwire.ErrorGeneric{
+		ChannelPoint:     &wire.OutPoint{},
+		PendingChannelID: chanID,
+		ErrorID:          uint16(code),
+		Problem:          problem,
+	}
+	peer.queueMsg(errMsg, nil)
+}
+
 // processFundingRequest sends a message to the fundingManager allowing it to
 // continue the second phase of a funding workflow with the target peer.
 func (f *fundingManager) processFundingResponse(msg *lnwire.SingleFundingResponse, peer *peer) {
@@ -403,6 +684,7 @@ func (f *fundingManager) handleFundingResponse(fmsg *fundingResponseMsg) {
 		MultiSigKey:     msg.ChannelDerivationPoint,
 		CommitKey:       msg.CommitmentKey,
 		DeliveryAddress: addrs[0],
+		UpfrontShutdown: msg.UpfrontShutdown,
 		RevocationKey:   msg.RevocationKey,
 		CsvDelay:        msg.CsvDelay,
 	}
@@ -492,6 +774,11 @@ func (f *fundingManager) handleFundingComplete(fmsg *fundingCompleteMsg) {
 	// the peer's readHandler once the channel is open.
 	fmsg.peer.barrierInits <- *fundingOut
 
+	// Now that the funding transaction is known, start the clock on its
+	// confirmation, so we don't hold the reservation forever should the
+	// initiator never broadcast it.
+	f.setFundingDeadline(fmsg.peer.id, chanID, resCtx, fundingOut)
+
 	fndgLog.Infof("sending signComplete for pendingID(%v) over ChannelPoint(%v)",
 		fmsg.msg.ChannelID, fundingOut)
 
@@ -532,6 +819,11 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 	fndgLog.Infof("Finalizing pendingID(%v) over ChannelPoint(%v), "+
 		"waiting for channel open on-chain", chanID, fundingPoint)
 
+	// Start the clock on the confirmation of the now broadcast funding
+	// transaction. If it fails to confirm in time, then we'll reclaim
+	// our inputs.
+	f.setFundingDeadline(fmsg.peer.id, chanID, resCtx, fundingPoint)
+
 	// Send an update to the upstream client that the negotiation process
 	// is over.
 	// TODO(roasbeef): add abstraction over updates to accomdate
@@ -581,16 +873,25 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 
 			// Register the new link with the L3 routing manager
 			// so this new channel can be utilized during path
-			// finding.
+			// finding. Private channels aren't announced, so
+			// they're only recorded within our local graph.
 			chanInfo := openChan.StateSnapshot()
 			capacity := int64(chanInfo.Capacity)
-			fmsg.peer.server.routingMgr.OpenChannel(
-				graph.NewID(chanInfo.RemoteID),
-				graph.NewEdgeID(fundingPoint.String()),
-				&rt.ChannelInfo{
-					Cpt: capacity,
-				},
-			)
+			if !chanInfo.IsPrivate {
+				fmsg.peer.server.routingMgr.OpenChannel(
+					graph.NewID(chanInfo.RemoteID),
+					graph.NewEdgeID(fundingPoint.String()),
+					&rt.ChannelInfo{
+						Cpt: capacity,
+					},
+				)
+				fmsg.peer.server.rpcCache.invalidate()
+			}
+
+			// Record the new channel within the channel graph.
+			fmsg.peer.server.addChannelEdge(fundingPoint,
+				fmsg.peer.lightningID, chanInfo.Capacity,
+				resCtx.policy)
 
 			// Finally give the caller a final update notifying
 			// them that the channel is now open.
@@ -606,6 +907,8 @@ func (f *fundingManager) handleFundingSignComplete(fmsg *fundingSignCompleteMsg)
 				},
 			}
 			return
+		case <-resCtx.timedOut:
+			return
 		case <-f.quit:
 			return
 		}
@@ -625,9 +928,17 @@ func (f *fundingManager) processFundingOpenProof(msg *lnwire.SingleFundingOpenPr
 // to the source peer.
 func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	f.resMtx.RLock()
-	resCtx := f.activeReservations[fmsg.peer.id][fmsg.msg.ChannelID]
+	resCtx, ok := f.activeReservations[fmsg.peer.id][fmsg.msg.ChannelID]
 	f.resMtx.RUnlock()
 
+	// If the funding transaction took too long to confirm, then we may
+	// have already forgotten the channel.
+	if !ok {
+		fndgLog.Warnf("Received open proof for unknown pendingID(%v) "+
+			"from peerID(%v)", fmsg.msg.ChannelID, fmsg.peer.id)
+		return
+	}
+
 	// The channel initiator has claimed the channel is now open, so we'll
 	// verify the contained SPV proof for validity.
 	// TODO(roasbeef): send off to the spv proof verifier, in the routing
@@ -653,6 +964,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		resCtx.reservation.FundingOutpoint, fmsg.peer.id)
 
 	// Notify the L3 routing manager of the newly active channel link.
+	// TODO(roasbeef): the initiator should signal within the funding
+	// request if the channel is private, so we also refrain from
+	// announcing it.
 	capacity := int64(resCtx.reservation.OurContribution().FundingAmount +
 		resCtx.reservation.TheirContribution().FundingAmount)
 	fmsg.peer.server.routingMgr.OpenChannel(
@@ -662,11 +976,246 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 			Cpt: capacity,
 		},
 	)
+	fmsg.peer.server.rpcCache.invalidate()
+
+	fmsg.peer.server.addChannelEdge(resCtx.reservation.FundingOutpoint(),
+		fmsg.peer.lightningID, btcutil.Amount(capacity), resCtx.policy)
 
 	// Finally, notify the target peer of the newly open channel.
 	fmsg.peer.newChannels <- openChan
 }
 
+// setFundingDeadline records the block height by which the funding
+// transaction of the target pending channel must confirm, and launches a
+// goroutine which signals the funding manager once it does.
+func (f *fundingManager) setFundingDeadline(peerID int32, chanID uint64,
+	resCtx *reservationWithCtx, fundingPoint *wire.OutPoint) {
+
+	currentHeight, err := f.bio.GetCurrentHeight()
+	if err != nil {
+		fndgLog.Errorf("Unable to set funding deadline for "+
+			"ChannelPoint(%v): %v", fundingPoint, err)
+		return
+	}
+
+	f.resMtx.Lock()
+	resCtx.fundingDeadline = currentHeight + int32(cfg.FundingTimeout)
+	resCtx.timedOut = make(chan struct{})
+	f.resMtx.Unlock()
+
+	txid := fundingPoint.Hash
+	confNtfn, err := f.notifier.RegisterConfirmationsNtfn(&txid, 1)
+	if err != nil {
+		fndgLog.Errorf("Unable to register for confirmation of "+
+			"ChannelPoint(%v): %v", fundingPoint, err)
+		return
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		select {
+		case _, ok := <-confNtfn.Confirmed:
+			if !ok {
+				return
+			}
+		case <-f.quit:
+			return
+		}
+
+		select {
+		case f.fundingMsgs <- &fundingConfirmedMsg{peerID, chanID}:
+		case <-f.quit:
+		}
+	}()
+}
+
+// handleFundingConfirmed clears the funding deadline of a pending channel
+// whose funding transaction has confirmed.
+func (f *fundingManager) handleFundingConfirmed(fmsg *fundingConfirmedMsg) {
+	f.resMtx.Lock()
+	defer f.resMtx.Unlock()
+
+	if resCtx, ok := f.activeReservations[fmsg.peerID][fmsg.chanID]; ok {
+		resCtx.fundingDeadline = 0
+	}
+}
+
+// watchFundingReclaim launches a goroutine which signals the funding manager
+// once the transaction reclaiming the funding inputs of the target pending
+// channel confirms.
+func (f *fundingManager) watchFundingReclaim(peerID int32, chanID uint64,
+	reclaimTxid *wire.ShaHash) error {
+
+	confNtfn, err := f.notifier.RegisterConfirmationsNtfn(reclaimTxid, 1)
+	if err != nil {
+		return err
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		select {
+		case _, ok := <-confNtfn.Confirmed:
+			if !ok {
+				return
+			}
+		case <-f.quit:
+			return
+		}
+
+		select {
+		case f.fundingMsgs <- &fundingReclaimedMsg{peerID, chanID}:
+		case <-f.quit:
+		}
+	}()
+
+	return nil
+}
+
+// handleFundingReclaimed forgets a pending channel whose funding inputs have
+// been reclaimed by a confirmed double spend. If the funding transaction
+// confirmed first, then the channel has already opened, and is no longer
+// pending.
+func (f *fundingManager) handleFundingReclaimed(fmsg *fundingReclaimedMsg) {
+	f.resMtx.Lock()
+	defer f.resMtx.Unlock()
+
+	peerChannels := f.activeReservations[fmsg.peerID]
+	resCtx, ok := peerChannels[fmsg.chanID]
+	if !ok {
+		return
+	}
+
+	fundingPoint := resCtx.reservation.FundingOutpoint()
+	if err := f.reclaimer.ForgetReclaimedChannel(resCtx.reservation); err != nil {
+		fndgLog.Errorf("Unable to forget ChannelPoint(%v): %v",
+			fundingPoint, err)
+		return
+	}
+
+	fndgLog.Infof("Reclaim of inputs of ChannelPoint(%v) confirmed, "+
+		"forgetting pendingID(%v) with peerID(%v)", fundingPoint,
+		fmsg.chanID, fmsg.peerID)
+
+	resCtx.err <- fmt.Errorf("funding transaction failed to confirm "+
+		"within %v blocks, inputs reclaimed by %v", cfg.FundingTimeout,
+		resCtx.reclaimTxid)
+
+	close(resCtx.timedOut)
+	delete(peerChannels, fmsg.chanID)
+}
+
+// handleFundingTimeouts handles all pending channels whose funding
+// transaction has failed to confirm by the passed block height. As the
+// responder, we simply release the reservation, forgetting the channel. As
+// the initiator, the inputs of the funding transaction are double spent back
+// to the wallet, with the channel only forgotten once the double spend
+// confirms.
+func (f *fundingManager) handleFundingTimeouts(height int32) {
+	f.resMtx.Lock()
+	defer f.resMtx.Unlock()
+
+	for peerID, peerChannels := range f.activeReservations {
+		for chanID, resCtx := range peerChannels {
+			deadline := resCtx.fundingDeadline
+			if deadline == 0 || height < deadline {
+				continue
+			}
+
+			fundingPoint := resCtx.reservation.FundingOutpoint()
+			fndgLog.Warnf("Funding transaction for ChannelPoint(%v) "+
+				"failed to confirm within %v blocks",
+				fundingPoint, cfg.FundingTimeout)
+
+			isInitiator := resCtx.reservation.OurContribution().FundingAmount != 0
+			if isInitiator {
+				// If the funding transaction is still within
+				// the mempool, then the double spend will be
+				// rejected, so we'll try again next block.
+				txid, err := f.reclaimer.ReclaimFundingInputs(
+					resCtx.reservation, fundingReclaimFeeRate)
+				if err != nil {
+					fndgLog.Errorf("Unable to reclaim inputs "+
+						"of ChannelPoint(%v): %v",
+						fundingPoint, err)
+					continue
+				}
+
+				fndgLog.Infof("Reclaiming inputs of "+
+					"ChannelPoint(%v) with txid %v",
+					fundingPoint, txid)
+
+				// Whichever of the two transactions
+				// confirms decides the fate of the channel,
+				// so we stop timing it out.
+				resCtx.fundingDeadline = 0
+				resCtx.reclaimTxid = txid
+				err = f.watchFundingReclaim(peerID, chanID, txid)
+				if err != nil {
+					fndgLog.Errorf("Unable to watch reclaim "+
+						"of ChannelPoint(%v): %v",
+						fundingPoint, err)
+				}
+				continue
+			}
+
+			if err := resCtx.reservation.Cancel(); err != nil {
+				fndgLog.Errorf("Unable to cancel reservation: %v",
+					err)
+			}
+
+			fndgLog.Infof("Forgetting pendingID(%v) with peerID(%v)",
+				chanID, peerID)
+
+			close(resCtx.timedOut)
+			delete(peerChannels, chanID)
+		}
+	}
+}
+
+// processErrorGeneric sends a message to the fundingManager allowing it to
+// abort the funding workflow of the pending channel referenced by the error.
+func (f *fundingManager) processErrorGeneric(msg *lnwire.ErrorGeneric, peer *peer) {
+	f.fundingMsgs <- &fundingErrorMsg{msg, peer}
+}
+
+// handleErrorGenericMsg cancels the reservation of a pending channel which the
+// remote peer has rejected, relaying the peer's reason for the rejection to
+// the caller which initiated the funding workflow.
+func (f *fundingManager) handleErrorGenericMsg(fmsg *fundingErrorMsg) {
+	msg := fmsg.msg
+	chanID := msg.PendingChannelID
+
+	f.resMtx.Lock()
+	resCtx, ok := f.activeReservations[fmsg.peer.id][chanID]
+	if ok {
+		delete(f.activeReservations[fmsg.peer.id], chanID)
+	}
+	f.resMtx.Unlock()
+	if !ok {
+		fndgLog.Warnf("Received error for unknown pendingID(%v) from "+
+			"peerID(%v): %v", chanID, fmsg.peer.id, msg.Problem)
+		return
+	}
+
+	fndgLog.Errorf("Peer %v rejected pendingID(%v): %v", fmsg.peer,
+		chanID, msg.Problem)
+
+	if err := resCtx.reservation.Cancel(); err != nil {
+		fndgLog.Errorf("Unable to cancel reservation: %v", err)
+	}
+
+	// Only the pending channels we initiated have a caller awaiting the
+	// outcome of the funding workflow.
+	if resCtx.err != nil {
+		resCtx.err <- fmt.Errorf("remote peer rejected channel: %v",
+			msg.Problem)
+	}
+}
+
 // initFundingWorkflow sends a message to the funding manager instructing it
 // to initiate a single funder workflow with the source peer.
 // TODO(roasbeef): re-visit blocking nature..
@@ -692,15 +1241,67 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	fndgLog.Infof("Initiating fundingRequest(localAmt=%v, remoteAmt=%v, "+
 		"capacity=%v, numConfs=%v)", localAmt, remoteAmt, capacity, numConfs)
 
+	// Channels above the maximum channel size may only be opened if both
+	// we and the remote peer support wumbo channels.
+	if capacity > maxFundingAmount {
+		if !wumboSupported(msg.peer) {
+			msg.err <- fmt.Errorf("channel size of %v is above the "+
+				"maximum of %v, and wumbo channels aren't "+
+				"supported by both peers", capacity,
+				maxFundingAmount)
+			return
+		}
+
+		fndgLog.Warnf("Opening wumbo channel of %v with peerID(%v), "+
+			"larger channels put more funds at risk", capacity,
+			msg.peer.id)
+	}
+
 	// Initialize a funding reservation with the local wallet. If the
 	// wallet doesn't have enough funds to commit to this channel, then
 	// the request will fail, and be aborted.
 	reservation, err := f.wallet.InitChannelReservation(capacity, localAmt,
 		nodeID, uint16(numConfs), 4)
-	if err != nil {
+	if err == lnwallet.ErrUnconfirmedFunds {
+		msg.err <- fmt.Errorf("%v: wait for them to reach %v "+
+			"confirmations, or set --allow-unconfirmed-funding", err,
+			cfg.FundingMinConfs)
+		return
+	} else if err != nil {
 		msg.err <- err
 		return
 	}
+	reservation.SetPrivate(msg.private)
+	reservation.SetLeaseExpiry(msg.leaseExpiry)
+	policy := msg.peer.server.channelPolicy(nodeID, msg.policy)
+	reservation.SetLocalPolicy(policy)
+
+	// Limits on the HTLC's in flight specified within the request take
+	// precedence over those within the configuration.
+	maxPendingAmt := msg.maxPendingAmt
+	if maxPendingAmt == 0 {
+		maxPendingAmt = btcutil.Amount(cfg.MaxPendingAmt)
+	}
+	maxAcceptedHtlcs := msg.maxAcceptedHtlcs
+	if maxAcceptedHtlcs == 0 {
+		maxAcceptedHtlcs = uint16(cfg.MaxAcceptedHtlcs)
+	}
+	reservation.SetHTLCLimits(maxPendingAmt, maxAcceptedHtlcs)
+
+	// A close address specified within the request takes precedence over
+	// the configured close address. Either is committed to as our upfront
+	// shutdown script.
+	closeAddr := msg.closeAddress
+	if closeAddr == nil {
+		closeAddr = msg.peer.server.closeAddress
+	}
+	if closeAddr != nil {
+		if err := reservation.SetDeliveryAddress(closeAddr); err != nil {
+			reservation.Cancel()
+			msg.err <- err
+			return
+		}
+	}
 
 	// Obtain a new pending channel ID which is used to track this
 	// reservation throughout its lifetime.
@@ -719,6 +1320,7 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 	f.activeReservations[msg.peer.id][chanID] = &reservationWithCtx{
 		reservation: reservation,
 		peer:        msg.peer,
+		policy:      policy,
 		updates:     msg.updates,
 		err:         msg.err,
 	}
@@ -745,9 +1347,11 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		0, // TODO(roasbeef): grab from fee estimation model
 		capacity,
 		contribution.CsvDelay,
+		msg.leaseExpiry,
 		contribution.CommitKey,
 		contribution.MultiSigKey,
 		deliveryScript,
 	)
+	fundingReq.UpfrontShutdown = contribution.UpfrontShutdown
 	msg.peer.queueMsg(fundingReq, nil)
 }
@@ -0,0 +1,503 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockWalletController is a mock implementation of the WalletController
+// interface which holds no funds, handing out fresh keys and addresses.
+type mockWalletController struct {
+	lnwallet.WalletController
+}
+
+func (m *mockWalletController) NewRawKey() (*btcec.PublicKey, error) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	return priv.PubKey(), nil
+}
+
+func (m *mockWalletController) NewAddress(addrType lnwallet.AddressType,
+	change bool) (btcutil.Address, error) {
+
+	return newTestAddress()
+}
+
+func (m *mockWalletController) Start() error { return nil }
+func (m *mockWalletController) Stop() error  { return nil }
+
+// mockKeyRing is a mock implementation of the RootKeyRing interface which
+// only provides the master elkrem root.
+type mockKeyRing struct {
+	lnwallet.RootKeyRing
+
+	elkremRoot *btcec.PrivateKey
+}
+
+func (m *mockKeyRing) MasterElkremRoot() (*btcec.PrivateKey, error) {
+	return m.elkremRoot, nil
+}
+
+// mockReclaimer is a mock implementation of the fundingReclaimer interface.
+// Each reclaim of funding inputs fails with reclaimErr if set, otherwise
+// returning reclaimTxid.
+type mockReclaimer struct {
+	reclaimTxid *wire.ShaHash
+	reclaimErr  error
+
+	numReclaims int
+	forgotten   []*lnwallet.ChannelReservation
+}
+
+func (m *mockReclaimer) ReclaimFundingInputs(res *lnwallet.ChannelReservation,
+	feeRate btcutil.Amount) (*wire.ShaHash, error) {
+
+	m.numReclaims++
+	if m.reclaimErr != nil {
+		return nil, m.reclaimErr
+	}
+	return m.reclaimTxid, nil
+}
+
+func (m *mockReclaimer) ForgetReclaimedChannel(
+	res *lnwallet.ChannelReservation) error {
+
+	m.forgotten = append(m.forgotten, res)
+	return nil
+}
+
+// testFundingAmt is the size of the channels opened within the tests.
+const testFundingAmt = btcutil.Amount(500000)
+
+// newTestAddress returns a pay-to-pubkey-hash address for a fresh key.
+func newTestAddress() (btcutil.Address, error) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	pkh := btcutil.Hash160(priv.PubKey().SerializeCompressed())
+	return btcutil.NewAddressPubKeyHash(pkh, activeNetParams.Params)
+}
+
+// newTestFundingManager returns a funding manager backed by a running wallet
+// which holds no funds, along with the notifier it watches the chain with.
+// The chain is at a height of 100 blocks.
+func newTestFundingManager(t *testing.T) (*fundingManager,
+	*lnwallet.LightningWallet, *mockNotifier, func()) {
+
+	elkremRoot, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	notifier := newMockNotifier()
+	bio := &mockChainIO{height: 100}
+	wallet := lnwallet.NewWatchOnlyLightningWallet(nil, notifier,
+		&mockWalletController{}, nil,
+		&mockKeyRing{elkremRoot: elkremRoot}, bio)
+	if err := wallet.Startup(); err != nil {
+		t.Fatalf("unable to start wallet: %v", err)
+	}
+
+	f := newFundingManager(wallet, notifier, bio)
+	cleanUp := func() {
+		f.Stop()
+		wallet.Shutdown()
+	}
+
+	return f, wallet, notifier, cleanUp
+}
+
+// newTestFundingPeer returns a peer which queues the messages sent to it.
+func newTestFundingPeer(id int32, lightningID wire.ShaHash) *peer {
+	return &peer{
+		conn: &mockConn{
+			remoteAddr: &net.TCPAddr{
+				IP:   net.ParseIP("127.0.0.1"),
+				Port: 9735 + int(id),
+			},
+		},
+		id:            id,
+		lightningID:   lightningID,
+		server:        &server{},
+		outgoingQueue: make(chan outgoinMsg, outgoingQueueLen),
+	}
+}
+
+// sendFundingRequest has the funding manager handle a request from the passed
+// peer to open a channel of the passed size with the passed pending channel
+// ID, returning the message sent in response.
+func sendFundingRequest(t *testing.T, f *fundingManager, p *peer,
+	chanID uint64, amt btcutil.Amount) lnwire.Message {
+
+	commitPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	multiSigPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	deliveryAddr, err := newTestAddress()
+	if err != nil {
+		t.Fatalf("unable to generate address: %v", err)
+	}
+	deliveryScript, err := txscript.PayToAddrScript(deliveryAddr)
+	if err != nil {
+		t.Fatalf("unable to generate script: %v", err)
+	}
+
+	req := lnwire.NewSingleFundingRequest(chanID, 0, 0, 0, amt, 4, 0,
+		commitPriv.PubKey(), multiSigPriv.PubKey(), deliveryScript)
+	f.handleFundingRequest(&fundingRequestMsg{req, p})
+
+	select {
+	case outMsg := <-p.outgoingQueue:
+		return outMsg.msg
+	default:
+		t.Fatalf("no response to funding request %v from peer %v",
+			chanID, p.id)
+		return nil
+	}
+}
+
+// assertPending asserts whether the passed peer has a pending channel with
+// the passed pending channel ID.
+func assertPending(t *testing.T, f *fundingManager, p *peer, chanID uint64,
+	pending bool) {
+
+	if _, ok := f.activeReservations[p.id][chanID]; ok != pending {
+		t.Fatalf("expected pending channel %v with peer %v to be "+
+			"pending: %v", chanID, p.id, pending)
+	}
+}
+
+// TestFundingPendingChannelLimit tests that a peer may only have up to the
+// configured number of pending channels with us, counted across each of its
+// connections, and that a pending channel rejected by the initiator no
+// longer counts towards the limit.
+func TestFundingPendingChannelLimit(t *testing.T) {
+	prevCfg := cfg
+	cfg = &config{MaxPendingChannels: 1}
+	defer func() { cfg = prevCfg }()
+
+	f, wallet, _, cleanUp := newTestFundingManager(t)
+	defer cleanUp()
+
+	// The first pending channel with a peer should be accepted.
+	peer1 := newTestFundingPeer(1, wire.ShaHash{1})
+	resp := sendFundingRequest(t, f, peer1, 0, testFundingAmt)
+	if _, ok := resp.(*lnwire.SingleFundingResponse); !ok {
+		t.Fatalf("expected funding response, got %T", resp)
+	}
+	assertPending(t, f, peer1, 0, true)
+
+	// Once the peer reconnects, a second pending channel should be
+	// rejected as the peer is at the limit.
+	peer2 := newTestFundingPeer(2, wire.ShaHash{1})
+	resp = sendFundingRequest(t, f, peer2, 0, testFundingAmt)
+	errMsg, ok := resp.(*lnwire.ErrorGeneric)
+	if !ok {
+		t.Fatalf("expected error, got %T", resp)
+	}
+	if errMsg.ErrorID != uint16(lnwire.ErrMaxPendingChannels) ||
+		errMsg.PendingChannelID != 0 {
+
+		t.Fatalf("expected max pending channels error for pending "+
+			"channel 0, got %v for %v", errMsg.ErrorID,
+			errMsg.PendingChannelID)
+	}
+	assertPending(t, f, peer2, 0, false)
+
+	// Another peer has a limit of its own.
+	peer3 := newTestFundingPeer(3, wire.ShaHash{3})
+	resp = sendFundingRequest(t, f, peer3, 0, testFundingAmt)
+	if _, ok := resp.(*lnwire.SingleFundingResponse); !ok {
+		t.Fatalf("expected funding response, got %T", resp)
+	}
+
+	// Once the initiator rejects the first pending channel, the
+	// reservation should be released without blocking, as no local
+	// caller awaits the outcome of the channel we're responding to.
+	done := make(chan struct{})
+	go func() {
+		f.handleErrorGenericMsg(&fundingErrorMsg{
+			msg: &lnwire.ErrorGeneric{
+				ChannelPoint:     &wire.OutPoint{},
+				PendingChannelID: 0,
+				Problem:          "rejected",
+			},
+			peer: peer1,
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("rejection of pending channel blocked")
+	}
+	assertPending(t, f, peer1, 0, false)
+	if n := len(wallet.ActiveReservations()); n != 1 {
+		t.Fatalf("expected 1 reservation within the wallet, got %v", n)
+	}
+
+	// As the peer is no longer at the limit, its next pending channel
+	// should be accepted.
+	resp = sendFundingRequest(t, f, peer2, 1, testFundingAmt)
+	if _, ok := resp.(*lnwire.SingleFundingResponse); !ok {
+		t.Fatalf("expected funding response, got %T", resp)
+	}
+}
+
+// TestFundingTimeoutResponder tests that as the responder, a pending channel
+// whose funding transaction fails to confirm within the funding timeout is
+// forgotten, with its reservation released, while a pending channel whose
+// funding transaction confirms is kept.
+func TestFundingTimeoutResponder(t *testing.T) {
+	prevCfg := cfg
+	cfg = &config{MaxPendingChannels: 2, FundingTimeout: 10}
+	defer func() { cfg = prevCfg }()
+
+	f, wallet, notifier, cleanUp := newTestFundingManager(t)
+	defer cleanUp()
+
+	p := newTestFundingPeer(1, wire.ShaHash{1})
+	fundingTxids := []wire.ShaHash{{10}, {11}}
+	for chanID, txid := range fundingTxids {
+		sendFundingRequest(t, f, p, uint64(chanID), testFundingAmt)
+
+		resCtx := f.activeReservations[p.id][uint64(chanID)]
+		f.setFundingDeadline(p.id, uint64(chanID), resCtx,
+			&wire.OutPoint{Hash: txid})
+	}
+	timedOut := f.activeReservations[p.id][0].timedOut
+
+	// The funding transaction of the second channel confirms before the
+	// deadline.
+	notifier.confirm(fundingTxids[1], 105)
+	select {
+	case msg := <-f.fundingMsgs:
+		f.handleFundingConfirmed(msg.(*fundingConfirmedMsg))
+	case <-time.After(5 * time.Second):
+		t.Fatalf("confirmation of funding transaction not received")
+	}
+
+	// Until the deadline is reached, both channels should remain pending.
+	f.handleFundingTimeouts(109)
+	assertPending(t, f, p, 0, true)
+	assertPending(t, f, p, 1, true)
+
+	// Once it's reached, the unconfirmed channel should be forgotten.
+	f.handleFundingTimeouts(110)
+	assertPending(t, f, p, 0, false)
+	assertPending(t, f, p, 1, true)
+	select {
+	case <-timedOut:
+	default:
+		t.Fatalf("timed out channel not signalled")
+	}
+	if n := len(wallet.ActiveReservations()); n != 1 {
+		t.Fatalf("expected 1 reservation within the wallet, got %v", n)
+	}
+}
+
+// TestFundingTimeoutInitiator tests that as the initiator, the inputs of a
+// funding transaction which fails to confirm within the funding timeout are
+// reclaimed, retrying each block until the double spend is accepted, and that
+// the pending channel is only forgotten once the double spend confirms.
+func TestFundingTimeoutInitiator(t *testing.T) {
+	prevCfg := cfg
+	cfg = &config{FundingTimeout: 10}
+	defer func() { cfg = prevCfg }()
+
+	f, wallet, notifier, cleanUp := newTestFundingManager(t)
+	defer cleanUp()
+
+	reclaimTxid := wire.ShaHash{21}
+	reclaimer := &mockReclaimer{
+		reclaimErr: errors.New("funding transaction within mempool"),
+	}
+	f.reclaimer = reclaimer
+
+	p := newTestFundingPeer(1, wire.ShaHash{1})
+	res := lnwallet.NewChannelReservation(1000000, 1000000, 0, wallet, 1,
+		1)
+	resCtx := &reservationWithCtx{
+		reservation: res,
+		peer:        p,
+		updates:     make(chan *lnrpc.OpenStatusUpdate, 1),
+		err:         make(chan error, 1),
+	}
+	f.activeReservations[p.id] = pendingChannels{0: resCtx}
+	f.setFundingDeadline(p.id, 0, resCtx, &wire.OutPoint{
+		Hash: wire.ShaHash{20},
+	})
+
+	// While the double spend is rejected, the channel should remain
+	// pending, with the reclaim retried at the next block.
+	f.handleFundingTimeouts(110)
+	assertPending(t, f, p, 0, true)
+	if resCtx.fundingDeadline != 110 || resCtx.reclaimTxid != nil {
+		t.Fatalf("funding deadline cleared by failed reclaim")
+	}
+
+	// Once accepted, the channel should stop timing out, awaiting the
+	// confirmation of either transaction.
+	reclaimer.reclaimErr = nil
+	reclaimer.reclaimTxid = &reclaimTxid
+	f.handleFundingTimeouts(111)
+	f.handleFundingTimeouts(112)
+	if reclaimer.numReclaims != 2 {
+		t.Fatalf("expected 2 reclaims, got %v", reclaimer.numReclaims)
+	}
+	if resCtx.fundingDeadline != 0 || resCtx.reclaimTxid == nil ||
+		*resCtx.reclaimTxid != reclaimTxid {
+
+		t.Fatalf("reclaim of ChannelPoint not recorded")
+	}
+	assertPending(t, f, p, 0, true)
+
+	// Once the double spend confirms, the channel should be forgotten, and
+	// the caller notified.
+	notifier.confirm(reclaimTxid, 113)
+	var reclaimedMsg *fundingReclaimedMsg
+	select {
+	case msg := <-f.fundingMsgs:
+		reclaimedMsg = msg.(*fundingReclaimedMsg)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("confirmation of reclaim not received")
+	}
+	f.handleFundingReclaimed(reclaimedMsg)
+
+	assertPending(t, f, p, 0, false)
+	if len(reclaimer.forgotten) != 1 || reclaimer.forgotten[0] != res {
+		t.Fatalf("reclaimed channel not forgotten by the wallet")
+	}
+	select {
+	case <-resCtx.timedOut:
+	default:
+		t.Fatalf("timed out channel not signalled")
+	}
+	select {
+	case err := <-resCtx.err:
+		if err == nil {
+			t.Fatalf("expected error for reclaimed channel")
+		}
+	default:
+		t.Fatalf("caller not notified of reclaimed channel")
+	}
+
+	// A repeated notification should be ignored.
+	f.handleFundingReclaimed(reclaimedMsg)
+	if len(reclaimer.forgotten) != 1 {
+		t.Fatalf("reclaimed channel forgotten twice")
+	}
+}
+
+// TestFundingWumboChannels tests that channels above the maximum channel size
+// are only accepted or initiated with wumbo channels enabled, and only with
+// peers which support them.
+func TestFundingWumboChannels(t *testing.T) {
+	prevCfg := cfg
+	cfg = &config{MaxPendingChannels: 5}
+	defer func() { cfg = prevCfg }()
+
+	f, _, _, cleanUp := newTestFundingManager(t)
+	defer cleanUp()
+
+	wumboPeer := newTestFundingPeer(1, wire.ShaHash{1})
+	wumboPeer.remoteFeatures = lnwire.NewFeatureVector(
+		lnwire.WumboChannelsOptional)
+	plainPeer := newTestFundingPeer(2, wire.ShaHash{2})
+	plainPeer.remoteFeatures = lnwire.NewFeatureVector()
+
+	wumboAmt := maxFundingAmount + 1
+	tests := []struct {
+		name     string
+		wumbo    bool
+		peer     *peer
+		amt      btcutil.Amount
+		accepted bool
+	}{
+		{
+			name:     "maximum channel size",
+			peer:     plainPeer,
+			amt:      maxFundingAmount,
+			accepted: true,
+		},
+		{
+			name: "wumbo channels disabled",
+			peer: wumboPeer,
+			amt:  wumboAmt,
+		},
+		{
+			name:  "wumbo channels unsupported by peer",
+			wumbo: true,
+			peer:  plainPeer,
+			amt:   wumboAmt,
+		},
+		{
+			name:     "wumbo channel",
+			wumbo:    true,
+			peer:     wumboPeer,
+			amt:      wumboAmt,
+			accepted: true,
+		},
+	}
+	for i, test := range tests {
+		cfg.WumboChannels = test.wumbo
+		resp := sendFundingRequest(t, f, test.peer, uint64(i), test.amt)
+
+		switch resp := resp.(type) {
+		case *lnwire.SingleFundingResponse:
+			if !test.accepted {
+				t.Fatalf("%s: channel accepted", test.name)
+			}
+		case *lnwire.ErrorGeneric:
+			if test.accepted {
+				t.Fatalf("%s: channel rejected: %v", test.name,
+					resp.Problem)
+			}
+			if resp.ErrorID != uint16(lnwire.ErrChanTooLarge) {
+				t.Fatalf("%s: expected channel too large error, "+
+					"got %v", test.name, resp.ErrorID)
+			}
+		default:
+			t.Fatalf("%s: unexpected response %T", test.name, resp)
+		}
+	}
+
+	// A wumbo channel we initiate with a peer which doesn't support them
+	// should be refused before any funds are reserved.
+	cfg.WumboChannels = true
+	errChan := make(chan error, 1)
+	f.handleInitFundingMsg(&initFundingMsg{
+		peer: plainPeer,
+		openChanReq: &openChanReq{
+			localFundingAmt: wumboAmt,
+			updates:         make(chan *lnrpc.OpenStatusUpdate, 1),
+			err:             errChan,
+		},
+	})
+	select {
+	case err := <-errChan:
+		if err == nil {
+			t.Fatalf("wumbo channel initiated with peer lacking " +
+				"support")
+		}
+	default:
+		t.Fatalf("wumbo channel initiated with peer lacking support")
+	}
+}
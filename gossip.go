@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+
+	"github.com/BitfuryLightning/tools/rt"
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// announcementBatch accumulates the routing messages destined to each peer
+// between trickle broadcasts. Rather than relaying each message to its
+// recipient as soon as it's generated, messages are queued within the batch
+// and released all at once at the next trickle interval. Identical messages
+// queued for the same peer within a single interval are only sent once.
+type announcementBatch struct {
+	// msgs is the ordered set of messages to be sent to each peer, keyed
+	// by the peer's lightning ID.
+	msgs map[[32]byte][]lnwire.Message
+
+	// seen is the set of digests of the messages queued for each peer
+	// within the current interval, used to de-duplicate messages.
+	seen map[[32]byte]map[[32]byte]struct{}
+
+	// numDuplicates is the number of messages dropped during the current
+	// interval as they had already been queued.
+	numDuplicates int
+}
+
+// newAnnouncementBatch creates a new, empty announcementBatch.
+func newAnnouncementBatch() *announcementBatch {
+	return &announcementBatch{
+		msgs: make(map[[32]byte][]lnwire.Message),
+		seen: make(map[[32]byte]map[[32]byte]struct{}),
+	}
+}
+
+// add queues the passed message to be sent to the target peer at the next
+// trickle interval. If an identical message has already been queued for the
+// peer within this interval, then the message is dropped and false is
+// returned.
+func (a *announcementBatch) add(target [32]byte, msg lnwire.Message) (bool, error) {
+	var b bytes.Buffer
+	if _, err := lnwire.WriteMessage(&b, msg, 0, 0); err != nil {
+		return false, err
+	}
+	digest := fastsha256.Sum256(b.Bytes())
+
+	if _, ok := a.seen[target]; !ok {
+		a.seen[target] = make(map[[32]byte]struct{})
+	}
+	if _, ok := a.seen[target][digest]; ok {
+		a.numDuplicates++
+		return false, nil
+	}
+	a.seen[target][digest] = struct{}{}
+
+	a.msgs[target] = append(a.msgs[target], msg)
+	return true, nil
+}
+
+// len returns the total number of messages currently queued within the
+// batch, across all peers.
+func (a *announcementBatch) len() int {
+	var numMsgs int
+	for _, msgs := range a.msgs {
+		numMsgs += len(msgs)
+	}
+
+	return numMsgs
+}
+
+// reset clears the batch, readying it for the next trickle interval.
+func (a *announcementBatch) reset() {
+	a.msgs = make(map[[32]byte][]lnwire.Message)
+	a.seen = make(map[[32]byte]map[[32]byte]struct{})
+	a.numDuplicates = 0
+}
+
+// filterRoutingMessage drops every channel with a capacity below minCapacity
+// from the routing table carried by the passed message, so the channel is
+// neither stored within our own routing table nor relayed to our other peers.
+// Messages without a routing table, or a minCapacity of zero, are left
+// untouched.
+// TODO(roasbeef): also filter the difference buffers of NeighborUpdMessages.
+func filterRoutingMessage(msg lnwire.Message, minCapacity int64) {
+	if minCapacity == 0 {
+		return
+	}
+
+	switch msg := msg.(type) {
+	case *lnwire.NeighborHelloMessage:
+		msg.RT = filterRoutingTable(msg.RT, minCapacity)
+	case *lnwire.RoutingTableTransferMessage:
+		msg.RT = filterRoutingTable(msg.RT, minCapacity)
+	}
+}
+
+// filterRoutingTable returns a copy of the passed routing table containing
+// only the channels with a capacity of at least minCapacity.
+func filterRoutingTable(table *rt.RoutingTable, minCapacity int64) *rt.RoutingTable {
+	if table == nil {
+		return nil
+	}
+
+	filtered := rt.NewRoutingTable()
+	for _, channel := range table.AllChannels() {
+		if channel.Info.Capacity() < minCapacity {
+			continue
+		}
+
+		filtered.AddChannel(channel.Id1, channel.Id2, channel.EdgeID,
+			channel.Info)
+	}
+
+	return filtered
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/BitfuryLightning/tools/rt"
+	"github.com/BitfuryLightning/tools/rt/graph"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestFilterRoutingMessage tests that channels below the minimum capacity are
+// dropped from the routing tables received from peers, and that a minimum
+// capacity of zero leaves them untouched.
+func TestFilterRoutingMessage(t *testing.T) {
+	var (
+		node1 = graph.NewID(1)
+		node2 = graph.NewID(2)
+		node3 = graph.NewID(3)
+
+		smallChan = graph.NewEdgeID("small")
+		largeChan = graph.NewEdgeID("large")
+	)
+
+	newTable := func() *rt.RoutingTable {
+		table := rt.NewRoutingTable()
+		table.AddChannel(node1, node2, smallChan,
+			&rt.ChannelInfo{Cpt: 1000})
+		table.AddChannel(node2, node3, largeChan,
+			&rt.ChannelInfo{Cpt: 100000})
+		return table
+	}
+
+	// Without a minimum capacity, the routing table shouldn't be touched.
+	table := newTable()
+	hello := &lnwire.NeighborHelloMessage{RT: table}
+	filterRoutingMessage(hello, 0)
+	if hello.RT != table {
+		t.Fatalf("routing table replaced without a minimum capacity")
+	}
+
+	msgs := []lnwire.Message{
+		&lnwire.NeighborHelloMessage{RT: newTable()},
+		&lnwire.RoutingTableTransferMessage{RT: newTable()},
+	}
+	for _, msg := range msgs {
+		filterRoutingMessage(msg, 5000)
+
+		var filtered *rt.RoutingTable
+		switch msg := msg.(type) {
+		case *lnwire.NeighborHelloMessage:
+			filtered = msg.RT
+		case *lnwire.RoutingTableTransferMessage:
+			filtered = msg.RT
+		}
+
+		if filtered.HasChannel(node1, node2, smallChan) {
+			t.Fatalf("%T: small channel not dropped", msg)
+		}
+		if !filtered.HasChannel(node2, node3, largeChan) {
+			t.Fatalf("%T: large channel dropped", msg)
+		}
+	}
+
+	// A message lacking a routing table should be left as is.
+	hello = &lnwire.NeighborHelloMessage{}
+	filterRoutingMessage(hello, 5000)
+	if hello.RT != nil {
+		t.Fatalf("routing table added to message")
+	}
+}
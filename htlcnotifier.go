@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// htlcEventType denotes the stage of an HTLC's lifecycle an htlcEvent
+// describes.
+type htlcEventType uint8
+
+const (
+	// htlcEventForward indicates that an HTLC has been added to one of
+	// our channels, either by us or by the remote peer.
+	htlcEventForward htlcEventType = iota
+
+	// htlcEventSettle indicates that an HTLC within one of our channels
+	// has been settled with its preimage.
+	htlcEventSettle
+
+	// htlcEventForwardFail indicates that the switch was unable to locate
+	// a link with sufficient bandwidth to forward an HTLC over.
+	htlcEventForwardFail
+
+	// htlcEventLinkFail indicates that a link rejected an HTLC, either
+	// because it would violate the channel's limits, or because we refused
+	// to settle an HTLC paying to one of our invoices.
+	htlcEventLinkFail
+)
+
+// htlcEvent describes a single change in the state of an HTLC flowing through
+// the daemon. Incoming HTLC's are offered to us by the remote peer, while
+// outgoing HTLC's are offered by us, so at most one of the incoming and
+// outgoing channels is set until multi-hop forwarding is supported.
+type htlcEvent struct {
+	eventType htlcEventType
+
+	incomingChanPoint   *wire.OutPoint
+	incomingShortChanID lnwire.ShortChannelID
+
+	outgoingChanPoint   *wire.OutPoint
+	outgoingShortChanID lnwire.ShortChannelID
+
+	amt         btcutil.Amount
+	paymentHash [32]byte
+
+	// failureCode and failureReason describe why the HTLC failed, and are
+	// only set for htlcEventForwardFail and htlcEventLinkFail.
+	failureCode   lnwire.FailCode
+	failureReason string
+
+	// customRecords is the set of custom records attached by the sender
+	// to an HTLC paying to one of our invoices, and is only set for
+	// htlcEventSettle.
+	customRecords map[uint64][]byte
+
+	timestamp time.Time
+}
+
+// htlcEventSubscription is a client subscription to all HTLC events. Each
+// event is delivered over the events channel.
+type htlcEventSubscription struct {
+	id uint64
+
+	events chan *htlcEvent
+
+	cancel func()
+}
+
+// htlcNotifier dispatches HTLC events from the switch and each channel's
+// htlcManager to all subscribed clients. Events are delivered on a best-effort
+// basis: slow subscribers miss events rather than stalling a channel.
+type htlcNotifier struct {
+	subscribers   map[uint64]*htlcEventSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newHtlcNotifier creates a new htlcNotifier without any subscribers.
+func newHtlcNotifier() *htlcNotifier {
+	return &htlcNotifier{
+		subscribers: make(map[uint64]*htlcEventSubscription),
+	}
+}
+
+// subscribe creates a new subscription to all HTLC events which occur after
+// this call returns.
+func (h *htlcNotifier) subscribe() *htlcEventSubscription {
+	h.subscriberMtx.Lock()
+	defer h.subscriberMtx.Unlock()
+
+	clientID := h.nextClientID
+	h.nextClientID++
+
+	sub := &htlcEventSubscription{
+		id:     clientID,
+		events: make(chan *htlcEvent, htlcQueueSize),
+	}
+	sub.cancel = func() {
+		h.subscriberMtx.Lock()
+		delete(h.subscribers, clientID)
+		h.subscriberMtx.Unlock()
+	}
+	h.subscribers[clientID] = sub
+
+	return sub
+}
+
+// notify timestamps the passed event, then sends it to all current
+// subscribers.
+func (h *htlcNotifier) notify(event *htlcEvent) {
+	h.subscriberMtx.Lock()
+	defer h.subscriberMtx.Unlock()
+
+	if len(h.subscribers) == 0 {
+		return
+	}
+
+	event.timestamp = time.Now()
+	for _, sub := range h.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			hswcLog.Warnf("Dropping htlc event for slow "+
+				"subscriber %v", sub.id)
+		}
+	}
+}
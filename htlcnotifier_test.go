@@ -0,0 +1,147 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestHtlcNotifierSubscribe tests that each event is delivered to all current
+// subscribers, that cancelled subscribers no longer receive events, and that a
+// slow subscriber misses events rather than blocking the notifier.
+func TestHtlcNotifierSubscribe(t *testing.T) {
+	h := newHtlcNotifier()
+
+	sub1 := h.subscribe()
+	sub2 := h.subscribe()
+	defer sub2.cancel()
+
+	event := &htlcEvent{
+		eventType:   htlcEventForward,
+		amt:         1000,
+		paymentHash: [32]byte{1},
+	}
+	h.notify(event)
+	for _, sub := range []*htlcEventSubscription{sub1, sub2} {
+		select {
+		case e := <-sub.events:
+			if e != event {
+				t.Fatalf("subscriber %v received the wrong "+
+					"event", sub.id)
+			}
+			if e.timestamp.IsZero() {
+				t.Fatalf("event not timestamped")
+			}
+		default:
+			t.Fatalf("subscriber %v missed event", sub.id)
+		}
+	}
+
+	// Once cancelled, the first subscriber should no longer receive
+	// events.
+	sub1.cancel()
+	h.notify(&htlcEvent{eventType: htlcEventSettle})
+	select {
+	case <-sub1.events:
+		t.Fatalf("event sent to cancelled subscriber")
+	default:
+	}
+	<-sub2.events
+
+	// Notifying more events than the second subscriber has room for must
+	// not block, with the excess events dropped.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < htlcQueueSize+1; i++ {
+			h.notify(&htlcEvent{eventType: htlcEventSettle})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("notifier blocked by slow subscriber")
+	}
+	if len(sub2.events) != htlcQueueSize {
+		t.Fatalf("expected %v queued events, got %v", htlcQueueSize,
+			len(sub2.events))
+	}
+}
+
+// TestSwitchForwardFailEvent tests that the switch notifies subscribers of
+// each HTLC it's unable to forward, along with the reason why.
+func TestSwitchForwardFailEvent(t *testing.T) {
+	notifier := newHtlcNotifier()
+	sub := notifier.subscribe()
+	defer sub.cancel()
+
+	h := newHtlcSwitch(notifier)
+	if err := h.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer h.Stop()
+
+	p := &peer{lightningID: wire.ShaHash{1}}
+	h.RegisterLink(p, &channeldb.ChannelSnapshot{
+		ChannelPoint: &wire.OutPoint{Hash: wire.ShaHash{2}},
+		Capacity:     10000,
+		LocalBalance: 10000,
+	}, make(chan *htlcPacket, 1))
+
+	tests := []struct {
+		name string
+		dest wire.ShaHash
+		amt  lnwire.CreditsAmount
+		code lnwire.FailCode
+	}{
+		{
+			name: "unknown peer",
+			dest: wire.ShaHash{3},
+			amt:  1000,
+			code: lnwire.CodeUnknownNextPeer,
+		},
+		{
+			name: "insufficient bandwidth",
+			dest: p.lightningID,
+			amt:  20000,
+			code: lnwire.CodeTemporaryChannelFailure,
+		},
+	}
+	for i, test := range tests {
+		paymentHash := [32]byte{byte(i)}
+		err := h.SendHTLC(&htlcPacket{
+			dest: test.dest,
+			msg: &lnwire.HTLCAddRequest{
+				Amount:           test.amt,
+				RedemptionHashes: [][32]byte{paymentHash},
+			},
+		})
+		if err == nil {
+			t.Fatalf("%s: htlc forwarded", test.name)
+		}
+
+		select {
+		case event := <-sub.events:
+			if event.eventType != htlcEventForwardFail {
+				t.Fatalf("%s: expected forward fail event, got "+
+					"%v", test.name, event.eventType)
+			}
+			if event.failureCode != test.code {
+				t.Fatalf("%s: expected failure code %v, got %v",
+					test.name, test.code, event.failureCode)
+			}
+			if event.paymentHash != paymentHash ||
+				event.amt != btcutil.Amount(test.amt) {
+
+				t.Fatalf("%s: event for the wrong htlc",
+					test.name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: no event for failed forward", test.name)
+		}
+	}
+}
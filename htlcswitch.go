@@ -35,6 +35,11 @@ type link struct {
 	peer *peer
 
 	chanPoint *wire.OutPoint
+
+	// shortChanID is the location of the channel's funding output within
+	// the chain. It's the zero value for channels which haven't yet been
+	// confirmed.
+	shortChanID lnwire.ShortChannelID
 }
 
 // htlcPacket is a wrapper around an lnwire message which adds, times out, or
@@ -44,6 +49,11 @@ type htlcPacket struct {
 	src  wire.ShaHash
 	dest wire.ShaHash
 
+	// chanPoint is the channel to the destination the HTLC must be sent
+	// over. If nil, then the HTLC may be sent over any link to the
+	// destination.
+	chanPoint *wire.OutPoint
+
 	msg lnwire.Message
 	amt btcutil.Amount
 
@@ -68,6 +78,11 @@ type htlcSwitch struct {
 	// pointer to the peer mangaing the channel.
 	chanIndex map[wire.OutPoint]*link
 
+	// shortChanIndex maps a channel's short channel ID to its link,
+	// allowing the outgoing link of a forwarded HTLC to be located
+	// directly from the short channel ID specified within its onion.
+	shortChanIndex map[lnwire.ShortChannelID]*link
+
 	// interfaces maps a node's ID to the set of links (active channels) we
 	// currently have open with that peer.
 	interfaces map[wire.ShaHash][]*link
@@ -79,6 +94,9 @@ type htlcSwitch struct {
 
 	htlcPlex chan *htlcPacket
 
+	// notifier is notified of each HTLC the switch is unable to forward.
+	notifier *htlcNotifier
+
 	// TODO(roasbeef): messaging chan to/from upper layer (routing - L3)
 
 	// TODO(roasbeef): sampler to log sat/sec and tx/sec
@@ -87,10 +105,13 @@ type htlcSwitch struct {
 	quit chan struct{}
 }
 
-// newHtlcSwitch creates a new htlcSwitch.
-func newHtlcSwitch() *htlcSwitch {
+// newHtlcSwitch creates a new htlcSwitch which reports failed forwards to the
+// passed notifier.
+func newHtlcSwitch(notifier *htlcNotifier) *htlcSwitch {
 	return &htlcSwitch{
+		notifier:         notifier,
 		chanIndex:        make(map[wire.OutPoint]*link),
+		shortChanIndex:   make(map[lnwire.ShortChannelID]*link),
 		interfaces:       make(map[wire.ShaHash][]*link),
 		linkControl:      make(chan interface{}),
 		htlcPlex:         make(chan *htlcPacket, htlcQueueSize),
@@ -157,50 +178,42 @@ out:
 		select {
 		case htlcPkt := <-h.outgoingPayments:
 			dest := htlcPkt.dest
-			chanInterface, ok := h.interfaces[dest]
-			if !ok {
-				err := fmt.Errorf("Unable to locate link %x", dest)
-				hswcLog.Errorf(err.Error())
-				htlcPkt.err <- err
-				continue
-			}
-
 			wireMsg := htlcPkt.msg.(*lnwire.HTLCAddRequest)
 			amt := btcutil.Amount(wireMsg.Amount)
 
-			// Handle this send request in a distinct goroutine in
-			// order to avoid a possible deadlock between the htlc
-			// switch and channel's htlc manager.
-			var sent bool
-			for _, link := range chanInterface {
-				// TODO(roasbeef): implement HTLC fragmentation
-				//  * avoid full channel depletion at higher
-				//    level (here) instead of within state
-				//    machine?
-				if link.availableBandwidth < amt {
-					continue
+			link, failureCode, failureReason := h.selectLink(htlcPkt, amt)
+			if link == nil {
+				hswcLog.Errorf("Unable to send payment to %x: %v",
+					dest[:], failureReason)
+				failure := &lnwire.FailureMessage{Code: failureCode}
+				if failureCode == lnwire.CodeTemporaryChannelFailure {
+					failure.Amount = wireMsg.Amount
 				}
-
-				hswcLog.Tracef("Sending %v to %x", amt, dest[:])
-
-				// TODO(roasbeef): peer downstream should set chanPoint
-				wireMsg.ChannelPoint = link.chanPoint
-				go func() {
-					link.linkChan <- htlcPkt
-				}()
-
-				// TODO(roasbeef): update link info on
-				// timeout/settle
-				link.availableBandwidth -= amt
-				sent = true
-			}
-
-			if sent {
+				htlcPkt.err <- failure
+				h.notifier.notify(&htlcEvent{
+					eventType:     htlcEventForwardFail,
+					amt:           amt,
+					paymentHash:   wireMsg.RedemptionHashes[0],
+					failureCode:   failureCode,
+					failureReason: failureReason,
+				})
 				continue
 			}
 
-			hswcLog.Errorf("Unable to send payment, insufficient capacity")
-			htlcPkt.err <- fmt.Errorf("Insufficient capacity")
+			hswcLog.Tracef("Sending %v to %x over %v", amt, dest[:],
+				link.chanPoint)
+
+			// Handle this send request in a distinct goroutine in
+			// order to avoid a possible deadlock between the htlc
+			// switch and channel's htlc manager.
+			wireMsg.ChannelPoint = link.chanPoint
+			go func(linkChan chan *htlcPacket) {
+				linkChan <- htlcPkt
+			}(link.linkChan)
+
+			// TODO(roasbeef): update link info on
+			// timeout/settle
+			link.availableBandwidth -= amt
 		case pkt := <-h.htlcPlex:
 			numUpdates += 1
 			// TODO(roasbeef): properly account with cleared vs settled
@@ -212,7 +225,8 @@ out:
 			}
 
 			// TODO(roasbeef): parse dest/src, forward on outgoing
-			// link to complete multi-hop payments.
+			// link (located via the shortChanIndex) to complete
+			// multi-hop payments.
 		case <-logTicker.C:
 			if numUpdates == 0 {
 				continue
@@ -233,6 +247,49 @@ out:
 	h.wg.Done()
 }
 
+// selectLink selects the link the passed outgoing HTLC should be sent over. If
+// the packet designates a channel, then only the link of that channel may
+// carry the HTLC, otherwise the first link to the destination with sufficient
+// bandwidth is selected. If no link is able to carry the HTLC, then nil is
+// returned along with the failure code and reason the HTLC should be failed
+// with.
+func (h *htlcSwitch) selectLink(htlcPkt *htlcPacket,
+	amt btcutil.Amount) (*link, lnwire.FailCode, string) {
+
+	chanInterface, ok := h.interfaces[htlcPkt.dest]
+	if !ok {
+		return nil, lnwire.CodeUnknownNextPeer, "unable to locate link"
+	}
+
+	// If the HTLC must be sent over a particular channel, then that
+	// channel's link must belong to the destination's interface.
+	if htlcPkt.chanPoint != nil {
+		link, ok := h.chanIndex[*htlcPkt.chanPoint]
+		if !ok || link.peer.lightningID != htlcPkt.dest {
+			return nil, lnwire.CodeUnknownNextPeer,
+				"unable to locate link"
+		}
+		if link.availableBandwidth < amt {
+			return nil, lnwire.CodeTemporaryChannelFailure,
+				"insufficient capacity"
+		}
+
+		return link, 0, ""
+	}
+
+	// TODO(roasbeef): implement HTLC fragmentation
+	//  * avoid full channel depletion at higher
+	//    level (here) instead of within state
+	//    machine?
+	for _, link := range chanInterface {
+		if link.availableBandwidth >= amt {
+			return link, 0, ""
+		}
+	}
+
+	return nil, lnwire.CodeTemporaryChannelFailure, "insufficient capacity"
+}
+
 // networkAdmin is responsible for handline requests to register, unregister,
 // and close any link. In the event that a unregister requests leaves an
 // interface with no active links, that interface is garbage collected.
@@ -268,8 +325,12 @@ func (h *htlcSwitch) handleRegisterLink(req *registerLinkMsg) {
 		linkChan:           req.linkChan,
 		peer:               req.peer,
 		chanPoint:          chanPoint,
+		shortChanID:        req.linkInfo.ShortChanID,
 	}
 	h.chanIndex[*chanPoint] = newLink
+	if newLink.shortChanID != (lnwire.ShortChannelID{}) {
+		h.shortChanIndex[newLink.shortChanID] = newLink
+	}
 
 	interfaceID := req.peer.lightningID
 	h.interfaces[interfaceID] = append(h.interfaces[interfaceID], newLink)
@@ -300,9 +361,13 @@ func (h *htlcSwitch) handleUnregisterLink(req *unregisterLinkMsg) {
 
 		for _, link := range links {
 			delete(h.chanIndex, *link.chanPoint)
+			delete(h.shortChanIndex, link.shortChanID)
 		}
 		links = nil
 	} else {
+		if link, ok := h.chanIndex[*req.chanPoint]; ok {
+			delete(h.shortChanIndex, link.shortChanID)
+		}
 		delete(h.chanIndex, *req.chanPoint)
 
 		for i := 0; i < len(links); i++ {
@@ -404,25 +469,33 @@ type closeLinkReq struct {
 	chanPoint  *wire.OutPoint
 	forceClose bool
 
+	// deliveryScript, if non-nil, is the script our balance is delivered
+	// to upon a cooperative close, replacing the one negotiated during
+	// funding.
+	deliveryScript []byte
+
 	updates chan *lnrpc.CloseStatusUpdate
 	err     chan error
 }
 
 // CloseLink closes an active link targetted by it's channel point. Closing the
 // link initiates a cooperative channel closure iff forceClose is false. If
-// forceClose is true, then a unilateral channel closure is executed.
+// forceClose is true, then a unilateral channel closure is executed. The
+// passed delivery script, if non-nil, is the script our balance is delivered
+// to upon a cooperative closure.
 // TODO(roabeef): bool flag for timeout
-func (h *htlcSwitch) CloseLink(chanPoint *wire.OutPoint,
-	forceClose bool) (chan *lnrpc.CloseStatusUpdate, chan error) {
+func (h *htlcSwitch) CloseLink(chanPoint *wire.OutPoint, forceClose bool,
+	deliveryScript []byte) (chan *lnrpc.CloseStatusUpdate, chan error) {
 
 	updateChan := make(chan *lnrpc.CloseStatusUpdate, 1)
 	errChan := make(chan error, 1)
 
 	h.linkControl <- &closeLinkReq{
-		chanPoint:  chanPoint,
-		forceClose: forceClose,
-		updates:    updateChan,
-		err:        errChan,
+		chanPoint:      chanPoint,
+		forceClose:     forceClose,
+		deliveryScript: deliveryScript,
+		updates:        updateChan,
+		err:            errChan,
 	}
 
 	return updateChan, errChan
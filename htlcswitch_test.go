@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestSwitchSendOverChosenLink tests that an HTLC designating a channel is
+// only ever sent over the link of that channel, even if another link to the
+// same peer is able to carry it.
+func TestSwitchSendOverChosenLink(t *testing.T) {
+	h := newHtlcSwitch(newHtlcNotifier())
+	if err := h.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer h.Stop()
+
+	// Register two links to the same peer, the first of which has the
+	// larger balance.
+	p := &peer{lightningID: wire.ShaHash{1}}
+	bigChan := &wire.OutPoint{Hash: wire.ShaHash{2}}
+	smallChan := &wire.OutPoint{Hash: wire.ShaHash{3}}
+	bigLink := make(chan *htlcPacket, 1)
+	smallLink := make(chan *htlcPacket, 1)
+	h.RegisterLink(p, &channeldb.ChannelSnapshot{
+		ChannelPoint: bigChan,
+		Capacity:     100000,
+		LocalBalance: 100000,
+	}, bigLink)
+	h.RegisterLink(p, &channeldb.ChannelSnapshot{
+		ChannelPoint: smallChan,
+		Capacity:     10000,
+		LocalBalance: 10000,
+	}, smallLink)
+
+	// sendHTLC sends an HTLC of the passed amount to the peer over the
+	// passed channel, returning the error the switch responds with.
+	sendHTLC := func(amt lnwire.CreditsAmount,
+		chanPoint *wire.OutPoint) chan error {
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- h.SendHTLC(&htlcPacket{
+				dest:      p.lightningID,
+				chanPoint: chanPoint,
+				msg: &lnwire.HTLCAddRequest{
+					Amount:           amt,
+					RedemptionHashes: [][32]byte{{1}},
+				},
+			})
+		}()
+		return errChan
+	}
+
+	// An HTLC over the smaller channel should arrive on its link, rather
+	// than on the link with the larger balance.
+	errChan := sendHTLC(1000, smallChan)
+	select {
+	case pkt := <-smallLink:
+		htlc := pkt.msg.(*lnwire.HTLCAddRequest)
+		if *htlc.ChannelPoint != *smallChan {
+			t.Fatalf("expected htlc over %v, got %v", smallChan,
+				htlc.ChannelPoint)
+		}
+		pkt.err <- nil
+	case <-bigLink:
+		t.Fatalf("htlc sent over the wrong link")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("htlc not sent")
+	}
+	if err := <-errChan; err != nil {
+		t.Fatalf("unable to send htlc: %v", err)
+	}
+
+	// An HTLC exceeding the bandwidth of the chosen channel should fail,
+	// even though the other link could carry it.
+	err := <-sendHTLC(20000, smallChan)
+	failure, ok := err.(*lnwire.FailureMessage)
+	if !ok || failure.Code != lnwire.CodeTemporaryChannelFailure {
+		t.Fatalf("expected temporary channel failure, got %v", err)
+	}
+
+	// An HTLC over a channel not belonging to the peer should fail.
+	unknownChan := &wire.OutPoint{Hash: wire.ShaHash{4}}
+	err = <-sendHTLC(1000, unknownChan)
+	failure, ok = err.(*lnwire.FailureMessage)
+	if !ok || failure.Code != lnwire.CodeUnknownNextPeer {
+		t.Fatalf("expected unknown next peer, got %v", err)
+	}
+
+	select {
+	case <-bigLink:
+		t.Fatalf("htlc sent over the wrong link")
+	case <-smallLink:
+		t.Fatalf("failed htlc sent over a link")
+	default:
+	}
+}
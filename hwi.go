@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/hwsigner"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+// hwiConfig is the parsed configuration of the hardware wallet holding our
+// on-chain funds.
+type hwiConfig struct {
+	fingerprint [4]byte
+	path        []uint32
+	xpub        *hdkeychain.ExtendedKey
+	chain       string
+}
+
+// parseHWIConfig parses the configuration of the hardware wallet holding our
+// on-chain funds. If no hardware wallet is configured, then nil is returned.
+func parseHWIConfig(cfg *config) (*hwiConfig, error) {
+	if cfg.HWIFingerprint == "" && cfg.HWIXPub == "" {
+		return nil, nil
+	}
+	if cfg.HWIFingerprint == "" || cfg.HWIXPub == "" {
+		return nil, fmt.Errorf("both the hwi.fingerprint and hwi.xpub " +
+			"options must be set to use a hardware wallet")
+	}
+
+	hwCfg := &hwiConfig{}
+	fingerprint, err := hex.DecodeString(cfg.HWIFingerprint)
+	if err != nil || len(fingerprint) != len(hwCfg.fingerprint) {
+		return nil, fmt.Errorf("invalid hardware wallet fingerprint %q, "+
+			"expected 8 hex characters", cfg.HWIFingerprint)
+	}
+	copy(hwCfg.fingerprint[:], fingerprint)
+
+	// Unless told otherwise, we assume the extended public key is that of
+	// the first BIP 84 account of the active network.
+	path := cfg.HWIPath
+	if path == "" {
+		path = fmt.Sprintf("m/84'/%d'/0'", activeNetParams.HDCoinType)
+	}
+	hwCfg.path, err = hwsigner.ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	hwCfg.xpub, err = hdkeychain.NewKeyFromString(cfg.HWIXPub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hardware wallet extended "+
+			"public key: %v", err)
+	}
+	if hwCfg.xpub.IsPrivate() {
+		return nil, fmt.Errorf("hardware wallet extended key must be " +
+			"public, never pass a private key")
+	}
+	if !hwCfg.xpub.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("hardware wallet extended public key "+
+			"isn't for the %v network", activeNetParams.Name)
+	}
+
+	hwCfg.chain, err = hwsigner.HWIChain(activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return hwCfg, nil
+}
+
+// newHardwareWallet wraps the passed wallet and signer, such that our
+// on-chain funds are held by the configured hardware wallet, while the keys of
+// our channels remain with the passed wallet and signer.
+func newHardwareWallet(hwCfg *hwiConfig, wallet lnwallet.WalletController,
+	signer lnwallet.Signer, chanDB *channeldb.DB) (lnwallet.WalletController,
+	lnwallet.Signer, error) {
+
+	account, err := hwsigner.NewAccount(hwCfg.fingerprint, hwCfg.path,
+		hwCfg.xpub, activeNetParams.Params, chanDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	device := &hwsigner.HWI{
+		Bin:         cfg.HWIBin,
+		Fingerprint: hwCfg.fingerprint,
+		Chain:       hwCfg.chain,
+	}
+
+	ltndLog.Infof("On-chain funds held by hardware wallet %x",
+		hwCfg.fingerprint[:])
+
+	return hwsigner.NewWallet(wallet, account),
+		hwsigner.NewSigner(signer, device, account,
+			wallet.FetchInputInfo), nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// errServerShuttingDown is returned when a request can't be handled as the
+// server is shutting down.
+var errServerShuttingDown = errors.New("server shutting down")
+
+// addrHost returns the IP address of the passed network address.
+func addrHost(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+
+	return host
+}
+
+// peerHost returns the IP address the passed peer is connected from.
+func peerHost(p *peer) string {
+	return addrHost(p.conn.RemoteAddr())
+}
+
+// numInboundFrom returns the number of inbound peers connected from the
+// passed IP address.
+//
+// NOTE: This MUST only be called from the queryHandler goroutine.
+func (s *server) numInboundFrom(host string) int {
+	var numFromIP int
+	for _, p := range s.peers {
+		if p.inbound && peerHost(p) == host {
+			numFromIP++
+		}
+	}
+
+	return numFromIP
+}
+
+// inboundConnMsg is a message sent to the server in order to learn whether a
+// new inbound connection from the passed IP address may proceed to the
+// handshake.
+type inboundConnMsg struct {
+	host string
+	resp chan error
+}
+
+// filterInboundConn refuses a new inbound connection before its handshake if
+// the IP address it's from has already reached the per-IP limit, sparing us
+// the cost of the handshake and the remote peer's Init message. As several
+// connections from the same IP address may carry out their handshakes at
+// once, the limit is enforced once more by admitInboundPeer.
+func (s *server) filterInboundConn(remoteAddr net.Addr) error {
+	if cfg.MaxPeersPerIP == 0 {
+		return nil
+	}
+
+	req := &inboundConnMsg{
+		host: addrHost(remoteAddr),
+		resp: make(chan error, 1),
+	}
+	select {
+	case s.queries <- req:
+	case <-s.quit:
+		return errServerShuttingDown
+	}
+
+	select {
+	case err := <-req.resp:
+		return err
+	case <-s.quit:
+		return errServerShuttingDown
+	}
+}
+
+// handleInboundConn responds to a query as to whether a new inbound connection
+// may proceed to the handshake.
+func (s *server) handleInboundConn(req *inboundConnMsg) {
+	numFromIP := s.numInboundFrom(req.host)
+	if numFromIP >= cfg.MaxPeersPerIP {
+		req.resp <- fmt.Errorf("refusing inbound connection: %v peers "+
+			"already connected from %v", numFromIP, req.host)
+		return
+	}
+
+	req.resp <- nil
+}
+
+// hasChannels returns whether we have any open channels with the passed peer.
+func (s *server) hasChannels(p *peer) bool {
+	channels, err := s.chanDB.FetchOpenChannels(&p.lightningID)
+	if err != nil {
+		// If we're unable to tell, then we assume the peer has
+		// channels so it's never evicted in error.
+		srvrLog.Errorf("unable to fetch channels of %v: %v", p, err)
+		return true
+	}
+
+	return len(channels) != 0
+}
+
+// admitInboundPeer enforces the limits on inbound connections for a newly
+// connected inbound peer, returning whether the peer may be added. A peer
+// connecting from an IP address which has already reached the per-IP limit
+// is refused. Once the total limit is reached, room is made for the peer by
+// evicting an existing inbound peer we have no channels with, unless we're to
+// remain connected to it. If no such peer exists, then the new peer is
+// refused.
+//
+// NOTE: This MUST only be called from the queryHandler goroutine.
+func (s *server) admitInboundPeer(p *peer) bool {
+	host := peerHost(p)
+
+	var (
+		numInbound int
+		evictable  []*peer
+	)
+	for _, existing := range s.peers {
+		if !existing.inbound {
+			continue
+		}
+		numInbound++
+
+		if _, ok := s.persistentPeers[existing.lightningID]; !ok {
+			evictable = append(evictable, existing)
+		}
+	}
+
+	numFromIP := s.numInboundFrom(host)
+	if cfg.MaxPeersPerIP != 0 && numFromIP >= cfg.MaxPeersPerIP {
+		srvrLog.Warnf("Refusing inbound peer %v: %v peers already "+
+			"connected from %v", p, numFromIP, host)
+		return false
+	}
+
+	if cfg.MaxInboundPeers == 0 || numInbound < cfg.MaxInboundPeers {
+		return true
+	}
+
+	// We're out of inbound slots, so the new peer may only take the slot
+	// of a peer we have no channels with.
+	for _, candidate := range evictable {
+		if s.hasChannels(candidate) {
+			continue
+		}
+
+		srvrLog.Infof("Evicting inbound peer %v without channels to "+
+			"admit %v", candidate, p)
+
+		candidate.Disconnect()
+		s.removePeer(candidate)
+		return true
+	}
+
+	srvrLog.Warnf("Refusing inbound peer %v: %v inbound peers already "+
+		"connected", p, numInbound)
+	return false
+}
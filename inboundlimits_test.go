@@ -0,0 +1,153 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lndc"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockConn is a connection which only reports its remote address.
+type mockConn struct {
+	net.Conn
+
+	remoteAddr net.Addr
+}
+
+func (m *mockConn) RemoteAddr() net.Addr {
+	return m.remoteAddr
+}
+
+// TestInboundPeerLimits tests that inbound peers from an IP address at the
+// per-IP limit are refused both before and after the handshake, and that once
+// the total limit is reached, a new inbound peer evicts a peer we may
+// disconnect from, or is refused if there is none.
+func TestInboundPeerLimits(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "inboundlimits")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	prevCfg := cfg
+	cfg = &config{MaxInboundPeers: 3, MaxPeersPerIP: 2}
+	defer func() { cfg = prevCfg }()
+
+	s := &server{
+		chanDB:          db,
+		htlcSwitch:      newHtlcSwitch(newHtlcNotifier()),
+		peerNotifier:    newPeerNotifier(),
+		onionMessenger:  newOnionMessenger(nil),
+		peers:           make(map[int32]*peer),
+		persistentPeers: make(map[wire.ShaHash]*lndc.LNAdr),
+		donePeers:       make(chan *peer, 100),
+		quit:            make(chan struct{}),
+	}
+	if err := s.htlcSwitch.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.htlcSwitch.Stop()
+
+	var nextID int32
+	newInboundPeer := func(ip string) *peer {
+		nextID++
+		return &peer{
+			conn: &mockConn{
+				remoteAddr: &net.TCPAddr{
+					IP:   net.ParseIP(ip),
+					Port: 9735 + int(nextID),
+				},
+			},
+			server:      s,
+			inbound:     true,
+			id:          nextID,
+			lightningID: wire.ShaHash{byte(nextID)},
+			quit:        make(chan struct{}),
+		}
+	}
+
+	// filter queries the filter applied to a new connection from the
+	// peer's address before the handshake, as the queryHandler would.
+	filter := func(p *peer) error {
+		req := &inboundConnMsg{
+			host: peerHost(p),
+			resp: make(chan error, 1),
+		}
+		s.handleInboundConn(req)
+		return <-req.resp
+	}
+	admit := func(p *peer) bool {
+		if !s.admitInboundPeer(p) {
+			return false
+		}
+		s.peers[p.id] = p
+		return true
+	}
+
+	// The first peer is one we're to remain connected to, so it's never
+	// evicted.
+	persistent := newInboundPeer("10.0.0.1")
+	s.persistentPeers[persistent.lightningID] = &lndc.LNAdr{}
+	if !admit(persistent) {
+		t.Fatalf("first peer refused")
+	}
+	evictable := newInboundPeer("10.0.0.1")
+	if !admit(evictable) {
+		t.Fatalf("second peer from IP refused")
+	}
+
+	// A third peer from the same IP address should be refused, both
+	// before the handshake and after it.
+	sameIP := newInboundPeer("10.0.0.1")
+	if err := filter(sameIP); err == nil {
+		t.Fatalf("connection beyond per-IP limit passed filter")
+	}
+	if admit(sameIP) {
+		t.Fatalf("peer beyond per-IP limit admitted")
+	}
+
+	// A connection from another IP address passes the filter, and the
+	// peer takes the last inbound slot.
+	other := newInboundPeer("10.0.0.2")
+	if err := filter(other); err != nil {
+		t.Fatalf("connection from new IP refused: %v", err)
+	}
+	if !admit(other) {
+		t.Fatalf("peer within limits refused")
+	}
+
+	// With every slot taken, a new peer evicts one of the peers without
+	// channels, leaving the persistent peer connected.
+	fourth := newInboundPeer("10.0.0.3")
+	if !admit(fourth) {
+		t.Fatalf("peer refused despite evictable peers")
+	}
+	if _, ok := s.peers[persistent.id]; !ok {
+		t.Fatalf("persistent peer evicted")
+	}
+	if len(s.peers) != 3 {
+		t.Fatalf("expected 3 peers, got %v", len(s.peers))
+	}
+
+	// Once we're to remain connected to every peer, a new peer must be
+	// refused.
+	for _, p := range s.peers {
+		if p != persistent {
+			s.persistentPeers[p.lightningID] = &lndc.LNAdr{}
+		}
+	}
+	if admit(newInboundPeer("10.0.0.4")) {
+		t.Fatalf("peer admitted without an evictable peer")
+	}
+}
@@ -14,9 +14,24 @@ import (
 type invoice struct {
 	value btcutil.Amount
 
+	// finalCltvDelta is the minimum number of blocks which must remain
+	// until an HTLC paying to this invoice expires at the time the HTLC
+	// is received.
+	finalCltvDelta uint32
+
+	// paymentAddr is the secret which the payer must present within the
+	// payload of the final hop. If the payment address is all zeroes,
+	// then no secret is required.
+	paymentAddr [32]byte
+
 	paymentHash     wire.ShaHash
 	paymentPreimage wire.ShaHash
 
+	// customRecords is the set of custom records the sender attached to
+	// an HTLC paying to the invoice. It's only populated within the copy
+	// of the invoice made for each HTLC.
+	customRecords map[uint64][]byte
+
 	// TODO(roasbeef): other contract stuff
 }
 
@@ -38,13 +53,20 @@ func newInvoiceRegistry() *invoiceRegistry {
 // addInvoice adds an invoice for the specified amount, identified by the
 // passed preimage. Once this invoice is added, sub-systems within the daemon
 // add/forward HTLC's are able to obtain the proper preimage required for
-// redemption in the case that we're the final destination.
-func (i *invoiceRegistry) addInvoice(amt btcutil.Amount, preimage wire.ShaHash) {
+// redemption in the case that we're the final destination. HTLC's paying to the
+// invoice must arrive with at least finalCltvDelta blocks remaining until
+// their expiry, and must carry the passed payment address within their final
+// hop payload.
+func (i *invoiceRegistry) addInvoice(amt btcutil.Amount, preimage wire.ShaHash,
+	finalCltvDelta uint32, paymentAddr [32]byte) {
+
 	paymentHash := wire.ShaHash(fastsha256.Sum256(preimage[:]))
 
 	i.Lock()
 	i.invoiceIndex[paymentHash] = &invoice{
 		value:           amt,
+		finalCltvDelta:  finalCltvDelta,
+		paymentAddr:     paymentAddr,
 		paymentHash:     paymentHash,
 		paymentPreimage: preimage,
 	}
@@ -61,6 +83,14 @@ func (i *invoiceRegistry) lookupInvoice(hash wire.ShaHash) (*invoice, bool) {
 	return inv, ok
 }
 
+// defaultFinalCltvDelta is the final CLTV delta used for invoices, and for
+// payments where the receiver's requirement isn't known.
+const defaultFinalCltvDelta = 144
+
+// zeroPaymentAddr is the payment address of invoices which don't require a
+// payment secret.
+var zeroPaymentAddr [32]byte
+
 var (
 	debugPre, _ = wire.NewShaHash(bytes.Repeat([]byte{1}, 32))
 	debugHash   = wire.ShaHash(fastsha256.Sum256(debugPre[:]))
@@ -71,6 +101,7 @@ var (
 func (i *invoiceRegistry) debugInvoice() *invoice {
 	return &invoice{
 		value:           btcutil.Amount(100000 * 1e8),
+		finalCltvDelta:  defaultFinalCltvDelta,
 		paymentPreimage: *debugPre,
 		paymentHash:     debugHash,
 	}
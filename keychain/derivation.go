@@ -0,0 +1,175 @@
+package keychain
+
+import (
+	"fmt"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+const (
+	// BIP0043Purpose is the "purpose" value used within the first level
+	// of the derivation path of all keys within the keychain, as defined
+	// by BIP43. All keys are derived at the following path:
+	//
+	//	m/1017'/coinType'/keyFamily'/0/index
+	BIP0043Purpose = 1017
+
+	// legacyNodeKeyIndex is the top level HD key index at which the node
+	// identity key was derived before the introduction of the keychain.
+	// To preserve existing node identities, index 0 of the KeyFamilyNodeKey
+	// family resolves to the key at this index.
+	legacyNodeKeyIndex = hdkeychain.HardenedKeyStart + 2
+)
+
+// KeyFamily represents a "family" of keys that will be used within the
+// keychain. Each family is derived along a distinct hardened branch, so keys
+// of one family reveal nothing about those of another.
+type KeyFamily uint32
+
+const (
+	// KeyFamilyMultiSig is the family of keys used within the 2-of-2
+	// multi-sig outputs of funding transactions.
+	KeyFamilyMultiSig KeyFamily = 0
+
+	// KeyFamilyRevocationBase is the family of keys used as the base
+	// points from which revocation keys are derived.
+	KeyFamilyRevocationBase KeyFamily = 1
+
+	// KeyFamilyHtlcBase is the family of keys used as the base points
+	// from which the keys within HTLC scripts are derived.
+	KeyFamilyHtlcBase KeyFamily = 2
+
+	// KeyFamilyNodeKey is the family of keys used as the node's long-term
+	// identity key.
+	KeyFamilyNodeKey KeyFamily = 6
+)
+
+// String returns a human readable name for the key family.
+func (k KeyFamily) String() string {
+	switch k {
+	case KeyFamilyMultiSig:
+		return "multisig"
+	case KeyFamilyRevocationBase:
+		return "revocation-base"
+	case KeyFamilyHtlcBase:
+		return "htlc-base"
+	case KeyFamilyNodeKey:
+		return "node-key"
+	default:
+		return fmt.Sprintf("family-%d", uint32(k))
+	}
+}
+
+// KeyLocator is a two-tuple which uniquely identifies a key within the
+// keychain.
+type KeyLocator struct {
+	// Family is the family of the key.
+	Family KeyFamily
+
+	// Index is the index of the key within its family.
+	Index uint32
+}
+
+// KeyDescriptor pairs a key's locator with the public key found there.
+type KeyDescriptor struct {
+	KeyLocator
+
+	// PubKey is the public key located at KeyLocator.
+	PubKey *btcec.PublicKey
+}
+
+// KeyRing is the interface which allows callers to derive the public keys of
+// the keychain.
+type KeyRing interface {
+	// DeriveKey returns the descriptor of the key at the target locator.
+	DeriveKey(keyLoc KeyLocator) (KeyDescriptor, error)
+}
+
+// SecretKeyRing is a KeyRing which is also able to derive the private keys of
+// the keychain.
+type SecretKeyRing interface {
+	KeyRing
+
+	// DerivePrivKey returns the private key at the target locator.
+	DerivePrivKey(keyLoc KeyLocator) (*btcec.PrivateKey, error)
+}
+
+// HDKeyRing is an implementation of the SecretKeyRing interface which derives
+// all keys from a root HD key held in memory.
+type HDKeyRing struct {
+	root     *hdkeychain.ExtendedKey
+	coinType uint32
+}
+
+// A compile time check to ensure that HDKeyRing implements the SecretKeyRing
+// interface.
+var _ SecretKeyRing = (*HDKeyRing)(nil)
+
+// NewHDKeyRing creates a new HDKeyRing which derives keys from the passed
+// root, for the chain identified by coinType.
+func NewHDKeyRing(root *hdkeychain.ExtendedKey, coinType uint32) *HDKeyRing {
+	return &HDKeyRing{
+		root:     root,
+		coinType: coinType,
+	}
+}
+
+// deriveExtendedKey derives the extended key at the target locator.
+func (h *HDKeyRing) deriveExtendedKey(
+	keyLoc KeyLocator) (*hdkeychain.ExtendedKey, error) {
+
+	if keyLoc.Family == KeyFamilyNodeKey && keyLoc.Index == 0 {
+		return h.root.Child(legacyNodeKeyIndex)
+	}
+
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + BIP0043Purpose,
+		hdkeychain.HardenedKeyStart + h.coinType,
+		hdkeychain.HardenedKeyStart + uint32(keyLoc.Family),
+		0,
+		keyLoc.Index,
+	}
+
+	key := h.root
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// DeriveKey returns the descriptor of the key at the target locator.
+//
+// This is a part of the KeyRing interface.
+func (h *HDKeyRing) DeriveKey(keyLoc KeyLocator) (KeyDescriptor, error) {
+	key, err := h.deriveExtendedKey(keyLoc)
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return KeyDescriptor{}, err
+	}
+
+	return KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     pubKey,
+	}, nil
+}
+
+// DerivePrivKey returns the private key at the target locator.
+//
+// This is a part of the SecretKeyRing interface.
+func (h *HDKeyRing) DerivePrivKey(keyLoc KeyLocator) (*btcec.PrivateKey, error) {
+	key, err := h.deriveExtendedKey(keyLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.ECPrivKey()
+}
@@ -0,0 +1,130 @@
+package keychain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+var testSeed = bytes.Repeat([]byte{0x1}, 32)
+
+// newTestKeyRing creates a new HDKeyRing from a static test seed, returning
+// the root key alongside it.
+func newTestKeyRing(t *testing.T) (*HDKeyRing, *hdkeychain.ExtendedKey) {
+	root, err := hdkeychain.NewMaster(testSeed, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatalf("unable to create root key: %v", err)
+	}
+
+	return NewHDKeyRing(root, 1), root
+}
+
+// TestDeriveKeyPath ensures that keys are derived at the path dictated by
+// their locator.
+func TestDeriveKeyPath(t *testing.T) {
+	keyRing, root := newTestKeyRing(t)
+
+	keyLoc := KeyLocator{Family: KeyFamilyMultiSig, Index: 3}
+	keyDesc, err := keyRing.DeriveKey(keyLoc)
+	if err != nil {
+		t.Fatalf("unable to derive key: %v", err)
+	}
+	if keyDesc.KeyLocator != keyLoc {
+		t.Fatalf("locator mismatch: expected %v, got %v", keyLoc,
+			keyDesc.KeyLocator)
+	}
+
+	// Manually derive the key at m/1017'/1'/0'/0/3, it should match the
+	// one derived by the key ring.
+	path := []uint32{
+		hdkeychain.HardenedKeyStart + BIP0043Purpose,
+		hdkeychain.HardenedKeyStart + 1,
+		hdkeychain.HardenedKeyStart + uint32(KeyFamilyMultiSig),
+		0,
+		3,
+	}
+	key := root
+	for _, index := range path {
+		key, err = key.Child(index)
+		if err != nil {
+			t.Fatalf("unable to derive child: %v", err)
+		}
+	}
+	expectedPub, err := key.ECPubKey()
+	if err != nil {
+		t.Fatalf("unable to fetch pubkey: %v", err)
+	}
+	if !bytes.Equal(expectedPub.SerializeCompressed(),
+		keyDesc.PubKey.SerializeCompressed()) {
+
+		t.Fatalf("key derived at wrong path")
+	}
+
+	// The private key should correspond to the derived public key.
+	privKey, err := keyRing.DerivePrivKey(keyLoc)
+	if err != nil {
+		t.Fatalf("unable to derive private key: %v", err)
+	}
+	if !bytes.Equal(privKey.PubKey().SerializeCompressed(),
+		keyDesc.PubKey.SerializeCompressed()) {
+
+		t.Fatalf("private key doesn't match public key")
+	}
+}
+
+// TestDeriveKeyFamilies ensures that keys of distinct families, or distinct
+// indexes within the same family, never collide.
+func TestDeriveKeyFamilies(t *testing.T) {
+	keyRing, _ := newTestKeyRing(t)
+
+	families := []KeyFamily{
+		KeyFamilyMultiSig,
+		KeyFamilyRevocationBase,
+		KeyFamilyHtlcBase,
+		KeyFamilyNodeKey,
+	}
+	seen := make(map[string]KeyLocator)
+	for _, family := range families {
+		for index := uint32(0); index < 3; index++ {
+			keyLoc := KeyLocator{Family: family, Index: index}
+			keyDesc, err := keyRing.DeriveKey(keyLoc)
+			if err != nil {
+				t.Fatalf("unable to derive key: %v", err)
+			}
+
+			pub := string(keyDesc.PubKey.SerializeCompressed())
+			if prior, ok := seen[pub]; ok {
+				t.Fatalf("key at %v collides with key at %v",
+					keyLoc, prior)
+			}
+			seen[pub] = keyLoc
+		}
+	}
+}
+
+// TestLegacyNodeKey ensures that the first key of the node key family is the
+// node identity key derived prior to the introduction of the keychain.
+func TestLegacyNodeKey(t *testing.T) {
+	keyRing, root := newTestKeyRing(t)
+
+	legacyKey, err := root.Child(hdkeychain.HardenedKeyStart + 2)
+	if err != nil {
+		t.Fatalf("unable to derive legacy key: %v", err)
+	}
+	legacyPub, err := legacyKey.ECPubKey()
+	if err != nil {
+		t.Fatalf("unable to fetch pubkey: %v", err)
+	}
+
+	keyDesc, err := keyRing.DeriveKey(KeyLocator{Family: KeyFamilyNodeKey})
+	if err != nil {
+		t.Fatalf("unable to derive node key: %v", err)
+	}
+	if !bytes.Equal(legacyPub.SerializeCompressed(),
+		keyDesc.PubKey.SerializeCompressed()) {
+
+		t.Fatalf("node key doesn't match legacy identity key")
+	}
+}
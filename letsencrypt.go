@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"path/filepath"
+
+	"golang.org/x/crypto/acme/autocert"
+	"google.golang.org/grpc/credentials"
+)
+
+// letsEncryptDirname is the name of the directory within the data directory
+// caching the certificates obtained from Let's Encrypt, along with the key of
+// our ACME account.
+const letsEncryptDirname = "letsencrypt"
+
+// newLetsEncryptCreds returns transport credentials which serve a certificate
+// for the configured domain, obtained from Let's Encrypt upon the first
+// connection, then renewed automatically ahead of its expiry. The HTTP
+// challenges proving our control of the domain are answered by a server
+// listening on the configured address.
+func newLetsEncryptCreds(cfg *config) credentials.TransportCredentials {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.LetsEncryptDomain),
+		Cache: autocert.DirCache(
+			filepath.Join(cfg.DataDir, letsEncryptDirname),
+		),
+	}
+
+	go func() {
+		rpcsLog.Infof("Answering Let's Encrypt challenges for %v on %v",
+			cfg.LetsEncryptDomain, cfg.LetsEncryptListen)
+
+		err := http.ListenAndServe(cfg.LetsEncryptListen,
+			manager.HTTPHandler(nil))
+		if err != nil {
+			rpcsLog.Errorf("Unable to answer Let's Encrypt "+
+				"challenges: %v", err)
+		}
+	}()
+
+	return credentials.NewTLS(&tls.Config{
+		GetCertificate: manager.GetCertificate,
+	})
+}
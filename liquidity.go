@@ -0,0 +1,203 @@
+package main
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// liquidityBoundTTL is the duration after which a learned liquidity bound is
+// forgotten. Liquidity shifts as payments flow through a channel, so old
+// observations say little about the channel's current state.
+const liquidityBoundTTL = time.Hour
+
+// liquidityScale is the scale, in satoshis, of the bimodal distribution
+// assumed for the liquidity of a channel. Within channels much larger than
+// the scale, the liquidity tends to be found close to either end, as payments
+// flowing in a single direction drain one side of the channel. Within smaller
+// channels, the distribution approaches a uniform one.
+const liquidityScale = 300000
+
+// minLiquidityMass is the smallest mass of the bimodal density between the
+// bounds of a channel's liquidity, as measured by liquidityPrimitive, for
+// which the density is used to estimate the probability of a payment. Below
+// it, rounding errors dominate.
+const minLiquidityMass = 1e-12
+
+// channelDirection identifies a channel along with the direction payments
+// flow through it, as the liquidity of a channel differs in each direction.
+type channelDirection struct {
+	chanPoint wire.OutPoint
+
+	// from is the lightning ID of the node sending over the channel.
+	from wire.ShaHash
+}
+
+// liquidityBounds are the bounds learned on the liquidity available to send
+// over a channel in one direction. A bound is only valid while its update
+// time is set, and younger than liquidityBoundTTL.
+type liquidityBounds struct {
+	// lower is the largest amount known to have been successfully sent
+	// over the channel.
+	lower       btcutil.Amount
+	lowerUpdate time.Time
+
+	// upper is the largest amount which may still be sent over the
+	// channel, below the smallest amount the channel failed to carry.
+	upper       btcutil.Amount
+	upperUpdate time.Time
+}
+
+// liquidityModel estimates the probability that a channel is able to carry a
+// payment. Without any prior knowledge of a channel, the liquidity available
+// in each direction is assumed to follow a bimodal distribution over the
+// channel's capacity, with a density proportional to
+// exp(-x/liquidityScale) + exp((x-capacity)/liquidityScale), as most channels
+// are depleted in one direction or the other. The model narrows the range of
+// the liquidity using the outcomes of our past payment attempts: a failure at
+// an amount lowers the upper bound of the liquidity, while a success raises
+// the lower bound. Amounts below the lower bound are then certain to succeed,
+// amounts above the upper bound certain to fail, and the probability of the
+// amounts in between follows the bimodal distribution within the bounds.
+type liquidityModel struct {
+	sync.Mutex
+
+	bounds map[channelDirection]*liquidityBounds
+}
+
+// newLiquidityModel creates a new liquidityModel without any knowledge of the
+// liquidity within the graph.
+func newLiquidityModel() *liquidityModel {
+	return &liquidityModel{
+		bounds: make(map[channelDirection]*liquidityBounds),
+	}
+}
+
+// successProbability returns the probability that the passed node is able to
+// send the given amount over the channel edge.
+func (m *liquidityModel) successProbability(edge *channeldb.ChannelEdge,
+	from wire.ShaHash, amt btcutil.Amount) float64 {
+
+	lower, upper := btcutil.Amount(0), edge.Capacity
+	if m != nil {
+		m.Lock()
+		bounds, ok := m.bounds[channelDirection{edge.ChannelPoint, from}]
+		if ok {
+			now := time.Now()
+			if now.Sub(bounds.lowerUpdate) < liquidityBoundTTL {
+				lower = bounds.lower
+			}
+			if now.Sub(bounds.upperUpdate) < liquidityBoundTTL {
+				upper = bounds.upper
+			}
+		}
+		m.Unlock()
+	}
+
+	switch {
+	case amt > upper:
+		return 0
+	case amt <= lower:
+		return 1
+	}
+
+	// The liquidity lies within [lower, upper], so the amount is carried
+	// if the liquidity lies within [amt, upper]. Each integer amount of
+	// liquidity x is taken to cover the interval [x, x+1).
+	top := upper + 1
+	total := liquidityPrimitive(edge.Capacity, top) -
+		liquidityPrimitive(edge.Capacity, lower)
+
+	// Far from either end of a large channel, the density vanishes to
+	// the point that it can't be represented, in which case the
+	// liquidity is taken to be uniformly distributed within the bounds.
+	if total < minLiquidityMass {
+		return float64(top-amt) / float64(top-lower)
+	}
+
+	return (liquidityPrimitive(edge.Capacity, top) -
+		liquidityPrimitive(edge.Capacity, amt)) / total
+}
+
+// liquidityPrimitive returns an antiderivative of the bimodal liquidity
+// density of a channel with the passed capacity at x, scaled by
+// 1/liquidityScale. Only the differences between its values are meaningful.
+func liquidityPrimitive(capacity, x btcutil.Amount) float64 {
+	s := float64(liquidityScale)
+	return math.Exp(float64(x-capacity)/s) - math.Exp(float64(-x)/s)
+}
+
+// reportSuccess records that the passed node successfully sent the given
+// amount over the channel.
+func (m *liquidityModel) reportSuccess(chanPoint wire.OutPoint,
+	from wire.ShaHash, amt btcutil.Amount) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	// A success below a valid lower bound tells us nothing new.
+	bounds := m.fetchBounds(chanPoint, from)
+	now := time.Now()
+	if now.Sub(bounds.lowerUpdate) < liquidityBoundTTL &&
+		amt <= bounds.lower {
+
+		return
+	}
+
+	bounds.lower = amt
+	bounds.lowerUpdate = now
+
+	// If the upper bound contradicts the success, then the liquidity has
+	// since shifted, and the upper bound no longer holds.
+	if bounds.upper < amt {
+		bounds.upperUpdate = time.Time{}
+	}
+}
+
+// reportFailure records that the passed node lacked the liquidity to send
+// the given amount over the channel.
+func (m *liquidityModel) reportFailure(chanPoint wire.OutPoint,
+	from wire.ShaHash, amt btcutil.Amount) {
+
+	m.Lock()
+	defer m.Unlock()
+
+	// A failure above a valid upper bound tells us nothing new.
+	bounds := m.fetchBounds(chanPoint, from)
+	now := time.Now()
+	if now.Sub(bounds.upperUpdate) < liquidityBoundTTL &&
+		amt > bounds.upper {
+
+		return
+	}
+
+	bounds.upper = amt - 1
+	bounds.upperUpdate = now
+
+	// If the lower bound contradicts the failure, then the liquidity has
+	// since shifted, and the lower bound no longer holds.
+	if bounds.lower > bounds.upper {
+		bounds.lowerUpdate = time.Time{}
+	}
+}
+
+// fetchBounds returns the bounds of the channel in the direction of the
+// passed node, creating an empty set of bounds if none exist.
+//
+// NOTE: The model's mutex MUST be held when calling this method.
+func (m *liquidityModel) fetchBounds(chanPoint wire.OutPoint,
+	from wire.ShaHash) *liquidityBounds {
+
+	direction := channelDirection{chanPoint, from}
+	bounds, ok := m.bounds[direction]
+	if !ok {
+		bounds = &liquidityBounds{}
+		m.bounds[direction] = bounds
+	}
+
+	return bounds
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// bimodalProbability returns the probability that a channel with the passed
+// capacity, and its liquidity within [lower, upper], is able to carry amt, by
+// summing the bimodal liquidity density over each amount of liquidity.
+func bimodalProbability(capacity, lower, upper, amt btcutil.Amount) float64 {
+	density := func(x btcutil.Amount) float64 {
+		// Each amount of liquidity x covers the interval [x, x+1), so
+		// the density is taken at its midpoint.
+		mid := float64(x) + 0.5
+		return math.Exp(-mid/liquidityScale) +
+			math.Exp((mid-float64(capacity))/liquidityScale)
+	}
+
+	var carried, total float64
+	for x := lower; x <= upper; x++ {
+		d := density(x)
+		total += d
+		if x >= amt {
+			carried += d
+		}
+	}
+
+	return carried / total
+}
+
+// TestLiquidityModelBounds tests that the liquidity model narrows the range
+// of a channel's liquidity with each reported outcome, that an outcome which
+// says nothing new leaves the bounds untouched, and that an outcome which
+// contradicts a bound invalidates it.
+func TestLiquidityModelBounds(t *testing.T) {
+	edge := &channeldb.ChannelEdge{
+		ChannelPoint: wire.OutPoint{Hash: wire.ShaHash{1}},
+		Node1:        wire.ShaHash{2},
+		Node2:        wire.ShaHash{3},
+		Capacity:     1000,
+	}
+	from := edge.Node1
+	m := newLiquidityModel()
+
+	assertProb := func(amt btcutil.Amount, expected float64) {
+		prob := m.successProbability(edge, from, amt)
+		if math.Abs(prob-expected) > 1e-6 {
+			t.Fatalf("expected probability %v to send %v, got %v",
+				expected, amt, prob)
+		}
+	}
+
+	// Without any knowledge, the liquidity is distributed over the whole
+	// capacity.
+	assertProb(500, bimodalProbability(1000, 0, 1000, 500))
+	assertProb(1001, 0)
+
+	// A success at an amount makes any smaller amount certain.
+	m.reportSuccess(edge.ChannelPoint, from, 600)
+	assertProb(600, 1)
+	assertProb(500, 1)
+
+	// A success at a smaller amount mustn't lower the lower bound.
+	m.reportSuccess(edge.ChannelPoint, from, 300)
+	assertProb(600, 1)
+
+	// A failure at an amount makes it, and any larger amount, impossible,
+	// leaving the liquidity distributed between the bounds.
+	m.reportFailure(edge.ChannelPoint, from, 800)
+	assertProb(800, 0)
+	assertProb(700, bimodalProbability(1000, 600, 799, 700))
+
+	// A failure at a larger amount mustn't raise the upper bound.
+	m.reportFailure(edge.ChannelPoint, from, 900)
+	assertProb(850, 0)
+
+	// The liquidity in the opposite direction remains unknown.
+	prob := m.successProbability(edge, edge.Node2, 500)
+	if math.Abs(prob-bimodalProbability(1000, 0, 1000, 500)) > 1e-6 {
+		t.Fatalf("liquidity learned in the wrong direction, "+
+			"probability %v", prob)
+	}
+
+	// A failure below the lower bound means the liquidity has shifted, so
+	// the lower bound no longer holds.
+	m.reportFailure(edge.ChannelPoint, from, 500)
+	assertProb(500, 0)
+	assertProb(400, bimodalProbability(1000, 0, 499, 400))
+
+	// Once expired, a bound is replaced by any new outcome, even one
+	// which would otherwise say nothing new.
+	bounds := m.bounds[channelDirection{edge.ChannelPoint, from}]
+	bounds.upperUpdate = time.Now().Add(-2 * liquidityBoundTTL)
+	m.reportFailure(edge.ChannelPoint, from, 900)
+	assertProb(800, bimodalProbability(1000, 0, 899, 800))
+}
+
+// TestLiquidityModelBimodal tests that within channels much larger than the
+// liquidity scale, the liquidity is expected close to either end of the
+// channel, and that far from either end, the liquidity is taken to be
+// uniformly distributed between its bounds.
+func TestLiquidityModelBimodal(t *testing.T) {
+	const capacity = 10 * liquidityScale
+	edge := &channeldb.ChannelEdge{
+		ChannelPoint: wire.OutPoint{Hash: wire.ShaHash{1}},
+		Node1:        wire.ShaHash{2},
+		Node2:        wire.ShaHash{3},
+		Capacity:     capacity,
+	}
+	from := edge.Node1
+
+	// A nil model assumes the same distribution as a model without any
+	// knowledge of the channel.
+	var m *liquidityModel
+	for _, amt := range []btcutil.Amount{
+		capacity / 10, capacity / 2, capacity * 9 / 10,
+	} {
+		prob := m.successProbability(edge, from, amt)
+		expected := bimodalProbability(capacity, 0, capacity, amt)
+		if math.Abs(prob-expected) > 1e-6 {
+			t.Fatalf("expected probability %v to send %v, got %v",
+				expected, amt, prob)
+		}
+	}
+
+	// As the liquidity is likely found close to either end, small
+	// amounts are less likely to be carried than were the liquidity
+	// uniformly distributed, while large amounts are more likely to be.
+	if prob := m.successProbability(edge, from, capacity/10); prob > 0.8 {
+		t.Fatalf("probability %v to send a tenth of the capacity "+
+			"isn't bimodal", prob)
+	}
+	if prob := m.successProbability(edge, from, capacity/2); math.Abs(
+		prob-0.5) > 1e-3 {
+
+		t.Fatalf("probability %v to send half the capacity isn't "+
+			"symmetric", prob)
+	}
+	if prob := m.successProbability(edge, from, capacity*9/10); prob < 0.2 {
+		t.Fatalf("probability %v to send nine tenths of the "+
+			"capacity isn't bimodal", prob)
+	}
+
+	// Within a channel so large that the density vanishes between narrow
+	// bounds in its middle, the liquidity is taken to be uniformly
+	// distributed between the bounds.
+	edge.Capacity = 1000 * liquidityScale
+	mid := edge.Capacity / 2
+	m = newLiquidityModel()
+	m.reportSuccess(edge.ChannelPoint, from, mid-10)
+	m.reportFailure(edge.ChannelPoint, from, mid+11)
+	prob := m.successProbability(edge, from, mid)
+	if math.Abs(prob-11.0/21.0) > 1e-9 {
+		t.Fatalf("expected probability %v to send %v, got %v",
+			11.0/21.0, mid, prob)
+	}
+}
@@ -10,23 +10,108 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strconv"
+	"runtime/debug"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/chainntnfs/btcdnotify"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/chainrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/rescuerpc"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
+	"github.com/lightningnetwork/lnd/lnwallet/remotesigner"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/roasbeef/btcrpcclient"
 )
 
+const (
+	// signerMacaroonFilename is the name of the file within the data
+	// directory storing the macaroon which grants access to the signrpc
+	// service.
+	signerMacaroonFilename = "signer.macaroon"
+
+	// rescueMacaroonFilename is the name of the file within the data
+	// directory storing the macaroon which grants access to the rescuerpc
+	// service.
+	rescueMacaroonFilename = "rescue.macaroon"
+
+	// lightningMacaroonFilename is the name of the file within the data
+	// directory storing the macaroon which grants access to the
+	// lnrpc.Lightning service, should macaroons be required to call it.
+	lightningMacaroonFilename = "lightning.macaroon"
+
+	// remoteSignerMacaroonFilename is the name of the file within the data
+	// directory storing the macaroon a watch-only node presents to the
+	// signerlisten address.
+	remoteSignerMacaroonFilename = "remotesigner.macaroon"
+
+	// adminMacaroonFilename is the name of the file within the data
+	// directory storing the macaroon which grants access to the RPCs
+	// baking and revoking macaroons.
+	adminMacaroonFilename = "admin.macaroon"
+
+	// signerRootKeyID is the ID of the root key the signer macaroon is
+	// issued under. Each dedicated macaroon is issued under its own root
+	// key, so that it can be revoked without affecting the others.
+	signerRootKeyID uint64 = 1
+
+	// rescueRootKeyID is the ID of the root key the rescue macaroon is
+	// issued under.
+	rescueRootKeyID uint64 = 2
+
+	// lightningRootKeyID is the ID of the root key the lightning macaroon
+	// is issued under.
+	lightningRootKeyID uint64 = 3
+
+	// remoteSignerRootKeyID is the ID of the root key the remote signer
+	// macaroon is issued under.
+	remoteSignerRootKeyID uint64 = 4
+
+	// adminRootKeyID is the ID of the root key the admin macaroon is
+	// issued under.
+	adminRootKeyID uint64 = 5
+
+	// signerKeyLookahead is the number of keys a remote signer derives
+	// ahead when asked to sign for a key it doesn't yet know of.
+	signerKeyLookahead = 100
+)
+
 var (
 	cfg             *config
 	shutdownChannel = make(chan struct{})
+
+	// reservedRootKeyIDs are the IDs of the root keys the dedicated
+	// macaroons written to the data directory are issued under. They're
+	// kept across a rotation of the root keys and can't be deleted, so
+	// the admin macaroon can't be used to lock its holder out, nor to
+	// cut off a watch-only node from its remote signer.
+	reservedRootKeyIDs = []uint64{
+		signerRootKeyID, rescueRootKeyID, lightningRootKeyID,
+		remoteSignerRootKeyID, adminRootKeyID,
+	}
 )
 
+// isReservedRootKeyID returns true if the passed root key ID is one of the
+// reservedRootKeyIDs.
+func isReservedRootKeyID(id uint64) bool {
+	for _, reserved := range reservedRootKeyIDs {
+		if id == reserved {
+			return true
+		}
+	}
+
+	return false
+}
+
 // lndMain is the true entry point for lnd. This function is required since
 // defers created in the top-level scope of a main method aren't executed if
 // os.Exit() is called.
@@ -43,6 +128,13 @@ func lndMain() error {
 	// Show version at startup.
 	ltndLog.Infof("Version %s", version())
 
+	// Collect garbage more eagerly when running low on memory, trading
+	// CPU time for a smaller heap, unless the collector has already been
+	// tuned through the GOGC environment variable.
+	if cfg.LowMem && os.Getenv("GOGC") == "" {
+		debug.SetGCPercent(lowMemGCPercent)
+	}
+
 	if loadedConfig.SPVMode == true {
 		shell(loadedConfig.SPVHostAdr, activeNetParams.Params)
 		return err
@@ -61,13 +153,65 @@ func lndMain() error {
 
 	// Open the channeldb, which is dedicated to storing channel, and
 	// network related meta-data.
-	chanDB, err := channeldb.Open(loadedConfig.DataDir, activeNetParams.Params)
+	chanDB, err := channeldb.Open(loadedConfig.DataDir,
+		activeNetParams.Params,
+		channeldb.OptionAllocSize(loadedConfig.DBAllocSize),
+		channeldb.OptionMaxConcurrentReads(
+			loadedConfig.DBMaxConcurrentReads))
 	if err != nil {
 		fmt.Println("unable to open channeldb: ", err)
 		return err
 	}
 	defer chanDB.Close()
 
+	// Refuse to start should the state of any channel be corrupt. Acting
+	// upon a corrupt state could have us broadcast an invalid or revoked
+	// commitment, so it's safer to leave the channels untouched until the
+	// database has been repaired. As this reads every channel, it may be
+	// skipped on nodes with many channels which are restarted often.
+	if loadedConfig.NoVerifyChannels {
+		ltndLog.Warnf("Skipping the channel state integrity check")
+	} else if err := chanDB.VerifyChannels(); err != nil {
+		ltndLog.Errorf("Channel state integrity check failed: %v -- "+
+			"restore %v from a backup, or move it aside and "+
+			"recover the channel's funds with the rescue "+
+			"service (--rescue)", err,
+			filepath.Join(loadedConfig.DataDir, "channel.db"))
+		return err
+	}
+
+	// Open the channel graph. The graph is kept within its own database
+	// file so it can be dropped or re-synced without ever touching the
+	// channel state stored above.
+	chanGraph, err := channeldb.OpenGraph(loadedConfig.DataDir,
+		activeNetParams.Params,
+		channeldb.OptionAllocSize(loadedConfig.DBAllocSize))
+	if err != nil {
+		fmt.Println("unable to open channel graph: ", err)
+		return err
+	}
+	defer chanGraph.Close()
+
+	// Bootstrap the channel graph from a snapshot if one was given, rather
+	// than waiting for it to be synced from the network.
+	if loadedConfig.GraphSnapshot != "" {
+		snapshot, err := os.Open(cleanAndExpandPath(loadedConfig.GraphSnapshot))
+		if err != nil {
+			fmt.Println("unable to open graph snapshot: ", err)
+			return err
+		}
+		numNodes, numEdges, err := chanGraph.ImportSnapshot(snapshot)
+		snapshot.Close()
+		if err != nil {
+			fmt.Println("unable to import graph snapshot: ", err)
+			return err
+		}
+
+		ltndLog.Infof("Imported %v nodes and %v edges from graph "+
+			"snapshot %v", numNodes, numEdges,
+			loadedConfig.GraphSnapshot)
+	}
+
 	// Next load btcd's TLS cert for the RPC connection. If a raw cert was
 	// specified in the config, then we'll se that directly. Otherwise, we
 	// attempt to read the cert from the path specified in the config.
@@ -126,35 +270,113 @@ func lndMain() error {
 		RpcPass:     loadedConfig.RPCPass,
 		CACert:      rpcCert,
 		NetParams:   activeNetParams.Params,
+		WatchOnly:   loadedConfig.RemoteSigner != "",
+	}
+	if loadedConfig.SignerListen != "" {
+		walletConfig.KeyLookahead = signerKeyLookahead
+	}
+	if loadedConfig.RestoreSeed != "" {
+		// The seed has already been validated when loading the
+		// config.
+		walletConfig.HdSeed, _ = hex.DecodeString(loadedConfig.RestoreSeed)
+		walletConfig.RecoveryWindow = uint32(loadedConfig.RecoveryWindow)
 	}
 	wc, err := btcwallet.New(walletConfig)
 	if err != nil {
 		fmt.Printf("unable to create wallet controller: %v\n", err)
 		return err
 	}
-	signer := wc
 	bio := wc
 
 	// Create, and start the lnwallet, which handles the core payment
-	// channel logic, and exposes control via proxy state machines.
-	wallet, err := lnwallet.NewLightningWallet(chanDB, notifier,
-		wc, signer, bio, activeNetParams.Params)
+	// channel logic, and exposes control via proxy state machines. If
+	// we're configured with a remote signer, then the wallet holds only
+	// public keys, with all private key operations, including those using
+	// our identity key, forwarded to the remote signer.
+	var (
+		wallet   *lnwallet.LightningWallet
+		identity brontide.SingleKeyECDH
+	)
+	hwCfg, err := parseHWIConfig(loadedConfig)
+	if err != nil {
+		return err
+	}
+	if loadedConfig.RemoteSigner != "" {
+		var signer *remotesigner.Signer
+		wallet, signer, err = newWatchOnlyWallet(loadedConfig, chanDB,
+			notifier, wc, hwCfg)
+		if err != nil {
+			fmt.Printf("unable to create watch-only wallet: %v\n", err)
+			return err
+		}
+		identity = signer
+	} else {
+		// If a hardware wallet is configured, then it holds our
+		// on-chain funds, with the keys of our channels still derived
+		// by the software wallet.
+		var (
+			controller lnwallet.WalletController = wc
+			signer     lnwallet.Signer           = wc
+		)
+		if hwCfg != nil {
+			controller, signer, err = newHardwareWallet(hwCfg, wc,
+				wc, chanDB)
+			if err != nil {
+				fmt.Printf("unable to create hardware wallet: "+
+					"%v\n", err)
+				return err
+			}
+		}
+
+		wallet, err = lnwallet.NewLightningWallet(chanDB, notifier,
+			controller, signer, bio, activeNetParams.Params)
+		if err != nil {
+			fmt.Printf("unable to create wallet: %v\n", err)
+			return err
+		}
+
+		identityKey, err := wallet.GetIdentitykey()
+		if err != nil {
+			fmt.Printf("unable to fetch identity key: %v\n", err)
+			return err
+		}
+		identity = &brontide.PrivKeyECDH{PrivKey: identityKey}
+	}
+	coinSelection, err := lnwallet.ParseCoinSelectionStrategy(
+		loadedConfig.CoinSelectionStrategy)
 	if err != nil {
-		fmt.Printf("unable to create wallet: %v\n", err)
+		fmt.Printf("invalid coin selection strategy: %v\n", err)
 		return err
 	}
+	wallet.SetCoinSelectionStrategy(coinSelection)
+
+	// Unless explicitly permitted, channels are only funded from outputs
+	// which have reached the configured confirmation depth.
+	fundingMinConfs := int32(loadedConfig.FundingMinConfs)
+	if loadedConfig.AllowUnconfirmedFunding {
+		fundingMinConfs = 0
+	}
+	wallet.SetFundingMinConfs(fundingMinConfs)
 	if err := wallet.Startup(); err != nil {
 		fmt.Printf("unable to start wallet: %v\n", err)
 		return err
 	}
 	ltndLog.Info("LightningWallet opened")
 
+	// Create the macaroon service, which issues the macaroons gating
+	// access to the signrpc and rescuerpc services, and manages the root
+	// keys they're issued under.
+	macaroonService, err := macaroons.NewService(loadedConfig.DataDir)
+	if err != nil {
+		fmt.Printf("unable to create macaroon service: %v\n", err)
+		return err
+	}
+	defer macaroonService.Close()
+
 	// Set up the core server which will listen for incoming peer
 	// connections.
-	defaultListenAddrs := []string{
-		net.JoinHostPort("", strconv.Itoa(loadedConfig.PeerPort)),
-	}
-	server, err := newServer(defaultListenAddrs, notifier, bio, wallet, chanDB)
+	server, err := newServer(loadedConfig.Listeners, notifier, bio, wallet,
+		identity, chanDB, chanGraph, macaroonService)
 	if err != nil {
 		srvrLog.Errorf("unable to create server: %v\n", err)
 		return err
@@ -170,13 +392,118 @@ func lndMain() error {
 		server.WaitForShutdown()
 	})
 
+	// Issue the dedicated macaroon which grants companion tools access to
+	// the signrpc service, unless a valid one already exists.
+	signerMacPath := filepath.Join(loadedConfig.DataDir,
+		signerMacaroonFilename)
+	err = genMacaroon(macaroonService, signerMacPath, signerRootKeyID,
+		macaroons.PermissionSigner)
+	if err != nil {
+		fmt.Printf("unable to create signer macaroon: %v\n", err)
+		return err
+	}
+	signServer, err := newSignRPCServer(wallet, false)
+	if err != nil {
+		fmt.Printf("unable to create signer rpc server: %v\n", err)
+		return err
+	}
+
+	permissions := make(map[string]string)
+	for method, perm := range signerPermissions {
+		permissions[method] = perm
+	}
+
+	// The RPCs baking and revoking macaroons are always gated behind the
+	// admin macaroon.
+	adminMacPath := filepath.Join(loadedConfig.DataDir,
+		adminMacaroonFilename)
+	err = genMacaroon(macaroonService, adminMacPath, adminRootKeyID,
+		macaroons.PermissionAdmin)
+	if err != nil {
+		fmt.Printf("unable to create admin macaroon: %v\n", err)
+		return err
+	}
+	for method, perm := range adminPermissions {
+		permissions[method] = perm
+	}
+
+	// The rescuerpc service is only served if explicitly enabled, in
+	// which case its macaroon is issued alongside the signer's.
+	if loadedConfig.Rescue {
+		rescueMacPath := filepath.Join(loadedConfig.DataDir,
+			rescueMacaroonFilename)
+		err = genMacaroon(macaroonService, rescueMacPath,
+			rescueRootKeyID, macaroons.PermissionRescue)
+		if err != nil {
+			fmt.Printf("unable to create rescue macaroon: %v\n", err)
+			return err
+		}
+		for method, perm := range rescuePermissions {
+			permissions[method] = perm
+		}
+	}
+
+	// If required, every method of the lnrpc.Lightning service, along
+	// with the reflection service describing them, is gated behind the
+	// lightning macaroon, or a macaroon baked from it with a narrower
+	// scope, such as one restricted to certain RPC methods.
+	if loadedConfig.RequireMacaroons {
+		lightningMacPath := filepath.Join(loadedConfig.DataDir,
+			lightningMacaroonFilename)
+		err = genMacaroon(macaroonService, lightningMacPath,
+			lightningRootKeyID, macaroons.PermissionLightning)
+		if err != nil {
+			fmt.Printf("unable to create lightning macaroon: %v\n",
+				err)
+			return err
+		}
+		for service, perm := range lightningPermissions {
+			permissions[service] = perm
+		}
+	}
+
 	// Initialize, and register our implementation of the gRPC server.
-	var opts []grpc.ServerOption
+	// Access to the signrpc and rescuerpc services, the RPCs managing
+	// macaroons, and optionally the lnrpc.Lightning service, is gated
+	// behind their macaroons.
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(
+			macaroonService.UnaryServerInterceptor(permissions),
+		),
+		grpc.StreamInterceptor(
+			macaroonService.StreamServerInterceptor(permissions),
+		),
+	}
+	opts = append(opts, rpcServerOpts(loadedConfig)...)
+
+	// The rpc server only listens on localhost, unless it's served over
+	// TLS with a certificate from Let's Encrypt, allowing remote clients
+	// to connect without pinning a self-signed certificate.
+	rpcHost := "localhost"
+	if loadedConfig.LetsEncryptDomain != "" {
+		opts = append(opts, grpc.Creds(newLetsEncryptCreds(loadedConfig)))
+		rpcHost = ""
+	}
+
 	grpcServer := grpc.NewServer(opts...)
 	lnrpc.RegisterLightningServer(grpcServer, server.rpcServer)
+	signrpc.RegisterSignerServer(grpcServer, signServer)
+	chainrpc.RegisterChainNotifierServer(grpcServer,
+		newChainRPCServer(notifier))
+	if loadedConfig.Rescue {
+		rescuerpc.RegisterRescueServer(grpcServer,
+			newRescueRPCServer(wallet, bio, server.newSweepAddr))
+	}
+
+	// If requested, describe the services registered above to clients
+	// such as grpcurl, which lack our proto files.
+	if loadedConfig.RPCReflection {
+		reflection.Register(grpcServer)
+	}
 
 	// Finally, start the grpc server listening for HTTP/2 connections.
-	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", loadedConfig.RPCPort))
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", rpcHost,
+		loadedConfig.RPCPort))
 	if err != nil {
 		fmt.Printf("failed to listen: %v", err)
 		return err
@@ -186,6 +513,67 @@ func lndMain() error {
 		grpcServer.Serve(lis)
 	}()
 
+	// If requested, serve our signing capabilities to a watch-only node
+	// over a dedicated connection, separate from the main RPC server. As
+	// the service hands out revocation secrets, it's only served over TLS
+	// with a self-signed certificate the watch-only node pins, and only to
+	// clients presenting the remote signer macaroon.
+	if loadedConfig.SignerListen != "" {
+		remoteSignServer, err := newSignRPCServer(wallet, true)
+		if err != nil {
+			fmt.Printf("unable to create signer rpc server: %v\n", err)
+			return err
+		}
+
+		remoteSignerMacPath := filepath.Join(loadedConfig.DataDir,
+			remoteSignerMacaroonFilename)
+		err = genMacaroon(macaroonService, remoteSignerMacPath,
+			remoteSignerRootKeyID, macaroons.PermissionRemoteSigner)
+		if err != nil {
+			fmt.Printf("unable to create remote signer macaroon: "+
+				"%v\n", err)
+			return err
+		}
+
+		certPath := filepath.Join(loadedConfig.DataDir,
+			signerCertFilename)
+		keyPath := filepath.Join(loadedConfig.DataDir, signerKeyFilename)
+		if err := genSignerCertPair(certPath, keyPath); err != nil {
+			fmt.Printf("unable to create signer certificate: %v\n",
+				err)
+			return err
+		}
+		creds, err := credentials.NewServerTLSFromFile(certPath, keyPath)
+		if err != nil {
+			fmt.Printf("unable to load signer certificate: %v\n", err)
+			return err
+		}
+
+		signGrpcServer := grpc.NewServer(
+			grpc.Creds(creds),
+			grpc.UnaryInterceptor(
+				macaroonService.UnaryServerInterceptor(
+					remoteSignerPermissions),
+			),
+			grpc.StreamInterceptor(
+				macaroonService.StreamServerInterceptor(
+					remoteSignerPermissions),
+			),
+		)
+		signrpc.RegisterSignerServer(signGrpcServer, remoteSignServer)
+
+		signLis, err := net.Listen("tcp", loadedConfig.SignerListen)
+		if err != nil {
+			fmt.Printf("failed to listen: %v", err)
+			return err
+		}
+		go func() {
+			rpcsLog.Infof("Signer RPC server listening on %s",
+				signLis.Addr())
+			signGrpcServer.Serve(signLis)
+		}()
+	}
+
 	// Wait for shutdown signal from either a graceful server stop or from
 	// the interrupt handler.
 	<-shutdownChannel
@@ -193,6 +581,146 @@ func lndMain() error {
 	return nil
 }
 
+// newWatchOnlyWallet connects to the remote signer configured within cfg,
+// then creates a LightningWallet which forwards all signing requests to it.
+// The connection is authenticated in both directions: the remote signer by its
+// pinned certificate, and ourselves by the remote signer macaroon. If the
+// local wallet still holds private keys, then it's stripped of them once
+// confirmed to be a copy of the remote signer's wallet. If a hardware wallet
+// is configured, then it signs for our on-chain funds instead.
+func newWatchOnlyWallet(cfg *config, chanDB *channeldb.DB,
+	notifier chainntnfs.ChainNotifier, wc *btcwallet.BtcWallet,
+	hwCfg *hwiConfig) (*lnwallet.LightningWallet, *remotesigner.Signer,
+	error) {
+
+	creds, err := credentials.NewClientTLSFromFile(cfg.RemoteSignerCert,
+		signerTLSServerName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load remote signer "+
+			"certificate: %v", err)
+	}
+	serializedMac, err := ioutil.ReadFile(cfg.RemoteSignerMacaroon)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read remote signer "+
+			"macaroon: %v", err)
+	}
+	mac, err := macaroons.Deserialize(serializedMac)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode remote signer "+
+			"macaroon: %v", err)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(macaroons.Credential{Macaroon: mac}),
+	}
+	conn, err := grpc.Dial(cfg.RemoteSigner, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, err := remotesigner.New(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !wc.IsWatchOnly() {
+		err := lnwallet.VerifyKeyRing(wc, signer, activeNetParams.Params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wallet isn't a copy of the "+
+				"remote signer's wallet: %v", err)
+		}
+		if err := wc.ConvertToWatchOnly(); err != nil {
+			return nil, nil, err
+		}
+
+		ltndLog.Infof("Wallet stripped of private keys, now watch-only")
+	}
+
+	// A hardware wallet may hold our on-chain funds, in which case the
+	// remote signer only signs with the keys of our channels.
+	var (
+		controller lnwallet.WalletController = wc
+		txSigner   lnwallet.Signer           = signer
+	)
+	if hwCfg != nil {
+		controller, txSigner, err = newHardwareWallet(hwCfg, wc, signer,
+			chanDB)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	wallet := lnwallet.NewWatchOnlyLightningWallet(chanDB, notifier,
+		controller, txSigner, signer, wc)
+
+	return wallet, signer, nil
+}
+
+// rpcServerOpts returns the gRPC server options which apply the message size
+// limits, keepalive parameters, and stream limits of the passed config.
+func rpcServerOpts(cfg *config) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.RPCMaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.RPCMaxSendMsgSize),
+	}
+
+	if cfg.RPCMaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(
+			uint32(cfg.RPCMaxConcurrentStreams),
+		))
+	}
+
+	// Pinging idle connections prevents proxies sitting between us and
+	// our clients from dropping long-lived streams. Clients are likewise
+	// permitted to ping us, as long as they don't do so more often than
+	// we ping them.
+	if cfg.RPCKeepAliveTime > 0 {
+		keepAliveTime := time.Duration(cfg.RPCKeepAliveTime) * time.Second
+		opts = append(opts,
+			grpc.KeepaliveParams(keepalive.ServerParameters{
+				Time: keepAliveTime,
+				Timeout: time.Duration(cfg.RPCKeepAliveTimeout) *
+					time.Second,
+			}),
+			grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             keepAliveTime,
+				PermitWithoutStream: true,
+			}),
+		)
+	}
+
+	return opts
+}
+
+// genMacaroon issues a macaroon granting the passed permissions under the root
+// key with the passed ID, then writes it to the target path. If a macaroon
+// already exists at the path, then it's left untouched, unless it has been
+// revoked by the deletion of the root key it was issued under.
+func genMacaroon(service *macaroons.Service, path string, rootKeyID uint64,
+	permissions ...string) error {
+
+	if serialized, err := ioutil.ReadFile(path); err == nil {
+		m, err := macaroons.Deserialize(serialized)
+		if err == nil && service.CheckMacaroon(m) == nil {
+			return nil
+		}
+
+		ltndLog.Infof("Macaroon %v has been revoked, issuing a new one",
+			path)
+	}
+
+	m, err := service.NewMacaroon(rootKeyID, permissions...)
+	if err != nil {
+		return err
+	}
+	serialized, err := m.Serialize()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, serialized, 0600)
+}
+
 func main() {
 	// Use all processor cores.
 	// TODO(roasbeef): remove this if required version # is > 1.6?
@@ -3,11 +3,14 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"runtime/debug"
 	"testing"
 	"time"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/roasbeef/btcd/rpctest"
@@ -123,6 +126,35 @@ func testBasicChannelFunding(net *networkHarness, t *testing.T) {
 	// successfully.
 	chanPoint := openChannel(net.Alice, net.Bob, chanAmt)
 
+	// The funding transaction should now be reported by Alice's wallet as
+	// a confirmed transaction which debited her on-chain balance.
+	fundingTxID, err := wire.NewShaHash(chanPoint.FundingTxid)
+	if err != nil {
+		t.Fatalf("unable to create sha hash: %v", err)
+	}
+	txns, err := net.Alice.GetTransactions(ctxb,
+		&lnrpc.GetTransactionsRequest{})
+	if err != nil {
+		t.Fatalf("unable to fetch transactions: %v", err)
+	}
+	var fundingTx *lnrpc.Transaction
+	for _, tx := range txns.Transactions {
+		if tx.TxHash == fundingTxID.String() {
+			fundingTx = tx
+			break
+		}
+	}
+	switch {
+	case fundingTx == nil:
+		t.Fatalf("funding tx %v not found in wallet transactions",
+			fundingTxID)
+	case fundingTx.NumConfirmations < 1:
+		t.Fatalf("funding tx should be confirmed")
+	case fundingTx.Amount >= 0:
+		t.Fatalf("funding tx should debit the wallet, instead "+
+			"amount is %v", fundingTx.Amount)
+	}
+
 	// Finally, immediately close the channel. This function will also
 	// block until the channel is closed and will additionally assert the
 	// relevant channel closing post conditions.
@@ -147,6 +179,18 @@ func testChannelBalance(net *networkHarness, t *testing.T) {
 		if balance != amount {
 			t.Fatalf("channel balance wrong: %v != %v", balance, amount)
 		}
+
+		// The per-channel breakdown should contain our single open
+		// channel, carrying the entire balance.
+		if len(response.Channels) != 1 {
+			t.Fatalf("expected 1 channel in balance breakdown, "+
+				"instead have %v", len(response.Channels))
+		}
+		chanBalance := btcutil.Amount(response.Channels[0].LocalBalance)
+		if chanBalance != amount {
+			t.Fatalf("per-channel balance wrong: %v != %v",
+				chanBalance, amount)
+		}
 	}
 
 	// Open a channel with 0.5 BTC between Alice and Bob, ensuring the
@@ -369,3 +413,91 @@ func TestLightningNetworkDaemon(t *testing.T) {
 		lnTest(lightningNetwork, t)
 	}
 }
+
+// mockGraphServer is an lnrpc.Lightning service which only serves graph
+// snapshots.
+type mockGraphServer struct {
+	lnrpc.LightningServer
+
+	snapshot []byte
+}
+
+func (m *mockGraphServer) ExportGraphSnapshot(ctx context.Context,
+	in *lnrpc.ExportGraphSnapshotRequest) (*lnrpc.ExportGraphSnapshotResponse,
+	error) {
+
+	return &lnrpc.ExportGraphSnapshotResponse{Snapshot: m.snapshot}, nil
+}
+
+func (m *mockGraphServer) ImportGraphSnapshot(ctx context.Context,
+	in *lnrpc.ImportGraphSnapshotRequest) (*lnrpc.ImportGraphSnapshotResponse,
+	error) {
+
+	return &lnrpc.ImportGraphSnapshotResponse{}, nil
+}
+
+// TestRPCServerOpts tests that the rpc server enforces the configured limits
+// on the size of the messages it receives and sends.
+func TestRPCServerOpts(t *testing.T) {
+	const maxMsgSize = 1024
+	opts := rpcServerOpts(&config{
+		RPCMaxRecvMsgSize:   maxMsgSize,
+		RPCMaxSendMsgSize:   maxMsgSize,
+		RPCKeepAliveTime:    defaultRPCKeepAliveTime,
+		RPCKeepAliveTimeout: defaultRPCKeepAliveTimeout,
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+	graphServer := &mockGraphServer{}
+	grpcServer := grpc.NewServer(opts...)
+	lnrpc.RegisterLightningServer(grpcServer, graphServer)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("unable to dial rpc server: %v", err)
+	}
+	defer conn.Close()
+	client := lnrpc.NewLightningClient(conn)
+	ctxb := context.Background()
+
+	// Requests and responses within the limits should pass, while those
+	// exceeding them must be refused.
+	tests := []struct {
+		size  int
+		valid bool
+	}{
+		{size: maxMsgSize / 2, valid: true},
+		{size: maxMsgSize * 2, valid: false},
+	}
+	for _, test := range tests {
+		_, err := client.ImportGraphSnapshot(ctxb,
+			&lnrpc.ImportGraphSnapshotRequest{
+				Snapshot: make([]byte, test.size),
+			})
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("request of %v bytes refused: %v", test.size,
+				err)
+		case !test.valid && grpc.Code(err) != codes.ResourceExhausted:
+			t.Fatalf("expected request of %v bytes to be refused, "+
+				"got %v", test.size, err)
+		}
+
+		graphServer.snapshot = make([]byte, test.size)
+		_, err = client.ExportGraphSnapshot(ctxb,
+			&lnrpc.ExportGraphSnapshotRequest{})
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("response of %v bytes refused: %v", test.size,
+				err)
+		case !test.valid && grpc.Code(err) != codes.ResourceExhausted:
+			t.Fatalf("expected response of %v bytes to be refused, "+
+				"got %v", test.size, err)
+		}
+	}
+}
@@ -0,0 +1,661 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: chainnotifier.proto
+
+/*
+Package chainrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	chainnotifier.proto
+
+It has these top-level messages:
+	ConfRequest
+	ConfDetails
+	Reorg
+	ConfEvent
+	Outpoint
+	SpendRequest
+	SpendDetails
+	SpendEvent
+	BlockEpochRequest
+	BlockEpoch
+*/
+package chainrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ConfRequest struct {
+	// The hash of the transaction to watch for.
+	Txid []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// The number of confirmations the transaction must reach.
+	NumConfs uint32 `protobuf:"varint,2,opt,name=num_confs,json=numConfs" json:"num_confs,omitempty"`
+}
+
+func (m *ConfRequest) Reset()                    { *m = ConfRequest{} }
+func (m *ConfRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConfRequest) ProtoMessage()               {}
+func (*ConfRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ConfRequest) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *ConfRequest) GetNumConfs() uint32 {
+	if m != nil {
+		return m.NumConfs
+	}
+	return 0
+}
+
+type ConfDetails struct {
+	// The height of the block in which the transaction reached the
+	// requested number of confirmations.
+	BlockHeight uint32 `protobuf:"varint,1,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
+}
+
+func (m *ConfDetails) Reset()                    { *m = ConfDetails{} }
+func (m *ConfDetails) String() string            { return proto.CompactTextString(m) }
+func (*ConfDetails) ProtoMessage()               {}
+func (*ConfDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ConfDetails) GetBlockHeight() uint32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+type Reorg struct {
+	// The depth of the re-org which disconnected the transaction.
+	Depth uint32 `protobuf:"varint,1,opt,name=depth" json:"depth,omitempty"`
+}
+
+func (m *Reorg) Reset()                    { *m = Reorg{} }
+func (m *Reorg) String() string            { return proto.CompactTextString(m) }
+func (*Reorg) ProtoMessage()               {}
+func (*Reorg) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Reorg) GetDepth() uint32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+type ConfEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*ConfEvent_Conf
+	//	*ConfEvent_Reorg
+	Event isConfEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *ConfEvent) Reset()                    { *m = ConfEvent{} }
+func (m *ConfEvent) String() string            { return proto.CompactTextString(m) }
+func (*ConfEvent) ProtoMessage()               {}
+func (*ConfEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type isConfEvent_Event interface {
+	isConfEvent_Event()
+}
+
+type ConfEvent_Conf struct {
+	Conf *ConfDetails `protobuf:"bytes,1,opt,name=conf,oneof"`
+}
+type ConfEvent_Reorg struct {
+	Reorg *Reorg `protobuf:"bytes,2,opt,name=reorg,oneof"`
+}
+
+func (*ConfEvent_Conf) isConfEvent_Event()  {}
+func (*ConfEvent_Reorg) isConfEvent_Event() {}
+
+func (m *ConfEvent) GetEvent() isConfEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *ConfEvent) GetConf() *ConfDetails {
+	if x, ok := m.GetEvent().(*ConfEvent_Conf); ok {
+		return x.Conf
+	}
+	return nil
+}
+
+func (m *ConfEvent) GetReorg() *Reorg {
+	if x, ok := m.GetEvent().(*ConfEvent_Reorg); ok {
+		return x.Reorg
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ConfEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ConfEvent_OneofMarshaler, _ConfEvent_OneofUnmarshaler, _ConfEvent_OneofSizer, []interface{}{
+		(*ConfEvent_Conf)(nil),
+		(*ConfEvent_Reorg)(nil),
+	}
+}
+
+func _ConfEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ConfEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ConfEvent_Conf:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Conf); err != nil {
+			return err
+		}
+	case *ConfEvent_Reorg:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Reorg); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ConfEvent.Event has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ConfEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ConfEvent)
+	switch tag {
+	case 1: // event.conf
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfDetails)
+		err := b.DecodeMessage(msg)
+		m.Event = &ConfEvent_Conf{msg}
+		return true, err
+	case 2: // event.reorg
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Reorg)
+		err := b.DecodeMessage(msg)
+		m.Event = &ConfEvent_Reorg{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ConfEvent_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ConfEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *ConfEvent_Conf:
+		s := proto.Size(x.Conf)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *ConfEvent_Reorg:
+		s := proto.Size(x.Reorg)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type Outpoint struct {
+	// The hash of the transaction which created the outpoint.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The index of the output within the transaction.
+	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+}
+
+func (m *Outpoint) Reset()                    { *m = Outpoint{} }
+func (m *Outpoint) String() string            { return proto.CompactTextString(m) }
+func (*Outpoint) ProtoMessage()               {}
+func (*Outpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Outpoint) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *Outpoint) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type SpendRequest struct {
+	Outpoint *Outpoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+}
+
+func (m *SpendRequest) Reset()                    { *m = SpendRequest{} }
+func (m *SpendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SpendRequest) ProtoMessage()               {}
+func (*SpendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SpendRequest) GetOutpoint() *Outpoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+type SpendDetails struct {
+	SpendingOutpoint   *Outpoint `protobuf:"bytes,1,opt,name=spending_outpoint,json=spendingOutpoint" json:"spending_outpoint,omitempty"`
+	RawSpendingTx      []byte    `protobuf:"bytes,2,opt,name=raw_spending_tx,json=rawSpendingTx,proto3" json:"raw_spending_tx,omitempty"`
+	SpendingTxHash     []byte    `protobuf:"bytes,3,opt,name=spending_tx_hash,json=spendingTxHash,proto3" json:"spending_tx_hash,omitempty"`
+	SpendingInputIndex uint32    `protobuf:"varint,4,opt,name=spending_input_index,json=spendingInputIndex" json:"spending_input_index,omitempty"`
+	SpendingHeight     uint32    `protobuf:"varint,5,opt,name=spending_height,json=spendingHeight" json:"spending_height,omitempty"`
+}
+
+func (m *SpendDetails) Reset()                    { *m = SpendDetails{} }
+func (m *SpendDetails) String() string            { return proto.CompactTextString(m) }
+func (*SpendDetails) ProtoMessage()               {}
+func (*SpendDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SpendDetails) GetSpendingOutpoint() *Outpoint {
+	if m != nil {
+		return m.SpendingOutpoint
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetRawSpendingTx() []byte {
+	if m != nil {
+		return m.RawSpendingTx
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetSpendingTxHash() []byte {
+	if m != nil {
+		return m.SpendingTxHash
+	}
+	return nil
+}
+
+func (m *SpendDetails) GetSpendingInputIndex() uint32 {
+	if m != nil {
+		return m.SpendingInputIndex
+	}
+	return 0
+}
+
+func (m *SpendDetails) GetSpendingHeight() uint32 {
+	if m != nil {
+		return m.SpendingHeight
+	}
+	return 0
+}
+
+type SpendEvent struct {
+	Spend *SpendDetails `protobuf:"bytes,1,opt,name=spend" json:"spend,omitempty"`
+}
+
+func (m *SpendEvent) Reset()                    { *m = SpendEvent{} }
+func (m *SpendEvent) String() string            { return proto.CompactTextString(m) }
+func (*SpendEvent) ProtoMessage()               {}
+func (*SpendEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SpendEvent) GetSpend() *SpendDetails {
+	if m != nil {
+		return m.Spend
+	}
+	return nil
+}
+
+type BlockEpochRequest struct {
+}
+
+func (m *BlockEpochRequest) Reset()                    { *m = BlockEpochRequest{} }
+func (m *BlockEpochRequest) String() string            { return proto.CompactTextString(m) }
+func (*BlockEpochRequest) ProtoMessage()               {}
+func (*BlockEpochRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type BlockEpoch struct {
+	// The hash of the block.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// The height of the block.
+	Height uint32 `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+	// The time at which the block was mined as a unix timestamp, as
+	// reported within its header.
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *BlockEpoch) Reset()                    { *m = BlockEpoch{} }
+func (m *BlockEpoch) String() string            { return proto.CompactTextString(m) }
+func (*BlockEpoch) ProtoMessage()               {}
+func (*BlockEpoch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *BlockEpoch) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BlockEpoch) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockEpoch) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ConfRequest)(nil), "chainrpc.ConfRequest")
+	proto.RegisterType((*ConfDetails)(nil), "chainrpc.ConfDetails")
+	proto.RegisterType((*Reorg)(nil), "chainrpc.Reorg")
+	proto.RegisterType((*ConfEvent)(nil), "chainrpc.ConfEvent")
+	proto.RegisterType((*Outpoint)(nil), "chainrpc.Outpoint")
+	proto.RegisterType((*SpendRequest)(nil), "chainrpc.SpendRequest")
+	proto.RegisterType((*SpendDetails)(nil), "chainrpc.SpendDetails")
+	proto.RegisterType((*SpendEvent)(nil), "chainrpc.SpendEvent")
+	proto.RegisterType((*BlockEpochRequest)(nil), "chainrpc.BlockEpochRequest")
+	proto.RegisterType((*BlockEpoch)(nil), "chainrpc.BlockEpoch")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ChainNotifier service
+
+type ChainNotifierClient interface {
+	// RegisterConfirmationsNtfn streams a notification once the target
+	// transaction reaches the requested number of confirmations, followed
+	// by a notification each time the confirmed transaction is re-org'd
+	// out of the main chain.
+	RegisterConfirmationsNtfn(ctx context.Context, in *ConfRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterConfirmationsNtfnClient, error)
+	// RegisterSpendNtfn streams a single notification once the target
+	// outpoint has been spent.
+	RegisterSpendNtfn(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterSpendNtfnClient, error)
+	// RegisterBlockEpochNtfn streams a notification for each new block
+	// connected to the tip of the main chain.
+	RegisterBlockEpochNtfn(ctx context.Context, in *BlockEpochRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterBlockEpochNtfnClient, error)
+}
+
+type chainNotifierClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChainNotifierClient(cc *grpc.ClientConn) ChainNotifierClient {
+	return &chainNotifierClient{cc}
+}
+
+func (c *chainNotifierClient) RegisterConfirmationsNtfn(ctx context.Context, in *ConfRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterConfirmationsNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[0], c.cc, "/chainrpc.ChainNotifier/RegisterConfirmationsNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterConfirmationsNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterConfirmationsNtfnClient interface {
+	Recv() (*ConfEvent, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterConfirmationsNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterConfirmationsNtfnClient) Recv() (*ConfEvent, error) {
+	m := new(ConfEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *chainNotifierClient) RegisterSpendNtfn(ctx context.Context, in *SpendRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterSpendNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[1], c.cc, "/chainrpc.ChainNotifier/RegisterSpendNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterSpendNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterSpendNtfnClient interface {
+	Recv() (*SpendEvent, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterSpendNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterSpendNtfnClient) Recv() (*SpendEvent, error) {
+	m := new(SpendEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *chainNotifierClient) RegisterBlockEpochNtfn(ctx context.Context, in *BlockEpochRequest, opts ...grpc.CallOption) (ChainNotifier_RegisterBlockEpochNtfnClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChainNotifier_serviceDesc.Streams[2], c.cc, "/chainrpc.ChainNotifier/RegisterBlockEpochNtfn", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chainNotifierRegisterBlockEpochNtfnClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ChainNotifier_RegisterBlockEpochNtfnClient interface {
+	Recv() (*BlockEpoch, error)
+	grpc.ClientStream
+}
+
+type chainNotifierRegisterBlockEpochNtfnClient struct {
+	grpc.ClientStream
+}
+
+func (x *chainNotifierRegisterBlockEpochNtfnClient) Recv() (*BlockEpoch, error) {
+	m := new(BlockEpoch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for ChainNotifier service
+
+type ChainNotifierServer interface {
+	// RegisterConfirmationsNtfn streams a notification once the target
+	// transaction reaches the requested number of confirmations, followed
+	// by a notification each time the confirmed transaction is re-org'd
+	// out of the main chain.
+	RegisterConfirmationsNtfn(*ConfRequest, ChainNotifier_RegisterConfirmationsNtfnServer) error
+	// RegisterSpendNtfn streams a single notification once the target
+	// outpoint has been spent.
+	RegisterSpendNtfn(*SpendRequest, ChainNotifier_RegisterSpendNtfnServer) error
+	// RegisterBlockEpochNtfn streams a notification for each new block
+	// connected to the tip of the main chain.
+	RegisterBlockEpochNtfn(*BlockEpochRequest, ChainNotifier_RegisterBlockEpochNtfnServer) error
+}
+
+func RegisterChainNotifierServer(s *grpc.Server, srv ChainNotifierServer) {
+	s.RegisterService(&_ChainNotifier_serviceDesc, srv)
+}
+
+func _ChainNotifier_RegisterConfirmationsNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConfRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterConfirmationsNtfn(m, &chainNotifierRegisterConfirmationsNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterConfirmationsNtfnServer interface {
+	Send(*ConfEvent) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterConfirmationsNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterConfirmationsNtfnServer) Send(m *ConfEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ChainNotifier_RegisterSpendNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpendRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterSpendNtfn(m, &chainNotifierRegisterSpendNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterSpendNtfnServer interface {
+	Send(*SpendEvent) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterSpendNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterSpendNtfnServer) Send(m *SpendEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ChainNotifier_RegisterBlockEpochNtfn_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockEpochRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainNotifierServer).RegisterBlockEpochNtfn(m, &chainNotifierRegisterBlockEpochNtfnServer{stream})
+}
+
+type ChainNotifier_RegisterBlockEpochNtfnServer interface {
+	Send(*BlockEpoch) error
+	grpc.ServerStream
+}
+
+type chainNotifierRegisterBlockEpochNtfnServer struct {
+	grpc.ServerStream
+}
+
+func (x *chainNotifierRegisterBlockEpochNtfnServer) Send(m *BlockEpoch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ChainNotifier_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "chainrpc.ChainNotifier",
+	HandlerType: (*ChainNotifierServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RegisterConfirmationsNtfn",
+			Handler:       _ChainNotifier_RegisterConfirmationsNtfn_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegisterSpendNtfn",
+			Handler:       _ChainNotifier_RegisterSpendNtfn_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RegisterBlockEpochNtfn",
+			Handler:       _ChainNotifier_RegisterBlockEpochNtfn_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "chainnotifier.proto",
+}
+
+func init() { proto.RegisterFile("chainnotifier.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 524 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0x5e, 0xd8, 0x32, 0xda, 0xd7, 0x96, 0xae, 0x6e, 0xa9, 0xc6, 0x06, 0xd2, 0xc8, 0x81, 0x55,
+	0x02, 0x55, 0x55, 0xe1, 0xc4, 0x61, 0x48, 0x2b, 0x93, 0xda, 0x03, 0x43, 0xf2, 0x10, 0xd7, 0x28,
+	0x4b, 0xdd, 0xc6, 0xb0, 0xd8, 0x21, 0x76, 0x58, 0x7f, 0x35, 0xbf, 0x80, 0x03, 0xf2, 0x8b, 0x9d,
+	0x94, 0xb2, 0x03, 0xb7, 0xf8, 0xbd, 0xcf, 0xdf, 0xfb, 0xbe, 0xef, 0xb9, 0x85, 0x7e, 0x9c, 0x44,
+	0x5c, 0x08, 0xa9, 0xf9, 0x8a, 0xb3, 0x7c, 0x9c, 0xe5, 0x52, 0x4b, 0xd2, 0xc0, 0x62, 0x9e, 0xc5,
+	0xc1, 0x05, 0xb4, 0x66, 0x52, 0xac, 0x28, 0xfb, 0x51, 0x30, 0xa5, 0x09, 0x81, 0x03, 0xbd, 0xe1,
+	0xcb, 0x63, 0xef, 0xcc, 0x1b, 0xb5, 0x29, 0x7e, 0x93, 0x53, 0x68, 0x8a, 0x22, 0x0d, 0x63, 0x29,
+	0x56, 0xea, 0xf8, 0xd1, 0x99, 0x37, 0xea, 0xd0, 0x86, 0x28, 0x52, 0x73, 0x4d, 0x05, 0x93, 0xf2,
+	0xfe, 0x47, 0xa6, 0x23, 0x7e, 0xa7, 0xc8, 0x4b, 0x68, 0xdf, 0xde, 0xc9, 0xf8, 0x7b, 0x98, 0x30,
+	0xbe, 0x4e, 0x34, 0xf2, 0x74, 0x68, 0x0b, 0x6b, 0x73, 0x2c, 0x05, 0x2f, 0xc0, 0xa7, 0x4c, 0xe6,
+	0x6b, 0x32, 0x00, 0x7f, 0xc9, 0x32, 0x9d, 0x58, 0x50, 0x79, 0x08, 0xbe, 0x41, 0xd3, 0x10, 0x5e,
+	0xfd, 0x64, 0x42, 0x93, 0xd7, 0x70, 0x60, 0xc6, 0x22, 0xa2, 0x35, 0x7d, 0x3a, 0x76, 0xb2, 0xc7,
+	0x5b, 0x33, 0xe7, 0x7b, 0x14, 0x41, 0xe4, 0x1c, 0xfc, 0xdc, 0x10, 0xa3, 0xc6, 0xd6, 0xb4, 0x5b,
+	0xa3, 0x71, 0xde, 0x7c, 0x8f, 0x96, 0xfd, 0xcb, 0xc7, 0xe0, 0x33, 0x43, 0x1f, 0xbc, 0x83, 0xc6,
+	0xe7, 0x42, 0x67, 0x92, 0x0b, 0x74, 0x9e, 0x44, 0x2a, 0x71, 0xce, 0xcd, 0xb7, 0x51, 0xc8, 0xc5,
+	0x92, 0x6d, 0xac, 0xeb, 0xf2, 0x10, 0x5c, 0x40, 0xfb, 0x26, 0x63, 0x62, 0xe9, 0x32, 0x1b, 0x43,
+	0x43, 0x5a, 0x16, 0x2b, 0x94, 0xd4, 0xa3, 0x1d, 0x3f, 0xad, 0x30, 0xc1, 0x6f, 0xcf, 0x12, 0xb8,
+	0xd0, 0x3e, 0x40, 0x4f, 0x99, 0x33, 0x17, 0xeb, 0xf0, 0x3f, 0x98, 0x8e, 0x1c, 0xb8, 0xd2, 0xfe,
+	0x0a, 0xba, 0x79, 0x74, 0x1f, 0x56, 0x24, 0xba, 0x54, 0xdc, 0xa6, 0x9d, 0x3c, 0xba, 0xbf, 0xb1,
+	0xd5, 0x2f, 0x1b, 0x32, 0x82, 0xa3, 0x2d, 0x4c, 0x88, 0x7e, 0xf7, 0x11, 0xf8, 0x44, 0x55, 0xa8,
+	0xb9, 0x71, 0x3e, 0x81, 0x41, 0x85, 0xe4, 0x22, 0x2b, 0x74, 0x58, 0x06, 0x71, 0x80, 0x41, 0x10,
+	0xd7, 0x5b, 0x98, 0xd6, 0xc2, 0x74, 0xc8, 0x39, 0x74, 0xab, 0x1b, 0x76, 0xf9, 0x3e, 0x82, 0x2b,
+	0x6a, 0xbb, 0xff, 0xf7, 0x00, 0x28, 0xa9, 0xdc, 0xf0, 0x1b, 0xf0, 0xb1, 0x6f, 0xfd, 0x0e, 0x6b,
+	0xbf, 0xdb, 0x11, 0xd1, 0x12, 0x14, 0xf4, 0xa1, 0x77, 0x69, 0x9e, 0xd2, 0x55, 0x26, 0xe3, 0xc4,
+	0xe6, 0x1f, 0x7c, 0x05, 0xa8, 0x8b, 0x0f, 0xee, 0x71, 0x08, 0x87, 0x56, 0x52, 0xb9, 0x48, 0x7b,
+	0x22, 0xcf, 0xa1, 0xa9, 0x79, 0xca, 0x94, 0x8e, 0xd2, 0x0c, 0x83, 0xd8, 0xa7, 0x75, 0x61, 0xfa,
+	0xcb, 0x83, 0xce, 0xcc, 0xa8, 0xb9, 0xb6, 0x3f, 0x1e, 0xb2, 0x80, 0x67, 0x94, 0xad, 0xb9, 0xd2,
+	0x2c, 0x37, 0x0f, 0x90, 0xe7, 0x69, 0xa4, 0xb9, 0x14, 0xea, 0x5a, 0xaf, 0x04, 0xd9, 0x79, 0x9d,
+	0x56, 0xdd, 0x49, 0xff, 0xef, 0x32, 0xba, 0x9e, 0x78, 0x64, 0x06, 0x3d, 0x47, 0x85, 0x46, 0x91,
+	0x62, 0xd7, 0xbd, 0xe3, 0x18, 0xec, 0xd4, 0x1d, 0xc9, 0x27, 0x18, 0x3a, 0x92, 0x3a, 0x01, 0x64,
+	0x3a, 0xad, 0x6f, 0xfc, 0x13, 0xd8, 0xc9, 0xe0, 0xa1, 0xe6, 0xc4, 0xbb, 0x3d, 0xc4, 0x3f, 0x87,
+	0xb7, 0x7f, 0x06, 0x00, 0x86, 0xdc, 0xa6, 0x55, 0x33, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,3 @@
+#!/bin/sh
+
+protoc -I . chainnotifier.proto --go_out=plugins=grpc:.
@@ -0,0 +1,3 @@
+#!/bin/sh
+
+protoc -I . rescue.proto --go_out=plugins=grpc:.
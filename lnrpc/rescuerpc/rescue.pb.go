@@ -0,0 +1,463 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rescue.proto
+
+/*
+Package rescuerpc is a generated protocol buffer package.
+
+It is generated from these files:
+	rescue.proto
+
+It has these top-level messages:
+	ChannelParams
+	RescueRequest
+	RescueResponse
+	SweepRequest
+	SweepResponse
+*/
+package rescuerpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ChannelParams struct {
+	// The funding outpoint of the channel, given as txid:index.
+	ChanPoint string `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint" json:"chan_point,omitempty"`
+	// Our serialized commitment key within the channel.
+	LocalCommitKey []byte `protobuf:"bytes,2,opt,name=local_commit_key,json=localCommitKey,proto3" json:"local_commit_key,omitempty"`
+	// The remote node's serialized commitment key within the channel.
+	RemoteCommitKey []byte `protobuf:"bytes,3,opt,name=remote_commit_key,json=remoteCommitKey,proto3" json:"remote_commit_key,omitempty"`
+	// The relative delay applied to our output within our own commitment
+	// transaction.
+	CsvDelay uint32 `protobuf:"varint,4,opt,name=csv_delay,json=csvDelay" json:"csv_delay,omitempty"`
+	// The lease expiry height locking our outputs, if we sold a lease on
+	// the channel.
+	LeaseExpiry uint32 `protobuf:"varint,5,opt,name=lease_expiry,json=leaseExpiry" json:"lease_expiry,omitempty"`
+	// The highest commitment height searched for when matching our output
+	// within our own commitment transaction.
+	MaxStateNum uint64 `protobuf:"varint,6,opt,name=max_state_num,json=maxStateNum" json:"max_state_num,omitempty"`
+}
+
+func (m *ChannelParams) Reset()                    { *m = ChannelParams{} }
+func (m *ChannelParams) String() string            { return proto.CompactTextString(m) }
+func (*ChannelParams) ProtoMessage()               {}
+func (*ChannelParams) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ChannelParams) GetChanPoint() string {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return ""
+}
+
+func (m *ChannelParams) GetLocalCommitKey() []byte {
+	if m != nil {
+		return m.LocalCommitKey
+	}
+	return nil
+}
+
+func (m *ChannelParams) GetRemoteCommitKey() []byte {
+	if m != nil {
+		return m.RemoteCommitKey
+	}
+	return nil
+}
+
+func (m *ChannelParams) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+func (m *ChannelParams) GetLeaseExpiry() uint32 {
+	if m != nil {
+		return m.LeaseExpiry
+	}
+	return 0
+}
+
+func (m *ChannelParams) GetMaxStateNum() uint64 {
+	if m != nil {
+		return m.MaxStateNum
+	}
+	return 0
+}
+
+type RescueRequest struct {
+	// The static parameters of the channel.
+	Params *ChannelParams `protobuf:"bytes,1,opt,name=params" json:"params,omitempty"`
+	// The serialized commitment transaction which closed the channel.
+	RawCloseTx []byte `protobuf:"bytes,2,opt,name=raw_close_tx,json=rawCloseTx,proto3" json:"raw_close_tx,omitempty"`
+}
+
+func (m *RescueRequest) Reset()                    { *m = RescueRequest{} }
+func (m *RescueRequest) String() string            { return proto.CompactTextString(m) }
+func (*RescueRequest) ProtoMessage()               {}
+func (*RescueRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *RescueRequest) GetParams() *ChannelParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *RescueRequest) GetRawCloseTx() []byte {
+	if m != nil {
+		return m.RawCloseTx
+	}
+	return nil
+}
+
+type RescueResponse struct {
+	// The serialized multi-sig keys of the funding output.
+	LocalMultisigKey  []byte `protobuf:"bytes,1,opt,name=local_multisig_key,json=localMultisigKey,proto3" json:"local_multisig_key,omitempty"`
+	RemoteMultisigKey []byte `protobuf:"bytes,2,opt,name=remote_multisig_key,json=remoteMultisigKey,proto3" json:"remote_multisig_key,omitempty"`
+	// Whether the commitment transaction is our own.
+	LocalCommit bool `protobuf:"varint,3,opt,name=local_commit,json=localCommit" json:"local_commit,omitempty"`
+	// The height of our commitment transaction, if it's our own.
+	StateNum uint64 `protobuf:"varint,4,opt,name=state_num,json=stateNum" json:"state_num,omitempty"`
+	// The serialized revocation key of our commitment transaction, if it's
+	// our own.
+	RevocationKey []byte `protobuf:"bytes,5,opt,name=revocation_key,json=revocationKey,proto3" json:"revocation_key,omitempty"`
+	// Our output within the commitment transaction, given as txid:index.
+	Outpoint string `protobuf:"bytes,6,opt,name=outpoint" json:"outpoint,omitempty"`
+	// The value of our output in satoshis.
+	Amount int64 `protobuf:"varint,7,opt,name=amount" json:"amount,omitempty"`
+	// The relative delay which must pass before the output can be swept.
+	CsvDelay uint32 `protobuf:"varint,8,opt,name=csv_delay,json=csvDelay" json:"csv_delay,omitempty"`
+	// The absolute height before which the output can't be swept.
+	LeaseExpiry uint32 `protobuf:"varint,9,opt,name=lease_expiry,json=leaseExpiry" json:"lease_expiry,omitempty"`
+}
+
+func (m *RescueResponse) Reset()                    { *m = RescueResponse{} }
+func (m *RescueResponse) String() string            { return proto.CompactTextString(m) }
+func (*RescueResponse) ProtoMessage()               {}
+func (*RescueResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RescueResponse) GetLocalMultisigKey() []byte {
+	if m != nil {
+		return m.LocalMultisigKey
+	}
+	return nil
+}
+
+func (m *RescueResponse) GetRemoteMultisigKey() []byte {
+	if m != nil {
+		return m.RemoteMultisigKey
+	}
+	return nil
+}
+
+func (m *RescueResponse) GetLocalCommit() bool {
+	if m != nil {
+		return m.LocalCommit
+	}
+	return false
+}
+
+func (m *RescueResponse) GetStateNum() uint64 {
+	if m != nil {
+		return m.StateNum
+	}
+	return 0
+}
+
+func (m *RescueResponse) GetRevocationKey() []byte {
+	if m != nil {
+		return m.RevocationKey
+	}
+	return nil
+}
+
+func (m *RescueResponse) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *RescueResponse) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *RescueResponse) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+func (m *RescueResponse) GetLeaseExpiry() uint32 {
+	if m != nil {
+		return m.LeaseExpiry
+	}
+	return 0
+}
+
+type SweepRequest struct {
+	// The static parameters of the channel.
+	Params *ChannelParams `protobuf:"bytes,1,opt,name=params" json:"params,omitempty"`
+	// The serialized commitment transaction which closed the channel.
+	RawCloseTx []byte `protobuf:"bytes,2,opt,name=raw_close_tx,json=rawCloseTx,proto3" json:"raw_close_tx,omitempty"`
+	// The address to sweep the funds to. If empty, the funds are swept to
+	// the configured sweep address or extended public key, or to a fresh
+	// address of the wallet if neither is configured.
+	SweepAddr string `protobuf:"bytes,3,opt,name=sweep_addr,json=sweepAddr" json:"sweep_addr,omitempty"`
+	// The fee rate of the sweep transaction in sat/byte.
+	SatPerByte int64 `protobuf:"varint,4,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// Whether the sweep transaction should be broadcast, rather than only
+	// returned.
+	Publish bool `protobuf:"varint,5,opt,name=publish" json:"publish,omitempty"`
+}
+
+func (m *SweepRequest) Reset()                    { *m = SweepRequest{} }
+func (m *SweepRequest) String() string            { return proto.CompactTextString(m) }
+func (*SweepRequest) ProtoMessage()               {}
+func (*SweepRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SweepRequest) GetParams() *ChannelParams {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *SweepRequest) GetRawCloseTx() []byte {
+	if m != nil {
+		return m.RawCloseTx
+	}
+	return nil
+}
+
+func (m *SweepRequest) GetSweepAddr() string {
+	if m != nil {
+		return m.SweepAddr
+	}
+	return ""
+}
+
+func (m *SweepRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+func (m *SweepRequest) GetPublish() bool {
+	if m != nil {
+		return m.Publish
+	}
+	return false
+}
+
+type SweepResponse struct {
+	// The serialized sweep transaction.
+	RawSweepTx []byte `protobuf:"bytes,1,opt,name=raw_sweep_tx,json=rawSweepTx,proto3" json:"raw_sweep_tx,omitempty"`
+	// The hash of the sweep transaction.
+	SweepTxid []byte `protobuf:"bytes,2,opt,name=sweep_txid,json=sweepTxid,proto3" json:"sweep_txid,omitempty"`
+}
+
+func (m *SweepResponse) Reset()                    { *m = SweepResponse{} }
+func (m *SweepResponse) String() string            { return proto.CompactTextString(m) }
+func (*SweepResponse) ProtoMessage()               {}
+func (*SweepResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SweepResponse) GetRawSweepTx() []byte {
+	if m != nil {
+		return m.RawSweepTx
+	}
+	return nil
+}
+
+func (m *SweepResponse) GetSweepTxid() []byte {
+	if m != nil {
+		return m.SweepTxid
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChannelParams)(nil), "rescuerpc.ChannelParams")
+	proto.RegisterType((*RescueRequest)(nil), "rescuerpc.RescueRequest")
+	proto.RegisterType((*RescueResponse)(nil), "rescuerpc.RescueResponse")
+	proto.RegisterType((*SweepRequest)(nil), "rescuerpc.SweepRequest")
+	proto.RegisterType((*SweepResponse)(nil), "rescuerpc.SweepResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Rescue service
+
+type RescueClient interface {
+	// RescueChannel locates our output within a commitment transaction of
+	// the target channel, re-deriving the keys and revocation key of the
+	// channel from the wallet's seed. Nothing is swept.
+	RescueChannel(ctx context.Context, in *RescueRequest, opts ...grpc.CallOption) (*RescueResponse, error)
+	// SweepForceClose sweeps our output within a confirmed commitment
+	// transaction of the target channel, once any delay has passed.
+	SweepForceClose(ctx context.Context, in *SweepRequest, opts ...grpc.CallOption) (*SweepResponse, error)
+}
+
+type rescueClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRescueClient(cc *grpc.ClientConn) RescueClient {
+	return &rescueClient{cc}
+}
+
+func (c *rescueClient) RescueChannel(ctx context.Context, in *RescueRequest, opts ...grpc.CallOption) (*RescueResponse, error) {
+	out := new(RescueResponse)
+	err := grpc.Invoke(ctx, "/rescuerpc.Rescue/RescueChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rescueClient) SweepForceClose(ctx context.Context, in *SweepRequest, opts ...grpc.CallOption) (*SweepResponse, error) {
+	out := new(SweepResponse)
+	err := grpc.Invoke(ctx, "/rescuerpc.Rescue/SweepForceClose", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Rescue service
+
+type RescueServer interface {
+	// RescueChannel locates our output within a commitment transaction of
+	// the target channel, re-deriving the keys and revocation key of the
+	// channel from the wallet's seed. Nothing is swept.
+	RescueChannel(context.Context, *RescueRequest) (*RescueResponse, error)
+	// SweepForceClose sweeps our output within a confirmed commitment
+	// transaction of the target channel, once any delay has passed.
+	SweepForceClose(context.Context, *SweepRequest) (*SweepResponse, error)
+}
+
+func RegisterRescueServer(s *grpc.Server, srv RescueServer) {
+	s.RegisterService(&_Rescue_serviceDesc, srv)
+}
+
+func _Rescue_RescueChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RescueServer).RescueChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rescuerpc.Rescue/RescueChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RescueServer).RescueChannel(ctx, req.(*RescueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rescue_SweepForceClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SweepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RescueServer).SweepForceClose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rescuerpc.Rescue/SweepForceClose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RescueServer).SweepForceClose(ctx, req.(*SweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Rescue_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rescuerpc.Rescue",
+	HandlerType: (*RescueServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RescueChannel",
+			Handler:    _Rescue_RescueChannel_Handler,
+		},
+		{
+			MethodName: "SweepForceClose",
+			Handler:    _Rescue_SweepForceClose_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rescue.proto",
+}
+
+func init() { proto.RegisterFile("rescue.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 546 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xdf, 0x6a, 0xd5, 0x40,
+	0x10, 0xc6, 0x49, 0xff, 0x9c, 0x9e, 0xcc, 0x39, 0x69, 0x75, 0x05, 0x8d, 0x15, 0x21, 0x06, 0x84,
+	0x20, 0x72, 0x90, 0xfa, 0x04, 0xda, 0xea, 0x8d, 0x28, 0x87, 0x6d, 0xef, 0x97, 0x6d, 0x32, 0xd8,
+	0x60, 0x92, 0x8d, 0xbb, 0x9b, 0x36, 0x79, 0x0f, 0x5f, 0xc7, 0x07, 0xf0, 0x4d, 0x7c, 0x0c, 0xd9,
+	0xc9, 0xb6, 0x27, 0x87, 0x0a, 0x5e, 0x79, 0x39, 0xdf, 0x4c, 0xf6, 0x9b, 0xf9, 0xcd, 0x10, 0x58,
+	0x6a, 0x34, 0x79, 0x87, 0xab, 0x56, 0x2b, 0xab, 0x58, 0x38, 0x46, 0xba, 0xcd, 0xd3, 0xdf, 0x01,
+	0x44, 0xa7, 0x57, 0xb2, 0x69, 0xb0, 0x5a, 0x4b, 0x2d, 0x6b, 0xc3, 0x9e, 0x03, 0xe4, 0x57, 0xb2,
+	0x11, 0xad, 0x2a, 0x1b, 0x1b, 0x07, 0x49, 0x90, 0x85, 0x3c, 0x74, 0xca, 0xda, 0x09, 0x2c, 0x83,
+	0x07, 0x95, 0xca, 0x65, 0x25, 0x72, 0x55, 0xd7, 0xa5, 0x15, 0xdf, 0x70, 0x88, 0x77, 0x92, 0x20,
+	0x5b, 0xf2, 0x43, 0xd2, 0x4f, 0x49, 0xfe, 0x84, 0x03, 0x7b, 0x05, 0x0f, 0x35, 0xd6, 0xca, 0xe2,
+	0xb4, 0x74, 0x97, 0x4a, 0x8f, 0xc6, 0xc4, 0xa6, 0xf6, 0x19, 0x84, 0xb9, 0xb9, 0x16, 0x05, 0x56,
+	0x72, 0x88, 0xf7, 0x92, 0x20, 0x8b, 0xf8, 0x3c, 0x37, 0xd7, 0x67, 0x2e, 0x66, 0x2f, 0x60, 0x59,
+	0xa1, 0x34, 0x28, 0xb0, 0x6f, 0x4b, 0x3d, 0xc4, 0xfb, 0x94, 0x5f, 0x90, 0xf6, 0x81, 0x24, 0x96,
+	0x42, 0x54, 0xcb, 0x5e, 0x18, 0x2b, 0x2d, 0x8a, 0xa6, 0xab, 0xe3, 0x59, 0x12, 0x64, 0x7b, 0x7c,
+	0x51, 0xcb, 0xfe, 0xdc, 0x69, 0x5f, 0xba, 0x3a, 0xcd, 0x21, 0xe2, 0x34, 0x37, 0xc7, 0xef, 0x1d,
+	0x1a, 0xcb, 0xde, 0xc0, 0xac, 0xa5, 0x99, 0x69, 0xca, 0xc5, 0x49, 0xbc, 0xba, 0xe3, 0xb2, 0xda,
+	0x62, 0xc2, 0x7d, 0x1d, 0x4b, 0x60, 0xa9, 0xe5, 0x8d, 0xc8, 0x2b, 0x65, 0x50, 0xd8, 0xde, 0x0f,
+	0x0e, 0x5a, 0xde, 0x9c, 0x3a, 0xe9, 0xa2, 0x4f, 0x7f, 0xed, 0xc0, 0xe1, 0xad, 0x8b, 0x69, 0x55,
+	0x63, 0x90, 0xbd, 0x06, 0x36, 0x12, 0xab, 0xbb, 0xca, 0x96, 0xa6, 0xfc, 0x4a, 0x20, 0x02, 0xfa,
+	0x74, 0x64, 0xf9, 0xd9, 0x27, 0x1c, 0x89, 0x15, 0x3c, 0xf2, 0xd4, 0xb6, 0xca, 0x47, 0x27, 0x0f,
+	0x74, 0x5a, 0xef, 0xe0, 0x4c, 0xf6, 0x41, 0x80, 0xe7, 0x7c, 0x31, 0xd9, 0x85, 0x83, 0xbb, 0x01,
+	0xb3, 0x47, 0x60, 0xe6, 0xc6, 0x53, 0x61, 0x2f, 0xe1, 0x50, 0xe3, 0xb5, 0xca, 0xa5, 0x2d, 0x55,
+	0x43, 0x56, 0xfb, 0x64, 0x15, 0x6d, 0x54, 0x67, 0x73, 0x0c, 0x73, 0xd5, 0xd9, 0xf1, 0x26, 0x66,
+	0x74, 0x13, 0x77, 0x31, 0x7b, 0x0c, 0x33, 0x59, 0xab, 0xae, 0xb1, 0xf1, 0x41, 0x12, 0x64, 0xbb,
+	0xdc, 0x47, 0xdb, 0x4b, 0x9d, 0xff, 0x63, 0xa9, 0xe1, 0xbd, 0xa5, 0xa6, 0x3f, 0x03, 0x58, 0x9e,
+	0xdf, 0x20, 0xb6, 0xff, 0x71, 0x61, 0xee, 0xdc, 0x8d, 0xf3, 0x10, 0xb2, 0x28, 0x34, 0xd1, 0x0b,
+	0x79, 0x48, 0xca, 0xbb, 0xa2, 0xd0, 0xee, 0x01, 0x23, 0xad, 0x68, 0x51, 0x8b, 0xcb, 0xc1, 0x22,
+	0xe1, 0xdb, 0xe5, 0x60, 0xa4, 0x5d, 0xa3, 0x7e, 0x3f, 0x58, 0x64, 0x31, 0x1c, 0xb4, 0xdd, 0x65,
+	0x55, 0x9a, 0x2b, 0x22, 0x37, 0xe7, 0xb7, 0x61, 0xba, 0x86, 0xc8, 0xb7, 0xef, 0x2f, 0xc1, 0x77,
+	0x33, 0xfa, 0xd9, 0x3e, 0x0e, 0xee, 0xba, 0xa1, 0xba, 0x69, 0x37, 0xb6, 0x2f, 0x0b, 0xdf, 0xed,
+	0xd8, 0xcd, 0x45, 0x5f, 0x16, 0x27, 0x3f, 0x02, 0x98, 0x8d, 0xd7, 0xc5, 0xce, 0x6e, 0xaf, 0xd9,
+	0x0f, 0xce, 0xa6, 0x30, 0xb6, 0xee, 0xfc, 0xf8, 0xe9, 0x5f, 0x32, 0xbe, 0xa3, 0x33, 0x38, 0x22,
+	0xeb, 0x8f, 0x4a, 0xe7, 0x48, 0x48, 0xd8, 0x93, 0x49, 0xf5, 0x94, 0xfe, 0x71, 0x7c, 0x3f, 0x31,
+	0xbe, 0x72, 0x39, 0xa3, 0xdf, 0xca, 0xdb, 0x3f, 0x03, 0x00, 0xc4, 0x13, 0x54, 0xf4, 0x66, 0x04,
+	0x00, 0x00,
+}
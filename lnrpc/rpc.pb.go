@@ -1,6 +1,5 @@
-// Code generated by protoc-gen-go.
+// Code generated by protoc-gen-go. DO NOT EDIT.
 // source: rpc.proto
-// DO NOT EDIT!
 
 /*
 Package lnrpc is a generated protocol buffer package.
@@ -11,26 +10,65 @@ It is generated from these files:
 It has these top-level messages:
 	SendRequest
 	SendResponse
+	PaymentFailure
 	ChannelPoint
 	LightningAddress
+	Transaction
+	GetTransactionsRequest
+	TransactionDetails
+	ImportPublicKeyRequest
+	ImportPublicKeyResponse
+	ImportAddressRequest
+	ImportAddressResponse
+	RescanRequest
+	RescanUpdate
+	GetRecoveryInfoRequest
+	GetRecoveryInfoResponse
+	OutPoint
+	LeaseOutputRequest
+	LeaseOutputResponse
+	ReleaseOutputRequest
+	ReleaseOutputResponse
+	ListLeasesRequest
+	OutputLease
+	ListLeasesResponse
+	LabelTransactionRequest
+	LabelTransactionResponse
 	SendManyRequest
 	SendManyResponse
 	SendCoinsRequest
 	SendCoinsResponse
+	ConsolidateUtxosRequest
+	ConsolidateUtxosResponse
 	NewAddressRequest
 	NewAddressResponse
+	ListAddressesRequest
+	WalletAddress
+	ListAddressesResponse
+	ListUnspentRequest
+	Utxo
+	ListUnspentResponse
 	ConnectPeerRequest
 	ConnectPeerResponse
 	HTLC
 	ActiveChannel
 	Peer
+	Feature
 	ListPeersRequest
 	ListPeersResponse
+	PeerEventSubscription
+	PeerEvent
+	ListChannelsRequest
+	ListChannelsResponse
 	GetInfoRequest
 	GetInfoResponse
+	UpdateConfigRequest
+	UpdateConfigResponse
 	ConfirmationUpdate
 	ChannelOpenUpdate
 	ChannelCloseUpdate
+	BumpFeeRequest
+	BumpFeeResponse
 	CloseChannelRequest
 	CloseStatusUpdate
 	PendingUpdate
@@ -38,6 +76,7 @@ It has these top-level messages:
 	OpenStatusUpdate
 	PendingChannelRequest
 	PendingChannelResponse
+	ContractResolution
 	WalletBalanceRequest
 	WalletBalanceResponse
 	ChannelBalanceRequest
@@ -45,6 +84,51 @@ It has these top-level messages:
 	RoutingTableLink
 	ShowRoutingTableRequest
 	ShowRoutingTableResponse
+	DropGraphRequest
+	DropGraphResponse
+	ExportGraphSnapshotRequest
+	ExportGraphSnapshotResponse
+	ImportGraphSnapshotRequest
+	ImportGraphSnapshotResponse
+	QueryRoutesRequest
+	Hop
+	Route
+	QueryRoutesResponse
+	TrackPaymentRequest
+	HTLCAttempt
+	PaymentUpdate
+	SubscribeHtlcEventsRequest
+	HtlcEvent
+	NodeInfoRequest
+	LightningNode
+	NodeInfo
+	NodeMetricsRequest
+	FloatMetric
+	NodeMetricsResponse
+	RoutingPolicy
+	ChanInfoRequest
+	ChannelEdge
+	ChannelIDRequest
+	ChannelIDResponse
+	FeeReportRequest
+	ChannelFeeReport
+	FeeReportResponse
+	ListMacaroonIDsRequest
+	ListMacaroonIDsResponse
+	DeleteMacaroonIDRequest
+	DeleteMacaroonIDResponse
+	RotateMacaroonRootKeyRequest
+	RotateMacaroonRootKeyResponse
+	BakeMacaroonRequest
+	BakeMacaroonResponse
+	SendOnionMessageRequest
+	SendOnionMessageResponse
+	SubscribeOnionMessagesRequest
+	OnionMessageUpdate
+	SendCustomMessageRequest
+	SendCustomMessageResponse
+	SubscribeCustomMessagesRequest
+	CustomMessage
 */
 package lnrpc
 
@@ -68,6 +152,45 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type FailureCode int32
+
+const (
+	FailureCode_NONE                      FailureCode = 0
+	FailureCode_TEMPORARY_CHANNEL_FAILURE FailureCode = 4103
+	FailureCode_FEE_INSUFFICIENT          FailureCode = 4108
+	FailureCode_TEMPORARY_NODE_FAILURE    FailureCode = 8194
+	FailureCode_UNKNOWN_NEXT_PEER         FailureCode = 16394
+	FailureCode_INCORRECT_PAYMENT_DETAILS FailureCode = 16399
+	FailureCode_FINAL_EXPIRY_TOO_SOON     FailureCode = 17
+	FailureCode_EXPIRY_TOO_FAR            FailureCode = 21
+)
+
+var FailureCode_name = map[int32]string{
+	0:     "NONE",
+	4103:  "TEMPORARY_CHANNEL_FAILURE",
+	4108:  "FEE_INSUFFICIENT",
+	8194:  "TEMPORARY_NODE_FAILURE",
+	16394: "UNKNOWN_NEXT_PEER",
+	16399: "INCORRECT_PAYMENT_DETAILS",
+	17:    "FINAL_EXPIRY_TOO_SOON",
+	21:    "EXPIRY_TOO_FAR",
+}
+var FailureCode_value = map[string]int32{
+	"NONE":                      0,
+	"TEMPORARY_CHANNEL_FAILURE": 4103,
+	"FEE_INSUFFICIENT":          4108,
+	"TEMPORARY_NODE_FAILURE":    8194,
+	"UNKNOWN_NEXT_PEER":         16394,
+	"INCORRECT_PAYMENT_DETAILS": 16399,
+	"FINAL_EXPIRY_TOO_SOON":     17,
+	"EXPIRY_TOO_FAR":            21,
+}
+
+func (x FailureCode) String() string {
+	return proto.EnumName(FailureCode_name, int32(x))
+}
+func (FailureCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type ChannelStatus int32
 
 const (
@@ -90,7 +213,34 @@ var ChannelStatus_value = map[string]int32{
 func (x ChannelStatus) String() string {
 	return proto.EnumName(ChannelStatus_name, int32(x))
 }
-func (ChannelStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (ChannelStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type PaymentStatus int32
+
+const (
+	PaymentStatus_UNKNOWN   PaymentStatus = 0
+	PaymentStatus_IN_FLIGHT PaymentStatus = 1
+	PaymentStatus_SUCCEEDED PaymentStatus = 2
+	PaymentStatus_FAILED    PaymentStatus = 3
+)
+
+var PaymentStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "IN_FLIGHT",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var PaymentStatus_value = map[string]int32{
+	"UNKNOWN":   0,
+	"IN_FLIGHT": 1,
+	"SUCCEEDED": 2,
+	"FAILED":    3,
+}
+
+func (x PaymentStatus) String() string {
+	return proto.EnumName(PaymentStatus_name, int32(x))
+}
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 type NewAddressRequest_AddressType int32
 
@@ -115,14 +265,78 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{8, 0}
+	return fileDescriptor0, []int{32, 0}
+}
+
+type PeerEvent_EventType int32
+
+const (
+	PeerEvent_PEER_ONLINE  PeerEvent_EventType = 0
+	PeerEvent_PEER_OFFLINE PeerEvent_EventType = 1
+)
+
+var PeerEvent_EventType_name = map[int32]string{
+	0: "PEER_ONLINE",
+	1: "PEER_OFFLINE",
+}
+var PeerEvent_EventType_value = map[string]int32{
+	"PEER_ONLINE":  0,
+	"PEER_OFFLINE": 1,
+}
+
+func (x PeerEvent_EventType) String() string {
+	return proto.EnumName(PeerEvent_EventType_name, int32(x))
+}
+func (PeerEvent_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{49, 0} }
+
+type HtlcEvent_EventType int32
+
+const (
+	HtlcEvent_FORWARD      HtlcEvent_EventType = 0
+	HtlcEvent_SETTLE       HtlcEvent_EventType = 1
+	HtlcEvent_FORWARD_FAIL HtlcEvent_EventType = 2
+	HtlcEvent_LINK_FAIL    HtlcEvent_EventType = 3
+)
+
+var HtlcEvent_EventType_name = map[int32]string{
+	0: "FORWARD",
+	1: "SETTLE",
+	2: "FORWARD_FAIL",
+	3: "LINK_FAIL",
+}
+var HtlcEvent_EventType_value = map[string]int32{
+	"FORWARD":      0,
+	"SETTLE":       1,
+	"FORWARD_FAIL": 2,
+	"LINK_FAIL":    3,
+}
+
+func (x HtlcEvent_EventType) String() string {
+	return proto.EnumName(HtlcEvent_EventType_name, int32(x))
 }
+func (HtlcEvent_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{90, 0} }
 
 type SendRequest struct {
-	Dest        []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
-	Amt         int64  `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
-	PaymentHash []byte `protobuf:"bytes,3,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
-	FastSend    bool   `protobuf:"varint,4,opt,name=fast_send,json=fastSend" json:"fast_send,omitempty"`
+	Dest           []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	Amt            int64  `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	PaymentHash    []byte `protobuf:"bytes,3,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	FastSend       bool   `protobuf:"varint,4,opt,name=fast_send,json=fastSend" json:"fast_send,omitempty"`
+	FeeLimit       int64  `protobuf:"varint,5,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	TimeoutSeconds int64  `protobuf:"varint,6,opt,name=timeout_seconds,json=timeoutSeconds" json:"timeout_seconds,omitempty"`
+	FinalCltvDelta uint32 `protobuf:"varint,7,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	PaymentAddr    []byte `protobuf:"bytes,8,opt,name=payment_addr,json=paymentAddr,proto3" json:"payment_addr,omitempty"`
+	// Custom records to present to the destination within the payload of
+	// the final hop, keyed by their type, which must be at least 65536.
+	DestCustomRecords map[uint64][]byte `protobuf:"bytes,9,rep,name=dest_custom_records,json=destCustomRecords" json:"dest_custom_records,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If non-zero, the maximum number of blocks the payment may be time
+	// locked for, below the node's configured maximum.
+	CltvLimit uint32 `protobuf:"varint,10,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// If set, the payment may only depart over one of these channels,
+	// identified by their compact short channel IDs.
+	OutgoingChanIds []uint64 `protobuf:"varint,11,rep,packed,name=outgoing_chan_ids,json=outgoingChanIds" json:"outgoing_chan_ids,omitempty"`
+	// If set, the public key or lightning ID of the node the payment must
+	// pass through immediately before reaching the destination.
+	LastHopPubkey []byte `protobuf:"bytes,12,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -130,7 +344,93 @@ func (m *SendRequest) String() string            { return proto.CompactTextStrin
 func (*SendRequest) ProtoMessage()               {}
 func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+func (m *SendRequest) GetDest() []byte {
+	if m != nil {
+		return m.Dest
+	}
+	return nil
+}
+
+func (m *SendRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *SendRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendRequest) GetFastSend() bool {
+	if m != nil {
+		return m.FastSend
+	}
+	return false
+}
+
+func (m *SendRequest) GetFeeLimit() int64 {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetTimeoutSeconds() int64 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+func (m *SendRequest) GetFinalCltvDelta() uint32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *SendRequest) GetPaymentAddr() []byte {
+	if m != nil {
+		return m.PaymentAddr
+	}
+	return nil
+}
+
+func (m *SendRequest) GetDestCustomRecords() map[uint64][]byte {
+	if m != nil {
+		return m.DestCustomRecords
+	}
+	return nil
+}
+
+func (m *SendRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetOutgoingChanIds() []uint64 {
+	if m != nil {
+		return m.OutgoingChanIds
+	}
+	return nil
+}
+
+func (m *SendRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
 type SendResponse struct {
+	// TODO(roasbeef): info about route? stats?
+	Failure *PaymentFailure `protobuf:"bytes,1,opt,name=failure" json:"failure,omitempty"`
 }
 
 func (m *SendResponse) Reset()                    { *m = SendResponse{} }
@@ -138,6 +438,45 @@ func (m *SendResponse) String() string            { return proto.CompactTextStri
 func (*SendResponse) ProtoMessage()               {}
 func (*SendResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *SendResponse) GetFailure() *PaymentFailure {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+type PaymentFailure struct {
+	SourceIndex uint32      `protobuf:"varint,1,opt,name=source_index,json=sourceIndex" json:"source_index,omitempty"`
+	Code        FailureCode `protobuf:"varint,2,opt,name=code,enum=lnrpc.FailureCode" json:"code,omitempty"`
+	Amount      int64       `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *PaymentFailure) Reset()                    { *m = PaymentFailure{} }
+func (m *PaymentFailure) String() string            { return proto.CompactTextString(m) }
+func (*PaymentFailure) ProtoMessage()               {}
+func (*PaymentFailure) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *PaymentFailure) GetSourceIndex() uint32 {
+	if m != nil {
+		return m.SourceIndex
+	}
+	return 0
+}
+
+func (m *PaymentFailure) GetCode() FailureCode {
+	if m != nil {
+		return m.Code
+	}
+	return FailureCode_NONE
+}
+
+func (m *PaymentFailure) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
 type ChannelPoint struct {
 	FundingTxid []byte `protobuf:"bytes,1,opt,name=funding_txid,json=fundingTxid,proto3" json:"funding_txid,omitempty"`
 	OutputIndex uint32 `protobuf:"varint,2,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
@@ -146,7 +485,21 @@ type ChannelPoint struct {
 func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
 func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
 func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ChannelPoint) GetFundingTxid() []byte {
+	if m != nil {
+		return m.FundingTxid
+	}
+	return nil
+}
+
+func (m *ChannelPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
+	}
+	return 0
+}
 
 type LightningAddress struct {
 	PubKeyHash string `protobuf:"bytes,1,opt,name=pubKeyHash" json:"pubKeyHash,omitempty"`
@@ -156,1229 +509,5738 @@ type LightningAddress struct {
 func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
 func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
 func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
-type SendManyRequest struct {
-	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount,json=addrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+func (m *LightningAddress) GetPubKeyHash() string {
+	if m != nil {
+		return m.PubKeyHash
+	}
+	return ""
 }
 
-func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
-func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
-func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
-
-func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
+func (m *LightningAddress) GetHost() string {
 	if m != nil {
-		return m.AddrToAmount
+		return m.Host
 	}
-	return nil
+	return ""
 }
 
-type SendManyResponse struct {
-	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+type Transaction struct {
+	TxHash           string `protobuf:"bytes,1,opt,name=tx_hash,json=txHash" json:"tx_hash,omitempty"`
+	Amount           int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	NumConfirmations int32  `protobuf:"varint,3,opt,name=num_confirmations,json=numConfirmations" json:"num_confirmations,omitempty"`
+	BlockHash        string `protobuf:"bytes,4,opt,name=block_hash,json=blockHash" json:"block_hash,omitempty"`
+	BlockHeight      int32  `protobuf:"varint,5,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
+	TimeStamp        int64  `protobuf:"varint,6,opt,name=time_stamp,json=timeStamp" json:"time_stamp,omitempty"`
+	TotalFees        int64  `protobuf:"varint,7,opt,name=total_fees,json=totalFees" json:"total_fees,omitempty"`
+	Label            string `protobuf:"bytes,8,opt,name=label" json:"label,omitempty"`
+	WatchOnly        bool   `protobuf:"varint,9,opt,name=watch_only,json=watchOnly" json:"watch_only,omitempty"`
 }
 
-func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
-func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *Transaction) Reset()                    { *m = Transaction{} }
+func (m *Transaction) String() string            { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()               {}
+func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-type SendCoinsRequest struct {
-	Addr   string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
-	Amount int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+func (m *Transaction) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
 }
 
-func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
-func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
-func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
-
-type SendCoinsResponse struct {
-	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+func (m *Transaction) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
 }
 
-func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
-func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
-
-type NewAddressRequest struct {
-	Type NewAddressRequest_AddressType `protobuf:"varint,1,opt,name=type,enum=lnrpc.NewAddressRequest_AddressType" json:"type,omitempty"`
+func (m *Transaction) GetNumConfirmations() int32 {
+	if m != nil {
+		return m.NumConfirmations
+	}
+	return 0
 }
 
-func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
-func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
-func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
-
-type NewAddressResponse struct {
-	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+func (m *Transaction) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
 }
 
-func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
-func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
-func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
-
-type ConnectPeerRequest struct {
-	Addr *LightningAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+func (m *Transaction) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
 }
 
-func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
-func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
-func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (m *Transaction) GetTimeStamp() int64 {
+	if m != nil {
+		return m.TimeStamp
+	}
+	return 0
+}
 
-func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
+func (m *Transaction) GetTotalFees() int64 {
 	if m != nil {
-		return m.Addr
+		return m.TotalFees
 	}
-	return nil
+	return 0
 }
 
-type ConnectPeerResponse struct {
-	PeerId int32 `protobuf:"varint,1,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+func (m *Transaction) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
 }
 
-func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
-func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
-func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (m *Transaction) GetWatchOnly() bool {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return false
+}
 
-type HTLC struct {
-	Id       int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Amount   int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
-	HashLock []byte `protobuf:"bytes,3,opt,name=hash_lock,json=hashLock,proto3" json:"hash_lock,omitempty"`
-	ToUs     bool   `protobuf:"varint,4,opt,name=to_us,json=toUs" json:"to_us,omitempty"`
+type GetTransactionsRequest struct {
 }
 
-func (m *HTLC) Reset()                    { *m = HTLC{} }
-func (m *HTLC) String() string            { return proto.CompactTextString(m) }
-func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
+func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetTransactionsRequest) ProtoMessage()               {}
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-type ActiveChannel struct {
-	// TODO(roasbeef): make channel points a string everywhere in rpc?
-	RemoteId         string  `protobuf:"bytes,1,opt,name=remote_id,json=remoteId" json:"remote_id,omitempty"`
-	ChannelPoint     string  `protobuf:"bytes,2,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
-	Capacity         int64   `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
-	LocalBalance     int64   `protobuf:"varint,4,opt,name=local_balance,json=localBalance" json:"local_balance,omitempty"`
-	RemoteBalance    int64   `protobuf:"varint,5,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
-	UnsettledBelance int64   `protobuf:"varint,6,opt,name=unsettled_belance,json=unsettledBelance" json:"unsettled_belance,omitempty"`
-	PendingHtlcs     []*HTLC `protobuf:"bytes,7,rep,name=pending_htlcs,json=pendingHtlcs" json:"pending_htlcs,omitempty"`
-	NumUpdates       uint64  `protobuf:"varint,8,opt,name=num_updates,json=numUpdates" json:"num_updates,omitempty"`
+type TransactionDetails struct {
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
 }
 
-func (m *ActiveChannel) Reset()                    { *m = ActiveChannel{} }
-func (m *ActiveChannel) String() string            { return proto.CompactTextString(m) }
-func (*ActiveChannel) ProtoMessage()               {}
-func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
+func (m *TransactionDetails) String() string            { return proto.CompactTextString(m) }
+func (*TransactionDetails) ProtoMessage()               {}
+func (*TransactionDetails) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
-func (m *ActiveChannel) GetPendingHtlcs() []*HTLC {
+func (m *TransactionDetails) GetTransactions() []*Transaction {
 	if m != nil {
-		return m.PendingHtlcs
+		return m.Transactions
 	}
 	return nil
 }
 
-type Peer struct {
-	LightningId string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
-	PeerId      int32  `protobuf:"varint,2,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
-	Address     string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
-	BytesSent   uint64 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent" json:"bytes_sent,omitempty"`
-	BytesRecv   uint64 `protobuf:"varint,5,opt,name=bytes_recv,json=bytesRecv" json:"bytes_recv,omitempty"`
-	SatSent     int64  `protobuf:"varint,6,opt,name=sat_sent,json=satSent" json:"sat_sent,omitempty"`
-	SatRecv     int64  `protobuf:"varint,7,opt,name=sat_recv,json=satRecv" json:"sat_recv,omitempty"`
-	Inbound     bool   `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
-	// TODO(roasbeef): add pending channels
-	Channels []*ActiveChannel `protobuf:"bytes,9,rep,name=channels" json:"channels,omitempty"`
+type ImportPublicKeyRequest struct {
+	PublicKey   []byte                        `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	AddressType NewAddressRequest_AddressType `protobuf:"varint,2,opt,name=address_type,json=addressType,enum=lnrpc.NewAddressRequest_AddressType" json:"address_type,omitempty"`
 }
 
-func (m *Peer) Reset()                    { *m = Peer{} }
-func (m *Peer) String() string            { return proto.CompactTextString(m) }
-func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (m *ImportPublicKeyRequest) Reset()                    { *m = ImportPublicKeyRequest{} }
+func (m *ImportPublicKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportPublicKeyRequest) ProtoMessage()               {}
+func (*ImportPublicKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-func (m *Peer) GetChannels() []*ActiveChannel {
+func (m *ImportPublicKeyRequest) GetPublicKey() []byte {
 	if m != nil {
-		return m.Channels
+		return m.PublicKey
 	}
 	return nil
 }
 
-type ListPeersRequest struct {
+func (m *ImportPublicKeyRequest) GetAddressType() NewAddressRequest_AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return NewAddressRequest_WITNESS_PUBKEY_HASH
 }
 
-func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
-func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
-
-type ListPeersResponse struct {
-	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+type ImportPublicKeyResponse struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
 }
 
-func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
-func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (m *ImportPublicKeyResponse) Reset()                    { *m = ImportPublicKeyResponse{} }
+func (m *ImportPublicKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportPublicKeyResponse) ProtoMessage()               {}
+func (*ImportPublicKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
-func (m *ListPeersResponse) GetPeers() []*Peer {
+func (m *ImportPublicKeyResponse) GetAddress() string {
 	if m != nil {
-		return m.Peers
+		return m.Address
 	}
-	return nil
+	return ""
 }
 
-type GetInfoRequest struct {
+type ImportAddressRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
 }
 
-func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
-func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (m *ImportAddressRequest) Reset()                    { *m = ImportAddressRequest{} }
+func (m *ImportAddressRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportAddressRequest) ProtoMessage()               {}
+func (*ImportAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
-type GetInfoResponse struct {
-	LightningId        string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
-	IdentityAddress    string `protobuf:"bytes,2,opt,name=identity_address,json=identityAddress" json:"identity_address,omitempty"`
-	NumPendingChannels uint32 `protobuf:"varint,3,opt,name=num_pending_channels,json=numPendingChannels" json:"num_pending_channels,omitempty"`
-	NumActiveChannels  uint32 `protobuf:"varint,4,opt,name=num_active_channels,json=numActiveChannels" json:"num_active_channels,omitempty"`
-	NumPeers           uint32 `protobuf:"varint,5,opt,name=num_peers,json=numPeers" json:"num_peers,omitempty"`
+func (m *ImportAddressRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
 }
 
-func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
-func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
-
-type ConfirmationUpdate struct {
-	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,json=blockSha,proto3" json:"block_sha,omitempty"`
-	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
-	NumConfsLeft uint32 `protobuf:"varint,3,opt,name=num_confs_left,json=numConfsLeft" json:"num_confs_left,omitempty"`
+type ImportAddressResponse struct {
 }
 
-func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
-func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
-func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (m *ImportAddressResponse) Reset()                    { *m = ImportAddressResponse{} }
+func (m *ImportAddressResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportAddressResponse) ProtoMessage()               {}
+func (*ImportAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
-type ChannelOpenUpdate struct {
-	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+// RescanRequest specifies where a wallet rescan should begin: either at an
+// explicit block height, or at the first block mined around the wallet's
+// birthday, as a unix timestamp.
+type RescanRequest struct {
+	StartHeight       int32 `protobuf:"varint,1,opt,name=start_height,json=startHeight" json:"start_height,omitempty"`
+	BirthdayTimestamp int64 `protobuf:"varint,2,opt,name=birthday_timestamp,json=birthdayTimestamp" json:"birthday_timestamp,omitempty"`
 }
 
-func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
-func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
-func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (m *RescanRequest) Reset()                    { *m = RescanRequest{} }
+func (m *RescanRequest) String() string            { return proto.CompactTextString(m) }
+func (*RescanRequest) ProtoMessage()               {}
+func (*RescanRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
-func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
+func (m *RescanRequest) GetStartHeight() int32 {
 	if m != nil {
-		return m.ChannelPoint
+		return m.StartHeight
 	}
-	return nil
+	return 0
 }
 
-type ChannelCloseUpdate struct {
-	ClosingTxid []byte `protobuf:"bytes,1,opt,name=closing_txid,json=closingTxid,proto3" json:"closing_txid,omitempty"`
-	Success     bool   `protobuf:"varint,2,opt,name=success" json:"success,omitempty"`
+func (m *RescanRequest) GetBirthdayTimestamp() int64 {
+	if m != nil {
+		return m.BirthdayTimestamp
+	}
+	return 0
 }
 
-func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
-func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
-func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+type RescanUpdate struct {
+	StartHeight   int32 `protobuf:"varint,1,opt,name=start_height,json=startHeight" json:"start_height,omitempty"`
+	ScannedHeight int32 `protobuf:"varint,2,opt,name=scanned_height,json=scannedHeight" json:"scanned_height,omitempty"`
+	TargetHeight  int32 `protobuf:"varint,3,opt,name=target_height,json=targetHeight" json:"target_height,omitempty"`
+	Complete      bool  `protobuf:"varint,4,opt,name=complete" json:"complete,omitempty"`
+}
 
-type CloseChannelRequest struct {
-	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
-	TimeLimit    int64         `protobuf:"varint,2,opt,name=time_limit,json=timeLimit" json:"time_limit,omitempty"`
-	Force        bool          `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+func (m *RescanUpdate) Reset()                    { *m = RescanUpdate{} }
+func (m *RescanUpdate) String() string            { return proto.CompactTextString(m) }
+func (*RescanUpdate) ProtoMessage()               {}
+func (*RescanUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *RescanUpdate) GetStartHeight() int32 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
 }
 
-func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
-func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
-func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (m *RescanUpdate) GetScannedHeight() int32 {
+	if m != nil {
+		return m.ScannedHeight
+	}
+	return 0
+}
 
-func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
+func (m *RescanUpdate) GetTargetHeight() int32 {
 	if m != nil {
-		return m.ChannelPoint
+		return m.TargetHeight
 	}
-	return nil
+	return 0
 }
 
-type CloseStatusUpdate struct {
-	// Types that are valid to be assigned to Update:
-	//	*CloseStatusUpdate_ClosePending
-	//	*CloseStatusUpdate_Confirmation
-	//	*CloseStatusUpdate_ChanClose
-	Update isCloseStatusUpdate_Update `protobuf_oneof:"update"`
+func (m *RescanUpdate) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
 }
 
-func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
-func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
-func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+type GetRecoveryInfoRequest struct {
+}
 
-type isCloseStatusUpdate_Update interface {
-	isCloseStatusUpdate_Update()
+func (m *GetRecoveryInfoRequest) Reset()                    { *m = GetRecoveryInfoRequest{} }
+func (m *GetRecoveryInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoRequest) ProtoMessage()               {}
+func (*GetRecoveryInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+type GetRecoveryInfoResponse struct {
+	// recovery_mode is true if the wallet was restored from an existing
+	// seed when lnd was started.
+	RecoveryMode bool `protobuf:"varint,1,opt,name=recovery_mode,json=recoveryMode" json:"recovery_mode,omitempty"`
+	// recovery_finished is true once the chain has been scanned for the
+	// funds of the restored wallet.
+	RecoveryFinished bool `protobuf:"varint,2,opt,name=recovery_finished,json=recoveryFinished" json:"recovery_finished,omitempty"`
+	// progress is the fraction of the chain scanned so far, between 0 and
+	// 1.
+	Progress float64 `protobuf:"fixed64,3,opt,name=progress" json:"progress,omitempty"`
 }
 
-type CloseStatusUpdate_ClosePending struct {
-	ClosePending *PendingUpdate `protobuf:"bytes,1,opt,name=close_pending,json=closePending,oneof"`
+func (m *GetRecoveryInfoResponse) Reset()                    { *m = GetRecoveryInfoResponse{} }
+func (m *GetRecoveryInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetRecoveryInfoResponse) ProtoMessage()               {}
+func (*GetRecoveryInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *GetRecoveryInfoResponse) GetRecoveryMode() bool {
+	if m != nil {
+		return m.RecoveryMode
+	}
+	return false
 }
-type CloseStatusUpdate_Confirmation struct {
-	Confirmation *ConfirmationUpdate `protobuf:"bytes,2,opt,name=confirmation,oneof"`
+
+func (m *GetRecoveryInfoResponse) GetRecoveryFinished() bool {
+	if m != nil {
+		return m.RecoveryFinished
+	}
+	return false
 }
-type CloseStatusUpdate_ChanClose struct {
-	ChanClose *ChannelCloseUpdate `protobuf:"bytes,3,opt,name=chan_close,json=chanClose,oneof"`
+
+func (m *GetRecoveryInfoResponse) GetProgress() float64 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
 }
 
-func (*CloseStatusUpdate_ClosePending) isCloseStatusUpdate_Update() {}
-func (*CloseStatusUpdate_Confirmation) isCloseStatusUpdate_Update() {}
-func (*CloseStatusUpdate_ChanClose) isCloseStatusUpdate_Update()    {}
+type OutPoint struct {
+	Txid        string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	OutputIndex uint32 `protobuf:"varint,2,opt,name=output_index,json=outputIndex" json:"output_index,omitempty"`
+}
 
-func (m *CloseStatusUpdate) GetUpdate() isCloseStatusUpdate_Update {
+func (m *OutPoint) Reset()                    { *m = OutPoint{} }
+func (m *OutPoint) String() string            { return proto.CompactTextString(m) }
+func (*OutPoint) ProtoMessage()               {}
+func (*OutPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *OutPoint) GetTxid() string {
 	if m != nil {
-		return m.Update
+		return m.Txid
 	}
-	return nil
+	return ""
 }
 
-func (m *CloseStatusUpdate) GetClosePending() *PendingUpdate {
-	if x, ok := m.GetUpdate().(*CloseStatusUpdate_ClosePending); ok {
-		return x.ClosePending
+func (m *OutPoint) GetOutputIndex() uint32 {
+	if m != nil {
+		return m.OutputIndex
 	}
-	return nil
+	return 0
 }
 
-func (m *CloseStatusUpdate) GetConfirmation() *ConfirmationUpdate {
-	if x, ok := m.GetUpdate().(*CloseStatusUpdate_Confirmation); ok {
-		return x.Confirmation
+type LeaseOutputRequest struct {
+	Id                []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint          *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	ExpirationSeconds uint64    `protobuf:"varint,3,opt,name=expiration_seconds,json=expirationSeconds" json:"expiration_seconds,omitempty"`
+}
+
+func (m *LeaseOutputRequest) Reset()                    { *m = LeaseOutputRequest{} }
+func (m *LeaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputRequest) ProtoMessage()               {}
+func (*LeaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *LeaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
 	return nil
 }
 
-func (m *CloseStatusUpdate) GetChanClose() *ChannelCloseUpdate {
-	if x, ok := m.GetUpdate().(*CloseStatusUpdate_ChanClose); ok {
-		return x.ChanClose
+func (m *LeaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
 	}
 	return nil
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*CloseStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _CloseStatusUpdate_OneofMarshaler, _CloseStatusUpdate_OneofUnmarshaler, _CloseStatusUpdate_OneofSizer, []interface{}{
-		(*CloseStatusUpdate_ClosePending)(nil),
-		(*CloseStatusUpdate_Confirmation)(nil),
-		(*CloseStatusUpdate_ChanClose)(nil),
+func (m *LeaseOutputRequest) GetExpirationSeconds() uint64 {
+	if m != nil {
+		return m.ExpirationSeconds
 	}
+	return 0
 }
 
-func _CloseStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*CloseStatusUpdate)
-	// update
-	switch x := m.Update.(type) {
-	case *CloseStatusUpdate_ClosePending:
-		b.EncodeVarint(1<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.ClosePending); err != nil {
-			return err
-		}
-	case *CloseStatusUpdate_Confirmation:
-		b.EncodeVarint(2<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Confirmation); err != nil {
-			return err
-		}
-	case *CloseStatusUpdate_ChanClose:
-		b.EncodeVarint(3<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.ChanClose); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("CloseStatusUpdate.Update has unexpected type %T", x)
+type LeaseOutputResponse struct {
+	Expiration int64 `protobuf:"varint,1,opt,name=expiration" json:"expiration,omitempty"`
+}
+
+func (m *LeaseOutputResponse) Reset()                    { *m = LeaseOutputResponse{} }
+func (m *LeaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseOutputResponse) ProtoMessage()               {}
+func (*LeaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *LeaseOutputResponse) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
 	}
-	return nil
+	return 0
 }
 
-func _CloseStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*CloseStatusUpdate)
-	switch tag {
-	case 1: // update.close_pending
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(PendingUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &CloseStatusUpdate_ClosePending{msg}
-		return true, err
-	case 2: // update.confirmation
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ConfirmationUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &CloseStatusUpdate_Confirmation{msg}
-		return true, err
-	case 3: // update.chan_close
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ChannelCloseUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &CloseStatusUpdate_ChanClose{msg}
-		return true, err
-	default:
-		return false, nil
+type ReleaseOutputRequest struct {
+	Id       []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+}
+
+func (m *ReleaseOutputRequest) Reset()                    { *m = ReleaseOutputRequest{} }
+func (m *ReleaseOutputRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputRequest) ProtoMessage()               {}
+func (*ReleaseOutputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ReleaseOutputRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
+	return nil
 }
 
-func _CloseStatusUpdate_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*CloseStatusUpdate)
-	// update
-	switch x := m.Update.(type) {
-	case *CloseStatusUpdate_ClosePending:
-		s := proto.Size(x.ClosePending)
-		n += proto.SizeVarint(1<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *CloseStatusUpdate_Confirmation:
-		s := proto.Size(x.Confirmation)
-		n += proto.SizeVarint(2<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *CloseStatusUpdate_ChanClose:
-		s := proto.Size(x.ChanClose)
-		n += proto.SizeVarint(3<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+func (m *ReleaseOutputRequest) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
 	}
-	return n
+	return nil
 }
 
-type PendingUpdate struct {
-	Txid []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+type ReleaseOutputResponse struct {
 }
 
-func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
-func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
-func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (m *ReleaseOutputResponse) Reset()                    { *m = ReleaseOutputResponse{} }
+func (m *ReleaseOutputResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseOutputResponse) ProtoMessage()               {}
+func (*ReleaseOutputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
-type OpenChannelRequest struct {
-	TargetPeerId        int32  `protobuf:"varint,1,opt,name=target_peer_id,json=targetPeerId" json:"target_peer_id,omitempty"`
-	TargetNode          []byte `protobuf:"bytes,2,opt,name=target_node,json=targetNode,proto3" json:"target_node,omitempty"`
-	LocalFundingAmount  int64  `protobuf:"varint,3,opt,name=local_funding_amount,json=localFundingAmount" json:"local_funding_amount,omitempty"`
-	RemoteFundingAmount int64  `protobuf:"varint,4,opt,name=remote_funding_amount,json=remoteFundingAmount" json:"remote_funding_amount,omitempty"`
-	CommissionSize      int64  `protobuf:"varint,5,opt,name=commission_size,json=commissionSize" json:"commission_size,omitempty"`
-	NumConfs            uint32 `protobuf:"varint,6,opt,name=num_confs,json=numConfs" json:"num_confs,omitempty"`
+type ListLeasesRequest struct {
 }
 
-func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
-func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
-func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (m *ListLeasesRequest) Reset()                    { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()               {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
-type OpenStatusUpdate struct {
-	// Types that are valid to be assigned to Update:
-	//	*OpenStatusUpdate_ChanPending
-	//	*OpenStatusUpdate_Confirmation
-	//	*OpenStatusUpdate_ChanOpen
-	Update isOpenStatusUpdate_Update `protobuf_oneof:"update"`
+type OutputLease struct {
+	Id         []byte    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Outpoint   *OutPoint `protobuf:"bytes,2,opt,name=outpoint" json:"outpoint,omitempty"`
+	Expiration int64     `protobuf:"varint,3,opt,name=expiration" json:"expiration,omitempty"`
 }
 
-func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
-func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
-func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (m *OutputLease) Reset()                    { *m = OutputLease{} }
+func (m *OutputLease) String() string            { return proto.CompactTextString(m) }
+func (*OutputLease) ProtoMessage()               {}
+func (*OutputLease) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
-type isOpenStatusUpdate_Update interface {
-	isOpenStatusUpdate_Update()
+func (m *OutputLease) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
 }
 
-type OpenStatusUpdate_ChanPending struct {
-	ChanPending *PendingUpdate `protobuf:"bytes,1,opt,name=chan_pending,json=chanPending,oneof"`
+func (m *OutputLease) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
 }
-type OpenStatusUpdate_Confirmation struct {
-	Confirmation *ConfirmationUpdate `protobuf:"bytes,2,opt,name=confirmation,oneof"`
+
+func (m *OutputLease) GetExpiration() int64 {
+	if m != nil {
+		return m.Expiration
+	}
+	return 0
 }
-type OpenStatusUpdate_ChanOpen struct {
-	ChanOpen *ChannelOpenUpdate `protobuf:"bytes,3,opt,name=chan_open,json=chanOpen,oneof"`
+
+type ListLeasesResponse struct {
+	Leases []*OutputLease `protobuf:"bytes,1,rep,name=leases" json:"leases,omitempty"`
 }
 
-func (*OpenStatusUpdate_ChanPending) isOpenStatusUpdate_Update()  {}
-func (*OpenStatusUpdate_Confirmation) isOpenStatusUpdate_Update() {}
-func (*OpenStatusUpdate_ChanOpen) isOpenStatusUpdate_Update()     {}
+func (m *ListLeasesResponse) Reset()                    { *m = ListLeasesResponse{} }
+func (m *ListLeasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesResponse) ProtoMessage()               {}
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
-func (m *OpenStatusUpdate) GetUpdate() isOpenStatusUpdate_Update {
+func (m *ListLeasesResponse) GetLeases() []*OutputLease {
 	if m != nil {
-		return m.Update
+		return m.Leases
 	}
 	return nil
 }
 
-func (m *OpenStatusUpdate) GetChanPending() *PendingUpdate {
-	if x, ok := m.GetUpdate().(*OpenStatusUpdate_ChanPending); ok {
-		return x.ChanPending
-	}
-	return nil
+type LabelTransactionRequest struct {
+	Txid      string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	Label     string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	Overwrite bool   `protobuf:"varint,3,opt,name=overwrite" json:"overwrite,omitempty"`
 }
 
-func (m *OpenStatusUpdate) GetConfirmation() *ConfirmationUpdate {
-	if x, ok := m.GetUpdate().(*OpenStatusUpdate_Confirmation); ok {
-		return x.Confirmation
+func (m *LabelTransactionRequest) Reset()                    { *m = LabelTransactionRequest{} }
+func (m *LabelTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionRequest) ProtoMessage()               {}
+func (*LabelTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *LabelTransactionRequest) GetTxid() string {
+	if m != nil {
+		return m.Txid
 	}
-	return nil
+	return ""
 }
 
-func (m *OpenStatusUpdate) GetChanOpen() *ChannelOpenUpdate {
-	if x, ok := m.GetUpdate().(*OpenStatusUpdate_ChanOpen); ok {
-		return x.ChanOpen
+func (m *LabelTransactionRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
 	}
-	return nil
+	return ""
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*OpenStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _OpenStatusUpdate_OneofMarshaler, _OpenStatusUpdate_OneofUnmarshaler, _OpenStatusUpdate_OneofSizer, []interface{}{
-		(*OpenStatusUpdate_ChanPending)(nil),
-		(*OpenStatusUpdate_Confirmation)(nil),
-		(*OpenStatusUpdate_ChanOpen)(nil),
+func (m *LabelTransactionRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
 	}
+	return false
 }
 
-func _OpenStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*OpenStatusUpdate)
-	// update
-	switch x := m.Update.(type) {
-	case *OpenStatusUpdate_ChanPending:
-		b.EncodeVarint(1<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.ChanPending); err != nil {
-			return err
-		}
-	case *OpenStatusUpdate_Confirmation:
-		b.EncodeVarint(2<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.Confirmation); err != nil {
-			return err
-		}
-	case *OpenStatusUpdate_ChanOpen:
-		b.EncodeVarint(3<<3 | proto.WireBytes)
-		if err := b.EncodeMessage(x.ChanOpen); err != nil {
-			return err
-		}
-	case nil:
-	default:
-		return fmt.Errorf("OpenStatusUpdate.Update has unexpected type %T", x)
+type LabelTransactionResponse struct {
+}
+
+func (m *LabelTransactionResponse) Reset()                    { *m = LabelTransactionResponse{} }
+func (m *LabelTransactionResponse) String() string            { return proto.CompactTextString(m) }
+func (*LabelTransactionResponse) ProtoMessage()               {}
+func (*LabelTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+type SendManyRequest struct {
+	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount,json=addrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	SatPerByte   int64            `protobuf:"varint,2,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
+func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendManyRequest) ProtoMessage()               {}
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
+	if m != nil {
+		return m.AddrToAmount
 	}
 	return nil
 }
 
-func _OpenStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*OpenStatusUpdate)
-	switch tag {
-	case 1: // update.chan_pending
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(PendingUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &OpenStatusUpdate_ChanPending{msg}
-		return true, err
-	case 2: // update.confirmation
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ConfirmationUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &OpenStatusUpdate_Confirmation{msg}
-		return true, err
-	case 3: // update.chan_open
-		if wire != proto.WireBytes {
-			return true, proto.ErrInternalBadWireType
-		}
-		msg := new(ChannelOpenUpdate)
-		err := b.DecodeMessage(msg)
-		m.Update = &OpenStatusUpdate_ChanOpen{msg}
-		return true, err
-	default:
-		return false, nil
+func (m *SendManyRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
 	}
+	return 0
 }
 
-func _OpenStatusUpdate_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*OpenStatusUpdate)
-	// update
-	switch x := m.Update.(type) {
-	case *OpenStatusUpdate_ChanPending:
-		s := proto.Size(x.ChanPending)
-		n += proto.SizeVarint(1<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *OpenStatusUpdate_Confirmation:
-		s := proto.Size(x.Confirmation)
-		n += proto.SizeVarint(2<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case *OpenStatusUpdate_ChanOpen:
-		s := proto.Size(x.ChanOpen)
-		n += proto.SizeVarint(3<<3 | proto.WireBytes)
-		n += proto.SizeVarint(uint64(s))
-		n += s
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+type SendManyResponse struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
+func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendManyResponse) ProtoMessage()               {}
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *SendManyResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
 	}
-	return n
+	return ""
 }
 
-type PendingChannelRequest struct {
-	Status ChannelStatus `protobuf:"varint,1,opt,name=status,enum=lnrpc.ChannelStatus" json:"status,omitempty"`
+type SendCoinsRequest struct {
+	Addr       string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Amount     int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	SatPerByte int64  `protobuf:"varint,3,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	// send_all sweeps all of the wallet's confirmed funds to addr, less
+	// the fee, in which case amount must not be set.
+	SendAll bool `protobuf:"varint,4,opt,name=send_all,json=sendAll" json:"send_all,omitempty"`
 }
 
-func (m *PendingChannelRequest) Reset()                    { *m = PendingChannelRequest{} }
-func (m *PendingChannelRequest) String() string            { return proto.CompactTextString(m) }
-func (*PendingChannelRequest) ProtoMessage()               {}
-func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
+func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendCoinsRequest) ProtoMessage()               {}
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-type PendingChannelResponse struct {
-	PendingChannels []*PendingChannelResponse_PendingChannel `protobuf:"bytes,1,rep,name=pending_channels,json=pendingChannels" json:"pending_channels,omitempty"`
+func (m *SendCoinsRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
 }
 
-func (m *PendingChannelResponse) Reset()                    { *m = PendingChannelResponse{} }
-func (m *PendingChannelResponse) String() string            { return proto.CompactTextString(m) }
-func (*PendingChannelResponse) ProtoMessage()               {}
-func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
-
-func (m *PendingChannelResponse) GetPendingChannels() []*PendingChannelResponse_PendingChannel {
+func (m *SendCoinsRequest) GetAmount() int64 {
 	if m != nil {
-		return m.PendingChannels
+		return m.Amount
 	}
-	return nil
+	return 0
 }
 
-type PendingChannelResponse_PendingChannel struct {
-	PeerId        int32         `protobuf:"varint,1,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
-	LightningId   string        `protobuf:"bytes,2,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
-	ChannelPoint  string        `protobuf:"bytes,3,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
-	Capacity      int64         `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
-	LocalBalance  int64         `protobuf:"varint,5,opt,name=local_balance,json=localBalance" json:"local_balance,omitempty"`
-	RemoteBalance int64         `protobuf:"varint,6,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
-	ClosingTxid   string        `protobuf:"bytes,7,opt,name=closing_txid,json=closingTxid" json:"closing_txid,omitempty"`
-	Status        ChannelStatus `protobuf:"varint,8,opt,name=status,enum=lnrpc.ChannelStatus" json:"status,omitempty"`
+func (m *SendCoinsRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
 }
 
-func (m *PendingChannelResponse_PendingChannel) Reset()         { *m = PendingChannelResponse_PendingChannel{} }
-func (m *PendingChannelResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
-func (*PendingChannelResponse_PendingChannel) ProtoMessage()    {}
-func (*PendingChannelResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{28, 0}
+func (m *SendCoinsRequest) GetSendAll() bool {
+	if m != nil {
+		return m.SendAll
+	}
+	return false
 }
 
-type WalletBalanceRequest struct {
-	WitnessOnly bool `protobuf:"varint,1,opt,name=witness_only,json=witnessOnly" json:"witness_only,omitempty"`
+type SendCoinsResponse struct {
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
 }
 
-func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
-func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
-func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
+func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendCoinsResponse) ProtoMessage()               {}
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-type WalletBalanceResponse struct {
-	Balance float64 `protobuf:"fixed64,1,opt,name=balance" json:"balance,omitempty"`
+func (m *SendCoinsResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
 }
 
-func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
-func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
-func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+type ConsolidateUtxosRequest struct {
+	MaxUtxoValue int64  `protobuf:"varint,1,opt,name=max_utxo_value,json=maxUtxoValue" json:"max_utxo_value,omitempty"`
+	SatPerByte   int64  `protobuf:"varint,2,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+	MinUtxos     uint32 `protobuf:"varint,3,opt,name=min_utxos,json=minUtxos" json:"min_utxos,omitempty"`
+}
 
-type ChannelBalanceRequest struct {
+func (m *ConsolidateUtxosRequest) Reset()                    { *m = ConsolidateUtxosRequest{} }
+func (m *ConsolidateUtxosRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConsolidateUtxosRequest) ProtoMessage()               {}
+func (*ConsolidateUtxosRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *ConsolidateUtxosRequest) GetMaxUtxoValue() int64 {
+	if m != nil {
+		return m.MaxUtxoValue
+	}
+	return 0
 }
 
-func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
-func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
-func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (m *ConsolidateUtxosRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
 
-type ChannelBalanceResponse struct {
-	Balance int64 `protobuf:"varint,1,opt,name=balance" json:"balance,omitempty"`
+func (m *ConsolidateUtxosRequest) GetMinUtxos() uint32 {
+	if m != nil {
+		return m.MinUtxos
+	}
+	return 0
 }
 
-func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
-func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
-func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+type ConsolidateUtxosResponse struct {
+	Txid            string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+	NumConsolidated uint32 `protobuf:"varint,2,opt,name=num_consolidated,json=numConsolidated" json:"num_consolidated,omitempty"`
+}
 
-type RoutingTableLink struct {
-	Id1      string  `protobuf:"bytes,1,opt,name=id1" json:"id1,omitempty"`
-	Id2      string  `protobuf:"bytes,2,opt,name=id2" json:"id2,omitempty"`
-	Outpoint string  `protobuf:"bytes,3,opt,name=outpoint" json:"outpoint,omitempty"`
-	Capacity int64   `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
-	Weight   float64 `protobuf:"fixed64,5,opt,name=weight" json:"weight,omitempty"`
+func (m *ConsolidateUtxosResponse) Reset()                    { *m = ConsolidateUtxosResponse{} }
+func (m *ConsolidateUtxosResponse) String() string            { return proto.CompactTextString(m) }
+func (*ConsolidateUtxosResponse) ProtoMessage()               {}
+func (*ConsolidateUtxosResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ConsolidateUtxosResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
 }
 
-func (m *RoutingTableLink) Reset()                    { *m = RoutingTableLink{} }
-func (m *RoutingTableLink) String() string            { return proto.CompactTextString(m) }
-func (*RoutingTableLink) ProtoMessage()               {}
-func (*RoutingTableLink) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (m *ConsolidateUtxosResponse) GetNumConsolidated() uint32 {
+	if m != nil {
+		return m.NumConsolidated
+	}
+	return 0
+}
 
-type ShowRoutingTableRequest struct {
+type NewAddressRequest struct {
+	Type NewAddressRequest_AddressType `protobuf:"varint,1,opt,name=type,enum=lnrpc.NewAddressRequest_AddressType" json:"type,omitempty"`
 }
 
-func (m *ShowRoutingTableRequest) Reset()                    { *m = ShowRoutingTableRequest{} }
-func (m *ShowRoutingTableRequest) String() string            { return proto.CompactTextString(m) }
-func (*ShowRoutingTableRequest) ProtoMessage()               {}
-func (*ShowRoutingTableRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
+func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
+func (*NewAddressRequest) ProtoMessage()               {}
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
-type ShowRoutingTableResponse struct {
-	Channels []*RoutingTableLink `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
+	if m != nil {
+		return m.Type
+	}
+	return NewAddressRequest_WITNESS_PUBKEY_HASH
 }
 
-func (m *ShowRoutingTableResponse) Reset()                    { *m = ShowRoutingTableResponse{} }
-func (m *ShowRoutingTableResponse) String() string            { return proto.CompactTextString(m) }
-func (*ShowRoutingTableResponse) ProtoMessage()               {}
-func (*ShowRoutingTableResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+type NewAddressResponse struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+}
 
-func (m *ShowRoutingTableResponse) GetChannels() []*RoutingTableLink {
+func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
+func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
+func (*NewAddressResponse) ProtoMessage()               {}
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
-		return m.Channels
+		return m.Address
 	}
-	return nil
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
-	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
-	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
-	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
-	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
-	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
-	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
-	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
-	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
-	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
-	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
-	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
-	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
-	proto.RegisterType((*ActiveChannel)(nil), "lnrpc.ActiveChannel")
-	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
-	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
-	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
-	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
-	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
-	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
-	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
-	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
-	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
-	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
-	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
-	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
-	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
-	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
-	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
-	proto.RegisterType((*PendingChannelResponse_PendingChannel)(nil), "lnrpc.PendingChannelResponse.PendingChannel")
-	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
-	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
-	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
-	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
-	proto.RegisterType((*RoutingTableLink)(nil), "lnrpc.RoutingTableLink")
-	proto.RegisterType((*ShowRoutingTableRequest)(nil), "lnrpc.ShowRoutingTableRequest")
-	proto.RegisterType((*ShowRoutingTableResponse)(nil), "lnrpc.ShowRoutingTableResponse")
-	proto.RegisterEnum("lnrpc.ChannelStatus", ChannelStatus_name, ChannelStatus_value)
-	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+type ListAddressesRequest struct {
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ListAddressesRequest) Reset()                    { *m = ListAddressesRequest{} }
+func (m *ListAddressesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesRequest) ProtoMessage()               {}
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion3
+type WalletAddress struct {
+	Address string                        `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Type    NewAddressRequest_AddressType `protobuf:"varint,2,opt,name=type,enum=lnrpc.NewAddressRequest_AddressType" json:"type,omitempty"`
+	Change  bool                          `protobuf:"varint,3,opt,name=change" json:"change,omitempty"`
+	// balance is the total value in satoshis of the unspent outputs
+	// paying to the address.
+	Balance int64 `protobuf:"varint,4,opt,name=balance" json:"balance,omitempty"`
+}
 
-// Client API for Lightning service
+func (m *WalletAddress) Reset()                    { *m = WalletAddress{} }
+func (m *WalletAddress) String() string            { return proto.CompactTextString(m) }
+func (*WalletAddress) ProtoMessage()               {}
+func (*WalletAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
-type LightningClient interface {
-	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
-	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
-	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
-	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
-	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
-	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+func (m *WalletAddress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *WalletAddress) GetType() NewAddressRequest_AddressType {
+	if m != nil {
+		return m.Type
+	}
+	return NewAddressRequest_WITNESS_PUBKEY_HASH
+}
+
+func (m *WalletAddress) GetChange() bool {
+	if m != nil {
+		return m.Change
+	}
+	return false
+}
+
+func (m *WalletAddress) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+type ListAddressesResponse struct {
+	Addresses []*WalletAddress `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *ListAddressesResponse) Reset()                    { *m = ListAddressesResponse{} }
+func (m *ListAddressesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesResponse) ProtoMessage()               {}
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *ListAddressesResponse) GetAddresses() []*WalletAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// ListUnspentRequest lists the wallet's unspent outputs with at least
+// min_confs confirmations, and at most max_confs. A max_confs of zero places
+// no upper bound on the confirmations of the listed outputs, while a
+// min_confs of zero includes unconfirmed outputs.
+type ListUnspentRequest struct {
+	MinConfs int32 `protobuf:"varint,1,opt,name=min_confs,json=minConfs" json:"min_confs,omitempty"`
+	MaxConfs int32 `protobuf:"varint,2,opt,name=max_confs,json=maxConfs" json:"max_confs,omitempty"`
+}
+
+func (m *ListUnspentRequest) Reset()                    { *m = ListUnspentRequest{} }
+func (m *ListUnspentRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentRequest) ProtoMessage()               {}
+func (*ListUnspentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ListUnspentRequest) GetMinConfs() int32 {
+	if m != nil {
+		return m.MinConfs
+	}
+	return 0
+}
+
+func (m *ListUnspentRequest) GetMaxConfs() int32 {
+	if m != nil {
+		return m.MaxConfs
+	}
+	return 0
+}
+
+type Utxo struct {
+	Outpoint      *OutPoint `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	Address       string    `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Amount        int64     `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	PkScript      string    `protobuf:"bytes,4,opt,name=pk_script,json=pkScript" json:"pk_script,omitempty"`
+	Confirmations int64     `protobuf:"varint,5,opt,name=confirmations" json:"confirmations,omitempty"`
+}
+
+func (m *Utxo) Reset()                    { *m = Utxo{} }
+func (m *Utxo) String() string            { return proto.CompactTextString(m) }
+func (*Utxo) ProtoMessage()               {}
+func (*Utxo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *Utxo) GetOutpoint() *OutPoint {
+	if m != nil {
+		return m.Outpoint
+	}
+	return nil
+}
+
+func (m *Utxo) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Utxo) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Utxo) GetPkScript() string {
+	if m != nil {
+		return m.PkScript
+	}
+	return ""
+}
+
+func (m *Utxo) GetConfirmations() int64 {
+	if m != nil {
+		return m.Confirmations
+	}
+	return 0
+}
+
+type ListUnspentResponse struct {
+	Utxos []*Utxo `protobuf:"bytes,1,rep,name=utxos" json:"utxos,omitempty"`
+}
+
+func (m *ListUnspentResponse) Reset()                    { *m = ListUnspentResponse{} }
+func (m *ListUnspentResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUnspentResponse) ProtoMessage()               {}
+func (*ListUnspentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ListUnspentResponse) GetUtxos() []*Utxo {
+	if m != nil {
+		return m.Utxos
+	}
+	return nil
+}
+
+type ConnectPeerRequest struct {
+	Addr *LightningAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+}
+
+func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
+func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
+func (*ConnectPeerRequest) ProtoMessage()               {}
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+type ConnectPeerResponse struct {
+	PeerId int32 `protobuf:"varint,1,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+}
+
+func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
+func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
+func (*ConnectPeerResponse) ProtoMessage()               {}
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ConnectPeerResponse) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+type HTLC struct {
+	Id               int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Amount           int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+	HashLock         []byte `protobuf:"bytes,3,opt,name=hash_lock,json=hashLock,proto3" json:"hash_lock,omitempty"`
+	ToUs             bool   `protobuf:"varint,4,opt,name=to_us,json=toUs" json:"to_us,omitempty"`
+	ExpirationHeight uint32 `protobuf:"varint,5,opt,name=expiration_height,json=expirationHeight" json:"expiration_height,omitempty"`
+}
+
+func (m *HTLC) Reset()                    { *m = HTLC{} }
+func (m *HTLC) String() string            { return proto.CompactTextString(m) }
+func (*HTLC) ProtoMessage()               {}
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *HTLC) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *HTLC) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *HTLC) GetHashLock() []byte {
+	if m != nil {
+		return m.HashLock
+	}
+	return nil
+}
+
+func (m *HTLC) GetToUs() bool {
+	if m != nil {
+		return m.ToUs
+	}
+	return false
+}
+
+func (m *HTLC) GetExpirationHeight() uint32 {
+	if m != nil {
+		return m.ExpirationHeight
+	}
+	return 0
+}
+
+type ActiveChannel struct {
+	// TODO(roasbeef): make channel points a string everywhere in rpc?
+	RemoteId              string  `protobuf:"bytes,1,opt,name=remote_id,json=remoteId" json:"remote_id,omitempty"`
+	ChannelPoint          string  `protobuf:"bytes,2,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	Capacity              int64   `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
+	LocalBalance          int64   `protobuf:"varint,4,opt,name=local_balance,json=localBalance" json:"local_balance,omitempty"`
+	RemoteBalance         int64   `protobuf:"varint,5,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
+	UnsettledBelance      int64   `protobuf:"varint,6,opt,name=unsettled_belance,json=unsettledBelance" json:"unsettled_belance,omitempty"`
+	PendingHtlcs          []*HTLC `protobuf:"bytes,7,rep,name=pending_htlcs,json=pendingHtlcs" json:"pending_htlcs,omitempty"`
+	NumUpdates            uint64  `protobuf:"varint,8,opt,name=num_updates,json=numUpdates" json:"num_updates,omitempty"`
+	Active                bool    `protobuf:"varint,9,opt,name=active" json:"active,omitempty"`
+	Private               bool    `protobuf:"varint,10,opt,name=private" json:"private,omitempty"`
+	CsvDelay              uint32  `protobuf:"varint,11,opt,name=csv_delay,json=csvDelay" json:"csv_delay,omitempty"`
+	CommitFee             int64   `protobuf:"varint,12,opt,name=commit_fee,json=commitFee" json:"commit_fee,omitempty"`
+	TotalSatoshisSent     int64   `protobuf:"varint,13,opt,name=total_satoshis_sent,json=totalSatoshisSent" json:"total_satoshis_sent,omitempty"`
+	TotalSatoshisReceived int64   `protobuf:"varint,14,opt,name=total_satoshis_received,json=totalSatoshisReceived" json:"total_satoshis_received,omitempty"`
+	// uptime is the number of seconds the remote peer has been online
+	// while the channel has been open, while lifetime is the number of
+	// seconds since the channel was opened. Comparing the two allows
+	// chronically offline peers to be identified.
+	Uptime   int64 `protobuf:"varint,15,opt,name=uptime" json:"uptime,omitempty"`
+	Lifetime int64 `protobuf:"varint,16,opt,name=lifetime" json:"lifetime,omitempty"`
+	// lease_expiry is the block height until which the funds of the
+	// channel initiator are locked, or zero if the channel isn't leased.
+	LeaseExpiry uint32 `protobuf:"varint,17,opt,name=lease_expiry,json=leaseExpiry" json:"lease_expiry,omitempty"`
+	// max_pending_amt is the maximum total value in satoshis of HTLC's in
+	// flight in either direction within the channel.
+	MaxPendingAmt int64 `protobuf:"varint,18,opt,name=max_pending_amt,json=maxPendingAmt" json:"max_pending_amt,omitempty"`
+	// max_accepted_htlcs is the maximum number of HTLC's in flight in
+	// either direction within the channel.
+	MaxAcceptedHtlcs uint32 `protobuf:"varint,19,opt,name=max_accepted_htlcs,json=maxAcceptedHtlcs" json:"max_accepted_htlcs,omitempty"`
+	// chan_id is the compact short channel ID of the channel, or zero if
+	// the funding transaction hasn't yet been confirmed.
+	ChanId uint64 `protobuf:"varint,20,opt,name=chan_id,json=chanId" json:"chan_id,omitempty"`
+}
+
+func (m *ActiveChannel) Reset()                    { *m = ActiveChannel{} }
+func (m *ActiveChannel) String() string            { return proto.CompactTextString(m) }
+func (*ActiveChannel) ProtoMessage()               {}
+func (*ActiveChannel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ActiveChannel) GetRemoteId() string {
+	if m != nil {
+		return m.RemoteId
+	}
+	return ""
+}
+
+func (m *ActiveChannel) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ActiveChannel) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetUnsettledBelance() int64 {
+	if m != nil {
+		return m.UnsettledBelance
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetPendingHtlcs() []*HTLC {
+	if m != nil {
+		return m.PendingHtlcs
+	}
+	return nil
+}
+
+func (m *ActiveChannel) GetNumUpdates() uint64 {
+	if m != nil {
+		return m.NumUpdates
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *ActiveChannel) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetCommitFee() int64 {
+	if m != nil {
+		return m.CommitFee
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetTotalSatoshisSent() int64 {
+	if m != nil {
+		return m.TotalSatoshisSent
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetTotalSatoshisReceived() int64 {
+	if m != nil {
+		return m.TotalSatoshisReceived
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetUptime() int64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetLifetime() int64 {
+	if m != nil {
+		return m.Lifetime
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetLeaseExpiry() uint32 {
+	if m != nil {
+		return m.LeaseExpiry
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetMaxPendingAmt() int64 {
+	if m != nil {
+		return m.MaxPendingAmt
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetMaxAcceptedHtlcs() uint32 {
+	if m != nil {
+		return m.MaxAcceptedHtlcs
+	}
+	return 0
+}
+
+func (m *ActiveChannel) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+type Peer struct {
+	LightningId string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	PeerId      int32  `protobuf:"varint,2,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+	Address     string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	BytesSent   uint64 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent" json:"bytes_sent,omitempty"`
+	BytesRecv   uint64 `protobuf:"varint,5,opt,name=bytes_recv,json=bytesRecv" json:"bytes_recv,omitempty"`
+	SatSent     int64  `protobuf:"varint,6,opt,name=sat_sent,json=satSent" json:"sat_sent,omitempty"`
+	SatRecv     int64  `protobuf:"varint,7,opt,name=sat_recv,json=satRecv" json:"sat_recv,omitempty"`
+	Inbound     bool   `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
+	// TODO(roasbeef): add pending channels
+	Channels []*ActiveChannel `protobuf:"bytes,9,rep,name=channels" json:"channels,omitempty"`
+	Features []*Feature       `protobuf:"bytes,10,rep,name=features" json:"features,omitempty"`
+	// stalled_reconnects is the number of times a channel with the peer
+	// stalled mid commitment update, forcing the peer to be reconnected.
+	StalledReconnects uint32 `protobuf:"varint,11,opt,name=stalled_reconnects,json=stalledReconnects" json:"stalled_reconnects,omitempty"`
+}
+
+func (m *Peer) Reset()                    { *m = Peer{} }
+func (m *Peer) String() string            { return proto.CompactTextString(m) }
+func (*Peer) ProtoMessage()               {}
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *Peer) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *Peer) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+func (m *Peer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Peer) GetBytesSent() uint64 {
+	if m != nil {
+		return m.BytesSent
+	}
+	return 0
+}
+
+func (m *Peer) GetBytesRecv() uint64 {
+	if m != nil {
+		return m.BytesRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetSatSent() int64 {
+	if m != nil {
+		return m.SatSent
+	}
+	return 0
+}
+
+func (m *Peer) GetSatRecv() int64 {
+	if m != nil {
+		return m.SatRecv
+	}
+	return 0
+}
+
+func (m *Peer) GetInbound() bool {
+	if m != nil {
+		return m.Inbound
+	}
+	return false
+}
+
+func (m *Peer) GetChannels() []*ActiveChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Peer) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *Peer) GetStalledReconnects() uint32 {
+	if m != nil {
+		return m.StalledReconnects
+	}
+	return 0
+}
+
+type Feature struct {
+	Bit        uint32 `protobuf:"varint,1,opt,name=bit" json:"bit,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	IsRequired bool   `protobuf:"varint,3,opt,name=is_required,json=isRequired" json:"is_required,omitempty"`
+	IsKnown    bool   `protobuf:"varint,4,opt,name=is_known,json=isKnown" json:"is_known,omitempty"`
+}
+
+func (m *Feature) Reset()                    { *m = Feature{} }
+func (m *Feature) String() string            { return proto.CompactTextString(m) }
+func (*Feature) ProtoMessage()               {}
+func (*Feature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *Feature) GetBit() uint32 {
+	if m != nil {
+		return m.Bit
+	}
+	return 0
+}
+
+func (m *Feature) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Feature) GetIsRequired() bool {
+	if m != nil {
+		return m.IsRequired
+	}
+	return false
+}
+
+func (m *Feature) GetIsKnown() bool {
+	if m != nil {
+		return m.IsKnown
+	}
+	return false
+}
+
+type ListPeersRequest struct {
+}
+
+func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
+func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersRequest) ProtoMessage()               {}
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+type ListPeersResponse struct {
+	Peers []*Peer `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
+func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPeersResponse) ProtoMessage()               {}
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *ListPeersResponse) GetPeers() []*Peer {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+type PeerEventSubscription struct {
+}
+
+func (m *PeerEventSubscription) Reset()                    { *m = PeerEventSubscription{} }
+func (m *PeerEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*PeerEventSubscription) ProtoMessage()               {}
+func (*PeerEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+type PeerEvent struct {
+	Type        PeerEvent_EventType `protobuf:"varint,1,opt,name=type,enum=lnrpc.PeerEvent_EventType" json:"type,omitempty"`
+	LightningId string              `protobuf:"bytes,2,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	PeerId      int32               `protobuf:"varint,3,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+	Address     string              `protobuf:"bytes,4,opt,name=address" json:"address,omitempty"`
+	Timestamp   int64               `protobuf:"varint,5,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *PeerEvent) Reset()                    { *m = PeerEvent{} }
+func (m *PeerEvent) String() string            { return proto.CompactTextString(m) }
+func (*PeerEvent) ProtoMessage()               {}
+func (*PeerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *PeerEvent) GetType() PeerEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return PeerEvent_PEER_ONLINE
+}
+
+func (m *PeerEvent) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+func (m *PeerEvent) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+type ListChannelsRequest struct {
+	ActiveOnly   bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly" json:"active_only,omitempty"`
+	InactiveOnly bool `protobuf:"varint,2,opt,name=inactive_only,json=inactiveOnly" json:"inactive_only,omitempty"`
+	PublicOnly   bool `protobuf:"varint,3,opt,name=public_only,json=publicOnly" json:"public_only,omitempty"`
+	PrivateOnly  bool `protobuf:"varint,4,opt,name=private_only,json=privateOnly" json:"private_only,omitempty"`
+}
+
+func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
+func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListChannelsRequest) ProtoMessage()               {}
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *ListChannelsRequest) GetActiveOnly() bool {
+	if m != nil {
+		return m.ActiveOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetInactiveOnly() bool {
+	if m != nil {
+		return m.InactiveOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetPublicOnly() bool {
+	if m != nil {
+		return m.PublicOnly
+	}
+	return false
+}
+
+func (m *ListChannelsRequest) GetPrivateOnly() bool {
+	if m != nil {
+		return m.PrivateOnly
+	}
+	return false
+}
+
+type ListChannelsResponse struct {
+	Channels []*ActiveChannel `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
+func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListChannelsResponse) ProtoMessage()               {}
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *ListChannelsResponse) GetChannels() []*ActiveChannel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type GetInfoRequest struct {
+}
+
+func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
+func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetInfoRequest) ProtoMessage()               {}
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type GetInfoResponse struct {
+	LightningId        string   `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	IdentityAddress    string   `protobuf:"bytes,2,opt,name=identity_address,json=identityAddress" json:"identity_address,omitempty"`
+	NumPendingChannels uint32   `protobuf:"varint,3,opt,name=num_pending_channels,json=numPendingChannels" json:"num_pending_channels,omitempty"`
+	NumActiveChannels  uint32   `protobuf:"varint,4,opt,name=num_active_channels,json=numActiveChannels" json:"num_active_channels,omitempty"`
+	NumPeers           uint32   `protobuf:"varint,5,opt,name=num_peers,json=numPeers" json:"num_peers,omitempty"`
+	IdentityPubkey     string   `protobuf:"bytes,6,opt,name=identity_pubkey,json=identityPubkey" json:"identity_pubkey,omitempty"`
+	Uris               []string `protobuf:"bytes,7,rep,name=uris" json:"uris,omitempty"`
+}
+
+func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
+func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetInfoResponse) ProtoMessage()               {}
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *GetInfoResponse) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetIdentityAddress() string {
+	if m != nil {
+		return m.IdentityAddress
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetNumPendingChannels() uint32 {
+	if m != nil {
+		return m.NumPendingChannels
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetNumActiveChannels() uint32 {
+	if m != nil {
+		return m.NumActiveChannels
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetNumPeers() uint32 {
+	if m != nil {
+		return m.NumPeers
+	}
+	return 0
+}
+
+func (m *GetInfoResponse) GetIdentityPubkey() string {
+	if m != nil {
+		return m.IdentityPubkey
+	}
+	return ""
+}
+
+func (m *GetInfoResponse) GetUris() []string {
+	if m != nil {
+		return m.Uris
+	}
+	return nil
+}
+
+type UpdateConfigRequest struct {
+}
+
+func (m *UpdateConfigRequest) Reset()                    { *m = UpdateConfigRequest{} }
+func (m *UpdateConfigRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateConfigRequest) ProtoMessage()               {}
+func (*UpdateConfigRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+// The reloadable options in effect once the configuration has been re-read.
+type UpdateConfigResponse struct {
+	DebugLevel   string   `protobuf:"bytes,1,opt,name=debug_level,json=debugLevel" json:"debug_level,omitempty"`
+	PeerPolicies []string `protobuf:"bytes,2,rep,name=peer_policies,json=peerPolicies" json:"peer_policies,omitempty"`
+	MinChanSize  int64    `protobuf:"varint,3,opt,name=min_chan_size,json=minChanSize" json:"min_chan_size,omitempty"`
+	MaxChanSize  int64    `protobuf:"varint,4,opt,name=max_chan_size,json=maxChanSize" json:"max_chan_size,omitempty"`
+}
+
+func (m *UpdateConfigResponse) Reset()                    { *m = UpdateConfigResponse{} }
+func (m *UpdateConfigResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateConfigResponse) ProtoMessage()               {}
+func (*UpdateConfigResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *UpdateConfigResponse) GetDebugLevel() string {
+	if m != nil {
+		return m.DebugLevel
+	}
+	return ""
+}
+
+func (m *UpdateConfigResponse) GetPeerPolicies() []string {
+	if m != nil {
+		return m.PeerPolicies
+	}
+	return nil
+}
+
+func (m *UpdateConfigResponse) GetMinChanSize() int64 {
+	if m != nil {
+		return m.MinChanSize
+	}
+	return 0
+}
+
+func (m *UpdateConfigResponse) GetMaxChanSize() int64 {
+	if m != nil {
+		return m.MaxChanSize
+	}
+	return 0
+}
+
+type ConfirmationUpdate struct {
+	BlockSha     []byte `protobuf:"bytes,1,opt,name=block_sha,json=blockSha,proto3" json:"block_sha,omitempty"`
+	BlockHeight  int32  `protobuf:"varint,2,opt,name=block_height,json=blockHeight" json:"block_height,omitempty"`
+	NumConfsLeft uint32 `protobuf:"varint,3,opt,name=num_confs_left,json=numConfsLeft" json:"num_confs_left,omitempty"`
+}
+
+func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
+func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ConfirmationUpdate) ProtoMessage()               {}
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *ConfirmationUpdate) GetBlockSha() []byte {
+	if m != nil {
+		return m.BlockSha
+	}
+	return nil
+}
+
+func (m *ConfirmationUpdate) GetBlockHeight() int32 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ConfirmationUpdate) GetNumConfsLeft() uint32 {
+	if m != nil {
+		return m.NumConfsLeft
+	}
+	return 0
+}
+
+type ChannelOpenUpdate struct {
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+}
+
+func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
+func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelOpenUpdate) ProtoMessage()               {}
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+type ChannelCloseUpdate struct {
+	ClosingTxid []byte `protobuf:"bytes,1,opt,name=closing_txid,json=closingTxid,proto3" json:"closing_txid,omitempty"`
+	Success     bool   `protobuf:"varint,2,opt,name=success" json:"success,omitempty"`
+}
+
+func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
+func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelCloseUpdate) ProtoMessage()               {}
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
+	if m != nil {
+		return m.ClosingTxid
+	}
+	return nil
+}
+
+func (m *ChannelCloseUpdate) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+type BumpFeeRequest struct {
+	// channel_point identifies the pending channel whose funding
+	// transaction should have its fee bumped.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	// sat_per_byte is the fee rate the funding transaction and the child
+	// transaction spending its change output should pay together.
+	SatPerByte int64 `protobuf:"varint,2,opt,name=sat_per_byte,json=satPerByte" json:"sat_per_byte,omitempty"`
+}
+
+func (m *BumpFeeRequest) Reset()                    { *m = BumpFeeRequest{} }
+func (m *BumpFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeRequest) ProtoMessage()               {}
+func (*BumpFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *BumpFeeRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *BumpFeeRequest) GetSatPerByte() int64 {
+	if m != nil {
+		return m.SatPerByte
+	}
+	return 0
+}
+
+type BumpFeeResponse struct {
+	// txid is the id of the child transaction.
+	Txid string `protobuf:"bytes,1,opt,name=txid" json:"txid,omitempty"`
+}
+
+func (m *BumpFeeResponse) Reset()                    { *m = BumpFeeResponse{} }
+func (m *BumpFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*BumpFeeResponse) ProtoMessage()               {}
+func (*BumpFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *BumpFeeResponse) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+type CloseChannelRequest struct {
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	TimeLimit    int64         `protobuf:"varint,2,opt,name=time_limit,json=timeLimit" json:"time_limit,omitempty"`
+	Force        bool          `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
+	// chan_id and scid may be used in place of channel_point to identify
+	// the channel by its short channel ID, either in compact form or as
+	// height:txindex:output.
+	ChanId uint64 `protobuf:"varint,4,opt,name=chan_id,json=chanId" json:"chan_id,omitempty"`
+	Scid   string `protobuf:"bytes,5,opt,name=scid" json:"scid,omitempty"`
+	// A force close is refused while the peer is online and no HTLCs are
+	// in flight, as the channel can then be closed cooperatively instead.
+	// Setting allow_online_peer overrides this check.
+	AllowOnlinePeer bool `protobuf:"varint,6,opt,name=allow_online_peer,json=allowOnlinePeer" json:"allow_online_peer,omitempty"`
+	// A force close is refused while HTLCs are in flight, as they must
+	// then be resolved on-chain. Setting allow_pending_htlcs acknowledges
+	// this and overrides the check.
+	AllowPendingHtlcs bool `protobuf:"varint,7,opt,name=allow_pending_htlcs,json=allowPendingHtlcs" json:"allow_pending_htlcs,omitempty"`
+	// delivery_address, if set, is the address our balance is delivered
+	// to upon a cooperative close, overriding the configured close
+	// address. It's refused if we committed to a different address when
+	// the channel was opened.
+	DeliveryAddress string `protobuf:"bytes,8,opt,name=delivery_address,json=deliveryAddress" json:"delivery_address,omitempty"`
+}
+
+func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
+func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloseChannelRequest) ProtoMessage()               {}
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+func (m *CloseChannelRequest) GetTimeLimit() int64 {
+	if m != nil {
+		return m.TimeLimit
+	}
+	return 0
+}
+
+func (m *CloseChannelRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *CloseChannelRequest) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *CloseChannelRequest) GetScid() string {
+	if m != nil {
+		return m.Scid
+	}
+	return ""
+}
+
+func (m *CloseChannelRequest) GetAllowOnlinePeer() bool {
+	if m != nil {
+		return m.AllowOnlinePeer
+	}
+	return false
+}
+
+func (m *CloseChannelRequest) GetAllowPendingHtlcs() bool {
+	if m != nil {
+		return m.AllowPendingHtlcs
+	}
+	return false
+}
+
+func (m *CloseChannelRequest) GetDeliveryAddress() string {
+	if m != nil {
+		return m.DeliveryAddress
+	}
+	return ""
+}
+
+type CloseStatusUpdate struct {
+	// Types that are valid to be assigned to Update:
+	//	*CloseStatusUpdate_ClosePending
+	//	*CloseStatusUpdate_Confirmation
+	//	*CloseStatusUpdate_ChanClose
+	Update isCloseStatusUpdate_Update `protobuf_oneof:"update"`
+}
+
+func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
+func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
+func (*CloseStatusUpdate) ProtoMessage()               {}
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+type isCloseStatusUpdate_Update interface {
+	isCloseStatusUpdate_Update()
+}
+
+type CloseStatusUpdate_ClosePending struct {
+	ClosePending *PendingUpdate `protobuf:"bytes,1,opt,name=close_pending,json=closePending,oneof"`
+}
+type CloseStatusUpdate_Confirmation struct {
+	Confirmation *ConfirmationUpdate `protobuf:"bytes,2,opt,name=confirmation,oneof"`
+}
+type CloseStatusUpdate_ChanClose struct {
+	ChanClose *ChannelCloseUpdate `protobuf:"bytes,3,opt,name=chan_close,json=chanClose,oneof"`
+}
+
+func (*CloseStatusUpdate_ClosePending) isCloseStatusUpdate_Update() {}
+func (*CloseStatusUpdate_Confirmation) isCloseStatusUpdate_Update() {}
+func (*CloseStatusUpdate_ChanClose) isCloseStatusUpdate_Update()    {}
+
+func (m *CloseStatusUpdate) GetUpdate() isCloseStatusUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (m *CloseStatusUpdate) GetClosePending() *PendingUpdate {
+	if x, ok := m.GetUpdate().(*CloseStatusUpdate_ClosePending); ok {
+		return x.ClosePending
+	}
+	return nil
+}
+
+func (m *CloseStatusUpdate) GetConfirmation() *ConfirmationUpdate {
+	if x, ok := m.GetUpdate().(*CloseStatusUpdate_Confirmation); ok {
+		return x.Confirmation
+	}
+	return nil
+}
+
+func (m *CloseStatusUpdate) GetChanClose() *ChannelCloseUpdate {
+	if x, ok := m.GetUpdate().(*CloseStatusUpdate_ChanClose); ok {
+		return x.ChanClose
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*CloseStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _CloseStatusUpdate_OneofMarshaler, _CloseStatusUpdate_OneofUnmarshaler, _CloseStatusUpdate_OneofSizer, []interface{}{
+		(*CloseStatusUpdate_ClosePending)(nil),
+		(*CloseStatusUpdate_Confirmation)(nil),
+		(*CloseStatusUpdate_ChanClose)(nil),
+	}
+}
+
+func _CloseStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*CloseStatusUpdate)
+	// update
+	switch x := m.Update.(type) {
+	case *CloseStatusUpdate_ClosePending:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ClosePending); err != nil {
+			return err
+		}
+	case *CloseStatusUpdate_Confirmation:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Confirmation); err != nil {
+			return err
+		}
+	case *CloseStatusUpdate_ChanClose:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanClose); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("CloseStatusUpdate.Update has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _CloseStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*CloseStatusUpdate)
+	switch tag {
+	case 1: // update.close_pending
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PendingUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &CloseStatusUpdate_ClosePending{msg}
+		return true, err
+	case 2: // update.confirmation
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfirmationUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &CloseStatusUpdate_Confirmation{msg}
+		return true, err
+	case 3: // update.chan_close
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelCloseUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &CloseStatusUpdate_ChanClose{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _CloseStatusUpdate_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*CloseStatusUpdate)
+	// update
+	switch x := m.Update.(type) {
+	case *CloseStatusUpdate_ClosePending:
+		s := proto.Size(x.ClosePending)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CloseStatusUpdate_Confirmation:
+		s := proto.Size(x.Confirmation)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *CloseStatusUpdate_ChanClose:
+		s := proto.Size(x.ChanClose)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type PendingUpdate struct {
+	Txid []byte `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// The number of blocks which must pass after the closing transaction
+	// confirms before our funds can be swept. It's zero for a cooperative
+	// close.
+	CsvDelay uint32 `protobuf:"varint,2,opt,name=csv_delay,json=csvDelay" json:"csv_delay,omitempty"`
+}
+
+func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
+func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
+func (*PendingUpdate) ProtoMessage()               {}
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *PendingUpdate) GetTxid() []byte {
+	if m != nil {
+		return m.Txid
+	}
+	return nil
+}
+
+func (m *PendingUpdate) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+type OpenChannelRequest struct {
+	TargetPeerId        int32  `protobuf:"varint,1,opt,name=target_peer_id,json=targetPeerId" json:"target_peer_id,omitempty"`
+	TargetNode          []byte `protobuf:"bytes,2,opt,name=target_node,json=targetNode,proto3" json:"target_node,omitempty"`
+	LocalFundingAmount  int64  `protobuf:"varint,3,opt,name=local_funding_amount,json=localFundingAmount" json:"local_funding_amount,omitempty"`
+	RemoteFundingAmount int64  `protobuf:"varint,4,opt,name=remote_funding_amount,json=remoteFundingAmount" json:"remote_funding_amount,omitempty"`
+	CommissionSize      int64  `protobuf:"varint,5,opt,name=commission_size,json=commissionSize" json:"commission_size,omitempty"`
+	NumConfs            uint32 `protobuf:"varint,6,opt,name=num_confs,json=numConfs" json:"num_confs,omitempty"`
+	Private             bool   `protobuf:"varint,7,opt,name=private" json:"private,omitempty"`
+	// lease_expiry, if non-zero, opens a leased channel. The funds we
+	// commit to the channel will be locked by the commitment scripts until
+	// this absolute block height, guaranteeing the remote peer the sold
+	// inbound liquidity for the duration of the lease.
+	LeaseExpiry uint32 `protobuf:"varint,8,opt,name=lease_expiry,json=leaseExpiry" json:"lease_expiry,omitempty"`
+	// policy, if set, is the forwarding policy advertised for the new
+	// channel, taking precedence over any per-peer or default policy. The
+	// last_update field is ignored.
+	Policy *RoutingPolicy `protobuf:"bytes,9,opt,name=policy" json:"policy,omitempty"`
+	// max_pending_amt, if non-zero, is the maximum total value in satoshis
+	// of HTLC's in flight in either direction within the new channel,
+	// overriding the configured default.
+	MaxPendingAmt int64 `protobuf:"varint,10,opt,name=max_pending_amt,json=maxPendingAmt" json:"max_pending_amt,omitempty"`
+	// max_accepted_htlcs, if non-zero, is the maximum number of HTLC's in
+	// flight in either direction within the new channel, overriding the
+	// configured default.
+	MaxAcceptedHtlcs uint32 `protobuf:"varint,11,opt,name=max_accepted_htlcs,json=maxAcceptedHtlcs" json:"max_accepted_htlcs,omitempty"`
+	// close_address, if set, is the address our balance is delivered to
+	// upon a cooperative close of the channel, overriding the configured
+	// close address. We commit to the address as an upfront shutdown
+	// script, so it can't be changed when the channel is closed.
+	CloseAddress string `protobuf:"bytes,12,opt,name=close_address,json=closeAddress" json:"close_address,omitempty"`
+}
+
+func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
+func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*OpenChannelRequest) ProtoMessage()               {}
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *OpenChannelRequest) GetTargetPeerId() int32 {
+	if m != nil {
+		return m.TargetPeerId
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetTargetNode() []byte {
+	if m != nil {
+		return m.TargetNode
+	}
+	return nil
+}
+
+func (m *OpenChannelRequest) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetRemoteFundingAmount() int64 {
+	if m != nil {
+		return m.RemoteFundingAmount
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetCommissionSize() int64 {
+	if m != nil {
+		return m.CommissionSize
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetNumConfs() uint32 {
+	if m != nil {
+		return m.NumConfs
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *OpenChannelRequest) GetLeaseExpiry() uint32 {
+	if m != nil {
+		return m.LeaseExpiry
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetPolicy() *RoutingPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *OpenChannelRequest) GetMaxPendingAmt() int64 {
+	if m != nil {
+		return m.MaxPendingAmt
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetMaxAcceptedHtlcs() uint32 {
+	if m != nil {
+		return m.MaxAcceptedHtlcs
+	}
+	return 0
+}
+
+func (m *OpenChannelRequest) GetCloseAddress() string {
+	if m != nil {
+		return m.CloseAddress
+	}
+	return ""
+}
+
+type OpenStatusUpdate struct {
+	// Types that are valid to be assigned to Update:
+	//	*OpenStatusUpdate_ChanPending
+	//	*OpenStatusUpdate_Confirmation
+	//	*OpenStatusUpdate_ChanOpen
+	Update isOpenStatusUpdate_Update `protobuf_oneof:"update"`
+}
+
+func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
+func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
+func (*OpenStatusUpdate) ProtoMessage()               {}
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+type isOpenStatusUpdate_Update interface {
+	isOpenStatusUpdate_Update()
+}
+
+type OpenStatusUpdate_ChanPending struct {
+	ChanPending *PendingUpdate `protobuf:"bytes,1,opt,name=chan_pending,json=chanPending,oneof"`
+}
+type OpenStatusUpdate_Confirmation struct {
+	Confirmation *ConfirmationUpdate `protobuf:"bytes,2,opt,name=confirmation,oneof"`
+}
+type OpenStatusUpdate_ChanOpen struct {
+	ChanOpen *ChannelOpenUpdate `protobuf:"bytes,3,opt,name=chan_open,json=chanOpen,oneof"`
+}
+
+func (*OpenStatusUpdate_ChanPending) isOpenStatusUpdate_Update()  {}
+func (*OpenStatusUpdate_Confirmation) isOpenStatusUpdate_Update() {}
+func (*OpenStatusUpdate_ChanOpen) isOpenStatusUpdate_Update()     {}
+
+func (m *OpenStatusUpdate) GetUpdate() isOpenStatusUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (m *OpenStatusUpdate) GetChanPending() *PendingUpdate {
+	if x, ok := m.GetUpdate().(*OpenStatusUpdate_ChanPending); ok {
+		return x.ChanPending
+	}
+	return nil
+}
+
+func (m *OpenStatusUpdate) GetConfirmation() *ConfirmationUpdate {
+	if x, ok := m.GetUpdate().(*OpenStatusUpdate_Confirmation); ok {
+		return x.Confirmation
+	}
+	return nil
+}
+
+func (m *OpenStatusUpdate) GetChanOpen() *ChannelOpenUpdate {
+	if x, ok := m.GetUpdate().(*OpenStatusUpdate_ChanOpen); ok {
+		return x.ChanOpen
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*OpenStatusUpdate) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _OpenStatusUpdate_OneofMarshaler, _OpenStatusUpdate_OneofUnmarshaler, _OpenStatusUpdate_OneofSizer, []interface{}{
+		(*OpenStatusUpdate_ChanPending)(nil),
+		(*OpenStatusUpdate_Confirmation)(nil),
+		(*OpenStatusUpdate_ChanOpen)(nil),
+	}
+}
+
+func _OpenStatusUpdate_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*OpenStatusUpdate)
+	// update
+	switch x := m.Update.(type) {
+	case *OpenStatusUpdate_ChanPending:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanPending); err != nil {
+			return err
+		}
+	case *OpenStatusUpdate_Confirmation:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Confirmation); err != nil {
+			return err
+		}
+	case *OpenStatusUpdate_ChanOpen:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChanOpen); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("OpenStatusUpdate.Update has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _OpenStatusUpdate_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*OpenStatusUpdate)
+	switch tag {
+	case 1: // update.chan_pending
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PendingUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &OpenStatusUpdate_ChanPending{msg}
+		return true, err
+	case 2: // update.confirmation
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ConfirmationUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &OpenStatusUpdate_Confirmation{msg}
+		return true, err
+	case 3: // update.chan_open
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChannelOpenUpdate)
+		err := b.DecodeMessage(msg)
+		m.Update = &OpenStatusUpdate_ChanOpen{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _OpenStatusUpdate_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*OpenStatusUpdate)
+	// update
+	switch x := m.Update.(type) {
+	case *OpenStatusUpdate_ChanPending:
+		s := proto.Size(x.ChanPending)
+		n += proto.SizeVarint(1<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *OpenStatusUpdate_Confirmation:
+		s := proto.Size(x.Confirmation)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *OpenStatusUpdate_ChanOpen:
+		s := proto.Size(x.ChanOpen)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type PendingChannelRequest struct {
+	Status ChannelStatus `protobuf:"varint,1,opt,name=status,enum=lnrpc.ChannelStatus" json:"status,omitempty"`
+}
+
+func (m *PendingChannelRequest) Reset()                    { *m = PendingChannelRequest{} }
+func (m *PendingChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*PendingChannelRequest) ProtoMessage()               {}
+func (*PendingChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *PendingChannelRequest) GetStatus() ChannelStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ChannelStatus_ALL
+}
+
+type PendingChannelResponse struct {
+	PendingChannels []*PendingChannelResponse_PendingChannel `protobuf:"bytes,1,rep,name=pending_channels,json=pendingChannels" json:"pending_channels,omitempty"`
+}
+
+func (m *PendingChannelResponse) Reset()                    { *m = PendingChannelResponse{} }
+func (m *PendingChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*PendingChannelResponse) ProtoMessage()               {}
+func (*PendingChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *PendingChannelResponse) GetPendingChannels() []*PendingChannelResponse_PendingChannel {
+	if m != nil {
+		return m.PendingChannels
+	}
+	return nil
+}
+
+type PendingChannelResponse_PendingChannel struct {
+	PeerId        int32         `protobuf:"varint,1,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+	LightningId   string        `protobuf:"bytes,2,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	ChannelPoint  string        `protobuf:"bytes,3,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	Capacity      int64         `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
+	LocalBalance  int64         `protobuf:"varint,5,opt,name=local_balance,json=localBalance" json:"local_balance,omitempty"`
+	RemoteBalance int64         `protobuf:"varint,6,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
+	ClosingTxid   string        `protobuf:"bytes,7,opt,name=closing_txid,json=closingTxid" json:"closing_txid,omitempty"`
+	Status        ChannelStatus `protobuf:"varint,8,opt,name=status,enum=lnrpc.ChannelStatus" json:"status,omitempty"`
+	// The height at which the closing transaction confirmed, or zero if
+	// it has yet to confirm.
+	CloseHeight uint32 `protobuf:"varint,9,opt,name=close_height,json=closeHeight" json:"close_height,omitempty"`
+	// The total value of the outputs of the closing transaction which
+	// have yet to be resolved.
+	LimboBalance int64 `protobuf:"varint,10,opt,name=limbo_balance,json=limboBalance" json:"limbo_balance,omitempty"`
+	// The progress of the on-chain resolution of each output of the
+	// closing transaction which pays to us.
+	Resolutions []*ContractResolution `protobuf:"bytes,11,rep,name=resolutions" json:"resolutions,omitempty"`
+}
+
+func (m *PendingChannelResponse_PendingChannel) Reset()         { *m = PendingChannelResponse_PendingChannel{} }
+func (m *PendingChannelResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
+func (*PendingChannelResponse_PendingChannel) ProtoMessage()    {}
+func (*PendingChannelResponse_PendingChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{67, 0}
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetClosingTxid() string {
+	if m != nil {
+		return m.ClosingTxid
+	}
+	return ""
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetStatus() ChannelStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ChannelStatus_ALL
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetCloseHeight() uint32 {
+	if m != nil {
+		return m.CloseHeight
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetLimboBalance() int64 {
+	if m != nil {
+		return m.LimboBalance
+	}
+	return 0
+}
+
+func (m *PendingChannelResponse_PendingChannel) GetResolutions() []*ContractResolution {
+	if m != nil {
+		return m.Resolutions
+	}
+	return nil
+}
+
+type ContractResolution struct {
+	// The output of the closing transaction.
+	Outpoint string `protobuf:"bytes,1,opt,name=outpoint" json:"outpoint,omitempty"`
+	// The kind of contract: CommitOutput, OutgoingHtlc or IncomingHtlc.
+	Kind   string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	Amount int64  `protobuf:"varint,3,opt,name=amount" json:"amount,omitempty"`
+	// The height from which the output can be swept, or zero if the
+	// closing transaction has yet to confirm.
+	MaturityHeight uint32 `protobuf:"varint,4,opt,name=maturity_height,json=maturityHeight" json:"maturity_height,omitempty"`
+	// Whether the output has been spent on-chain.
+	Resolved bool `protobuf:"varint,5,opt,name=resolved" json:"resolved,omitempty"`
+}
+
+func (m *ContractResolution) Reset()                    { *m = ContractResolution{} }
+func (m *ContractResolution) String() string            { return proto.CompactTextString(m) }
+func (*ContractResolution) ProtoMessage()               {}
+func (*ContractResolution) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *ContractResolution) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *ContractResolution) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ContractResolution) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ContractResolution) GetMaturityHeight() uint32 {
+	if m != nil {
+		return m.MaturityHeight
+	}
+	return 0
+}
+
+func (m *ContractResolution) GetResolved() bool {
+	if m != nil {
+		return m.Resolved
+	}
+	return false
+}
+
+type WalletBalanceRequest struct {
+	WitnessOnly bool `protobuf:"varint,1,opt,name=witness_only,json=witnessOnly" json:"witness_only,omitempty"`
+}
+
+func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
+func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*WalletBalanceRequest) ProtoMessage()               {}
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *WalletBalanceRequest) GetWitnessOnly() bool {
+	if m != nil {
+		return m.WitnessOnly
+	}
+	return false
+}
+
+type WalletBalanceResponse struct {
+	Balance          float64 `protobuf:"fixed64,1,opt,name=balance" json:"balance,omitempty"`
+	WatchOnlyBalance float64 `protobuf:"fixed64,2,opt,name=watch_only_balance,json=watchOnlyBalance" json:"watch_only_balance,omitempty"`
+}
+
+func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
+func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*WalletBalanceResponse) ProtoMessage()               {}
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+func (m *WalletBalanceResponse) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *WalletBalanceResponse) GetWatchOnlyBalance() float64 {
+	if m != nil {
+		return m.WatchOnlyBalance
+	}
+	return 0
+}
+
+type ChannelBalanceRequest struct {
+}
+
+func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
+func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBalanceRequest) ProtoMessage()               {}
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+type ChannelBalanceResponse struct {
+	// balance is the sum of our settled balance across all open channels.
+	Balance                  int64                                    `protobuf:"varint,1,opt,name=balance" json:"balance,omitempty"`
+	RemoteBalance            int64                                    `protobuf:"varint,2,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
+	UnsettledLocalBalance    int64                                    `protobuf:"varint,3,opt,name=unsettled_local_balance,json=unsettledLocalBalance" json:"unsettled_local_balance,omitempty"`
+	UnsettledRemoteBalance   int64                                    `protobuf:"varint,4,opt,name=unsettled_remote_balance,json=unsettledRemoteBalance" json:"unsettled_remote_balance,omitempty"`
+	PendingOpenLocalBalance  int64                                    `protobuf:"varint,5,opt,name=pending_open_local_balance,json=pendingOpenLocalBalance" json:"pending_open_local_balance,omitempty"`
+	PendingOpenRemoteBalance int64                                    `protobuf:"varint,6,opt,name=pending_open_remote_balance,json=pendingOpenRemoteBalance" json:"pending_open_remote_balance,omitempty"`
+	Channels                 []*ChannelBalanceResponse_ChannelBalance `protobuf:"bytes,7,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
+func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelBalanceResponse) ProtoMessage()               {}
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *ChannelBalanceResponse) GetBalance() int64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetUnsettledLocalBalance() int64 {
+	if m != nil {
+		return m.UnsettledLocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetUnsettledRemoteBalance() int64 {
+	if m != nil {
+		return m.UnsettledRemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetPendingOpenLocalBalance() int64 {
+	if m != nil {
+		return m.PendingOpenLocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetPendingOpenRemoteBalance() int64 {
+	if m != nil {
+		return m.PendingOpenRemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse) GetChannels() []*ChannelBalanceResponse_ChannelBalance {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type ChannelBalanceResponse_ChannelBalance struct {
+	ChannelPoint           string `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	LightningId            string `protobuf:"bytes,2,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	Pending                bool   `protobuf:"varint,3,opt,name=pending" json:"pending,omitempty"`
+	Capacity               int64  `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
+	LocalBalance           int64  `protobuf:"varint,5,opt,name=local_balance,json=localBalance" json:"local_balance,omitempty"`
+	RemoteBalance          int64  `protobuf:"varint,6,opt,name=remote_balance,json=remoteBalance" json:"remote_balance,omitempty"`
+	UnsettledLocalBalance  int64  `protobuf:"varint,7,opt,name=unsettled_local_balance,json=unsettledLocalBalance" json:"unsettled_local_balance,omitempty"`
+	UnsettledRemoteBalance int64  `protobuf:"varint,8,opt,name=unsettled_remote_balance,json=unsettledRemoteBalance" json:"unsettled_remote_balance,omitempty"`
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) Reset()         { *m = ChannelBalanceResponse_ChannelBalance{} }
+func (m *ChannelBalanceResponse_ChannelBalance) String() string { return proto.CompactTextString(m) }
+func (*ChannelBalanceResponse_ChannelBalance) ProtoMessage()    {}
+func (*ChannelBalanceResponse_ChannelBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{72, 0}
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetPending() bool {
+	if m != nil {
+		return m.Pending
+	}
+	return false
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetLocalBalance() int64 {
+	if m != nil {
+		return m.LocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetRemoteBalance() int64 {
+	if m != nil {
+		return m.RemoteBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetUnsettledLocalBalance() int64 {
+	if m != nil {
+		return m.UnsettledLocalBalance
+	}
+	return 0
+}
+
+func (m *ChannelBalanceResponse_ChannelBalance) GetUnsettledRemoteBalance() int64 {
+	if m != nil {
+		return m.UnsettledRemoteBalance
+	}
+	return 0
+}
+
+type RoutingTableLink struct {
+	Id1      string  `protobuf:"bytes,1,opt,name=id1" json:"id1,omitempty"`
+	Id2      string  `protobuf:"bytes,2,opt,name=id2" json:"id2,omitempty"`
+	Outpoint string  `protobuf:"bytes,3,opt,name=outpoint" json:"outpoint,omitempty"`
+	Capacity int64   `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
+	Weight   float64 `protobuf:"fixed64,5,opt,name=weight" json:"weight,omitempty"`
+}
+
+func (m *RoutingTableLink) Reset()                    { *m = RoutingTableLink{} }
+func (m *RoutingTableLink) String() string            { return proto.CompactTextString(m) }
+func (*RoutingTableLink) ProtoMessage()               {}
+func (*RoutingTableLink) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *RoutingTableLink) GetId1() string {
+	if m != nil {
+		return m.Id1
+	}
+	return ""
+}
+
+func (m *RoutingTableLink) GetId2() string {
+	if m != nil {
+		return m.Id2
+	}
+	return ""
+}
+
+func (m *RoutingTableLink) GetOutpoint() string {
+	if m != nil {
+		return m.Outpoint
+	}
+	return ""
+}
+
+func (m *RoutingTableLink) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *RoutingTableLink) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+type ShowRoutingTableRequest struct {
+}
+
+func (m *ShowRoutingTableRequest) Reset()                    { *m = ShowRoutingTableRequest{} }
+func (m *ShowRoutingTableRequest) String() string            { return proto.CompactTextString(m) }
+func (*ShowRoutingTableRequest) ProtoMessage()               {}
+func (*ShowRoutingTableRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+type ShowRoutingTableResponse struct {
+	Channels []*RoutingTableLink `protobuf:"bytes,1,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *ShowRoutingTableResponse) Reset()                    { *m = ShowRoutingTableResponse{} }
+func (m *ShowRoutingTableResponse) String() string            { return proto.CompactTextString(m) }
+func (*ShowRoutingTableResponse) ProtoMessage()               {}
+func (*ShowRoutingTableResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+func (m *ShowRoutingTableResponse) GetChannels() []*RoutingTableLink {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type DropGraphRequest struct {
+}
+
+func (m *DropGraphRequest) Reset()                    { *m = DropGraphRequest{} }
+func (m *DropGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*DropGraphRequest) ProtoMessage()               {}
+func (*DropGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+type DropGraphResponse struct {
+	NumEdges int64 `protobuf:"varint,1,opt,name=num_edges,json=numEdges" json:"num_edges,omitempty"`
+}
+
+func (m *DropGraphResponse) Reset()                    { *m = DropGraphResponse{} }
+func (m *DropGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*DropGraphResponse) ProtoMessage()               {}
+func (*DropGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+func (m *DropGraphResponse) GetNumEdges() int64 {
+	if m != nil {
+		return m.NumEdges
+	}
+	return 0
+}
+
+type ExportGraphSnapshotRequest struct {
+}
+
+func (m *ExportGraphSnapshotRequest) Reset()                    { *m = ExportGraphSnapshotRequest{} }
+func (m *ExportGraphSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphSnapshotRequest) ProtoMessage()               {}
+func (*ExportGraphSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+type ExportGraphSnapshotResponse struct {
+	// The compact binary snapshot of the channel graph, which can be
+	// imported by another node on the same network.
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	NumNodes uint32 `protobuf:"varint,2,opt,name=num_nodes,json=numNodes" json:"num_nodes,omitempty"`
+	NumEdges uint32 `protobuf:"varint,3,opt,name=num_edges,json=numEdges" json:"num_edges,omitempty"`
+}
+
+func (m *ExportGraphSnapshotResponse) Reset()                    { *m = ExportGraphSnapshotResponse{} }
+func (m *ExportGraphSnapshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphSnapshotResponse) ProtoMessage()               {}
+func (*ExportGraphSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+func (m *ExportGraphSnapshotResponse) GetSnapshot() []byte {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+func (m *ExportGraphSnapshotResponse) GetNumNodes() uint32 {
+	if m != nil {
+		return m.NumNodes
+	}
+	return 0
+}
+
+func (m *ExportGraphSnapshotResponse) GetNumEdges() uint32 {
+	if m != nil {
+		return m.NumEdges
+	}
+	return 0
+}
+
+type ImportGraphSnapshotRequest struct {
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (m *ImportGraphSnapshotRequest) Reset()                    { *m = ImportGraphSnapshotRequest{} }
+func (m *ImportGraphSnapshotRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphSnapshotRequest) ProtoMessage()               {}
+func (*ImportGraphSnapshotRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
+func (m *ImportGraphSnapshotRequest) GetSnapshot() []byte {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+// The number of nodes and edges added to the graph. Those already known
+// with a more recent update are skipped.
+type ImportGraphSnapshotResponse struct {
+	NumNodes uint32 `protobuf:"varint,1,opt,name=num_nodes,json=numNodes" json:"num_nodes,omitempty"`
+	NumEdges uint32 `protobuf:"varint,2,opt,name=num_edges,json=numEdges" json:"num_edges,omitempty"`
+}
+
+func (m *ImportGraphSnapshotResponse) Reset()                    { *m = ImportGraphSnapshotResponse{} }
+func (m *ImportGraphSnapshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphSnapshotResponse) ProtoMessage()               {}
+func (*ImportGraphSnapshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+func (m *ImportGraphSnapshotResponse) GetNumNodes() uint32 {
+	if m != nil {
+		return m.NumNodes
+	}
+	return 0
+}
+
+func (m *ImportGraphSnapshotResponse) GetNumEdges() uint32 {
+	if m != nil {
+		return m.NumEdges
+	}
+	return 0
+}
+
+type QueryRoutesRequest struct {
+	Dest           []byte `protobuf:"bytes,1,opt,name=dest,proto3" json:"dest,omitempty"`
+	Amt            int64  `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	FinalCltvDelta uint32 `protobuf:"varint,3,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// If non-zero, the maximum number of blocks the route may time lock
+	// the payment for, below the node's configured maximum.
+	CltvLimit uint32 `protobuf:"varint,4,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// If set, the route may only depart over one of these channels,
+	// identified by their compact short channel IDs.
+	OutgoingChanIds []uint64 `protobuf:"varint,5,rep,packed,name=outgoing_chan_ids,json=outgoingChanIds" json:"outgoing_chan_ids,omitempty"`
+	// If set, the public key or lightning ID of the node the route must
+	// pass through immediately before reaching the destination.
+	LastHopPubkey []byte `protobuf:"bytes,6,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
+}
+
+func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
+func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryRoutesRequest) ProtoMessage()               {}
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *QueryRoutesRequest) GetDest() []byte {
+	if m != nil {
+		return m.Dest
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetFinalCltvDelta() uint32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetOutgoingChanIds() []uint64 {
+	if m != nil {
+		return m.OutgoingChanIds
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
+type Hop struct {
+	LightningId  string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	ChannelPoint string `protobuf:"bytes,2,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	ChanCapacity int64  `protobuf:"varint,3,opt,name=chan_capacity,json=chanCapacity" json:"chan_capacity,omitempty"`
+	AmtToForward int64  `protobuf:"varint,4,opt,name=amt_to_forward,json=amtToForward" json:"amt_to_forward,omitempty"`
+}
+
+func (m *Hop) Reset()                    { *m = Hop{} }
+func (m *Hop) String() string            { return proto.CompactTextString(m) }
+func (*Hop) ProtoMessage()               {}
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+func (m *Hop) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *Hop) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *Hop) GetChanCapacity() int64 {
+	if m != nil {
+		return m.ChanCapacity
+	}
+	return 0
+}
+
+func (m *Hop) GetAmtToForward() int64 {
+	if m != nil {
+		return m.AmtToForward
+	}
+	return 0
+}
+
+type Route struct {
+	TotalAmt      int64  `protobuf:"varint,1,opt,name=total_amt,json=totalAmt" json:"total_amt,omitempty"`
+	Hops          []*Hop `protobuf:"bytes,2,rep,name=hops" json:"hops,omitempty"`
+	TotalTimeLock uint32 `protobuf:"varint,3,opt,name=total_time_lock,json=totalTimeLock" json:"total_time_lock,omitempty"`
+}
+
+func (m *Route) Reset()                    { *m = Route{} }
+func (m *Route) String() string            { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()               {}
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+func (m *Route) GetTotalAmt() int64 {
+	if m != nil {
+		return m.TotalAmt
+	}
+	return 0
+}
+
+func (m *Route) GetHops() []*Hop {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+func (m *Route) GetTotalTimeLock() uint32 {
+	if m != nil {
+		return m.TotalTimeLock
+	}
+	return 0
+}
+
+type QueryRoutesResponse struct {
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+}
+
+func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
+func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryRoutesResponse) ProtoMessage()               {}
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+func (m *QueryRoutesResponse) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+type TrackPaymentRequest struct {
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type HTLCAttempt struct {
+	AttemptId          uint32        `protobuf:"varint,1,opt,name=attempt_id,json=attemptId" json:"attempt_id,omitempty"`
+	Status             PaymentStatus `protobuf:"varint,2,opt,name=status,enum=lnrpc.PaymentStatus" json:"status,omitempty"`
+	Route              *Route        `protobuf:"bytes,3,opt,name=route" json:"route,omitempty"`
+	FailureSourceIndex uint32        `protobuf:"varint,4,opt,name=failure_source_index,json=failureSourceIndex" json:"failure_source_index,omitempty"`
+	FailureReason      string        `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason" json:"failure_reason,omitempty"`
+	FailureCode        FailureCode   `protobuf:"varint,6,opt,name=failure_code,json=failureCode,enum=lnrpc.FailureCode" json:"failure_code,omitempty"`
+}
+
+func (m *HTLCAttempt) Reset()                    { *m = HTLCAttempt{} }
+func (m *HTLCAttempt) String() string            { return proto.CompactTextString(m) }
+func (*HTLCAttempt) ProtoMessage()               {}
+func (*HTLCAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+func (m *HTLCAttempt) GetAttemptId() uint32 {
+	if m != nil {
+		return m.AttemptId
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetStatus() PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (m *HTLCAttempt) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *HTLCAttempt) GetFailureSourceIndex() uint32 {
+	if m != nil {
+		return m.FailureSourceIndex
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
+func (m *HTLCAttempt) GetFailureCode() FailureCode {
+	if m != nil {
+		return m.FailureCode
+	}
+	return FailureCode_NONE
+}
+
+type PaymentUpdate struct {
+	PaymentHash []byte         `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	Status      PaymentStatus  `protobuf:"varint,2,opt,name=status,enum=lnrpc.PaymentStatus" json:"status,omitempty"`
+	Preimage    []byte         `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
+	Htlcs       []*HTLCAttempt `protobuf:"bytes,4,rep,name=htlcs" json:"htlcs,omitempty"`
+}
+
+func (m *PaymentUpdate) Reset()                    { *m = PaymentUpdate{} }
+func (m *PaymentUpdate) String() string            { return proto.CompactTextString(m) }
+func (*PaymentUpdate) ProtoMessage()               {}
+func (*PaymentUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *PaymentUpdate) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetStatus() PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return PaymentStatus_UNKNOWN
+}
+
+func (m *PaymentUpdate) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetHtlcs() []*HTLCAttempt {
+	if m != nil {
+		return m.Htlcs
+	}
+	return nil
+}
+
+type SubscribeHtlcEventsRequest struct {
+}
+
+func (m *SubscribeHtlcEventsRequest) Reset()                    { *m = SubscribeHtlcEventsRequest{} }
+func (m *SubscribeHtlcEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeHtlcEventsRequest) ProtoMessage()               {}
+func (*SubscribeHtlcEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+// HtlcEvent describes a change in the state of an HTLC within one of our
+// channels. Incoming HTLC's are offered to us by the remote peer, while
+// outgoing HTLC's are offered by us.
+type HtlcEvent struct {
+	EventType            HtlcEvent_EventType `protobuf:"varint,1,opt,name=event_type,json=eventType,enum=lnrpc.HtlcEvent_EventType" json:"event_type,omitempty"`
+	IncomingChannelPoint string              `protobuf:"bytes,2,opt,name=incoming_channel_point,json=incomingChannelPoint" json:"incoming_channel_point,omitempty"`
+	IncomingChanId       uint64              `protobuf:"varint,3,opt,name=incoming_chan_id,json=incomingChanId" json:"incoming_chan_id,omitempty"`
+	OutgoingChannelPoint string              `protobuf:"bytes,4,opt,name=outgoing_channel_point,json=outgoingChannelPoint" json:"outgoing_channel_point,omitempty"`
+	OutgoingChanId       uint64              `protobuf:"varint,5,opt,name=outgoing_chan_id,json=outgoingChanId" json:"outgoing_chan_id,omitempty"`
+	Amt                  int64               `protobuf:"varint,6,opt,name=amt" json:"amt,omitempty"`
+	PaymentHash          []byte              `protobuf:"bytes,7,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// failure_code and failure_reason are only set for FORWARD_FAIL and
+	// LINK_FAIL events.
+	FailureCode   FailureCode `protobuf:"varint,8,opt,name=failure_code,json=failureCode,enum=lnrpc.FailureCode" json:"failure_code,omitempty"`
+	FailureReason string      `protobuf:"bytes,9,opt,name=failure_reason,json=failureReason" json:"failure_reason,omitempty"`
+	Timestamp     int64       `protobuf:"varint,10,opt,name=timestamp" json:"timestamp,omitempty"`
+	// The custom records attached by the sender to an HTLC paying to one
+	// of our invoices, only set for SETTLE events.
+	CustomRecords map[uint64][]byte `protobuf:"bytes,11,rep,name=custom_records,json=customRecords" json:"custom_records,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *HtlcEvent) Reset()                    { *m = HtlcEvent{} }
+func (m *HtlcEvent) String() string            { return proto.CompactTextString(m) }
+func (*HtlcEvent) ProtoMessage()               {}
+func (*HtlcEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *HtlcEvent) GetEventType() HtlcEvent_EventType {
+	if m != nil {
+		return m.EventType
+	}
+	return HtlcEvent_FORWARD
+}
+
+func (m *HtlcEvent) GetIncomingChannelPoint() string {
+	if m != nil {
+		return m.IncomingChannelPoint
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetOutgoingChannelPoint() string {
+	if m != nil {
+		return m.OutgoingChannelPoint
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *HtlcEvent) GetFailureCode() FailureCode {
+	if m != nil {
+		return m.FailureCode
+	}
+	return FailureCode_NONE
+}
+
+func (m *HtlcEvent) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
+}
+
+func (m *HtlcEvent) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *HtlcEvent) GetCustomRecords() map[uint64][]byte {
+	if m != nil {
+		return m.CustomRecords
+	}
+	return nil
+}
+
+type NodeInfoRequest struct {
+	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+}
+
+func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
+func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeInfoRequest) ProtoMessage()               {}
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *NodeInfoRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+type LightningNode struct {
+	LightningId string     `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	Addresses   []string   `protobuf:"bytes,2,rep,name=addresses" json:"addresses,omitempty"`
+	Alias       string     `protobuf:"bytes,3,opt,name=alias" json:"alias,omitempty"`
+	Features    []*Feature `protobuf:"bytes,4,rep,name=features" json:"features,omitempty"`
+	LastUpdate  int64      `protobuf:"varint,5,opt,name=last_update,json=lastUpdate" json:"last_update,omitempty"`
+}
+
+func (m *LightningNode) Reset()                    { *m = LightningNode{} }
+func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
+func (*LightningNode) ProtoMessage()               {}
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+
+func (m *LightningNode) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *LightningNode) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *LightningNode) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *LightningNode) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *LightningNode) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+type NodeInfo struct {
+	Node          *LightningNode `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	NumChannels   uint32         `protobuf:"varint,2,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	TotalCapacity int64          `protobuf:"varint,3,opt,name=total_capacity,json=totalCapacity" json:"total_capacity,omitempty"`
+	Channels      []*ChannelEdge `protobuf:"bytes,4,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
+func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()               {}
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+
+func (m *NodeInfo) GetNode() *LightningNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetNumChannels() uint32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetTotalCapacity() int64 {
+	if m != nil {
+		return m.TotalCapacity
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetChannels() []*ChannelEdge {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+type NodeMetricsRequest struct {
+	// Whether to also compute the eigenvector centrality of each node.
+	IncludeEigenvector bool `protobuf:"varint,1,opt,name=include_eigenvector,json=includeEigenvector" json:"include_eigenvector,omitempty"`
+}
+
+func (m *NodeMetricsRequest) Reset()                    { *m = NodeMetricsRequest{} }
+func (m *NodeMetricsRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeMetricsRequest) ProtoMessage()               {}
+func (*NodeMetricsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+
+func (m *NodeMetricsRequest) GetIncludeEigenvector() bool {
+	if m != nil {
+		return m.IncludeEigenvector
+	}
+	return false
+}
+
+type FloatMetric struct {
+	Value           float64 `protobuf:"fixed64,1,opt,name=value" json:"value,omitempty"`
+	NormalizedValue float64 `protobuf:"fixed64,2,opt,name=normalized_value,json=normalizedValue" json:"normalized_value,omitempty"`
+}
+
+func (m *FloatMetric) Reset()                    { *m = FloatMetric{} }
+func (m *FloatMetric) String() string            { return proto.CompactTextString(m) }
+func (*FloatMetric) ProtoMessage()               {}
+func (*FloatMetric) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+
+func (m *FloatMetric) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *FloatMetric) GetNormalizedValue() float64 {
+	if m != nil {
+		return m.NormalizedValue
+	}
+	return 0
+}
+
+// Each metric is keyed by the hex encoded lightning ID of the node.
+type NodeMetricsResponse struct {
+	// The number of shortest paths between other nodes passing through
+	// each node, normalized by the number of pairs of other nodes.
+	BetweennessCentrality map[string]*FloatMetric `protobuf:"bytes,1,rep,name=betweenness_centrality,json=betweennessCentrality" json:"betweenness_centrality,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The eigenvector centrality of each node, normalized by that of the
+	// most central node. Only set if requested.
+	EigenvectorCentrality map[string]*FloatMetric `protobuf:"bytes,2,rep,name=eigenvector_centrality,json=eigenvectorCentrality" json:"eigenvector_centrality,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *NodeMetricsResponse) Reset()                    { *m = NodeMetricsResponse{} }
+func (m *NodeMetricsResponse) String() string            { return proto.CompactTextString(m) }
+func (*NodeMetricsResponse) ProtoMessage()               {}
+func (*NodeMetricsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+func (m *NodeMetricsResponse) GetBetweennessCentrality() map[string]*FloatMetric {
+	if m != nil {
+		return m.BetweennessCentrality
+	}
+	return nil
+}
+
+func (m *NodeMetricsResponse) GetEigenvectorCentrality() map[string]*FloatMetric {
+	if m != nil {
+		return m.EigenvectorCentrality
+	}
+	return nil
+}
+
+type RoutingPolicy struct {
+	TimeLockDelta uint32 `protobuf:"varint,1,opt,name=time_lock_delta,json=timeLockDelta" json:"time_lock_delta,omitempty"`
+	MinHtlc       int64  `protobuf:"varint,2,opt,name=min_htlc,json=minHtlc" json:"min_htlc,omitempty"`
+	FeeBase       int64  `protobuf:"varint,3,opt,name=fee_base,json=feeBase" json:"fee_base,omitempty"`
+	FeeRate       uint32 `protobuf:"varint,4,opt,name=fee_rate,json=feeRate" json:"fee_rate,omitempty"`
+	LastUpdate    int64  `protobuf:"varint,5,opt,name=last_update,json=lastUpdate" json:"last_update,omitempty"`
+}
+
+func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
+func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RoutingPolicy) ProtoMessage()               {}
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
+	if m != nil {
+		return m.TimeLockDelta
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetMinHtlc() int64 {
+	if m != nil {
+		return m.MinHtlc
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetFeeBase() int64 {
+	if m != nil {
+		return m.FeeBase
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetFeeRate() uint32 {
+	if m != nil {
+		return m.FeeRate
+	}
+	return 0
+}
+
+func (m *RoutingPolicy) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+type ChanInfoRequest struct {
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint" json:"chan_point,omitempty"`
+}
+
+func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
+func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChanInfoRequest) ProtoMessage()               {}
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+func (m *ChanInfoRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+type ChannelEdge struct {
+	ChannelPoint string         `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	Node1Id      string         `protobuf:"bytes,2,opt,name=node1_id,json=node1Id" json:"node1_id,omitempty"`
+	Node2Id      string         `protobuf:"bytes,3,opt,name=node2_id,json=node2Id" json:"node2_id,omitempty"`
+	Capacity     int64          `protobuf:"varint,4,opt,name=capacity" json:"capacity,omitempty"`
+	Node1Policy  *RoutingPolicy `protobuf:"bytes,5,opt,name=node1_policy,json=node1Policy" json:"node1_policy,omitempty"`
+	Node2Policy  *RoutingPolicy `protobuf:"bytes,6,opt,name=node2_policy,json=node2Policy" json:"node2_policy,omitempty"`
+	LastUpdate   int64          `protobuf:"varint,7,opt,name=last_update,json=lastUpdate" json:"last_update,omitempty"`
+}
+
+func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
+func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEdge) ProtoMessage()               {}
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *ChannelEdge) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelEdge) GetNode1Id() string {
+	if m != nil {
+		return m.Node1Id
+	}
+	return ""
+}
+
+func (m *ChannelEdge) GetNode2Id() string {
+	if m != nil {
+		return m.Node2Id
+	}
+	return ""
+}
+
+func (m *ChannelEdge) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *ChannelEdge) GetNode1Policy() *RoutingPolicy {
+	if m != nil {
+		return m.Node1Policy
+	}
+	return nil
+}
+
+func (m *ChannelEdge) GetNode2Policy() *RoutingPolicy {
+	if m != nil {
+		return m.Node2Policy
+	}
+	return nil
+}
+
+func (m *ChannelEdge) GetLastUpdate() int64 {
+	if m != nil {
+		return m.LastUpdate
+	}
+	return 0
+}
+
+// ChannelIDRequest identifies a channel by exactly one of its channel point,
+// compact short channel ID, or short channel ID in height:txindex:output form.
+type ChannelIDRequest struct {
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point,json=chanPoint" json:"chan_point,omitempty"`
+	ChanId    uint64        `protobuf:"varint,2,opt,name=chan_id,json=chanId" json:"chan_id,omitempty"`
+	Scid      string        `protobuf:"bytes,3,opt,name=scid" json:"scid,omitempty"`
+}
+
+func (m *ChannelIDRequest) Reset()                    { *m = ChannelIDRequest{} }
+func (m *ChannelIDRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelIDRequest) ProtoMessage()               {}
+func (*ChannelIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *ChannelIDRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *ChannelIDRequest) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ChannelIDRequest) GetScid() string {
+	if m != nil {
+		return m.Scid
+	}
+	return ""
+}
+
+type ChannelIDResponse struct {
+	ChannelPoint string `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	ChanId       uint64 `protobuf:"varint,2,opt,name=chan_id,json=chanId" json:"chan_id,omitempty"`
+	Scid         string `protobuf:"bytes,3,opt,name=scid" json:"scid,omitempty"`
+}
+
+func (m *ChannelIDResponse) Reset()                    { *m = ChannelIDResponse{} }
+func (m *ChannelIDResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelIDResponse) ProtoMessage()               {}
+func (*ChannelIDResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+func (m *ChannelIDResponse) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelIDResponse) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ChannelIDResponse) GetScid() string {
+	if m != nil {
+		return m.Scid
+	}
+	return ""
+}
+
+type FeeReportRequest struct {
+}
+
+func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
+func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*FeeReportRequest) ProtoMessage()               {}
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+// ChannelFeeReport is the forwarding policy of one of our open channels, along
+// with the fees in satoshis earned forwarding HTLCs over the channel within
+// the trailing day, week, and month.
+type ChannelFeeReport struct {
+	ChannelPoint string         `protobuf:"bytes,1,opt,name=channel_point,json=channelPoint" json:"channel_point,omitempty"`
+	Policy       *RoutingPolicy `protobuf:"bytes,2,opt,name=policy" json:"policy,omitempty"`
+	DayFeeSum    int64          `protobuf:"varint,3,opt,name=day_fee_sum,json=dayFeeSum" json:"day_fee_sum,omitempty"`
+	WeekFeeSum   int64          `protobuf:"varint,4,opt,name=week_fee_sum,json=weekFeeSum" json:"week_fee_sum,omitempty"`
+	MonthFeeSum  int64          `protobuf:"varint,5,opt,name=month_fee_sum,json=monthFeeSum" json:"month_fee_sum,omitempty"`
+}
+
+func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
+func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
+func (*ChannelFeeReport) ProtoMessage()               {}
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+func (m *ChannelFeeReport) GetChannelPoint() string {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return ""
+}
+
+func (m *ChannelFeeReport) GetPolicy() *RoutingPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *ChannelFeeReport) GetDayFeeSum() int64 {
+	if m != nil {
+		return m.DayFeeSum
+	}
+	return 0
+}
+
+func (m *ChannelFeeReport) GetWeekFeeSum() int64 {
+	if m != nil {
+		return m.WeekFeeSum
+	}
+	return 0
+}
+
+func (m *ChannelFeeReport) GetMonthFeeSum() int64 {
+	if m != nil {
+		return m.MonthFeeSum
+	}
+	return 0
+}
+
+type FeeReportResponse struct {
+	ChannelFees []*ChannelFeeReport `protobuf:"bytes,1,rep,name=channel_fees,json=channelFees" json:"channel_fees,omitempty"`
+	// The fees earned across all channels, including those since closed.
+	DayFeeSum   int64 `protobuf:"varint,2,opt,name=day_fee_sum,json=dayFeeSum" json:"day_fee_sum,omitempty"`
+	WeekFeeSum  int64 `protobuf:"varint,3,opt,name=week_fee_sum,json=weekFeeSum" json:"week_fee_sum,omitempty"`
+	MonthFeeSum int64 `protobuf:"varint,4,opt,name=month_fee_sum,json=monthFeeSum" json:"month_fee_sum,omitempty"`
+}
+
+func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
+func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*FeeReportResponse) ProtoMessage()               {}
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
+	if m != nil {
+		return m.ChannelFees
+	}
+	return nil
+}
+
+func (m *FeeReportResponse) GetDayFeeSum() int64 {
+	if m != nil {
+		return m.DayFeeSum
+	}
+	return 0
+}
+
+func (m *FeeReportResponse) GetWeekFeeSum() int64 {
+	if m != nil {
+		return m.WeekFeeSum
+	}
+	return 0
+}
+
+func (m *FeeReportResponse) GetMonthFeeSum() int64 {
+	if m != nil {
+		return m.MonthFeeSum
+	}
+	return 0
+}
+
+type ListMacaroonIDsRequest struct {
+}
+
+func (m *ListMacaroonIDsRequest) Reset()                    { *m = ListMacaroonIDsRequest{} }
+func (m *ListMacaroonIDsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListMacaroonIDsRequest) ProtoMessage()               {}
+func (*ListMacaroonIDsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+type ListMacaroonIDsResponse struct {
+	// The IDs of the root keys macaroons are issued under. Deleting a root
+	// key revokes every macaroon issued under it.
+	RootKeyIds []uint64 `protobuf:"varint,1,rep,packed,name=root_key_ids,json=rootKeyIds" json:"root_key_ids,omitempty"`
+}
+
+func (m *ListMacaroonIDsResponse) Reset()                    { *m = ListMacaroonIDsResponse{} }
+func (m *ListMacaroonIDsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListMacaroonIDsResponse) ProtoMessage()               {}
+func (*ListMacaroonIDsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+func (m *ListMacaroonIDsResponse) GetRootKeyIds() []uint64 {
+	if m != nil {
+		return m.RootKeyIds
+	}
+	return nil
+}
+
+type DeleteMacaroonIDRequest struct {
+	RootKeyId uint64 `protobuf:"varint,1,opt,name=root_key_id,json=rootKeyId" json:"root_key_id,omitempty"`
+}
+
+func (m *DeleteMacaroonIDRequest) Reset()                    { *m = DeleteMacaroonIDRequest{} }
+func (m *DeleteMacaroonIDRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMacaroonIDRequest) ProtoMessage()               {}
+func (*DeleteMacaroonIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+func (m *DeleteMacaroonIDRequest) GetRootKeyId() uint64 {
+	if m != nil {
+		return m.RootKeyId
+	}
+	return 0
+}
+
+type DeleteMacaroonIDResponse struct {
+	// Whether a root key with the requested ID existed, and was deleted.
+	Deleted bool `protobuf:"varint,1,opt,name=deleted" json:"deleted,omitempty"`
+}
+
+func (m *DeleteMacaroonIDResponse) Reset()                    { *m = DeleteMacaroonIDResponse{} }
+func (m *DeleteMacaroonIDResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMacaroonIDResponse) ProtoMessage()               {}
+func (*DeleteMacaroonIDResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+func (m *DeleteMacaroonIDResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type RotateMacaroonRootKeyRequest struct {
+}
+
+func (m *RotateMacaroonRootKeyRequest) Reset()                    { *m = RotateMacaroonRootKeyRequest{} }
+func (m *RotateMacaroonRootKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*RotateMacaroonRootKeyRequest) ProtoMessage()               {}
+func (*RotateMacaroonRootKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+type RotateMacaroonRootKeyResponse struct {
+}
+
+func (m *RotateMacaroonRootKeyResponse) Reset()         { *m = RotateMacaroonRootKeyResponse{} }
+func (m *RotateMacaroonRootKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateMacaroonRootKeyResponse) ProtoMessage()    {}
+func (*RotateMacaroonRootKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{110}
+}
+
+type BakeMacaroonRequest struct {
+	// The permissions granted by the macaroon, each of signer, rescue,
+	// lightning or admin.
+	Permissions []string `protobuf:"bytes,1,rep,name=permissions" json:"permissions,omitempty"`
+	// The ID of the root key to issue the macaroon under. The IDs 1 to 5
+	// are reserved for the macaroons lnd writes to its data directory.
+	RootKeyId uint64 `protobuf:"varint,2,opt,name=root_key_id,json=rootKeyId" json:"root_key_id,omitempty"`
+	// If non-zero, the number of seconds after which the macaroon expires.
+	Timeout int64 `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	// If set, the CIDR range of IP addresses the macaroon may be used from.
+	IpRange string `protobuf:"bytes,4,opt,name=ip_range,json=ipRange" json:"ip_range,omitempty"`
+	// If set, the full URIs of the only RPC methods the macaroon may be used
+	// to call, such as /lnrpc.Lightning/NewAddress.
+	AllowUris []string `protobuf:"bytes,5,rep,name=allow_uris,json=allowUris" json:"allow_uris,omitempty"`
+	// The full URIs of RPC methods the macaroon may not be used to call.
+	DenyUris []string `protobuf:"bytes,6,rep,name=deny_uris,json=denyUris" json:"deny_uris,omitempty"`
+}
+
+func (m *BakeMacaroonRequest) Reset()                    { *m = BakeMacaroonRequest{} }
+func (m *BakeMacaroonRequest) String() string            { return proto.CompactTextString(m) }
+func (*BakeMacaroonRequest) ProtoMessage()               {}
+func (*BakeMacaroonRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+func (m *BakeMacaroonRequest) GetPermissions() []string {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *BakeMacaroonRequest) GetRootKeyId() uint64 {
+	if m != nil {
+		return m.RootKeyId
+	}
+	return 0
+}
+
+func (m *BakeMacaroonRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *BakeMacaroonRequest) GetIpRange() string {
+	if m != nil {
+		return m.IpRange
+	}
+	return ""
+}
+
+func (m *BakeMacaroonRequest) GetAllowUris() []string {
+	if m != nil {
+		return m.AllowUris
+	}
+	return nil
+}
+
+func (m *BakeMacaroonRequest) GetDenyUris() []string {
+	if m != nil {
+		return m.DenyUris
+	}
+	return nil
+}
+
+type BakeMacaroonResponse struct {
+	// The hex-encoded serialized macaroon.
+	Macaroon string `protobuf:"bytes,1,opt,name=macaroon" json:"macaroon,omitempty"`
+}
+
+func (m *BakeMacaroonResponse) Reset()                    { *m = BakeMacaroonResponse{} }
+func (m *BakeMacaroonResponse) String() string            { return proto.CompactTextString(m) }
+func (*BakeMacaroonResponse) ProtoMessage()               {}
+func (*BakeMacaroonResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *BakeMacaroonResponse) GetMacaroon() string {
+	if m != nil {
+		return m.Macaroon
+	}
+	return ""
+}
+
+type SendOnionMessageRequest struct {
+	// The hex-encoded compressed public keys of the nodes along the route
+	// of the message. The first must be a connected peer, and the last is
+	// the message's recipient.
+	Path []string `protobuf:"bytes,1,rep,name=path" json:"path,omitempty"`
+	// The type of the message, which must be at least 64.
+	Type uint64 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+	// The content of the message.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *SendOnionMessageRequest) Reset()                    { *m = SendOnionMessageRequest{} }
+func (m *SendOnionMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendOnionMessageRequest) ProtoMessage()               {}
+func (*SendOnionMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *SendOnionMessageRequest) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *SendOnionMessageRequest) GetType() uint64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *SendOnionMessageRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SendOnionMessageResponse struct {
+}
+
+func (m *SendOnionMessageResponse) Reset()                    { *m = SendOnionMessageResponse{} }
+func (m *SendOnionMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendOnionMessageResponse) ProtoMessage()               {}
+func (*SendOnionMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+type SubscribeOnionMessagesRequest struct {
+}
+
+func (m *SubscribeOnionMessagesRequest) Reset()         { *m = SubscribeOnionMessagesRequest{} }
+func (m *SubscribeOnionMessagesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeOnionMessagesRequest) ProtoMessage()    {}
+func (*SubscribeOnionMessagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{115}
+}
+
+type OnionMessageUpdate struct {
+	// The type of the received message.
+	Type uint64 `protobuf:"varint,1,opt,name=type" json:"type,omitempty"`
+	// The content of the received message.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *OnionMessageUpdate) Reset()                    { *m = OnionMessageUpdate{} }
+func (m *OnionMessageUpdate) String() string            { return proto.CompactTextString(m) }
+func (*OnionMessageUpdate) ProtoMessage()               {}
+func (*OnionMessageUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
+
+func (m *OnionMessageUpdate) GetType() uint64 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *OnionMessageUpdate) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SendCustomMessageRequest struct {
+	// The lightning ID of the connected peer to send the message to.
+	LightningId string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	// The type of the message, which must be odd, and at least 32768.
+	Type uint32 `protobuf:"varint,2,opt,name=type" json:"type,omitempty"`
+	// The content of the message.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *SendCustomMessageRequest) Reset()                    { *m = SendCustomMessageRequest{} }
+func (m *SendCustomMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendCustomMessageRequest) ProtoMessage()               {}
+func (*SendCustomMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+func (m *SendCustomMessageRequest) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *SendCustomMessageRequest) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *SendCustomMessageRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SendCustomMessageResponse struct {
+}
+
+func (m *SendCustomMessageResponse) Reset()                    { *m = SendCustomMessageResponse{} }
+func (m *SendCustomMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SendCustomMessageResponse) ProtoMessage()               {}
+func (*SendCustomMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+type SubscribeCustomMessagesRequest struct {
+}
+
+func (m *SubscribeCustomMessagesRequest) Reset()         { *m = SubscribeCustomMessagesRequest{} }
+func (m *SubscribeCustomMessagesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeCustomMessagesRequest) ProtoMessage()    {}
+func (*SubscribeCustomMessagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{119}
+}
+
+type CustomMessage struct {
+	// The lightning ID of the peer the message was received from.
+	LightningId string `protobuf:"bytes,1,opt,name=lightning_id,json=lightningId" json:"lightning_id,omitempty"`
+	PeerId      int32  `protobuf:"varint,2,opt,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+	// The type of the received message.
+	Type uint32 `protobuf:"varint,3,opt,name=type" json:"type,omitempty"`
+	// The content of the received message.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *CustomMessage) Reset()                    { *m = CustomMessage{} }
+func (m *CustomMessage) String() string            { return proto.CompactTextString(m) }
+func (*CustomMessage) ProtoMessage()               {}
+func (*CustomMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
+
+func (m *CustomMessage) GetLightningId() string {
+	if m != nil {
+		return m.LightningId
+	}
+	return ""
+}
+
+func (m *CustomMessage) GetPeerId() int32 {
+	if m != nil {
+		return m.PeerId
+	}
+	return 0
+}
+
+func (m *CustomMessage) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *CustomMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*PaymentFailure)(nil), "lnrpc.PaymentFailure")
+	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
+	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
+	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
+	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
+	proto.RegisterType((*ImportPublicKeyRequest)(nil), "lnrpc.ImportPublicKeyRequest")
+	proto.RegisterType((*ImportPublicKeyResponse)(nil), "lnrpc.ImportPublicKeyResponse")
+	proto.RegisterType((*ImportAddressRequest)(nil), "lnrpc.ImportAddressRequest")
+	proto.RegisterType((*ImportAddressResponse)(nil), "lnrpc.ImportAddressResponse")
+	proto.RegisterType((*RescanRequest)(nil), "lnrpc.RescanRequest")
+	proto.RegisterType((*RescanUpdate)(nil), "lnrpc.RescanUpdate")
+	proto.RegisterType((*GetRecoveryInfoRequest)(nil), "lnrpc.GetRecoveryInfoRequest")
+	proto.RegisterType((*GetRecoveryInfoResponse)(nil), "lnrpc.GetRecoveryInfoResponse")
+	proto.RegisterType((*OutPoint)(nil), "lnrpc.OutPoint")
+	proto.RegisterType((*LeaseOutputRequest)(nil), "lnrpc.LeaseOutputRequest")
+	proto.RegisterType((*LeaseOutputResponse)(nil), "lnrpc.LeaseOutputResponse")
+	proto.RegisterType((*ReleaseOutputRequest)(nil), "lnrpc.ReleaseOutputRequest")
+	proto.RegisterType((*ReleaseOutputResponse)(nil), "lnrpc.ReleaseOutputResponse")
+	proto.RegisterType((*ListLeasesRequest)(nil), "lnrpc.ListLeasesRequest")
+	proto.RegisterType((*OutputLease)(nil), "lnrpc.OutputLease")
+	proto.RegisterType((*ListLeasesResponse)(nil), "lnrpc.ListLeasesResponse")
+	proto.RegisterType((*LabelTransactionRequest)(nil), "lnrpc.LabelTransactionRequest")
+	proto.RegisterType((*LabelTransactionResponse)(nil), "lnrpc.LabelTransactionResponse")
+	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
+	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
+	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
+	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*ConsolidateUtxosRequest)(nil), "lnrpc.ConsolidateUtxosRequest")
+	proto.RegisterType((*ConsolidateUtxosResponse)(nil), "lnrpc.ConsolidateUtxosResponse")
+	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
+	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
+	proto.RegisterType((*ListAddressesRequest)(nil), "lnrpc.ListAddressesRequest")
+	proto.RegisterType((*WalletAddress)(nil), "lnrpc.WalletAddress")
+	proto.RegisterType((*ListAddressesResponse)(nil), "lnrpc.ListAddressesResponse")
+	proto.RegisterType((*ListUnspentRequest)(nil), "lnrpc.ListUnspentRequest")
+	proto.RegisterType((*Utxo)(nil), "lnrpc.Utxo")
+	proto.RegisterType((*ListUnspentResponse)(nil), "lnrpc.ListUnspentResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
+	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
+	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
+	proto.RegisterType((*ActiveChannel)(nil), "lnrpc.ActiveChannel")
+	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
+	proto.RegisterType((*Feature)(nil), "lnrpc.Feature")
+	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
+	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*PeerEventSubscription)(nil), "lnrpc.PeerEventSubscription")
+	proto.RegisterType((*PeerEvent)(nil), "lnrpc.PeerEvent")
+	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
+	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*UpdateConfigRequest)(nil), "lnrpc.UpdateConfigRequest")
+	proto.RegisterType((*UpdateConfigResponse)(nil), "lnrpc.UpdateConfigResponse")
+	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
+	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
+	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
+	proto.RegisterType((*BumpFeeRequest)(nil), "lnrpc.BumpFeeRequest")
+	proto.RegisterType((*BumpFeeResponse)(nil), "lnrpc.BumpFeeResponse")
+	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
+	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
+	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
+	proto.RegisterType((*PendingChannelRequest)(nil), "lnrpc.PendingChannelRequest")
+	proto.RegisterType((*PendingChannelResponse)(nil), "lnrpc.PendingChannelResponse")
+	proto.RegisterType((*PendingChannelResponse_PendingChannel)(nil), "lnrpc.PendingChannelResponse.PendingChannel")
+	proto.RegisterType((*ContractResolution)(nil), "lnrpc.ContractResolution")
+	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
+	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
+	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
+	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
+	proto.RegisterType((*ChannelBalanceResponse_ChannelBalance)(nil), "lnrpc.ChannelBalanceResponse.ChannelBalance")
+	proto.RegisterType((*RoutingTableLink)(nil), "lnrpc.RoutingTableLink")
+	proto.RegisterType((*ShowRoutingTableRequest)(nil), "lnrpc.ShowRoutingTableRequest")
+	proto.RegisterType((*ShowRoutingTableResponse)(nil), "lnrpc.ShowRoutingTableResponse")
+	proto.RegisterType((*DropGraphRequest)(nil), "lnrpc.DropGraphRequest")
+	proto.RegisterType((*DropGraphResponse)(nil), "lnrpc.DropGraphResponse")
+	proto.RegisterType((*ExportGraphSnapshotRequest)(nil), "lnrpc.ExportGraphSnapshotRequest")
+	proto.RegisterType((*ExportGraphSnapshotResponse)(nil), "lnrpc.ExportGraphSnapshotResponse")
+	proto.RegisterType((*ImportGraphSnapshotRequest)(nil), "lnrpc.ImportGraphSnapshotRequest")
+	proto.RegisterType((*ImportGraphSnapshotResponse)(nil), "lnrpc.ImportGraphSnapshotResponse")
+	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
+	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
+	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
+	proto.RegisterType((*HTLCAttempt)(nil), "lnrpc.HTLCAttempt")
+	proto.RegisterType((*PaymentUpdate)(nil), "lnrpc.PaymentUpdate")
+	proto.RegisterType((*SubscribeHtlcEventsRequest)(nil), "lnrpc.SubscribeHtlcEventsRequest")
+	proto.RegisterType((*HtlcEvent)(nil), "lnrpc.HtlcEvent")
+	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
+	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
+	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
+	proto.RegisterType((*NodeMetricsRequest)(nil), "lnrpc.NodeMetricsRequest")
+	proto.RegisterType((*FloatMetric)(nil), "lnrpc.FloatMetric")
+	proto.RegisterType((*NodeMetricsResponse)(nil), "lnrpc.NodeMetricsResponse")
+	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
+	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
+	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
+	proto.RegisterType((*ChannelIDRequest)(nil), "lnrpc.ChannelIDRequest")
+	proto.RegisterType((*ChannelIDResponse)(nil), "lnrpc.ChannelIDResponse")
+	proto.RegisterType((*FeeReportRequest)(nil), "lnrpc.FeeReportRequest")
+	proto.RegisterType((*ChannelFeeReport)(nil), "lnrpc.ChannelFeeReport")
+	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
+	proto.RegisterType((*ListMacaroonIDsRequest)(nil), "lnrpc.ListMacaroonIDsRequest")
+	proto.RegisterType((*ListMacaroonIDsResponse)(nil), "lnrpc.ListMacaroonIDsResponse")
+	proto.RegisterType((*DeleteMacaroonIDRequest)(nil), "lnrpc.DeleteMacaroonIDRequest")
+	proto.RegisterType((*DeleteMacaroonIDResponse)(nil), "lnrpc.DeleteMacaroonIDResponse")
+	proto.RegisterType((*RotateMacaroonRootKeyRequest)(nil), "lnrpc.RotateMacaroonRootKeyRequest")
+	proto.RegisterType((*RotateMacaroonRootKeyResponse)(nil), "lnrpc.RotateMacaroonRootKeyResponse")
+	proto.RegisterType((*BakeMacaroonRequest)(nil), "lnrpc.BakeMacaroonRequest")
+	proto.RegisterType((*BakeMacaroonResponse)(nil), "lnrpc.BakeMacaroonResponse")
+	proto.RegisterType((*SendOnionMessageRequest)(nil), "lnrpc.SendOnionMessageRequest")
+	proto.RegisterType((*SendOnionMessageResponse)(nil), "lnrpc.SendOnionMessageResponse")
+	proto.RegisterType((*SubscribeOnionMessagesRequest)(nil), "lnrpc.SubscribeOnionMessagesRequest")
+	proto.RegisterType((*OnionMessageUpdate)(nil), "lnrpc.OnionMessageUpdate")
+	proto.RegisterType((*SendCustomMessageRequest)(nil), "lnrpc.SendCustomMessageRequest")
+	proto.RegisterType((*SendCustomMessageResponse)(nil), "lnrpc.SendCustomMessageResponse")
+	proto.RegisterType((*SubscribeCustomMessagesRequest)(nil), "lnrpc.SubscribeCustomMessagesRequest")
+	proto.RegisterType((*CustomMessage)(nil), "lnrpc.CustomMessage")
+	proto.RegisterEnum("lnrpc.FailureCode", FailureCode_name, FailureCode_value)
+	proto.RegisterEnum("lnrpc.ChannelStatus", ChannelStatus_name, ChannelStatus_value)
+	proto.RegisterEnum("lnrpc.PaymentStatus", PaymentStatus_name, PaymentStatus_value)
+	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.PeerEvent_EventType", PeerEvent_EventType_name, PeerEvent_EventType_value)
+	proto.RegisterEnum("lnrpc.HtlcEvent_EventType", HtlcEvent_EventType_name, HtlcEvent_EventType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Lightning service
+
+type LightningClient interface {
+	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
+	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
+	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
+	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error)
+	ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error)
+	ConsolidateUtxos(ctx context.Context, in *ConsolidateUtxosRequest, opts ...grpc.CallOption) (*ConsolidateUtxosResponse, error)
+	LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error)
+	ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error)
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
+	ImportPublicKey(ctx context.Context, in *ImportPublicKeyRequest, opts ...grpc.CallOption) (*ImportPublicKeyResponse, error)
+	ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportAddressResponse, error)
+	RescanWallet(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (Lightning_RescanWalletClient, error)
+	GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
+	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
+	LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error)
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error)
+	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
 	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error)
 	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
 	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
 	PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error)
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
 	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
 	ShowRoutingTable(ctx context.Context, in *ShowRoutingTableRequest, opts ...grpc.CallOption) (*ShowRoutingTableResponse, error)
+	DropGraph(ctx context.Context, in *DropGraphRequest, opts ...grpc.CallOption) (*DropGraphResponse, error)
+	ExportGraphSnapshot(ctx context.Context, in *ExportGraphSnapshotRequest, opts ...grpc.CallOption) (*ExportGraphSnapshotResponse, error)
+	ImportGraphSnapshot(ctx context.Context, in *ImportGraphSnapshotRequest, opts ...grpc.CallOption) (*ImportGraphSnapshotResponse, error)
+	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
+	SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error)
+	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
+	GetNodeMetrics(ctx context.Context, in *NodeMetricsRequest, opts ...grpc.CallOption) (*NodeMetricsResponse, error)
+	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
+	LookupChannelID(ctx context.Context, in *ChannelIDRequest, opts ...grpc.CallOption) (*ChannelIDResponse, error)
+	FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error)
+	ListMacaroonIDs(ctx context.Context, in *ListMacaroonIDsRequest, opts ...grpc.CallOption) (*ListMacaroonIDsResponse, error)
+	DeleteMacaroonID(ctx context.Context, in *DeleteMacaroonIDRequest, opts ...grpc.CallOption) (*DeleteMacaroonIDResponse, error)
+	RotateMacaroonRootKey(ctx context.Context, in *RotateMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateMacaroonRootKeyResponse, error)
+	BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error)
+	SendOnionMessage(ctx context.Context, in *SendOnionMessageRequest, opts ...grpc.CallOption) (*SendOnionMessageResponse, error)
+	SubscribeOnionMessages(ctx context.Context, in *SubscribeOnionMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeOnionMessagesClient, error)
+	SendCustomMessage(ctx context.Context, in *SendCustomMessageRequest, opts ...grpc.CallOption) (*SendCustomMessageResponse, error)
+	SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error)
+}
+
+type lightningClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLightningClient(cc *grpc.ClientConn) LightningClient {
+	return &lightningClient{cc}
+}
+
+func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
+	out := new(WalletBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
+	out := new(ChannelBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
+	out := new(SendManyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
+	out := new(SendCoinsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error) {
+	out := new(ListAddressesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListAddresses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListUnspent(ctx context.Context, in *ListUnspentRequest, opts ...grpc.CallOption) (*ListUnspentResponse, error) {
+	out := new(ListUnspentResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListUnspent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ConsolidateUtxos(ctx context.Context, in *ConsolidateUtxosRequest, opts ...grpc.CallOption) (*ConsolidateUtxosResponse, error) {
+	out := new(ConsolidateUtxosResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConsolidateUtxos", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LeaseOutput(ctx context.Context, in *LeaseOutputRequest, opts ...grpc.CallOption) (*LeaseOutputResponse, error) {
+	out := new(LeaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LeaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ReleaseOutput(ctx context.Context, in *ReleaseOutputRequest, opts ...grpc.CallOption) (*ReleaseOutputResponse, error) {
+	out := new(ReleaseOutputResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ReleaseOutput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error) {
+	out := new(ListLeasesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportPublicKey(ctx context.Context, in *ImportPublicKeyRequest, opts ...grpc.CallOption) (*ImportPublicKeyResponse, error) {
+	out := new(ImportPublicKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportPublicKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportAddress(ctx context.Context, in *ImportAddressRequest, opts ...grpc.CallOption) (*ImportAddressResponse, error) {
+	out := new(ImportAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RescanWallet(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (Lightning_RescanWalletClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/RescanWallet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningRescanWalletClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_RescanWalletClient interface {
+	Recv() (*RescanUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningRescanWalletClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningRescanWalletClient) Recv() (*RescanUpdate, error) {
+	m := new(RescanUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) GetRecoveryInfo(ctx context.Context, in *GetRecoveryInfoRequest, opts ...grpc.CallOption) (*GetRecoveryInfoResponse, error) {
+	out := new(GetRecoveryInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetRecoveryInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
+	out := new(TransactionDetails)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) LabelTransaction(ctx context.Context, in *LabelTransactionRequest, opts ...grpc.CallOption) (*LabelTransactionResponse, error) {
+	out := new(LabelTransactionResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LabelTransaction", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
+	out := new(ConnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/SubscribePeerEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribePeerEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribePeerEventsClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribePeerEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribePeerEventsClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
+	out := new(ListChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*UpdateConfigResponse, error) {
+	out := new(UpdateConfigResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateConfig", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningOpenChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_OpenChannelClient interface {
+	Recv() (*OpenStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningOpenChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
+	m := new(OpenStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningCloseChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_CloseChannelClient interface {
+	Recv() (*CloseStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningCloseChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
+	m := new(CloseStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error) {
+	out := new(PendingChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BumpFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentClient{stream}
+	return x, nil
+}
+
+type Lightning_SendPaymentClient interface {
+	Send(*SendRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) ShowRoutingTable(ctx context.Context, in *ShowRoutingTableRequest, opts ...grpc.CallOption) (*ShowRoutingTableResponse, error) {
+	out := new(ShowRoutingTableResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ShowRoutingTable", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DropGraph(ctx context.Context, in *DropGraphRequest, opts ...grpc.CallOption) (*DropGraphResponse, error) {
+	out := new(DropGraphResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DropGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportGraphSnapshot(ctx context.Context, in *ExportGraphSnapshotRequest, opts ...grpc.CallOption) (*ExportGraphSnapshotResponse, error) {
+	out := new(ExportGraphSnapshotResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportGraphSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportGraphSnapshot(ctx context.Context, in *ImportGraphSnapshotRequest, opts ...grpc.CallOption) (*ImportGraphSnapshotResponse, error) {
+	out := new(ImportGraphSnapshotResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportGraphSnapshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error) {
+	out := new(QueryRoutesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoutes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*PaymentUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*PaymentUpdate, error) {
+	m := new(PaymentUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SubscribeHtlcEvents(ctx context.Context, in *SubscribeHtlcEventsRequest, opts ...grpc.CallOption) (Lightning_SubscribeHtlcEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/SubscribeHtlcEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeHtlcEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeHtlcEventsClient interface {
+	Recv() (*HtlcEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeHtlcEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeHtlcEventsClient) Recv() (*HtlcEvent, error) {
+	m := new(HtlcEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNodeMetrics(ctx context.Context, in *NodeMetricsRequest, opts ...grpc.CallOption) (*NodeMetricsResponse, error) {
+	out := new(NodeMetricsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeMetrics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
+	out := new(ChannelEdge)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LookupChannelID(ctx context.Context, in *ChannelIDRequest, opts ...grpc.CallOption) (*ChannelIDResponse, error) {
+	out := new(ChannelIDResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupChannelID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
+	out := new(FeeReportResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListMacaroonIDs(ctx context.Context, in *ListMacaroonIDsRequest, opts ...grpc.CallOption) (*ListMacaroonIDsResponse, error) {
+	out := new(ListMacaroonIDsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListMacaroonIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteMacaroonID(ctx context.Context, in *DeleteMacaroonIDRequest, opts ...grpc.CallOption) (*DeleteMacaroonIDResponse, error) {
+	out := new(DeleteMacaroonIDResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteMacaroonID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) RotateMacaroonRootKey(ctx context.Context, in *RotateMacaroonRootKeyRequest, opts ...grpc.CallOption) (*RotateMacaroonRootKeyResponse, error) {
+	out := new(RotateMacaroonRootKeyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/RotateMacaroonRootKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error) {
+	out := new(BakeMacaroonResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BakeMacaroon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendOnionMessage(ctx context.Context, in *SendOnionMessageRequest, opts ...grpc.CallOption) (*SendOnionMessageResponse, error) {
+	out := new(SendOnionMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendOnionMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeOnionMessages(ctx context.Context, in *SubscribeOnionMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeOnionMessagesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/SubscribeOnionMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeOnionMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeOnionMessagesClient interface {
+	Recv() (*OnionMessageUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeOnionMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeOnionMessagesClient) Recv() (*OnionMessageUpdate, error) {
+	m := new(OnionMessageUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendCustomMessage(ctx context.Context, in *SendCustomMessageRequest, opts ...grpc.CallOption) (*SendCustomMessageResponse, error) {
+	out := new(SendCustomMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCustomMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeCustomMessages(ctx context.Context, in *SubscribeCustomMessagesRequest, opts ...grpc.CallOption) (Lightning_SubscribeCustomMessagesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[9], c.cc, "/lnrpc.Lightning/SubscribeCustomMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeCustomMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeCustomMessagesClient interface {
+	Recv() (*CustomMessage, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeCustomMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeCustomMessagesClient) Recv() (*CustomMessage, error) {
+	m := new(CustomMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Lightning service
+
+type LightningServer interface {
+	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
+	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
+	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	ListAddresses(context.Context, *ListAddressesRequest) (*ListAddressesResponse, error)
+	ListUnspent(context.Context, *ListUnspentRequest) (*ListUnspentResponse, error)
+	ConsolidateUtxos(context.Context, *ConsolidateUtxosRequest) (*ConsolidateUtxosResponse, error)
+	LeaseOutput(context.Context, *LeaseOutputRequest) (*LeaseOutputResponse, error)
+	ReleaseOutput(context.Context, *ReleaseOutputRequest) (*ReleaseOutputResponse, error)
+	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
+	ImportPublicKey(context.Context, *ImportPublicKeyRequest) (*ImportPublicKeyResponse, error)
+	ImportAddress(context.Context, *ImportAddressRequest) (*ImportAddressResponse, error)
+	RescanWallet(*RescanRequest, Lightning_RescanWalletServer) error
+	GetRecoveryInfo(context.Context, *GetRecoveryInfoRequest) (*GetRecoveryInfoResponse, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
+	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
+	LabelTransaction(context.Context, *LabelTransactionRequest) (*LabelTransactionResponse, error)
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	SubscribePeerEvents(*PeerEventSubscription, Lightning_SubscribePeerEventsServer) error
+	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	UpdateConfig(context.Context, *UpdateConfigRequest) (*UpdateConfigResponse, error)
+	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	SendPayment(Lightning_SendPaymentServer) error
+	ShowRoutingTable(context.Context, *ShowRoutingTableRequest) (*ShowRoutingTableResponse, error)
+	DropGraph(context.Context, *DropGraphRequest) (*DropGraphResponse, error)
+	ExportGraphSnapshot(context.Context, *ExportGraphSnapshotRequest) (*ExportGraphSnapshotResponse, error)
+	ImportGraphSnapshot(context.Context, *ImportGraphSnapshotRequest) (*ImportGraphSnapshotResponse, error)
+	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
+	SubscribeHtlcEvents(*SubscribeHtlcEventsRequest, Lightning_SubscribeHtlcEventsServer) error
+	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
+	GetNodeMetrics(context.Context, *NodeMetricsRequest) (*NodeMetricsResponse, error)
+	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
+	LookupChannelID(context.Context, *ChannelIDRequest) (*ChannelIDResponse, error)
+	FeeReport(context.Context, *FeeReportRequest) (*FeeReportResponse, error)
+	ListMacaroonIDs(context.Context, *ListMacaroonIDsRequest) (*ListMacaroonIDsResponse, error)
+	DeleteMacaroonID(context.Context, *DeleteMacaroonIDRequest) (*DeleteMacaroonIDResponse, error)
+	RotateMacaroonRootKey(context.Context, *RotateMacaroonRootKeyRequest) (*RotateMacaroonRootKeyResponse, error)
+	BakeMacaroon(context.Context, *BakeMacaroonRequest) (*BakeMacaroonResponse, error)
+	SendOnionMessage(context.Context, *SendOnionMessageRequest) (*SendOnionMessageResponse, error)
+	SubscribeOnionMessages(*SubscribeOnionMessagesRequest, Lightning_SubscribeOnionMessagesServer) error
+	SendCustomMessage(context.Context, *SendCustomMessageRequest) (*SendCustomMessageResponse, error)
+	SubscribeCustomMessages(*SubscribeCustomMessagesRequest, Lightning_SubscribeCustomMessagesServer) error
+}
+
+func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
+	s.RegisterService(&_Lightning_serviceDesc, srv)
+}
+
+func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/WalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ChannelBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCoinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendCoins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendCoins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListAddresses(ctx, req.(*ListAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListUnspent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUnspentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListUnspent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListUnspent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListUnspent(ctx, req.(*ListUnspentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ConsolidateUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsolidateUtxosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ConsolidateUtxos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ConsolidateUtxos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ConsolidateUtxos(ctx, req.(*ConsolidateUtxosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LeaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LeaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LeaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LeaseOutput(ctx, req.(*LeaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ReleaseOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ReleaseOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ReleaseOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ReleaseOutput(ctx, req.(*ReleaseOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLeasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListLeases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListLeases",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListLeases(ctx, req.(*ListLeasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ImportPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportPublicKey(ctx, req.(*ImportPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ImportAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportAddress(ctx, req.(*ImportAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_RescanWallet_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RescanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).RescanWallet(m, &lightningRescanWalletServer{stream})
+}
+
+type Lightning_RescanWalletServer interface {
+	Send(*RescanUpdate) error
+	grpc.ServerStream
+}
+
+type lightningRescanWalletServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningRescanWalletServer) Send(m *RescanUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_GetRecoveryInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetRecoveryInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetRecoveryInfo(ctx, req.(*GetRecoveryInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+}
+
+type Lightning_SubscribeTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_LabelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LabelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LabelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LabelTransaction(ctx, req.(*LabelTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ConnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type lightningClient struct {
-	cc *grpc.ClientConn
+func _Lightning_SubscribePeerEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerEventSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribePeerEvents(m, &lightningSubscribePeerEventsServer{stream})
 }
 
-func NewLightningClient(cc *grpc.ClientConn) LightningClient {
-	return &lightningClient{cc}
+type Lightning_SubscribePeerEventsServer interface {
+	Send(*PeerEvent) error
+	grpc.ServerStream
 }
 
-func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
-	out := new(WalletBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type lightningSubscribePeerEventsServer struct {
+	grpc.ServerStream
 }
 
-func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
-	out := new(ChannelBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *lightningSubscribePeerEventsServer) Send(m *PeerEvent) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
-	out := new(SendManyResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
-	if err != nil {
+func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LightningServer).ListChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
-	out := new(SendCoinsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
-	if err != nil {
+func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LightningServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
-	if err != nil {
+func _Lightning_UpdateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateConfigRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateConfig(ctx, req.(*UpdateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
-	out := new(ConnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OpenChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
 }
 
-func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
-	out := new(ListPeersResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type Lightning_OpenChannelServer interface {
+	Send(*OpenStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningOpenChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloseChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
 }
 
-func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
-	out := new(GetInfoResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
-	if err != nil {
+type Lightning_CloseChannelServer interface {
+	Send(*CloseStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningCloseChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingChannelRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LightningServer).PendingChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
-	if err != nil {
+func _Lightning_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &lightningOpenChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(LightningServer).BumpFee(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BumpFee",
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Lightning_OpenChannelClient interface {
-	Recv() (*OpenStatusUpdate, error)
-	grpc.ClientStream
+func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
 }
 
-type lightningOpenChannelClient struct {
-	grpc.ClientStream
+type Lightning_SendPaymentServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
 }
 
-func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
-	m := new(OpenStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+type lightningSendPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
-	if err != nil {
+func _Lightning_ShowRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShowRoutingTableRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &lightningCloseChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(LightningServer).ShowRoutingTable(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ShowRoutingTable",
 	}
-	return x, nil
-}
-
-type Lightning_CloseChannelClient interface {
-	Recv() (*CloseStatusUpdate, error)
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ShowRoutingTable(ctx, req.(*ShowRoutingTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type lightningCloseChannelClient struct {
-	grpc.ClientStream
+func _Lightning_DropGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DropGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DropGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DropGraph(ctx, req.(*DropGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
-	m := new(CloseStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _Lightning_ExportGraphSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGraphSnapshotRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(LightningServer).ExportGraphSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportGraphSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportGraphSnapshot(ctx, req.(*ExportGraphSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelRequest, opts ...grpc.CallOption) (*PendingChannelResponse, error) {
-	out := new(PendingChannelResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
-	if err != nil {
+func _Lightning_ImportGraphSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGraphSnapshotRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LightningServer).ImportGraphSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportGraphSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportGraphSnapshot(ctx, req.(*ImportGraphSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
-	if err != nil {
+func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRoutesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &lightningSendPaymentClient{stream}
-	return x, nil
+	if interceptor == nil {
+		return srv.(LightningServer).QueryRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryRoutes(ctx, req.(*QueryRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type Lightning_SendPaymentClient interface {
-	Send(*SendRequest) error
-	Recv() (*SendResponse, error)
-	grpc.ClientStream
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
 }
 
-type lightningSendPaymentClient struct {
-	grpc.ClientStream
+type Lightning_TrackPaymentServer interface {
+	Send(*PaymentUpdate) error
+	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
-	return x.ClientStream.SendMsg(m)
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
-	m := new(SendResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (x *lightningTrackPaymentServer) Send(m *PaymentUpdate) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *lightningClient) ShowRoutingTable(ctx context.Context, in *ShowRoutingTableRequest, opts ...grpc.CallOption) (*ShowRoutingTableResponse, error) {
-	out := new(ShowRoutingTableResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ShowRoutingTable", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func _Lightning_SubscribeHtlcEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHtlcEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(LightningServer).SubscribeHtlcEvents(m, &lightningSubscribeHtlcEventsServer{stream})
 }
 
-// Server API for Lightning service
+type Lightning_SubscribeHtlcEventsServer interface {
+	Send(*HtlcEvent) error
+	grpc.ServerStream
+}
 
-type LightningServer interface {
-	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
-	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
-	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
-	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
-	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
-	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
-	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
-	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
-	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
-	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
-	PendingChannels(context.Context, *PendingChannelRequest) (*PendingChannelResponse, error)
-	SendPayment(Lightning_SendPaymentServer) error
-	ShowRoutingTable(context.Context, *ShowRoutingTableRequest) (*ShowRoutingTableResponse, error)
+type lightningSubscribeHtlcEventsServer struct {
+	grpc.ServerStream
 }
 
-func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
-	s.RegisterService(&_Lightning_serviceDesc, srv)
+func (x *lightningSubscribeHtlcEventsServer) Send(m *HtlcEvent) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletBalanceRequest)
+func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).WalletBalance(ctx, in)
+		return srv.(LightningServer).GetNodeInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/WalletBalance",
+		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelBalanceRequest)
+func _Lightning_GetNodeMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeMetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ChannelBalance(ctx, in)
+		return srv.(LightningServer).GetNodeMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+		FullMethod: "/lnrpc.Lightning/GetNodeMetrics",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+		return srv.(LightningServer).GetNodeMetrics(ctx, req.(*NodeMetricsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendManyRequest)
+func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendMany(ctx, in)
+		return srv.(LightningServer).GetChanInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendMany",
+		FullMethod: "/lnrpc.Lightning/GetChanInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendCoinsRequest)
+func _Lightning_LookupChannelID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendCoins(ctx, in)
+		return srv.(LightningServer).LookupChannelID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendCoins",
+		FullMethod: "/lnrpc.Lightning/LookupChannelID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+		return srv.(LightningServer).LookupChannelID(ctx, req.(*ChannelIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewAddressRequest)
+func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).NewAddress(ctx, in)
+		return srv.(LightningServer).FeeReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewAddress",
+		FullMethod: "/lnrpc.Lightning/FeeReport",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+		return srv.(LightningServer).FeeReport(ctx, req.(*FeeReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectPeerRequest)
+func _Lightning_ListMacaroonIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMacaroonIDsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ConnectPeer(ctx, in)
+		return srv.(LightningServer).ListMacaroonIDs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+		FullMethod: "/lnrpc.Lightning/ListMacaroonIDs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+		return srv.(LightningServer).ListMacaroonIDs(ctx, req.(*ListMacaroonIDsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPeersRequest)
+func _Lightning_DeleteMacaroonID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMacaroonIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListPeers(ctx, in)
+		return srv.(LightningServer).DeleteMacaroonID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPeers",
+		FullMethod: "/lnrpc.Lightning/DeleteMacaroonID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+		return srv.(LightningServer).DeleteMacaroonID(ctx, req.(*DeleteMacaroonIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInfoRequest)
+func _Lightning_RotateMacaroonRootKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateMacaroonRootKeyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetInfo(ctx, in)
+		return srv.(LightningServer).RotateMacaroonRootKey(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetInfo",
+		FullMethod: "/lnrpc.Lightning/RotateMacaroonRootKey",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+		return srv.(LightningServer).RotateMacaroonRootKey(ctx, req.(*RotateMacaroonRootKeyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(OpenChannelRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Lightning_BakeMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BakeMacaroonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
-}
-
-type Lightning_OpenChannelServer interface {
-	Send(*OpenStatusUpdate) error
-	grpc.ServerStream
-}
-
-type lightningOpenChannelServer struct {
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(LightningServer).BakeMacaroon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/BakeMacaroon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).BakeMacaroon(ctx, req.(*BakeMacaroonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
-	return x.ServerStream.SendMsg(m)
+func _Lightning_SendOnionMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendOnionMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendOnionMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendOnionMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendOnionMessage(ctx, req.(*SendOnionMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CloseChannelRequest)
+func _Lightning_SubscribeOnionMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOnionMessagesRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+	return srv.(LightningServer).SubscribeOnionMessages(m, &lightningSubscribeOnionMessagesServer{stream})
 }
 
-type Lightning_CloseChannelServer interface {
-	Send(*CloseStatusUpdate) error
+type Lightning_SubscribeOnionMessagesServer interface {
+	Send(*OnionMessageUpdate) error
 	grpc.ServerStream
 }
 
-type lightningCloseChannelServer struct {
+type lightningSubscribeOnionMessagesServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+func (x *lightningSubscribeOnionMessagesServer) Send(m *OnionMessageUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PendingChannelRequest)
+func _Lightning_SendCustomMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCustomMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).PendingChannels(ctx, in)
+		return srv.(LightningServer).SendCustomMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/PendingChannels",
+		FullMethod: "/lnrpc.Lightning/SendCustomMessage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelRequest))
+		return srv.(LightningServer).SendCustomMessage(ctx, req.(*SendCustomMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+func _Lightning_SubscribeCustomMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCustomMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeCustomMessages(m, &lightningSubscribeCustomMessagesServer{stream})
 }
 
-type Lightning_SendPaymentServer interface {
-	Send(*SendResponse) error
-	Recv() (*SendRequest, error)
+type Lightning_SubscribeCustomMessagesServer interface {
+	Send(*CustomMessage) error
 	grpc.ServerStream
 }
 
-type lightningSendPaymentServer struct {
+type lightningSubscribeCustomMessagesServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+func (x *lightningSubscribeCustomMessagesServer) Send(m *CustomMessage) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
-	m := new(SendRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
-
-func _Lightning_ShowRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShowRoutingTableRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).ShowRoutingTable(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ShowRoutingTable",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ShowRoutingTable(ctx, req.(*ShowRoutingTableRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
 var _Lightning_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lnrpc.Lightning",
 	HandlerType: (*LightningServer)(nil),
@@ -1403,6 +6265,50 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewAddress",
 			Handler:    _Lightning_NewAddress_Handler,
 		},
+		{
+			MethodName: "ListAddresses",
+			Handler:    _Lightning_ListAddresses_Handler,
+		},
+		{
+			MethodName: "ListUnspent",
+			Handler:    _Lightning_ListUnspent_Handler,
+		},
+		{
+			MethodName: "ConsolidateUtxos",
+			Handler:    _Lightning_ConsolidateUtxos_Handler,
+		},
+		{
+			MethodName: "LeaseOutput",
+			Handler:    _Lightning_LeaseOutput_Handler,
+		},
+		{
+			MethodName: "ReleaseOutput",
+			Handler:    _Lightning_ReleaseOutput_Handler,
+		},
+		{
+			MethodName: "ListLeases",
+			Handler:    _Lightning_ListLeases_Handler,
+		},
+		{
+			MethodName: "ImportPublicKey",
+			Handler:    _Lightning_ImportPublicKey_Handler,
+		},
+		{
+			MethodName: "ImportAddress",
+			Handler:    _Lightning_ImportAddress_Handler,
+		},
+		{
+			MethodName: "GetRecoveryInfo",
+			Handler:    _Lightning_GetRecoveryInfo_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Lightning_GetTransactions_Handler,
+		},
+		{
+			MethodName: "LabelTransaction",
+			Handler:    _Lightning_LabelTransaction_Handler,
+		},
 		{
 			MethodName: "ConnectPeer",
 			Handler:    _Lightning_ConnectPeer_Handler,
@@ -1411,20 +6317,107 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListPeers",
 			Handler:    _Lightning_ListPeers_Handler,
 		},
+		{
+			MethodName: "ListChannels",
+			Handler:    _Lightning_ListChannels_Handler,
+		},
 		{
 			MethodName: "GetInfo",
 			Handler:    _Lightning_GetInfo_Handler,
 		},
+		{
+			MethodName: "UpdateConfig",
+			Handler:    _Lightning_UpdateConfig_Handler,
+		},
 		{
 			MethodName: "PendingChannels",
 			Handler:    _Lightning_PendingChannels_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Lightning_BumpFee_Handler,
+		},
 		{
 			MethodName: "ShowRoutingTable",
 			Handler:    _Lightning_ShowRoutingTable_Handler,
 		},
+		{
+			MethodName: "DropGraph",
+			Handler:    _Lightning_DropGraph_Handler,
+		},
+		{
+			MethodName: "ExportGraphSnapshot",
+			Handler:    _Lightning_ExportGraphSnapshot_Handler,
+		},
+		{
+			MethodName: "ImportGraphSnapshot",
+			Handler:    _Lightning_ImportGraphSnapshot_Handler,
+		},
+		{
+			MethodName: "QueryRoutes",
+			Handler:    _Lightning_QueryRoutes_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _Lightning_GetNodeInfo_Handler,
+		},
+		{
+			MethodName: "GetNodeMetrics",
+			Handler:    _Lightning_GetNodeMetrics_Handler,
+		},
+		{
+			MethodName: "GetChanInfo",
+			Handler:    _Lightning_GetChanInfo_Handler,
+		},
+		{
+			MethodName: "LookupChannelID",
+			Handler:    _Lightning_LookupChannelID_Handler,
+		},
+		{
+			MethodName: "FeeReport",
+			Handler:    _Lightning_FeeReport_Handler,
+		},
+		{
+			MethodName: "ListMacaroonIDs",
+			Handler:    _Lightning_ListMacaroonIDs_Handler,
+		},
+		{
+			MethodName: "DeleteMacaroonID",
+			Handler:    _Lightning_DeleteMacaroonID_Handler,
+		},
+		{
+			MethodName: "RotateMacaroonRootKey",
+			Handler:    _Lightning_RotateMacaroonRootKey_Handler,
+		},
+		{
+			MethodName: "BakeMacaroon",
+			Handler:    _Lightning_BakeMacaroon_Handler,
+		},
+		{
+			MethodName: "SendOnionMessage",
+			Handler:    _Lightning_SendOnionMessage_Handler,
+		},
+		{
+			MethodName: "SendCustomMessage",
+			Handler:    _Lightning_SendCustomMessage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RescanWallet",
+			Handler:       _Lightning_RescanWallet_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeTransactions",
+			Handler:       _Lightning_SubscribeTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribePeerEvents",
+			Handler:       _Lightning_SubscribePeerEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "OpenChannel",
 			Handler:       _Lightning_OpenChannel_Handler,
@@ -1441,129 +6434,426 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeHtlcEvents",
+			Handler:       _Lightning_SubscribeHtlcEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOnionMessages",
+			Handler:       _Lightning_SubscribeOnionMessages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeCustomMessages",
+			Handler:       _Lightning_SubscribeCustomMessages_Handler,
+			ServerStreams: true,
+		},
 	},
-	Metadata: fileDescriptor0,
+	Metadata: "rpc.proto",
 }
 
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1863 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x58, 0x5f, 0x73, 0xd3, 0xca,
-	0x15, 0xbf, 0xfe, 0x17, 0xdb, 0x47, 0x8e, 0xe3, 0x6c, 0xfe, 0x39, 0xbe, 0xdc, 0x4b, 0x51, 0x69,
-	0x49, 0x0b, 0x93, 0x01, 0x33, 0xd3, 0x06, 0x98, 0x81, 0x49, 0x42, 0x20, 0x29, 0x26, 0x49, 0xe5,
-	0x30, 0x4c, 0x9f, 0x54, 0x59, 0xde, 0x60, 0x0d, 0xb2, 0xe4, 0x5a, 0x6b, 0x82, 0x79, 0xee, 0xb4,
-	0x5f, 0xa0, 0x8f, 0x1d, 0xa6, 0xcf, 0xfd, 0x06, 0xfd, 0x18, 0x7d, 0xea, 0x5b, 0x3f, 0x4b, 0xcf,
-	0xfe, 0x93, 0x25, 0xd9, 0x01, 0xa6, 0x73, 0x9f, 0xac, 0xfd, 0xed, 0xd9, 0xb3, 0xe7, 0xff, 0x39,
-	0x6b, 0xa8, 0x8e, 0x47, 0xee, 0xee, 0x68, 0x1c, 0xb2, 0x90, 0x94, 0xfc, 0x00, 0x17, 0x66, 0x04,
-	0x46, 0x97, 0x06, 0x7d, 0x8b, 0xfe, 0x69, 0x42, 0x23, 0x46, 0x08, 0x14, 0xfb, 0xf8, 0xdb, 0xcc,
-	0xfd, 0x2c, 0xb7, 0x53, 0xb3, 0xc4, 0x37, 0x69, 0x40, 0xc1, 0x19, 0xb2, 0x66, 0x1e, 0xa1, 0x82,
-	0xc5, 0x3f, 0xc9, 0x2d, 0xa8, 0x8d, 0x9c, 0xe9, 0x90, 0x06, 0xcc, 0x1e, 0x38, 0xd1, 0xa0, 0x59,
-	0x10, 0xd4, 0x86, 0xc2, 0x8e, 0x11, 0x22, 0xdf, 0x43, 0xf5, 0xd2, 0x89, 0x98, 0x1d, 0x21, 0xf3,
-	0x66, 0x11, 0xf7, 0x2b, 0x56, 0x85, 0x03, 0xfc, 0x32, 0xb3, 0x0e, 0x35, 0x79, 0x69, 0x34, 0x0a,
-	0x83, 0x88, 0x9a, 0x17, 0x50, 0x3b, 0x1c, 0x38, 0x41, 0x40, 0xfd, 0xf3, 0xd0, 0x0b, 0x04, 0xff,
-	0xcb, 0x49, 0xd0, 0xf7, 0x82, 0x77, 0x36, 0xfb, 0xe8, 0xf5, 0x95, 0x34, 0x86, 0xc2, 0x2e, 0x10,
-	0xe2, 0x24, 0xe1, 0x84, 0x8d, 0x26, 0xcc, 0xf6, 0x82, 0x3e, 0xfd, 0x28, 0xa4, 0x5b, 0xb6, 0x0c,
-	0x89, 0x9d, 0x70, 0xc8, 0x7c, 0x01, 0x8d, 0x8e, 0xf7, 0x6e, 0xc0, 0x02, 0x3c, 0xb3, 0xdf, 0xef,
-	0x8f, 0x69, 0x14, 0x91, 0x1f, 0x01, 0x46, 0x93, 0xde, 0x2b, 0x3a, 0xe5, 0x42, 0x0a, 0xbe, 0x55,
-	0x2b, 0x81, 0x70, 0xfd, 0x07, 0x61, 0x24, 0x95, 0xad, 0x5a, 0xe2, 0xdb, 0xfc, 0x47, 0x0e, 0x56,
-	0xb8, 0xb8, 0xaf, 0x9d, 0x60, 0xaa, 0xed, 0xd4, 0x81, 0x1a, 0x67, 0x79, 0x11, 0xee, 0x0f, 0xc3,
-	0x49, 0xc0, 0xed, 0x55, 0xd8, 0x31, 0xda, 0x3b, 0xbb, 0xc2, 0xa8, 0xbb, 0x19, 0xea, 0xdd, 0x24,
-	0xe9, 0x51, 0xc0, 0xc6, 0x53, 0xab, 0xe6, 0x24, 0xa0, 0xd6, 0x33, 0x58, 0x9d, 0x23, 0xe1, 0x66,
-	0x7f, 0x4f, 0xa7, 0x4a, 0x46, 0xfe, 0x49, 0xd6, 0xa1, 0xf4, 0xc1, 0xf1, 0x27, 0x54, 0xb9, 0x42,
-	0x2e, 0x1e, 0xe7, 0xf7, 0x72, 0xe6, 0x2f, 0xa1, 0x31, 0xbb, 0x53, 0x1a, 0x95, 0xab, 0x12, 0x1b,
-	0x0f, 0x55, 0xe1, 0xdf, 0xe6, 0x53, 0x49, 0x77, 0x88, 0x56, 0x8e, 0x12, 0x2e, 0xe7, 0xc2, 0x68,
-	0x3a, 0xfe, 0x4d, 0x36, 0x61, 0xc9, 0x91, 0x8a, 0xc9, 0xab, 0xd4, 0xca, 0xbc, 0x03, 0xab, 0x89,
-	0xf3, 0x5f, 0xb8, 0xe8, 0x73, 0x0e, 0x56, 0x4f, 0xe9, 0x95, 0x32, 0xbb, 0xbe, 0x6a, 0x0f, 0x29,
-	0xa7, 0x23, 0x2a, 0x28, 0xeb, 0xed, 0xdb, 0xca, 0x5a, 0x73, 0x74, 0xbb, 0x6a, 0x79, 0x81, 0xb4,
-	0x96, 0x38, 0x61, 0x9e, 0x81, 0x91, 0x00, 0xc9, 0x16, 0xac, 0xbd, 0x3d, 0xb9, 0x38, 0x3d, 0xea,
-	0x76, 0xed, 0xf3, 0x37, 0x07, 0xaf, 0x8e, 0xfe, 0x60, 0x1f, 0xef, 0x77, 0x8f, 0x1b, 0xdf, 0xa1,
-	0xe0, 0x04, 0xd1, 0x8b, 0xa3, 0xe7, 0x29, 0x3c, 0x47, 0x56, 0xc0, 0x48, 0x02, 0x79, 0x73, 0x17,
-	0x09, 0x13, 0xf7, 0x2a, 0x55, 0x9a, 0x50, 0x76, 0x24, 0xa4, 0xb4, 0xd1, 0x4b, 0x73, 0x1f, 0xc8,
-	0x61, 0x88, 0x11, 0xea, 0xb2, 0x73, 0x4a, 0xc7, 0x5a, 0xa1, 0xbb, 0x09, 0xdb, 0x19, 0xed, 0x2d,
-	0xa5, 0x50, 0x36, 0xea, 0xa4, 0x51, 0xf1, 0xca, 0xb5, 0x14, 0x0b, 0x75, 0xe7, 0x16, 0x94, 0x47,
-	0xb8, 0xb6, 0x95, 0x05, 0x4b, 0xd6, 0x12, 0x5f, 0x9e, 0xf4, 0xcd, 0x3f, 0x42, 0xf1, 0xf8, 0xa2,
-	0x73, 0x48, 0xea, 0x90, 0x57, 0x7b, 0x05, 0x0b, 0xbf, 0xae, 0x73, 0x0e, 0x4f, 0x39, 0x9e, 0x8d,
-	0xb6, 0x1f, 0xba, 0xef, 0x55, 0x4a, 0x56, 0x38, 0xd0, 0xc1, 0x35, 0x59, 0x83, 0x12, 0x0b, 0xed,
-	0x49, 0xa4, 0x72, 0xb1, 0xc8, 0xc2, 0x37, 0x91, 0xf9, 0xaf, 0x3c, 0x2c, 0xef, 0xbb, 0xcc, 0xfb,
-	0x40, 0x55, 0xfa, 0x71, 0x1e, 0x63, 0x3a, 0x0c, 0x19, 0xb5, 0x63, 0x87, 0x56, 0x24, 0x70, 0xd2,
-	0x27, 0x3f, 0x87, 0x65, 0x57, 0xd2, 0xd9, 0x23, 0x9e, 0xa7, 0x2a, 0x4b, 0x6a, 0x6e, 0x32, 0x77,
-	0x5b, 0x50, 0x71, 0x9d, 0x91, 0xe3, 0x7a, 0x6c, 0x2a, 0x84, 0x28, 0x58, 0xf1, 0x9a, 0x33, 0x40,
-	0xe1, 0x1c, 0xdf, 0xee, 0x39, 0xbe, 0x13, 0xb8, 0x54, 0x08, 0x53, 0xb0, 0x6a, 0x02, 0x3c, 0x90,
-	0x18, 0xf9, 0x05, 0xd4, 0x95, 0x08, 0x9a, 0xaa, 0x24, 0xa8, 0x96, 0x25, 0xaa, 0xc9, 0xee, 0xc2,
-	0xea, 0x04, 0xcd, 0xc7, 0x98, 0x4f, 0xfb, 0x76, 0x8f, 0x4a, 0xca, 0x25, 0x41, 0xd9, 0x88, 0x37,
-	0x0e, 0x24, 0x4e, 0xee, 0xc3, 0xf2, 0x88, 0xca, 0x82, 0x32, 0x60, 0xbe, 0x1b, 0x35, 0xcb, 0x22,
-	0x5f, 0x0d, 0xe5, 0x30, 0x6e, 0x66, 0xab, 0xa6, 0x28, 0x8e, 0x39, 0x01, 0xb9, 0x09, 0x46, 0x30,
-	0x19, 0xda, 0x93, 0x51, 0xdf, 0x61, 0x34, 0x6a, 0x56, 0x90, 0x71, 0xd1, 0x02, 0x84, 0xde, 0x48,
-	0xc4, 0xfc, 0x7b, 0x1e, 0x8a, 0xdc, 0x8f, 0xbc, 0x12, 0xf9, 0xda, 0xe1, 0x33, 0xab, 0x19, 0x31,
-	0x86, 0x86, 0x4b, 0xb8, 0x38, 0x9f, 0x74, 0x71, 0x32, 0xde, 0x0a, 0xa9, 0x78, 0x23, 0x3f, 0x00,
-	0xf4, 0xa6, 0x78, 0x0f, 0x2f, 0xa0, 0x4c, 0xd8, 0xa9, 0x68, 0x55, 0x05, 0x82, 0x09, 0xc8, 0x66,
-	0xdb, 0x63, 0xea, 0x7e, 0x10, 0x06, 0xd2, 0xdb, 0x16, 0x02, 0x64, 0x1b, 0x2a, 0x91, 0xc3, 0xe4,
-	0x59, 0x69, 0x93, 0x32, 0xae, 0xc5, 0x49, 0xb5, 0x25, 0xce, 0x95, 0xe3, 0x2d, 0x71, 0x0a, 0xa5,
-	0xf1, 0x82, 0x1e, 0xc6, 0x52, 0x5f, 0xe8, 0x5b, 0xb1, 0xf4, 0x12, 0xed, 0x57, 0x51, 0x4e, 0x8e,
-	0x9a, 0x55, 0x61, 0xba, 0x75, 0x65, 0xba, 0x54, 0xf8, 0x58, 0x31, 0x95, 0x49, 0x78, 0xf1, 0x8d,
-	0x44, 0xa4, 0xeb, 0xb4, 0x36, 0x7f, 0x03, 0xab, 0x09, 0x4c, 0x85, 0xff, 0x2d, 0x28, 0x71, 0x63,
-	0x44, 0xaa, 0x84, 0x6a, 0x97, 0x88, 0x14, 0x91, 0x3b, 0x66, 0x03, 0xea, 0x2f, 0x29, 0x16, 0xf5,
-	0xcb, 0x50, 0x73, 0xfa, 0x2f, 0x96, 0xe4, 0x18, 0x8a, 0x19, 0x7d, 0xd5, 0x0f, 0xbf, 0x82, 0x86,
-	0xd7, 0x47, 0x23, 0x60, 0x2c, 0xda, 0xda, 0xee, 0x32, 0x86, 0x57, 0x34, 0xae, 0x1b, 0xc5, 0x7d,
-	0x58, 0xe7, 0xfe, 0xd7, 0x51, 0x13, 0x6b, 0x5f, 0x10, 0x7d, 0x86, 0xe0, 0xde, 0xb9, 0xdc, 0x52,
-	0xaa, 0x47, 0x04, 0xd3, 0x9b, 0x9f, 0x70, 0x84, 0x41, 0x66, 0x07, 0x8a, 0xe2, 0xc0, 0x2a, 0x6e,
-	0xa5, 0x4c, 0x15, 0xf1, 0x54, 0x93, 0x37, 0x70, 0xe5, 0x4b, 0x82, 0xaa, 0x22, 0xd8, 0x72, 0x95,
-	0x3f, 0x89, 0x72, 0x73, 0xe9, 0x8d, 0x87, 0x0e, 0xf3, 0xc2, 0x40, 0x06, 0x1d, 0x3f, 0xd2, 0xe3,
-	0xd9, 0x6d, 0x47, 0x03, 0x47, 0x35, 0xc5, 0x8a, 0x00, 0xba, 0x03, 0x87, 0xeb, 0x2f, 0x37, 0x07,
-	0x94, 0xab, 0xac, 0x22, 0xcd, 0x10, 0xd8, 0xb1, 0x80, 0xc8, 0x6d, 0xa8, 0xf3, 0x2b, 0x5d, 0xe4,
-	0x1c, 0xd9, 0x3e, 0xbd, 0x64, 0x4a, 0x9d, 0x1a, 0xa2, 0xfc, 0xba, 0xa8, 0x83, 0x98, 0xf9, 0x1a,
-	0x56, 0x95, 0x90, 0x67, 0xa8, 0xbe, 0xba, 0x7a, 0x2f, 0x9b, 0xfb, 0xb2, 0xe4, 0xad, 0x29, 0x77,
-	0x25, 0xdb, 0x77, 0xba, 0x20, 0x98, 0xbf, 0x47, 0x55, 0xe4, 0xfa, 0xd0, 0x0f, 0x23, 0xaa, 0xf8,
-	0xa1, 0xb4, 0x2e, 0x2e, 0xb3, 0x2d, 0x5e, 0x61, 0xa2, 0xc5, 0x63, 0x38, 0x46, 0x13, 0xd7, 0xd5,
-	0x4e, 0xc2, 0x70, 0x54, 0x4b, 0xf3, 0xcf, 0x39, 0x2c, 0xa5, 0x9c, 0x99, 0x8e, 0xbb, 0xb8, 0xbf,
-	0xfc, 0x9f, 0x42, 0xf2, 0x7c, 0x62, 0xde, 0x90, 0xda, 0xbe, 0x37, 0xf4, 0x74, 0x5d, 0xad, 0x72,
-	0xa4, 0xc3, 0x01, 0xde, 0x79, 0x2f, 0xc3, 0x31, 0x16, 0x98, 0x82, 0x10, 0x44, 0x2e, 0xcc, 0xff,
-	0x60, 0x93, 0x13, 0x62, 0x74, 0x99, 0xc3, 0x26, 0x91, 0xd2, 0xec, 0x09, 0x0a, 0xc1, 0x41, 0x1d,
-	0x3b, 0x4a, 0x88, 0xf5, 0x38, 0xb0, 0x05, 0x2a, 0x89, 0x8f, 0xbf, 0xb3, 0x84, 0x19, 0xa8, 0x42,
-	0xc9, 0x33, 0x34, 0x4b, 0xc2, 0xef, 0x42, 0x12, 0xa3, 0xbd, 0xad, 0x15, 0x98, 0x0b, 0x09, 0xc1,
-	0x20, 0x81, 0x92, 0xc7, 0x00, 0x5c, 0x31, 0x5b, 0x70, 0x15, 0xe2, 0x26, 0x8e, 0xcf, 0xb9, 0x01,
-	0x8f, 0x57, 0x39, 0xb9, 0x80, 0x0e, 0x2a, 0xb0, 0x24, 0xeb, 0x9d, 0x89, 0x85, 0x3a, 0x25, 0x67,
-	0xaa, 0xc7, 0xd7, 0x54, 0x8f, 0xff, 0x6b, 0x1e, 0x08, 0x8f, 0x90, 0x8c, 0x13, 0x30, 0xc8, 0x98,
-	0x33, 0x7e, 0x47, 0x99, 0x9d, 0x6e, 0x6b, 0x35, 0x89, 0x9e, 0xcb, 0xca, 0x87, 0xf5, 0x55, 0x51,
-	0x05, 0x61, 0x5f, 0x4e, 0x34, 0x35, 0x0b, 0x24, 0x74, 0x8a, 0x08, 0x4f, 0x40, 0xd9, 0x2b, 0xf4,
-	0x24, 0xa8, 0x7a, 0x9e, 0xec, 0x29, 0x44, 0xec, 0xbd, 0x90, 0x5b, 0x72, 0x6a, 0x22, 0x6d, 0xd8,
-	0x50, 0x8d, 0x23, 0x73, 0x44, 0x76, 0x99, 0x35, 0xb9, 0x99, 0x3e, 0x73, 0x07, 0x56, 0xdc, 0x70,
-	0x38, 0xf4, 0xa2, 0x08, 0x8d, 0x67, 0x47, 0xde, 0x27, 0xdd, 0x6d, 0xea, 0x33, 0xb8, 0x8b, 0xa8,
-	0xce, 0x56, 0x91, 0x3a, 0xa2, 0xa4, 0xca, 0x6c, 0x15, 0x59, 0x63, 0xfe, 0x3b, 0x07, 0x0d, 0x6e,
-	0x89, 0x54, 0x1c, 0x3c, 0x02, 0x11, 0x62, 0xdf, 0x18, 0x06, 0x06, 0xa7, 0xfd, 0xc9, 0xa2, 0xe0,
-	0xb7, 0x20, 0xdc, 0x6a, 0x87, 0x78, 0xb9, 0x0a, 0x82, 0x66, 0x3a, 0x08, 0x66, 0xa9, 0x8d, 0x87,
-	0x45, 0xd9, 0xe6, 0x48, 0x22, 0x04, 0x8e, 0x60, 0x23, 0x5d, 0xe1, 0xb4, 0x7f, 0xef, 0xc1, 0x52,
-	0x24, 0xf4, 0x54, 0x63, 0xdc, 0x7a, 0x9a, 0xb1, 0xb4, 0x81, 0xa5, 0x68, 0xcc, 0xcf, 0x05, 0xd8,
-	0xcc, 0xf2, 0x51, 0x05, 0xfb, 0x2d, 0x34, 0xe6, 0xca, 0xab, 0x6c, 0x02, 0xf7, 0xd2, 0x46, 0xca,
-	0x1c, 0xcc, 0xc2, 0x2b, 0xa3, 0x74, 0x25, 0x6e, 0xfd, 0x33, 0x0f, 0xf5, 0x34, 0xcd, 0xb5, 0x43,
-	0xd6, 0x5c, 0xd7, 0xc8, 0xcf, 0x77, 0x8d, 0xb9, 0xb1, 0xa7, 0xf0, 0x95, 0xb1, 0xa7, 0xf8, 0xb5,
-	0xb1, 0xa7, 0xf4, 0x4d, 0x63, 0xcf, 0xd2, 0xa2, 0xb1, 0x27, 0x5b, 0x37, 0xcb, 0x52, 0xde, 0x64,
-	0xdd, 0x9c, 0x39, 0xa8, 0xf2, 0x0d, 0x0e, 0x7a, 0x04, 0xeb, 0x6f, 0x1d, 0xdf, 0xa7, 0x4c, 0xdd,
-	0xa0, 0xdd, 0x8c, 0x17, 0x5d, 0x79, 0x2c, 0xc0, 0x72, 0x6b, 0x87, 0x81, 0x2f, 0xdf, 0x21, 0x15,
-	0xcb, 0x50, 0xd8, 0x19, 0x42, 0xe6, 0x03, 0xd8, 0xc8, 0x1c, 0x9d, 0x8d, 0xd1, 0x5a, 0x09, 0x7e,
-	0x2c, 0x67, 0xe9, 0xa5, 0xb9, 0x05, 0x1b, 0x4a, 0x8c, 0xf4, 0x75, 0x66, 0x1b, 0x36, 0xb3, 0x1b,
-	0x8b, 0x99, 0x15, 0x66, 0xcc, 0xfe, 0x82, 0x69, 0x67, 0xe1, 0x8b, 0x8f, 0x2b, 0xee, 0xf4, 0x7c,
-	0xac, 0xd5, 0xc1, 0x7b, 0xfe, 0x6c, 0xf2, 0xfa, 0x0f, 0xf4, 0xb3, 0x09, 0x3f, 0x25, 0xd2, 0x56,
-	0x9e, 0xe5, 0x9f, 0xdc, 0x59, 0xfc, 0xa1, 0x98, 0x70, 0x66, 0xbc, 0xfe, 0xa2, 0x23, 0x71, 0xf2,
-	0xbe, 0x92, 0xcd, 0xb5, 0x24, 0xd4, 0x52, 0x2b, 0x73, 0x1b, 0xb6, 0xba, 0x83, 0xf0, 0x2a, 0x29,
-	0x8b, 0xd6, 0xeb, 0x0c, 0x9a, 0xf3, 0x5b, 0x4a, 0xb3, 0x87, 0x89, 0xa9, 0x4a, 0x06, 0xbe, 0x7e,
-	0x41, 0x64, 0xb5, 0x9a, 0x0d, 0x56, 0xbf, 0x6e, 0xc3, 0x72, 0xca, 0x91, 0xa4, 0x0c, 0x85, 0xfd,
-	0x4e, 0x07, 0xdf, 0x3e, 0x06, 0x94, 0xcf, 0xce, 0x8f, 0x4e, 0x4f, 0x4e, 0x5f, 0xe2, 0x83, 0x07,
-	0x17, 0x87, 0x9d, 0xb3, 0x2e, 0x5f, 0xe4, 0xdb, 0x7f, 0x2b, 0x43, 0x35, 0x7e, 0x94, 0x90, 0xdf,
-	0xc1, 0x72, 0xca, 0x6d, 0xe4, 0x7b, 0x75, 0xeb, 0xa2, 0x38, 0x68, 0xdd, 0x58, 0xbc, 0xa9, 0x54,
-	0x78, 0x0d, 0xf5, 0xb4, 0xdb, 0xc8, 0x8d, 0x74, 0xb4, 0x65, 0xb8, 0xfd, 0x70, 0xcd, 0xae, 0x62,
-	0xf7, 0x04, 0x2a, 0xfa, 0x1d, 0x4b, 0x36, 0x17, 0x3f, 0xa6, 0x5b, 0x5b, 0x73, 0xb8, 0x3a, 0xfc,
-	0x14, 0xaa, 0xf1, 0xe3, 0x94, 0x24, 0xa9, 0x92, 0xcf, 0xdd, 0x56, 0x73, 0x7e, 0x43, 0x9d, 0xdf,
-	0x07, 0x98, 0x3d, 0x09, 0x49, 0xf3, 0xba, 0xd7, 0x69, 0x6b, 0x7b, 0xc1, 0x8e, 0x62, 0xf1, 0x1c,
-	0x8c, 0xc4, 0x13, 0x8f, 0x24, 0x2a, 0x76, 0xe6, 0xe5, 0xd8, 0x6a, 0x2d, 0xda, 0x9a, 0x29, 0x12,
-	0xcf, 0xc9, 0x64, 0xf6, 0xa8, 0x4c, 0x4f, 0xd3, 0xb1, 0x22, 0xf3, 0x23, 0xf5, 0x1e, 0x94, 0xd5,
-	0x70, 0x4c, 0x36, 0x14, 0x51, 0x7a, 0x7e, 0x6e, 0x6d, 0x66, 0x61, 0x75, 0xf2, 0x10, 0x8c, 0x44,
-	0x47, 0x8f, 0xe5, 0x9f, 0xef, 0xf2, 0xb1, 0x17, 0xb2, 0x6d, 0xef, 0x7e, 0x8e, 0xbc, 0x80, 0x5a,
-	0x72, 0x38, 0x23, 0xb1, 0xaa, 0xf3, 0x13, 0x5b, 0xac, 0xc4, 0xdc, 0x18, 0x85, 0x7c, 0x4e, 0x61,
-	0x25, 0x3b, 0x63, 0xdf, 0xb8, 0xa6, 0x31, 0xa4, 0x83, 0xeb, 0x9a, 0x7e, 0xf3, 0x58, 0xfe, 0xd5,
-	0x75, 0x2e, 0xff, 0xa5, 0x22, 0x24, 0x11, 0x08, 0x9a, 0xc3, 0x5a, 0x0a, 0x93, 0xe7, 0x76, 0x72,
-	0x28, 0x4b, 0x17, 0x1a, 0xd9, 0x34, 0x26, 0x3f, 0x6a, 0xe2, 0xc5, 0xa9, 0xdf, 0xba, 0x79, 0xed,
-	0xbe, 0x64, 0xdc, 0x5b, 0x12, 0xff, 0xc4, 0x3d, 0xfc, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc2,
-	0xc3, 0x5e, 0x39, 0x96, 0x13, 0x00, 0x00,
+	// 6296 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x7c, 0xcd, 0x6f, 0x23, 0xc9,
+	0x75, 0xf8, 0x34, 0x49, 0x49, 0xe4, 0x23, 0x29, 0x51, 0xa5, 0x2f, 0x4e, 0xcf, 0xec, 0x48, 0xdb,
+	0xbb, 0xeb, 0x9d, 0xfd, 0xf8, 0xc9, 0x63, 0xd9, 0x5e, 0xcf, 0x7a, 0x7f, 0x3f, 0x7b, 0x35, 0x12,
+	0x35, 0xa2, 0x47, 0x43, 0xc9, 0x2d, 0x8d, 0xd7, 0x8b, 0x9f, 0x81, 0x46, 0x8b, 0x2c, 0x8d, 0x3a,
+	0xd3, 0xec, 0xa6, 0xbb, 0x9b, 0x33, 0xd2, 0x06, 0x08, 0x16, 0x39, 0xc4, 0x40, 0xe0, 0x24, 0xa7,
+	0x20, 0x5f, 0x40, 0x3e, 0x90, 0x20, 0x48, 0x0e, 0x49, 0x0e, 0x41, 0x80, 0x9c, 0x73, 0x0a, 0x90,
+	0x1c, 0x12, 0x20, 0x81, 0x8f, 0xc9, 0x3f, 0x90, 0x73, 0xae, 0xc1, 0xab, 0xaf, 0xae, 0x6e, 0x36,
+	0x25, 0xad, 0xd7, 0xc8, 0x45, 0x60, 0xbd, 0xf7, 0xaa, 0xea, 0x55, 0xd5, 0xab, 0x57, 0xef, 0xab,
+	0x05, 0xb5, 0x68, 0xd4, 0xdf, 0x1c, 0x45, 0x61, 0x12, 0x92, 0x19, 0x3f, 0x88, 0x46, 0x7d, 0xeb,
+	0xf7, 0x2b, 0x50, 0x3f, 0xa6, 0xc1, 0xc0, 0xa6, 0x3f, 0x1e, 0xd3, 0x38, 0x21, 0x04, 0x2a, 0x03,
+	0x1a, 0x27, 0x6d, 0x63, 0xc3, 0xb8, 0xdf, 0xb0, 0xd9, 0x6f, 0xd2, 0x82, 0xb2, 0x3b, 0x4c, 0xda,
+	0xa5, 0x0d, 0xe3, 0x7e, 0xd9, 0xc6, 0x9f, 0xe4, 0x75, 0x68, 0x8c, 0xdc, 0xcb, 0x21, 0x0d, 0x12,
+	0xe7, 0xdc, 0x8d, 0xcf, 0xdb, 0x65, 0x46, 0x5d, 0x17, 0xb0, 0x7d, 0x37, 0x3e, 0x27, 0x77, 0xa0,
+	0x76, 0xe6, 0xc6, 0x89, 0x13, 0xd3, 0x60, 0xd0, 0xae, 0x6c, 0x18, 0xf7, 0xab, 0x76, 0x15, 0x01,
+	0x38, 0x19, 0x43, 0x52, 0xea, 0xf8, 0xde, 0xd0, 0x4b, 0xda, 0x33, 0x6c, 0xdc, 0xea, 0x19, 0xa5,
+	0x07, 0xd8, 0x26, 0x6f, 0xc3, 0x42, 0xe2, 0x0d, 0x69, 0x38, 0xc6, 0xce, 0xfd, 0x30, 0x18, 0xc4,
+	0xed, 0x59, 0x46, 0x32, 0x2f, 0xc0, 0xc7, 0x1c, 0x4a, 0xee, 0x43, 0xeb, 0xcc, 0x0b, 0x5c, 0xdf,
+	0xe9, 0xfb, 0xc9, 0x4b, 0x67, 0x40, 0xfd, 0xc4, 0x6d, 0xcf, 0x6d, 0x18, 0xf7, 0x9b, 0xf6, 0x3c,
+	0x83, 0xef, 0xf8, 0xc9, 0xcb, 0x5d, 0x84, 0xea, 0xfc, 0xba, 0x83, 0x41, 0xd4, 0xae, 0x66, 0xf8,
+	0xdd, 0x1e, 0x0c, 0x22, 0xf2, 0x29, 0x2c, 0xe1, 0x62, 0x9d, 0xfe, 0x38, 0x4e, 0xc2, 0xa1, 0x13,
+	0xd1, 0x7e, 0x18, 0x0d, 0xe2, 0x76, 0x6d, 0xa3, 0x7c, 0xbf, 0xbe, 0xf5, 0xce, 0x26, 0xdb, 0xad,
+	0x4d, 0x6d, 0xa7, 0x36, 0x77, 0x69, 0x9c, 0xec, 0x30, 0x62, 0x9b, 0xd3, 0x76, 0x82, 0x24, 0xba,
+	0xb4, 0x17, 0x07, 0x79, 0x38, 0x79, 0x0d, 0x80, 0x71, 0xc8, 0x97, 0x0b, 0x8c, 0xc3, 0x1a, 0x42,
+	0xf8, 0x7a, 0xdf, 0x85, 0xc5, 0x70, 0x9c, 0x3c, 0x0f, 0xbd, 0xe0, 0xb9, 0xd3, 0x3f, 0x77, 0x03,
+	0xc7, 0x1b, 0xc4, 0xed, 0xfa, 0x46, 0xf9, 0x7e, 0xc5, 0x5e, 0x90, 0x88, 0x9d, 0x73, 0x37, 0xe8,
+	0x0e, 0x62, 0xf2, 0x15, 0x58, 0xf0, 0x71, 0x57, 0xcf, 0xc3, 0x91, 0x33, 0x1a, 0x9f, 0xbe, 0xa0,
+	0x97, 0xed, 0x06, 0x5b, 0x4b, 0x13, 0xc1, 0xfb, 0xe1, 0xe8, 0x88, 0x01, 0xcd, 0x5d, 0x58, 0x2d,
+	0xe6, 0x0f, 0x0f, 0x13, 0x7b, 0xe1, 0xf9, 0x56, 0x6c, 0xfc, 0x49, 0x96, 0x61, 0xe6, 0xa5, 0xeb,
+	0x8f, 0x29, 0x3b, 0xe0, 0x86, 0xcd, 0x1b, 0xdf, 0x2e, 0x3d, 0x34, 0xac, 0xef, 0x42, 0x83, 0xaf,
+	0x38, 0x1e, 0x85, 0x41, 0x4c, 0xc9, 0x57, 0x61, 0xee, 0xcc, 0xf5, 0xfc, 0x71, 0x44, 0x59, 0xff,
+	0xfa, 0xd6, 0x8a, 0xd8, 0x97, 0x23, 0xbe, 0x91, 0x7b, 0x1c, 0x69, 0x4b, 0x2a, 0x2b, 0x86, 0xf9,
+	0x2c, 0x0a, 0x4f, 0x22, 0x0e, 0xc7, 0x51, 0x9f, 0x3a, 0x5e, 0x30, 0xa0, 0x17, 0x6c, 0x9c, 0xa6,
+	0x5d, 0xe7, 0xb0, 0x2e, 0x82, 0xc8, 0x57, 0xa0, 0xd2, 0x0f, 0x07, 0x9c, 0x9d, 0xf9, 0x2d, 0x22,
+	0xa6, 0x10, 0x03, 0xec, 0x84, 0x03, 0x6a, 0x33, 0x3c, 0x59, 0x85, 0x59, 0x77, 0x18, 0x8e, 0x83,
+	0x84, 0x89, 0x5f, 0xd9, 0x16, 0x2d, 0xeb, 0x04, 0x1a, 0xb8, 0x5d, 0x01, 0xf5, 0x8f, 0x42, 0x2f,
+	0x60, 0xc2, 0x7a, 0x36, 0x0e, 0x06, 0xb8, 0xbd, 0xc9, 0x85, 0x37, 0x10, 0xa2, 0x5d, 0x17, 0xb0,
+	0x93, 0x0b, 0x6f, 0x80, 0x24, 0xe1, 0x38, 0x19, 0x8d, 0x13, 0xc1, 0x55, 0x89, 0x73, 0xc5, 0x61,
+	0x8c, 0x2b, 0x6b, 0x0f, 0x5a, 0x07, 0xde, 0xf3, 0xf3, 0x24, 0xf0, 0x82, 0xe7, 0x28, 0x30, 0x34,
+	0x8e, 0xc9, 0x3d, 0x80, 0xd1, 0xf8, 0xf4, 0x09, 0xbd, 0x44, 0x89, 0x67, 0xe3, 0xd6, 0x6c, 0x0d,
+	0x82, 0x97, 0xe9, 0x3c, 0x8c, 0xf9, 0xcd, 0xa9, 0xd9, 0xec, 0xb7, 0xf5, 0xc7, 0x25, 0xa8, 0x9f,
+	0x44, 0x6e, 0x10, 0xbb, 0xfd, 0xc4, 0x0b, 0x03, 0xb2, 0x06, 0x73, 0xc9, 0x85, 0x73, 0x9e, 0x0e,
+	0x30, 0x9b, 0x5c, 0xb0, 0xce, 0xe9, 0xf2, 0x4a, 0xfa, 0xf2, 0xc8, 0x7b, 0xb0, 0x18, 0x8c, 0x87,
+	0x4e, 0x3f, 0x0c, 0xce, 0xbc, 0x68, 0xe8, 0xe2, 0x20, 0x31, 0xdb, 0x81, 0x19, 0xbb, 0x15, 0x8c,
+	0x87, 0x3b, 0x3a, 0x1c, 0x45, 0xef, 0xd4, 0x0f, 0xfb, 0x2f, 0xf8, 0x04, 0x15, 0x36, 0x41, 0x8d,
+	0x41, 0xd8, 0x1c, 0xaf, 0x43, 0x43, 0xa0, 0x29, 0xae, 0x8d, 0x5d, 0xc5, 0x19, 0xbb, 0xce, 0x09,
+	0x18, 0x08, 0x47, 0xc0, 0x6b, 0xe7, 0xc4, 0x89, 0x3b, 0x1c, 0x89, 0x8b, 0x58, 0x43, 0xc8, 0x31,
+	0x02, 0x18, 0x3a, 0x4c, 0x5c, 0xdf, 0x39, 0xa3, 0x34, 0x6e, 0xcf, 0x09, 0x34, 0x42, 0xf6, 0x28,
+	0x8d, 0x51, 0xb6, 0x7c, 0xf7, 0x94, 0xfa, 0xec, 0xc6, 0xd5, 0x6c, 0xde, 0xc0, 0x4e, 0xaf, 0xdc,
+	0xa4, 0x7f, 0xee, 0x84, 0x81, 0x7f, 0xd9, 0xae, 0x31, 0xe5, 0x50, 0x63, 0x90, 0xc3, 0xc0, 0xbf,
+	0xb4, 0xda, 0xb0, 0xfa, 0x98, 0x26, 0xda, 0x26, 0xc5, 0xe2, 0xce, 0x59, 0x07, 0x40, 0x34, 0xf0,
+	0x2e, 0x4d, 0x5c, 0xcf, 0x8f, 0xc9, 0x07, 0xd0, 0x48, 0x34, 0xe2, 0xb6, 0xc1, 0xee, 0xac, 0x14,
+	0x1c, 0xad, 0x83, 0x9d, 0xa1, 0xb3, 0x3e, 0x37, 0x60, 0xb5, 0x3b, 0x1c, 0x85, 0x51, 0x72, 0x34,
+	0x3e, 0xf5, 0xbd, 0xfe, 0x13, 0x7a, 0x29, 0xd5, 0xe0, 0x6b, 0xec, 0x64, 0x7d, 0xaf, 0xef, 0xc8,
+	0xcb, 0xd2, 0xb0, 0x6b, 0x23, 0x49, 0x45, 0x1e, 0x43, 0xc3, 0xe5, 0x32, 0xe0, 0x24, 0x97, 0x23,
+	0x29, 0xaa, 0x6f, 0x8a, 0x19, 0x7b, 0xf4, 0x95, 0x90, 0x10, 0xa9, 0x2b, 0x44, 0xf3, 0xe4, 0x72,
+	0x44, 0xed, 0xba, 0x9b, 0x36, 0xac, 0xaf, 0xc3, 0xda, 0x04, 0x07, 0xe2, 0xb2, 0xb5, 0x61, 0x4e,
+	0x50, 0x0a, 0xc1, 0x90, 0x4d, 0xeb, 0x01, 0x2c, 0xf3, 0x4e, 0xd9, 0x59, 0xae, 0xe8, 0xb1, 0x06,
+	0x2b, 0xb9, 0x1e, 0x7c, 0x12, 0xcb, 0x85, 0xa6, 0x4d, 0xe3, 0xbe, 0x1b, 0xc8, 0x31, 0xf0, 0x7e,
+	0x26, 0x6e, 0x94, 0x48, 0x89, 0x30, 0xb8, 0x44, 0x30, 0x98, 0x90, 0x88, 0xff, 0x03, 0xe4, 0xd4,
+	0x8b, 0x92, 0xf3, 0x81, 0x7b, 0xe9, 0xa0, 0x20, 0x70, 0xc9, 0xe0, 0x42, 0xba, 0x28, 0x31, 0x27,
+	0x12, 0x61, 0xfd, 0x9e, 0x01, 0x0d, 0x3e, 0xc7, 0xb3, 0xd1, 0xc0, 0x4d, 0xe8, 0x4d, 0xa6, 0x78,
+	0x0b, 0xe6, 0xb1, 0x43, 0x40, 0x07, 0x92, 0xa8, 0xc4, 0x88, 0x9a, 0x02, 0x2a, 0xc8, 0xde, 0x80,
+	0x66, 0xe2, 0x46, 0xcf, 0xa9, 0x1a, 0x8a, 0x5f, 0x83, 0x06, 0x07, 0x0a, 0x22, 0x13, 0xaa, 0xfd,
+	0x70, 0x38, 0xf2, 0x69, 0x42, 0xe5, 0x3b, 0x24, 0xdb, 0x42, 0xd2, 0x50, 0x3f, 0xbe, 0xa4, 0xd1,
+	0x65, 0x37, 0x38, 0x0b, 0xa5, 0xa4, 0xfd, 0xc4, 0x80, 0xb5, 0x09, 0x94, 0x38, 0x99, 0x37, 0xa0,
+	0x19, 0x09, 0xb8, 0x33, 0x44, 0x4d, 0x65, 0xb0, 0x61, 0x1b, 0x12, 0xf8, 0x14, 0xb5, 0xd3, 0x7b,
+	0xb0, 0xa8, 0x88, 0xce, 0xbc, 0xc0, 0x8b, 0xcf, 0xe9, 0x80, 0xad, 0xa2, 0x6a, 0xb7, 0x24, 0x62,
+	0x4f, 0xc0, 0x91, 0xc7, 0x51, 0x14, 0x3e, 0x67, 0x47, 0x87, 0x6b, 0x30, 0x6c, 0xd5, 0xb6, 0xb6,
+	0xa1, 0x7a, 0x38, 0x4e, 0xb8, 0x2a, 0x23, 0x50, 0x51, 0x2a, 0xac, 0x66, 0xb3, 0xdf, 0x37, 0xd1,
+	0x5d, 0x9f, 0x1b, 0x40, 0x0e, 0xa8, 0x1b, 0xd3, 0x43, 0x06, 0x94, 0x67, 0x3d, 0x0f, 0x25, 0xa5,
+	0x0e, 0x4b, 0xde, 0x80, 0xbc, 0x07, 0x55, 0xec, 0x85, 0x33, 0xb1, 0x51, 0xea, 0x5b, 0x0b, 0x42,
+	0xa2, 0x25, 0x03, 0xb6, 0x22, 0x40, 0x29, 0xa0, 0x17, 0x23, 0x2f, 0x62, 0x8a, 0x46, 0x3d, 0xd4,
+	0x65, 0xf6, 0xac, 0x2c, 0xa6, 0x18, 0xf1, 0x56, 0x5b, 0xdf, 0x84, 0xa5, 0x0c, 0x07, 0x62, 0x2b,
+	0xef, 0x01, 0xa4, 0xb4, 0x8c, 0x95, 0xb2, 0xad, 0x41, 0xac, 0x63, 0x58, 0xb6, 0xa9, 0xff, 0x8b,
+	0x65, 0x1d, 0x6f, 0x43, 0x6e, 0x50, 0x71, 0x1b, 0x96, 0x60, 0xf1, 0xc0, 0x8b, 0x13, 0xc6, 0xa8,
+	0xd2, 0x39, 0xbf, 0x04, 0x75, 0x4e, 0xc6, 0xc0, 0x5f, 0x6e, 0xd3, 0xb2, 0xcb, 0x2d, 0x4f, 0x2c,
+	0xf7, 0x63, 0x20, 0x3a, 0x03, 0x62, 0x93, 0xde, 0x85, 0x59, 0xc6, 0x6d, 0x5e, 0xb3, 0x69, 0x6c,
+	0xd9, 0x82, 0xc2, 0x72, 0x61, 0xed, 0x00, 0x75, 0xac, 0xae, 0xf5, 0x52, 0xd3, 0x6e, 0x42, 0x78,
+	0x94, 0x7e, 0x2e, 0xe9, 0xfa, 0xf9, 0x2e, 0xd4, 0x50, 0x3e, 0x5f, 0x45, 0x5e, 0x42, 0x19, 0x97,
+	0x55, 0x3b, 0x05, 0x58, 0x26, 0xb4, 0x27, 0xa7, 0x10, 0x3b, 0xf8, 0x0f, 0x06, 0x2c, 0xa0, 0xc9,
+	0xf0, 0xd4, 0x0d, 0x94, 0x2e, 0x3d, 0x80, 0x06, 0xaa, 0x9d, 0x93, 0x70, 0x9b, 0x3f, 0x67, 0x7c,
+	0x11, 0xf7, 0x35, 0x93, 0x4a, 0xa3, 0xde, 0xd4, 0x49, 0xb9, 0x45, 0xd5, 0x70, 0x35, 0x10, 0xd9,
+	0x80, 0x46, 0xec, 0x26, 0xce, 0x88, 0x46, 0xce, 0xe9, 0x65, 0x42, 0x85, 0xde, 0x81, 0xd8, 0x4d,
+	0x8e, 0x68, 0xf4, 0xe8, 0x32, 0xa1, 0xe6, 0x77, 0x61, 0x71, 0x62, 0x10, 0xdd, 0xec, 0xa9, 0x15,
+	0x98, 0x3d, 0x65, 0xdd, 0xec, 0xf9, 0x0a, 0xb4, 0x52, 0xae, 0xc4, 0x19, 0x14, 0x6c, 0x9e, 0xf5,
+	0xcb, 0x9c, 0x6e, 0x27, 0xf4, 0xd4, 0x0b, 0x85, 0x74, 0xcc, 0xc2, 0x14, 0x74, 0xf8, 0x7b, 0xea,
+	0x4b, 0x9e, 0x5f, 0x4a, 0x39, 0xbf, 0x14, 0x72, 0x1b, 0xaa, 0x31, 0x0d, 0x06, 0x8e, 0xeb, 0xfb,
+	0x42, 0x77, 0xcd, 0x61, 0x7b, 0xdb, 0xf7, 0xad, 0xb7, 0x61, 0x51, 0x9b, 0xfc, 0x0a, 0x2e, 0x7f,
+	0x05, 0xd6, 0x76, 0xc2, 0x20, 0x0e, 0x7d, 0x0f, 0xb5, 0xef, 0xb3, 0xe4, 0x22, 0x54, 0xcc, 0xbe,
+	0x09, 0xf3, 0x43, 0xf7, 0xc2, 0x19, 0x27, 0x17, 0xa1, 0xc3, 0xf7, 0x82, 0xdf, 0xc0, 0xc6, 0xd0,
+	0xbd, 0x40, 0xc2, 0x1f, 0x20, 0xec, 0xfa, 0x1d, 0x47, 0x73, 0x7e, 0xe8, 0x05, 0x6c, 0x1c, 0xae,
+	0x02, 0x9a, 0x76, 0x75, 0xe8, 0x05, 0x6c, 0x2e, 0xeb, 0x53, 0x68, 0x4f, 0xce, 0x3f, 0x9d, 0x5f,
+	0xf2, 0x0e, 0xb4, 0x84, 0x7d, 0x23, 0xfb, 0x0c, 0x84, 0x4e, 0x5b, 0xe0, 0xe6, 0x8d, 0x02, 0x5b,
+	0x7f, 0x68, 0xc0, 0xe2, 0xc4, 0x63, 0x4b, 0x1e, 0x42, 0x85, 0x3d, 0xca, 0xc6, 0x17, 0x78, 0x94,
+	0x59, 0x0f, 0xeb, 0x10, 0xea, 0x1a, 0x90, 0xac, 0xc1, 0xd2, 0x27, 0xdd, 0x93, 0x5e, 0xe7, 0xf8,
+	0xd8, 0x39, 0x7a, 0xf6, 0xe8, 0x49, 0xe7, 0x53, 0x67, 0x7f, 0xfb, 0x78, 0xbf, 0x75, 0x8b, 0xac,
+	0x02, 0xe9, 0x75, 0x8e, 0x4f, 0x3a, 0xbb, 0x19, 0xb8, 0x41, 0x16, 0xa0, 0xae, 0x03, 0x4a, 0xd6,
+	0x26, 0x10, 0x7d, 0xde, 0x6b, 0x5f, 0xf6, 0x55, 0x58, 0xc6, 0xfb, 0x2f, 0x3a, 0xa4, 0x3a, 0xe8,
+	0xb7, 0x0d, 0x68, 0x7e, 0xe2, 0xfa, 0x3e, 0x95, 0xa8, 0xe9, 0x63, 0xa8, 0xe5, 0x97, 0xbe, 0xe8,
+	0xf2, 0x51, 0x4e, 0xd1, 0xff, 0x78, 0x2e, 0xef, 0xbc, 0x68, 0xe1, 0x5c, 0xa7, 0xae, 0xef, 0x06,
+	0x7d, 0xfe, 0x80, 0x96, 0x6d, 0xd9, 0xb4, 0x9e, 0xc0, 0x4a, 0x8e, 0x5f, 0xb1, 0xc4, 0x2d, 0xa8,
+	0xb9, 0x12, 0x28, 0x2e, 0xfc, 0xb2, 0xe0, 0x24, 0xb3, 0x0e, 0x3b, 0x25, 0xb3, 0x7a, 0x5c, 0xf9,
+	0x3d, 0x0b, 0xe2, 0x11, 0x0d, 0x94, 0xa6, 0x17, 0xb2, 0x85, 0xe6, 0x6e, 0x2c, 0x4c, 0x05, 0x94,
+	0x2d, 0x34, 0x73, 0x63, 0x86, 0x74, 0x2f, 0x04, 0xb2, 0x24, 0x90, 0xee, 0x05, 0x43, 0x5a, 0x7f,
+	0x61, 0x40, 0x05, 0xc5, 0x2d, 0xa3, 0xa2, 0x8d, 0xeb, 0x54, 0xb4, 0xb6, 0xb1, 0xa5, 0xec, 0xc6,
+	0x4e, 0xf1, 0x37, 0x90, 0x89, 0xd1, 0x0b, 0x27, 0xee, 0x47, 0xde, 0x28, 0x11, 0x26, 0x76, 0x75,
+	0xf4, 0xe2, 0x98, 0xb5, 0xc9, 0x9b, 0xd0, 0xcc, 0x5a, 0xea, 0xdc, 0xdb, 0xcd, 0x02, 0xad, 0x87,
+	0xb0, 0x94, 0x59, 0xba, 0xd8, 0xc5, 0xd7, 0x61, 0x86, 0xdf, 0x29, 0xbe, 0x83, 0x75, 0xc1, 0x35,
+	0x2e, 0xca, 0xe6, 0x18, 0x6b, 0x1b, 0xc8, 0x4e, 0x18, 0x04, 0xb4, 0x9f, 0x1c, 0x51, 0x1a, 0xc9,
+	0x4d, 0x7b, 0x4f, 0xd3, 0x42, 0xf5, 0xad, 0x35, 0xd1, 0x2f, 0xef, 0xbf, 0x70, 0xf5, 0x64, 0x6d,
+	0xc2, 0x52, 0x66, 0x08, 0x31, 0xf9, 0x1a, 0xcc, 0x8d, 0x28, 0x8d, 0x1c, 0x71, 0x3d, 0x67, 0xec,
+	0x59, 0x6c, 0x76, 0x07, 0xd6, 0x6f, 0x18, 0x50, 0xd9, 0x3f, 0x39, 0xd8, 0xd1, 0x9e, 0xc2, 0x32,
+	0x7b, 0x0a, 0xa7, 0xe9, 0xb9, 0x3b, 0x50, 0x43, 0xf7, 0xc3, 0x41, 0xaf, 0x42, 0x84, 0x0a, 0xaa,
+	0x08, 0x38, 0x08, 0xfb, 0x2f, 0xc8, 0x12, 0xcc, 0x24, 0xa1, 0x33, 0x8e, 0x85, 0x7e, 0xab, 0x24,
+	0xe1, 0xb3, 0x18, 0x8d, 0x27, 0xcd, 0xb8, 0xd0, 0x9c, 0x93, 0xa6, 0xdd, 0x4a, 0x11, 0xdc, 0xc0,
+	0xb3, 0xfe, 0x7d, 0x06, 0x9a, 0xdb, 0xfd, 0xc4, 0x7b, 0x49, 0x85, 0xdb, 0x87, 0x13, 0x46, 0x74,
+	0x18, 0x26, 0xd4, 0x51, 0xba, 0xa5, 0xca, 0x01, 0xdd, 0x01, 0x5a, 0x6f, 0x7d, 0x4e, 0xe7, 0xa4,
+	0xaf, 0x76, 0xcd, 0x6e, 0xf4, 0x75, 0x9f, 0x11, 0x8d, 0x46, 0x77, 0xe4, 0xf6, 0xbd, 0xe4, 0x52,
+	0x9c, 0xb6, 0x6a, 0xe3, 0x00, 0x7e, 0xd8, 0x77, 0x7d, 0x27, 0x7b, 0x29, 0x1a, 0x0c, 0xf8, 0x88,
+	0xc3, 0xd0, 0x82, 0x15, 0x2c, 0x48, 0x2a, 0x71, 0xf0, 0x1c, 0x2a, 0xc9, 0xde, 0x83, 0xc5, 0x71,
+	0x10, 0xd3, 0x24, 0xf1, 0xe9, 0xc0, 0x39, 0xa5, 0x9c, 0x92, 0x3b, 0x59, 0x2d, 0x85, 0x78, 0xc4,
+	0xe1, 0xe4, 0x01, 0x34, 0x47, 0x94, 0x3b, 0xb2, 0xe7, 0x89, 0xdf, 0x47, 0x77, 0x4b, 0x17, 0x0b,
+	0x3c, 0x13, 0xbb, 0x21, 0x28, 0xf6, 0x91, 0x80, 0xac, 0x43, 0x1d, 0x75, 0xe9, 0x98, 0x19, 0xde,
+	0x31, 0x73, 0xc2, 0x2a, 0x36, 0x04, 0xe3, 0x21, 0x37, 0xc5, 0xb9, 0x4c, 0xb3, 0xad, 0x13, 0x5e,
+	0x98, 0x68, 0xe1, 0x2d, 0x18, 0x45, 0xde, 0x4b, 0x37, 0xa1, 0x2c, 0x5e, 0x51, 0xb5, 0x65, 0x13,
+	0xf7, 0xb6, 0x1f, 0xb3, 0x68, 0x8b, 0x7b, 0xd9, 0xae, 0x73, 0x5d, 0xdf, 0x8f, 0x31, 0xce, 0xe2,
+	0x5e, 0xb2, 0x48, 0x47, 0x38, 0x1c, 0x7a, 0x09, 0xba, 0x83, 0x2c, 0x32, 0x51, 0xb6, 0x6b, 0x1c,
+	0xb2, 0x47, 0x29, 0xd9, 0x84, 0x25, 0xee, 0x2c, 0xc6, 0x6e, 0x12, 0xc6, 0xe7, 0x5e, 0xec, 0xc4,
+	0x34, 0x48, 0xda, 0x4d, 0xee, 0x3a, 0x30, 0xd4, 0xb1, 0xc0, 0x1c, 0xd3, 0x20, 0x21, 0x1f, 0xc0,
+	0x5a, 0x8e, 0x3e, 0xa2, 0x7d, 0xea, 0xbd, 0xa4, 0x83, 0xf6, 0x3c, 0xeb, 0xb3, 0x92, 0xe9, 0x63,
+	0x0b, 0x24, 0xae, 0x6a, 0x3c, 0x42, 0xd7, 0xa4, 0xbd, 0xc0, 0x05, 0x91, 0xb7, 0xf0, 0x54, 0x7d,
+	0xef, 0x8c, 0x32, 0x4c, 0x8b, 0x9f, 0xaa, 0x6c, 0xa3, 0x19, 0xcd, 0x4c, 0x28, 0x87, 0xc9, 0xd7,
+	0x65, 0x7b, 0x91, 0x9b, 0xd1, 0x0c, 0xd6, 0x61, 0x20, 0x0c, 0xbe, 0xa0, 0xb6, 0x91, 0x67, 0x80,
+	0x31, 0x31, 0xc2, 0x0f, 0x75, 0xe8, 0x5e, 0x1c, 0x71, 0xe8, 0xf6, 0x30, 0x21, 0xef, 0x03, 0x41,
+	0x3a, 0xb7, 0xdf, 0xa7, 0xa3, 0x04, 0x5d, 0x18, 0x76, 0x58, 0x4b, 0x5c, 0x7c, 0x87, 0xee, 0xc5,
+	0xb6, 0x40, 0xf0, 0x33, 0x5a, 0x83, 0x39, 0x11, 0xf5, 0x69, 0x2f, 0xb3, 0xf3, 0x61, 0x6a, 0xb7,
+	0x3b, 0xb0, 0xfe, 0xbb, 0x04, 0x15, 0xbc, 0x91, 0x8c, 0x35, 0x79, 0x75, 0x53, 0x89, 0xae, 0x2b,
+	0x58, 0x77, 0xa0, 0x5f, 0xd6, 0x92, 0x7e, 0x59, 0x75, 0x75, 0x56, 0xce, 0xaa, 0x33, 0x0c, 0x0d,
+	0x5c, 0x26, 0x54, 0x9c, 0x41, 0x85, 0x4d, 0x5d, 0x63, 0x10, 0xb6, 0xf7, 0x0a, 0x1d, 0xd1, 0xfe,
+	0xcb, 0xf6, 0x8c, 0x86, 0xb6, 0x69, 0xff, 0x25, 0xb3, 0x4c, 0xdc, 0x84, 0xf7, 0xe5, 0xf2, 0x3a,
+	0x17, 0xbb, 0x09, 0xeb, 0x29, 0x50, 0xac, 0xdf, 0x9c, 0x42, 0xb1, 0x5e, 0x6d, 0x98, 0xf3, 0x82,
+	0xd3, 0x70, 0x1c, 0x0c, 0x98, 0x2c, 0x56, 0x6d, 0xd9, 0x24, 0x0f, 0xa0, 0x2a, 0x2e, 0xa0, 0x8c,
+	0xb9, 0xc9, 0xf7, 0x22, 0x73, 0xb5, 0x6d, 0x45, 0x45, 0xde, 0x85, 0xea, 0x19, 0x75, 0x93, 0x71,
+	0x44, 0xe3, 0x36, 0xb0, 0x1e, 0xf3, 0x32, 0x54, 0xc4, 0xc1, 0xb6, 0xc2, 0xa3, 0xb3, 0x12, 0x27,
+	0xf8, 0xee, 0x0c, 0x90, 0x2d, 0xae, 0xec, 0x62, 0x21, 0xbd, 0x8b, 0x02, 0x63, 0x2b, 0x84, 0xf5,
+	0x02, 0xe6, 0xc4, 0x18, 0x68, 0x37, 0x9e, 0x7a, 0x89, 0x08, 0x53, 0xe1, 0x4f, 0xb4, 0x59, 0x02,
+	0x77, 0x48, 0x65, 0x50, 0x07, 0x7f, 0xe3, 0x3d, 0x63, 0xc2, 0xf9, 0xe3, 0xb1, 0x17, 0xd1, 0x81,
+	0x78, 0x3e, 0xc1, 0x8b, 0x6d, 0x01, 0xc1, 0x3d, 0xf1, 0x62, 0xe7, 0x45, 0x10, 0xbe, 0x0a, 0xa4,
+	0x21, 0xe7, 0xc5, 0x4f, 0xb0, 0x69, 0x11, 0x0c, 0x2c, 0xc5, 0x4c, 0xf7, 0xaa, 0xf7, 0xfe, 0x03,
+	0x58, 0xd4, 0x60, 0xe9, 0x6b, 0x80, 0x87, 0x9a, 0x7f, 0x0d, 0x90, 0xc8, 0xe6, 0x18, 0xf4, 0x6c,
+	0xb0, 0xd9, 0x79, 0x49, 0x83, 0xe4, 0x78, 0x7c, 0xca, 0xdf, 0x24, 0x74, 0x2c, 0xfe, 0xc3, 0x80,
+	0x9a, 0xc2, 0x90, 0xcd, 0x8c, 0x85, 0x64, 0x6a, 0x03, 0x31, 0xfc, 0x26, 0xfb, 0xab, 0x19, 0x06,
+	0x79, 0x01, 0x2c, 0x5d, 0x29, 0x80, 0xe5, 0x69, 0x02, 0x58, 0xc9, 0x0a, 0xe0, 0x5d, 0xa8, 0xa5,
+	0xe1, 0x83, 0x99, 0x34, 0xb0, 0xc4, 0x00, 0xd6, 0x26, 0xd4, 0x14, 0x1b, 0xcc, 0xb0, 0xea, 0x74,
+	0x6c, 0xe7, 0xb0, 0x77, 0xd0, 0xed, 0x75, 0x5a, 0xb7, 0x48, 0x0b, 0x1a, 0x1c, 0xb0, 0xb7, 0xc7,
+	0x20, 0x86, 0xf5, 0x47, 0x06, 0x7f, 0x43, 0x85, 0xa0, 0x28, 0x6b, 0x70, 0x1d, 0xea, 0x5c, 0xa7,
+	0xf1, 0x60, 0x13, 0x77, 0xd5, 0x81, 0x83, 0x30, 0xda, 0x84, 0xea, 0xdc, 0x0b, 0x74, 0x12, 0xee,
+	0xa4, 0x37, 0xbc, 0x40, 0x23, 0x5a, 0x87, 0xba, 0x88, 0x07, 0x31, 0x12, 0x71, 0xc0, 0x1c, 0xc4,
+	0x08, 0x30, 0xc2, 0xcc, 0x35, 0x24, 0xa7, 0xe0, 0x87, 0x5c, 0x17, 0x30, 0x24, 0xb1, 0xf6, 0x61,
+	0x39, 0xcb, 0xa0, 0x38, 0x57, 0x5d, 0xf4, 0x8d, 0x9b, 0x88, 0xbe, 0xd5, 0x82, 0xf9, 0xc7, 0x34,
+	0xd1, 0xc3, 0x15, 0x7f, 0x50, 0x82, 0x05, 0x05, 0x52, 0xf2, 0x72, 0xad, 0xda, 0x78, 0x07, 0x5a,
+	0xde, 0x80, 0x06, 0x89, 0x97, 0x5c, 0x3a, 0x59, 0xab, 0x67, 0x41, 0xc2, 0xa5, 0xc1, 0xf9, 0x00,
+	0x96, 0xf1, 0x29, 0x91, 0xca, 0x4f, 0x71, 0xcc, 0xcd, 0x7d, 0x12, 0x8c, 0x87, 0x42, 0x03, 0xca,
+	0xf5, 0xa1, 0xb6, 0xc7, 0x1e, 0x62, 0x6b, 0x55, 0x87, 0x0a, 0xbf, 0x75, 0xc1, 0x78, 0x98, 0x59,
+	0x1e, 0x33, 0xe6, 0xf8, 0x0c, 0x28, 0xe3, 0xfc, 0xb1, 0xaf, 0xb2, 0x61, 0x69, 0x14, 0x63, 0x52,
+	0x40, 0x71, 0x2a, 0x02, 0xdf, 0xb3, 0x8c, 0xd1, 0x79, 0x09, 0xe6, 0x91, 0x6f, 0xbc, 0x9e, 0xe3,
+	0xc8, 0xe3, 0x6f, 0x63, 0xcd, 0x66, 0xbf, 0xad, 0x15, 0x58, 0xe2, 0x0f, 0x1e, 0x0b, 0x8e, 0x3e,
+	0x97, 0x9b, 0xf6, 0x67, 0x06, 0x2c, 0x67, 0xe1, 0x62, 0xe7, 0xd6, 0xa1, 0x3e, 0xa0, 0xa7, 0xe3,
+	0xe7, 0x8e, 0x4f, 0x5f, 0x52, 0x5f, 0x06, 0x76, 0x19, 0xe8, 0x00, 0x21, 0x28, 0x33, 0x4c, 0xda,
+	0x47, 0xa1, 0xef, 0xf5, 0x3d, 0x8a, 0x9b, 0x86, 0xb3, 0x35, 0x10, 0x78, 0x24, 0x60, 0xc4, 0x82,
+	0x26, 0xb3, 0x5c, 0x51, 0xb9, 0xc7, 0xde, 0x67, 0xd2, 0xbf, 0xab, 0xa3, 0xf5, 0x7a, 0xee, 0x06,
+	0xc7, 0xde, 0x67, 0x94, 0xd1, 0xb8, 0x17, 0x1a, 0x4d, 0x45, 0xd0, 0xb8, 0x17, 0x92, 0xc6, 0xfa,
+	0x8c, 0x99, 0x78, 0xca, 0x5a, 0xe4, 0x1c, 0xe3, 0x6e, 0xf1, 0xd0, 0x6d, 0x7c, 0xee, 0x8a, 0x70,
+	0x44, 0x95, 0x01, 0x8e, 0xcf, 0xdd, 0x89, 0xb8, 0x6e, 0x69, 0x32, 0xae, 0xfb, 0x26, 0xcc, 0xcb,
+	0x30, 0x72, 0xec, 0xf8, 0xf4, 0x2c, 0x11, 0x27, 0xd9, 0x10, 0x31, 0xe4, 0xf8, 0x80, 0x9e, 0x25,
+	0xd6, 0x53, 0x58, 0x14, 0xe7, 0x73, 0x38, 0xa2, 0x72, 0xea, 0x87, 0x79, 0x0b, 0x8a, 0x9b, 0x99,
+	0x4b, 0x42, 0x6a, 0xf5, 0xe0, 0x7b, 0xd6, 0xac, 0xb2, 0xbe, 0x0f, 0x44, 0x60, 0x77, 0xfc, 0x30,
+	0xa6, 0x69, 0x40, 0xb0, 0xef, 0x87, 0x71, 0x3e, 0x40, 0x2f, 0x60, 0x2c, 0x40, 0xdf, 0x86, 0xb9,
+	0x78, 0xdc, 0xef, 0x4b, 0xf9, 0xac, 0xda, 0xb2, 0x69, 0xf9, 0x30, 0xff, 0x68, 0x3c, 0x1c, 0xed,
+	0x51, 0x9a, 0xfa, 0x7f, 0x3f, 0x27, 0x7b, 0xd7, 0x7b, 0xba, 0xd6, 0x5b, 0xb0, 0xa0, 0x66, 0xbb,
+	0xc2, 0xe7, 0xfe, 0xfb, 0x12, 0x2c, 0xb1, 0x15, 0xca, 0xbb, 0xfb, 0xa5, 0x59, 0x93, 0x61, 0x78,
+	0x9e, 0x43, 0x2a, 0xa5, 0xda, 0x92, 0xe7, 0x90, 0x96, 0x61, 0xe6, 0x2c, 0x8c, 0xfa, 0xd2, 0x73,
+	0xe3, 0x0d, 0xdd, 0xb4, 0xa8, 0xe8, 0xa6, 0x05, 0xf2, 0x1c, 0xf7, 0xbd, 0x01, 0xbb, 0x65, 0x35,
+	0x9b, 0xfd, 0xc6, 0x34, 0x94, 0xeb, 0xfb, 0xe1, 0x2b, 0xd4, 0x5f, 0x5e, 0x40, 0xd9, 0x3d, 0x64,
+	0x77, 0xac, 0x6a, 0x2f, 0x30, 0xc4, 0x21, 0x83, 0x33, 0x8b, 0x64, 0x13, 0x96, 0x38, 0x6d, 0xde,
+	0x1e, 0x45, 0x6a, 0x3e, 0xcc, 0x91, 0x6e, 0x87, 0xbe, 0x03, 0xad, 0x01, 0xf5, 0x3d, 0x16, 0x0c,
+	0x95, 0x7a, 0x86, 0x67, 0x04, 0x16, 0x24, 0x5c, 0xe8, 0x19, 0xeb, 0x67, 0x06, 0x2c, 0xb2, 0xad,
+	0x3b, 0x4e, 0xdc, 0x64, 0x1c, 0x0b, 0x11, 0xf9, 0x08, 0x9a, 0x28, 0x0e, 0x54, 0x4e, 0x28, 0x36,
+	0x6e, 0x59, 0x3d, 0x5d, 0x0c, 0xca, 0x89, 0xf7, 0x6f, 0xd9, 0x4c, 0x9e, 0xa8, 0x80, 0x92, 0xef,
+	0x42, 0x43, 0x77, 0xb7, 0x44, 0x98, 0xee, 0xb6, 0xdc, 0xf4, 0x89, 0xbb, 0xc5, 0x06, 0xd0, 0xa0,
+	0xe4, 0xdb, 0x00, 0x6c, 0x1f, 0xd9, 0xa8, 0xed, 0x72, 0xb6, 0xfb, 0x84, 0x3c, 0xef, 0xdf, 0xb2,
+	0x6b, 0x48, 0xce, 0x40, 0x8f, 0xaa, 0x68, 0x8b, 0x22, 0xd8, 0xfa, 0x18, 0x9a, 0x19, 0x3e, 0x33,
+	0x92, 0xd3, 0x10, 0xd1, 0x8f, 0x8c, 0x79, 0x5d, 0xca, 0x9a, 0xd7, 0xd6, 0x7f, 0x95, 0x81, 0xe0,
+	0x3d, 0xcc, 0x49, 0xd5, 0x9b, 0x30, 0x2f, 0xc2, 0xe0, 0x59, 0x87, 0x4d, 0xc4, 0xc1, 0x8f, 0xf8,
+	0x43, 0xbc, 0x0e, 0x75, 0x41, 0x15, 0xc8, 0xec, 0x5a, 0xc3, 0x06, 0x0e, 0xea, 0x61, 0xc4, 0xfa,
+	0x01, 0x2c, 0x73, 0xbf, 0x46, 0x66, 0xcb, 0x32, 0xde, 0x2e, 0x61, 0xb8, 0xbd, 0xb1, 0xb0, 0x72,
+	0x11, 0x43, 0xb6, 0x60, 0x45, 0x38, 0x39, 0xb9, 0x2e, 0x5c, 0x8b, 0x2d, 0x71, 0x64, 0xb6, 0xcf,
+	0xdb, 0xb0, 0xc0, 0x1c, 0x82, 0x38, 0xf6, 0x42, 0xa1, 0xf3, 0xf8, 0xdb, 0x3f, 0x9f, 0x82, 0x99,
+	0x6a, 0x14, 0xcf, 0x01, 0xf7, 0xed, 0x67, 0xd5, 0x73, 0xc0, 0x1d, 0x7f, 0xcd, 0x3f, 0x99, 0xcb,
+	0xfa, 0x27, 0x79, 0x3b, 0xbe, 0x3a, 0x69, 0xc7, 0xbf, 0x0f, 0xb3, 0x4c, 0x71, 0xf3, 0xd4, 0x53,
+	0x2a, 0x45, 0x76, 0x38, 0x4e, 0xbc, 0xe0, 0x39, 0x53, 0xe0, 0x97, 0xb6, 0xa0, 0x29, 0xb2, 0xfa,
+	0xe1, 0xe6, 0x56, 0x7f, 0x7d, 0x8a, 0xd5, 0xff, 0x86, 0x14, 0x68, 0x79, 0x1d, 0x1a, 0xc2, 0x0b,
+	0x45, 0xa0, 0xbc, 0x0b, 0xff, 0x6a, 0x40, 0x0b, 0xcf, 0x3b, 0x73, 0x15, 0x3e, 0x04, 0xa6, 0x19,
+	0x6e, 0x78, 0x13, 0xea, 0x48, 0xfb, 0x0b, 0xbb, 0x08, 0xdf, 0x02, 0x26, 0xd9, 0x4e, 0x38, 0xa2,
+	0x81, 0xb8, 0x07, 0xed, 0xec, 0x3d, 0x48, 0x9f, 0x89, 0xfd, 0x5b, 0xdc, 0x62, 0x41, 0x88, 0x76,
+	0x0b, 0x3a, 0xb0, 0x22, 0xd8, 0xc9, 0x49, 0xf1, 0xfb, 0x30, 0x1b, 0xb3, 0x75, 0x0a, 0xb3, 0x74,
+	0x39, 0x3b, 0x30, 0xdf, 0x03, 0x5b, 0xd0, 0x58, 0x7f, 0x5a, 0x81, 0xd5, 0xfc, 0x38, 0x42, 0x21,
+	0x7f, 0x02, 0xad, 0x09, 0x2b, 0x85, 0xdb, 0x55, 0xef, 0x67, 0x37, 0x29, 0xd7, 0x31, 0x0f, 0x5e,
+	0x18, 0x65, 0xda, 0xb1, 0xf9, 0x37, 0x65, 0x98, 0xcf, 0xd2, 0x4c, 0x0d, 0x92, 0xdc, 0xc4, 0x64,
+	0x9e, 0x08, 0x44, 0x94, 0xaf, 0x09, 0x44, 0x54, 0xae, 0x0b, 0x44, 0xcc, 0xdc, 0x28, 0x10, 0x31,
+	0x5b, 0x14, 0x88, 0xc8, 0xbf, 0xc1, 0x73, 0x9c, 0x5f, 0xfd, 0x0d, 0x4e, 0x0f, 0xa8, 0x7a, 0xfd,
+	0x01, 0xc9, 0x01, 0xa9, 0x34, 0x41, 0x6a, 0xfc, 0x1e, 0x32, 0x58, 0x9a, 0xbe, 0xf3, 0xbd, 0xe1,
+	0x69, 0xa8, 0x38, 0x03, 0xc1, 0x3f, 0x02, 0x25, 0x63, 0x1f, 0x41, 0x3d, 0xa2, 0x71, 0xe8, 0x8f,
+	0x79, 0xf8, 0xac, 0xbe, 0x51, 0xce, 0x8a, 0x6c, 0x12, 0xb9, 0xfd, 0xc4, 0x56, 0x14, 0xb6, 0x4e,
+	0x6d, 0xfd, 0x89, 0x01, 0x64, 0x92, 0x06, 0x37, 0x35, 0x13, 0x10, 0xac, 0x69, 0xf1, 0x3f, 0x02,
+	0x95, 0x17, 0x5e, 0x20, 0x0f, 0x8c, 0xfd, 0x9e, 0x1a, 0xf9, 0x7b, 0x1b, 0x55, 0x43, 0x32, 0x8e,
+	0xd0, 0x28, 0x15, 0xcb, 0xe4, 0xd6, 0xed, 0xbc, 0x04, 0xa7, 0x39, 0x48, 0xc6, 0x16, 0x46, 0x2e,
+	0x66, 0x78, 0x0e, 0x52, 0xb6, 0xad, 0x0f, 0x61, 0x99, 0x87, 0x44, 0xc5, 0x8a, 0xb5, 0x4c, 0xec,
+	0x2b, 0x2f, 0x09, 0x68, 0x1c, 0xeb, 0x9e, 0x4b, 0x5d, 0xc0, 0x98, 0x47, 0xe1, 0xc0, 0x4a, 0xae,
+	0x6b, 0x1a, 0x61, 0x96, 0x7b, 0x6a, 0xb0, 0x74, 0xa2, 0x6c, 0xa2, 0x96, 0x4a, 0x53, 0xef, 0x6a,
+	0xe3, 0x4b, 0x8c, 0xa8, 0xa5, 0x52, 0xf0, 0x62, 0x3c, 0xf4, 0x27, 0xc5, 0xe9, 0x66, 0x99, 0xb3,
+	0xfe, 0x73, 0x06, 0x56, 0xf3, 0x98, 0xe2, 0xb9, 0xd3, 0x68, 0x71, 0x81, 0x28, 0x96, 0x8a, 0x44,
+	0xf1, 0x03, 0x58, 0x4b, 0x63, 0x62, 0x59, 0x01, 0xe7, 0xdb, 0xbf, 0xa2, 0xd0, 0x07, 0xba, 0xa4,
+	0x3f, 0x84, 0x76, 0xda, 0x2f, 0x37, 0x11, 0xbf, 0x3a, 0xab, 0x0a, 0x6f, 0x67, 0x66, 0xfc, 0x08,
+	0x4c, 0xa9, 0x31, 0x50, 0xb3, 0x39, 0x45, 0xb7, 0x6a, 0x4d, 0x50, 0xa0, 0x3a, 0xcb, 0x4c, 0xfb,
+	0xff, 0xe0, 0x4e, 0xa6, 0x73, 0xe1, 0x6d, 0x6b, 0x6b, 0xbd, 0xb3, 0x73, 0xef, 0x6b, 0xde, 0xdf,
+	0x5c, 0x46, 0x4b, 0x15, 0xef, 0x6f, 0x1e, 0xac, 0x7a, 0x9b, 0xff, 0x5c, 0x82, 0xf9, 0x2c, 0x72,
+	0x52, 0xc5, 0x18, 0x05, 0x2a, 0xe6, 0x06, 0xaa, 0x0a, 0x9f, 0x5b, 0xf1, 0xdc, 0x94, 0xc5, 0x73,
+	0xcb, 0x9b, 0xff, 0x6b, 0xfa, 0xe9, 0x0a, 0xa1, 0x98, 0xfb, 0x79, 0x85, 0xa2, 0x7a, 0x95, 0x50,
+	0x58, 0xbf, 0x66, 0x40, 0x4b, 0x58, 0x04, 0x27, 0xee, 0xa9, 0x4f, 0x0f, 0xbc, 0xe0, 0x05, 0x86,
+	0x83, 0xbc, 0xc1, 0xd7, 0x64, 0x1a, 0xd1, 0x1b, 0x7c, 0x8d, 0x43, 0xb6, 0xc4, 0xa6, 0xe1, 0xcf,
+	0x8c, 0x76, 0x29, 0xe7, 0xb4, 0xcb, 0x55, 0xdb, 0xb5, 0x0a, 0xb3, 0xaf, 0xd2, 0x48, 0xb7, 0x61,
+	0x8b, 0x96, 0x75, 0x1b, 0xd6, 0x8e, 0xcf, 0xc3, 0x57, 0x3a, 0x2f, 0xf2, 0x1a, 0x1e, 0x42, 0x7b,
+	0x12, 0x25, 0xee, 0xe1, 0xd7, 0x27, 0xc2, 0x0a, 0x6b, 0x59, 0x3b, 0x47, 0xad, 0x4a, 0x8b, 0x2c,
+	0x10, 0x68, 0xed, 0x46, 0xe1, 0xe8, 0x71, 0xe4, 0x8e, 0xce, 0xe5, 0x24, 0x0f, 0x60, 0x51, 0x83,
+	0x89, 0xd1, 0x85, 0x75, 0x46, 0x07, 0xcf, 0x69, 0x2c, 0xee, 0x39, 0x5a, 0x67, 0x1d, 0x6c, 0x5b,
+	0x77, 0xc1, 0xec, 0x5c, 0x8c, 0xc2, 0x28, 0x61, 0x7d, 0x8e, 0x03, 0x77, 0x14, 0x9f, 0x87, 0x32,
+	0xa3, 0x63, 0xc5, 0x70, 0xa7, 0x10, 0x2b, 0x46, 0x36, 0xa1, 0x1a, 0x0b, 0x98, 0xf4, 0x6b, 0x65,
+	0x5b, 0xce, 0x8a, 0x06, 0x6c, 0x2c, 0xad, 0xe3, 0x60, 0x3c, 0x44, 0xf3, 0x35, 0xce, 0xb2, 0x54,
+	0x56, 0x48, 0xce, 0xd2, 0x43, 0x30, 0xbb, 0xc3, 0x82, 0x49, 0xb9, 0xae, 0xbd, 0x62, 0x4e, 0xeb,
+	0x13, 0xb8, 0xd3, 0x1d, 0x4e, 0x67, 0x37, 0xc3, 0x92, 0x71, 0x15, 0x4b, 0xa5, 0x1c, 0x4b, 0x3f,
+	0x33, 0x80, 0x7c, 0x7f, 0x4c, 0xa3, 0x4b, 0x3c, 0x0f, 0x1a, 0x7f, 0xb1, 0x0a, 0xcc, 0xa2, 0xda,
+	0xc7, 0x72, 0x61, 0xed, 0x63, 0xb6, 0xfa, 0xb0, 0x72, 0xa3, 0xea, 0xc3, 0x99, 0x1b, 0x57, 0x1f,
+	0xce, 0x16, 0x54, 0x1f, 0x5a, 0xbf, 0x6b, 0x40, 0x79, 0x3f, 0x1c, 0xdd, 0x24, 0x02, 0x75, 0xa3,
+	0x6c, 0x8c, 0x20, 0x72, 0x72, 0x29, 0x19, 0x46, 0xb4, 0x23, 0x60, 0xe8, 0x05, 0xb9, 0xc3, 0xc4,
+	0x49, 0x42, 0xe7, 0x2c, 0x8c, 0x5e, 0xb9, 0xd1, 0x40, 0xe6, 0x65, 0xdc, 0x61, 0x72, 0x12, 0xee,
+	0x71, 0x98, 0xe5, 0xc3, 0x0c, 0xdb, 0x6e, 0x3c, 0x1a, 0x9e, 0x5b, 0xc0, 0x8d, 0x15, 0x02, 0xcc,
+	0x00, 0x68, 0xcb, 0xdf, 0xc3, 0xc2, 0xbd, 0x11, 0x0f, 0xeb, 0xd4, 0xb7, 0x40, 0x26, 0x58, 0xc2,
+	0x91, 0xcd, 0xe0, 0xb8, 0x11, 0xbc, 0x33, 0xf7, 0xc9, 0x65, 0x5e, 0xab, 0x69, 0x37, 0x19, 0x18,
+	0x8b, 0x9f, 0x30, 0xb9, 0x65, 0x7d, 0x08, 0x4b, 0x99, 0x13, 0x16, 0x32, 0x63, 0xc1, 0x4c, 0x84,
+	0x10, 0x61, 0xbb, 0x37, 0xb4, 0x7b, 0x49, 0x6d, 0x8e, 0xc2, 0x94, 0xe0, 0x49, 0xe4, 0xf6, 0x5f,
+	0x88, 0xfa, 0x49, 0xcd, 0x2a, 0xc8, 0x54, 0xde, 0x1a, 0x13, 0x95, 0xb7, 0xd6, 0x6f, 0x96, 0xa0,
+	0x8e, 0xb9, 0xa0, 0xed, 0x24, 0xa1, 0xc3, 0x11, 0x0b, 0x1d, 0xb8, 0xfc, 0xa7, 0x3c, 0x83, 0xa6,
+	0x5d, 0x13, 0x90, 0xae, 0x6e, 0xd6, 0x95, 0x32, 0x66, 0x9d, 0x98, 0x38, 0x67, 0xd6, 0x29, 0xd6,
+	0xcb, 0x53, 0x59, 0x47, 0x47, 0x52, 0x14, 0x80, 0x3a, 0x99, 0x5a, 0x4f, 0x2e, 0x7b, 0x44, 0xe0,
+	0x8e, 0xb5, 0x92, 0xcf, 0xb7, 0x60, 0x5e, 0xf6, 0x88, 0xa8, 0x1b, 0x87, 0x81, 0x88, 0x4c, 0x34,
+	0x05, 0xd4, 0x66, 0x40, 0xf2, 0x4d, 0x68, 0x48, 0x32, 0x56, 0x21, 0x3a, 0x3b, 0xb5, 0x42, 0xb4,
+	0x7e, 0x96, 0x36, 0xac, 0x3f, 0x37, 0xa0, 0x29, 0x56, 0x93, 0x46, 0x9c, 0xae, 0xd9, 0xc5, 0x2f,
+	0xb8, 0x2d, 0xac, 0x80, 0x8b, 0x7a, 0x43, 0x57, 0x24, 0xcf, 0x1b, 0xb6, 0x6a, 0x93, 0xfb, 0x30,
+	0xc3, 0x7d, 0xc1, 0x4a, 0xa6, 0x7a, 0x47, 0x3b, 0x22, 0x9b, 0x13, 0xa0, 0xde, 0x14, 0x51, 0xfb,
+	0x53, 0x8a, 0x6e, 0x22, 0x0b, 0x80, 0xab, 0xa4, 0xc0, 0x4f, 0x67, 0xa0, 0xa6, 0xa0, 0xe4, 0x43,
+	0x00, 0x8a, 0x3f, 0x9c, 0x82, 0x48, 0xbe, 0xa2, 0xd2, 0x22, 0xf9, 0x35, 0x2a, 0x7f, 0x92, 0x6f,
+	0xc0, 0xaa, 0x17, 0xf4, 0xc3, 0xa1, 0xe6, 0x21, 0x65, 0x2e, 0xdf, 0xb2, 0xc4, 0x66, 0xca, 0x68,
+	0xef, 0x43, 0x2b, 0xd3, 0x4b, 0x86, 0xfa, 0x2b, 0xf6, 0xbc, 0x4e, 0xdf, 0x1d, 0xe0, 0xf8, 0x19,
+	0x95, 0x92, 0x8e, 0xcf, 0x33, 0x00, 0xcb, 0xba, 0x5e, 0xd1, 0xc7, 0xcf, 0x2b, 0x22, 0x91, 0x76,
+	0x9a, 0xcf, 0xea, 0x21, 0xa9, 0x0d, 0x67, 0xa7, 0xd7, 0xa3, 0xcf, 0x4d, 0x9e, 0x67, 0x5e, 0x76,
+	0xaa, 0x37, 0x92, 0x9d, 0x02, 0xc9, 0xac, 0x15, 0x49, 0x66, 0x26, 0x97, 0x01, 0xb9, 0x5c, 0x06,
+	0xf9, 0x1e, 0xcc, 0xe7, 0xca, 0xca, 0xb9, 0x1b, 0xf3, 0xc6, 0xc4, 0x79, 0x15, 0x14, 0x94, 0x37,
+	0xfb, 0x3a, 0xcc, 0xfc, 0x18, 0xc8, 0x97, 0xac, 0xea, 0xee, 0xe8, 0x99, 0x95, 0x3a, 0xcc, 0xed,
+	0x1d, 0xda, 0x9f, 0x6c, 0xdb, 0xbb, 0xad, 0x5b, 0x04, 0x60, 0xf6, 0xb8, 0x73, 0x72, 0x72, 0xd0,
+	0x69, 0x19, 0x98, 0x61, 0x11, 0x08, 0x67, 0x6f, 0xbb, 0x7b, 0xd0, 0x2a, 0x91, 0x26, 0xd4, 0x0e,
+	0xba, 0xbd, 0x27, 0xbc, 0x59, 0xb6, 0xde, 0x85, 0x05, 0x7c, 0xe4, 0xb4, 0x2c, 0x04, 0xf3, 0x86,
+	0xc7, 0xa7, 0x5a, 0xc9, 0xec, 0x2c, 0x2f, 0x86, 0xb6, 0xfe, 0xd6, 0x80, 0xa6, 0xaa, 0x3e, 0xc0,
+	0x5e, 0x37, 0x79, 0x1a, 0xee, 0xea, 0x35, 0x24, 0x3c, 0xc0, 0x9e, 0x02, 0x70, 0x7d, 0xae, 0xef,
+	0xb9, 0x32, 0xad, 0xc9, 0x1b, 0x99, 0xa4, 0x60, 0xe5, 0x9a, 0xa4, 0xe0, 0x3a, 0xd4, 0xd9, 0x6b,
+	0xc6, 0x03, 0x13, 0xc2, 0x38, 0x05, 0x04, 0x71, 0x2d, 0x61, 0xfd, 0xb5, 0x01, 0x55, 0xb9, 0x44,
+	0x72, 0x1f, 0x2a, 0x81, 0xac, 0xf5, 0x4c, 0xc3, 0x2d, 0x99, 0x45, 0xd9, 0x95, 0x40, 0x2c, 0x8d,
+	0x05, 0xae, 0xa4, 0xf1, 0x25, 0x0a, 0x32, 0x31, 0x76, 0x25, 0x40, 0x28, 0x55, 0xfc, 0xfd, 0xc8,
+	0xbd, 0x68, 0xfc, 0xf9, 0x50, 0x4f, 0xda, 0xa6, 0x66, 0xc2, 0x65, 0x95, 0x87, 0x18, 0x09, 0x0d,
+	0x09, 0xcd, 0x7a, 0xeb, 0x00, 0x41, 0x3e, 0x9e, 0xd2, 0x24, 0xf2, 0xfa, 0xca, 0xa0, 0xf8, 0x2a,
+	0x2c, 0x79, 0x41, 0xdf, 0x1f, 0x0f, 0xa8, 0x43, 0xbd, 0xe7, 0x34, 0x78, 0x49, 0xfb, 0x49, 0x18,
+	0x09, 0x7f, 0x92, 0x08, 0x54, 0x27, 0xc5, 0x58, 0x3d, 0xa8, 0xef, 0xf9, 0xa1, 0x9b, 0xf0, 0x71,
+	0x52, 0x49, 0xe2, 0xae, 0x24, 0x6f, 0xb0, 0x32, 0xad, 0x30, 0x1a, 0xba, 0xbe, 0xf7, 0x19, 0x1d,
+	0x38, 0xa9, 0xa8, 0x19, 0xf6, 0x42, 0x0a, 0x67, 0x05, 0x64, 0xd6, 0x5f, 0x95, 0x61, 0x29, 0xc3,
+	0x97, 0x78, 0x06, 0x7d, 0x58, 0x3d, 0xa5, 0xc9, 0x2b, 0x4a, 0x03, 0xe6, 0xe5, 0xf6, 0x29, 0x3a,
+	0xea, 0x3e, 0xee, 0x06, 0xb7, 0x57, 0xbf, 0x29, 0x6b, 0x97, 0x26, 0xfb, 0x6e, 0x3e, 0x4a, 0x3b,
+	0xee, 0xa8, 0x7e, 0xfc, 0xc2, 0xac, 0x9c, 0x16, 0xe1, 0x70, 0x36, 0x6d, 0xf9, 0xfa, 0x6c, 0xa5,
+	0x6b, 0x67, 0xd3, 0x76, 0x67, 0x62, 0x36, 0x5a, 0x84, 0x33, 0x7f, 0x04, 0xe6, 0x74, 0x16, 0x0b,
+	0xaa, 0x11, 0xef, 0xeb, 0xd7, 0x35, 0x3d, 0x67, 0xed, 0x1c, 0xb4, 0x2b, 0x8c, 0xa3, 0x4f, 0x67,
+	0xe9, 0xcb, 0x8e, 0x6e, 0xfd, 0xa5, 0x01, 0xcd, 0x4c, 0x2c, 0x94, 0xd9, 0x3b, 0xd2, 0xd2, 0x11,
+	0xc6, 0xa6, 0x21, 0xec, 0x1d, 0x61, 0xea, 0x70, 0x5b, 0xf3, 0x36, 0x60, 0x6d, 0x16, 0x0b, 0x7d,
+	0x0a, 0x63, 0x75, 0x6e, 0xe8, 0x05, 0xa8, 0xde, 0x10, 0x85, 0x9f, 0xfc, 0x9c, 0xba, 0xb1, 0x74,
+	0xe3, 0xe7, 0xce, 0x28, 0x7d, 0xe4, 0xc6, 0x54, 0xa2, 0x22, 0x57, 0x54, 0x68, 0x37, 0x19, 0xca,
+	0xc6, 0x87, 0xfa, 0xda, 0x3b, 0xda, 0x81, 0x05, 0xf6, 0x2a, 0x68, 0x5a, 0x68, 0x4b, 0x44, 0xeb,
+	0xaf, 0xcd, 0xb0, 0xb0, 0x58, 0x26, 0xfb, 0x69, 0xfd, 0x4e, 0x09, 0xea, 0xda, 0x9d, 0xba, 0x99,
+	0xe3, 0x7c, 0x1b, 0xaa, 0x78, 0xe1, 0xbf, 0x96, 0x3a, 0xcd, 0x73, 0xac, 0xdd, 0x1d, 0x48, 0xd4,
+	0x96, 0x7c, 0x24, 0x05, 0x6a, 0xab, 0x3b, 0xb8, 0xd2, 0x05, 0xfc, 0x16, 0x34, 0xf8, 0x88, 0x22,
+	0x3e, 0x3d, 0x73, 0x45, 0x7c, 0xba, 0xce, 0x28, 0x79, 0x43, 0x76, 0xdc, 0x92, 0x1d, 0x67, 0xaf,
+	0xeb, 0xb8, 0x25, 0x3a, 0xe6, 0x36, 0x78, 0x6e, 0x62, 0x83, 0x63, 0x68, 0x89, 0x8d, 0xe9, 0xee,
+	0x7e, 0x89, 0x1d, 0xd6, 0x73, 0x51, 0xa5, 0xc2, 0x5c, 0x54, 0x39, 0xcd, 0x45, 0x59, 0x14, 0x16,
+	0xb5, 0x49, 0xd3, 0xb2, 0xfb, 0xeb, 0xcf, 0xe4, 0x0b, 0x4d, 0x43, 0xa0, 0xc5, 0x32, 0x79, 0xe8,
+	0xdd, 0x49, 0x2b, 0xeb, 0x9f, 0x0c, 0xb5, 0x60, 0x85, 0xbb, 0xd9, 0xd4, 0x69, 0x5a, 0xa1, 0x74,
+	0x83, 0xb4, 0xc2, 0x3d, 0xa8, 0xe3, 0x07, 0x14, 0x28, 0xf8, 0xf1, 0x78, 0x28, 0xae, 0x44, 0x6d,
+	0xe0, 0x5e, 0xee, 0x51, 0x7a, 0x3c, 0x1e, 0x62, 0x2e, 0xf2, 0x15, 0xa5, 0x2f, 0x14, 0x01, 0x17,
+	0x15, 0x40, 0x98, 0xa0, 0xc0, 0xdc, 0x71, 0x18, 0x24, 0xe7, 0x8a, 0x64, 0x46, 0xe4, 0x8e, 0x11,
+	0xc8, 0x69, 0xac, 0xbf, 0x33, 0x60, 0x51, 0x5b, 0xa2, 0xd8, 0xc9, 0x6f, 0x83, 0xe4, 0x9c, 0x7f,
+	0xb6, 0x93, 0x0d, 0x0f, 0xe4, 0x57, 0xcf, 0x73, 0x08, 0x1c, 0x12, 0xe7, 0xf9, 0x2e, 0x5d, 0xc7,
+	0x77, 0xf9, 0x7a, 0xbe, 0x2b, 0x93, 0x7c, 0xb7, 0x61, 0x15, 0x6b, 0x25, 0x9e, 0xba, 0x7d, 0x37,
+	0x0a, 0xc3, 0xa0, 0xbb, 0xab, 0xac, 0xe0, 0x8f, 0x60, 0x6d, 0x02, 0x23, 0x96, 0xb5, 0x01, 0x8d,
+	0x28, 0x0c, 0x13, 0xb4, 0x3f, 0x98, 0x17, 0x6b, 0x30, 0x2f, 0x16, 0x10, 0xf6, 0x84, 0x5e, 0x76,
+	0x07, 0xb1, 0xf5, 0x21, 0xac, 0xed, 0x52, 0x9f, 0x26, 0x34, 0xed, 0x2e, 0x65, 0xfa, 0x1e, 0xd4,
+	0xb5, 0xce, 0xc2, 0x92, 0xaa, 0xa9, 0xbe, 0xd6, 0x37, 0xa0, 0x3d, 0xd9, 0x35, 0x0d, 0x79, 0x0e,
+	0x18, 0x6e, 0x20, 0x5e, 0x55, 0xd9, 0xb4, 0xee, 0xc1, 0x5d, 0x3b, 0x4c, 0xdc, 0xb4, 0x97, 0xcd,
+	0x07, 0x94, 0xab, 0x59, 0x87, 0xd7, 0xa6, 0xe0, 0xf9, 0xd0, 0xd6, 0x3f, 0x1a, 0xb0, 0xf4, 0xc8,
+	0x7d, 0x91, 0xe2, 0x05, 0xbb, 0x1b, 0x50, 0x1f, 0xd1, 0x48, 0xe4, 0xcb, 0xf8, 0x52, 0x6b, 0xb6,
+	0x0e, 0xca, 0x2f, 0xa8, 0x94, 0x5b, 0x10, 0x32, 0x2d, 0xbe, 0xa7, 0x94, 0xfa, 0x58, 0x34, 0x59,
+	0xb1, 0xd2, 0xc8, 0x89, 0x58, 0x25, 0xb0, 0xa8, 0xd9, 0xf1, 0x46, 0x36, 0x36, 0x99, 0x2f, 0xc9,
+	0x12, 0xbf, 0xac, 0xc6, 0x62, 0x46, 0x18, 0x65, 0x08, 0x79, 0x16, 0x79, 0x2c, 0xde, 0x31, 0xa0,
+	0xc1, 0x25, 0xc7, 0xce, 0x32, 0x6c, 0x15, 0x01, 0x88, 0xb4, 0xb6, 0x60, 0x39, 0xbb, 0x92, 0x34,
+	0xe0, 0x33, 0x14, 0x30, 0x19, 0x8d, 0x97, 0x6d, 0xeb, 0x19, 0xac, 0x61, 0x95, 0xfb, 0x61, 0xe0,
+	0x85, 0xc1, 0x53, 0x1a, 0xc7, 0xee, 0x73, 0xaa, 0xc5, 0x49, 0x46, 0x6e, 0x72, 0x2e, 0x96, 0xce,
+	0x7e, 0x23, 0x4c, 0xd5, 0x3e, 0x57, 0x44, 0xf1, 0x12, 0xc6, 0x53, 0x5c, 0x11, 0x1d, 0xc1, 0x78,
+	0x8a, 0x9b, 0xb8, 0xf8, 0x09, 0xc3, 0xe4, 0xb0, 0x62, 0xc7, 0xd7, 0xe1, 0x35, 0xe5, 0x84, 0xe9,
+	0x04, 0x4a, 0x02, 0xff, 0x2f, 0x10, 0x1d, 0xae, 0x25, 0x73, 0xa5, 0x27, 0x96, 0x9f, 0xba, 0xa4,
+	0x4d, 0x4d, 0xf9, 0xd4, 0xdc, 0x86, 0xcf, 0x2d, 0xe9, 0x06, 0x46, 0xb1, 0xbe, 0xc2, 0xe6, 0x15,
+	0x2b, 0xbc, 0x03, 0xb7, 0x0b, 0xa6, 0x11, 0x4b, 0xdc, 0x80, 0x7b, 0x6a, 0x89, 0x19, 0x8a, 0x38,
+	0x8d, 0xd1, 0x35, 0x33, 0x88, 0x2f, 0x55, 0x83, 0x28, 0x79, 0x2e, 0x17, 0xf0, 0x5c, 0x49, 0x79,
+	0x7e, 0xf7, 0xdf, 0x0c, 0xa8, 0x6b, 0x8e, 0x18, 0xa9, 0x42, 0xa5, 0x77, 0xc8, 0xca, 0xbd, 0xee,
+	0xc1, 0xed, 0x93, 0xce, 0xd3, 0xa3, 0x43, 0x7b, 0xdb, 0xfe, 0xd4, 0xd9, 0xd9, 0xdf, 0xee, 0xf5,
+	0x3a, 0x07, 0xcc, 0x0f, 0x79, 0x66, 0x77, 0x5a, 0x3f, 0xd9, 0x20, 0x2b, 0xd0, 0xda, 0xeb, 0x74,
+	0x9c, 0x6e, 0xef, 0xf8, 0xd9, 0xde, 0x5e, 0x77, 0xa7, 0xdb, 0xe9, 0x9d, 0xb4, 0x7e, 0xba, 0x41,
+	0xee, 0xc0, 0x6a, 0xda, 0xad, 0x77, 0xb8, 0xdb, 0x51, 0x7d, 0x7e, 0xf5, 0x63, 0xb2, 0x06, 0x8b,
+	0xcf, 0x7a, 0x4f, 0x7a, 0x87, 0x9f, 0xf4, 0x9c, 0x5e, 0xe7, 0x87, 0x27, 0x0e, 0xd6, 0x93, 0xb5,
+	0x7e, 0xfd, 0x73, 0x83, 0xac, 0xc3, 0xed, 0x6e, 0x6f, 0xe7, 0xd0, 0xb6, 0x3b, 0x3b, 0x27, 0xce,
+	0xd1, 0xf6, 0xa7, 0x4f, 0x3b, 0xbd, 0x13, 0x67, 0xb7, 0x73, 0xb2, 0xdd, 0x3d, 0x38, 0x6e, 0xfd,
+	0xd6, 0xe7, 0x06, 0xb9, 0x0d, 0x2b, 0x7b, 0xdd, 0xde, 0xf6, 0x81, 0xd3, 0xf9, 0xe1, 0x51, 0xd7,
+	0xfe, 0xd4, 0x39, 0x39, 0x3c, 0x74, 0x8e, 0x0f, 0x0f, 0x7b, 0xad, 0x45, 0x42, 0x60, 0x5e, 0x03,
+	0xee, 0x6d, 0xdb, 0xad, 0x95, 0x77, 0xb7, 0xa0, 0x99, 0x49, 0x91, 0x91, 0x39, 0x28, 0x6f, 0x1f,
+	0x1c, 0xb4, 0x6e, 0xa1, 0xf3, 0x75, 0x78, 0xd4, 0xe9, 0x75, 0x7b, 0x8f, 0x5b, 0x06, 0x36, 0x76,
+	0x0e, 0x0e, 0x8f, 0xb1, 0x51, 0x7a, 0x77, 0x4f, 0x45, 0x2c, 0x44, 0x9f, 0x3a, 0xcc, 0x09, 0x6e,
+	0x5b, 0xb7, 0xd0, 0x13, 0xeb, 0xf6, 0x9c, 0xbd, 0x83, 0xee, 0xe3, 0xfd, 0x93, 0x96, 0x81, 0xcd,
+	0xe3, 0x67, 0x3b, 0x3b, 0x9d, 0xce, 0x6e, 0x67, 0xb7, 0x55, 0x42, 0x2f, 0x0e, 0x97, 0xd9, 0xd9,
+	0x6d, 0x95, 0xb7, 0xfe, 0xe5, 0x2e, 0xd4, 0x94, 0x8f, 0x42, 0xbe, 0x27, 0xbf, 0x22, 0x90, 0xd1,
+	0xf1, 0x3b, 0x99, 0x9a, 0xfc, 0x6c, 0x8e, 0xc7, 0xbc, 0x5b, 0x8c, 0x14, 0xb7, 0xf6, 0xe9, 0x44,
+	0xb2, 0xe1, 0xee, 0x94, 0xbc, 0x05, 0x1f, 0xed, 0xb5, 0x2b, 0xb3, 0x1a, 0xe4, 0x23, 0xa8, 0xca,
+	0x6f, 0x6e, 0xc8, 0x6a, 0xf1, 0xa7, 0x41, 0xe6, 0xda, 0x04, 0x5c, 0x74, 0xfe, 0x0e, 0xd4, 0xd4,
+	0xb7, 0x30, 0x44, 0xa7, 0xd2, 0x3f, 0xcd, 0x31, 0xdb, 0x93, 0x08, 0xd1, 0x7f, 0x1b, 0x20, 0xfd,
+	0x3e, 0x82, 0xb4, 0xa7, 0x7d, 0x32, 0x61, 0xde, 0x2e, 0xc0, 0x88, 0x21, 0xbe, 0x07, 0xcd, 0xcc,
+	0x97, 0x10, 0x6a, 0x6b, 0x8b, 0xbe, 0xe7, 0x30, 0xef, 0x16, 0x23, 0xc5, 0x58, 0xbb, 0x50, 0xd7,
+	0xbe, 0x06, 0x20, 0xb7, 0x35, 0xe2, 0xec, 0xc7, 0x11, 0xa6, 0x59, 0x84, 0x12, 0xa3, 0x1c, 0x43,
+	0x2b, 0xff, 0xdd, 0x0d, 0xb9, 0x97, 0xe6, 0x4d, 0x8b, 0x3e, 0x08, 0x32, 0xd7, 0xa7, 0xe2, 0x35,
+	0xd6, 0xd2, 0x0f, 0xe7, 0x52, 0xd6, 0x26, 0xbe, 0xd0, 0x33, 0xcd, 0x22, 0x54, 0xba, 0x59, 0x99,
+	0x0f, 0xf0, 0xd4, 0x66, 0x15, 0x7d, 0xeb, 0x67, 0xde, 0x2d, 0x46, 0xa6, 0x67, 0x97, 0x7e, 0x32,
+	0xa7, 0xce, 0x6e, 0xe2, 0x33, 0x3e, 0xf3, 0x76, 0x01, 0x46, 0x0c, 0x71, 0x04, 0x0b, 0xb9, 0x8f,
+	0x70, 0x89, 0x94, 0xd6, 0xe2, 0xcf, 0x83, 0xcd, 0x7b, 0xd3, 0xd0, 0xe9, 0x02, 0x33, 0xdf, 0xdb,
+	0xaa, 0x05, 0x16, 0x7d, 0xb7, 0x6b, 0xde, 0x2d, 0x46, 0xaa, 0x9b, 0x21, 0x3e, 0x9f, 0xe5, 0xf7,
+	0x90, 0x28, 0xb3, 0x52, 0xff, 0x6e, 0xd7, 0x5c, 0xca, 0x40, 0xf9, 0x9b, 0xf4, 0xc0, 0xc0, 0xa5,
+	0xe5, 0xbe, 0x62, 0x55, 0x4b, 0x2b, 0xfe, 0xf0, 0xd5, 0xbc, 0x37, 0x0d, 0x2d, 0xd8, 0x79, 0xc2,
+	0x46, 0xd4, 0x3f, 0xce, 0xd6, 0x47, 0x2c, 0xf8, 0x68, 0x5b, 0xed, 0x7c, 0xc1, 0x97, 0xdb, 0x07,
+	0xb0, 0xa2, 0x1e, 0xa2, 0x2f, 0x32, 0x64, 0xc1, 0xb7, 0xdd, 0x0f, 0x0c, 0x94, 0xf8, 0xfc, 0x87,
+	0x89, 0x4a, 0xe2, 0xa7, 0x7c, 0x14, 0x69, 0xae, 0x4f, 0xc5, 0xa7, 0x12, 0xaf, 0x7d, 0x1d, 0x43,
+	0xb4, 0xca, 0x83, 0xdc, 0x47, 0x37, 0xa6, 0x59, 0x84, 0x4a, 0x35, 0x94, 0x2a, 0xe8, 0x26, 0x6b,
+	0x9a, 0x28, 0xea, 0x65, 0xdf, 0x66, 0x7b, 0x12, 0x21, 0xfa, 0x3f, 0x86, 0x25, 0xb5, 0x51, 0xaa,
+	0x4e, 0x3b, 0x56, 0x2a, 0xb7, 0xb0, 0xe8, 0xdb, 0x6c, 0xe5, 0xb1, 0x0f, 0x0c, 0xfc, 0x72, 0x5d,
+	0x2f, 0x42, 0x26, 0xba, 0x06, 0xc9, 0x95, 0x4e, 0x9b, 0x77, 0x0a, 0x71, 0x82, 0xa3, 0x87, 0x30,
+	0x27, 0x0a, 0x8e, 0xc9, 0x4a, 0x7a, 0x58, 0xba, 0x24, 0xad, 0xe6, 0xc1, 0x6a, 0x2d, 0x0d, 0xbd,
+	0xea, 0x56, 0xb1, 0x50, 0x50, 0xa2, 0x6b, 0xde, 0x29, 0xc4, 0x89, 0x81, 0x76, 0xa0, 0xae, 0x55,
+	0xc3, 0xa9, 0xa3, 0x99, 0xac, 0x90, 0x33, 0xd7, 0x34, 0x94, 0x5e, 0x4c, 0xf5, 0xc0, 0x20, 0x7b,
+	0xd0, 0xd0, 0x2b, 0x35, 0x15, 0x37, 0x05, 0xe5, 0x9b, 0x66, 0x5b, 0xc7, 0xe5, 0xc6, 0xe9, 0xc1,
+	0x42, 0xbe, 0x00, 0xfa, 0xee, 0x94, 0x72, 0xa3, 0xec, 0x83, 0x38, 0xa5, 0x8a, 0xe9, 0x21, 0xcc,
+	0x89, 0x4a, 0x53, 0xb5, 0xbf, 0xd9, 0x3a, 0x57, 0x73, 0x35, 0x0f, 0x56, 0xde, 0x1d, 0xfb, 0x8f,
+	0x2e, 0xc2, 0x7e, 0x20, 0x64, 0xf2, 0x7f, 0x97, 0x98, 0x4b, 0x19, 0x18, 0xef, 0x77, 0xdf, 0xe0,
+	0x57, 0x28, 0x9f, 0x50, 0x56, 0x57, 0x68, 0x4a, 0x12, 0xda, 0x5c, 0x9f, 0x8a, 0x4f, 0x85, 0x5f,
+	0x25, 0x90, 0x95, 0xf0, 0xe7, 0xd3, 0xcc, 0x66, 0x7b, 0x12, 0x21, 0xfa, 0xff, 0x08, 0x96, 0x0a,
+	0x12, 0xc6, 0xe4, 0x75, 0xd1, 0x61, 0x7a, 0xaa, 0xd9, 0xb4, 0xae, 0x22, 0x49, 0x47, 0xef, 0x0e,
+	0xa7, 0x8f, 0xde, 0x1d, 0x5e, 0x3b, 0xfa, 0x55, 0xe9, 0xe1, 0x5d, 0xa8, 0x6b, 0x19, 0x40, 0x25,
+	0xa3, 0x93, 0x79, 0x5f, 0xd3, 0x2c, 0x42, 0x89, 0x51, 0x1e, 0x41, 0x43, 0x4f, 0x06, 0x2a, 0x21,
+	0x2d, 0xc8, 0x10, 0x9a, 0xb9, 0x44, 0x95, 0x12, 0xd0, 0x03, 0x4d, 0x85, 0xa4, 0xc9, 0x25, 0xb5,
+	0xce, 0xe9, 0x89, 0x27, 0xa5, 0x47, 0x14, 0xe6, 0x81, 0x41, 0x3e, 0x80, 0xfa, 0x63, 0x5e, 0x37,
+	0xca, 0x54, 0xc0, 0xaa, 0x16, 0x3c, 0xd5, 0x75, 0xc0, 0x42, 0x0e, 0x4e, 0x1e, 0xb3, 0x4f, 0x17,
+	0xb4, 0x18, 0xab, 0xda, 0x92, 0xc9, 0xc8, 0xb5, 0x69, 0x16, 0xa1, 0xc4, 0x96, 0x7c, 0xc8, 0x18,
+	0x90, 0xb1, 0x3f, 0xc5, 0x40, 0x2e, 0x18, 0x68, 0x16, 0x04, 0xcc, 0xc9, 0x2e, 0x2c, 0x1c, 0x84,
+	0xe1, 0x8b, 0xf1, 0x48, 0xc5, 0x98, 0x48, 0x2e, 0xf6, 0xd1, 0xdd, 0xcd, 0x4b, 0xe5, 0x64, 0x38,
+	0xea, 0x3b, 0x50, 0x4b, 0x03, 0x44, 0x6b, 0x2a, 0xcb, 0x90, 0x0d, 0x27, 0x99, 0xed, 0x49, 0x44,
+	0x6a, 0x75, 0xe4, 0x02, 0x19, 0xea, 0xd5, 0x2b, 0x0e, 0x7d, 0x98, 0xf7, 0xa6, 0xa1, 0x53, 0x8b,
+	0x2f, 0x1f, 0xa2, 0x50, 0x97, 0x77, 0x4a, 0xd8, 0xc3, 0x5c, 0x9f, 0x8a, 0x17, 0x83, 0x9e, 0xc2,
+	0x4a, 0x61, 0x84, 0x82, 0xbc, 0xa1, 0xc2, 0x5b, 0xd3, 0xe3, 0x1b, 0xe6, 0x9b, 0x57, 0x13, 0xa5,
+	0x2f, 0x82, 0x1e, 0x19, 0x50, 0xe2, 0x5d, 0x10, 0xf8, 0x30, 0xef, 0x14, 0xe2, 0xd2, 0x1d, 0xc8,
+	0xfb, 0xf5, 0xa9, 0xfa, 0x2a, 0x8e, 0x23, 0x98, 0xeb, 0x53, 0xf1, 0x62, 0xd0, 0xff, 0x0f, 0xab,
+	0xc5, 0x01, 0x01, 0xf2, 0x66, 0xfe, 0xee, 0x14, 0xc5, 0x0b, 0x94, 0xfd, 0x33, 0x19, 0x34, 0x78,
+	0x60, 0x90, 0x1f, 0x88, 0xcf, 0xf8, 0x33, 0xce, 0xb6, 0xce, 0x52, 0x51, 0xa0, 0xc0, 0xdc, 0x98,
+	0x4e, 0x20, 0x98, 0xfe, 0x21, 0xac, 0x4d, 0x71, 0xf1, 0xc9, 0x5b, 0x79, 0xae, 0x0b, 0x43, 0x00,
+	0x4a, 0x8f, 0x64, 0xb0, 0x0f, 0x8c, 0xd3, 0x59, 0xf6, 0xff, 0xc3, 0xbe, 0xfe, 0x3f, 0x03, 0x00,
+	0x1a, 0x7f, 0x05, 0xee, 0x4c, 0x4c, 0x00, 0x00,
 }
@@ -0,0 +1,3 @@
+#!/bin/sh
+
+protoc -I . signer.proto --go_out=plugins=grpc:.
@@ -0,0 +1,661 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: signer.proto
+
+/*
+Package signrpc is a generated protocol buffer package.
+
+It is generated from these files:
+	signer.proto
+
+It has these top-level messages:
+	TxOut
+	SignDescriptor
+	SignReq
+	SignResp
+	InputScript
+	InputScriptResp
+	SharedKeyRequest
+	SharedKeyResponse
+	IdentityPubKeyRequest
+	IdentityPubKeyResponse
+	RevocationRootRequest
+	RevocationRootResponse
+	KeyLocator
+	KeyDescriptor
+*/
+package signrpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type TxOut struct {
+	Value    int64  `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+	PkScript []byte `protobuf:"bytes,2,opt,name=pk_script,json=pkScript,proto3" json:"pk_script,omitempty"`
+}
+
+func (m *TxOut) Reset()                    { *m = TxOut{} }
+func (m *TxOut) String() string            { return proto.CompactTextString(m) }
+func (*TxOut) ProtoMessage()               {}
+func (*TxOut) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *TxOut) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *TxOut) GetPkScript() []byte {
+	if m != nil {
+		return m.PkScript
+	}
+	return nil
+}
+
+type SignDescriptor struct {
+	PubKey       []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	RedeemScript []byte `protobuf:"bytes,2,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	Output       *TxOut `protobuf:"bytes,3,opt,name=output" json:"output,omitempty"`
+	Sighash      uint32 `protobuf:"varint,4,opt,name=sighash" json:"sighash,omitempty"`
+	InputIndex   int32  `protobuf:"varint,5,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+}
+
+func (m *SignDescriptor) Reset()                    { *m = SignDescriptor{} }
+func (m *SignDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*SignDescriptor) ProtoMessage()               {}
+func (*SignDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SignDescriptor) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *SignDescriptor) GetRedeemScript() []byte {
+	if m != nil {
+		return m.RedeemScript
+	}
+	return nil
+}
+
+func (m *SignDescriptor) GetOutput() *TxOut {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *SignDescriptor) GetSighash() uint32 {
+	if m != nil {
+		return m.Sighash
+	}
+	return 0
+}
+
+func (m *SignDescriptor) GetInputIndex() int32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+type SignReq struct {
+	RawTxBytes []byte            `protobuf:"bytes,1,opt,name=raw_tx_bytes,json=rawTxBytes,proto3" json:"raw_tx_bytes,omitempty"`
+	SignDescs  []*SignDescriptor `protobuf:"bytes,2,rep,name=sign_descs,json=signDescs" json:"sign_descs,omitempty"`
+}
+
+func (m *SignReq) Reset()                    { *m = SignReq{} }
+func (m *SignReq) String() string            { return proto.CompactTextString(m) }
+func (*SignReq) ProtoMessage()               {}
+func (*SignReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *SignReq) GetRawTxBytes() []byte {
+	if m != nil {
+		return m.RawTxBytes
+	}
+	return nil
+}
+
+func (m *SignReq) GetSignDescs() []*SignDescriptor {
+	if m != nil {
+		return m.SignDescs
+	}
+	return nil
+}
+
+type SignResp struct {
+	RawSigs [][]byte `protobuf:"bytes,1,rep,name=raw_sigs,json=rawSigs,proto3" json:"raw_sigs,omitempty"`
+}
+
+func (m *SignResp) Reset()                    { *m = SignResp{} }
+func (m *SignResp) String() string            { return proto.CompactTextString(m) }
+func (*SignResp) ProtoMessage()               {}
+func (*SignResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *SignResp) GetRawSigs() [][]byte {
+	if m != nil {
+		return m.RawSigs
+	}
+	return nil
+}
+
+type InputScript struct {
+	Witness   [][]byte `protobuf:"bytes,1,rep,name=witness,proto3" json:"witness,omitempty"`
+	SigScript []byte   `protobuf:"bytes,2,opt,name=sig_script,json=sigScript,proto3" json:"sig_script,omitempty"`
+}
+
+func (m *InputScript) Reset()                    { *m = InputScript{} }
+func (m *InputScript) String() string            { return proto.CompactTextString(m) }
+func (*InputScript) ProtoMessage()               {}
+func (*InputScript) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *InputScript) GetWitness() [][]byte {
+	if m != nil {
+		return m.Witness
+	}
+	return nil
+}
+
+func (m *InputScript) GetSigScript() []byte {
+	if m != nil {
+		return m.SigScript
+	}
+	return nil
+}
+
+type InputScriptResp struct {
+	InputScripts []*InputScript `protobuf:"bytes,1,rep,name=input_scripts,json=inputScripts" json:"input_scripts,omitempty"`
+}
+
+func (m *InputScriptResp) Reset()                    { *m = InputScriptResp{} }
+func (m *InputScriptResp) String() string            { return proto.CompactTextString(m) }
+func (*InputScriptResp) ProtoMessage()               {}
+func (*InputScriptResp) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *InputScriptResp) GetInputScripts() []*InputScript {
+	if m != nil {
+		return m.InputScripts
+	}
+	return nil
+}
+
+type SharedKeyRequest struct {
+	EphemeralPubkey []byte `protobuf:"bytes,1,opt,name=ephemeral_pubkey,json=ephemeralPubkey,proto3" json:"ephemeral_pubkey,omitempty"`
+}
+
+func (m *SharedKeyRequest) Reset()                    { *m = SharedKeyRequest{} }
+func (m *SharedKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*SharedKeyRequest) ProtoMessage()               {}
+func (*SharedKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SharedKeyRequest) GetEphemeralPubkey() []byte {
+	if m != nil {
+		return m.EphemeralPubkey
+	}
+	return nil
+}
+
+type SharedKeyResponse struct {
+	SharedKey []byte `protobuf:"bytes,1,opt,name=shared_key,json=sharedKey,proto3" json:"shared_key,omitempty"`
+}
+
+func (m *SharedKeyResponse) Reset()                    { *m = SharedKeyResponse{} }
+func (m *SharedKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SharedKeyResponse) ProtoMessage()               {}
+func (*SharedKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SharedKeyResponse) GetSharedKey() []byte {
+	if m != nil {
+		return m.SharedKey
+	}
+	return nil
+}
+
+type IdentityPubKeyRequest struct {
+}
+
+func (m *IdentityPubKeyRequest) Reset()                    { *m = IdentityPubKeyRequest{} }
+func (m *IdentityPubKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*IdentityPubKeyRequest) ProtoMessage()               {}
+func (*IdentityPubKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+type IdentityPubKeyResponse struct {
+	IdentityPubkey []byte `protobuf:"bytes,1,opt,name=identity_pubkey,json=identityPubkey,proto3" json:"identity_pubkey,omitempty"`
+}
+
+func (m *IdentityPubKeyResponse) Reset()                    { *m = IdentityPubKeyResponse{} }
+func (m *IdentityPubKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*IdentityPubKeyResponse) ProtoMessage()               {}
+func (*IdentityPubKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *IdentityPubKeyResponse) GetIdentityPubkey() []byte {
+	if m != nil {
+		return m.IdentityPubkey
+	}
+	return nil
+}
+
+type RevocationRootRequest struct {
+}
+
+func (m *RevocationRootRequest) Reset()                    { *m = RevocationRootRequest{} }
+func (m *RevocationRootRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevocationRootRequest) ProtoMessage()               {}
+func (*RevocationRootRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+type RevocationRootResponse struct {
+	RootKey []byte `protobuf:"bytes,1,opt,name=root_key,json=rootKey,proto3" json:"root_key,omitempty"`
+}
+
+func (m *RevocationRootResponse) Reset()                    { *m = RevocationRootResponse{} }
+func (m *RevocationRootResponse) String() string            { return proto.CompactTextString(m) }
+func (*RevocationRootResponse) ProtoMessage()               {}
+func (*RevocationRootResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RevocationRootResponse) GetRootKey() []byte {
+	if m != nil {
+		return m.RootKey
+	}
+	return nil
+}
+
+type KeyLocator struct {
+	// The family of the key, e.g. 0 for multisig keys.
+	KeyFamily int32 `protobuf:"varint,1,opt,name=key_family,json=keyFamily" json:"key_family,omitempty"`
+	// The index of the key within its family.
+	KeyIndex int32 `protobuf:"varint,2,opt,name=key_index,json=keyIndex" json:"key_index,omitempty"`
+}
+
+func (m *KeyLocator) Reset()                    { *m = KeyLocator{} }
+func (m *KeyLocator) String() string            { return proto.CompactTextString(m) }
+func (*KeyLocator) ProtoMessage()               {}
+func (*KeyLocator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *KeyLocator) GetKeyFamily() int32 {
+	if m != nil {
+		return m.KeyFamily
+	}
+	return 0
+}
+
+func (m *KeyLocator) GetKeyIndex() int32 {
+	if m != nil {
+		return m.KeyIndex
+	}
+	return 0
+}
+
+type KeyDescriptor struct {
+	// The raw bytes of the compressed public key.
+	RawKeyBytes []byte `protobuf:"bytes,1,opt,name=raw_key_bytes,json=rawKeyBytes,proto3" json:"raw_key_bytes,omitempty"`
+	// The locator of the key.
+	KeyLoc *KeyLocator `protobuf:"bytes,2,opt,name=key_loc,json=keyLoc" json:"key_loc,omitempty"`
+}
+
+func (m *KeyDescriptor) Reset()                    { *m = KeyDescriptor{} }
+func (m *KeyDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*KeyDescriptor) ProtoMessage()               {}
+func (*KeyDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *KeyDescriptor) GetRawKeyBytes() []byte {
+	if m != nil {
+		return m.RawKeyBytes
+	}
+	return nil
+}
+
+func (m *KeyDescriptor) GetKeyLoc() *KeyLocator {
+	if m != nil {
+		return m.KeyLoc
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TxOut)(nil), "signrpc.TxOut")
+	proto.RegisterType((*SignDescriptor)(nil), "signrpc.SignDescriptor")
+	proto.RegisterType((*SignReq)(nil), "signrpc.SignReq")
+	proto.RegisterType((*SignResp)(nil), "signrpc.SignResp")
+	proto.RegisterType((*InputScript)(nil), "signrpc.InputScript")
+	proto.RegisterType((*InputScriptResp)(nil), "signrpc.InputScriptResp")
+	proto.RegisterType((*SharedKeyRequest)(nil), "signrpc.SharedKeyRequest")
+	proto.RegisterType((*SharedKeyResponse)(nil), "signrpc.SharedKeyResponse")
+	proto.RegisterType((*IdentityPubKeyRequest)(nil), "signrpc.IdentityPubKeyRequest")
+	proto.RegisterType((*IdentityPubKeyResponse)(nil), "signrpc.IdentityPubKeyResponse")
+	proto.RegisterType((*RevocationRootRequest)(nil), "signrpc.RevocationRootRequest")
+	proto.RegisterType((*RevocationRootResponse)(nil), "signrpc.RevocationRootResponse")
+	proto.RegisterType((*KeyLocator)(nil), "signrpc.KeyLocator")
+	proto.RegisterType((*KeyDescriptor)(nil), "signrpc.KeyDescriptor")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Signer service
+
+type SignerClient interface {
+	// SignOutputRaw generates a signature for each of the sign descriptors
+	// within the request, using the key identified by the descriptor.
+	SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error)
+	// ComputeInputScript generates a complete input script for each of the
+	// sign descriptors within the request, each of which must spend an
+	// output controlled by the wallet.
+	ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error)
+	// DeriveSharedKey carries out ECDH with the node's identity key. It's
+	// only available to a watch-only node.
+	DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error)
+	// GetIdentityPubKey returns the public key of the node's identity key.
+	GetIdentityPubKey(ctx context.Context, in *IdentityPubKeyRequest, opts ...grpc.CallOption) (*IdentityPubKeyResponse, error)
+	// DeriveRevocationRoot returns the master revocation root of the node.
+	// It's only available to a watch-only node.
+	DeriveRevocationRoot(ctx context.Context, in *RevocationRootRequest, opts ...grpc.CallOption) (*RevocationRootResponse, error)
+	// DeriveKey returns the public key found at the target locator within
+	// the node's keychain. Keys are derived at the path
+	// m/1017'/coinType'/keyFamily'/0/index, allowing external tooling, such
+	// as channel fund recovery tools, to locate the keys used by the node.
+	DeriveKey(ctx context.Context, in *KeyLocator, opts ...grpc.CallOption) (*KeyDescriptor, error)
+}
+
+type signerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) SignOutputRaw(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*SignResp, error) {
+	out := new(SignResp)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/SignOutputRaw", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) ComputeInputScript(ctx context.Context, in *SignReq, opts ...grpc.CallOption) (*InputScriptResp, error) {
+	out := new(InputScriptResp)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/ComputeInputScript", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) DeriveSharedKey(ctx context.Context, in *SharedKeyRequest, opts ...grpc.CallOption) (*SharedKeyResponse, error) {
+	out := new(SharedKeyResponse)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/DeriveSharedKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) GetIdentityPubKey(ctx context.Context, in *IdentityPubKeyRequest, opts ...grpc.CallOption) (*IdentityPubKeyResponse, error) {
+	out := new(IdentityPubKeyResponse)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/GetIdentityPubKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) DeriveRevocationRoot(ctx context.Context, in *RevocationRootRequest, opts ...grpc.CallOption) (*RevocationRootResponse, error) {
+	out := new(RevocationRootResponse)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/DeriveRevocationRoot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) DeriveKey(ctx context.Context, in *KeyLocator, opts ...grpc.CallOption) (*KeyDescriptor, error) {
+	out := new(KeyDescriptor)
+	err := grpc.Invoke(ctx, "/signrpc.Signer/DeriveKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Signer service
+
+type SignerServer interface {
+	// SignOutputRaw generates a signature for each of the sign descriptors
+	// within the request, using the key identified by the descriptor.
+	SignOutputRaw(context.Context, *SignReq) (*SignResp, error)
+	// ComputeInputScript generates a complete input script for each of the
+	// sign descriptors within the request, each of which must spend an
+	// output controlled by the wallet.
+	ComputeInputScript(context.Context, *SignReq) (*InputScriptResp, error)
+	// DeriveSharedKey carries out ECDH with the node's identity key. It's
+	// only available to a watch-only node.
+	DeriveSharedKey(context.Context, *SharedKeyRequest) (*SharedKeyResponse, error)
+	// GetIdentityPubKey returns the public key of the node's identity key.
+	GetIdentityPubKey(context.Context, *IdentityPubKeyRequest) (*IdentityPubKeyResponse, error)
+	// DeriveRevocationRoot returns the master revocation root of the node.
+	// It's only available to a watch-only node.
+	DeriveRevocationRoot(context.Context, *RevocationRootRequest) (*RevocationRootResponse, error)
+	// DeriveKey returns the public key found at the target locator within
+	// the node's keychain. Keys are derived at the path
+	// m/1017'/coinType'/keyFamily'/0/index, allowing external tooling, such
+	// as channel fund recovery tools, to locate the keys used by the node.
+	DeriveKey(context.Context, *KeyLocator) (*KeyDescriptor, error)
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
+
+func _Signer_SignOutputRaw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).SignOutputRaw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/SignOutputRaw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).SignOutputRaw(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_ComputeInputScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).ComputeInputScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/ComputeInputScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).ComputeInputScript(ctx, req.(*SignReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_DeriveSharedKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).DeriveSharedKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/DeriveSharedKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).DeriveSharedKey(ctx, req.(*SharedKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_GetIdentityPubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityPubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).GetIdentityPubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/GetIdentityPubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).GetIdentityPubKey(ctx, req.(*IdentityPubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_DeriveRevocationRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevocationRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).DeriveRevocationRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/DeriveRevocationRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).DeriveRevocationRoot(ctx, req.(*RevocationRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_DeriveKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyLocator)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).DeriveKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signrpc.Signer/DeriveKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).DeriveKey(ctx, req.(*KeyLocator))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Signer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "signrpc.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignOutputRaw",
+			Handler:    _Signer_SignOutputRaw_Handler,
+		},
+		{
+			MethodName: "ComputeInputScript",
+			Handler:    _Signer_ComputeInputScript_Handler,
+		},
+		{
+			MethodName: "DeriveSharedKey",
+			Handler:    _Signer_DeriveSharedKey_Handler,
+		},
+		{
+			MethodName: "GetIdentityPubKey",
+			Handler:    _Signer_GetIdentityPubKey_Handler,
+		},
+		{
+			MethodName: "DeriveRevocationRoot",
+			Handler:    _Signer_DeriveRevocationRoot_Handler,
+		},
+		{
+			MethodName: "DeriveKey",
+			Handler:    _Signer_DeriveKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
+
+func init() { proto.RegisterFile("signer.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 687 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xef, 0x6f, 0xda, 0x3a,
+	0x14, 0x15, 0xe5, 0xf1, 0xeb, 0x02, 0xa5, 0xf5, 0xeb, 0x6b, 0x53, 0x9e, 0xde, 0x2b, 0xca, 0xb4,
+	0x8d, 0x49, 0x53, 0x3f, 0xd0, 0x69, 0xda, 0x26, 0x4d, 0xda, 0x8f, 0xaa, 0x6b, 0x45, 0xa5, 0x56,
+	0xa6, 0xd2, 0x3e, 0x46, 0x01, 0xee, 0xc0, 0x0a, 0x24, 0xae, 0xed, 0x14, 0xf2, 0x67, 0x4d, 0xfb,
+	0x07, 0x27, 0xdb, 0x29, 0x09, 0x8c, 0x7e, 0xe3, 0x9e, 0xe3, 0x7b, 0xee, 0x71, 0xee, 0x31, 0xd0,
+	0x90, 0x6c, 0x12, 0xa2, 0x38, 0xe5, 0x22, 0x52, 0x11, 0xa9, 0xe8, 0x4a, 0xf0, 0x91, 0xfb, 0x01,
+	0x4a, 0x77, 0xcb, 0x9b, 0x58, 0x91, 0x03, 0x28, 0x3d, 0xf8, 0xb3, 0x18, 0x9d, 0x42, 0xa7, 0xd0,
+	0x2d, 0x52, 0x5b, 0x90, 0x7f, 0xa1, 0xc6, 0x03, 0x4f, 0x8e, 0x04, 0xe3, 0xca, 0xd9, 0xe9, 0x14,
+	0xba, 0x0d, 0x5a, 0xe5, 0xc1, 0xc0, 0xd4, 0xee, 0xcf, 0x02, 0xec, 0x0e, 0xd8, 0x24, 0x3c, 0x47,
+	0x7b, 0x20, 0x12, 0xe4, 0x08, 0x2a, 0x3c, 0x1e, 0x7a, 0x01, 0x26, 0x46, 0xa7, 0x41, 0xcb, 0x3c,
+	0x1e, 0xf6, 0x31, 0x21, 0xcf, 0xa0, 0x29, 0x70, 0x8c, 0x38, 0x5f, 0x17, 0x6b, 0x58, 0xd0, 0x0a,
+	0x92, 0x17, 0x50, 0x8e, 0x62, 0xc5, 0x63, 0xe5, 0x14, 0x3b, 0x85, 0x6e, 0xbd, 0xb7, 0x7b, 0x9a,
+	0xda, 0x3c, 0x35, 0x1e, 0x69, 0xca, 0x12, 0x07, 0xb4, 0xff, 0xa9, 0x2f, 0xa7, 0xce, 0x5f, 0x9d,
+	0x42, 0xb7, 0x49, 0x1f, 0x4b, 0x72, 0x02, 0x75, 0x16, 0xf2, 0x58, 0x79, 0x2c, 0x1c, 0xe3, 0xd2,
+	0x29, 0x75, 0x0a, 0xdd, 0x12, 0x05, 0x03, 0x5d, 0x69, 0xc4, 0x1d, 0x41, 0x45, 0x5b, 0xa6, 0x78,
+	0x4f, 0x3a, 0xd0, 0x10, 0xfe, 0xc2, 0x53, 0x4b, 0x6f, 0x98, 0x28, 0x94, 0xa9, 0x61, 0x10, 0xfe,
+	0xe2, 0x6e, 0xf9, 0x45, 0x23, 0xe4, 0x2d, 0x80, 0x36, 0xe0, 0x8d, 0x51, 0x8e, 0xa4, 0xb3, 0xd3,
+	0x29, 0x76, 0xeb, 0xbd, 0xa3, 0x95, 0xa7, 0xf5, 0xab, 0xd3, 0x9a, 0x4c, 0x6b, 0xe9, 0x3e, 0x87,
+	0xaa, 0x1d, 0x22, 0x39, 0x39, 0x86, 0xaa, 0x9e, 0x22, 0xd9, 0x44, 0x4f, 0x28, 0x76, 0x1b, 0xb4,
+	0x22, 0xfc, 0xc5, 0x80, 0x4d, 0xa4, 0x7b, 0x01, 0xf5, 0x2b, 0xed, 0x2c, 0xbd, 0xbd, 0x03, 0x95,
+	0x05, 0x53, 0x21, 0xca, 0xd5, 0xc1, 0xb4, 0x24, 0xff, 0x19, 0x1f, 0xeb, 0x5f, 0x4e, 0x8f, 0x4b,
+	0xf7, 0x70, 0x0d, 0xad, 0x9c, 0x8e, 0x99, 0xfa, 0x1e, 0x9a, 0xf6, 0x3b, 0xd8, 0x1e, 0xab, 0x58,
+	0xef, 0x1d, 0xac, 0xcc, 0xe7, 0x1b, 0x1a, 0x2c, 0x2b, 0xa4, 0xfb, 0x11, 0xf6, 0x06, 0x53, 0x5f,
+	0xe0, 0xb8, 0x8f, 0x09, 0xc5, 0xfb, 0x18, 0xa5, 0x22, 0xaf, 0x60, 0x0f, 0xf9, 0x14, 0xe7, 0x28,
+	0xfc, 0x99, 0xc7, 0xe3, 0x61, 0xb6, 0xdf, 0xd6, 0x0a, 0xbf, 0x35, 0xb0, 0xdb, 0x83, 0xfd, 0x5c,
+	0xbb, 0xe4, 0x51, 0x28, 0xd1, 0x5c, 0xc0, 0x80, 0xb9, 0x64, 0xd4, 0xe4, 0xe3, 0x31, 0xf7, 0x08,
+	0xfe, 0xb9, 0x1a, 0x63, 0xa8, 0x98, 0x4a, 0x6e, 0xe3, 0x61, 0x36, 0xd7, 0xfd, 0x0c, 0x87, 0x9b,
+	0x44, 0xaa, 0xf8, 0x12, 0x5a, 0x2c, 0x65, 0xd6, 0x0d, 0xed, 0xb2, 0xac, 0x21, 0xb0, 0xda, 0x14,
+	0x1f, 0xa2, 0x91, 0xaf, 0x58, 0x14, 0xd2, 0x28, 0x52, 0x8f, 0xda, 0x67, 0x70, 0xb8, 0x49, 0xa4,
+	0xda, 0x7a, 0x65, 0x51, 0xa4, 0x72, 0x5e, 0x2b, 0xba, 0xd6, 0x4e, 0x2f, 0x01, 0xfa, 0x98, 0x5c,
+	0xeb, 0xae, 0x48, 0xe8, 0x6b, 0x05, 0x98, 0x78, 0x3f, 0xfc, 0x39, 0x9b, 0xd9, 0xa3, 0x25, 0x5a,
+	0x0b, 0x30, 0xb9, 0x30, 0x80, 0x7e, 0x3c, 0x9a, 0xb6, 0x51, 0xdc, 0x31, 0x6c, 0x35, 0xc0, 0xc4,
+	0x06, 0xd1, 0x87, 0x66, 0x1f, 0x93, 0xdc, 0xd3, 0x71, 0xa1, 0xa9, 0x83, 0xa2, 0x3b, 0xf2, 0x79,
+	0xac, 0x0b, 0x7f, 0xd1, 0xc7, 0xc4, 0x06, 0xf2, 0x35, 0x54, 0x34, 0x3f, 0x8b, 0x46, 0x46, 0xaf,
+	0xde, 0xfb, 0x7b, 0xb5, 0xd0, 0xcc, 0x16, 0x2d, 0x07, 0xe6, 0x77, 0xef, 0x57, 0x11, 0xca, 0x03,
+	0xf3, 0xea, 0xc9, 0x1b, 0x68, 0xea, 0x5f, 0x37, 0xe6, 0xfd, 0x50, 0x7f, 0x41, 0xf6, 0xd6, 0x62,
+	0x4c, 0xf1, 0xbe, 0xbd, 0xbf, 0x81, 0x48, 0x4e, 0x3e, 0x01, 0xf9, 0x1a, 0xcd, 0x79, 0xac, 0x30,
+	0x9f, 0xd3, 0x3f, 0x5b, 0x9d, 0xad, 0xb1, 0xd2, 0x0a, 0x97, 0xd0, 0x3a, 0x47, 0xc1, 0x1e, 0x70,
+	0x95, 0x09, 0x72, 0x9c, 0xb5, 0x6f, 0xc4, 0xac, 0xdd, 0xde, 0x46, 0xa5, 0x4b, 0xb9, 0x83, 0xfd,
+	0x6f, 0xa8, 0xd6, 0xd3, 0x40, 0xfe, 0xcf, 0x06, 0x6f, 0xcb, 0x4f, 0xfb, 0xe4, 0x49, 0x3e, 0x55,
+	0xfd, 0x0e, 0x07, 0xd6, 0xdf, 0x7a, 0x14, 0x72, 0xc2, 0x5b, 0xc3, 0xd3, 0x3e, 0x79, 0x92, 0x4f,
+	0x85, 0xdf, 0x41, 0xcd, 0x0a, 0x6b, 0x9b, 0xdb, 0xb6, 0xd4, 0x3e, 0xcc, 0x83, 0x59, 0x0e, 0x86,
+	0x65, 0xf3, 0x0f, 0x7d, 0xf6, 0x7b, 0x00, 0x62, 0x46, 0x4e, 0xdd, 0xb1, 0x05, 0x00, 0x00,
+}
@@ -2,6 +2,7 @@ package btcwallet
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	"github.com/roasbeef/btcd/wire"
 )
@@ -28,6 +29,13 @@ func (b *BtcWallet) GetUtxo(txid *wire.ShaHash, index uint32) (*wire.TxOut, erro
 		return nil, err
 	}
 
+	// A nil result indicates that the output is either spent, or was
+	// never confirmed within the main chain.
+	if txout == nil {
+		return nil, fmt.Errorf("output %v:%v not found within the "+
+			"utxo set", txid, index)
+	}
+
 	pkScript, err := hex.DecodeString(txout.ScriptPubKey.Hex)
 	if err != nil {
 		return nil, err
@@ -53,3 +61,23 @@ func (b *BtcWallet) GetTransaction(txid *wire.ShaHash) (*wire.MsgTx, error) {
 
 	return tx.MsgTx(), nil
 }
+
+// GetBlockHash returns the hash of the block in the best blockchain at the
+// given height.
+//
+// This method is a part of the lnwallet.BlockChainIO interface.
+func (b *BtcWallet) GetBlockHash(blockHeight int64) (*wire.ShaHash, error) {
+	return b.rpc.GetBlockHash(blockHeight)
+}
+
+// GetBlock returns the block in the main chain identified by the given hash.
+//
+// This method is a part of the lnwallet.BlockChainIO interface.
+func (b *BtcWallet) GetBlock(blockHash *wire.ShaHash) (*wire.MsgBlock, error) {
+	block, err := b.rpc.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.MsgBlock(), nil
+}
@@ -1,6 +1,7 @@
 package btcwallet
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -26,6 +27,10 @@ const (
 var (
 	lnNamespace = []byte("ln")
 	rootKey     = []byte("ln-root")
+
+	// txLabelBucket is a sub-bucket within the lnNamespace which maps a
+	// transaction hash to a free-form label describing the transaction.
+	txLabelBucket = []byte("tx-labels")
 )
 
 // BtcWallet is an implementation of the lnwallet.WalletController interface
@@ -50,6 +55,26 @@ type BtcWallet struct {
 	// FetchInputInfo.
 	utxoCache map[wire.OutPoint]*wire.TxOut
 	cacheMtx  sync.RWMutex
+
+	// watchOnlyScripts is the set of pkScripts belonging to addresses
+	// imported as watch-only.
+	watchOnlyScripts map[string]struct{}
+	watchMtx         sync.RWMutex
+
+	// keyLookahead is the number of keys derived ahead when a signing
+	// request references a key unknown to the wallet.
+	keyLookahead uint32
+
+	// recoveryWindow is the number of addresses derived ahead on each
+	// branch while recovering the funds of a wallet restored from an
+	// existing seed. It's zero unless the wallet was restored during this
+	// run.
+	recoveryWindow uint32
+
+	// recovery tracks the progress of the scan for funds of a restored
+	// wallet.
+	recovery    recoveryState
+	recoveryMtx sync.Mutex
 }
 
 // A compile time check to ensure that BtcWallet implements the
@@ -75,14 +100,32 @@ func New(cfg *Config) (*BtcWallet, error) {
 		return nil, err
 	}
 
-	var wallet *base.Wallet
+	var (
+		wallet         *base.Wallet
+		recoveryWindow uint32
+	)
 	if !walletExists {
+		// A fresh wallet would generate its own keys, rather than
+		// those of the remote signer, so a watch-only wallet can only
+		// be initialized from a copy of the signer's wallet.
+		if cfg.WatchOnly {
+			return nil, fmt.Errorf("watch-only wallet not found in "+
+				"%v, copy the remote signer's wallet there",
+				netDir)
+		}
+
 		// Wallet has never been created, perform initial set up.
 		wallet, err = loader.CreateNewWallet(pubPass, cfg.PrivatePass,
 			cfg.HdSeed)
 		if err != nil {
 			return nil, err
 		}
+
+		// Funds may only exist on-chain if the wallet was restored
+		// from an existing seed, so we only recover in that case.
+		if cfg.HdSeed != nil {
+			recoveryWindow = cfg.RecoveryWindow
+		}
 	} else {
 		// Wallet has been created and been initialized at this point, open it
 		// along with all the required DB namepsaces, and the DB itself.
@@ -92,8 +135,12 @@ func New(cfg *Config) (*BtcWallet, error) {
 		}
 	}
 
-	if err := wallet.Manager.Unlock(cfg.PrivatePass); err != nil {
-		return nil, err
+	// A wallet which has already been converted to watching-only has no
+	// private keys to unlock.
+	if !wallet.Manager.WatchingOnly() {
+		if err := wallet.Manager.Unlock(cfg.PrivatePass); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create a special websockets rpc client for btcd which will be used
@@ -111,14 +158,30 @@ func New(cfg *Config) (*BtcWallet, error) {
 	}
 
 	return &BtcWallet{
-		wallet:      wallet,
-		rpc:         rpcc,
-		lnNamespace: walletNamespace,
-		netParams:   cfg.NetParams,
-		utxoCache:   make(map[wire.OutPoint]*wire.TxOut),
+		wallet:           wallet,
+		rpc:              rpcc,
+		lnNamespace:      walletNamespace,
+		netParams:        cfg.NetParams,
+		utxoCache:        make(map[wire.OutPoint]*wire.TxOut),
+		watchOnlyScripts: make(map[string]struct{}),
+		keyLookahead:     cfg.KeyLookahead,
+		recoveryWindow:   recoveryWindow,
 	}, nil
 }
 
+// IsWatchOnly returns true if the wallet has been stripped of its private
+// keys.
+func (b *BtcWallet) IsWatchOnly() bool {
+	return b.wallet.Manager.WatchingOnly()
+}
+
+// ConvertToWatchOnly irreversibly removes all private keys from the wallet,
+// after which any operation requiring them must be carried out by a remote
+// signer.
+func (b *BtcWallet) ConvertToWatchOnly() error {
+	return b.wallet.Manager.ConvertToWatchingOnly()
+}
+
 // Start initializes the underlying rpc connection, the wallet itself, and
 // begins syncing to the current available blockchain state.
 //
@@ -137,6 +200,22 @@ func (b *BtcWallet) Start() error {
 	// current main chain.
 	b.wallet.SynchronizeRPC(b.rpc)
 
+	// Resume tracking of any addresses previously imported as
+	// watch-only.
+	if err := b.watchImported(); err != nil {
+		return err
+	}
+
+	// If the wallet was just restored from an existing seed, then scan
+	// the chain for its funds in the background.
+	if b.recoveryWindow > 0 {
+		b.recoveryMtx.Lock()
+		b.recovery.inRecovery = true
+		b.recoveryMtx.Unlock()
+
+		go b.recoverFunds()
+	}
+
 	return nil
 }
 
@@ -211,6 +290,27 @@ func (b *BtcWallet) NewAddress(t lnwallet.AddressType, change bool) (btcutil.Add
 	}
 }
 
+// ListAddresses returns every address derived within the wallet's default
+// account, both external and change addresses.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListAddresses() ([]*lnwallet.WalletAddress, error) {
+	var addrs []*lnwallet.WalletAddress
+	err := b.wallet.Manager.ForEachAccountAddress(defaultAccount,
+		func(maddr waddrmgr.ManagedAddress) error {
+			addrs = append(addrs, &lnwallet.WalletAddress{
+				Address: maddr.Address(),
+				Change:  maddr.Internal(),
+			})
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return addrs, nil
+}
+
 // GetPrivKey retrives the underlying private key associated with the passed
 // address. If the we're unable to locate the proper private key, then a
 // non-nil error will be returned.
@@ -365,6 +465,8 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 					Hash:  *txid,
 					Index: output.Vout,
 				},
+				PkScript:      pkScript,
+				Confirmations: output.Confirmations,
 			}
 			colorData, err := lndcc.GetTxoData(utxo.OutPoint)
 			if err != nil {
@@ -385,3 +487,312 @@ func (b *BtcWallet) ListUnspentWitness(minConfs int32) ([]*lnwallet.Utxo, error)
 func (b *BtcWallet) PublishTransaction(tx *wire.MsgTx) error {
 	return b.wallet.PublishTransaction(tx)
 }
+
+// fetchTxLabel returns the label stored for the target transaction. If the
+// transaction hasn't been labeled, then an empty string is returned.
+func (b *BtcWallet) fetchTxLabel(txid *wire.ShaHash) (string, error) {
+	var label string
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		labels := tx.RootBucket().Bucket(txLabelBucket)
+		if labels == nil {
+			return nil
+		}
+
+		label = string(labels.Get(txid[:]))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return label, nil
+}
+
+// LabelTransaction attaches the passed label to the target transaction. If
+// the transaction is already labelled, then lnwallet.ErrTxLabelExists is
+// returned unless overwrite is true.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) LabelTransaction(txid wire.ShaHash, label string,
+	overwrite bool) error {
+
+	if err := lnwallet.ValidateTxLabel(label); err != nil {
+		return err
+	}
+
+	return b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		labels, err := tx.RootBucket().CreateBucketIfNotExists(
+			txLabelBucket)
+		if err != nil {
+			return err
+		}
+
+		if labels.Get(txid[:]) != nil && !overwrite {
+			return lnwallet.ErrTxLabelExists
+		}
+
+		return labels.Put(txid[:], []byte(label))
+	})
+}
+
+// extractBalanceDelta extracts the net balance delta from the PoV of the
+// wallet given a TransactionSummary. If the transaction neither credits nor
+// debits the wallet itself, then the change in the watch-only balance is
+// returned instead, along with a true boolean.
+func (b *BtcWallet) extractBalanceDelta(
+	txSummary base.TransactionSummary) (btcutil.Amount, bool, error) {
+
+	tx := wire.NewMsgTx()
+	if err := tx.Deserialize(bytes.NewReader(txSummary.Transaction)); err != nil {
+		return 0, false, err
+	}
+
+	if len(txSummary.MyInputs) == 0 && len(txSummary.MyOutputs) == 0 {
+		delta, watchOnly, err := b.watchOnlyDelta(tx)
+		if err != nil {
+			return 0, false, err
+		}
+		if watchOnly {
+			return delta, true, nil
+		}
+	}
+
+	// For each input we debit the wallet's outflow for this transaction,
+	// and for each output we credit the wallet's inflow for this
+	// transaction.
+	var balanceDelta btcutil.Amount
+	for _, input := range txSummary.MyInputs {
+		balanceDelta -= input.PreviousAmount
+	}
+	for _, output := range txSummary.MyOutputs {
+		if int(output.Index) >= len(tx.TxOut) {
+			return 0, false, fmt.Errorf("output index %v out of "+
+				"range for tx %v", output.Index, txSummary.Hash)
+		}
+		balanceDelta += btcutil.Amount(tx.TxOut[output.Index].Value)
+	}
+
+	return balanceDelta, false, nil
+}
+
+// minedTransactionsToDetails is a helper function which converts a summary
+// information about mined transactions to a TransactionDetail.
+func (b *BtcWallet) minedTransactionsToDetails(currentHeight int32,
+	block base.Block) ([]*lnwallet.TransactionDetail, error) {
+
+	details := make([]*lnwallet.TransactionDetail, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		balanceDelta, watchOnly, err := b.extractBalanceDelta(tx)
+		if err != nil {
+			return nil, err
+		}
+		label, err := b.fetchTxLabel(tx.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		details = append(details, &lnwallet.TransactionDetail{
+			Hash:             *tx.Hash,
+			Value:            balanceDelta,
+			NumConfirmations: currentHeight - block.Height + 1,
+			BlockHash:        block.Hash,
+			BlockHeight:      block.Height,
+			Timestamp:        block.Timestamp,
+			TotalFees:        int64(tx.Fee),
+			Label:            label,
+			WatchOnly:        watchOnly,
+		})
+	}
+
+	return details, nil
+}
+
+// unminedTransactionsToDetail is a helper function which converts a summary
+// for an unconfirmed transaction to a transaction detail.
+func (b *BtcWallet) unminedTransactionsToDetail(
+	summary base.TransactionSummary) (*lnwallet.TransactionDetail, error) {
+
+	balanceDelta, watchOnly, err := b.extractBalanceDelta(summary)
+	if err != nil {
+		return nil, err
+	}
+	label, err := b.fetchTxLabel(summary.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnwallet.TransactionDetail{
+		Hash:      *summary.Hash,
+		Value:     balanceDelta,
+		Timestamp: summary.Timestamp,
+		TotalFees: int64(summary.Fee),
+		Label:     label,
+		WatchOnly: watchOnly,
+	}, nil
+}
+
+// ListTransactionDetails returns a list of all transactions which are
+// relevant to the wallet, including unconfirmed transactions currently within
+// the mempool.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListTransactionDetails() ([]*lnwallet.TransactionDetail, error) {
+	// Grab the best block the wallet knows of, we'll use this to calculate
+	// # of confirmations shortly below.
+	currentHeight := b.wallet.Manager.SyncedTo().Height
+
+	// We'll attempt to find all transactions from the genesis block up
+	// through the current mempool, a stop height of -1 includes all
+	// unmined transactions as well.
+	start := base.NewBlockIdentifierFromHeight(0)
+	stop := base.NewBlockIdentifierFromHeight(-1)
+	txns, err := b.wallet.GetTransactions(start, stop, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	txDetails := make([]*lnwallet.TransactionDetail, 0,
+		len(txns.MinedTransactions)+len(txns.UnminedTransactions))
+
+	// For both confirmed and unconfirmed transactions, create a
+	// TransactionDetail which re-packages the data returned by the base
+	// wallet.
+	for _, blockPackage := range txns.MinedTransactions {
+		details, err := b.minedTransactionsToDetails(currentHeight,
+			blockPackage)
+		if err != nil {
+			return nil, err
+		}
+
+		txDetails = append(txDetails, details...)
+	}
+	for _, tx := range txns.UnminedTransactions {
+		detail, err := b.unminedTransactionsToDetail(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		txDetails = append(txDetails, detail)
+	}
+
+	return txDetails, nil
+}
+
+// txSubscriptionClient encapsulates the transaction notification client from
+// the base wallet. Notifications received from the client will be proxied over
+// two distinct channels.
+type txSubscriptionClient struct {
+	txClient base.TransactionNotificationsClient
+
+	confirmed   chan *lnwallet.TransactionDetail
+	unconfirmed chan *lnwallet.TransactionDetail
+
+	w *BtcWallet
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// ConfirmedTransactions returns a channel which will be sent on as new
+// relevant transactions are confirmed.
+//
+// This is part of the TransactionSubscription interface.
+func (t *txSubscriptionClient) ConfirmedTransactions() chan *lnwallet.TransactionDetail {
+	return t.confirmed
+}
+
+// UnconfirmedTransactions returns a channel which will be sent on as
+// new relevant transactions are seen within the network.
+//
+// This is part of the TransactionSubscription interface.
+func (t *txSubscriptionClient) UnconfirmedTransactions() chan *lnwallet.TransactionDetail {
+	return t.unconfirmed
+}
+
+// Cancel finalizes the subscription, cleaning up any resources allocated.
+//
+// This is part of the TransactionSubscription interface.
+func (t *txSubscriptionClient) Cancel() {
+	close(t.quit)
+	t.wg.Wait()
+
+	t.txClient.Done()
+}
+
+// notificationProxier proxies the notifications received by the underlying
+// wallet's notification client to a higher-level TransactionSubscription
+// client.
+//
+// NOTE: This MUST be run as a goroutine.
+func (t *txSubscriptionClient) notificationProxier() {
+	defer t.wg.Done()
+
+out:
+	for {
+		select {
+		case txNtfn, ok := <-t.txClient.C:
+			if !ok {
+				break out
+			}
+
+			// TODO(roasbeef): handle detached blocks
+			currentHeight := t.w.wallet.Manager.SyncedTo().Height
+
+			// Re-package and send notifications for any newly
+			// confirmed transactions.
+			for _, block := range txNtfn.AttachedBlocks {
+				details, err := t.w.minedTransactionsToDetails(
+					currentHeight, block)
+				if err != nil {
+					continue
+				}
+
+				for _, d := range details {
+					select {
+					case t.confirmed <- d:
+					case <-t.quit:
+						break out
+					}
+				}
+			}
+
+			// Next, we'll notify the client of any new transactions
+			// which have been seen within the mempool.
+			for _, tx := range txNtfn.UnminedTransactions {
+				detail, err := t.w.unminedTransactionsToDetail(tx)
+				if err != nil {
+					continue
+				}
+
+				select {
+				case t.unconfirmed <- detail:
+				case <-t.quit:
+					break out
+				}
+			}
+		case <-t.quit:
+			break out
+		}
+	}
+}
+
+// SubscribeTransactions returns a TransactionSubscription client which is
+// capable of receiving async notifications as new transactions related to the
+// wallet are seen within the network, or found in blocks.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) SubscribeTransactions() (lnwallet.TransactionSubscription, error) {
+	walletClient := b.wallet.NtfnServer.TransactionNotifications()
+
+	txClient := &txSubscriptionClient{
+		txClient:    walletClient,
+		confirmed:   make(chan *lnwallet.TransactionDetail, 1),
+		unconfirmed: make(chan *lnwallet.TransactionDetail, 1),
+		w:           b,
+		quit:        make(chan struct{}),
+	}
+	txClient.wg.Add(1)
+	go txClient.notificationProxier()
+
+	return txClient, nil
+}
@@ -73,7 +73,26 @@ type Config struct {
 	PublicPass  []byte
 	HdSeed      []byte
 
+	// RecoveryWindow is the number of addresses to derive ahead on both
+	// the external and internal branches when a new wallet is restored
+	// from an existing HdSeed. Once the wallet has started, the chain is
+	// scanned for funds paying to these addresses. A value of zero
+	// disables recovery.
+	RecoveryWindow uint32
+
 	NetParams *chaincfg.Params
+
+	// WatchOnly denotes that the wallet should hold no private keys, as
+	// all signing is carried out by a remote signer. A watch-only wallet
+	// must be initialized from a copy of the remote signer's wallet, so
+	// both derive identical public keys.
+	WatchOnly bool
+
+	// KeyLookahead is the number of keys to derive ahead when asked to
+	// sign for a key unknown to the wallet. A remote signer should set
+	// this, as the watch-only node it serves derives new keys from its
+	// own copy of the wallet. A value of zero disables the lookahead.
+	KeyLookahead uint32
 }
 
 // networkDir returns the directory name of a network directory to hold wallet
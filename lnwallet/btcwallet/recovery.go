@@ -0,0 +1,85 @@
+package btcwallet
+
+import (
+	"github.com/roasbeef/btcwallet/waddrmgr"
+)
+
+// recoveryState describes the progress of the scan for the funds of a wallet
+// restored from an existing seed.
+type recoveryState struct {
+	// inRecovery is true if the wallet was restored from an existing seed
+	// during this run.
+	inRecovery bool
+
+	// finished is true once the chain has been scanned up to its tip.
+	finished bool
+
+	// progress is the fraction of the chain scanned so far.
+	progress float64
+
+	// err is the error which halted the recovery, if any.
+	err error
+}
+
+// recoverFunds derives recoveryWindow addresses ahead on both the external
+// and internal branches of the default account, then scans the entire chain
+// for transactions paying to, or spending from the wallet. Any funds found are
+// recorded by the wallet as the scan progresses.
+//
+// TODO(roasbeef): addresses are only derived as p2wkh, so funds paying to
+// nested p2sh addresses beyond the window aren't recovered
+//
+// NOTE: This MUST be run as a goroutine.
+func (b *BtcWallet) recoverFunds() {
+	err := b.deriveRecoveryWindow()
+	if err == nil {
+		var bestHeight int32
+		_, bestHeight, err = b.rpc.GetBestBlock()
+		if err == nil {
+			err = b.Rescan(0, func(height int32) {
+				b.recoveryMtx.Lock()
+				b.recovery.progress = float64(height+1) /
+					float64(bestHeight+1)
+				b.recoveryMtx.Unlock()
+			})
+		}
+	}
+
+	b.recoveryMtx.Lock()
+	defer b.recoveryMtx.Unlock()
+
+	if err != nil {
+		b.recovery.err = err
+		return
+	}
+	b.recovery.finished = true
+	b.recovery.progress = 1
+}
+
+// deriveRecoveryWindow derives the next recoveryWindow addresses on both the
+// external and internal branches of the default account, so the subsequent
+// rescan detects any funds paying to them.
+func (b *BtcWallet) deriveRecoveryWindow() error {
+	_, err := b.wallet.Manager.NextExternalAddresses(defaultAccount,
+		b.recoveryWindow, waddrmgr.WitnessPubKey)
+	if err != nil {
+		return err
+	}
+	_, err = b.wallet.Manager.NextInternalAddresses(defaultAccount,
+		b.recoveryWindow, waddrmgr.WitnessPubKey)
+	return err
+}
+
+// RecoveryProgress reports whether the wallet was restored from an existing
+// seed during this run, whether the scan for its funds has finished, and the
+// fraction of the chain scanned so far. If the scan failed, then the error
+// which halted it is returned.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) RecoveryProgress() (bool, bool, float64, error) {
+	b.recoveryMtx.Lock()
+	defer b.recoveryMtx.Unlock()
+
+	return b.recovery.inRecovery, b.recovery.finished,
+		b.recovery.progress, b.recovery.err
+}
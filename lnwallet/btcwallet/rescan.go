@@ -0,0 +1,97 @@
+package btcwallet
+
+import (
+	"fmt"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// rescanBatchSize is the number of blocks scanned by each rescan request sent
+// to the chain backend. Progress is reported once per batch.
+const rescanBatchSize = 1000
+
+// Rescan scans the chain from startHeight up to the current tip for
+// transactions relevant to the wallet, including those paying to, or
+// spending from watch-only addresses. Any transactions found are recorded by
+// the wallet as they're delivered by the chain backend. After each batch of
+// blocks has been scanned, progress is called with the height scanned up to.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) Rescan(startHeight int32, progress func(int32)) error {
+	_, bestHeight, err := b.rpc.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	if startHeight < 0 || startHeight > bestHeight {
+		return fmt.Errorf("start height %v is outside of the main "+
+			"chain, best height is %v", startHeight, bestHeight)
+	}
+
+	for height := startHeight; height <= bestHeight; height += rescanBatchSize {
+		endHeight := height + rescanBatchSize - 1
+		if endHeight > bestHeight {
+			endHeight = bestHeight
+		}
+
+		// The set of addresses and outputs to watch for is gathered
+		// anew for each batch, so outputs found within one batch are
+		// detected as spent within later batches.
+		addrs, outPoints, err := b.rescanTargets()
+		if err != nil {
+			return err
+		}
+
+		startHash, err := b.rpc.GetBlockHash(int64(height))
+		if err != nil {
+			return err
+		}
+		err = b.rpc.RescanEndHeight(startHash, addrs, outPoints,
+			int64(endHeight))
+		if err != nil {
+			return err
+		}
+
+		progress(endHeight)
+	}
+
+	return nil
+}
+
+// rescanTargets returns all addresses controlled by the wallet, including
+// watch-only addresses, along with all unspent outputs known to the wallet.
+func (b *BtcWallet) rescanTargets() ([]btcutil.Address, []*wire.OutPoint, error) {
+	addrs, err := b.wallet.Manager.AllActiveAddresses()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b.watchMtx.Lock()
+	watchOnly, err := b.loadWatchOnly()
+	b.watchMtx.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	addrs = append(addrs, watchOnly...)
+
+	credits, err := b.wallet.TxStore.UnspentOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+	outPoints := make([]*wire.OutPoint, 0, len(credits))
+	for _, credit := range credits {
+		op := credit.OutPoint
+		outPoints = append(outPoints, &op)
+	}
+
+	watchOnlyCredits, err := b.unspentWatchOnly()
+	if err != nil {
+		return nil, nil, err
+	}
+	for op := range watchOnlyCredits {
+		op := op
+		outPoints = append(outPoints, &op)
+	}
+
+	return addrs, outPoints, nil
+}
@@ -49,6 +49,24 @@ func (b *BtcWallet) FetchInputInfo(prevOut *wire.OutPoint) (*wire.TxOut, error)
 	return output, nil
 }
 
+// deriveLookahead derives the next keyLookahead keys on both the external
+// and internal branches of the default account. This allows a remote signer
+// to sign for keys derived by a watch-only node from its copy of the wallet
+// which are ahead of the signer's own.
+//
+// TODO(roasbeef): keys are only derived as p2wkh addresses, so outputs
+// nested within p2sh still require the signer to derive them itself
+func (b *BtcWallet) deriveLookahead() error {
+	_, err := b.wallet.Manager.NextExternalAddresses(defaultAccount,
+		b.keyLookahead, waddrmgr.WitnessPubKey)
+	if err != nil {
+		return err
+	}
+	_, err = b.wallet.Manager.NextInternalAddresses(defaultAccount,
+		b.keyLookahead, waddrmgr.WitnessPubKey)
+	return err
+}
+
 // fetchOutputKey attempts to fetch the managed address corresponding to the
 // passed output script. This function is used to look up the proper key which
 // should be used to sign a specified input.
@@ -58,12 +76,30 @@ func (b *BtcWallet) fetchOutputAddr(script []byte) (waddrmgr.ManagedAddress, err
 		return nil, err
 	}
 
-	// If the case of a multi-sig output, several address may be extracted.
-	// Therefore, we simply select the key for the first address we know
-	// of.
-	for _, addr := range addrs {
-		wAddr, err := b.wallet.Manager.Address(addr)
-		if err == nil {
+	lookup := func() waddrmgr.ManagedAddress {
+		// If the case of a multi-sig output, several address may be
+		// extracted. Therefore, we simply select the key for the first
+		// address we know of.
+		for _, addr := range addrs {
+			wAddr, err := b.wallet.Manager.Address(addr)
+			if err == nil {
+				return wAddr
+			}
+		}
+
+		return nil
+	}
+	if wAddr := lookup(); wAddr != nil {
+		return wAddr, nil
+	}
+
+	// The key may have been derived by a watch-only node ahead of us, so
+	// we'll derive further keys before trying once more.
+	if b.keyLookahead > 0 {
+		if err := b.deriveLookahead(); err != nil {
+			return nil, err
+		}
+		if wAddr := lookup(); wAddr != nil {
 			return wAddr, nil
 		}
 	}
@@ -84,6 +120,15 @@ func (b *BtcWallet) fetchPrivKey(pub *btcec.PublicKey) (*btcec.PrivateKey, error
 	}
 
 	walletddr, err := b.wallet.Manager.Address(addr)
+	if err != nil && b.keyLookahead > 0 {
+		// The key may have been derived by a watch-only node ahead of
+		// us, so we'll derive further keys before trying once more.
+		if err := b.deriveLookahead(); err != nil {
+			return nil, err
+		}
+
+		walletddr, err = b.wallet.Manager.Address(addr)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,361 @@
+package btcwallet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lndcc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	base "github.com/roasbeef/btcwallet/wallet"
+	"github.com/roasbeef/btcwallet/walletdb"
+)
+
+var (
+	// watchOnlyBucket is a sub-bucket within the lnNamespace which stores
+	// the set of addresses imported as watch-only. The bucket maps the
+	// pkScript of each address to its encoded form.
+	watchOnlyBucket = []byte("watch-only")
+)
+
+// loadWatchOnly populates the in-memory set of watch-only scripts from the
+// database, returning the watched addresses.
+func (b *BtcWallet) loadWatchOnly() ([]btcutil.Address, error) {
+	var addrs []btcutil.Address
+	err := b.lnNamespace.View(func(tx walletdb.Tx) error {
+		watched := tx.RootBucket().Bucket(watchOnlyBucket)
+		if watched == nil {
+			return nil
+		}
+
+		return watched.ForEach(func(pkScript, encodedAddr []byte) error {
+			addr, err := btcutil.DecodeAddress(string(encodedAddr),
+				b.netParams)
+			if err != nil {
+				return err
+			}
+
+			b.watchOnlyScripts[string(pkScript)] = struct{}{}
+			addrs = append(addrs, addr)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addrs, nil
+}
+
+// watchImported registers all imported watch-only addresses, along with their
+// unspent outputs, with the chain backend so the wallet is notified of any
+// transactions which credit or debit them.
+func (b *BtcWallet) watchImported() error {
+	b.watchMtx.Lock()
+	addrs, err := b.loadWatchOnly()
+	b.watchMtx.Unlock()
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	if err := b.rpc.NotifyReceived(addrs); err != nil {
+		return err
+	}
+
+	credits, err := b.unspentWatchOnly()
+	if err != nil {
+		return err
+	}
+	outPoints := make([]*wire.OutPoint, 0, len(credits))
+	for op := range credits {
+		op := op
+		outPoints = append(outPoints, &op)
+	}
+
+	return b.rpc.NotifySpent(outPoints)
+}
+
+// isWatchOnly returns true if the passed pkScript pays to an address which
+// has been imported as watch-only.
+func (b *BtcWallet) isWatchOnly(pkScript []byte) bool {
+	b.watchMtx.RLock()
+	defer b.watchMtx.RUnlock()
+
+	_, ok := b.watchOnlyScripts[string(pkScript)]
+	return ok
+}
+
+// ImportAddress imports the passed address into the wallet as watch-only.
+// Transactions paying to, or spending from the address will be tracked from
+// this point forward. Prior transactions are found by a subsequent Rescan.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ImportAddress(addr btcutil.Address) error {
+	if !addr.IsForNet(b.netParams) {
+		return fmt.Errorf("address %v is not for the active network",
+			addr)
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+
+	b.watchMtx.Lock()
+	defer b.watchMtx.Unlock()
+
+	if _, ok := b.watchOnlyScripts[string(pkScript)]; ok {
+		return nil
+	}
+
+	err = b.lnNamespace.Update(func(tx walletdb.Tx) error {
+		watched, err := tx.RootBucket().CreateBucketIfNotExists(
+			watchOnlyBucket)
+		if err != nil {
+			return err
+		}
+
+		return watched.Put(pkScript, []byte(addr.EncodeAddress()))
+	})
+	if err != nil {
+		return err
+	}
+	b.watchOnlyScripts[string(pkScript)] = struct{}{}
+
+	// Instruct the chain backend to notify us of any transactions paying
+	// to the address, these will then be recorded by the wallet.
+	return b.rpc.NotifyReceived([]btcutil.Address{addr})
+}
+
+// ImportPublicKey imports the passed public key into the wallet as
+// watch-only, tracking the address of the target type which corresponds to
+// the key.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ImportPublicKey(pubKey *btcec.PublicKey,
+	addrType lnwallet.AddressType) (btcutil.Address, error) {
+
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+
+	var (
+		addr btcutil.Address
+		err  error
+	)
+	switch addrType {
+	case lnwallet.WitnessPubKey:
+		addr, err = btcutil.NewAddressWitnessPubKeyHash(pubKeyHash,
+			b.netParams)
+	case lnwallet.NestedWitnessPubKey:
+		var witnessAddr btcutil.Address
+		witnessAddr, err = btcutil.NewAddressWitnessPubKeyHash(
+			pubKeyHash, b.netParams)
+		if err != nil {
+			return nil, err
+		}
+
+		var witnessProgram []byte
+		witnessProgram, err = txscript.PayToAddrScript(witnessAddr)
+		if err != nil {
+			return nil, err
+		}
+		addr, err = btcutil.NewAddressScriptHash(witnessProgram,
+			b.netParams)
+	case lnwallet.PubKeyHash:
+		addr, err = btcutil.NewAddressPubKeyHash(pubKeyHash,
+			b.netParams)
+	default:
+		return nil, fmt.Errorf("unknown address type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.ImportAddress(addr); err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// watchOnlyDelta returns the net change in the watch-only balance caused by
+// the passed transaction, along with whether the transaction touches any
+// watch-only addresses at all.
+func (b *BtcWallet) watchOnlyDelta(tx *wire.MsgTx) (btcutil.Amount, bool, error) {
+	var (
+		delta   btcutil.Amount
+		touched bool
+	)
+	for _, txOut := range tx.TxOut {
+		if b.isWatchOnly(txOut.PkScript) {
+			delta += btcutil.Amount(txOut.Value)
+			touched = true
+		}
+	}
+
+	// To determine if the transaction spends any watch-only outputs,
+	// we'll need to look up the transaction which created each output
+	// being spent, which will be known to the wallet if it was relevant.
+	for _, txIn := range tx.TxIn {
+		prevOut := txIn.PreviousOutPoint
+		prevTx, err := b.wallet.TxStore.TxDetails(&prevOut.Hash)
+		if err != nil {
+			return 0, false, err
+		}
+		if prevTx == nil || int(prevOut.Index) >= len(prevTx.MsgTx.TxOut) {
+			continue
+		}
+
+		output := prevTx.MsgTx.TxOut[prevOut.Index]
+		if b.isWatchOnly(output.PkScript) {
+			delta -= btcutil.Amount(output.Value)
+			touched = true
+		}
+	}
+
+	return delta, touched, nil
+}
+
+// watchOnlyCredit is an output paying to a watch-only address, along with the
+// height of the block which includes it. Unconfirmed credits have a height of
+// -1.
+type watchOnlyCredit struct {
+	value  btcutil.Amount
+	height int32
+}
+
+// unspentWatchOnly returns all unspent outputs known to the wallet which pay
+// to watch-only addresses.
+func (b *BtcWallet) unspentWatchOnly() (map[wire.OutPoint]watchOnlyCredit, error) {
+	start := base.NewBlockIdentifierFromHeight(0)
+	stop := base.NewBlockIdentifierFromHeight(-1)
+	txns, err := b.wallet.GetTransactions(start, stop, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// We'll make a pass over every transaction known to the wallet,
+	// collecting all outputs paying to watch-only addresses, along with
+	// the set of all outputs which have been spent.
+	credits := make(map[wire.OutPoint]watchOnlyCredit)
+	spent := make(map[wire.OutPoint]struct{})
+	processTx := func(summary base.TransactionSummary, height int32) error {
+		tx := wire.NewMsgTx()
+		err := tx.Deserialize(bytes.NewReader(summary.Transaction))
+		if err != nil {
+			return err
+		}
+
+		for _, txIn := range tx.TxIn {
+			spent[txIn.PreviousOutPoint] = struct{}{}
+		}
+		for i, txOut := range tx.TxOut {
+			if !b.isWatchOnly(txOut.PkScript) {
+				continue
+			}
+
+			op := wire.OutPoint{Hash: *summary.Hash, Index: uint32(i)}
+			credits[op] = watchOnlyCredit{
+				value:  btcutil.Amount(txOut.Value),
+				height: height,
+			}
+		}
+
+		return nil
+	}
+	for _, block := range txns.MinedTransactions {
+		for _, summary := range block.Transactions {
+			if err := processTx(summary, block.Height); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, summary := range txns.UnminedTransactions {
+		if err := processTx(summary, -1); err != nil {
+			return nil, err
+		}
+	}
+
+	for op := range spent {
+		delete(credits, op)
+	}
+
+	return credits, nil
+}
+
+// WatchOnlyBalance returns the sum of all unspent outputs paying to
+// watch-only addresses that have at least confs confirmations.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) WatchOnlyBalance(confs int32) (btcutil.Amount, error) {
+	currentHeight := b.wallet.Manager.SyncedTo().Height
+
+	credits, err := b.unspentWatchOnly()
+	if err != nil {
+		return 0, err
+	}
+
+	var balance btcutil.Amount
+	for _, credit := range credits {
+		if confs > 0 {
+			if credit.height == -1 {
+				continue
+			}
+			if currentHeight-credit.height+1 < confs {
+				continue
+			}
+		}
+
+		balance += credit.value
+	}
+
+	return balance, nil
+}
+
+// ListUnspentWatchOnly returns all unspent outputs paying to watch-only
+// addresses that have at least confirms confirmations.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) ListUnspentWatchOnly(confirms int32) ([]*lnwallet.Utxo, error) {
+	currentHeight := b.wallet.Manager.SyncedTo().Height
+
+	credits, err := b.unspentWatchOnly()
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*lnwallet.Utxo, 0, len(credits))
+	for op, credit := range credits {
+		var numConfs int32
+		if credit.height != -1 {
+			numConfs = currentHeight - credit.height + 1
+		}
+		if numConfs < confirms {
+			continue
+		}
+
+		txOut, err := b.FetchInputInfo(&op)
+		if err != nil {
+			return nil, err
+		}
+		colorData, err := lndcc.GetTxoData(op)
+		if err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, &lnwallet.Utxo{
+			Value:         credit.value,
+			ColorData:     colorData,
+			OutPoint:      op,
+			PkScript:      txOut.PkScript,
+			Confirmations: int64(numConfs),
+		})
+	}
+
+	return utxos, nil
+}
@@ -27,12 +27,30 @@ var (
 	ErrChanClosing = fmt.Errorf("channel is being closed, operation disallowed")
 	ErrNoWindow    = fmt.Errorf("unable to sign new commitment, the current" +
 		" revocation window is exhausted")
+
+	// ErrMaxHTLCNumber is returned when adding an HTLC would exceed the
+	// maximum number of HTLC's permitted in flight within the channel.
+	ErrMaxHTLCNumber = fmt.Errorf("commitment transaction exceeds max " +
+		"number of htlcs in flight")
+
+	// ErrMaxPendingAmount is returned when adding an HTLC would exceed the
+	// maximum total value of HTLC's permitted in flight within the
+	// channel.
+	ErrMaxPendingAmount = fmt.Errorf("commitment transaction exceeds max " +
+		"value of htlcs in flight")
+
+	// ErrUpfrontShutdown is returned when a cooperative close attempts to
+	// replace a delivery script which was committed to as an upfront
+	// shutdown script during the funding workflow.
+	ErrUpfrontShutdown = fmt.Errorf("delivery script doesn't match the " +
+		"upfront shutdown script committed to during funding")
 )
 
 const (
-	// MaxPendingPayments is the max number of pending HTLC's permitted on
-	// a channel.
-	// TODO(roasbeef): make not random value + enforce
+	// MaxPendingPayments is the default max number of pending HTLC's
+	// permitted in either direction on a channel which doesn't specify a
+	// limit of its own.
+	// TODO(roasbeef): make not random value
 	//  * should be tuned to account for max tx "cost"
 	MaxPendingPayments = 100
 
@@ -315,6 +333,10 @@ type LightningChannel struct {
 	signer   Signer
 	signDesc *SignDescriptor
 
+	// sigPool, if non-nil, is the pool of workers shared by all channels
+	// which generates and validates our commitment signatures.
+	sigPool *SigPool
+
 	bio BlockChainIO
 
 	channelEvents chainntnfs.ChainNotifier
@@ -409,14 +431,16 @@ type LightningChannel struct {
 // implementation of the chain notifier, channel database, and the current
 // settled channel state. Throughout state transitions, then channel will
 // automatically persist pertinent state to the database in an efficient
-// manner.
-func NewLightningChannel(signer Signer, bio BlockChainIO,
+// manner. If the passed SigPool is nil, then commitment signatures are
+// generated and validated by the caller's goroutine.
+func NewLightningChannel(signer Signer, sigPool *SigPool, bio BlockChainIO,
 	events chainntnfs.ChainNotifier,
 	state *channeldb.OpenChannel) (*LightningChannel, error) {
 
 	// TODO(roasbeef): remove events+wallet
 	lc := &LightningChannel{
 		signer:                signer,
+		sigPool:               sigPool,
 		bio:                   bio,
 		channelEvents:         events,
 		currentHeight:         state.NumUpdates,
@@ -635,16 +659,28 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	filteredHTLCView := lc.evaluateHTLCView(htlcView, &ourBalance, &theirBalance,
 		nextHeight, remoteChain)
 
+	// Ensure that neither party has exceeded the limits on the HTLC's in
+	// flight within this new commitment.
+	if err := lc.validateHTLCLimits(filteredHTLCView.ourUpdates); err != nil {
+		return nil, err
+	}
+	if err := lc.validateHTLCLimits(filteredHTLCView.theirUpdates); err != nil {
+		return nil, err
+	}
+
 	var selfKey *btcec.PublicKey
 	var remoteKey *btcec.PublicKey
 	var delay uint32
 	var delayBalance, p2wkhBalance btcutil.Amount
+	ourLease, theirLease := leaseExpiries(lc.channelState)
+	selfLease, remoteLease := ourLease, theirLease
 	if remoteChain {
 		selfKey = lc.channelState.TheirCommitKey
 		remoteKey = lc.channelState.OurCommitKey
 		delay = lc.channelState.RemoteCsvDelay
 		delayBalance = theirBalance
 		p2wkhBalance = ourBalance
+		selfLease, remoteLease = theirLease, ourLease
 	} else {
 		selfKey = lc.channelState.OurCommitKey
 		remoteKey = lc.channelState.TheirCommitKey
@@ -657,7 +693,8 @@ func (lc *LightningChannel) fetchCommitmentView(remoteChain bool,
 	// unsettled/un-timed out HTLC's.
 	ourCommitTx := !remoteChain
 	commitTx, err := CreateCommitTx(lc.fundingTxIn, selfKey, remoteKey,
-		revocationKey, delay, delayBalance, p2wkhBalance)
+		revocationKey, delay, selfLease, remoteLease, delayBalance,
+		p2wkhBalance)
 	if err != nil {
 		return nil, err
 	}
@@ -891,7 +928,7 @@ func (lc *LightningChannel) SignNextCommitment() ([]byte, uint32, error) {
 
 	// Sign their version of the new commitment transaction.
 	lc.signDesc.SigHashes = txscript.NewTxSigHashes(newCommitView.txn)
-	sig, err := lc.signer.SignOutputRaw(newCommitView.txn, lc.signDesc)
+	sig, err := lc.signCommitment(newCommitView.txn)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -971,11 +1008,8 @@ func (lc *LightningChannel) ReceiveNewCommitment(rawSig []byte,
 
 	// Ensure that the newly constructed commitment state has a valid
 	// signature.
-	sig, err := btcec.ParseSignature(rawSig, btcec.S256())
-	if err != nil {
+	if err := lc.verifyCommitSig(rawSig, sigHash, theirMultiSigKey); err != nil {
 		return err
-	} else if !sig.Verify(sigHash, theirMultiSigKey) {
-		return fmt.Errorf("invalid commitment signature")
 	}
 
 	// The signature checks out, so we can now add the new commitment to
@@ -986,6 +1020,29 @@ func (lc *LightningChannel) ReceiveNewCommitment(rawSig []byte,
 	return nil
 }
 
+// signCommitment generates our signature for the passed commitment
+// transaction, handing the work off to the sig pool if we have one.
+func (lc *LightningChannel) signCommitment(commitTx *wire.MsgTx) ([]byte, error) {
+	if lc.sigPool == nil {
+		return lc.signer.SignOutputRaw(commitTx, lc.signDesc)
+	}
+
+	return lc.sigPool.SignOutputRaw(commitTx, lc.signDesc)
+}
+
+// verifyCommitSig ensures the passed raw signature is a valid signature of the
+// commitment sighash under pubKey, handing the work off to the sig pool if we
+// have one.
+func (lc *LightningChannel) verifyCommitSig(rawSig, sigHash []byte,
+	pubKey *btcec.PublicKey) error {
+
+	if lc.sigPool == nil {
+		return verifySig(rawSig, sigHash, pubKey)
+	}
+
+	return lc.sigPool.VerifySig(rawSig, sigHash, pubKey)
+}
+
 // PendingUpdates returns a boolean value reflecting if there are any pending
 // updates which need to be committed. The state machine has pending updates if
 // the local log index on the local and remote chain tip aren't identical. This
@@ -1246,10 +1303,12 @@ func (lc *LightningChannel) ExtendRevocationWindow() (*lnwire.CommitRevocation,
 }
 
 // AddHTLC adds an HTLC to the state machine's local update log. This method
-// should be called when preparing to send an outgoing HTLC.
+// should be called when preparing to send an outgoing HTLC. If the HTLC would
+// exceed the channel's limits on HTLC's in flight, then it isn't added and
+// either ErrMaxHTLCNumber or ErrMaxPendingAmount is returned.
 // TODO(roasbeef): check for duplicates below? edge case during restart w/ HTLC
 // persistence
-func (lc *LightningChannel) AddHTLC(htlc *lnwire.HTLCAddRequest) uint32 {
+func (lc *LightningChannel) AddHTLC(htlc *lnwire.HTLCAddRequest) (uint32, error) {
 	pd := &PaymentDescriptor{
 		EntryType: Add,
 		RHash:     PaymentHash(htlc.RedemptionHashes[0]),
@@ -1258,16 +1317,23 @@ func (lc *LightningChannel) AddHTLC(htlc *lnwire.HTLCAddRequest) uint32 {
 		Index:     lc.ourLogCounter,
 	}
 
+	active := activeHTLCs(lc.ourUpdateLog, lc.theirUpdateLog)
+	if err := lc.validateHTLCLimits(append(active, pd)); err != nil {
+		return 0, err
+	}
+
 	lc.ourLogIndex[pd.Index] = lc.ourUpdateLog.PushBack(pd)
 	lc.ourLogCounter++
 
-	return pd.Index
+	return pd.Index, nil
 }
 
 // ReceiveHTLC adds an HTLC to the state machine's remote update log. This
 // method should be called in response to receiving a new HTLC from the remote
-// party.
-func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.HTLCAddRequest) uint32 {
+// party. If the HTLC would exceed the channel's limits on HTLC's in flight,
+// then it isn't added and either ErrMaxHTLCNumber or ErrMaxPendingAmount is
+// returned.
+func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.HTLCAddRequest) (uint32, error) {
 	pd := &PaymentDescriptor{
 		EntryType: Add,
 		RHash:     PaymentHash(htlc.RedemptionHashes[0]),
@@ -1276,10 +1342,70 @@ func (lc *LightningChannel) ReceiveHTLC(htlc *lnwire.HTLCAddRequest) uint32 {
 		Index:     lc.theirLogCounter,
 	}
 
+	active := activeHTLCs(lc.theirUpdateLog, lc.ourUpdateLog)
+	if err := lc.validateHTLCLimits(append(active, pd)); err != nil {
+		return 0, err
+	}
+
 	lc.theirLogIndex[pd.Index] = lc.theirUpdateLog.PushBack(pd)
 	lc.theirLogCounter++
 
-	return pd.Index
+	return pd.Index, nil
+}
+
+// activeHTLCs returns all HTLC's added within addLog which haven't yet been
+// settled or timed out by an entry within removeLog.
+func activeHTLCs(addLog, removeLog *list.List) []*PaymentDescriptor {
+	removed := make(map[uint32]struct{})
+	for e := removeLog.Front(); e != nil; e = e.Next() {
+		htlc := e.Value.(*PaymentDescriptor)
+		if htlc.EntryType != Add {
+			removed[htlc.ParentIndex] = struct{}{}
+		}
+	}
+
+	var active []*PaymentDescriptor
+	for e := addLog.Front(); e != nil; e = e.Next() {
+		htlc := e.Value.(*PaymentDescriptor)
+		if htlc.EntryType != Add {
+			continue
+		}
+		if _, ok := removed[htlc.Index]; ok {
+			continue
+		}
+
+		active = append(active, htlc)
+	}
+
+	return active
+}
+
+// validateHTLCLimits ensures that the passed set of HTLC's offered by one
+// party doesn't exceed either the maximum number, or the maximum total value
+// of HTLC's permitted in flight within the channel.
+func (lc *LightningChannel) validateHTLCLimits(htlcs []*PaymentDescriptor) error {
+	maxHtlcs := int(lc.channelState.MaxAcceptedHtlcs)
+	if maxHtlcs == 0 {
+		maxHtlcs = MaxPendingPayments
+	}
+	if len(htlcs) > maxHtlcs {
+		return ErrMaxHTLCNumber
+	}
+
+	maxPendingAmt := lc.channelState.MaxPendingAmount
+	if maxPendingAmt == 0 {
+		return nil
+	}
+
+	var pendingAmt btcutil.Amount
+	for _, htlc := range htlcs {
+		pendingAmt += htlc.Amount
+	}
+	if pendingAmt > maxPendingAmt {
+		return ErrMaxPendingAmount
+	}
+
+	return nil
 }
 
 // SettleHTLC attempst to settle an existing outstanding received HTLC. The
@@ -1352,6 +1478,60 @@ func (lc *LightningChannel) ReceiveHTLCSettle(preimage [32]byte, logIndex uint32
 	return nil
 }
 
+// FailHTLC attempts to fail an existing outstanding received HTLC indexed by
+// an index into the remote log. Once the failure has been committed, the
+// value of the HTLC is returned to the remote party. If the specified index
+// doesn't exist within the log, or the HTLC has already been settled or
+// failed, then an error is returned.
+func (lc *LightningChannel) FailHTLC(logIndex uint32) error {
+	addEntry, ok := lc.theirLogIndex[logIndex]
+	if !ok {
+		return fmt.Errorf("non existant log entry")
+	}
+
+	htlc := addEntry.Value.(*PaymentDescriptor)
+	if htlc.settled {
+		return fmt.Errorf("htlc already removed")
+	}
+	htlc.settled = true
+
+	pd := &PaymentDescriptor{
+		Amount:      htlc.Amount,
+		Index:       lc.ourLogCounter,
+		ParentIndex: htlc.Index,
+		EntryType:   Timeout,
+	}
+
+	lc.ourUpdateLog.PushBack(pd)
+	lc.ourLogCounter++
+
+	return nil
+}
+
+// ReceiveFailHTLC attempts to fail an existing outgoing HTLC indexed by an
+// index into the local log, as the remote party has refused to settle it. If
+// the specified index doesn't exist within the log, an error is returned.
+func (lc *LightningChannel) ReceiveFailHTLC(logIndex uint32) error {
+	addEntry, ok := lc.ourLogIndex[logIndex]
+	if !ok {
+		return fmt.Errorf("non existant log entry")
+	}
+
+	htlc := addEntry.Value.(*PaymentDescriptor)
+
+	pd := &PaymentDescriptor{
+		Amount:      htlc.Amount,
+		ParentIndex: htlc.Index,
+		Index:       lc.theirLogCounter,
+		EntryType:   Timeout,
+	}
+
+	lc.theirUpdateLog.PushBack(pd)
+	lc.theirLogCounter++
+
+	return nil
+}
+
 // TimeoutHTLC...
 func (lc *LightningChannel) TimeoutHTLC() error {
 	return nil
@@ -1426,11 +1606,41 @@ func (lc *LightningChannel) addHTLC(commitTx *wire.MsgTx, ourCommit bool,
 	return nil
 }
 
+// HtlcResolution describes an HTLC output within our broadcast commitment
+// transaction, along with the details required to sweep it back to the
+// wallet. An outgoing HTLC is swept via the timeout clause of its script once
+// it has expired, while an incoming HTLC is swept via the success clause,
+// which requires the payment preimage. In either case, the output is first
+// encumbered by the relative delay of our commitment transaction.
+type HtlcResolution struct {
+	// Outpoint is the HTLC output within the commitment transaction.
+	Outpoint wire.OutPoint
+
+	// Incoming is true if the HTLC pays to us.
+	Incoming bool
+
+	// RHash is the payment hash of the HTLC.
+	RHash [32]byte
+
+	// Expiry is the absolute height at which the HTLC expires. An outgoing
+	// HTLC can't be swept before this height.
+	Expiry uint32
+
+	// CsvDelay is the relative delay after the confirmation of the
+	// commitment transaction before the output can be swept.
+	CsvDelay uint32
+
+	// SignDesc is a fully populated sign descriptor capable of generating
+	// the signature needed to sweep the output. The hash cache, and input
+	// index are left to be set by the caller.
+	SignDesc *SignDescriptor
+}
+
 // ForceCloseSummary describes the final commitment state before the channel is
 // locked-down to initiate a force closure by broadcasting the latest state
 // on-chain. The summary includes all the information required to claim all
 // rightfully owned outputs.
-// TODO(roasbeef): generalize, add HTLC info, revocatio info, etc.
+// TODO(roasbeef): generalize, add revocation info, etc.
 type ForceCloseSummary struct {
 	// CloseTx is the transaction which closed the channel on-chain. If we
 	// initiate the force close, then this'll be our latest commitment
@@ -1446,9 +1656,19 @@ type ForceCloseSummary struct {
 	// output can be claimed.
 	SelfOutputMaturity uint32
 
+	// SelfOutputLeaseExpiry is the absolute block height before which the
+	// above output can't be claimed as it's locked by a channel lease. If
+	// zero, the output is only subject to the relative maturity period.
+	SelfOutputLeaseExpiry uint32
+
 	// SelfOutputSignDesc is a fully populated sign descriptor capable of
-	// generating a valid signature to swee the self output.
+	// generating a valid signature to swee the self output. It's nil if
+	// the close tx has no output paying to us.
 	SelfOutputSignDesc *SignDescriptor
+
+	// HtlcResolutions describes each of the HTLC outputs within the close
+	// tx, allowing them to be swept once they can be claimed.
+	HtlcResolutions []*HtlcResolution
 }
 
 // ForceClose executes a unilateral closure of the transaction at the current
@@ -1492,24 +1712,9 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 		theirKey, theirSig)
 	commitTx.TxIn[0].Witness = witness
 
-	// Locate the output index of the delayed commitment output back to us.
-	// We'll return the details of this output to the caller so they can
-	// sweep it once it's mature.
-	// TODO(roasbeef): also return HTLC info, assumes only p2wsh is commit
-	// tx
-	var delayIndex uint32
-	var delayScript []byte
-	for i, txOut := range commitTx.TxOut {
-		if !txscript.IsPayToWitnessScriptHash(txOut.PkScript) {
-			continue
-		}
-
-		delayIndex = uint32(i)
-		delayScript = txOut.PkScript
-	}
-
 	csvTimeout := lc.channelState.LocalCsvDelay
 	selfKey := lc.channelState.OurCommitKey
+	ourLease, _ := leaseExpiries(lc.channelState)
 
 	// Re-derive the original pkScript for out to-self output within the
 	// commitment transaction. We'll need this for the created sign
@@ -1521,23 +1726,48 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 	}
 	revokeKey := DeriveRevocationPubkey(lc.channelState.TheirCommitKey,
 		unusedRevocation[:])
-	selfScript, err := commitScriptToSelf(csvTimeout, selfKey, revokeKey)
+	selfScript, err := commitScriptToSelfWithLease(csvTimeout, ourLease,
+		selfKey, revokeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Locate the output index of the delayed commitment output back to us.
+	// We'll return the details of this output to the caller so they can
+	// sweep it once it's mature. As the output paying to the remote party
+	// is also p2wsh within a leased channel, we match on the exact script.
+	delayScript, err := witnessScriptHash(selfScript)
 	if err != nil {
 		return nil, err
 	}
+	delayFound, delayIndex := FindScriptOutputIndex(commitTx, delayScript)
 
 	// With the necessary information gatehred above, create a new sign
 	// descriptor which is capable of generating the signature the caller
 	// needs to sweep this output. The hash cache, and input index are not
 	// set as the caller will decide these values once sweeping the output.
-	selfSignDesc := &SignDescriptor{
-		PubKey:       selfKey,
-		RedeemScript: selfScript,
-		Output: &wire.TxOut{
-			PkScript: delayScript,
-			Value:    int64(lc.channelState.OurBalance),
-		},
-		HashType: txscript.SigHashAll,
+	// If we have no settled balance, then the commitment transaction has
+	// no output paying to us, and the sign descriptor is left nil.
+	var selfSignDesc *SignDescriptor
+	if delayFound {
+		selfSignDesc = &SignDescriptor{
+			PubKey:       selfKey,
+			RedeemScript: selfScript,
+			Output: &wire.TxOut{
+				PkScript: delayScript,
+				Value:    int64(lc.channelState.OurBalance),
+			},
+			HashType: txscript.SigHashAll,
+		}
+	}
+
+	// The HTLC outputs of our commitment are bound to the revocation hash
+	// of the current height, which we'll need to re-create their scripts.
+	revocationHash := fastsha256.Sum256(unusedRevocation[:])
+	htlcResolutions, err := lc.htlcResolutions(commitTx, revocationHash,
+		csvTimeout)
+	if err != nil {
+		return nil, err
 	}
 
 	// Finally, close the channel force close signal which notifies any
@@ -1552,11 +1782,95 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 			Hash:  commitTx.TxSha(),
 			Index: delayIndex,
 		},
-		SelfOutputMaturity: csvTimeout,
-		SelfOutputSignDesc: selfSignDesc,
+		SelfOutputMaturity:    csvTimeout,
+		SelfOutputLeaseExpiry: ourLease,
+		SelfOutputSignDesc:    selfSignDesc,
+		HtlcResolutions:       htlcResolutions,
 	}, nil
 }
 
+// htlcResolutions locates each of the HTLCs within our current commitment
+// transaction, returning the details required to sweep each of them.
+func (lc *LightningChannel) htlcResolutions(commitTx *wire.MsgTx,
+	revocationHash [32]byte, csvTimeout uint32) ([]*HtlcResolution, error) {
+
+	localKey := lc.channelState.OurCommitKey
+	remoteKey := lc.channelState.TheirCommitKey
+	commitHash := commitTx.TxSha()
+
+	// Multiple HTLCs may share the same script, so we track the outputs
+	// already claimed to ensure each HTLC is matched to a distinct output.
+	claimed := make(map[uint32]struct{})
+
+	resolutions := make([]*HtlcResolution, 0, len(lc.channelState.Htlcs))
+	for _, htlc := range lc.channelState.Htlcs {
+		var (
+			htlcScript []byte
+			err        error
+		)
+		if htlc.Incoming {
+			htlcScript, err = receiverHTLCScript(htlc.RefundTimeout,
+				csvTimeout, remoteKey, localKey,
+				revocationHash[:], htlc.RHash[:])
+		} else {
+			htlcScript, err = senderHTLCScript(htlc.RefundTimeout,
+				csvTimeout, localKey, remoteKey,
+				revocationHash[:], htlc.RHash[:])
+		}
+		if err != nil {
+			return nil, err
+		}
+		htlcPkScript, err := witnessScriptHash(htlcScript)
+		if err != nil {
+			return nil, err
+		}
+
+		index, ok := findUnclaimedOutput(commitTx, htlcPkScript,
+			int64(htlc.Amt), claimed)
+		if !ok {
+			return nil, fmt.Errorf("unable to find output of HTLC "+
+				"%x within commitment transaction", htlc.RHash)
+		}
+		claimed[index] = struct{}{}
+
+		resolutions = append(resolutions, &HtlcResolution{
+			Outpoint: wire.OutPoint{
+				Hash:  commitHash,
+				Index: index,
+			},
+			Incoming: htlc.Incoming,
+			RHash:    htlc.RHash,
+			Expiry:   htlc.RefundTimeout,
+			CsvDelay: csvTimeout,
+			SignDesc: &SignDescriptor{
+				PubKey:       localKey,
+				RedeemScript: htlcScript,
+				Output:       commitTx.TxOut[index],
+				HashType:     txscript.SigHashAll,
+			},
+		})
+	}
+
+	return resolutions, nil
+}
+
+// findUnclaimedOutput returns the index of the first output of the passed
+// transaction paying value to pkScript which isn't within the claimed set.
+func findUnclaimedOutput(tx *wire.MsgTx, pkScript []byte, value int64,
+	claimed map[uint32]struct{}) (uint32, bool) {
+
+	for i, txOut := range tx.TxOut {
+		if _, ok := claimed[uint32(i)]; ok {
+			continue
+		}
+		if txOut.Value == value && bytes.Equal(txOut.PkScript, pkScript) {
+			return uint32(i), true
+		}
+	}
+
+	return 0, false
+}
+
 // InitCooperativeClose initiates a cooperative closure of an active lightning
 // channel. This method should only be executed once all pending HTLCs (if any)
 // on the channel have been cleared/removed. Upon completion, the source channel
@@ -1568,9 +1882,14 @@ func (lc *LightningChannel) ForceClose() (*ForceCloseSummary, error) {
 // of an unresponsive remote party, the initiator can either choose to execute
 // a force closure, or backoff for a period of time, and retry the cooperative
 // closure.
+//
+// If non-nil, the passed delivery script replaces the one negotiated during
+// the funding workflow as the destination of our balance. It must then be
+// sent to the remote party along with our signature. ErrUpfrontShutdown is
+// returned if we committed to our delivery script during funding.
 // TODO(roasbeef): caller should initiate signal to reject all incoming HTLCs,
 // settle any inflight.
-func (lc *LightningChannel) InitCooperativeClose() ([]byte, *wire.ShaHash, error) {
+func (lc *LightningChannel) InitCooperativeClose(deliveryScript []byte) ([]byte, *wire.ShaHash, error) {
 	lc.Lock()
 	defer lc.Unlock()
 
@@ -1580,6 +1899,14 @@ func (lc *LightningChannel) InitCooperativeClose() ([]byte, *wire.ShaHash, error
 		return nil, nil, ErrChanClosing
 	}
 
+	ourDeliveryScript, err := closeDeliveryScript(
+		lc.channelState.OurDeliveryScript, deliveryScript,
+		lc.channelState.OurUpfrontShutdown,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Otherwise, indicate in the channel status that a channel closure has
 	// been initiated.
 	lc.status = channelClosing
@@ -1587,7 +1914,7 @@ func (lc *LightningChannel) InitCooperativeClose() ([]byte, *wire.ShaHash, error
 	// TODO(roasbeef): assumes initiator pays fees
 	closeTx := CreateCooperativeCloseTx(lc.fundingTxIn,
 		lc.channelState.OurBalance, lc.channelState.TheirBalance,
-		lc.channelState.OurDeliveryScript, lc.channelState.TheirDeliveryScript,
+		ourDeliveryScript, lc.channelState.TheirDeliveryScript,
 		true)
 	closeTxSha := closeTx.TxSha()
 
@@ -1610,9 +1937,16 @@ func (lc *LightningChannel) InitCooperativeClose() ([]byte, *wire.ShaHash, error
 // transaction is returned. It is the duty of the responding node to broadcast
 // a signed+valid closure transaction to the network.
 //
+// If non-nil, the passed delivery script is the one requested by the remote
+// node, replacing the one negotiated during the funding workflow as the
+// destination of their balance. ErrUpfrontShutdown is returned if the remote
+// node committed to their delivery script during funding.
+//
 // NOTE: The passed remote sig is expected to the a fully complete signature
 // including the proper sighash byte.
-func (lc *LightningChannel) CompleteCooperativeClose(remoteSig []byte) (*wire.MsgTx, error) {
+func (lc *LightningChannel) CompleteCooperativeClose(remoteSig,
+	deliveryScript []byte) (*wire.MsgTx, error) {
+
 	lc.Lock()
 	defer lc.Unlock()
 
@@ -1622,6 +1956,14 @@ func (lc *LightningChannel) CompleteCooperativeClose(remoteSig []byte) (*wire.Ms
 		return nil, ErrChanClosing
 	}
 
+	theirDeliveryScript, err := closeDeliveryScript(
+		lc.channelState.TheirDeliveryScript, deliveryScript,
+		lc.channelState.TheirUpfrontShutdown,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	lc.status = channelClosed
 
 	// Create the transaction used to return the current settled balance
@@ -1629,7 +1971,7 @@ func (lc *LightningChannel) CompleteCooperativeClose(remoteSig []byte) (*wire.Ms
 	// the initiator pays full fees for the cooperative close transaction.
 	closeTx := CreateCooperativeCloseTx(lc.fundingTxIn,
 		lc.channelState.OurBalance, lc.channelState.TheirBalance,
-		lc.channelState.OurDeliveryScript, lc.channelState.TheirDeliveryScript,
+		lc.channelState.OurDeliveryScript, theirDeliveryScript,
 		false)
 
 	// With the transaction created, we can finally generate our half of
@@ -1686,17 +2028,22 @@ func (lc *LightningChannel) StateSnapshot() *channeldb.ChannelSnapshot {
 // to the "owner" of the commitment transaction which can be spent after a
 // relative block delay or revocation event, and the other paying the the
 // counter-party within the channel, which can be spent immediately.
+//
+// Within a leased channel, the outputs paying to the seller of the lease are
+// additionally locked until the lease expiry height. A non-zero selfLease or
+// theirLease places this absolute timelock on the output paying to the owner
+// of the commitment transaction, or the counter-party respectively.
 func CreateCommitTx(fundingOutput *wire.TxIn, selfKey, theirKey *btcec.PublicKey,
-	revokeKey *btcec.PublicKey, csvTimeout uint32, amountToSelf,
-	amountToThem btcutil.Amount) (*wire.MsgTx, error) {
+	revokeKey *btcec.PublicKey, csvTimeout, selfLease, theirLease uint32,
+	amountToSelf, amountToThem btcutil.Amount) (*wire.MsgTx, error) {
 
 	// First, we create the script for the delayed "pay-to-self" output.
 	// This output has 2 main redemption clauses: either we can redeem the
 	// output after a relative block delay, or the remote node can claim
 	// the funds with the revocation key if we broadcast a revoked
 	// commitment transaction.
-	ourRedeemScript, err := commitScriptToSelf(csvTimeout, selfKey,
-		revokeKey)
+	ourRedeemScript, err := commitScriptToSelfWithLease(csvTimeout,
+		selfLease, selfKey, revokeKey)
 	if err != nil {
 		return nil, err
 	}
@@ -1706,10 +2053,24 @@ func CreateCommitTx(fundingOutput *wire.TxIn, selfKey, theirKey *btcec.PublicKey
 	}
 
 	// Next, we create the script paying to them. This is just a regular
-	// P2WKH output, without any added CSV delay.
-	theirWitnessKeyHash, err := commitScriptUnencumbered(theirKey)
-	if err != nil {
-		return nil, err
+	// P2WKH output, without any added CSV delay, unless they're the seller
+	// of a lease in which case their funds are locked until it expires.
+	var theirWitnessKeyHash []byte
+	if theirLease != 0 {
+		theirRedeemScript, err := leaseCommitScriptToRemote(theirLease,
+			theirKey)
+		if err != nil {
+			return nil, err
+		}
+		theirWitnessKeyHash, err = witnessScriptHash(theirRedeemScript)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		theirWitnessKeyHash, err = commitScriptUnencumbered(theirKey)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Now that both output scripts have been created, we can finally create
@@ -1730,6 +2091,57 @@ func CreateCommitTx(fundingOutput *wire.TxIn, selfKey, theirKey *btcec.PublicKey
 	return commitTx, nil
 }
 
+// commitScriptToSelfWithLease returns the delayed to-self script of a
+// commitment transaction, adding the lease timelock if leaseExpiry is
+// non-zero.
+func commitScriptToSelfWithLease(csvTimeout, leaseExpiry uint32, selfKey,
+	revokeKey *btcec.PublicKey) ([]byte, error) {
+
+	if leaseExpiry != 0 {
+		return leaseCommitScriptToSelf(csvTimeout, leaseExpiry, selfKey,
+			revokeKey)
+	}
+
+	return commitScriptToSelf(csvTimeout, selfKey, revokeKey)
+}
+
+// leaseExpiries returns the lease expiry heights which apply to our outputs,
+// and the remote party's outputs within the commitment transactions of the
+// passed channel. Only the outputs of the channel initiator, who sold the
+// lease, are locked, so at most one of the returned heights is non-zero.
+func leaseExpiries(state *channeldb.OpenChannel) (uint32, uint32) {
+	if state.IsInitiator {
+		return state.LeaseExpiry, 0
+	}
+
+	return 0, state.LeaseExpiry
+}
+
+// UpfrontShutdown returns true if we committed to our delivery script as an
+// upfront shutdown script during the funding workflow, in which case it can't
+// be replaced when the channel is cooperatively closed.
+func (lc *LightningChannel) UpfrontShutdown() bool {
+	return lc.channelState.OurUpfrontShutdown
+}
+
+// closeDeliveryScript returns the script a party's balance is delivered to
+// within a cooperative close transaction, given the delivery script
+// negotiated during funding, and the one requested when the channel is
+// closed, if any. A requested script which differs from one committed to as
+// an upfront shutdown script is rejected.
+func closeDeliveryScript(negotiated, requested []byte,
+	upfrontShutdown bool) ([]byte, error) {
+
+	switch {
+	case requested == nil || bytes.Equal(requested, negotiated):
+		return negotiated, nil
+	case upfrontShutdown:
+		return nil, ErrUpfrontShutdown
+	default:
+		return requested, nil
+	}
+}
+
 // CreateCooperativeCloseTx creates a transaction which if signed by both
 // parties, then broadcast cooperatively closes an active channel. The creation
 // of the closure transaction is modified by a boolean indicating if the party
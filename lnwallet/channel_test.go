@@ -206,12 +206,14 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 	aliceRevokeKey := DeriveRevocationPubkey(bobKeyPub, aliceFirstRevoke[:])
 
 	aliceCommitTx, err := CreateCommitTx(fundingTxIn, aliceKeyPub,
-		bobKeyPub, aliceRevokeKey, csvTimeoutAlice, channelBal, channelBal)
+		bobKeyPub, aliceRevokeKey, csvTimeoutAlice, 0, 0, channelBal,
+		channelBal)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	bobCommitTx, err := CreateCommitTx(fundingTxIn, bobKeyPub,
-		aliceKeyPub, bobRevokeKey, csvTimeoutBob, channelBal, channelBal)
+		aliceKeyPub, bobRevokeKey, csvTimeoutBob, 0, 0, channelBal,
+		channelBal)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -279,11 +281,11 @@ func createTestChannels(revocationWindow int) (*LightningChannel, *LightningChan
 
 	notifier := &mockNotfier{}
 
-	channelAlice, err := NewLightningChannel(aliceSigner, nil, notifier, aliceChannelState)
+	channelAlice, err := NewLightningChannel(aliceSigner, nil, nil, notifier, aliceChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	channelBob, err := NewLightningChannel(bobSigner, nil, notifier, bobChannelState)
+	channelBob, err := NewLightningChannel(bobSigner, nil, nil, notifier, bobChannelState)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -339,8 +341,12 @@ func TestSimpleAddSettleWorkflow(t *testing.T) {
 	// First Alice adds the outgoing HTLC to her local channel's state
 	// update log. Then Alice sends this wire message over to Bob who also
 	// adds this htlc to his local state update log.
-	aliceChannel.AddHTLC(htlc)
-	bobChannel.ReceiveHTLC(htlc)
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
 
 	// Next alice commits this change by sending a signature message.
 	aliceSig, bobLogIndex, err := aliceChannel.SignNextCommitment()
@@ -566,12 +572,12 @@ func TestCooperativeChannelClosure(t *testing.T) {
 	defer cleanUp()
 
 	// First we test the channel initiator requesting a cooperative close.
-	sig, txid, err := aliceChannel.InitCooperativeClose()
+	sig, txid, err := aliceChannel.InitCooperativeClose(nil)
 	if err != nil {
 		t.Fatalf("unable to initiate alice cooperative close: %v", err)
 	}
 	finalSig := append(sig, byte(txscript.SigHashAll))
-	closeTx, err := bobChannel.CompleteCooperativeClose(finalSig)
+	closeTx, err := bobChannel.CompleteCooperativeClose(finalSig, nil)
 	if err != nil {
 		t.Fatalf("unable to complete alice cooperative close: %v", err)
 	}
@@ -586,12 +592,12 @@ func TestCooperativeChannelClosure(t *testing.T) {
 
 	// Next we test the channel recipient requesting a cooperative closure.
 	// First we test the channel initiator requesting a cooperative close.
-	sig, txid, err = bobChannel.InitCooperativeClose()
+	sig, txid, err = bobChannel.InitCooperativeClose(nil)
 	if err != nil {
 		t.Fatalf("unable to initiate bob cooperative close: %v", err)
 	}
 	finalSig = append(sig, byte(txscript.SigHashAll))
-	closeTx, err = aliceChannel.CompleteCooperativeClose(finalSig)
+	closeTx, err = aliceChannel.CompleteCooperativeClose(finalSig, nil)
 	if err != nil {
 		t.Fatalf("unable to complete bob cooperative close: %v", err)
 	}
@@ -602,6 +608,68 @@ func TestCooperativeChannelClosure(t *testing.T) {
 	}
 }
 
+func TestCooperativeCloseDeliveryScript(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// Alice requests her balance be delivered to a script other than the
+	// one negotiated during funding. Bob should arrive at the same
+	// closing transaction once he learns of the new script.
+	deliveryScript := bytes.Repeat([]byte{0x01}, 22)
+	sig, txid, err := aliceChannel.InitCooperativeClose(deliveryScript)
+	if err != nil {
+		t.Fatalf("unable to initiate alice cooperative close: %v", err)
+	}
+	finalSig := append(sig, byte(txscript.SigHashAll))
+	closeTx, err := bobChannel.CompleteCooperativeClose(finalSig,
+		deliveryScript)
+	if err != nil {
+		t.Fatalf("unable to complete alice cooperative close: %v", err)
+	}
+	bobCloseSha := closeTx.TxSha()
+	if !bobCloseSha.IsEqual(txid) {
+		t.Fatalf("alice's transactions doesn't match: %x vs %x",
+			bobCloseSha[:], txid[:])
+	}
+	var found bool
+	for _, txOut := range closeTx.TxOut {
+		if bytes.Equal(txOut.PkScript, deliveryScript) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("closing transaction doesn't pay to alice's "+
+			"requested delivery script: %v", spew.Sdump(closeTx))
+	}
+
+	aliceChannel.status = channelOpen
+	bobChannel.status = channelOpen
+
+	// Once Alice has committed to her delivery script as an upfront
+	// shutdown script, neither side should allow it to be replaced.
+	aliceChannel.channelState.OurUpfrontShutdown = true
+	bobChannel.channelState.TheirUpfrontShutdown = true
+	_, _, err = aliceChannel.InitCooperativeClose(deliveryScript)
+	if err != ErrUpfrontShutdown {
+		t.Fatalf("expected ErrUpfrontShutdown, instead got: %v", err)
+	}
+	_, err = bobChannel.CompleteCooperativeClose(finalSig, deliveryScript)
+	if err != ErrUpfrontShutdown {
+		t.Fatalf("expected ErrUpfrontShutdown, instead got: %v", err)
+	}
+
+	// Requesting the committed script itself is permitted.
+	_, _, err = aliceChannel.InitCooperativeClose(
+		aliceChannel.channelState.OurDeliveryScript,
+	)
+	if err != nil {
+		t.Fatalf("unable to initiate alice cooperative close: %v", err)
+	}
+}
+
 func TestStateUpdatePersistence(t *testing.T) {
 	// Create a test channel which will be used for the duration of this
 	// unittest. The channel will be funded evenly with Alice having 5 BTC,
@@ -637,8 +705,12 @@ func TestStateUpdatePersistence(t *testing.T) {
 			Expiry:           uint32(10),
 		}
 
-		aliceChannel.AddHTLC(h)
-		bobChannel.ReceiveHTLC(h)
+		if _, err := aliceChannel.AddHTLC(h); err != nil {
+			t.Fatalf("unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(h); err != nil {
+			t.Fatalf("unable to recv htlc: %v", err)
+		}
 	}
 	rHash := fastsha256.Sum256(bobPreimage[:])
 	bobh := &lnwire.HTLCAddRequest{
@@ -646,8 +718,12 @@ func TestStateUpdatePersistence(t *testing.T) {
 		Amount:           lnwire.CreditsAmount(1000),
 		Expiry:           uint32(10),
 	}
-	bobChannel.AddHTLC(bobh)
-	aliceChannel.ReceiveHTLC(bobh)
+	if _, err := bobChannel.AddHTLC(bobh); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := aliceChannel.ReceiveHTLC(bobh); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
 
 	// Next, Alice initiates a state transition to lock in the above HTLC's.
 	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
@@ -698,11 +774,11 @@ func TestStateUpdatePersistence(t *testing.T) {
 		t.Fatalf("unable to fetch channel: %v", err)
 	}
 	notifier := aliceChannel.channelEvents
-	aliceChannelNew, err := NewLightningChannel(aliceChannel.signer, nil, notifier, aliceChannels[0])
+	aliceChannelNew, err := NewLightningChannel(aliceChannel.signer, nil, nil, notifier, aliceChannels[0])
 	if err != nil {
 		t.Fatalf("unable to create new channel: %v", err)
 	}
-	bobChannelNew, err := NewLightningChannel(bobChannel.signer, nil, notifier, bobChannels[0])
+	bobChannelNew, err := NewLightningChannel(bobChannel.signer, nil, nil, notifier, bobChannels[0])
 	if err != nil {
 		t.Fatalf("unable to create new channel: %v", err)
 	}
@@ -785,3 +861,227 @@ func TestStateUpdatePersistence(t *testing.T) {
 			bobBalance)
 	}
 }
+
+// TestHTLCLimits checks that both the number and total value of HTLC's in
+// flight within a channel are bounded by the channel's configured limits, and
+// that settled HTLC's no longer count towards those limits.
+func TestHTLCLimits(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		channel.channelState.MaxAcceptedHtlcs = 3
+		channel.channelState.MaxPendingAmount = 2500
+	}
+
+	preimages := make([][32]byte, 4)
+	htlcs := make([]*lnwire.HTLCAddRequest, 4)
+	for i := range htlcs {
+		preimages[i][0] = byte(i)
+		htlcs[i] = &lnwire.HTLCAddRequest{
+			RedemptionHashes: [][32]byte{fastsha256.Sum256(preimages[i][:])},
+			Amount:           lnwire.CreditsAmount(1000),
+			Expiry:           uint32(10),
+		}
+	}
+
+	// Alice should be able to add two HTLC's, but a third would push the
+	// total value in flight above the limit.
+	for _, htlc := range htlcs[:2] {
+		if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+			t.Fatalf("unable to add htlc: %v", err)
+		}
+		if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+			t.Fatalf("unable to recv htlc: %v", err)
+		}
+	}
+	if _, err := aliceChannel.AddHTLC(htlcs[2]); err != ErrMaxPendingAmount {
+		t.Fatalf("expected ErrMaxPendingAmount, got %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcs[2]); err != ErrMaxPendingAmount {
+		t.Fatalf("expected ErrMaxPendingAmount, got %v", err)
+	}
+
+	// Once the value limit is lifted, the third HTLC should be accepted,
+	// while a fourth would exceed the number of HTLC's permitted.
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		channel.channelState.MaxPendingAmount = 0
+	}
+	if _, err := aliceChannel.AddHTLC(htlcs[2]); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcs[2]); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if _, err := aliceChannel.AddHTLC(htlcs[3]); err != ErrMaxHTLCNumber {
+		t.Fatalf("expected ErrMaxHTLCNumber, got %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcs[3]); err != ErrMaxHTLCNumber {
+		t.Fatalf("expected ErrMaxHTLCNumber, got %v", err)
+	}
+
+	// The three HTLC's should be able to be locked in without violating
+	// the limits.
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to lock in HTLC's: %v", err)
+	}
+
+	// After Bob settles one of the HTLC's, Alice should once again be
+	// able to add the fourth.
+	settleIndex, err := bobChannel.SettleHTLC(preimages[0])
+	if err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	if err := aliceChannel.ReceiveHTLCSettle(preimages[0], settleIndex); err != nil {
+		t.Fatalf("unable to recv settle: %v", err)
+	}
+	if _, err := aliceChannel.AddHTLC(htlcs[3]); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlcs[3]); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to settle and add HTLC's: %v", err)
+	}
+}
+
+// TestAddFailWorkflow tests that an HTLC failed by its receiver is removed
+// from both commitment chains, returning its value to the sender.
+func TestAddFailWorkflow(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	var preimage [32]byte
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256(preimage[:])},
+		Amount:           lnwire.CreditsAmount(1e8),
+		Expiry:           uint32(5),
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	index, err := bobChannel.ReceiveHTLC(htlc)
+	if err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to lock in htlc: %v", err)
+	}
+
+	// Bob refuses to settle the HTLC, so he fails it back to Alice. Once
+	// failed, the HTLC can be neither failed again nor settled.
+	if err := bobChannel.FailHTLC(index); err != nil {
+		t.Fatalf("bob unable to fail htlc: %v", err)
+	}
+	if err := bobChannel.FailHTLC(index); err == nil {
+		t.Fatalf("bob able to fail htlc twice")
+	}
+	if _, err := bobChannel.SettleHTLC(preimage); err == nil {
+		t.Fatalf("bob able to settle failed htlc")
+	}
+	if err := aliceChannel.ReceiveFailHTLC(index); err != nil {
+		t.Fatalf("alice unable to accept fail of outbound htlc: %v", err)
+	}
+	if err := forceStateTransition(bobChannel, aliceChannel); err != nil {
+		t.Fatalf("unable to lock in failed htlc: %v", err)
+	}
+
+	// With the failure locked in, both sides should be back at their
+	// initial balances, and the logs of both sides should be cleared.
+	initialBalance := btcutil.Amount(5 * 1e8)
+	for _, channel := range []*LightningChannel{aliceChannel, bobChannel} {
+		state := channel.channelState
+		if state.OurBalance != initialBalance ||
+			state.TheirBalance != initialBalance {
+
+			t.Fatalf("incorrect balances after failed htlc: "+
+				"local=%v, remote=%v", state.OurBalance,
+				state.TheirBalance)
+		}
+		if channel.ourUpdateLog.Len() != 0 ||
+			channel.theirUpdateLog.Len() != 0 {
+
+			t.Fatalf("logs not cleared after failed htlc")
+		}
+	}
+	if err := aliceChannel.ReceiveFailHTLC(index); err == nil {
+		t.Fatalf("alice able to accept fail of removed htlc")
+	}
+}
+
+func TestSigPoolStateTransition(t *testing.T) {
+	aliceChannel, bobChannel, cleanUp, err := createTestChannels(3)
+	if err != nil {
+		t.Fatalf("unable to create test channels: %v", err)
+	}
+	defer cleanUp()
+
+	// Hand off the signature operations of both channels to their own sig
+	// pools, as they would be within each node.
+	alicePool := NewSigPool(2, aliceChannel.signer)
+	bobPool := NewSigPool(2, bobChannel.signer)
+	for _, pool := range []*SigPool{alicePool, bobPool} {
+		if err := pool.Start(); err != nil {
+			t.Fatalf("unable to start sig pool: %v", err)
+		}
+	}
+	defer bobPool.Stop()
+	aliceChannel.sigPool = alicePool
+	bobChannel.sigPool = bobPool
+
+	preimage := bytes.Repeat([]byte{0xaa}, 32)
+	htlc := &lnwire.HTLCAddRequest{
+		RedemptionHashes: [][32]byte{fastsha256.Sum256(preimage)},
+		Amount:           lnwire.CreditsAmount(1000),
+		Expiry:           uint32(10),
+	}
+	if _, err := aliceChannel.AddHTLC(htlc); err != nil {
+		t.Fatalf("unable to add htlc: %v", err)
+	}
+	if _, err := bobChannel.ReceiveHTLC(htlc); err != nil {
+		t.Fatalf("unable to recv htlc: %v", err)
+	}
+
+	// The HTLC should be locked in with all signatures generated and
+	// validated by the pools.
+	if err := forceStateTransition(aliceChannel, bobChannel); err != nil {
+		t.Fatalf("unable to lock in htlc: %v", err)
+	}
+
+	// A signature which doesn't match the new commitment should be
+	// rejected by the pool.
+	var preimageArr [32]byte
+	copy(preimageArr[:], preimage)
+	settleIndex, err := bobChannel.SettleHTLC(preimageArr)
+	if err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	if err := aliceChannel.ReceiveHTLCSettle(preimageArr, settleIndex); err != nil {
+		t.Fatalf("unable to recv settle: %v", err)
+	}
+	bobSig, aliceIndex, err := bobChannel.SignNextCommitment()
+	if err != nil {
+		t.Fatalf("unable to sign commitment: %v", err)
+	}
+	badSig := append([]byte(nil), bobSig...)
+	badSig[len(badSig)-1] ^= 0x01
+	if err := aliceChannel.ReceiveNewCommitment(badSig, aliceIndex); err == nil {
+		t.Fatalf("invalid commitment signature was accepted")
+	}
+
+	// Once the pool has been stopped, no further signatures can be
+	// generated with it.
+	if err := alicePool.Stop(); err != nil {
+		t.Fatalf("unable to stop sig pool: %v", err)
+	}
+	if _, _, err := aliceChannel.SignNextCommitment(); err != ErrSigPoolShuttingDown {
+		t.Fatalf("expected ErrSigPoolShuttingDown, got %v", err)
+	}
+}
@@ -0,0 +1,428 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+const (
+	// txOverhead is the overhead of a transaction residing within the
+	// version number, lock time, and the input/output counts.
+	txOverhead = 8 + 1 + 1
+
+	// p2wkhSpendSize an estimate of the number of bytes it takes to spend
+	// a p2wkh output.
+	//
+	// (p2wkh witness) + txid + index + varint script size + sequence
+	// TODO(roasbeef): div by 3 due to witness size?
+	p2wkhSpendSize = (1 + 73 + 1 + 33) + 32 + 4 + 1 + 4
+
+	// p2wkhOutputSize is an estimate of the size of a regular p2wkh
+	// output.
+	//
+	// 8 (output) + 1 (var int script) + 22 (p2wkh output)
+	p2wkhOutputSize = 8 + 1 + 22
+
+	// DefaultDustLimit is the value below which an output is considered
+	// dust. Change outputs below this value are instead donated to
+	// miners as fees.
+	DefaultDustLimit = btcutil.Amount(546)
+
+	// DefaultFundingMinConfs is the default number of confirmations an
+	// output requires before it may be used to fund a channel.
+	DefaultFundingMinConfs = 1
+)
+
+var (
+	// ErrNoConsolidationCandidates is returned when a consolidation is
+	// requested, but the wallet doesn't control enough small outputs to
+	// make merging them worthwhile.
+	ErrNoConsolidationCandidates = errors.New("not enough outputs " +
+		"eligible for consolidation")
+
+	// ErrConsolidationUneconomical is returned when the fees required to
+	// consolidate the selected outputs would consume their entire value.
+	ErrConsolidationUneconomical = errors.New("fees required to " +
+		"consolidate outputs exceed their value")
+
+	// ErrSweepUneconomical is returned when the fees required to sweep
+	// the wallet's outputs would consume their entire value.
+	ErrSweepUneconomical = errors.New("fees required to sweep the " +
+		"wallet exceed its balance")
+)
+
+// CoinSelectionStrategy dictates the order in which the wallet's unspent
+// outputs are considered during coin selection.
+type CoinSelectionStrategy uint8
+
+const (
+	// CoinSelectionLargestFirst selects the largest outputs first, which
+	// minimizes the number of inputs, and therefore the fees, of the
+	// resulting transaction.
+	CoinSelectionLargestFirst CoinSelectionStrategy = iota
+
+	// CoinSelectionRandom selects outputs in a random order, which avoids
+	// leaking information about the wallet's coin selection algorithm.
+	CoinSelectionRandom
+
+	// CoinSelectionSmallestFirst selects the smallest outputs first. This
+	// results in larger transactions, but gradually consolidates the
+	// wallet's small outputs, and is best used when fees are low.
+	CoinSelectionSmallestFirst
+)
+
+// String returns a human readable version of the target strategy.
+func (c CoinSelectionStrategy) String() string {
+	switch c {
+	case CoinSelectionLargestFirst:
+		return "largest-first"
+	case CoinSelectionRandom:
+		return "random"
+	case CoinSelectionSmallestFirst:
+		return "smallest-first"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCoinSelectionStrategy maps the human readable name of a coin selection
+// strategy to its typed representation.
+func ParseCoinSelectionStrategy(s string) (CoinSelectionStrategy, error) {
+	switch s {
+	case "largest-first":
+		return CoinSelectionLargestFirst, nil
+	case "random":
+		return CoinSelectionRandom, nil
+	case "smallest-first":
+		return CoinSelectionSmallestFirst, nil
+	default:
+		return 0, fmt.Errorf("unknown coin selection strategy %q, "+
+			"valid strategies are: largest-first, random, "+
+			"smallest-first", s)
+	}
+}
+
+// utxoValuer returns the value of a coin with respect to a particular type of
+// coin selection. Funding transactions are denominated in the value of the
+// active asset, while regular on-chain spends are denominated in satoshis.
+type utxoValuer func(*Utxo) btcutil.Amount
+
+// satoshiValue returns the raw bitcoin value of the passed coin.
+func satoshiValue(coin *Utxo) btcutil.Amount {
+	return coin.Value
+}
+
+// assetValue returns the colored asset value of the passed coin.
+func assetValue(coin *Utxo) btcutil.Amount {
+	return coin.ColorData.Value
+}
+
+// orderCoins sorts the passed coins in place according to the target coin
+// selection strategy.
+func orderCoins(coins []*Utxo, strategy CoinSelectionStrategy,
+	value utxoValuer) {
+
+	switch strategy {
+	case CoinSelectionRandom:
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for i := range coins {
+			j := r.Intn(i + 1)
+			coins[i], coins[j] = coins[j], coins[i]
+		}
+	case CoinSelectionSmallestFirst:
+		sort.Sort(coinSorter{coins, value, false})
+	default:
+		sort.Sort(coinSorter{coins, value, true})
+	}
+}
+
+// coinSorter implements sort.Interface, ordering a slice of coins by value.
+type coinSorter struct {
+	coins      []*Utxo
+	value      utxoValuer
+	descending bool
+}
+
+func (c coinSorter) Len() int      { return len(c.coins) }
+func (c coinSorter) Swap(i, j int) { c.coins[i], c.coins[j] = c.coins[j], c.coins[i] }
+func (c coinSorter) Less(i, j int) bool {
+	if c.descending {
+		return c.value(c.coins[i]) > c.value(c.coins[j])
+	}
+	return c.value(c.coins[i]) < c.value(c.coins[j])
+}
+
+// isColored returns true if the passed coin carries a colored asset. Such
+// coins must never be spent by a regular bitcoin transaction, as doing so
+// would destroy the asset.
+func isColored(coin *Utxo) bool {
+	return coin.ColorData != nil && coin.ColorData.AssetId != ""
+}
+
+// estimateTxSize returns an estimate of the size in bytes of a fully signed
+// transaction spending numInputs p2wkh outputs to numOutputs outputs.
+func estimateTxSize(numInputs, numOutputs int) int {
+	return txOverhead + numInputs*p2wkhSpendSize +
+		numOutputs*p2wkhOutputSize
+}
+
+// SetCoinSelectionStrategy sets the strategy used to select coins for both
+// funding transactions and regular on-chain spends.
+func (l *LightningWallet) SetCoinSelectionStrategy(s CoinSelectionStrategy) {
+	l.coinSelectMtx.Lock()
+	l.coinSelectStrategy = s
+	l.coinSelectMtx.Unlock()
+}
+
+// SetFundingMinConfs sets the number of confirmations an output requires
+// before it may be used to fund a channel. A value of zero permits funding
+// channels from unconfirmed outputs.
+func (l *LightningWallet) SetFundingMinConfs(minConfs int32) {
+	l.coinSelectMtx.Lock()
+	l.fundingMinConfs = minConfs
+	l.coinSelectMtx.Unlock()
+}
+
+// plainCoins returns all of the wallet's unlocked witness outputs with at
+// least minConfs confirmations which don't carry a colored asset.
+//
+// NOTE: The coinSelectMtx MUST be held when calling this method.
+func (l *LightningWallet) plainCoins(minConfs int32) ([]*Utxo, error) {
+	coins, err := l.ListUnspentWitness(minConfs)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]*Utxo, 0, len(coins))
+	for _, coin := range coins {
+		if isColored(coin) {
+			continue
+		}
+		if _, ok := l.lockedOutPoints[coin.OutPoint]; ok {
+			continue
+		}
+
+		plain = append(plain, coin)
+	}
+
+	return l.filterLeased(plain), nil
+}
+
+// SendCoins funds, signs, and broadcasts a transaction paying to the specified
+// outputs at the given fee rate, expressed in sat/byte. Unlike the SendOutputs
+// method of the underlying WalletController, inputs are chosen according to
+// the wallet's active coin selection strategy, and outputs carrying colored
+// assets are never selected.
+func (l *LightningWallet) SendCoins(outputs []*wire.TxOut,
+	feeRate btcutil.Amount) (*wire.ShaHash, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.plainCoins(1)
+	if err != nil {
+		return nil, err
+	}
+	orderCoins(coins, l.coinSelectStrategy, satoshiValue)
+
+	var amt btcutil.Amount
+	for _, output := range outputs {
+		amt += btcutil.Amount(output.Value)
+	}
+
+	// Select coins until we've collected enough to pay for the outputs,
+	// along with the fee of a transaction which also includes a change
+	// output.
+	var (
+		selected    []*Utxo
+		satSelected btcutil.Amount
+		fee         btcutil.Amount
+	)
+	for _, coin := range coins {
+		selected = append(selected, coin)
+		satSelected += coin.Value
+
+		size := estimateTxSize(len(selected), len(outputs)+1)
+		fee = btcutil.Amount(size) * feeRate
+		if satSelected >= amt+fee {
+			break
+		}
+	}
+	if len(selected) == 0 || satSelected < amt+fee {
+		return nil, ErrInsufficientFunds
+	}
+
+	tx := wire.NewMsgTx()
+	for _, coin := range selected {
+		outPoint := coin.OutPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+	}
+	for _, output := range outputs {
+		tx.AddTxOut(output)
+	}
+
+	// If the change left over is dust, then it'll be donated to the
+	// miners rather than creating an uneconomical output.
+	if changeAmt := satSelected - amt - fee; changeAmt >= DefaultDustLimit {
+		changeAddr, err := l.NewAddress(WitnessPubKey, true)
+		if err != nil {
+			return nil, err
+		}
+		changeScript, err := txscript.PayToAddrScript(changeAddr)
+		if err != nil {
+			return nil, err
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(changeAmt), changeScript))
+	}
+
+	return l.signAndPublish(tx)
+}
+
+// SweepCoins spends all of the wallet's confirmed outputs eligible for coin
+// selection to the passed pkScript, at the given fee rate expressed in
+// sat/byte. The fee is deducted from the swept value, so no change output is
+// created. The txid of the sweep transaction is returned along with the value
+// sent.
+func (l *LightningWallet) SweepCoins(pkScript []byte,
+	feeRate btcutil.Amount) (*wire.ShaHash, btcutil.Amount, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.plainCoins(1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(coins) == 0 {
+		return nil, 0, ErrInsufficientFunds
+	}
+
+	tx := wire.NewMsgTx()
+	var total btcutil.Amount
+	for _, coin := range coins {
+		outPoint := coin.OutPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+		total += coin.Value
+	}
+
+	fee := btcutil.Amount(estimateTxSize(len(tx.TxIn), 1)) * feeRate
+	if total-fee < DefaultDustLimit {
+		return nil, 0, ErrSweepUneconomical
+	}
+	tx.AddTxOut(wire.NewTxOut(int64(total-fee), pkScript))
+
+	txid, err := l.signAndPublish(tx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return txid, total - fee, nil
+}
+
+// ConsolidateOutputs sweeps all of the wallet's unspent outputs valued below
+// maxValue into a single fresh output controlled by the wallet, at the given
+// fee rate expressed in sat/byte. Consolidating small outputs while fees are
+// low reduces the cost of spending them once fees rise. At least minInputs
+// outputs must be eligible for the consolidation to be carried out. The
+// number of outputs consolidated is returned along with the txid of the
+// consolidation transaction.
+func (l *LightningWallet) ConsolidateOutputs(maxValue, feeRate btcutil.Amount,
+	minInputs int) (*wire.ShaHash, int, error) {
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	coins, err := l.plainCoins(1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx := wire.NewMsgTx()
+	var total btcutil.Amount
+	for _, coin := range coins {
+		if coin.Value >= maxValue {
+			continue
+		}
+
+		outPoint := coin.OutPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+		total += coin.Value
+	}
+	if len(tx.TxIn) < minInputs || len(tx.TxIn) < 2 {
+		return nil, 0, ErrNoConsolidationCandidates
+	}
+
+	fee := btcutil.Amount(estimateTxSize(len(tx.TxIn), 1)) * feeRate
+	if total-fee < DefaultDustLimit {
+		return nil, 0, ErrConsolidationUneconomical
+	}
+
+	addr, err := l.NewAddress(WitnessPubKey, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, 0, err
+	}
+	tx.AddTxOut(wire.NewTxOut(int64(total-fee), pkScript))
+
+	txid, err := l.signAndPublish(tx)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = l.LabelTransaction(*txid, ConsolidationTxLabel, false)
+	if err != nil {
+		walletLog.Warnf("unable to label consolidation tx %v: %v",
+			txid, err)
+	}
+
+	return txid, len(tx.TxIn), nil
+}
+
+// signAndPublish signs each of the inputs of the passed transaction, all of
+// which MUST be controlled by the wallet, then broadcasts the transaction to
+// the network.
+func (l *LightningWallet) signAndPublish(tx *wire.MsgTx) (*wire.ShaHash, error) {
+	// Fetch the outputs referenced by each input up front, as they're
+	// required to compute the witness sighashes below.
+	prevOuts := make([]*wire.TxOut, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		prevOut, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return nil, err
+		}
+		prevOuts[i] = prevOut
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx)
+	for i, txIn := range tx.TxIn {
+		signDesc := &SignDescriptor{
+			Output:     prevOuts[i],
+			HashType:   txscript.SigHashAll,
+			SigHashes:  sigHashes,
+			InputIndex: i,
+		}
+		inputScript, err := l.Signer.ComputeInputScript(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		txIn.SignatureScript = inputScript.ScriptSig
+		txIn.Witness = inputScript.Witness
+	}
+
+	if err := l.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	txid := tx.TxSha()
+	return &txid, nil
+}
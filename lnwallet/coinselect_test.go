@@ -0,0 +1,98 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lndcc"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestOrderCoins ensures that coins are ordered as dictated by each of the
+// deterministic coin selection strategies.
+func TestOrderCoins(t *testing.T) {
+	values := []btcutil.Amount{5000, 1000, 9000, 3000}
+	newCoins := func() []*Utxo {
+		coins := make([]*Utxo, len(values))
+		for i, value := range values {
+			coins[i] = &Utxo{Value: value}
+		}
+		return coins
+	}
+
+	testCases := []struct {
+		strategy CoinSelectionStrategy
+		expected []btcutil.Amount
+	}{
+		{
+			strategy: CoinSelectionLargestFirst,
+			expected: []btcutil.Amount{9000, 5000, 3000, 1000},
+		},
+		{
+			strategy: CoinSelectionSmallestFirst,
+			expected: []btcutil.Amount{1000, 3000, 5000, 9000},
+		},
+	}
+
+	for _, test := range testCases {
+		coins := newCoins()
+		orderCoins(coins, test.strategy, satoshiValue)
+
+		for i, coin := range coins {
+			if coin.Value != test.expected[i] {
+				t.Fatalf("%v: coin %v has value %v, expected %v",
+					test.strategy, i, coin.Value,
+					test.expected[i])
+			}
+		}
+	}
+
+	// A random ordering should still contain each of the original coins.
+	coins := newCoins()
+	orderCoins(coins, CoinSelectionRandom, satoshiValue)
+	var total btcutil.Amount
+	for _, coin := range coins {
+		total += coin.Value
+	}
+	if total != 18000 {
+		t.Fatalf("random ordering lost coins: total %v", total)
+	}
+}
+
+// TestParseCoinSelectionStrategy tests that each strategy survives a round
+// trip through its string representation, and that unknown strategies are
+// rejected.
+func TestParseCoinSelectionStrategy(t *testing.T) {
+	strategies := []CoinSelectionStrategy{
+		CoinSelectionLargestFirst,
+		CoinSelectionRandom,
+		CoinSelectionSmallestFirst,
+	}
+	for _, strategy := range strategies {
+		parsed, err := ParseCoinSelectionStrategy(strategy.String())
+		if err != nil {
+			t.Fatalf("unable to parse %v: %v", strategy, err)
+		}
+		if parsed != strategy {
+			t.Fatalf("strategy mismatch: expected %v, got %v",
+				strategy, parsed)
+		}
+	}
+
+	if _, err := ParseCoinSelectionStrategy("oldest-first"); err == nil {
+		t.Fatalf("unknown strategy should be rejected")
+	}
+}
+
+// TestIsColored ensures that only coins carrying an asset are deemed colored.
+func TestIsColored(t *testing.T) {
+	if isColored(&Utxo{}) {
+		t.Fatalf("coin without color data shouldn't be colored")
+	}
+	if isColored(&Utxo{ColorData: &lndcc.TxoData{}}) {
+		t.Fatalf("coin without asset id shouldn't be colored")
+	}
+	colored := &Utxo{ColorData: &lndcc.TxoData{AssetId: "asset", Value: 1}}
+	if !isColored(colored) {
+		t.Fatalf("coin with asset id should be colored")
+	}
+}
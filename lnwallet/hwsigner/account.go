@@ -0,0 +1,229 @@
+package hwsigner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+const (
+	// ExternalBranch is the branch of the account receiving addresses
+	// are derived from.
+	ExternalBranch uint32 = 0
+
+	// InternalBranch is the branch of the account change addresses are
+	// derived from.
+	InternalBranch uint32 = 1
+)
+
+// IndexStore persists the number of addresses issued from each branch of an
+// account, ensuring addresses are never reused, and that the keys of all
+// issued addresses can be re-derived upon restart.
+type IndexStore interface {
+	// NextHWAddrIndex returns the index of the next address to derive
+	// from the passed branch, then increments it.
+	NextHWAddrIndex(branch uint32) (uint32, error)
+
+	// NumHWAddrs returns the number of indexes returned by
+	// NextHWAddrIndex for the passed branch.
+	NumHWAddrs(branch uint32) (uint32, error)
+}
+
+// accountKey is a key of the account, along with its derivation from the
+// account key.
+type accountKey struct {
+	pubKey *btcec.PublicKey
+	branch uint32
+	index  uint32
+}
+
+// Account is a BIP 84 account of a hardware wallet. P2WKH addresses are
+// derived from the account's extended public key, while the private keys
+// never leave the device. The account tracks the key of each address it
+// issues, allowing the device to be told how to derive the key which signs
+// an input spending to the address.
+type Account struct {
+	fingerprint [4]byte
+	path        []uint32
+	branches    [2]*hdkeychain.ExtendedKey
+	netParams   *chaincfg.Params
+	indexes     IndexStore
+
+	mtx sync.RWMutex
+
+	// keys maps the pkScript of each address issued by the account to
+	// its key.
+	keys map[string]*accountKey
+}
+
+// NewAccount creates a new Account from the extended public key found at the
+// passed derivation path of the device with the given master key
+// fingerprint. The keys of all addresses previously issued according to the
+// IndexStore are re-derived.
+func NewAccount(fingerprint [4]byte, path []uint32,
+	xpub *hdkeychain.ExtendedKey, netParams *chaincfg.Params,
+	indexes IndexStore) (*Account, error) {
+
+	if xpub.IsPrivate() {
+		return nil, fmt.Errorf("account key must be an extended " +
+			"public key")
+	}
+
+	a := &Account{
+		fingerprint: fingerprint,
+		path:        path,
+		netParams:   netParams,
+		indexes:     indexes,
+		keys:        make(map[string]*accountKey),
+	}
+	for _, branch := range []uint32{ExternalBranch, InternalBranch} {
+		branchKey, err := xpub.Child(branch)
+		if err != nil {
+			return nil, err
+		}
+		a.branches[branch] = branchKey
+
+		numAddrs, err := indexes.NumHWAddrs(branch)
+		if err != nil {
+			return nil, err
+		}
+		for index := uint32(0); index < numAddrs; index++ {
+			_, err := a.derive(branch, index)
+			if err == hdkeychain.ErrInvalidChild {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return a, nil
+}
+
+// derive derives the address at the passed index of the target branch,
+// recording its key.
+func (a *Account) derive(branch, index uint32) (btcutil.Address, error) {
+	child, err := a.branches[branch].Child(index)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := child.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash,
+		a.netParams)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mtx.Lock()
+	a.keys[string(pkScript)] = &accountKey{
+		pubKey: pubKey,
+		branch: branch,
+		index:  index,
+	}
+	a.mtx.Unlock()
+
+	return addr, nil
+}
+
+// NewAddress issues the next unused address of the account, from the
+// internal branch if it's to receive change, otherwise from the external
+// branch.
+func (a *Account) NewAddress(change bool) (btcutil.Address, error) {
+	branch := ExternalBranch
+	if change {
+		branch = InternalBranch
+	}
+
+	for {
+		index, err := a.indexes.NextHWAddrIndex(branch)
+		if err != nil {
+			return nil, err
+		}
+
+		// A small fraction of indexes don't produce a valid child
+		// key, in which case we skip to the next index, as the device
+		// does.
+		addr, err := a.derive(branch, index)
+		if err == hdkeychain.ErrInvalidChild {
+			continue
+		}
+
+		return addr, err
+	}
+}
+
+// IsMine returns true if the passed pkScript pays to an address issued by the
+// account.
+func (a *Account) IsMine(pkScript []byte) bool {
+	_, ok := a.lookup(pkScript)
+	return ok
+}
+
+// lookup returns the key of the address the passed pkScript pays to, if it
+// was issued by the account.
+func (a *Account) lookup(pkScript []byte) (*accountKey, bool) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+
+	key, ok := a.keys[string(pkScript)]
+	return key, ok
+}
+
+// derivation returns the derivation of the passed key from the master key of
+// the device.
+func (a *Account) derivation(key *accountKey) *keyDerivation {
+	path := make([]uint32, 0, len(a.path)+2)
+	path = append(path, a.path...)
+	path = append(path, key.branch, key.index)
+
+	return &keyDerivation{
+		pubKey:      key.pubKey.SerializeCompressed(),
+		fingerprint: a.fingerprint,
+		path:        path,
+	}
+}
+
+// ParsePath parses a BIP 32 derivation path such as m/84'/0'/0', where
+// hardened indexes are marked with either ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	elems := strings.Split(path, "/")
+	if len(elems) == 0 || elems[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m",
+			path)
+	}
+
+	indexes := make([]uint32, 0, len(elems)-1)
+	for _, elem := range elems[1:] {
+		var offset uint32
+		if strings.HasSuffix(elem, "'") || strings.HasSuffix(elem, "h") {
+			offset = hdkeychain.HardenedKeyStart
+			elem = elem[:len(elem)-1]
+		}
+
+		index, err := strconv.ParseUint(elem, 10, 32)
+		if err != nil || uint32(index) >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("invalid index %q within "+
+				"derivation path %q", elem, path)
+		}
+
+		indexes = append(indexes, uint32(index)+offset)
+	}
+
+	return indexes, nil
+}
@@ -0,0 +1,96 @@
+package hwsigner
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// Device is a signer plugin backed by a hardware wallet, or any other device
+// holding private keys outside of the process. Requests are exchanged as
+// PSBTs (BIP 174), so any device able to sign a PSBT can be plugged in.
+type Device interface {
+	// SignPSBT signs each input of the passed serialized PSBT which
+	// carries a BIP 32 derivation of a key held by the device, returning
+	// the PSBT with the resulting partial signatures attached.
+	SignPSBT(psbt []byte) ([]byte, error)
+}
+
+// HWI is an implementation of the Device interface which signs using the HWI
+// command line tool, supporting the hardware wallets HWI does, such as those
+// of Trezor, Ledger, and Coldcard. Each request must be confirmed by the user
+// on the device itself.
+type HWI struct {
+	// Bin is the path of the hwi executable.
+	Bin string
+
+	// Fingerprint is the fingerprint of the master key of the device,
+	// used to select the device should several be connected.
+	Fingerprint [4]byte
+
+	// Chain is the name of the chain the device signs for, as understood
+	// by HWI.
+	Chain string
+}
+
+// A compile time check to ensure that HWI implements the Device interface.
+var _ Device = (*HWI)(nil)
+
+// HWIChain returns the name HWI uses for the chain of the passed network.
+func HWIChain(netParams *chaincfg.Params) (string, error) {
+	switch netParams.Net {
+	case wire.MainNet:
+		return "main", nil
+	case wire.TestNet3:
+		return "test", nil
+	case wire.TestNet:
+		return "regtest", nil
+	default:
+		return "", fmt.Errorf("hardware wallets don't support the %v "+
+			"network", netParams.Name)
+	}
+}
+
+// hwiResponse is the JSON response HWI writes to stdout.
+type hwiResponse struct {
+	PSBT  string `json:"psbt"`
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// SignPSBT hands the passed PSBT to HWI to be signed by the device, blocking
+// until the user has confirmed or rejected the request.
+//
+// This is a part of the Device interface.
+func (h *HWI) SignPSBT(psbt []byte) ([]byte, error) {
+	cmd := exec.Command(h.Bin,
+		"--fingerprint", hex.EncodeToString(h.Fingerprint[:]),
+		"--chain", h.Chain,
+		"signtx", base64.StdEncoding.EncodeToString(psbt),
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to run hwi: %v: %s", err,
+			bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	var resp hwiResponse
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("unable to parse hwi response: %v", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("hwi error %v: %v", resp.Code,
+			resp.Error)
+	}
+
+	return base64.StdEncoding.DecodeString(resp.PSBT)
+}
@@ -0,0 +1,259 @@
+package hwsigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// This file implements the subset of the PSBT format (BIP 174) required to
+// have a device sign the wallet's inputs of a transaction: the unsigned
+// transaction, along with the output each input spends and the derivation of
+// the key which may sign it, is sent to the device, and the resulting partial
+// signatures are read back.
+
+var (
+	// psbtMagic is the magic prefix of every serialized PSBT.
+	psbtMagic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+	// ErrInvalidPSBT is returned when a PSBT can't be decoded.
+	ErrInvalidPSBT = errors.New("invalid psbt")
+)
+
+const (
+	// maxPSBTField is the maximum size of a single key or value within a
+	// PSBT.
+	maxPSBTField = wire.MaxBlockPayload
+
+	psbtGlobalUnsignedTx     = 0x00
+	psbtInWitnessUtxo        = 0x01
+	psbtInPartialSig         = 0x02
+	psbtInBIP32Derivation    = 0x06
+	psbtInFinalScriptWitness = 0x08
+)
+
+// keyDerivation is the BIP 32 derivation of a key from the master key of a
+// device.
+type keyDerivation struct {
+	// pubKey is the serialized compressed public key which is derived.
+	pubKey []byte
+
+	// fingerprint is the fingerprint of the master key.
+	fingerprint [4]byte
+
+	// path is the derivation path of the key from the master key.
+	path []uint32
+}
+
+// psbtInput houses the fields of a PSBT input.
+type psbtInput struct {
+	// witnessUtxo is the output spent by the input.
+	witnessUtxo *wire.TxOut
+
+	// derivation is the derivation of the key which may sign the input.
+	derivation *keyDerivation
+
+	// partialSigs maps a serialized public key to its signature of the
+	// input, including the sighash flag.
+	partialSigs map[string][]byte
+
+	// finalWitness is the complete witness of the input, set by devices
+	// which finalize the inputs they sign.
+	finalWitness wire.TxWitness
+}
+
+// writeKV writes a single key-value pair of a PSBT map.
+func writeKV(w io.Writer, key, value []byte) error {
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// encodePSBT serializes a PSBT spending the inputs of the passed transaction,
+// which are described by the passed PSBT inputs, one per transaction input.
+func encodePSBT(tx *wire.MsgTx, inputs []*psbtInput) ([]byte, error) {
+	if len(inputs) != len(tx.TxIn) {
+		return nil, fmt.Errorf("psbt has %v inputs, transaction has %v",
+			len(inputs), len(tx.TxIn))
+	}
+
+	// The transaction within a PSBT must be unsigned, so we'll strip any
+	// signatures present on a copy of it.
+	unsignedTx := tx.Copy()
+	for _, txIn := range unsignedTx.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+	var txBuf bytes.Buffer
+	if err := unsignedTx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(psbtMagic)
+
+	err := writeKV(&b, []byte{psbtGlobalUnsignedTx}, txBuf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	b.WriteByte(0x00)
+
+	for _, input := range inputs {
+		if input.witnessUtxo != nil {
+			var utxo bytes.Buffer
+			var value [8]byte
+			binary.LittleEndian.PutUint64(value[:],
+				uint64(input.witnessUtxo.Value))
+			utxo.Write(value[:])
+			err := wire.WriteVarBytes(&utxo, 0,
+				input.witnessUtxo.PkScript)
+			if err != nil {
+				return nil, err
+			}
+
+			err = writeKV(&b, []byte{psbtInWitnessUtxo},
+				utxo.Bytes())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if d := input.derivation; d != nil {
+			key := append([]byte{psbtInBIP32Derivation}, d.pubKey...)
+			value := make([]byte, 4+4*len(d.path))
+			copy(value, d.fingerprint[:])
+			for i, index := range d.path {
+				binary.LittleEndian.PutUint32(value[4+4*i:],
+					index)
+			}
+
+			if err := writeKV(&b, key, value); err != nil {
+				return nil, err
+			}
+		}
+
+		b.WriteByte(0x00)
+	}
+
+	// We don't describe any of the outputs, so each output map is empty.
+	for range tx.TxOut {
+		b.WriteByte(0x00)
+	}
+
+	return b.Bytes(), nil
+}
+
+// readKV reads a single key-value pair of a PSBT map. A nil key is returned
+// once the separator terminating the map has been read.
+func readKV(r io.Reader) ([]byte, []byte, error) {
+	key, err := wire.ReadVarBytes(r, 0, maxPSBTField, "psbt key")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(key) == 0 {
+		return nil, nil, nil
+	}
+
+	value, err := wire.ReadVarBytes(r, 0, maxPSBTField, "psbt value")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return key, value, nil
+}
+
+// decodePSBT deserializes the passed PSBT, returning its unsigned transaction
+// along with the partial signatures, and any final witnesses, of its inputs.
+// All other fields are ignored.
+func decodePSBT(psbt []byte) (*wire.MsgTx, []*psbtInput, error) {
+	if !bytes.HasPrefix(psbt, psbtMagic) {
+		return nil, nil, ErrInvalidPSBT
+	}
+	r := bytes.NewReader(psbt[len(psbtMagic):])
+
+	var tx *wire.MsgTx
+	for {
+		key, value, err := readKV(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		if key == nil {
+			break
+		}
+
+		if len(key) == 1 && key[0] == psbtGlobalUnsignedTx {
+			tx = wire.NewMsgTx()
+			err := tx.Deserialize(bytes.NewReader(value))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if tx == nil {
+		return nil, nil, fmt.Errorf("%v: no unsigned transaction",
+			ErrInvalidPSBT)
+	}
+
+	inputs := make([]*psbtInput, len(tx.TxIn))
+	for i := range inputs {
+		input := &psbtInput{
+			partialSigs: make(map[string][]byte),
+		}
+
+		for {
+			key, value, err := readKV(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			if key == nil {
+				break
+			}
+
+			switch key[0] {
+			case psbtInPartialSig:
+				input.partialSigs[string(key[1:])] = value
+
+			case psbtInFinalScriptWitness:
+				witness, err := readWitness(
+					bytes.NewReader(value),
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				input.finalWitness = witness
+			}
+		}
+
+		inputs[i] = input
+	}
+
+	return tx, inputs, nil
+}
+
+// readWitness reads a serialized witness stack.
+func readWitness(r io.Reader) (wire.TxWitness, error) {
+	numItems, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numItems > wire.MaxBlockPayload {
+		return nil, fmt.Errorf("%v: witness has too many items",
+			ErrInvalidPSBT)
+	}
+
+	witness := make(wire.TxWitness, numItems)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(r, 0, maxPSBTField,
+			"witness item")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return witness, nil
+}
@@ -0,0 +1,113 @@
+package hwsigner
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+func TestPSBTRoundTrip(t *testing.T) {
+	tx := wire.NewMsgTx()
+	for i := 0; i < 2; i++ {
+		txIn := wire.NewTxIn(&wire.OutPoint{Index: uint32(i)}, nil, nil)
+		txIn.Witness = wire.TxWitness{{0x01}, {0x02}}
+		tx.AddTxIn(txIn)
+	}
+	tx.AddTxOut(wire.NewTxOut(5000, bytes.Repeat([]byte{0x00}, 22)))
+
+	// Only the second input is described, as only it belongs to the
+	// device.
+	inputs := []*psbtInput{
+		{},
+		{
+			witnessUtxo: wire.NewTxOut(10000,
+				bytes.Repeat([]byte{0x00}, 22)),
+			derivation: &keyDerivation{
+				pubKey:      bytes.Repeat([]byte{0x02}, 33),
+				fingerprint: [4]byte{0xde, 0xad, 0xbe, 0xef},
+				path:        []uint32{0x80000054, 0x80000000, 0, 5},
+			},
+		},
+	}
+	psbt, err := encodePSBT(tx, inputs)
+	if err != nil {
+		t.Fatalf("unable to encode psbt: %v", err)
+	}
+
+	// Append a partial signature to the map of the second input, as a
+	// device would, by replacing the separator terminating it.
+	sig := bytes.Repeat([]byte{0x30}, 71)
+	pubKey := inputs[1].derivation.pubKey
+	var signed bytes.Buffer
+	signed.Write(psbt[:len(psbt)-2])
+	if err := writeKV(&signed, append([]byte{psbtInPartialSig},
+		pubKey...), sig); err != nil {
+		t.Fatalf("unable to write partial sig: %v", err)
+	}
+	signed.Write([]byte{0x00, 0x00})
+
+	unsignedTx, decoded, err := decodePSBT(signed.Bytes())
+	if err != nil {
+		t.Fatalf("unable to decode psbt: %v", err)
+	}
+
+	// The transaction within the PSBT should be stripped of witnesses.
+	if unsignedTx.TxSha() != unsignedTxID(tx) {
+		t.Fatalf("decoded transaction doesn't match original")
+	}
+	for _, txIn := range unsignedTx.TxIn {
+		if len(txIn.Witness) != 0 {
+			t.Fatalf("psbt transaction has witness")
+		}
+	}
+
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 inputs, instead have %v", len(decoded))
+	}
+	if len(decoded[0].partialSigs) != 0 {
+		t.Fatalf("first input shouldn't be signed")
+	}
+	if !bytes.Equal(decoded[1].partialSigs[string(pubKey)], sig) {
+		t.Fatalf("partial sig of second input doesn't match")
+	}
+
+	if _, _, err := decodePSBT(psbt[1:]); err != ErrInvalidPSBT {
+		t.Fatalf("expected ErrInvalidPSBT, instead got %v", err)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	h := hdkeychain.HardenedKeyStart
+	tests := []struct {
+		path     string
+		expected []uint32
+		valid    bool
+	}{
+		{"m", []uint32{}, true},
+		{"m/84'/1'/0'", []uint32{h + 84, h + 1, h}, true},
+		{"m/84h/0h/0h/1/7", []uint32{h + 84, h, h, 1, 7}, true},
+		{"84'/0'/0'", nil, false},
+		{"m/2147483648", nil, false},
+		{"m/x'", nil, false},
+	}
+
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if !test.valid {
+			if err == nil {
+				t.Fatalf("path %q should be invalid", test.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unable to parse path %q: %v", test.path, err)
+		}
+		if !reflect.DeepEqual(path, test.expected) {
+			t.Fatalf("path %q parsed as %v, expected %v",
+				test.path, path, test.expected)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package hwsigner
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// Signer is an implementation of the lnwallet.Signer interface which has a
+// hardware wallet sign all inputs spending the funds of its account. All
+// other requests, including every signature made with a channel key, are
+// handled by the wrapped software signer, so channel operations never require
+// the device.
+type Signer struct {
+	// Signer is the software signer which handles all requests not
+	// involving the hardware wallet account.
+	lnwallet.Signer
+
+	device  Device
+	account *Account
+
+	// fetchInput returns the output spent by an input of a transaction
+	// being signed. It allows all of the account's inputs of a
+	// transaction to be signed by the device at once, rather than the
+	// user having to confirm each input separately.
+	fetchInput func(*wire.OutPoint) (*wire.TxOut, error)
+
+	mtx sync.Mutex
+
+	// signedTx is the hash of the unsigned form of the last transaction
+	// signed by the device, and signedInputs are the input scripts the
+	// device produced for it, keyed by input index.
+	signedTx     wire.ShaHash
+	signedInputs map[int]*lnwallet.InputScript
+}
+
+// A compile time check to ensure that Signer implements the lnwallet.Signer
+// interface.
+var _ lnwallet.Signer = (*Signer)(nil)
+
+// NewSigner creates a new Signer which has the passed device sign for the
+// inputs spending the funds of the given account. The passed fetchInput
+// function, typically the FetchInputInfo method of the wallet, is used to
+// look up the outputs spent by the inputs of a transaction.
+func NewSigner(signer lnwallet.Signer, device Device, account *Account,
+	fetchInput func(*wire.OutPoint) (*wire.TxOut, error)) *Signer {
+
+	return &Signer{
+		Signer:     signer,
+		device:     device,
+		account:    account,
+		fetchInput: fetchInput,
+	}
+}
+
+// unsignedTxID returns the hash of the passed transaction stripped of any
+// signatures. Unlike the txid, it remains unchanged as the inputs of the
+// transaction are signed one by one.
+func unsignedTxID(tx *wire.MsgTx) wire.ShaHash {
+	unsignedTx := tx.Copy()
+	for _, txIn := range unsignedTx.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+
+	return unsignedTx.TxSha()
+}
+
+// ComputeInputScript generates a complete input script for the passed
+// transaction. If the input spends the funds of the hardware wallet account,
+// then the device is asked to sign it. Otherwise, the request is handled by
+// the software signer.
+//
+// This is a part of the lnwallet.Signer interface.
+func (s *Signer) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	if !s.account.IsMine(signDesc.Output.PkScript) {
+		return s.Signer.ComputeInputScript(tx, signDesc)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	txid := unsignedTxID(tx)
+	if s.signedInputs == nil || s.signedTx != txid {
+		signedInputs, err := s.signTx(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+
+		s.signedTx = txid
+		s.signedInputs = signedInputs
+	}
+
+	inputScript, ok := s.signedInputs[signDesc.InputIndex]
+	if !ok {
+		return nil, fmt.Errorf("device didn't sign input %v",
+			signDesc.InputIndex)
+	}
+
+	return inputScript, nil
+}
+
+// signTx has the device sign every input of the passed transaction spending
+// the funds of the account, returning the resulting input scripts keyed by
+// input index. The output spent by the input the passed sign descriptor
+// targets is taken from the descriptor, the outputs spent by the remaining
+// inputs are looked up.
+func (s *Signer) signTx(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (map[int]*lnwallet.InputScript, error) {
+
+	inputs := make([]*psbtInput, len(tx.TxIn))
+	prevOuts := make([]*wire.TxOut, len(tx.TxIn))
+	keys := make(map[int]*accountKey)
+	for i, txIn := range tx.TxIn {
+		inputs[i] = &psbtInput{}
+
+		prevOut := signDesc.Output
+		if i != signDesc.InputIndex {
+			var err error
+			prevOut, err = s.fetchInput(&txIn.PreviousOutPoint)
+			if err == lnwallet.ErrNotMine {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		key, ok := s.account.lookup(prevOut.PkScript)
+		if !ok {
+			continue
+		}
+
+		inputs[i].witnessUtxo = prevOut
+		inputs[i].derivation = s.account.derivation(key)
+		prevOuts[i] = prevOut
+		keys[i] = key
+	}
+
+	psbt, err := encodePSBT(tx, inputs)
+	if err != nil {
+		return nil, err
+	}
+	signedPSBT, err := s.device.SignPSBT(psbt)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, signedInputs, err := decodePSBT(signedPSBT)
+	if err != nil {
+		return nil, err
+	}
+	if signedTx.TxSha() != unsignedTxID(tx) {
+		return nil, fmt.Errorf("device signed a different transaction")
+	}
+
+	// With the signatures obtained, we'll assemble the witness of each
+	// input, then verify it against the output it spends, as a device
+	// must never be trusted to have signed what it was asked to.
+	hashCache := txscript.NewTxSigHashes(tx)
+	inputScripts := make(map[int]*lnwallet.InputScript, len(keys))
+	for i, key := range keys {
+		pubKey := key.pubKey.SerializeCompressed()
+
+		witness := signedInputs[i].finalWitness
+		if sig, ok := signedInputs[i].partialSigs[string(pubKey)]; ok {
+			witness = wire.TxWitness{sig, pubKey}
+		}
+		if len(witness) != 2 || !bytes.Equal(witness[1], pubKey) {
+			return nil, fmt.Errorf("device didn't sign input %v", i)
+		}
+
+		verifyTx := tx.Copy()
+		verifyTx.TxIn[i].SignatureScript = nil
+		verifyTx.TxIn[i].Witness = witness
+		vm, err := txscript.NewEngine(prevOuts[i].PkScript, verifyTx,
+			i, txscript.StandardVerifyFlags, nil, hashCache,
+			prevOuts[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		if err := vm.Execute(); err != nil {
+			return nil, fmt.Errorf("device produced an invalid "+
+				"signature for input %v: %v", i, err)
+		}
+
+		inputScripts[i] = &lnwallet.InputScript{
+			Witness: witness,
+		}
+	}
+
+	return inputScripts, nil
+}
@@ -0,0 +1,162 @@
+package hwsigner
+
+import (
+	"errors"
+
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcutil"
+)
+
+// ErrUnsupportedAddrType is returned when an address of a type other than
+// P2WKH is requested from a wallet whose funds are held by a hardware wallet.
+var ErrUnsupportedAddrType = errors.New("hardware wallet accounts only " +
+	"support p2wkh addresses")
+
+// Wallet is an implementation of the lnwallet.WalletController interface
+// whose on-chain funds are held by a hardware wallet account. Every new
+// address, including those receiving change and our balance upon the
+// cooperative close of a channel, is issued by the account, then imported
+// into the wrapped wallet as watch-only so its outputs are tracked. The
+// outputs are offered to coin selection alongside those of the wrapped
+// wallet, to be signed by the device via a Signer. The keys of channels are
+// still derived by the wrapped wallet.
+type Wallet struct {
+	// WalletController is the wrapped software wallet, which tracks the
+	// outputs of the account.
+	lnwallet.WalletController
+
+	account *Account
+}
+
+// A compile time check to ensure that Wallet implements the
+// lnwallet.WalletController interface.
+var _ lnwallet.WalletController = (*Wallet)(nil)
+
+// NewWallet creates a new Wallet whose funds are held by the passed hardware
+// wallet account, tracked by the given software wallet.
+func NewWallet(wallet lnwallet.WalletController, account *Account) *Wallet {
+	return &Wallet{
+		WalletController: wallet,
+		account:          account,
+	}
+}
+
+// NewAddress issues the next address of the hardware wallet account, then
+// imports it into the wrapped wallet. Only P2WKH addresses are supported.
+//
+// This is a part of the lnwallet.WalletController interface.
+func (w *Wallet) NewAddress(addrType lnwallet.AddressType,
+	change bool) (btcutil.Address, error) {
+
+	if addrType != lnwallet.WitnessPubKey {
+		return nil, ErrUnsupportedAddrType
+	}
+
+	addr, err := w.account.NewAddress(change)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.WalletController.ImportAddress(addr); err != nil {
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// accountOutputs returns the unspent outputs of the hardware wallet account
+// with at least confirms confirmations.
+func (w *Wallet) accountOutputs(confirms int32) ([]*lnwallet.Utxo, error) {
+	watched, err := w.WalletController.ListUnspentWatchOnly(confirms)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*lnwallet.Utxo, 0, len(watched))
+	for _, utxo := range watched {
+		if w.account.IsMine(utxo.PkScript) {
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	return utxos, nil
+}
+
+// ListUnspentWitness returns the unspent witness outputs of both the wrapped
+// wallet and the hardware wallet account.
+//
+// This is a part of the lnwallet.WalletController interface.
+func (w *Wallet) ListUnspentWitness(confirms int32) ([]*lnwallet.Utxo, error) {
+	utxos, err := w.WalletController.ListUnspentWitness(confirms)
+	if err != nil {
+		return nil, err
+	}
+	accountUtxos, err := w.accountOutputs(confirms)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(utxos, accountUtxos...), nil
+}
+
+// ConfirmedBalance returns the balance of the wrapped wallet, plus that of the
+// hardware wallet account.
+//
+// This is a part of the lnwallet.WalletController interface.
+func (w *Wallet) ConfirmedBalance(confs int32,
+	witness bool) (btcutil.Amount, error) {
+
+	balance, err := w.WalletController.ConfirmedBalance(confs, witness)
+	if err != nil {
+		return 0, err
+	}
+	accountUtxos, err := w.accountOutputs(confs)
+	if err != nil {
+		return 0, err
+	}
+	for _, utxo := range accountUtxos {
+		balance += utxo.Value
+	}
+
+	return balance, nil
+}
+
+// WatchOnlyBalance returns the balance of the watch-only addresses of the
+// wrapped wallet, excluding those of the hardware wallet account, as the
+// account's funds are spendable.
+//
+// This is a part of the lnwallet.WalletController interface.
+func (w *Wallet) WatchOnlyBalance(confs int32) (btcutil.Amount, error) {
+	balance, err := w.WalletController.WatchOnlyBalance(confs)
+	if err != nil {
+		return 0, err
+	}
+	accountUtxos, err := w.accountOutputs(confs)
+	if err != nil {
+		return 0, err
+	}
+	for _, utxo := range accountUtxos {
+		balance -= utxo.Value
+	}
+
+	return balance, nil
+}
+
+// ListUnspentWatchOnly returns the unspent outputs of the watch-only addresses
+// of the wrapped wallet, excluding those of the hardware wallet account.
+//
+// This is a part of the lnwallet.WalletController interface.
+func (w *Wallet) ListUnspentWatchOnly(confirms int32) ([]*lnwallet.Utxo, error) {
+	watched, err := w.WalletController.ListUnspentWatchOnly(confirms)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*lnwallet.Utxo, 0, len(watched))
+	for _, utxo := range watched {
+		if !w.account.IsMine(utxo.PkScript) {
+			utxos = append(utxos, utxo)
+		}
+	}
+
+	return utxos, nil
+}
@@ -38,6 +38,84 @@ type Utxo struct {
 	Value     btcutil.Amount
 	ColorData *lndcc.TxoData
 	wire.OutPoint
+
+	// PkScript is the public key script the output pays to.
+	PkScript []byte
+
+	// Confirmations is the number of confirmations the output's
+	// transaction has, or zero if it's still unconfirmed.
+	Confirmations int64
+}
+
+// WalletAddress is an address derived by the wallet, along with whether it
+// was derived as a change address.
+type WalletAddress struct {
+	Address btcutil.Address
+	Change  bool
+}
+
+// TransactionDetail describes a transaction with either inputs which belong to
+// the wallet, or has outputs that pay to the wallet.
+type TransactionDetail struct {
+	// Hash is the transaction hash of the transaction.
+	Hash wire.ShaHash
+
+	// Value is the net value of this transaction (in satoshis) from the
+	// PoV of the wallet. If this transaction purely spends from the
+	// wallet's funds, then this value will be negative. Similarly, if this
+	// transaction credits the wallet, then this value will be positive.
+	Value btcutil.Amount
+
+	// NumConfirmations is the number of confirmations this transaction
+	// has. If the transaction is unconfirmed, then this value will be
+	// zero.
+	NumConfirmations int32
+
+	// BlockHash is the hash of the block which includes this
+	// transaction. Unconfirmed transactions will have a nil value for
+	// this field.
+	BlockHash *wire.ShaHash
+
+	// BlockHeight is the height of the block including this transaction.
+	// Unconfirmed transaction will show a height of zero.
+	BlockHeight int32
+
+	// Timestamp is the unix timestamp of the block including this
+	// transaction. If the transaction is unconfirmed, then this will be a
+	// timestamp of the time the wallet first became aware of it.
+	Timestamp int64
+
+	// TotalFees is the total fee in satoshis paid by this transaction.
+	// Fees are only known for transactions which spend exclusively from
+	// the wallet, otherwise this value will be zero.
+	TotalFees int64
+
+	// Label is an optional free-form description attached to the
+	// transaction by the wallet.
+	Label string
+
+	// WatchOnly denotes that the transaction only credits or debits
+	// addresses imported as watch-only, rather than addresses the wallet
+	// is able to spend from. In this case, Value is the net change in the
+	// watch-only balance.
+	WatchOnly bool
+}
+
+// TransactionSubscription is an interface which describes an object capable of
+// receiving notifications of new transaction related to the underlying wallet.
+// TODO(roasbeef): add balance updates?
+type TransactionSubscription interface {
+	// ConfirmedTransactions returns a channel which will be sent on as new
+	// relevant transactions are confirmed.
+	ConfirmedTransactions() chan *TransactionDetail
+
+	// UnconfirmedTransactions returns a channel which will be sent on as
+	// new relevant transactions are seen within the network.
+	UnconfirmedTransactions() chan *TransactionDetail
+
+	// Cancel finalizes the subscription, cleaning up any resources
+	// allocated.
+	Cancel()
 }
 
 // WalletController defines an abstract interface for controlling a local Pure
@@ -71,6 +149,10 @@ type WalletController interface {
 	// p2wkh, p2wsh, etc.
 	NewAddress(addrType AddressType, change bool) (btcutil.Address, error)
 
+	// ListAddresses returns every address the wallet has derived so far,
+	// both external and change addresses.
+	ListAddresses() ([]*WalletAddress, error)
+
 	// GetPrivKey retrives the underlying private key associated with the
 	// passed address. If the wallet is unable to locate this private key
 	// due to the address not being under control of the wallet, then an
@@ -117,6 +199,59 @@ type WalletController interface {
 	// then finally broadcasts the passed transaction to the Bitcoin network.
 	PublishTransaction(tx *wire.MsgTx) error
 
+	// ListTransactionDetails returns a list of all transactions which are
+	// relevant to the wallet, including unconfirmed transactions currently
+	// within the mempool.
+	ListTransactionDetails() ([]*TransactionDetail, error)
+
+	// SubscribeTransactions returns a TransactionSubscription client which
+	// is capable of receiving async notifications as new transactions
+	// related to the wallet are seen within the network, or found in
+	// blocks.
+	//
+	// NOTE: a non-nil error should be returned if notifications aren't
+	// supported.
+	SubscribeTransactions() (TransactionSubscription, error)
+
+	// LabelTransaction attaches the passed label to the target
+	// transaction, which is then returned within the TransactionDetail for
+	// the transaction. If the transaction is already labelled, then
+	// ErrTxLabelExists should be returned unless overwrite is true.
+	LabelTransaction(txid wire.ShaHash, label string, overwrite bool) error
+
+	// ImportPublicKey imports the passed public key into the wallet as
+	// watch-only. The address of the target type corresponding to the key
+	// is returned. The wallet will track the balance of, and transactions
+	// related to, this address, but won't be able to spend from it.
+	ImportPublicKey(pubKey *btcec.PublicKey,
+		addrType AddressType) (btcutil.Address, error)
+
+	// ImportAddress imports the passed address into the wallet as
+	// watch-only. The wallet will track the balance of, and transactions
+	// related to, this address, but won't be able to spend from it.
+	ImportAddress(addr btcutil.Address) error
+
+	// WatchOnlyBalance returns the sum of all unspent outputs paying to
+	// watch-only addresses that have at least confs confirmations.
+	WatchOnlyBalance(confs int32) (btcutil.Amount, error)
+
+	// ListUnspentWatchOnly returns all unspent outputs paying to
+	// watch-only addresses that have at least confirms confirmations.
+	ListUnspentWatchOnly(confirms int32) ([]*Utxo, error)
+
+	// Rescan scans the chain from startHeight up to the current tip for
+	// transactions relevant to the wallet, including those related to
+	// watch-only addresses. The progress callback is periodically called
+	// with the height the rescan has reached.
+	Rescan(startHeight int32, progress func(int32)) error
+
+	// RecoveryProgress reports whether the wallet was restored from an
+	// existing seed during this run, whether the scan of the chain for
+	// its funds has finished, and the fraction of the chain scanned so
+	// far. If the scan failed, then the error which halted it is
+	// returned.
+	RecoveryProgress() (bool, bool, float64, error)
+
 	// Start initializes the wallet, making any neccessary connections,
 	// starting up required goroutines etc.
 	Start() error
@@ -145,6 +280,14 @@ type BlockChainIO interface {
 	// GetTransaction returns the full transaction identified by the passed
 	// transaction ID.
 	GetTransaction(txid *wire.ShaHash) (*wire.MsgTx, error)
+
+	// GetBlockHash returns the hash of the block in the best blockchain
+	// at the given height.
+	GetBlockHash(blockHeight int64) (*wire.ShaHash, error)
+
+	// GetBlock returns the block in the main chain identified by the given
+	// hash.
+	GetBlock(blockHash *wire.ShaHash) (*wire.MsgBlock, error)
 }
 
 // SignDescriptor houses the necessary information required to succesfully sign
@@ -470,7 +470,7 @@ func testDualFundingReservationWorkflow(miner *rpctest.Harness, wallet *lnwallet
 
 	// Now that the channel is open, execute a cooperative closure of the
 	// now open channel.
-	aliceCloseSig, _, err := lnc.InitCooperativeClose()
+	aliceCloseSig, _, err := lnc.InitCooperativeClose(nil)
 	if err != nil {
 		t.Fatalf("unable to init cooperative closure: %v", err)
 	}
@@ -837,7 +837,7 @@ func testSingleFunderReservationWorkflowResponder(miner *rpctest.Harness,
 	fundingTxIn := wire.NewTxIn(fundingOutpoint, nil, nil)
 	aliceCommitTx, err := lnwallet.CreateCommitTx(fundingTxIn, ourContribution.CommitKey,
 		bobContribution.CommitKey, ourContribution.RevocationKey,
-		ourContribution.CsvDelay, 0, capacity)
+		ourContribution.CsvDelay, 0, 0, 0, capacity)
 	if err != nil {
 		t.Fatalf("unable to create alice's commit tx: %v", err)
 	}
@@ -872,12 +872,247 @@ func testSingleFunderReservationWorkflowResponder(miner *rpctest.Harness,
 	// TODO(roasbeef): bob verify alice's sig
 }
 
+func testTransactionLabels(miner *rpctest.Harness,
+	wallet *lnwallet.LightningWallet, t *testing.T) {
+
+	// The wallet was funded by the miner during set up, so it should
+	// already be aware of several transactions which credited it.
+	txns, err := wallet.ListTransactionDetails()
+	if err != nil {
+		t.Fatalf("unable to list transactions: %v", err)
+	}
+	if len(txns) == 0 {
+		t.Fatalf("wallet should have at least one transaction")
+	}
+	txid := txns[0].Hash
+
+	// Attempting to attach an empty label should be rejected.
+	err = wallet.LabelTransaction(txid, "", false)
+	if err != lnwallet.ErrEmptyTxLabel {
+		t.Fatalf("expected ErrEmptyTxLabel, instead got: %v", err)
+	}
+
+	// Label the transaction, the label should then be reflected in the
+	// transaction details returned by the wallet.
+	assertLabel := func(label string) {
+		txns, err := wallet.ListTransactionDetails()
+		if err != nil {
+			t.Fatalf("unable to list transactions: %v", err)
+		}
+		for _, tx := range txns {
+			if tx.Hash != txid {
+				continue
+			}
+			if tx.Label != label {
+				t.Fatalf("label mismatch: expected %q, got %q",
+					label, tx.Label)
+			}
+			return
+		}
+		t.Fatalf("transaction %v not found", txid)
+	}
+	if err := wallet.LabelTransaction(txid, "first", false); err != nil {
+		t.Fatalf("unable to label transaction: %v", err)
+	}
+	assertLabel("first")
+
+	// A second label shouldn't replace the first unless an overwrite is
+	// explicitly requested.
+	err = wallet.LabelTransaction(txid, "second", false)
+	if err != lnwallet.ErrTxLabelExists {
+		t.Fatalf("expected ErrTxLabelExists, instead got: %v", err)
+	}
+	assertLabel("first")
+
+	if err := wallet.LabelTransaction(txid, "second", true); err != nil {
+		t.Fatalf("unable to overwrite label: %v", err)
+	}
+	assertLabel("second")
+}
+
+func testOutputLeases(miner *rpctest.Harness,
+	wallet *lnwallet.LightningWallet, t *testing.T) {
+
+	utxos, err := wallet.ListUnspentWitness(1)
+	if err != nil {
+		t.Fatalf("unable to list unspent outputs: %v", err)
+	}
+	if len(utxos) == 0 {
+		t.Fatalf("wallet should have at least one output")
+	}
+	op := utxos[0].OutPoint
+
+	leaseID := lnwallet.LeaseID{1}
+	otherID := lnwallet.LeaseID{2}
+
+	if _, err := wallet.LeaseOutput(leaseID, op, time.Minute); err != nil {
+		t.Fatalf("unable to lease output: %v", err)
+	}
+
+	// The output should now be reported as leased, and shouldn't be
+	// leasable under a different lease ID.
+	leases := wallet.ListLeases()
+	if len(leases) != 1 || leases[0].OutPoint != op {
+		t.Fatalf("expected single lease for %v, instead got %v", op,
+			leases)
+	}
+	_, err = wallet.LeaseOutput(otherID, op, time.Minute)
+	if err != lnwallet.ErrOutputAlreadyLeased {
+		t.Fatalf("expected ErrOutputAlreadyLeased, instead got: %v", err)
+	}
+
+	// Only the lease holder should be able to release the output.
+	err = wallet.ReleaseOutput(otherID, op)
+	if err != lnwallet.ErrLeaseIDMismatch {
+		t.Fatalf("expected ErrLeaseIDMismatch, instead got: %v", err)
+	}
+	if err := wallet.ReleaseOutput(leaseID, op); err != nil {
+		t.Fatalf("unable to release output: %v", err)
+	}
+	if len(wallet.ListLeases()) != 0 {
+		t.Fatalf("no leases should remain")
+	}
+	err = wallet.ReleaseOutput(leaseID, op)
+	if err != lnwallet.ErrOutputNotLeased {
+		t.Fatalf("expected ErrOutputNotLeased, instead got: %v", err)
+	}
+
+	// Finally, a lease should lapse once its expiration has passed.
+	if _, err := wallet.LeaseOutput(leaseID, op, time.Millisecond); err != nil {
+		t.Fatalf("unable to lease output: %v", err)
+	}
+	time.Sleep(time.Millisecond * 10)
+	if len(wallet.ListLeases()) != 0 {
+		t.Fatalf("lease should have expired")
+	}
+}
+
+func testListUnspentAndAddresses(miner *rpctest.Harness,
+	wallet *lnwallet.LightningWallet, t *testing.T) {
+
+	addrs, err := wallet.ListAddresses()
+	if err != nil {
+		t.Fatalf("unable to list addresses: %v", err)
+	}
+	walletAddrs := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		walletAddrs[addr.Address.String()] = struct{}{}
+	}
+
+	utxos, err := wallet.ListUnspentWitness(1)
+	if err != nil {
+		t.Fatalf("unable to list unspent outputs: %v", err)
+	}
+	if len(utxos) == 0 {
+		t.Fatalf("wallet should have at least one output")
+	}
+
+	// Each confirmed output should report its confirmations, and pay to
+	// one of the addresses derived by the wallet.
+	for _, utxo := range utxos {
+		if utxo.Confirmations < 1 {
+			t.Fatalf("output %v should be confirmed, instead has "+
+				"%v confirmations", utxo.OutPoint,
+				utxo.Confirmations)
+		}
+
+		_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(
+			utxo.PkScript, &chaincfg.SimNetParams)
+		if err != nil {
+			t.Fatalf("unable to extract address: %v", err)
+		}
+		if len(outAddrs) != 1 {
+			t.Fatalf("expected single address for output %v",
+				utxo.OutPoint)
+		}
+		if _, ok := walletAddrs[outAddrs[0].String()]; !ok {
+			t.Fatalf("address %v of output %v not listed by "+
+				"wallet", outAddrs[0], utxo.OutPoint)
+		}
+	}
+}
+
 func testFundingReservationInvalidCounterpartySigs(miner *rpctest.Harness, lnwallet *lnwallet.LightningWallet, t *testing.T) {
 }
 
 func testFundingTransactionTxFees(miner *rpctest.Harness, lnwallet *lnwallet.LightningWallet, t *testing.T) {
 }
 
+func testWalletRecovery(miner *rpctest.Harness,
+	w *lnwallet.LightningWallet, t *testing.T) {
+
+	// The wallet under test was created without a recovery window, so it
+	// mustn't be in recovery.
+	if inRecovery, _, _, _ := w.RecoveryProgress(); inRecovery {
+		t.Fatalf("new wallet in recovery")
+	}
+
+	// A new wallet restored from the seed of the wallet under test should
+	// scan the chain for its funds, then report the same balance.
+	tempTestDir, err := ioutil.TempDir("", "lnwallet-recovery")
+	if err != nil {
+		t.Fatalf("unable to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempTestDir)
+
+	rpcConfig := miner.RPCConfig()
+	restored, err := btcwallet.New(&btcwallet.Config{
+		PrivatePass:    privPass,
+		HdSeed:         testHdSeed[:],
+		RecoveryWindow: 200,
+		DataDir:        tempTestDir,
+		NetParams:      &chaincfg.SimNetParams,
+		RpcHost:        rpcConfig.Host,
+		RpcUser:        rpcConfig.User,
+		RpcPass:        rpcConfig.Pass,
+		CACert:         rpcConfig.Certificates,
+	})
+	if err != nil {
+		t.Fatalf("unable to create restored wallet: %v", err)
+	}
+	if err := restored.Start(); err != nil {
+		t.Fatalf("unable to start restored wallet: %v", err)
+	}
+	defer restored.Stop()
+
+	timeout := time.After(30 * time.Second)
+	for {
+		inRecovery, finished, progress, err := restored.RecoveryProgress()
+		if err != nil {
+			t.Fatalf("recovery failed: %v", err)
+		}
+		if !inRecovery {
+			t.Fatalf("restored wallet not in recovery")
+		}
+		if finished {
+			if progress != 1 {
+				t.Fatalf("recovery finished at progress %v",
+					progress)
+			}
+			break
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("recovery not finished, progress %v", progress)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	expectedBalance, err := w.ConfirmedBalance(1, false)
+	if err != nil {
+		t.Fatalf("unable to get balance: %v", err)
+	}
+	balance, err := restored.ConfirmedBalance(1, false)
+	if err != nil {
+		t.Fatalf("unable to get restored balance: %v", err)
+	}
+	if balance != expectedBalance {
+		t.Fatalf("expected restored balance %v, got %v",
+			expectedBalance, balance)
+	}
+}
+
 var walletTests = []func(miner *rpctest.Harness, w *lnwallet.LightningWallet, test *testing.T){
 	testDualFundingReservationWorkflow,
 	testSingleFunderReservationWorkflowInitiator,
@@ -885,6 +1120,10 @@ var walletTests = []func(miner *rpctest.Harness, w *lnwallet.LightningWallet, te
 	testFundingTransactionLockedOutputs,
 	testFundingCancellationNotEnoughFunds,
 	testFundingReservationInvalidCounterpartySigs,
+	testTransactionLabels,
+	testOutputLeases,
+	testListUnspentAndAddresses,
+	testWalletRecovery,
 }
 
 type testLnWallet struct {
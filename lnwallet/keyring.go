@@ -0,0 +1,140 @@
+package lnwallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+// ErrNoPrivateKeys is returned when a private key is requested from a wallet
+// running in watch-only mode, which delegates all operations requiring
+// private keys to a remote signer.
+var ErrNoPrivateKeys = errors.New("wallet is watch-only and holds no " +
+	"private keys")
+
+// RootKeyRing is responsible for deriving the Lightning specific secrets of
+// the wallet from its root HD key. Abstracting these derivations behind an
+// interface allows the root key to reside outside of the process, e.g. within
+// a remote signer, while the LightningWallet holds only public keys.
+type RootKeyRing interface {
+	// KeyRing allows the public keys of the keychain, which is derived
+	// from the same root, to be derived.
+	keychain.KeyRing
+
+	// IdentityPubKey returns the public key of the node's long-term
+	// identity key.
+	IdentityPubKey() (*btcec.PublicKey, error)
+
+	// MasterElkremRoot returns the private key which serves as the master
+	// elkrem root. This master secret is used as the secret input to a
+	// HKDF to generate elkrem secrets based on random, but public data.
+	MasterElkremRoot() (*btcec.PrivateKey, error)
+}
+
+// hdKeyRing is an implementation of the RootKeyRing interface backed by a
+// root HD key held in memory.
+type hdKeyRing struct {
+	// HDKeyRing derives the keys of the keychain from the rootKey.
+	*keychain.HDKeyRing
+
+	// rootKey is the root HD key dervied from a WalletController private
+	// key. This rootKey is used to derive all LN specific secrets.
+	rootKey *hdkeychain.ExtendedKey
+}
+
+// A compile time check to ensure that hdKeyRing implements the RootKeyRing
+// interface.
+var _ RootKeyRing = (*hdKeyRing)(nil)
+
+// newHDKeyRing creates a new hdKeyRing from the root key of the passed
+// WalletController.
+func newHDKeyRing(wallet WalletController,
+	netParams *chaincfg.Params) (*hdKeyRing, error) {
+
+	// Fetch the root derivation key from the wallet's HD chain. We'll use
+	// this to generate specific Lightning related secrets on the fly.
+	rootKey, err := wallet.FetchRootKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO(roasbeef): always re-derive on the fly?
+	rootKeyRaw := rootKey.Serialize()
+	rootMasterKey, err := hdkeychain.NewMaster(rootKeyRaw, netParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hdKeyRing{
+		HDKeyRing: keychain.NewHDKeyRing(rootMasterKey,
+			netParams.HDCoinType),
+		rootKey: rootMasterKey,
+	}, nil
+}
+
+// identityKey derives the node's long-term identity private key.
+func (h *hdKeyRing) identityKey() (*btcec.PrivateKey, error) {
+	return h.DerivePrivKey(keychain.KeyLocator{
+		Family: keychain.KeyFamilyNodeKey,
+	})
+}
+
+// IdentityPubKey returns the public key of the node's long-term identity key.
+//
+// This is a part of the RootKeyRing interface.
+func (h *hdKeyRing) IdentityPubKey() (*btcec.PublicKey, error) {
+	identityKey, err := h.identityKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return identityKey.PubKey(), nil
+}
+
+// MasterElkremRoot derives the private key which serves as the master elkrem
+// root.
+//
+// This is a part of the RootKeyRing interface.
+func (h *hdKeyRing) MasterElkremRoot() (*btcec.PrivateKey, error) {
+	masterElkremRoot, err := h.rootKey.Child(elkremRootIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return masterElkremRoot.ECPrivKey()
+}
+
+// VerifyKeyRing ensures that the passed RootKeyRing derives the same identity
+// key as the root key of the passed WalletController. This is used to confirm
+// that a wallet is a copy of a remote signer's wallet before stripping it of
+// its private keys.
+func VerifyKeyRing(wallet WalletController, keyRing RootKeyRing,
+	netParams *chaincfg.Params) error {
+
+	localKeys, err := newHDKeyRing(wallet, netParams)
+	if err != nil {
+		return err
+	}
+	localPub, err := localKeys.IdentityPubKey()
+	if err != nil {
+		return err
+	}
+	remotePub, err := keyRing.IdentityPubKey()
+	if err != nil {
+		return err
+	}
+
+	localKey := localPub.SerializeCompressed()
+	remoteKey := remotePub.SerializeCompressed()
+	if !bytes.Equal(localKey, remoteKey) {
+		return fmt.Errorf("wallet identity key %x doesn't match key "+
+			"ring identity key %x", localKey, remoteKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// MaxTxLabelLength is the maximum length, in bytes, of a label which can be
+// attached to a transaction.
+const MaxTxLabelLength = 500
+
+var (
+	// ErrEmptyTxLabel is returned when an attempt is made to attach an
+	// empty label to a transaction.
+	ErrEmptyTxLabel = errors.New("cannot label transaction with empty " +
+		"label")
+
+	// ErrTxLabelTooLong is returned when an attempt is made to attach a
+	// label which exceeds MaxTxLabelLength to a transaction.
+	ErrTxLabelTooLong = fmt.Errorf("transaction label exceeds max "+
+		"length of %v bytes", MaxTxLabelLength)
+
+	// ErrTxLabelExists is returned when an attempt is made to label a
+	// transaction which already carries a label without requesting that
+	// the existing label be overwritten.
+	ErrTxLabelExists = errors.New("transaction already labelled")
+)
+
+// SweepTxLabel is the label applied to transactions which sweep the outputs
+// of a force closed channel back into the wallet once they've matured.
+const SweepTxLabel = "lnd:sweep"
+
+// ConsolidationTxLabel is the label applied to transactions which merge the
+// wallet's small outputs into a single output.
+const ConsolidationTxLabel = "lnd:consolidate"
+
+// ValidateTxLabel ensures the passed label is suitable to be attached to a
+// transaction.
+func ValidateTxLabel(label string) error {
+	switch {
+	case len(label) == 0:
+		return ErrEmptyTxLabel
+	case len(label) > MaxTxLabelLength:
+		return ErrTxLabelTooLong
+	}
+
+	return nil
+}
+
+// FundingTxLabel returns the label applied to the funding transaction of the
+// channel identified by the passed outpoint.
+func FundingTxLabel(chanPoint *wire.OutPoint) string {
+	return fmt.Sprintf("lnd:open:%v", chanPoint)
+}
+
+// CooperativeCloseTxLabel returns the label applied to the cooperative
+// closing transaction of the channel identified by the passed outpoint.
+func CooperativeCloseTxLabel(chanPoint *wire.OutPoint) string {
+	return fmt.Sprintf("lnd:coopclose:%v", chanPoint)
+}
+
+// FundingReclaimTxLabel returns the label applied to the transaction which
+// double spends the inputs of the funding transaction of the channel
+// identified by the passed outpoint, after it failed to confirm.
+func FundingReclaimTxLabel(chanPoint *wire.OutPoint) string {
+	return fmt.Sprintf("lnd:reclaim:%v", chanPoint)
+}
+
+// FundingFeeBumpTxLabel returns the label applied to the child transaction
+// which raises the fee rate of the funding transaction of the channel
+// identified by the passed outpoint.
+func FundingFeeBumpTxLabel(chanPoint *wire.OutPoint) string {
+	return fmt.Sprintf("lnd:bump:%v", chanPoint)
+}
+
+// ForceCloseTxLabel returns the label applied to our commitment transaction
+// when it's broadcast in order to unilaterally close the channel identified
+// by the passed outpoint.
+func ForceCloseTxLabel(chanPoint *wire.OutPoint) string {
+	return fmt.Sprintf("lnd:forceclose:%v", chanPoint)
+}
@@ -0,0 +1,166 @@
+package lnwallet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// DefaultLeaseDuration is the duration an output is leased for if the caller
+// doesn't specify one.
+const DefaultLeaseDuration = 10 * time.Minute
+
+var (
+	// ErrOutputAlreadyLeased is returned when an attempt is made to lease
+	// an output which is currently leased under a different lease ID, or
+	// has been reserved for a pending channel funding.
+	ErrOutputAlreadyLeased = errors.New("output is already locked")
+
+	// ErrOutputNotLeased is returned when an attempt is made to release an
+	// output which isn't currently leased.
+	ErrOutputNotLeased = errors.New("output is not leased")
+
+	// ErrLeaseIDMismatch is returned when an attempt is made to release
+	// an output under a lease ID other than the one which leased it.
+	ErrLeaseIDMismatch = errors.New("output leased under a different " +
+		"lease ID")
+)
+
+// LeaseID is an opaque identifier chosen by the caller of LeaseOutput. Only
+// the holder of the lease ID may release or extend a lease.
+type LeaseID [32]byte
+
+// OutputLease describes an output which has been reserved by an external
+// caller, and is therefore excluded from the wallet's coin selection until
+// the lease either expires or is released.
+type OutputLease struct {
+	// ID is the identifier of the lease.
+	ID LeaseID
+
+	// OutPoint is the leased output.
+	OutPoint wire.OutPoint
+
+	// Expiration is the time at which the lease expires, and the output
+	// once again becomes eligible for coin selection.
+	Expiration time.Time
+}
+
+// expireLeases releases all leases which have expired as of now.
+//
+// NOTE: The coinSelectMtx MUST be held when calling this method.
+func (l *LightningWallet) expireLeases(now time.Time) {
+	for outPoint, lease := range l.leasedOutputs {
+		if now.Before(lease.Expiration) {
+			continue
+		}
+
+		walletLog.Debugf("Lease on output %v expired", outPoint)
+
+		delete(l.leasedOutputs, outPoint)
+		l.UnlockOutpoint(outPoint)
+	}
+}
+
+// filterLeased removes any outputs currently under an active lease from the
+// passed set of coins.
+//
+// NOTE: The coinSelectMtx MUST be held when calling this method.
+func (l *LightningWallet) filterLeased(coins []*Utxo) []*Utxo {
+	l.expireLeases(time.Now())
+
+	unleased := coins[:0]
+	for _, coin := range coins {
+		if _, ok := l.leasedOutputs[coin.OutPoint]; ok {
+			continue
+		}
+
+		unleased = append(unleased, coin)
+	}
+
+	return unleased
+}
+
+// LeaseOutput locks the target output for the given duration, preventing the
+// wallet from selecting it when funding channels or sending coins. This allows
+// external tools, e.g. a transaction batching service, to coordinate use of
+// the wallet's outputs without risking a double spend. Leasing an output that
+// is already leased under the same ID extends the lease. The expiration time
+// of the lease is returned.
+func (l *LightningWallet) LeaseOutput(id LeaseID, op wire.OutPoint,
+	duration time.Duration) (time.Time, error) {
+
+	// Ensure the output is actually controlled by the wallet before
+	// attempting to lease it.
+	if _, err := l.FetchInputInfo(&op); err != nil {
+		return time.Time{}, err
+	}
+
+	if duration <= 0 {
+		duration = DefaultLeaseDuration
+	}
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	now := time.Now()
+	l.expireLeases(now)
+
+	if _, ok := l.lockedOutPoints[op]; ok {
+		return time.Time{}, ErrOutputAlreadyLeased
+	}
+	if lease, ok := l.leasedOutputs[op]; ok && lease.ID != id {
+		return time.Time{}, ErrOutputAlreadyLeased
+	}
+
+	lease := &OutputLease{
+		ID:         id,
+		OutPoint:   op,
+		Expiration: now.Add(duration),
+	}
+	l.leasedOutputs[op] = lease
+	l.LockOutpoint(op)
+
+	walletLog.Debugf("Leased output %v until %v", op, lease.Expiration)
+
+	return lease.Expiration, nil
+}
+
+// ReleaseOutput releases a lease previously acquired via LeaseOutput, making
+// the output eligible for coin selection once again. The passed ID must match
+// the ID the output was leased under.
+func (l *LightningWallet) ReleaseOutput(id LeaseID, op wire.OutPoint) error {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	l.expireLeases(time.Now())
+
+	lease, ok := l.leasedOutputs[op]
+	if !ok {
+		return ErrOutputNotLeased
+	}
+	if lease.ID != id {
+		return ErrLeaseIDMismatch
+	}
+
+	delete(l.leasedOutputs, op)
+	l.UnlockOutpoint(op)
+
+	return nil
+}
+
+// ListLeases returns all currently active output leases.
+func (l *LightningWallet) ListLeases() []*OutputLease {
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	l.expireLeases(time.Now())
+
+	leases := make([]*OutputLease, 0, len(l.leasedOutputs))
+	for _, lease := range l.leasedOutputs {
+		leaseCopy := *lease
+		leases = append(leases, &leaseCopy)
+	}
+
+	return leases
+}
@@ -0,0 +1,235 @@
+package remotesigner
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// requestTimeout is the maximum time to wait for the remote signer to respond
+// to a single request.
+var requestTimeout = time.Second * 30
+
+// Signer is an implementation of the lnwallet.Signer interface which forwards
+// all signing requests to a remote signer over its signrpc connection. This
+// allows lnd to run with only public keys held locally. Additionally, Signer
+// implements lnwallet.RootKeyRing, and brontide.SingleKeyECDH for the node's
+// identity key, as both of these are derived from private keys held by the
+// remote signer.
+type Signer struct {
+	client signrpc.SignerClient
+
+	// identityPub is the public key of the remote signer's identity key.
+	// It's fetched once upon creation as it never changes.
+	identityPub *btcec.PublicKey
+}
+
+// A compile time check to ensure that Signer implements each of the
+// interfaces required to back a watch-only node.
+var _ lnwallet.Signer = (*Signer)(nil)
+var _ lnwallet.RootKeyRing = (*Signer)(nil)
+var _ brontide.SingleKeyECDH = (*Signer)(nil)
+
+// New creates a new Signer which forwards requests to the remote signer
+// reachable over the passed connection.
+func New(conn *grpc.ClientConn) (*Signer, error) {
+	s := &Signer{
+		client: signrpc.NewSignerClient(conn),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.GetIdentityPubKey(ctx,
+		&signrpc.IdentityPubKeyRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach remote signer: %v", err)
+	}
+	s.identityPub, err = btcec.ParsePubKey(resp.IdentityPubkey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// newSignReq serializes the passed transaction and sign descriptor into a
+// request for the remote signer. The sighash midstate isn't sent, as it's
+// recomputed by the remote signer from the transaction itself.
+func newSignReq(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*signrpc.SignReq, error) {
+
+	var txBuf bytes.Buffer
+	if err := tx.Serialize(&txBuf); err != nil {
+		return nil, err
+	}
+
+	var pubKey []byte
+	if signDesc.PubKey != nil {
+		pubKey = signDesc.PubKey.SerializeCompressed()
+	}
+
+	return &signrpc.SignReq{
+		RawTxBytes: txBuf.Bytes(),
+		SignDescs: []*signrpc.SignDescriptor{
+			{
+				PubKey:       pubKey,
+				RedeemScript: signDesc.RedeemScript,
+				Output: &signrpc.TxOut{
+					Value:    signDesc.Output.Value,
+					PkScript: signDesc.Output.PkScript,
+				},
+				Sighash:    uint32(signDesc.HashType),
+				InputIndex: int32(signDesc.InputIndex),
+			},
+		},
+	}, nil
+}
+
+// SignOutputRaw generates a signature for the passed transaction according to
+// the data within the passed SignDescriptor.
+//
+// This is a part of the lnwallet.Signer interface.
+func (s *Signer) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) ([]byte, error) {
+
+	req, err := newSignReq(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.SignOutputRaw(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.RawSigs) != 1 {
+		return nil, fmt.Errorf("remote signer returned %v signatures, "+
+			"expected 1", len(resp.RawSigs))
+	}
+
+	return resp.RawSigs[0], nil
+}
+
+// ComputeInputScript generates a complete input script for the passed
+// transaction with the signature as defined within the passed SignDescriptor.
+//
+// This is a part of the lnwallet.Signer interface.
+func (s *Signer) ComputeInputScript(tx *wire.MsgTx,
+	signDesc *lnwallet.SignDescriptor) (*lnwallet.InputScript, error) {
+
+	req, err := newSignReq(tx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.ComputeInputScript(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.InputScripts) != 1 {
+		return nil, fmt.Errorf("remote signer returned %v input "+
+			"scripts, expected 1", len(resp.InputScripts))
+	}
+
+	return &lnwallet.InputScript{
+		Witness:   resp.InputScripts[0].Witness,
+		ScriptSig: resp.InputScripts[0].SigScript,
+	}, nil
+}
+
+// IdentityPubKey returns the public key of the remote signer's identity key.
+//
+// This is a part of the lnwallet.RootKeyRing interface.
+func (s *Signer) IdentityPubKey() (*btcec.PublicKey, error) {
+	return s.identityPub, nil
+}
+
+// MasterElkremRoot fetches the master elkrem root from the remote signer.
+// Unlike the keys used for signing, the elkrem root must be known locally,
+// as revocation secrets are handed to the remote party as the channel state
+// advances.
+//
+// This is a part of the lnwallet.RootKeyRing interface.
+func (s *Signer) MasterElkremRoot() (*btcec.PrivateKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.DeriveRevocationRoot(ctx,
+		&signrpc.RevocationRootRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	rootKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), resp.RootKey)
+	return rootKey, nil
+}
+
+// DeriveKey fetches the descriptor of the key at the target locator within
+// the remote signer's keychain.
+//
+// This is a part of the keychain.KeyRing interface.
+func (s *Signer) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.DeriveKey(ctx, &signrpc.KeyLocator{
+		KeyFamily: int32(keyLoc.Family),
+		KeyIndex:  int32(keyLoc.Index),
+	})
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	pubKey, err := btcec.ParsePubKey(resp.RawKeyBytes, btcec.S256())
+	if err != nil {
+		return keychain.KeyDescriptor{}, err
+	}
+
+	return keychain.KeyDescriptor{
+		KeyLocator: keyLoc,
+		PubKey:     pubKey,
+	}, nil
+}
+
+// PubKey returns the public key of the remote signer's identity key.
+//
+// This is a part of the brontide.SingleKeyECDH interface.
+func (s *Signer) PubKey() *btcec.PublicKey {
+	return s.identityPub
+}
+
+// ECDH performs an ECDH operation between pub and the remote signer's
+// identity key.
+//
+// This is a part of the brontide.SingleKeyECDH interface.
+func (s *Signer) ECDH(pub *btcec.PublicKey) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := s.client.DeriveSharedKey(ctx, &signrpc.SharedKeyRequest{
+		EphemeralPubkey: pub.SerializeCompressed(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SharedKey, nil
+}
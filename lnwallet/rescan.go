@@ -0,0 +1,55 @@
+package lnwallet
+
+import (
+	"sort"
+	"time"
+)
+
+// birthdayBlockDelta is the number of blocks the rescan height determined
+// from a wallet birthday is moved back by. Block timestamps are only loosely
+// ordered, so a safety margin of roughly a day's worth of blocks ensures no
+// relevant transactions are skipped.
+const birthdayBlockDelta = 144
+
+// BirthdayHeight returns the height from which the chain should be rescanned
+// to find all transactions relevant to a wallet created at the passed time.
+// The first block mined at or after the birthday is located via a binary
+// search over the main chain.
+func (l *LightningWallet) BirthdayHeight(birthday time.Time) (int32, error) {
+	bestHeight, err := l.chainIO.GetCurrentHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	// sort.Search halts at the first error encountered by the predicate,
+	// reporting the height as found so the search terminates quickly.
+	var searchErr error
+	height := sort.Search(int(bestHeight)+1, func(h int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		hash, err := l.chainIO.GetBlockHash(int64(h))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		block, err := l.chainIO.GetBlock(hash)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+
+		return !block.Header.Timestamp.Before(birthday)
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+
+	height -= birthdayBlockDelta
+	if height < 0 {
+		height = 0
+	}
+
+	return int32(height), nil
+}
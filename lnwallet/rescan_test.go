@@ -0,0 +1,93 @@
+package lnwallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockChain is a chain of blocks mined at fixed intervals, of which only the
+// headers are served.
+type mockChain struct {
+	BlockChainIO
+
+	timestamps []time.Time
+	err        error
+}
+
+func (m *mockChain) GetCurrentHeight() (int32, error) {
+	return int32(len(m.timestamps) - 1), nil
+}
+
+func (m *mockChain) GetBlockHash(height int64) (*wire.ShaHash, error) {
+	return &wire.ShaHash{byte(height), byte(height >> 8)}, nil
+}
+
+func (m *mockChain) GetBlock(hash *wire.ShaHash) (*wire.MsgBlock, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	height := int(hash[0]) | int(hash[1])<<8
+	return &wire.MsgBlock{
+		Header: wire.BlockHeader{Timestamp: m.timestamps[height]},
+	}, nil
+}
+
+// TestBirthdayHeight tests that the rescan height of a wallet birthday is a
+// day's worth of blocks before the first block mined at or after it.
+func TestBirthdayHeight(t *testing.T) {
+	genesis := time.Unix(1500000000, 0)
+	chain := &mockChain{}
+	for i := 0; i < 1000; i++ {
+		chain.timestamps = append(chain.timestamps,
+			genesis.Add(time.Duration(i)*10*time.Minute))
+	}
+	l := &LightningWallet{chainIO: chain}
+
+	tests := []struct {
+		name     string
+		birthday time.Time
+		height   int32
+	}{
+		{
+			name:     "before genesis",
+			birthday: genesis.Add(-time.Hour),
+			height:   0,
+		},
+		{
+			name:     "within first day",
+			birthday: chain.timestamps[100],
+			height:   0,
+		},
+		{
+			name:     "at block",
+			birthday: chain.timestamps[500],
+			height:   500 - birthdayBlockDelta,
+		},
+		{
+			name:     "between blocks",
+			birthday: chain.timestamps[499].Add(time.Minute),
+			height:   500 - birthdayBlockDelta,
+		},
+	}
+	for _, test := range tests {
+		height, err := l.BirthdayHeight(test.birthday)
+		if err != nil {
+			t.Fatalf("%s: unable to find birthday height: %v",
+				test.name, err)
+		}
+		if height != test.height {
+			t.Fatalf("%s: expected height %v, got %v", test.name,
+				test.height, height)
+		}
+	}
+
+	// An error fetching a block must fail the search.
+	chain.err = errors.New("block not found")
+	if _, err := l.BirthdayHeight(genesis); err == nil {
+		t.Fatalf("birthday height found despite chain error")
+	}
+}
@@ -0,0 +1,338 @@
+package lnwallet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// ErrNotChannelClose is returned when the transaction handed to
+	// RescueChannel doesn't spend the funding output of the target
+	// channel.
+	ErrNotChannelClose = errors.New("transaction doesn't spend the " +
+		"funding output of the channel")
+
+	// ErrNoRescuableOutput is returned when none of the outputs of a
+	// commitment transaction could be matched to an output paying to us.
+	ErrNoRescuableOutput = errors.New("no output paying to us found " +
+		"within the commitment transaction")
+)
+
+// ChannelRescueParams holds the static parameters of a channel which are
+// required to recover our funds from a confirmed force close once the
+// channel's state has been lost. Together with the wallet's seed, these
+// parameters suffice to re-derive all keys and scripts of our outputs.
+type ChannelRescueParams struct {
+	// ChanPoint is the funding outpoint of the channel.
+	ChanPoint wire.OutPoint
+
+	// LocalCommitKey is our commitment key within the channel.
+	LocalCommitKey *btcec.PublicKey
+
+	// RemoteCommitKey is the remote node's commitment key within the
+	// channel.
+	RemoteCommitKey *btcec.PublicKey
+
+	// CsvDelay is the relative delay applied to our output within our
+	// own commitment transaction.
+	CsvDelay uint32
+
+	// LeaseExpiry is the lease expiry height locking our outputs within
+	// a leased channel in which we sold the lease. A value of zero
+	// indicates that our outputs aren't leased.
+	LeaseExpiry uint32
+
+	// MaxStateNum is the highest commitment height searched for when
+	// matching our delayed output within our own commitment transaction.
+	MaxStateNum uint64
+}
+
+// RescuedOutput describes an output paying to us within a confirmed
+// commitment transaction, along with everything required to sweep it.
+type RescuedOutput struct {
+	// OutPoint is the outpoint of our output.
+	OutPoint wire.OutPoint
+
+	// SignDesc is the sign descriptor needed to spend the output.
+	SignDesc *SignDescriptor
+
+	// LocalMultiSigKey and RemoteMultiSigKey are the keys of the 2-of-2
+	// funding output, as revealed by the commitment transaction.
+	LocalMultiSigKey  *btcec.PublicKey
+	RemoteMultiSigKey *btcec.PublicKey
+
+	// LocalCommit is true if the commitment transaction is our own, in
+	// which case the output is encumbered by CsvDelay.
+	LocalCommit bool
+
+	// StateNum is the height of our commitment transaction. It's only
+	// set if LocalCommit is.
+	StateNum uint64
+
+	// RevocationKey is the revocation key of our commitment transaction.
+	// It's only set if LocalCommit is.
+	RevocationKey *btcec.PublicKey
+
+	// CsvDelay is the relative delay which must pass before the output
+	// can be swept. It's zero for an output on the remote commitment.
+	CsvDelay uint32
+
+	// LeaseExpiry is the absolute height before which the output can't
+	// be swept. A value of zero indicates that the output isn't leased.
+	LeaseExpiry uint32
+}
+
+// RescueChannel locates our output within closeTx, a commitment transaction
+// of the channel described by params. Both our own, and the remote node's
+// commitment transactions are recognized. The multi-sig keys of the channel
+// are recovered from the witness spending the funding output, while the
+// revocation key of our own commitment is re-derived from the wallet's
+// master elkrem root.
+//
+// NOTE: HTLC outputs aren't matched, only the settled balance of the channel
+// can be recovered.
+func (l *LightningWallet) RescueChannel(closeTx *wire.MsgTx,
+	params *ChannelRescueParams) (*RescuedOutput, error) {
+
+	if len(closeTx.TxIn) != 1 ||
+		closeTx.TxIn[0].PreviousOutPoint != params.ChanPoint {
+
+		return nil, ErrNotChannelClose
+	}
+
+	witness := closeTx.TxIn[0].Witness
+	if len(witness) == 0 {
+		return nil, ErrNotChannelClose
+	}
+	keyA, keyB, err := parseMultiSigScript(witness[len(witness)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the private key of our own multi-sig key resides within the
+	// wallet, which tells the two keys apart.
+	localKey, remoteKey := keyA, keyB
+	if !l.ownsKey(localKey) {
+		localKey, remoteKey = keyB, keyA
+		if !l.ownsKey(localKey) {
+			return nil, fmt.Errorf("neither multi-sig key of " +
+				"channel is controlled by the wallet")
+		}
+	}
+
+	rescued := &RescuedOutput{
+		LocalMultiSigKey:  localKey,
+		RemoteMultiSigKey: remoteKey,
+		LeaseExpiry:       params.LeaseExpiry,
+	}
+
+	// If this is the remote node's commitment, then our output pays
+	// directly to our commitment key, so it's found without a search.
+	var toRemoteScript, toRemotePkScript []byte
+	if params.LeaseExpiry != 0 {
+		toRemoteScript, err = leaseCommitScriptToRemote(
+			params.LeaseExpiry, params.LocalCommitKey)
+		if err != nil {
+			return nil, err
+		}
+		toRemotePkScript, err = witnessScriptHash(toRemoteScript)
+	} else {
+		toRemotePkScript, err = commitScriptUnencumbered(
+			params.LocalCommitKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found, index := FindScriptOutputIndex(closeTx, toRemotePkScript); found {
+		rescued.OutPoint = wire.OutPoint{
+			Hash:  closeTx.TxSha(),
+			Index: index,
+		}
+		rescued.SignDesc = &SignDescriptor{
+			PubKey:       params.LocalCommitKey,
+			RedeemScript: toRemoteScript,
+			Output:       closeTx.TxOut[index],
+			HashType:     txscript.SigHashAll,
+		}
+		return rescued, nil
+	}
+
+	// Otherwise, we'll need to re-derive the revocation key of each of
+	// our commitment heights in turn until our delayed output is found.
+	masterElkremRoot, err := l.keyRing.MasterElkremRoot()
+	if err != nil {
+		return nil, err
+	}
+	elkremRoot := deriveElkremRoot(masterElkremRoot, localKey, remoteKey)
+	elkremSender := elkrem.NewElkremSender(elkremRoot)
+	for height := uint64(0); height <= params.MaxStateNum; height++ {
+		preimage, err := elkremSender.AtIndex(height)
+		if err != nil {
+			return nil, err
+		}
+		revokeKey := DeriveRevocationPubkey(params.RemoteCommitKey,
+			preimage[:])
+
+		toLocalScript, err := commitScriptToSelfWithLease(
+			params.CsvDelay, params.LeaseExpiry,
+			params.LocalCommitKey, revokeKey)
+		if err != nil {
+			return nil, err
+		}
+		toLocalPkScript, err := witnessScriptHash(toLocalScript)
+		if err != nil {
+			return nil, err
+		}
+
+		found, index := FindScriptOutputIndex(closeTx, toLocalPkScript)
+		if !found {
+			continue
+		}
+
+		rescued.OutPoint = wire.OutPoint{
+			Hash:  closeTx.TxSha(),
+			Index: index,
+		}
+		rescued.SignDesc = &SignDescriptor{
+			PubKey:       params.LocalCommitKey,
+			RedeemScript: toLocalScript,
+			Output:       closeTx.TxOut[index],
+			HashType:     txscript.SigHashAll,
+		}
+		rescued.LocalCommit = true
+		rescued.StateNum = height
+		rescued.RevocationKey = revokeKey
+		rescued.CsvDelay = params.CsvDelay
+		return rescued, nil
+	}
+
+	return nil, ErrNoRescuableOutput
+}
+
+// CreateRescueSweepTx creates a fully signed transaction sweeping the
+// rescued output to the passed address, paying a fee at the given rate in
+// sat/byte. If sweepAddr is nil, then the funds are swept to a fresh address
+// of the wallet. The transaction is returned without being broadcast.
+func (l *LightningWallet) CreateRescueSweepTx(output *RescuedOutput,
+	sweepAddr btcutil.Address, feeRate btcutil.Amount) (*wire.MsgTx, error) {
+
+	if sweepAddr == nil {
+		var err error
+		sweepAddr, err = l.NewAddress(WitnessPubKey, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pkScript, err := txscript.PayToAddrScript(sweepAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The delayed output spends through a larger witness than a p2wkh
+	// output, so we'll account for its witness script on top of the
+	// regular estimate.
+	size := estimateTxSize(1, 1) + len(output.SignDesc.RedeemScript)
+	fee := btcutil.Amount(size) * feeRate
+	amt := btcutil.Amount(output.SignDesc.Output.Value) - fee
+	if amt < DefaultDustLimit {
+		return nil, fmt.Errorf("output of %v is too small to be swept "+
+			"at a fee of %v", output.SignDesc.Output.Value, fee)
+	}
+
+	// CSV requires the sweeping transaction to have a version of at
+	// least 2, and the sequence of the input to encode the delay. A leased
+	// output additionally requires a lock time of at least the lease
+	// expiry, which is only enforced if the input isn't final.
+	sweepTx := wire.NewMsgTx()
+	sweepTx.Version = 2
+	sweepTx.LockTime = output.LeaseExpiry
+	sweepTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: output.OutPoint,
+		Sequence:         output.CsvDelay,
+	})
+	sweepTx.AddTxOut(wire.NewTxOut(int64(amt), pkScript))
+
+	signDesc := *output.SignDesc
+	signDesc.SigHashes = txscript.NewTxSigHashes(sweepTx)
+	signDesc.InputIndex = 0
+
+	var witness wire.TxWitness
+	switch {
+	case output.LocalCommit:
+		witness, err = CommitSpendTimeout(l.Signer, &signDesc, sweepTx)
+
+	case output.LeaseExpiry != 0:
+		witness, err = CommitSpendToRemoteLease(l.Signer, &signDesc,
+			sweepTx)
+
+	default:
+		var inputScript *InputScript
+		inputScript, err = l.Signer.ComputeInputScript(sweepTx,
+			&signDesc)
+		if inputScript != nil {
+			witness = inputScript.Witness
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	sweepTx.TxIn[0].Witness = witness
+
+	return sweepTx, nil
+}
+
+// ownsKey returns true if the private key of the passed public key is
+// controlled by the wallet.
+func (l *LightningWallet) ownsKey(pubKey *btcec.PublicKey) bool {
+	pkHash := btcutil.Hash160(pubKey.SerializeCompressed())
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(pkHash, l.netParams)
+	if err != nil {
+		return false
+	}
+
+	_, err = l.GetPrivKey(addr)
+	return err == nil
+}
+
+// parseMultiSigScript parses the two public keys of a 2-of-2 multi-sig
+// script as created by genMultiSigScript.
+func parseMultiSigScript(script []byte) (*btcec.PublicKey,
+	*btcec.PublicKey, error) {
+
+	pushes, err := txscript.PushedData(script)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(pushes) != 2 {
+		return nil, nil, ErrNotChannelClose
+	}
+
+	// Re-create the script from the parsed keys to ensure that it's
+	// indeed a funding script, rather than some other script pushing two
+	// items.
+	expected, err := genMultiSigScript(pushes[0], pushes[1])
+	if err != nil {
+		return nil, nil, ErrNotChannelClose
+	}
+	if !bytes.Equal(expected, script) {
+		return nil, nil, ErrNotChannelClose
+	}
+
+	keyA, err := btcec.ParsePubKey(pushes[0], btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+	keyB, err := btcec.ParsePubKey(pushes[1], btcec.S256())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return keyA, keyB, nil
+}
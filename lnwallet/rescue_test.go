@@ -0,0 +1,56 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+)
+
+// TestParseMultiSigScript tests that the keys of a funding script are
+// recovered, while any other script is rejected.
+func TestParseMultiSigScript(t *testing.T) {
+	_, alicePub := btcec.PrivKeyFromBytes(btcec.S256(), testWalletPrivKey)
+	_, bobPub := btcec.PrivKeyFromBytes(btcec.S256(), bobsPrivKey)
+
+	fundingScript, err := genMultiSigScript(alicePub.SerializeCompressed(),
+		bobPub.SerializeCompressed())
+	if err != nil {
+		t.Fatalf("unable to create funding script: %v", err)
+	}
+
+	keyA, keyB, err := parseMultiSigScript(fundingScript)
+	if err != nil {
+		t.Fatalf("unable to parse funding script: %v", err)
+	}
+	if !(keyA.IsEqual(alicePub) && keyB.IsEqual(bobPub)) &&
+		!(keyA.IsEqual(bobPub) && keyB.IsEqual(alicePub)) {
+
+		t.Fatalf("parsed keys don't match funding keys")
+	}
+
+	// A 1-of-2 multi-sig script pushes the same keys, but isn't a funding
+	// script.
+	builder := txscript.NewScriptBuilder()
+	builder.AddOp(txscript.OP_1)
+	builder.AddData(alicePub.SerializeCompressed())
+	builder.AddData(bobPub.SerializeCompressed())
+	builder.AddOp(txscript.OP_2)
+	builder.AddOp(txscript.OP_CHECKMULTISIG)
+	oneOfTwoScript, err := builder.Script()
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	delayScript, err := commitScriptToSelf(5, alicePub, bobPub)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	for _, script := range [][]byte{oneOfTwoScript, delayScript} {
+		if _, _, err := parseMultiSigScript(script); err == nil {
+			t.Fatalf("non-funding script %x parsed as funding "+
+				"script", script)
+		}
+	}
+}
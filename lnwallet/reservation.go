@@ -5,6 +5,7 @@ import (
 
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 )
@@ -41,6 +42,11 @@ type ChannelContribution struct {
 	// channel funds in the scenario of a cooperative channel closure.
 	DeliveryAddress btcutil.Address
 
+	// UpfrontShutdown indicates that this party commits to DeliveryAddress
+	// as the destination of its balance upon a cooperative close, and
+	// won't request a different address when the channel is closed.
+	UpfrontShutdown bool
+
 	// RevocationKey is the key to be used in the revocation clause for the
 	// initial version of this party's commitment transaction.
 	RevocationKey *btcec.PublicKey
@@ -352,6 +358,82 @@ func (r *ChannelReservation) FundingOutpoint() *wire.OutPoint {
 	return r.partialState.FundingOutpoint
 }
 
+// SetPrivate marks the channel resulting from this reservation as private,
+// meaning it won't be announced to the rest of the network.
+//
+// NOTE: This method must be called before the reservation is completed in
+// order for the flag to be persisted along with the channel's initial state.
+func (r *ChannelReservation) SetPrivate(private bool) {
+	r.Lock()
+	defer r.Unlock()
+	r.partialState.IsPrivate = private
+}
+
+// SetLocalPolicy sets the forwarding policy we'll advertise for the channel
+// resulting from this reservation.
+//
+// NOTE: This method must be called before the reservation is completed in
+// order for the policy to be persisted along with the channel's initial state.
+func (r *ChannelReservation) SetLocalPolicy(policy *channeldb.ChannelEdgePolicy) {
+	r.Lock()
+	defer r.Unlock()
+	r.partialState.LocalPolicy = policy
+}
+
+// SetLeaseExpiry marks the channel resulting from this reservation as a
+// leased channel, locking all funds of the channel initiator until the
+// passed absolute block height. A lease expiry of zero indicates a regular
+// channel.
+//
+// NOTE: This method must be called before either party's contribution is
+// processed, as the lease alters the scripts of both commitment transactions.
+func (r *ChannelReservation) SetLeaseExpiry(leaseExpiry uint32) {
+	r.Lock()
+	defer r.Unlock()
+	r.partialState.LeaseExpiry = leaseExpiry
+}
+
+// SetDeliveryAddress replaces the wallet generated address our balance will
+// be delivered to upon a cooperative close of the channel resulting from this
+// reservation, committing to the passed address as an upfront shutdown
+// script. The address can then no longer be replaced when the channel is
+// closed, ensuring our balance is delivered to it even should our node later
+// be compromised.
+//
+// NOTE: This method must be called before our contribution is sent to the
+// remote party.
+func (r *ChannelReservation) SetDeliveryAddress(addr btcutil.Address) error {
+	deliveryScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.partialState.OurDeliveryScript = deliveryScript
+	r.partialState.OurUpfrontShutdown = true
+	r.ourContribution.DeliveryAddress = addr
+	r.ourContribution.UpfrontShutdown = true
+
+	return nil
+}
+
+// SetHTLCLimits sets the limits on the total value, and number of HTLC's
+// permitted in flight in either direction within the channel resulting from
+// this reservation. A value of zero for either limit indicates the default:
+// the channel's capacity, and MaxPendingPayments respectively.
+//
+// NOTE: This method must be called before the reservation is completed in
+// order for the limits to be persisted along with the channel's initial state.
+func (r *ChannelReservation) SetHTLCLimits(maxPendingAmt btcutil.Amount,
+	maxAcceptedHtlcs uint16) {
+
+	r.Lock()
+	defer r.Unlock()
+	r.partialState.MaxPendingAmount = maxPendingAmt
+	r.partialState.MaxAcceptedHtlcs = maxAcceptedHtlcs
+}
+
 // Cancel abandons this channel reservation. This method should be called in
 // the scenario that communications with the counterparty break down. Upon
 // cancellation, all resources previously reserved for this pending payment
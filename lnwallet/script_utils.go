@@ -592,6 +592,94 @@ func commitScriptUnencumbered(key *btcec.PublicKey) ([]byte, error) {
 	return builder.Script()
 }
 
+// leaseCommitScriptToSelf constructs the public key script for the delayed
+// output paying to the seller of a leased channel within their own commitment
+// transaction. The script is identical to the one created by
+// commitScriptToSelf, with the addition of an absolute timelock within the
+// delayed clause which prevents the seller from sweeping their funds before
+// the lease has expired. As the witness for the delayed clause is unchanged,
+// the output can be swept with CommitSpendTimeout by a transaction whose lock
+// time is at least the lease expiry.
+//
+// Output Script:
+//     OP_IF
+//         <revokeKey> OP_CHECKSIG
+//     OP_ELSE
+//         <leaseExpiry> OP_CHECKLOCKTIMEVERIFY OP_DROP
+//         <timeKey> OP_CHECKSIGVERIFY
+//         <numRelativeBlocks> OP_CHECKSEQUENCEVERIFY
+//     OP_ENDIF
+func leaseCommitScriptToSelf(csvTimeout, leaseExpiry uint32, selfKey,
+	revokeKey *btcec.PublicKey) ([]byte, error) {
+
+	builder := txscript.NewScriptBuilder()
+
+	builder.AddOp(txscript.OP_IF)
+
+	// The revocation clause is unaffected by the lease, the seller must
+	// still be punishable for broadcasting a revoked state.
+	builder.AddData(revokeKey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIG)
+
+	builder.AddOp(txscript.OP_ELSE)
+
+	// Otherwise, the funds can only be re-claimed once the lease has
+	// expired, and the CSV delay has passed.
+	builder.AddInt64(int64(leaseExpiry))
+	builder.AddOp(txscript.OP_CHECKLOCKTIMEVERIFY)
+	builder.AddOp(txscript.OP_DROP)
+	builder.AddData(selfKey.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIGVERIFY)
+	builder.AddInt64(int64(csvTimeout))
+	builder.AddOp(OP_CHECKSEQUENCEVERIFY)
+
+	builder.AddOp(txscript.OP_ENDIF)
+
+	return builder.Script()
+}
+
+// leaseCommitScriptToRemote constructs the witness script for the output
+// paying to the seller of a leased channel within the buyer's commitment
+// transaction. Rather than a regular p2wkh output, the seller's funds are
+// locked until the lease expiry height.
+//
+// Possible Input Scripts:
+//     <sig>
+//
+// Output Script:
+//     <key> OP_CHECKSIGVERIFY <leaseExpiry> OP_CHECKLOCKTIMEVERIFY
+func leaseCommitScriptToRemote(leaseExpiry uint32,
+	key *btcec.PublicKey) ([]byte, error) {
+
+	builder := txscript.NewScriptBuilder()
+	builder.AddData(key.SerializeCompressed())
+	builder.AddOp(txscript.OP_CHECKSIGVERIFY)
+	builder.AddInt64(int64(leaseExpiry))
+	builder.AddOp(txscript.OP_CHECKLOCKTIMEVERIFY)
+
+	return builder.Script()
+}
+
+// CommitSpendToRemoteLease constructs a valid witness allowing the seller of
+// a leased channel to sweep their output on the buyer's commitment
+// transaction. In order for the spend to be valid, the lock time of the
+// sweeping transaction must be at least the lease expiry height, and the
+// sequence number of the target input must not be final.
+func CommitSpendToRemoteLease(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	witnessStack := wire.TxWitness(make([][]byte, 2))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = signDesc.RedeemScript
+
+	return witnessStack, nil
+}
+
 // CommitSpendTimeout constructs a valid witness allowing the owner of a
 // particular commitment transaction to spend the output returning settled
 // funds back to themselves after a relative block timeout.  In order to
@@ -626,6 +714,66 @@ func CommitSpendTimeout(signer Signer, signDesc *SignDescriptor,
 	return witnessStack, nil
 }
 
+// HtlcSpendTimeout constructs a valid witness allowing the sender of an HTLC
+// to sweep the HTLC's output within their own commitment transaction once it
+// has expired. In order for the spend to be valid, the lock time of the
+// sweeping transaction must be at least the expiry of the HTLC, and the
+// sequence number of the target input must encode the relative delay of the
+// commitment transaction. As with CommitSpendTimeout, the version of the
+// sweeping transaction *must* be >= 2.
+func HtlcSpendTimeout(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+	if sweepTx.Version < 2 {
+		return nil, fmt.Errorf("version of passed transaction MUST "+
+			"be >= 2, not %v", sweepTx.Version)
+	}
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// We place a zero as the first item of the evaluated witness stack in
+	// order to force Script execution to the HTLC timeout clause.
+	witnessStack := wire.TxWitness(make([][]byte, 3))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = []byte{0}
+	witnessStack[2] = signDesc.RedeemScript
+
+	return witnessStack, nil
+}
+
+// HtlcSpendSuccess constructs a valid witness allowing the receiver of an
+// HTLC to sweep the HTLC's output within their own commitment transaction
+// given the payment preimage. In order for the spend to be valid, the
+// sequence number of the target input must encode the relative delay of the
+// commitment transaction, and the version of the sweeping transaction *must*
+// be >= 2.
+func HtlcSpendSuccess(signer Signer, signDesc *SignDescriptor,
+	sweepTx *wire.MsgTx, paymentPreimage []byte) (wire.TxWitness, error) {
+
+	if sweepTx.Version < 2 {
+		return nil, fmt.Errorf("version of passed transaction MUST "+
+			"be >= 2, not %v", sweepTx.Version)
+	}
+
+	sweepSig, err := signer.SignOutputRaw(sweepTx, signDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Place a one as the first item in the evaluated witness stack to
+	// force script execution to the HTLC redemption clause.
+	witnessStack := wire.TxWitness(make([][]byte, 4))
+	witnessStack[0] = append(sweepSig, byte(txscript.SigHashAll))
+	witnessStack[1] = paymentPreimage
+	witnessStack[2] = []byte{1}
+	witnessStack[3] = signDesc.RedeemScript
+
+	return witnessStack, nil
+}
+
 // commitSpendRevoke constructs a valid witness allowing a node to sweep the
 // settled output of a malicious counter-party who broadcasts a revoked
 // commitment trransaction.
@@ -53,7 +53,8 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	// of 5 blocks before sweeping the output, while bob can spend
 	// immediately with either the revocation key, or his regular key.
 	commitmentTx, err := CreateCommitTx(fakeFundingTxIn, aliceKeyPub,
-		bobKeyPub, revokePubKey, csvTimeout, channelBalance, channelBalance)
+		bobKeyPub, revokePubKey, csvTimeout, 0, 0, channelBalance,
+		channelBalance)
 	if err != nil {
 		t.Fatalf("unable to create commitment transaction: %v", nil)
 	}
@@ -149,6 +150,308 @@ func TestCommitmentSpendValidation(t *testing.T) {
 	}
 }
 
+// TestLeaseCommitmentSpendValidation tests that the outputs of the seller of a
+// leased channel can only be swept once the lease has expired, both within
+// their own commitment transaction, and within the buyer's.
+func TestLeaseCommitmentSpendValidation(t *testing.T) {
+	fundingOut := &wire.OutPoint{
+		Hash:  testHdSeed,
+		Index: 50,
+	}
+	fakeFundingTxIn := wire.NewTxIn(fundingOut, nil, nil)
+
+	aliceKeyPriv, aliceKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		testWalletPrivKey)
+	bobKeyPriv, bobKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		bobsPrivKey)
+	channelBalance := btcutil.Amount(1 * 10e8)
+	csvTimeout := uint32(5)
+	leaseExpiry := uint32(1000)
+	revocationPreimage := testHdSeed[:]
+	aliceRevokeKey := DeriveRevocationPubkey(bobKeyPub, revocationPreimage)
+	bobRevokeKey := DeriveRevocationPubkey(aliceKeyPub, revocationPreimage)
+
+	// In this scenario, Alice has sold a lease to Bob, so her outputs on
+	// both commitment transactions are locked until the lease expires.
+	aliceCommitTx, err := CreateCommitTx(fakeFundingTxIn, aliceKeyPub,
+		bobKeyPub, aliceRevokeKey, csvTimeout, leaseExpiry, 0,
+		channelBalance, channelBalance)
+	if err != nil {
+		t.Fatalf("unable to create commitment transaction: %v", err)
+	}
+	bobCommitTx, err := CreateCommitTx(fakeFundingTxIn, bobKeyPub,
+		aliceKeyPub, bobRevokeKey, csvTimeout, 0, leaseExpiry,
+		channelBalance, channelBalance)
+	if err != nil {
+		t.Fatalf("unable to create commitment transaction: %v", err)
+	}
+
+	targetOutput, err := commitScriptUnencumbered(aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to create target output: %v", err)
+	}
+	newSweepTx := func(commitTx *wire.MsgTx, index uint32,
+		sequence, lockTime uint32) *wire.MsgTx {
+
+		sweepTx := wire.NewMsgTx()
+		sweepTx.Version = 2
+		sweepTx.LockTime = lockTime
+		sweepTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{
+			Hash:  commitTx.TxSha(),
+			Index: index,
+		}, nil, nil))
+		sweepTx.TxIn[0].Sequence = sequence
+		sweepTx.AddTxOut(&wire.TxOut{
+			PkScript: targetOutput,
+			Value:    0.5 * 10e8,
+		})
+		return sweepTx
+	}
+	execute := func(pkScript []byte, sweepTx *wire.MsgTx) error {
+		vm, err := txscript.NewEngine(pkScript, sweepTx, 0,
+			txscript.StandardVerifyFlags, nil, nil,
+			int64(channelBalance))
+		if err != nil {
+			return err
+		}
+		return vm.Execute()
+	}
+
+	// First, we'll test Alice sweeping her delayed output on her own
+	// commitment transaction, which requires both the CSV delay and the
+	// lease to have passed.
+	delayScript, err := leaseCommitScriptToSelf(csvTimeout, leaseExpiry,
+		aliceKeyPub, aliceRevokeKey)
+	if err != nil {
+		t.Fatalf("unable to generate alice delay script: %v", err)
+	}
+	sequence := lockTimeToSequence(false, csvTimeout)
+	for _, test := range []struct {
+		lockTime uint32
+		valid    bool
+	}{
+		{leaseExpiry - 1, false},
+		{leaseExpiry, true},
+	} {
+		sweepTx := newSweepTx(aliceCommitTx, 0, sequence, test.lockTime)
+		signDesc := &SignDescriptor{
+			RedeemScript: delayScript,
+			SigHashes:    txscript.NewTxSigHashes(sweepTx),
+			Output: &wire.TxOut{
+				Value: int64(channelBalance),
+			},
+			HashType:   txscript.SigHashAll,
+			InputIndex: 0,
+		}
+		witness, err := CommitSpendTimeout(&mockSigner{aliceKeyPriv},
+			signDesc, sweepTx)
+		if err != nil {
+			t.Fatalf("unable to generate delay spend witness: %v",
+				err)
+		}
+		sweepTx.TxIn[0].Witness = witness
+
+		err = execute(aliceCommitTx.TxOut[0].PkScript, sweepTx)
+		if test.valid && err != nil {
+			t.Fatalf("spend at lock time %v should be valid: %v",
+				test.lockTime, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("spend at lock time %v should be invalid",
+				test.lockTime)
+		}
+	}
+
+	// The revocation clause must remain immediately spendable by Bob.
+	sweepTx := newSweepTx(aliceCommitTx, 0, wire.MaxTxInSequenceNum, 0)
+	revokePrivKey := DeriveRevocationPrivKey(bobKeyPriv, revocationPreimage)
+	witness, err := commitSpendRevoke(delayScript, channelBalance,
+		revokePrivKey, sweepTx)
+	if err != nil {
+		t.Fatalf("unable to generate revocation witness: %v", err)
+	}
+	sweepTx.TxIn[0].Witness = witness
+	if err := execute(aliceCommitTx.TxOut[0].PkScript, sweepTx); err != nil {
+		t.Fatalf("revocation spend is invalid: %v", err)
+	}
+
+	// Finally, Alice's output on Bob's commitment transaction can't be
+	// swept until the lease expires either.
+	remoteScript, err := leaseCommitScriptToRemote(leaseExpiry, aliceKeyPub)
+	if err != nil {
+		t.Fatalf("unable to generate alice remote script: %v", err)
+	}
+	for _, test := range []struct {
+		lockTime uint32
+		valid    bool
+	}{
+		{leaseExpiry - 1, false},
+		{leaseExpiry, true},
+	} {
+		sweepTx := newSweepTx(bobCommitTx, 1, 0, test.lockTime)
+		signDesc := &SignDescriptor{
+			RedeemScript: remoteScript,
+			SigHashes:    txscript.NewTxSigHashes(sweepTx),
+			Output: &wire.TxOut{
+				Value: int64(channelBalance),
+			},
+			HashType:   txscript.SigHashAll,
+			InputIndex: 0,
+		}
+		witness, err := CommitSpendToRemoteLease(
+			&mockSigner{aliceKeyPriv}, signDesc, sweepTx)
+		if err != nil {
+			t.Fatalf("unable to generate remote spend witness: %v",
+				err)
+		}
+		sweepTx.TxIn[0].Witness = witness
+
+		err = execute(bobCommitTx.TxOut[1].PkScript, sweepTx)
+		if test.valid && err != nil {
+			t.Fatalf("spend at lock time %v should be valid: %v",
+				test.lockTime, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("spend at lock time %v should be invalid",
+				test.lockTime)
+		}
+	}
+}
+
+// TestHtlcSignerSpendValidation tests that the HTLC outputs within our own
+// commitment transaction can be swept through a Signer, via the timeout
+// clause once an outgoing HTLC has expired, and via the success clause given
+// the preimage of an incoming HTLC. Both spends require the relative delay of
+// the commitment transaction to have passed.
+func TestHtlcSignerSpendValidation(t *testing.T) {
+	fundingOut := &wire.OutPoint{
+		Hash:  testHdSeed,
+		Index: 50,
+	}
+	fakeFundingTxIn := wire.NewTxIn(fundingOut, nil, nil)
+
+	revokePreimage := testHdSeed[:]
+	revokeHash := fastsha256.Sum256(revokePreimage)
+	paymentPreimage := revokeHash
+	paymentPreimage[0] ^= 1
+	paymentHash := fastsha256.Sum256(paymentPreimage[:])
+
+	aliceKeyPriv, aliceKeyPub := btcec.PrivKeyFromBytes(btcec.S256(),
+		testWalletPrivKey)
+	_, bobKeyPub := btcec.PrivKeyFromBytes(btcec.S256(), bobsPrivKey)
+	paymentAmt := btcutil.Amount(1 * 10e8)
+	cltvTimeout := uint32(8)
+	csvTimeout := uint32(5)
+
+	// Alice's commitment transaction holds both an HTLC she offered to
+	// Bob, and an HTLC offered to her by Bob.
+	offeredScript, err := senderHTLCScript(cltvTimeout, csvTimeout,
+		aliceKeyPub, bobKeyPub, revokeHash[:], paymentHash[:])
+	if err != nil {
+		t.Fatalf("unable to create htlc sender script: %v", err)
+	}
+	receivedScript, err := receiverHTLCScript(cltvTimeout, csvTimeout,
+		bobKeyPub, aliceKeyPub, revokeHash[:], paymentHash[:])
+	if err != nil {
+		t.Fatalf("unable to create htlc receiver script: %v", err)
+	}
+	commitTx := wire.NewMsgTx()
+	commitTx.Version = 2
+	commitTx.AddTxIn(fakeFundingTxIn)
+	for _, script := range [][]byte{offeredScript, receivedScript} {
+		pkScript, err := witnessScriptHash(script)
+		if err != nil {
+			t.Fatalf("unable to create p2wsh htlc script: %v", err)
+		}
+		commitTx.AddTxOut(wire.NewTxOut(int64(paymentAmt), pkScript))
+	}
+
+	signer := &mockSigner{aliceKeyPriv}
+	sweep := func(index uint32, script []byte, sequence, lockTime uint32,
+		genWitness func(*SignDescriptor, *wire.MsgTx) (wire.TxWitness,
+			error)) error {
+
+		sweepTx := wire.NewMsgTx()
+		sweepTx.Version = 2
+		sweepTx.LockTime = lockTime
+		sweepTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{
+			Hash:  commitTx.TxSha(),
+			Index: index,
+		}, nil, nil))
+		sweepTx.TxIn[0].Sequence = sequence
+		sweepTx.AddTxOut(&wire.TxOut{
+			PkScript: []byte("doesn't matter"),
+			Value:    0.5 * 10e8,
+		})
+
+		signDesc := &SignDescriptor{
+			PubKey:       aliceKeyPub,
+			RedeemScript: script,
+			Output:       commitTx.TxOut[index],
+			HashType:     txscript.SigHashAll,
+			SigHashes:    txscript.NewTxSigHashes(sweepTx),
+			InputIndex:   0,
+		}
+		witness, err := genWitness(signDesc, sweepTx)
+		if err != nil {
+			t.Fatalf("unable to generate witness: %v", err)
+		}
+		sweepTx.TxIn[0].Witness = witness
+
+		vm, err := txscript.NewEngine(commitTx.TxOut[index].PkScript,
+			sweepTx, 0, txscript.StandardVerifyFlags, nil, nil,
+			int64(paymentAmt))
+		if err != nil {
+			return err
+		}
+		return vm.Execute()
+	}
+
+	timeoutWitness := func(signDesc *SignDescriptor,
+		sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+		return HtlcSpendTimeout(signer, signDesc, sweepTx)
+	}
+	successWitness := func(signDesc *SignDescriptor,
+		sweepTx *wire.MsgTx) (wire.TxWitness, error) {
+
+		return HtlcSpendSuccess(signer, signDesc, sweepTx,
+			paymentPreimage[:])
+	}
+
+	testCases := []struct {
+		name       string
+		index      uint32
+		script     []byte
+		sequence   uint32
+		lockTime   uint32
+		genWitness func(*SignDescriptor, *wire.MsgTx) (wire.TxWitness,
+			error)
+		valid bool
+	}{
+		{"timeout before expiry", 0, offeredScript, csvTimeout,
+			cltvTimeout - 1, timeoutWitness, false},
+		{"timeout before csv delay", 0, offeredScript, csvTimeout - 1,
+			cltvTimeout, timeoutWitness, false},
+		{"timeout", 0, offeredScript, csvTimeout, cltvTimeout,
+			timeoutWitness, true},
+		{"success before csv delay", 1, receivedScript, csvTimeout - 1,
+			0, successWitness, false},
+		{"success", 1, receivedScript, csvTimeout, 0, successWitness,
+			true},
+	}
+	for _, test := range testCases {
+		err := sweep(test.index, test.script, test.sequence,
+			test.lockTime, test.genWitness)
+		if test.valid && err != nil {
+			t.Fatalf("%v: spend should be valid: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("%v: spend should be invalid", test.name)
+		}
+	}
+}
+
 // TestRevocationKeyDerivation tests that given a public key, and a revocation
 // hash, the homomorphic revocation public and private key derivation work
 // properly.
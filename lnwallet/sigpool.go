@@ -0,0 +1,194 @@
+package lnwallet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// jobBuffer is the number of sign and verify jobs which may be queued
+	// within the SigPool before submitters block.
+	jobBuffer = 100
+)
+
+var (
+	// ErrSigPoolShuttingDown is returned when a job is submitted to a
+	// SigPool which is shutting down.
+	ErrSigPoolShuttingDown = errors.New("sig pool shutting down")
+)
+
+// signJob is a request to generate a signature for the passed transaction
+// according to the passed SignDescriptor.
+type signJob struct {
+	tx       *wire.MsgTx
+	signDesc *SignDescriptor
+
+	resp chan signJobResp
+}
+
+// signJobResp is the result of a signJob.
+type signJobResp struct {
+	sig []byte
+	err error
+}
+
+// verifyJob is a request to validate the passed raw signature of sigHash
+// under pubKey.
+type verifyJob struct {
+	rawSig  []byte
+	sigHash []byte
+	pubKey  *btcec.PublicKey
+
+	resp chan error
+}
+
+// SigPool is a pool of workers which generate and validate the commitment
+// signatures of all active channels. As the pool is shared by every channel,
+// the signature operations of many channels updating their state at once are
+// spread across all CPU cores, rather than each being carried out by the
+// goroutine of the peer owning the channel.
+type SigPool struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	signer     Signer
+	numWorkers int
+
+	signJobs   chan signJob
+	verifyJobs chan verifyJob
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// NewSigPool creates a new SigPool which generates signatures with the passed
+// Signer using numWorkers workers.
+func NewSigPool(numWorkers int, signer Signer) *SigPool {
+	return &SigPool{
+		signer:     signer,
+		numWorkers: numWorkers,
+		signJobs:   make(chan signJob, jobBuffer),
+		verifyJobs: make(chan verifyJob, jobBuffer),
+		quit:       make(chan struct{}),
+	}
+}
+
+// Start launches all the workers of the SigPool.
+func (s *SigPool) Start() error {
+	if !atomic.CompareAndSwapUint32(&s.started, 0, 1) {
+		return nil
+	}
+
+	for i := 0; i < s.numWorkers; i++ {
+		s.wg.Add(1)
+		go s.poolWorker()
+	}
+
+	return nil
+}
+
+// Stop signals all the workers of the SigPool to exit, and waits for them to
+// do so. Any jobs submitted afterwards fail with ErrSigPoolShuttingDown.
+func (s *SigPool) Stop() error {
+	if !atomic.CompareAndSwapUint32(&s.stopped, 0, 1) {
+		return nil
+	}
+
+	close(s.quit)
+	s.wg.Wait()
+
+	return nil
+}
+
+// poolWorker carries out the sign and verify jobs submitted to the SigPool
+// until the pool is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *SigPool) poolWorker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case job := <-s.signJobs:
+			sig, err := s.signer.SignOutputRaw(job.tx, job.signDesc)
+			job.resp <- signJobResp{sig: sig, err: err}
+
+		case job := <-s.verifyJobs:
+			job.resp <- verifySig(job.rawSig, job.sigHash, job.pubKey)
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// SignOutputRaw generates a signature for the passed transaction according to
+// the passed SignDescriptor on one of the workers of the pool, blocking until
+// the signature has been generated.
+func (s *SigPool) SignOutputRaw(tx *wire.MsgTx,
+	signDesc *SignDescriptor) ([]byte, error) {
+
+	job := signJob{
+		tx:       tx,
+		signDesc: signDesc,
+		resp:     make(chan signJobResp, 1),
+	}
+
+	select {
+	case s.signJobs <- job:
+	case <-s.quit:
+		return nil, ErrSigPoolShuttingDown
+	}
+
+	select {
+	case resp := <-job.resp:
+		return resp.sig, resp.err
+	case <-s.quit:
+		return nil, ErrSigPoolShuttingDown
+	}
+}
+
+// VerifySig validates the passed raw signature of sigHash under pubKey on one
+// of the workers of the pool, blocking until the signature has been
+// validated. A nil error is returned only if the signature is valid.
+func (s *SigPool) VerifySig(rawSig, sigHash []byte,
+	pubKey *btcec.PublicKey) error {
+
+	job := verifyJob{
+		rawSig:  rawSig,
+		sigHash: sigHash,
+		pubKey:  pubKey,
+		resp:    make(chan error, 1),
+	}
+
+	select {
+	case s.verifyJobs <- job:
+	case <-s.quit:
+		return ErrSigPoolShuttingDown
+	}
+
+	select {
+	case err := <-job.resp:
+		return err
+	case <-s.quit:
+		return ErrSigPoolShuttingDown
+	}
+}
+
+// verifySig parses the passed raw signature, and ensures it's a valid
+// signature of sigHash under pubKey.
+func verifySig(rawSig, sigHash []byte, pubKey *btcec.PublicKey) error {
+	sig, err := btcec.ParseSignature(rawSig, btcec.S256())
+	if err != nil {
+		return err
+	} else if !sig.Verify(sigHash, pubKey) {
+		return fmt.Errorf("invalid commitment signature")
+	}
+
+	return nil
+}
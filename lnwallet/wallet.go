@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/elkrem"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lndcc"
+	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcutil/hdkeychain"
 
@@ -32,13 +36,6 @@ const (
 	// used to generate elkrem roots should be derived from.
 	elkremRootIndex = hdkeychain.HardenedKeyStart + 1
 
-	// identityKeyIndex is the top level HD key index which is used to
-	// generate/rotate identity keys.
-	//
-	// TODO(roasbeef): should instead be child to make room for future
-	// rotations, etc.
-	identityKeyIndex = hdkeychain.HardenedKeyStart + 2
-
 	// @CC: disable fees for PoC simplification
 	commitFee = 0
 )
@@ -48,6 +45,23 @@ var (
 	ErrInsufficientFunds = errors.New("not enough available outputs to " +
 		"create funding transaction")
 
+	// ErrUnconfirmedFunds is returned when the wallet would be able to
+	// fund a channel, but only by spending outputs which haven't yet
+	// reached the required number of confirmations.
+	ErrUnconfirmedFunds = errors.New("not enough sufficiently confirmed " +
+		"outputs to create funding transaction, remaining funds are " +
+		"awaiting confirmation")
+
+	// ErrNotFundingInitiator is returned when an attempt is made to
+	// reclaim the inputs of a funding transaction which we didn't create.
+	ErrNotFundingInitiator = errors.New("only the initiator of a " +
+		"channel is able to spend its funding inputs")
+
+	// ErrNoFundingChange is returned when an attempt is made to bump the
+	// fee of a funding transaction which lacks a change output to spend.
+	ErrNoFundingChange = errors.New("funding transaction has no change " +
+		"output to bump its fee with")
+
 	// Namespace bucket keys.
 	lightningNamespaceKey = []byte("ln-wallet")
 	waddrmgrNamespaceKey  = []byte("waddrmgr")
@@ -240,6 +254,18 @@ type LightningWallet struct {
 	// double spend inputs accross each other.
 	coinSelectMtx sync.RWMutex
 
+	// coinSelectStrategy is the order in which unspent outputs are
+	// considered during coin selection. It's protected by the
+	// coinSelectMtx.
+	coinSelectStrategy CoinSelectionStrategy
+
+	// fundingMinConfs is the number of confirmations an output requires
+	// before it may be used to fund a channel. Spending unconfirmed
+	// outputs risks the funding transaction being invalidated should one
+	// of its parents be double spent. It's protected by the
+	// coinSelectMtx.
+	fundingMinConfs int32
+
 	// A wrapper around a namespace within boltdb reserved for ln-based
 	// wallet meta-data. See the 'channeldb' package for further
 	// information.
@@ -260,13 +286,18 @@ type LightningWallet struct {
 	// update the commitment state.
 	Signer Signer
 
+	// SigPool is the pool of workers shared by all channels to generate
+	// and validate commitment signatures using the wallet's Signer.
+	SigPool *SigPool
+
 	// chainIO is an instance of the BlockChainIO interface. chainIO is
 	// used to lookup the existance of outputs within the utxo set.
 	chainIO BlockChainIO
 
-	// rootKey is the root HD key dervied from a WalletController private
-	// key. This rootKey is used to derive all LN specific secrets.
-	rootKey *hdkeychain.ExtendedKey
+	// keyRing derives all LN specific secrets from the wallet's root key.
+	// If the wallet is watch-only, then the root key resides within a
+	// remote signer.
+	keyRing RootKeyRing
 
 	// All messages to the wallet are to be sent accross this channel.
 	msgChan chan interface{}
@@ -288,6 +319,11 @@ type LightningWallet struct {
 	// the currently locked outpoints.
 	lockedOutPoints map[wire.OutPoint]struct{}
 
+	// leasedOutputs is the set of outputs currently leased by external
+	// callers via LeaseOutput. Leased outputs are never selected to fund
+	// a channel or an on-chain send. It's protected by the coinSelectMtx.
+	leasedOutputs map[wire.OutPoint]*OutputLease
+
 	netParams *chaincfg.Params
 
 	started  int32
@@ -311,24 +347,29 @@ func NewLightningWallet(cdb *channeldb.DB, notifier chainntnfs.ChainNotifier,
 
 	// TODO(roasbeef): need a another wallet level config
 
-	// Fetch the root derivation key from the wallet's HD chain. We'll use
-	// this to generate specific Lightning related secrets on the fly.
-	rootKey, err := wallet.FetchRootKey()
+	keyRing, err := newHDKeyRing(wallet, netParams)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO(roasbeef): always re-derive on the fly?
-	rootKeyRaw := rootKey.Serialize()
-	rootMasterKey, err := hdkeychain.NewMaster(rootKeyRaw, netParams)
-	if err != nil {
-		return nil, err
-	}
+	return NewWatchOnlyLightningWallet(cdb, notifier, wallet, signer,
+		keyRing, bio), nil
+}
+
+// NewWatchOnlyLightningWallet creates a LightningWallet which never accesses
+// the private keys of the passed WalletController. Instead, all signatures are
+// generated by the passed Signer, and all LN specific secrets are derived by
+// the passed RootKeyRing, both of which are typically backed by a remote
+// signer holding the keys.
+func NewWatchOnlyLightningWallet(cdb *channeldb.DB,
+	notifier chainntnfs.ChainNotifier, wallet WalletController,
+	signer Signer, keyRing RootKeyRing, bio BlockChainIO) *LightningWallet {
 
 	return &LightningWallet{
-		rootKey:          rootMasterKey,
+		keyRing:          keyRing,
 		chainNotifier:    notifier,
 		Signer:           signer,
+		SigPool:          NewSigPool(runtime.NumCPU(), signer),
 		WalletController: wallet,
 		chainIO:          bio,
 		ChannelDB:        cdb,
@@ -336,8 +377,10 @@ func NewLightningWallet(cdb *channeldb.DB, notifier chainntnfs.ChainNotifier,
 		nextFundingID:    0,
 		fundingLimbo:     make(map[uint64]*ChannelReservation),
 		lockedOutPoints:  make(map[wire.OutPoint]struct{}),
+		leasedOutputs:    make(map[wire.OutPoint]*OutputLease),
+		fundingMinConfs:  DefaultFundingMinConfs,
 		quit:             make(chan struct{}),
-	}, nil
+	}
 }
 
 // Startup establishes a connection to the RPC source, and spins up all
@@ -353,6 +396,10 @@ func (l *LightningWallet) Startup() error {
 		return err
 	}
 
+	if err := l.SigPool.Start(); err != nil {
+		return err
+	}
+
 	l.wg.Add(1)
 	// TODO(roasbeef): multiple request handlers?
 	go l.requestHandler()
@@ -372,6 +419,10 @@ func (l *LightningWallet) Shutdown() error {
 		return err
 	}
 
+	if err := l.SigPool.Stop(); err != nil {
+		return err
+	}
+
 	close(l.quit)
 	l.wg.Wait()
 	return nil
@@ -410,15 +461,35 @@ func (l *LightningWallet) ActiveReservations() []*ChannelReservation {
 	return reservations
 }
 
-// GetIdentitykey returns the identity private key of the wallet.
+// GetIdentitykey returns the identity private key of the wallet. If the
+// wallet is watch-only, then ErrNoPrivateKeys is returned.
 // TODO(roasbeef): should be moved elsewhere
 func (l *LightningWallet) GetIdentitykey() (*btcec.PrivateKey, error) {
-	identityKey, err := l.rootKey.Child(identityKeyIndex)
-	if err != nil {
-		return nil, err
+	hdKeys, ok := l.keyRing.(*hdKeyRing)
+	if !ok {
+		return nil, ErrNoPrivateKeys
 	}
 
-	return identityKey.ECPrivKey()
+	return hdKeys.identityKey()
+}
+
+// IdentityPubKey returns the public key of the wallet's identity key.
+func (l *LightningWallet) IdentityPubKey() (*btcec.PublicKey, error) {
+	return l.keyRing.IdentityPubKey()
+}
+
+// DeriveKey returns the descriptor of the key within the wallet's keychain at
+// the target locator.
+func (l *LightningWallet) DeriveKey(
+	keyLoc keychain.KeyLocator) (keychain.KeyDescriptor, error) {
+
+	return l.keyRing.DeriveKey(keyLoc)
+}
+
+// MasterElkremRoot returns the private key which serves as the master elkrem
+// root of the wallet.
+func (l *LightningWallet) MasterElkremRoot() (*btcec.PrivateKey, error) {
+	return l.keyRing.MasterElkremRoot()
 }
 
 // requestHandler is the primary goroutine(s) resposible for handling, and
@@ -503,6 +574,7 @@ func (l *LightningWallet) handleFundingReserveRequest(req *initFundingReserveMsg
 	defer reservation.Unlock()
 
 	reservation.partialState.TheirLNID = req.nodeID
+	reservation.partialState.IsInitiator = req.fundingAmount != 0
 	ourContribution := reservation.ourContribution
 	ourContribution.CsvDelay = req.csvDelay
 	reservation.partialState.LocalCsvDelay = req.csvDelay
@@ -727,7 +799,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	pendingReservation.partialState.RemoteElkrem = e
 	pendingReservation.partialState.TheirCurrentRevocation = theirContribution.RevocationKey
 
-	masterElkremRoot, err := l.deriveMasterElkremRoot()
+	masterElkremRoot, err := l.keyRing.MasterElkremRoot()
 	if err != nil {
 		req.err <- err
 		return
@@ -758,8 +830,9 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	ourBalance := ourContribution.FundingAmount
 	theirBalance := theirContribution.FundingAmount
 	ourCommitKey := ourContribution.CommitKey
+	ourLease, theirLease := leaseExpiries(pendingReservation.partialState)
 	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, theirCommitKey,
-		ourRevokeKey, ourContribution.CsvDelay,
+		ourRevokeKey, ourContribution.CsvDelay, ourLease, theirLease,
 		ourBalance, theirBalance)
 	if err != nil {
 		req.err <- err
@@ -767,7 +840,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	}
 	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, ourCommitKey,
 		theirContribution.RevocationKey, theirContribution.CsvDelay,
-		theirBalance, ourBalance)
+		theirLease, ourLease, theirBalance, ourBalance)
 	if err != nil {
 		req.err <- err
 		return
@@ -799,6 +872,7 @@ func (l *LightningWallet) handleContributionMsg(req *addContributionMsg) {
 	// Record newly available information witin the open channel state.
 	pendingReservation.partialState.RemoteCsvDelay = theirContribution.CsvDelay
 	pendingReservation.partialState.TheirDeliveryScript = deliveryScript
+	pendingReservation.partialState.TheirUpfrontShutdown = theirContribution.UpfrontShutdown
 	pendingReservation.partialState.ChanID = fundingOutpoint
 	pendingReservation.partialState.TheirCommitKey = theirCommitKey
 	pendingReservation.partialState.TheirMultiSigKey = theirContribution.MultiSigKey
@@ -861,7 +935,7 @@ func (l *LightningWallet) handleSingleContribution(req *addSingleContributionMsg
 	}
 	pendingReservation.partialState.FundingRedeemScript = redeemScript
 
-	masterElkremRoot, err := l.deriveMasterElkremRoot()
+	masterElkremRoot, err := l.keyRing.MasterElkremRoot()
 	if err != nil {
 		req.err <- err
 		return
@@ -895,6 +969,7 @@ func (l *LightningWallet) handleSingleContribution(req *addSingleContributionMsg
 	}
 	pendingReservation.partialState.RemoteCsvDelay = theirContribution.CsvDelay
 	pendingReservation.partialState.TheirDeliveryScript = deliveryScript
+	pendingReservation.partialState.TheirUpfrontShutdown = theirContribution.UpfrontShutdown
 	pendingReservation.partialState.TheirCommitKey = theirContribution.CommitKey
 	pendingReservation.partialState.TheirMultiSigKey = theirContribution.MultiSigKey
 	pendingReservation.ourContribution.RevocationKey = ourRevokeKey
@@ -1019,8 +1094,20 @@ func (l *LightningWallet) handleFundingCounterPartySigs(msg *addCounterPartySigs
 		return
 	}
 
+	// Label the funding transaction so it can easily be identified within
+	// the wallet's transaction history. A failure here isn't fatal to the
+	// funding flow.
+	fundingPoint := pendingReservation.partialState.FundingOutpoint
+	err = l.LabelTransaction(fundingTx.TxSha(), FundingTxLabel(fundingPoint),
+		false)
+	if err != nil {
+		walletLog.Warnf("unable to label funding tx for "+
+			"ChannelPoint(%v): %v", fundingPoint, err)
+	}
+
 	// Add the complete funding transaction to the DB, in it's open bucket
 	// which will be used for the lifetime of this channel.
+	pendingReservation.partialState.CreationTime = time.Now()
 	if err := pendingReservation.partialState.FullSync(); err != nil {
 		msg.err <- err
 		return
@@ -1063,16 +1150,18 @@ func (l *LightningWallet) handleSingleFunderSigs(req *addSingleFunderSigsMsg) {
 	theirCommitKey := pendingReservation.theirContribution.CommitKey
 	ourBalance := pendingReservation.ourContribution.FundingAmount
 	theirBalance := pendingReservation.theirContribution.FundingAmount
+	ourLease, theirLease := leaseExpiries(pendingReservation.partialState)
 	ourCommitTx, err := CreateCommitTx(fundingTxIn, ourCommitKey, theirCommitKey,
 		pendingReservation.ourContribution.RevocationKey,
-		pendingReservation.ourContribution.CsvDelay, ourBalance, theirBalance)
+		pendingReservation.ourContribution.CsvDelay, ourLease, theirLease,
+		ourBalance, theirBalance)
 	if err != nil {
 		req.err <- err
 		return
 	}
 	theirCommitTx, err := CreateCommitTx(fundingTxIn, theirCommitKey, ourCommitKey,
 		req.revokeKey, pendingReservation.theirContribution.CsvDelay,
-		theirBalance, ourBalance)
+		theirLease, ourLease, theirBalance, ourBalance)
 	if err != nil {
 		req.err <- err
 		return
@@ -1175,6 +1264,9 @@ func (l *LightningWallet) handleChannelOpen(req *channelOpenMsg) {
 
 	// Add the complete funding transaction to the DB, in it's open bucket
 	// which will be used for the lifetime of this channel.
+	// TODO(roasbeef): set the short channel ID once the funding
+	// transaction confirms.
+	res.partialState.CreationTime = time.Now()
 	if err := res.partialState.FullSync(); err != nil {
 		req.err <- err
 		res.chanOpen <- nil
@@ -1182,8 +1274,8 @@ func (l *LightningWallet) handleChannelOpen(req *channelOpenMsg) {
 	}
 
 	// Finally, create and officially open the payment channel!
-	// TODO(roasbeef): CreationTime once tx is 'open'
-	channel, _ := NewLightningChannel(l.Signer, l.chainIO, l.chainNotifier, res.partialState)
+	channel, _ := NewLightningChannel(l.Signer, l.SigPool, l.chainIO,
+		l.chainNotifier, res.partialState)
 
 	res.chanOpen <- channel
 	req.err <- nil
@@ -1204,9 +1296,10 @@ func (l *LightningWallet) openChannelAfterConfirmations(res *ChannelReservation)
 
 	// Wait until the specified number of confirmations has been reached,
 	// or the wallet signals a shutdown.
+	var triggerHeight int32
 out:
 	select {
-	case _, ok := <-confNtfn.Confirmed:
+	case height, ok := <-confNtfn.Confirmed:
 		// Reading a falsey value for the second parameter indicates that
 		// the notifier is in the process of shutting down. Therefore, we
 		// don't count this as the signal that the funding transaction has
@@ -1216,19 +1309,247 @@ out:
 			return
 		}
 
+		triggerHeight = height
 		break out
 	case <-l.quit:
 		res.chanOpen <- nil
 		return
 	}
 
+	// With the funding transaction confirmed, locate it within the chain
+	// in order to record the channel's short channel ID. The notification
+	// is dispatched at the height of the final required confirmation, so
+	// the transaction itself was included numConfs-1 blocks prior.
+	confHeight := triggerHeight - int32(numConfs) + 1
+	shortChanID, err := l.fetchShortChanID(res.partialState.ChanID,
+		confHeight)
+	if err != nil {
+		walletLog.Errorf("Unable to locate funding tx (txid: %v): %v",
+			txid, err)
+	} else if err := res.partialState.SetShortChanID(*shortChanID); err != nil {
+		walletLog.Errorf("Unable to set short chan ID for "+
+			"ChannelPoint(%v): %v", res.partialState.ChanID, err)
+	}
+
 	// Finally, create and officially open the payment channel!
-	// TODO(roasbeef): CreationTime once tx is 'open'
-	channel, _ := NewLightningChannel(l.Signer, l.chainIO, l.chainNotifier,
-		res.partialState)
+	channel, _ := NewLightningChannel(l.Signer, l.SigPool, l.chainIO,
+		l.chainNotifier, res.partialState)
 	res.chanOpen <- channel
 }
 
+// ReclaimFundingInputs abandons a pending channel to which we're the
+// initiator, and whose funding transaction has failed to confirm. All of our
+// inputs to the funding transaction are double spent into a single fresh
+// output controlled by the wallet, at the passed fee rate expressed in
+// sat/byte. The state of the pending channel is kept until one of the two
+// transactions confirms: should the double spend confirm, the channel is to be
+// forgotten via ForgetReclaimedChannel, while should the funding transaction
+// confirm after all, the channel opens as usual.
+//
+// NOTE: If the funding transaction is still within the mempool of our chain
+// backend, then the double spend will be rejected and an error is returned.
+// In that case the funding transaction may yet confirm.
+func (l *LightningWallet) ReclaimFundingInputs(res *ChannelReservation,
+	feeRate btcutil.Amount) (*wire.ShaHash, error) {
+
+	res.Lock()
+	defer res.Unlock()
+
+	if !res.partialState.IsInitiator || res.fundingTx == nil {
+		return nil, ErrNotFundingInitiator
+	}
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	tx := wire.NewMsgTx()
+	var total btcutil.Amount
+	for _, txIn := range res.ourContribution.Inputs {
+		prevOut, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return nil, err
+		}
+
+		outPoint := txIn.PreviousOutPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+		total += btcutil.Amount(prevOut.Value)
+	}
+
+	fee := btcutil.Amount(estimateTxSize(len(tx.TxIn), 1)) * feeRate
+	if total-fee < DefaultDustLimit {
+		return nil, fmt.Errorf("funding inputs worth %v are unable "+
+			"to pay for a fee of %v", total, fee)
+	}
+
+	addr, err := l.NewAddress(WitnessPubKey, false)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	tx.AddTxOut(wire.NewTxOut(int64(total-fee), pkScript))
+
+	txid, err := l.signAndPublish(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingPoint := res.partialState.FundingOutpoint
+	err = l.LabelTransaction(*txid, FundingReclaimTxLabel(fundingPoint),
+		false)
+	if err != nil {
+		walletLog.Warnf("unable to label reclaim tx %v: %v", txid, err)
+	}
+
+	return txid, nil
+}
+
+// ForgetReclaimedChannel removes the state of a pending channel whose funding
+// inputs have been reclaimed by ReclaimFundingInputs, releasing the inputs.
+//
+// NOTE: This method MUST only be called once the double spend of the funding
+// inputs has confirmed, as until then the funding transaction may still
+// confirm in its place.
+func (l *LightningWallet) ForgetReclaimedChannel(res *ChannelReservation) error {
+	res.Lock()
+	defer res.Unlock()
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	for _, txIn := range res.ourContribution.Inputs {
+		delete(l.lockedOutPoints, txIn.PreviousOutPoint)
+		l.UnlockOutpoint(txIn.PreviousOutPoint)
+	}
+
+	return res.partialState.CloseChannel()
+}
+
+// BumpFundingFee raises the effective fee rate of the unconfirmed funding
+// transaction of a pending channel to which we're the initiator, using
+// child-pays-for-parent. A child transaction spending the change output of
+// the funding transaction back to the wallet is broadcast, paying a fee such
+// that both transactions together pay the passed fee rate, expressed in
+// sat/byte.
+//
+// NOTE: The child transaction can't be replaced, so the fee of a funding
+// transaction may only be bumped once.
+func (l *LightningWallet) BumpFundingFee(res *ChannelReservation,
+	feeRate btcutil.Amount) (*wire.ShaHash, error) {
+
+	res.Lock()
+	defer res.Unlock()
+
+	if !res.partialState.IsInitiator || res.fundingTx == nil {
+		return nil, ErrNotFundingInitiator
+	}
+	if len(res.ourContribution.ChangeOutputs) == 0 {
+		return nil, ErrNoFundingChange
+	}
+
+	fundingTx := res.fundingTx
+	changeScript := res.ourContribution.ChangeOutputs[0].PkScript
+	found, changeIndex := FindScriptOutputIndex(fundingTx, changeScript)
+	if !found {
+		return nil, ErrNoFundingChange
+	}
+	change := btcutil.Amount(fundingTx.TxOut[changeIndex].Value)
+
+	l.coinSelectMtx.Lock()
+	defer l.coinSelectMtx.Unlock()
+
+	// As we're the sole funder of the channel, all inputs to the funding
+	// transaction are ours, allowing us to compute the fee it already
+	// pays.
+	var inputTotal, outputTotal btcutil.Amount
+	for _, txIn := range fundingTx.TxIn {
+		prevOut, err := l.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return nil, err
+		}
+		inputTotal += btcutil.Amount(prevOut.Value)
+	}
+	for _, txOut := range fundingTx.TxOut {
+		outputTotal += btcutil.Amount(txOut.Value)
+	}
+	parentFee := inputTotal - outputTotal
+
+	// The child pays for the size of both transactions at the target fee
+	// rate, less what the parent already pays. However, it must always pay
+	// at least the target fee rate for its own size.
+	childSize := estimateTxSize(1, 1)
+	packageSize := fundingTx.SerializeSize() + childSize
+	childFee := btcutil.Amount(packageSize)*feeRate - parentFee
+	if minFee := btcutil.Amount(childSize) * feeRate; childFee < minFee {
+		childFee = minFee
+	}
+	if change-childFee < DefaultDustLimit {
+		return nil, fmt.Errorf("change output worth %v is unable to "+
+			"pay for a fee of %v", change, childFee)
+	}
+
+	addr, err := l.NewAddress(WitnessPubKey, true)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingTxID := fundingTx.TxSha()
+	tx := wire.NewMsgTx()
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&fundingTxID, changeIndex),
+		nil, nil))
+	tx.AddTxOut(wire.NewTxOut(int64(change-childFee), pkScript))
+
+	txid, err := l.signAndPublish(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	fundingPoint := res.partialState.FundingOutpoint
+	err = l.LabelTransaction(*txid, FundingFeeBumpTxLabel(fundingPoint),
+		false)
+	if err != nil {
+		walletLog.Warnf("unable to label fee bump tx %v: %v", txid, err)
+	}
+
+	return txid, nil
+}
+
+// fetchShortChanID locates the funding transaction of a channel within the
+// block at the passed height, returning the resulting short channel ID.
+func (l *LightningWallet) fetchShortChanID(fundingPoint *wire.OutPoint,
+	blockHeight int32) (*lnwire.ShortChannelID, error) {
+
+	blockHash, err := l.chainIO.GetBlockHash(int64(blockHeight))
+	if err != nil {
+		return nil, err
+	}
+	block, err := l.chainIO.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tx := range block.Transactions {
+		if tx.TxSha() != fundingPoint.Hash {
+			continue
+		}
+
+		return &lnwire.ShortChannelID{
+			BlockHeight: uint32(blockHeight),
+			TxIndex:     uint32(i),
+			TxPosition:  uint16(fundingPoint.Index),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("funding tx %v not found within block %v",
+		fundingPoint.Hash, blockHash)
+}
+
 // selectCoinsAndChange performs coin selection in order to obtain witness
 // outputs which sum to at least 'numCoins' amount of satoshis. If coin
 // selection is succesful/possible, then the selected coins are available
@@ -1244,19 +1565,35 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 	l.coinSelectMtx.Lock()
 	defer l.coinSelectMtx.Unlock()
 
-	// Find all unlocked unspent witness outputs with greater than 1
-	// confirmation.
-	// TODO(roasbeef): make num confs a configuration paramter
-	coins, err := l.ListUnspentWitness(1)
+	// Find all unlocked unspent witness outputs with at least the
+	// required number of confirmations.
+	coins, err := l.ListUnspentWitness(l.fundingMinConfs)
 	if err != nil {
 		return err
 	}
+	coins = l.filterLeased(coins)
+	orderCoins(coins, l.coinSelectStrategy, assetValue)
 
 	// Peform coin selection over our available, unlocked unspent outputs
 	// in order to find enough coins to meet the funding amount
 	// requirements.
 	selectedCoins, changeAmt, err := coinSelect(feeRate, amt, coins, globallyActiveAssetId)
-	if err != nil {
+	if err == ErrInsufficientFunds && l.fundingMinConfs > 0 {
+		// If the funding would succeed once our less confirmed
+		// outputs are taken into account, then we return a distinct
+		// error so the caller knows to simply wait.
+		allCoins, lerr := l.ListUnspentWitness(0)
+		if lerr != nil {
+			return lerr
+		}
+		allCoins = l.filterLeased(allCoins)
+		_, _, lerr = coinSelect(feeRate, amt, allCoins,
+			globallyActiveAssetId)
+		if lerr == nil {
+			return ErrUnconfirmedFunds
+		}
+		return err
+	} else if err != nil {
 		return err
 	}
 
@@ -1295,18 +1632,6 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 	return nil
 }
 
-// deriveMasterElkremRoot derives the private key which serves as the master
-// elkrem root. This master secret is used as the secret input to a HKDF to
-// generate elkrem secrets based on random, but public data.
-func (l *LightningWallet) deriveMasterElkremRoot() (*btcec.PrivateKey, error) {
-	masterElkremRoot, err := l.rootKey.Child(elkremRootIndex)
-	if err != nil {
-		return nil, err
-	}
-
-	return masterElkremRoot.ECPrivKey()
-}
-
 // selectInputs selects a slice of inputs necessary to meet the specified
 // selection amount. If input selectino is unable to suceed to to insuffcient
 // funds, a non-nil error is returned. Additionally, the total amount of the
@@ -13,7 +13,9 @@ import (
 // CloseRequest is sent by either side in order to initiate the cooperative
 // closure of a channel. This message is rather sparse as both side implicitly
 // know to craft a transaction sending the settled funds of both parties to the
-// final delivery addresses negotiated during the funding workflow.
+// final delivery addresses negotiated during the funding workflow. The
+// requester may optionally replace its own delivery address, unless it
+// committed to it as an upfront shutdown script during the funding workflow.
 //
 // NOTE: The requester is able to only send a signature to initiate the
 // cooperative channel closure as all transactions are assembled observing
@@ -33,6 +35,14 @@ type CloseRequest struct {
 	// timely channel closure.
 	// TODO(roasbeef): if initiator always pays fees, then no longer needed.
 	Fee btcutil.Amount
+
+	// DeliveryPkScript, if non-empty, is the public key script the
+	// requester would like to receive their balance to, replacing the one
+	// negotiated during the funding workflow. The requester's signature
+	// covers a closing transaction paying to this script. A requester
+	// which committed to an upfront shutdown script MUST leave this field
+	// empty.
+	DeliveryPkScript PkScript
 }
 
 // NewCloseRequest creates a new CloseRequest.
@@ -57,14 +67,22 @@ func (c *CloseRequest) Decode(r io.Reader, pver uint32) error {
 	// RequesterCloseSig (73)
 	// 	First byte length then sig
 	// Fee (8)
+	// DeliveryPkScript (final delivery)
 	err := readElements(r,
 		&c.ChannelPoint,
 		&c.RequesterCloseSig,
-		&c.Fee)
+		&c.Fee,
+		&c.DeliveryPkScript)
 	if err != nil {
 		return err
 	}
 
+	// An empty delivery script indicates the script negotiated during
+	// the funding workflow is to be used.
+	if len(c.DeliveryPkScript) == 0 {
+		c.DeliveryPkScript = nil
+	}
+
 	return nil
 }
 
@@ -76,10 +94,12 @@ func (c *CloseRequest) Encode(w io.Writer, pver uint32) error {
 	// ChannelID
 	// RequesterCloseSig
 	// Fee
+	// DeliveryPkScript
 	err := writeElements(w,
 		c.ChannelPoint,
 		c.RequesterCloseSig,
-		c.Fee)
+		c.Fee,
+		c.DeliveryPkScript)
 	if err != nil {
 		return err
 	}
@@ -100,8 +120,8 @@ func (c *CloseRequest) Command() uint32 {
 //
 // This is part of the lnwire.Message interface.
 func (c *CloseRequest) MaxPayloadLength(pver uint32) uint32 {
-	// 36 + 73 + 8
-	return 117
+	// 36 + 73 + 8 + 26
+	return 143
 }
 
 // Validate performs any necessary sanity checks to ensure all fields present
@@ -114,6 +134,13 @@ func (c *CloseRequest) Validate() error {
 		return fmt.Errorf("Fee must be greater than zero.")
 	}
 
+	// If present, the delivery pkScript must be amongst the supported
+	// script templates.
+	if c.DeliveryPkScript != nil && !isValidPkScript(c.DeliveryPkScript) {
+		return fmt.Errorf("Valid delivery public key scripts MUST be: " +
+			"P2PKH, P2WKH, P2SH, or P2WSH.")
+	}
+
 	// We're good!
 	return nil
 }
@@ -131,5 +158,6 @@ func (c *CloseRequest) String() string {
 		fmt.Sprintf("ChannelPoint:\t\t%v\n", c.ChannelPoint) +
 		fmt.Sprintf("CloseSig\t\t%x\n", serializedSig) +
 		fmt.Sprintf("Fee:\t\t\t%d\n", c.Fee) +
+		fmt.Sprintf("DeliveryPkScript\t\t%x\n", c.DeliveryPkScript) +
 		fmt.Sprintf("--- End CloseRequest ---\n")
 }
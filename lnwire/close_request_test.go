@@ -9,27 +9,33 @@ import (
 )
 
 func TestCloseRequestEncodeDecode(t *testing.T) {
-	cr := &CloseRequest{
-		ChannelPoint:      outpoint1,
-		RequesterCloseSig: commitSig,
-		Fee:               btcutil.Amount(10000),
-	}
+	// The message is tested both with, and without a replacement delivery
+	// script.
+	delivery := PkScript(bytes.Repeat([]byte{0x02}, 25))
+	for _, deliveryScript := range []PkScript{nil, delivery} {
+		cr := &CloseRequest{
+			ChannelPoint:      outpoint1,
+			RequesterCloseSig: commitSig,
+			Fee:               btcutil.Amount(10000),
+			DeliveryPkScript:  deliveryScript,
+		}
 
-	// Next encode the CR message into an empty bytes buffer.
-	var b bytes.Buffer
-	if err := cr.Encode(&b, 0); err != nil {
-		t.Fatalf("unable to encode CloseRequest: %v", err)
-	}
+		// Next encode the CR message into an empty bytes buffer.
+		var b bytes.Buffer
+		if err := cr.Encode(&b, 0); err != nil {
+			t.Fatalf("unable to encode CloseRequest: %v", err)
+		}
 
-	// Deserialize the encoded CR message into a new empty struct.
-	cr2 := &CloseRequest{}
-	if err := cr2.Decode(&b, 0); err != nil {
-		t.Fatalf("unable to decode CloseRequest: %v", err)
-	}
+		// Deserialize the encoded CR message into a new empty struct.
+		cr2 := &CloseRequest{}
+		if err := cr2.Decode(&b, 0); err != nil {
+			t.Fatalf("unable to decode CloseRequest: %v", err)
+		}
 
-	// Assert equality of the two instances.
-	if !reflect.DeepEqual(cr, cr2) {
-		t.Fatalf("encode/decode error messages don't match %#v vs %#v",
-			cr, cr2)
+		// Assert equality of the two instances.
+		if !reflect.DeepEqual(cr, cr2) {
+			t.Fatalf("encode/decode error messages don't match "+
+				"%#v vs %#v", cr, cr2)
+		}
 	}
 }
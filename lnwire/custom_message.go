@@ -0,0 +1,109 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+)
+
+// CustomTypeStart is the lowest command a custom message may be sent under.
+// Commands at or above CustomTypeStart are never assigned to messages of the
+// lightning protocol itself, leaving them free for applications to build
+// their own protocols upon.
+const CustomTypeStart = uint32(32768)
+
+// Custom is a message of a protocol built by an application on top of the
+// lightning transport, which is opaque to the daemon. Only odd commands may be
+// used, such that peers which don't understand a custom message may safely
+// ignore it.
+type Custom struct {
+	// Type is the command the message is sent under, which must be an
+	// odd command no lower than CustomTypeStart.
+	Type uint32
+
+	// Data is the content of the message.
+	Data []byte
+}
+
+// A compile time check to ensure Custom implements the lnwire.Message
+// interface.
+var _ Message = (*Custom)(nil)
+
+// NewCustom creates a new custom message of the passed type, returning an
+// error if the type is outside of the custom range, or is even.
+func NewCustom(msgType uint32, data []byte) (*Custom, error) {
+	if !IsCustomType(msgType) {
+		return nil, fmt.Errorf("custom message type %v must be odd, "+
+			"and at least %v", msgType, CustomTypeStart)
+	}
+
+	return &Custom{
+		Type: msgType,
+		Data: data,
+	}, nil
+}
+
+// IsCustomType returns true if the passed command may be used by a custom
+// message.
+func IsCustomType(command uint32) bool {
+	return command >= CustomTypeStart && command%2 == 1
+}
+
+// Decode deserializes a serialized Custom message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Decode(r io.Reader, pver uint32) error {
+	// Data
+	return readElements(r,
+		&c.Data,
+	)
+}
+
+// Encode serializes the target Custom message into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		c.Data,
+	)
+}
+
+// Command returns the integer uniquely identifying the Custom message on the
+// wire, which is its type.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Command() uint32 {
+	return c.Type
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a Custom
+// message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) MaxPayloadLength(uint32) uint32 {
+	// 3 + 65535
+	return 65538
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the Custom message are valid.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) Validate() error {
+	if !IsCustomType(c.Type) {
+		return fmt.Errorf("invalid custom message type %v", c.Type)
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target Custom message.
+//
+// This is part of the lnwire.Message interface.
+func (c *Custom) String() string {
+	return fmt.Sprintf("\n--- Begin Custom ---\n") +
+		fmt.Sprintf("Type:\t\t%d\n", c.Type) +
+		fmt.Sprintf("Data:\t\t%x\n", c.Data) +
+		fmt.Sprintf("--- End Custom ---\n")
+}
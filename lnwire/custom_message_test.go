@@ -0,0 +1,42 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestCustomEncodeDecode(t *testing.T) {
+	custom, err := NewCustom(CustomTypeStart+1, []byte("hello"))
+	if err != nil {
+		t.Fatalf("unable to create custom message: %v", err)
+	}
+
+	// Next encode the message, header included, into an empty bytes
+	// buffer.
+	var b bytes.Buffer
+	if _, err := WriteMessage(&b, custom, 0, 0); err != nil {
+		t.Fatalf("unable to encode Custom: %v", err)
+	}
+
+	// Read the message back, which should be recognized as a custom
+	// message by its type alone.
+	_, msg, _, err := ReadMessage(&b, 0, 0)
+	if err != nil {
+		t.Fatalf("unable to decode Custom: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(custom, msg) {
+		t.Fatalf("encode/decode custom messages don't match %#v vs %#v",
+			custom, msg)
+	}
+
+	// Custom messages may only be sent under odd types within the custom
+	// range.
+	for _, msgType := range []uint32{CmdErrorGeneric, CustomTypeStart} {
+		if _, err := NewCustom(msgType, nil); err == nil {
+			t.Fatalf("custom message of type %v accepted", msgType)
+		}
+	}
+}
@@ -7,6 +7,31 @@ import (
 	"github.com/roasbeef/btcd/wire"
 )
 
+// ErrorCode represents the short error code for each of the defined errors
+// which may be sent within an ErrorGeneric message.
+type ErrorCode uint16
+
+const (
+	// ErrChanTooSmall is returned by the responder of a funding workflow
+	// when the proposed channel is below its minimum accepted size.
+	ErrChanTooSmall ErrorCode = 1
+
+	// ErrChanTooLarge is returned by the responder of a funding workflow
+	// when the proposed channel is above its maximum accepted size.
+	ErrChanTooLarge ErrorCode = 2
+
+	// ErrMaxPendingChannels is returned by the responder of a funding
+	// workflow when the initiator already has the maximum number of
+	// channels awaiting confirmation with it.
+	ErrMaxPendingChannels ErrorCode = 3
+
+	// ErrUpfrontShutdownViolation is returned by the responder of a
+	// cooperative close when the initiator requests a delivery script
+	// other than the upfront shutdown script it committed to during the
+	// funding workflow.
+	ErrUpfrontShutdownViolation ErrorCode = 4
+)
+
 // ErrorGeneric represents a generic error bound to an exact channel. The
 // message format is purposefully general in order to allow expressino of a wide
 // array of possible errors. Each ErrorGeneric message is directed at a particular
@@ -17,6 +42,11 @@ type ErrorGeneric struct {
 	// the entire established connection.
 	ChannelPoint *wire.OutPoint
 
+	// PendingChannelID allows peers to send errors concerning a channel
+	// whose funding workflow is still in progress, and which therefore
+	// lacks a ChannelPoint.
+	PendingChannelID uint64
+
 	// ErrorID quickly defines the nature of the error according to error
 	// type.
 	ErrorID uint16
@@ -42,9 +72,12 @@ var _ Message = (*ErrorGeneric)(nil)
 // This is part of the lnwire.Message interface.
 func (c *ErrorGeneric) Decode(r io.Reader, pver uint32) error {
 	// ChannelPoint(8)
+	// PendingChannelID(8)
+	// ErrorID(2)
 	// Problem
 	err := readElements(r,
 		&c.ChannelPoint,
+		&c.PendingChannelID,
 		&c.ErrorID,
 		&c.Problem,
 	)
@@ -62,6 +95,7 @@ func (c *ErrorGeneric) Decode(r io.Reader, pver uint32) error {
 func (c *ErrorGeneric) Encode(w io.Writer, pver uint32) error {
 	err := writeElements(w,
 		c.ChannelPoint,
+		c.PendingChannelID,
 		c.ErrorID,
 		c.Problem,
 	)
@@ -85,8 +119,8 @@ func (c *ErrorGeneric) Command() uint32 {
 //
 // This is part of the lnwire.Message interface.
 func (c *ErrorGeneric) MaxPayloadLength(uint32) uint32 {
-	// 8+8192
-	return 8208
+	// 8+8+8192
+	return 8216
 }
 
 // Validate performs any necessary sanity checks to ensure all fields present
@@ -108,6 +142,7 @@ func (c *ErrorGeneric) Validate() error {
 func (c *ErrorGeneric) String() string {
 	return fmt.Sprintf("\n--- Begin ErrorGeneric ---\n") +
 		fmt.Sprintf("ChannelPoint:\t%d\n", c.ChannelPoint) +
+		fmt.Sprintf("PendingChannelID:\t%d\n", c.PendingChannelID) +
 		fmt.Sprintf("ErrorID:\t%d\n", c.ErrorID) +
 		fmt.Sprintf("Problem:\t%s\n", c.Problem) +
 		fmt.Sprintf("--- End ErrorGeneric ---\n")
@@ -0,0 +1,208 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// FeatureBit represents a feature that can be enabled in either a local or
+// global feature vector at a specific bit position. Feature bits follow the
+// "it's OK to be odd" rule, where features at even bit positions must be
+// known to a node receiving them from a peer while odd bits do not. In
+// accordance, feature bits are usually assigned in pairs, first being assigned
+// an odd bit position which may later be changed to the preceding even bit
+// position once the feature is required.
+type FeatureBit uint16
+
+const (
+	// PaymentAddrRequired is a required feature bit that signals that a
+	// node requires payment addresses, which are used to mitigate probing
+	// attacks on the receiver of a payment.
+	PaymentAddrRequired FeatureBit = 14
+
+	// PaymentAddrOptional is an optional feature bit that signals that a
+	// node supports payment addresses, which are used to mitigate probing
+	// attacks on the receiver of a payment.
+	PaymentAddrOptional FeatureBit = 15
+
+	// WumboChannelsRequired is a required feature bit that signals that a
+	// node requires channels larger than the historical maximum channel
+	// size of 2^24 - 1 satoshis.
+	WumboChannelsRequired FeatureBit = 18
+
+	// WumboChannelsOptional is an optional feature bit that signals that
+	// a node is willing to accept channels larger than the historical
+	// maximum channel size of 2^24 - 1 satoshis.
+	WumboChannelsOptional FeatureBit = 19
+
+	// OnionMessagesRequired is a required feature bit that signals that a
+	// node requires its peers to relay onion messages.
+	OnionMessagesRequired FeatureBit = 38
+
+	// OnionMessagesOptional is an optional feature bit that signals that
+	// a node relays onion messages, and accepts those addressed to it.
+	OnionMessagesOptional FeatureBit = 39
+)
+
+// Features is a mapping of known feature bits to a descriptive name. All known
+// feature bits must be assigned a name in this mapping. Feature bits not
+// present within this map are considered unknown.
+var Features = map[FeatureBit]string{
+	PaymentAddrRequired:   "payment-addr",
+	PaymentAddrOptional:   "payment-addr",
+	WumboChannelsRequired: "wumbo-channels",
+	WumboChannelsOptional: "wumbo-channels",
+	OnionMessagesRequired: "onion-messages",
+	OnionMessagesOptional: "onion-messages",
+}
+
+// IsRequired returns true if the feature bit is even, and false otherwise.
+func (b FeatureBit) IsRequired() bool {
+	return b&0x01 == 0x00
+}
+
+// String returns a human readable name for the feature bit, falling back to
+// the bit position if the feature is unknown.
+func (b FeatureBit) String() string {
+	if name, ok := Features[b]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint16(b))
+}
+
+// FeatureVector represents a set of feature bits advertised by a node, either
+// within the init message sent upon connection, or within its announcement.
+type FeatureVector struct {
+	features map[FeatureBit]struct{}
+}
+
+// NewFeatureVector creates a new feature vector with the passed feature bits
+// set.
+func NewFeatureVector(bits ...FeatureBit) *FeatureVector {
+	fv := &FeatureVector{
+		features: make(map[FeatureBit]struct{}),
+	}
+	for _, bit := range bits {
+		fv.Set(bit)
+	}
+
+	return fv
+}
+
+// Set marks the target feature bit as set within the vector.
+func (fv *FeatureVector) Set(bit FeatureBit) {
+	fv.features[bit] = struct{}{}
+}
+
+// Unset clears the target feature bit within the vector.
+func (fv *FeatureVector) Unset(bit FeatureBit) {
+	delete(fv.features, bit)
+}
+
+// IsSet returns true if the precise feature bit is set within the vector.
+func (fv *FeatureVector) IsSet(bit FeatureBit) bool {
+	_, ok := fv.features[bit]
+	return ok
+}
+
+// HasFeature returns true if either the required or optional variant of the
+// passed feature bit is set within the vector.
+func (fv *FeatureVector) HasFeature(bit FeatureBit) bool {
+	return fv.IsSet(bit&^0x01) || fv.IsSet(bit|0x01)
+}
+
+// Bits returns all the feature bits set within the vector in ascending order.
+func (fv *FeatureVector) Bits() []FeatureBit {
+	bits := make([]FeatureBit, 0, len(fv.features))
+	for bit := range fv.features {
+		bits = append(bits, bit)
+	}
+	sort.Sort(sortableBits(bits))
+
+	return bits
+}
+
+// sortableBits is a slice of feature bits which implements sort.Interface,
+// sorting the bits in ascending order.
+type sortableBits []FeatureBit
+
+func (s sortableBits) Len() int           { return len(s) }
+func (s sortableBits) Less(i, j int) bool { return s[i] < s[j] }
+func (s sortableBits) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// UnknownRequiredFeatures returns all the required feature bits set within
+// the vector which aren't known to us. A peer advertising any unknown required
+// features must be disconnected.
+func (fv *FeatureVector) UnknownRequiredFeatures() []FeatureBit {
+	var unknown []FeatureBit
+	for _, bit := range fv.Bits() {
+		if _, ok := Features[bit]; !ok && bit.IsRequired() {
+			unknown = append(unknown, bit)
+		}
+	}
+
+	return unknown
+}
+
+// Clone returns a deep copy of the feature vector.
+func (fv *FeatureVector) Clone() *FeatureVector {
+	return NewFeatureVector(fv.Bits()...)
+}
+
+// Encode serializes the feature vector into the passed io.Writer. The vector
+// is encoded as a variable length bit field, where the feature bit at
+// position i is found at bit (i % 8) of the byte (i / 8) positions from the
+// end of the field.
+func (fv *FeatureVector) Encode(w io.Writer) error {
+	var numBytes int
+	for bit := range fv.features {
+		if int(bit)/8+1 > numBytes {
+			numBytes = int(bit)/8 + 1
+		}
+	}
+
+	field := make([]byte, numBytes)
+	for bit := range fv.features {
+		field[numBytes-int(bit)/8-1] |= 1 << (bit % 8)
+	}
+
+	return wire.WriteVarBytes(w, 0, field)
+}
+
+// Decode deserializes a feature vector from the passed io.Reader, replacing
+// any existing bits within the vector.
+func (fv *FeatureVector) Decode(r io.Reader) error {
+	field, err := wire.ReadVarBytes(r, 0, MaxSliceLength, "features")
+	if err != nil {
+		return err
+	}
+
+	fv.features = make(map[FeatureBit]struct{})
+	for i, b := range field {
+		for j := uint(0); j < 8; j++ {
+			if b&(1<<j) == 0 {
+				continue
+			}
+
+			bit := FeatureBit((len(field)-i-1)*8 + int(j))
+			fv.features[bit] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// String returns a human readable representation of the feature vector.
+func (fv *FeatureVector) String() string {
+	strs := make([]string, 0, len(fv.features))
+	for _, bit := range fv.Bits() {
+		strs = append(strs, fmt.Sprintf("%d(%v)", bit, bit))
+	}
+
+	return strings.Join(strs, ", ")
+}
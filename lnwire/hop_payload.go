@@ -0,0 +1,144 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// CustomRecordStart is the lowest type a custom record within a HopPayload
+// may be given. Lower types are reserved for fields of the payload defined by
+// the protocol itself.
+const CustomRecordStart = uint64(65536)
+
+// MaxCustomRecordsSize is the maximum number of bytes the custom records
+// within a HopPayload may occupy, ensuring the payload always fits within the
+// OnionBlob of an HTLCAddRequest.
+const MaxCustomRecordsSize = 4096
+
+// HopPayload is the set of instructions intended for a particular hop within
+// the route of an HTLC. The payload destined for the final hop carries the
+// payment secret of the invoice being paid, which proves to the receiver that
+// the sender obtained the invoice itself, rather than merely learning the
+// payment hash. This prevents intermediate nodes from probing whether the
+// destination knows the preimage for a payment hash they've observed.
+//
+// TODO(roasbeef): wrap within the sphinx mix-header once onion routing is in
+// place, currently the payload is sent in the clear within the OnionBlob.
+type HopPayload struct {
+	// PaymentSecret is the secret contained within the invoice being paid.
+	// This field is only populated within the payload of the final hop.
+	PaymentSecret [32]byte
+
+	// FailureSecret is a secret chosen by the sender for this payment
+	// attempt, from which each hop derives the key it encrypts any
+	// failure sent back to the sender with.
+	FailureSecret [32]byte
+
+	// CustomRecords is a set of records attached to the payment by the
+	// sender, keyed by their type, which must be at least
+	// CustomRecordStart. The records are opaque to the daemon, and are
+	// passed on to applications built on top of it by the final hop.
+	CustomRecords map[uint64][]byte
+}
+
+// Decode deserializes a serialized HopPayload stored in the passed io.Reader.
+func (h *HopPayload) Decode(r io.Reader) error {
+	// PaymentSecret (32)
+	// FailureSecret (32)
+	err := readElements(r,
+		&h.PaymentSecret,
+		&h.FailureSecret,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The custom records are optional, and omitted entirely by senders
+	// which don't attach any.
+	var numRecords uint16
+	err = readElement(r, &numRecords)
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	h.CustomRecords = make(map[uint64][]byte, numRecords)
+	var lastType uint64
+	for i := uint16(0); i < numRecords; i++ {
+		var (
+			recordType uint64
+			value      []byte
+		)
+		if err := readElements(r, &recordType, &value); err != nil {
+			return err
+		}
+
+		// Records are sorted by type, so each type must be greater
+		// than the last.
+		if recordType < CustomRecordStart ||
+			(i > 0 && recordType <= lastType) {
+
+			return fmt.Errorf("invalid custom record type %v",
+				recordType)
+		}
+		lastType = recordType
+
+		h.CustomRecords[recordType] = value
+	}
+
+	return nil
+}
+
+// Encode serializes the target HopPayload into the passed io.Writer.
+func (h *HopPayload) Encode(w io.Writer) error {
+	err := writeElements(w,
+		h.PaymentSecret,
+		h.FailureSecret,
+	)
+	if err != nil {
+		return err
+	}
+	if len(h.CustomRecords) == 0 {
+		return nil
+	}
+
+	recordTypes := make([]uint64, 0, len(h.CustomRecords))
+	var recordsSize int
+	for recordType, value := range h.CustomRecords {
+		if recordType < CustomRecordStart {
+			return fmt.Errorf("custom record type %v is below %v",
+				recordType, CustomRecordStart)
+		}
+		recordTypes = append(recordTypes, recordType)
+
+		// Type (8) + Length (at most 3) + Value
+		recordsSize += 8 + 3 + len(value)
+	}
+	if recordsSize > MaxCustomRecordsSize {
+		return fmt.Errorf("custom records exceed %v bytes",
+			MaxCustomRecordsSize)
+	}
+	sort.Sort(sortableRecordTypes(recordTypes))
+
+	if err := writeElement(w, uint16(len(recordTypes))); err != nil {
+		return err
+	}
+	for _, recordType := range recordTypes {
+		err := writeElements(w, recordType, h.CustomRecords[recordType])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sortableRecordTypes is a slice of custom record types which implements
+// sort.Interface, sorting the types in ascending order.
+type sortableRecordTypes []uint64
+
+func (s sortableRecordTypes) Len() int           { return len(s) }
+func (s sortableRecordTypes) Less(i, j int) bool { return s[i] < s[j] }
+func (s sortableRecordTypes) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
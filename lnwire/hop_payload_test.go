@@ -0,0 +1,57 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestHopPayloadEncodeDecode(t *testing.T) {
+	payload := &HopPayload{
+		PaymentSecret: revHash,
+		FailureSecret: [32]byte{1, 2, 3},
+	}
+
+	var b bytes.Buffer
+	if err := payload.Encode(&b); err != nil {
+		t.Fatalf("unable to encode HopPayload: %v", err)
+	}
+
+	payload2 := &HopPayload{}
+	if err := payload2.Decode(&b); err != nil {
+		t.Fatalf("unable to decode HopPayload: %v", err)
+	}
+
+	if !reflect.DeepEqual(payload, payload2) {
+		t.Fatalf("encode/decode hop payloads don't match %#v vs %#v",
+			payload, payload2)
+	}
+
+	// Attach a set of custom records to the payload, which should survive
+	// the round trip.
+	payload.CustomRecords = map[uint64][]byte{
+		CustomRecordStart + 1: []byte("world"),
+		CustomRecordStart:     []byte("hello"),
+	}
+
+	b.Reset()
+	if err := payload.Encode(&b); err != nil {
+		t.Fatalf("unable to encode HopPayload: %v", err)
+	}
+
+	payload2 = &HopPayload{}
+	if err := payload2.Decode(&b); err != nil {
+		t.Fatalf("unable to decode HopPayload: %v", err)
+	}
+
+	if !reflect.DeepEqual(payload, payload2) {
+		t.Fatalf("encode/decode hop payloads don't match %#v vs %#v",
+			payload, payload2)
+	}
+
+	// Custom records may not use the types reserved by the protocol.
+	payload.CustomRecords[CustomRecordStart-1] = nil
+	if err := payload.Encode(&b); err == nil {
+		t.Fatalf("custom record with reserved type accepted")
+	}
+}
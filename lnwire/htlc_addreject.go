@@ -11,7 +11,8 @@ import (
 // Alice attempted to add via an HTLCAddRequest message. The rejected HTLC is
 // referenced by its unique HTLCKey ID. An HTLCAddReject message is bound to a
 // single active channel, referenced by a unique ChannelPoint. Additionally, the
-// HTLCKey of the rejected HTLC is present
+// HTLCKey of the rejected HTLC is present, along with an encrypted reason
+// detailing why the HTLC was rejected.
 type HTLCAddReject struct {
 	// ChannelPoint references the particular active channel to which this
 	// HTLCAddReject message is binded to.
@@ -20,6 +21,13 @@ type HTLCAddReject struct {
 	// HTLCKey is used to identify which HTLC previously attempted to be
 	// added via an HTLCAddRequest message is being declined.
 	HTLCKey HTLCKey
+
+	// Reason is an encrypted, serialized FailureMessage which describes
+	// why the HTLC was rejected. The failure is encrypted by the hop which
+	// generated it, then re-encrypted by each hop along the reverse route,
+	// therefore only the origin of the payment is able to decrypt it and
+	// attribute it to a particular hop.
+	Reason []byte
 }
 
 // Decode deserializes a serialized HTLCAddReject message stored in the passed
@@ -29,9 +37,11 @@ type HTLCAddReject struct {
 func (c *HTLCAddReject) Decode(r io.Reader, pver uint32) error {
 	// ChannelPoint (8)
 	// HTLCKey   (8)
+	// Reason (var)
 	err := readElements(r,
 		&c.ChannelPoint,
 		&c.HTLCKey,
+		&c.Reason,
 	)
 	if err != nil {
 		return err
@@ -57,6 +67,7 @@ func (c *HTLCAddReject) Encode(w io.Writer, pver uint32) error {
 	err := writeElements(w,
 		c.ChannelPoint,
 		c.HTLCKey,
+		c.Reason,
 	)
 
 	if err != nil {
@@ -79,8 +90,8 @@ func (c *HTLCAddReject) Command() uint32 {
 //
 // This is part of the lnwire.Message interface.
 func (c *HTLCAddReject) MaxPayloadLength(uint32) uint32 {
-	// 36 + 8
-	return 44
+	// 36 + 8 + 3 + 65535
+	return 65582
 }
 
 // Validate performs any necessary sanity checks to ensure all fields present
@@ -99,5 +110,6 @@ func (c *HTLCAddReject) String() string {
 	return fmt.Sprintf("\n--- Begin HTLCAddReject ---\n") +
 		fmt.Sprintf("ChannelPoint:\t\t%d\n", c.ChannelPoint) +
 		fmt.Sprintf("HTLCKey:\t\t%d\n", c.HTLCKey) +
+		fmt.Sprintf("Reason:\t\t%x\n", c.Reason) +
 		fmt.Sprintf("--- End HTLCAddReject ---\n")
 }
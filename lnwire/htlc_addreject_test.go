@@ -11,6 +11,7 @@ func TestHTLCAddRejectEncodeDecode(t *testing.T) {
 	rejectReq := &HTLCAddReject{
 		ChannelPoint: outpoint1,
 		HTLCKey:      22,
+		Reason:       []byte{0x01, 0x02, 0x03, 0x04},
 	}
 
 	// Next encode the HTLCAR message into an empty bytes buffer.
@@ -0,0 +1,84 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+)
+
+// Init is the first message sent by each side of a new connection. The
+// message advertises the set of features supported, or required, by the
+// sending node. No other messages may be sent until both sides have exchanged
+// their Init messages, allowing each side to disconnect immediately if the
+// other requires a feature it doesn't understand.
+type Init struct {
+	// Features is the set of features advertised by the sending node.
+	Features *FeatureVector
+}
+
+// NewInitMessage creates a new Init message advertising the passed feature
+// vector.
+func NewInitMessage(features *FeatureVector) *Init {
+	return &Init{
+		Features: features,
+	}
+}
+
+// A compile time check to ensure Init implements the lnwire.Message
+// interface.
+var _ Message = (*Init)(nil)
+
+// Decode deserializes a serialized Init message stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Decode(r io.Reader, pver uint32) error {
+	// Features (var)
+	msg.Features = NewFeatureVector()
+	return msg.Features.Decode(r)
+}
+
+// Encode serializes the target Init into the passed io.Writer observing the
+// protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Encode(w io.Writer, pver uint32) error {
+	return msg.Features.Encode(w)
+}
+
+// Command returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Command() uint32 {
+	return CmdInit
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for an Init
+// complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) MaxPayloadLength(uint32) uint32 {
+	// 3 + 65535
+	return 65538
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the Init are valid.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) Validate() error {
+	if msg.Features == nil {
+		return fmt.Errorf("init message must contain a feature vector")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target Init.
+//
+// This is part of the lnwire.Message interface.
+func (msg *Init) String() string {
+	return fmt.Sprintf("\n--- Begin Init ---\n") +
+		fmt.Sprintf("Features:\t\t%v\n", msg.Features) +
+		fmt.Sprintf("--- End Init ---\n")
+}
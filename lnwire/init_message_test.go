@@ -0,0 +1,46 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestInitEncodeDecode(t *testing.T) {
+	// Set both a known feature bit, and an unknown bit far into the
+	// vector to ensure the bit field is sized properly.
+	initMsg := NewInitMessage(NewFeatureVector(PaymentAddrOptional, 100))
+
+	var b bytes.Buffer
+	if err := initMsg.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode Init: %v", err)
+	}
+
+	initMsg2 := &Init{}
+	if err := initMsg2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode Init: %v", err)
+	}
+
+	if !reflect.DeepEqual(initMsg, initMsg2) {
+		t.Fatalf("encode/decode initMsg messages don't match %v vs %v",
+			initMsg, initMsg2)
+	}
+}
+
+func TestFeatureVectorUnknownRequired(t *testing.T) {
+	fv := NewFeatureVector(PaymentAddrRequired, 101)
+	if unknown := fv.UnknownRequiredFeatures(); len(unknown) != 0 {
+		t.Fatalf("expected no unknown required features, got %v",
+			unknown)
+	}
+	if !fv.HasFeature(PaymentAddrOptional) {
+		t.Fatalf("vector should have payment-addr feature")
+	}
+
+	fv.Set(100)
+	unknown := fv.UnknownRequiredFeatures()
+	if len(unknown) != 1 || unknown[0] != 100 {
+		t.Fatalf("expected unknown required feature 100, got %v",
+			unknown)
+	}
+}
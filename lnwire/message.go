@@ -22,6 +22,9 @@ const MaxMessagePayload = 1024 * 1024 * 32 //  32MB
 
 // Commands used in lightning message headers which detail the type of message.
 const (
+	// Commands for connection setup.
+	CmdInit = uint32(10)
+
 	// Commands for opening a channel funded by one party (single funder).
 	CmdSingleFundingRequest      = uint32(100)
 	CmdSingleFundingResponse     = uint32(110)
@@ -54,6 +57,9 @@ const (
 
 	// Commands for reporting protocol errors.
 	CmdErrorGeneric = uint32(4000)
+
+	// Commands for relaying messages independently of HTLCs.
+	CmdOnionMessage = uint32(5000)
 )
 
 // Message is an interface that defines a lightning wire protocol message. The
@@ -74,6 +80,8 @@ func makeEmptyMessage(command uint32) (Message, error) {
 	var msg Message
 
 	switch command {
+	case CmdInit:
+		msg = &Init{}
 	case CmdSingleFundingRequest:
 		msg = &SingleFundingRequest{}
 	case CmdSingleFundingResponse:
@@ -114,8 +122,15 @@ func makeEmptyMessage(command uint32) (Message, error) {
 		msg = &RoutingTableRequestMessage{}
 	case CmdRoutingTableTransferMessage:
 		msg = &RoutingTableTransferMessage{}
+	case CmdOnionMessage:
+		msg = &OnionMessage{}
 	default:
-		return nil, fmt.Errorf("unhandled command [%d]", command)
+		// Any command within the custom range is passed on to the
+		// application as an opaque custom message.
+		if !IsCustomType(command) {
+			return nil, fmt.Errorf("unhandled command [%d]", command)
+		}
+		msg = &Custom{Type: command}
 	}
 
 	return msg, nil
@@ -0,0 +1,143 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+)
+
+// FailCode specifies the precise reason that an upstream HTLC was rejected.
+// The two most significant bytes of the code carry a set of flags which allow
+// the origin of a payment to handle classes of failures without needing to
+// understand every specific code.
+type FailCode uint16
+
+const (
+	// FlagBadOnion signals that the failure occurred as the onion blob
+	// could not be parsed by the failing hop.
+	FlagBadOnion FailCode = 0x8000
+
+	// FlagPerm signals that the failure is permanent, and the payment
+	// shouldn't be retried along the same route.
+	FlagPerm FailCode = 0x4000
+
+	// FlagNode signals that the failure was caused by the failing node
+	// itself, rather than one of its channels.
+	FlagNode FailCode = 0x2000
+
+	// FlagUpdate signals that the failure was caused by an outdated view
+	// of a channel's parameters by the origin of the payment.
+	FlagUpdate FailCode = 0x1000
+)
+
+const (
+	// CodeNone indicates that no failure code was provided.
+	CodeNone FailCode = 0
+
+	// CodeTemporaryNodeFailure indicates that the failing node was unable
+	// to process the HTLC due to a transient condition.
+	CodeTemporaryNodeFailure = FlagNode | 2
+
+	// CodeUnknownNextPeer indicates that the failing node wasn't
+	// connected to the next hop within the route.
+	CodeUnknownNextPeer = FlagPerm | 10
+
+	// CodeTemporaryChannelFailure indicates that the outgoing channel of
+	// the failing node was unable to carry the HTLC, for example due to
+	// insufficient available bandwidth.
+	CodeTemporaryChannelFailure = FlagUpdate | 7
+
+	// CodeFeeInsufficient indicates that the fee paid to the failing node
+	// was less than the fee it requires to forward the HTLC.
+	CodeFeeInsufficient = FlagUpdate | 12
+
+	// CodeIncorrectPaymentDetails indicates that the final node doesn't
+	// know of the payment hash, or that the amount of the HTLC doesn't
+	// match the amount it was expecting.
+	CodeIncorrectPaymentDetails = FlagPerm | 15
+
+	// CodeFinalExpiryTooSoon indicates that the HTLC arrived at the final
+	// node with fewer blocks remaining until its expiry than the final
+	// node requires.
+	CodeFinalExpiryTooSoon FailCode = 17
+
+	// CodeExpiryTooFar indicates that the expiry of the HTLC is further in
+	// the future than the failing node is willing to time lock its funds
+	// for.
+	CodeExpiryTooFar FailCode = 21
+)
+
+// String returns a human readable representation of the failure code.
+func (c FailCode) String() string {
+	switch c {
+	case CodeNone:
+		return "None"
+	case CodeTemporaryNodeFailure:
+		return "TemporaryNodeFailure"
+	case CodeUnknownNextPeer:
+		return "UnknownNextPeer"
+	case CodeTemporaryChannelFailure:
+		return "TemporaryChannelFailure"
+	case CodeFeeInsufficient:
+		return "FeeInsufficient"
+	case CodeIncorrectPaymentDetails:
+		return "IncorrectPaymentDetails"
+	case CodeFinalExpiryTooSoon:
+		return "FinalExpiryTooSoon"
+	case CodeExpiryTooFar:
+		return "ExpiryTooFar"
+	default:
+		return fmt.Sprintf("<unknown failure code: %d>", uint16(c))
+	}
+}
+
+// FailureMessage describes the reason a particular hop was unable to forward
+// or accept an HTLC. A serialized FailureMessage is sent back to the origin of
+// the payment within the Reason field of an HTLCAddReject message, encrypted
+// by each hop along the reverse route such that only the origin is able to
+// read it.
+type FailureMessage struct {
+	// Code is the precise reason the HTLC was rejected.
+	Code FailCode
+
+	// Amount is the value of the HTLC as seen by the failing hop. This
+	// field is populated for failures which concern the amount of the
+	// HTLC, allowing the origin to determine the discrepancy.
+	Amount CreditsAmount
+}
+
+// Decode deserializes a serialized FailureMessage stored in the passed
+// io.Reader.
+func (f *FailureMessage) Decode(r io.Reader) error {
+	// Code (2)
+	// Amount (8)
+	var code uint16
+	err := readElements(r,
+		&code,
+		&f.Amount,
+	)
+	if err != nil {
+		return err
+	}
+	f.Code = FailCode(code)
+
+	return nil
+}
+
+// Encode serializes the target FailureMessage into the passed io.Writer.
+func (f *FailureMessage) Encode(w io.Writer) error {
+	return writeElements(w,
+		uint16(f.Code),
+		f.Amount,
+	)
+}
+
+// Error returns a human readable description of the failure, allowing a
+// FailureMessage to be passed around as an error.
+func (f *FailureMessage) Error() string {
+	switch f.Code {
+	case CodeFeeInsufficient, CodeIncorrectPaymentDetails:
+		return fmt.Sprintf("%v(amount=%v)", f.Code, f.Amount)
+	default:
+		return f.Code.String()
+	}
+}
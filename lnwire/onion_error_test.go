@@ -0,0 +1,33 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestFailureMessageEncodeDecode(t *testing.T) {
+	failure := &FailureMessage{
+		Code:   CodeFeeInsufficient,
+		Amount: CreditsAmount(12345),
+	}
+
+	var b bytes.Buffer
+	if err := failure.Encode(&b); err != nil {
+		t.Fatalf("unable to encode FailureMessage: %v", err)
+	}
+
+	failure2 := &FailureMessage{}
+	if err := failure2.Decode(&b); err != nil {
+		t.Fatalf("unable to decode FailureMessage: %v", err)
+	}
+
+	if !reflect.DeepEqual(failure, failure2) {
+		t.Fatalf("encode/decode failure messages don't match %#v vs %#v",
+			failure, failure2)
+	}
+
+	if failure2.Code&FlagUpdate == 0 {
+		t.Fatalf("fee insufficient failure should have update flag set")
+	}
+}
@@ -0,0 +1,79 @@
+package lnwire
+
+import (
+	"fmt"
+	"io"
+)
+
+// OnionMessage carries an onion packet relaying a message between nodes,
+// independently of any channel or HTLC. Each node along the route of the
+// message peels off a layer of the packet, learning only the node to forward
+// the remainder to, or the message itself if it's the final recipient.
+type OnionMessage struct {
+	// Onion is the serialized onion packet, as constructed by the sender
+	// of the message, then stripped of a layer by each prior hop.
+	Onion []byte
+}
+
+// A compile time check to ensure OnionMessage implements the lnwire.Message
+// interface.
+var _ Message = (*OnionMessage)(nil)
+
+// Decode deserializes a serialized OnionMessage stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) Decode(r io.Reader, pver uint32) error {
+	// Onion
+	return readElements(r,
+		&c.Onion,
+	)
+}
+
+// Encode serializes the target OnionMessage into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		c.Onion,
+	)
+}
+
+// Command returns the integer uniquely identifying an OnionMessage on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) Command() uint32 {
+	return CmdOnionMessage
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for an
+// OnionMessage observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) MaxPayloadLength(uint32) uint32 {
+	// 3 + 65535
+	return 65538
+}
+
+// Validate performs any necessary sanity checks to ensure all fields present
+// on the OnionMessage are valid.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) Validate() error {
+	if len(c.Onion) == 0 {
+		return fmt.Errorf("onion message carries no onion")
+	}
+
+	return nil
+}
+
+// String returns the string representation of the target OnionMessage.
+//
+// This is part of the lnwire.Message interface.
+func (c *OnionMessage) String() string {
+	return fmt.Sprintf("\n--- Begin OnionMessage ---\n") +
+		fmt.Sprintf("Onion:\t\t%x\n", c.Onion) +
+		fmt.Sprintf("--- End OnionMessage ---\n")
+}
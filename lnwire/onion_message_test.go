@@ -0,0 +1,31 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestOnionMessageEncodeDecode(t *testing.T) {
+	om := &OnionMessage{
+		Onion: bytes.Repeat([]byte{0x42}, 1366),
+	}
+
+	// Next encode the OnionMessage into an empty bytes buffer.
+	var b bytes.Buffer
+	if err := om.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode OnionMessage: %v", err)
+	}
+
+	// Deserialize the encoded OnionMessage into a new empty struct.
+	om2 := &OnionMessage{}
+	if err := om2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode OnionMessage: %v", err)
+	}
+
+	// Assert equality of the two instances.
+	if !reflect.DeepEqual(om, om2) {
+		t.Fatalf("encode/decode onion messages don't match %#v vs %#v",
+			om, om2)
+	}
+}
@@ -0,0 +1,86 @@
+package lnwire
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ShortChannelID represents the location of a channel's funding output within
+// the blockchain: the height of the block which confirmed the funding
+// transaction, the index of the transaction within that block, and the index
+// of the funding output within the transaction. Once a channel's funding
+// transaction has been confirmed, the short channel ID can be used as a
+// compact, unique identifier for the channel.
+type ShortChannelID struct {
+	// BlockHeight is the height of the block which confirmed the funding
+	// transaction.
+	BlockHeight uint32
+
+	// TxIndex is the position of the funding transaction within the
+	// block.
+	TxIndex uint32
+
+	// TxPosition is the index of the funding output within the funding
+	// transaction.
+	TxPosition uint16
+}
+
+// NewShortChanIDFromInt returns a new ShortChannelID which is the decoded
+// version of the compact channel ID encoded within the uint64. The format of
+// the compact channel ID is as follows: 3 bytes for the block height, 3 bytes
+// for the transaction index, and 2 bytes for the output index.
+func NewShortChanIDFromInt(chanID uint64) ShortChannelID {
+	return ShortChannelID{
+		BlockHeight: uint32(chanID >> 40),
+		TxIndex:     uint32(chanID>>16) & 0xFFFFFF,
+		TxPosition:  uint16(chanID),
+	}
+}
+
+// NewShortChanIDFromString parses a ShortChannelID from its human readable
+// form of height:txindex:output, as returned by String.
+func NewShortChanIDFromString(s string) (ShortChannelID, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return ShortChannelID{}, fmt.Errorf("invalid short channel "+
+			"id %q, expected height:txindex:output", s)
+	}
+
+	height, err := strconv.ParseUint(parts[0], 10, 24)
+	if err != nil {
+		return ShortChannelID{}, fmt.Errorf("invalid block height: %v",
+			err)
+	}
+	txIndex, err := strconv.ParseUint(parts[1], 10, 24)
+	if err != nil {
+		return ShortChannelID{}, fmt.Errorf("invalid tx index: %v",
+			err)
+	}
+	txPosition, err := strconv.ParseUint(parts[2], 10, 16)
+	if err != nil {
+		return ShortChannelID{}, fmt.Errorf("invalid output index: %v",
+			err)
+	}
+
+	return ShortChannelID{
+		BlockHeight: uint32(height),
+		TxIndex:     uint32(txIndex),
+		TxPosition:  uint16(txPosition),
+	}, nil
+}
+
+// ToUint64 converts the ShortChannelID into a compact format encoded within a
+// uint64 (8 bytes).
+func (c ShortChannelID) ToUint64() uint64 {
+	// TODO(roasbeef): explicit error on overflow?
+	return ((uint64(c.BlockHeight) << 40) | (uint64(c.TxIndex) << 16) |
+		(uint64(c.TxPosition)))
+}
+
+// String returns a human readable representation of the ShortChannelID in
+// the form height:txindex:output.
+func (c ShortChannelID) String() string {
+	return fmt.Sprintf("%d:%d:%d", c.BlockHeight, c.TxIndex,
+		c.TxPosition)
+}
@@ -0,0 +1,64 @@
+package lnwire
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShortChannelIDEncoding(t *testing.T) {
+	var testCases = []ShortChannelID{
+		{
+			BlockHeight: (1 << 24) - 1,
+			TxIndex:     (1 << 24) - 1,
+			TxPosition:  (1 << 16) - 1,
+		},
+		{
+			BlockHeight: 2304934,
+			TxIndex:     2345,
+			TxPosition:  5,
+		},
+		{
+			BlockHeight: 9304934,
+			TxIndex:     2345,
+			TxPosition:  5233,
+		},
+	}
+
+	for _, testCase := range testCases {
+		chanInt := testCase.ToUint64()
+
+		newChanID := NewShortChanIDFromInt(chanInt)
+
+		if !reflect.DeepEqual(testCase, newChanID) {
+			t.Fatalf("chan ID's don't match: expected %v got %v",
+				testCase, newChanID)
+		}
+
+		strChanID, err := NewShortChanIDFromString(testCase.String())
+		if err != nil {
+			t.Fatalf("unable to parse chan ID: %v", err)
+		}
+		if !reflect.DeepEqual(testCase, strChanID) {
+			t.Fatalf("chan ID's don't match: expected %v got %v",
+				testCase, strChanID)
+		}
+	}
+}
+
+func TestShortChannelIDFromStringInvalid(t *testing.T) {
+	var testCases = []string{
+		"",
+		"1:2",
+		"1:2:3:4",
+		"a:2:3",
+		"16777216:0:0",
+		"0:16777216:0",
+		"0:0:65536",
+	}
+
+	for _, testCase := range testCases {
+		if _, err := NewShortChanIDFromString(testCase); err == nil {
+			t.Fatalf("expected error parsing %q", testCase)
+		}
+	}
+}
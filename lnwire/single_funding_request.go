@@ -49,6 +49,14 @@ type SingleFundingRequest struct {
 	// in the pay-to-self output of both commitment transactions.
 	CsvDelay uint32
 
+	// LeaseExpiry is the absolute block height until which the funds of
+	// the initiator are locked within the channel. A non-zero value
+	// indicates a leased channel, wherein every commitment output paying
+	// to the initiator is encumbered by a CHECKLOCKTIMEVERIFY, ensuring
+	// the liquidity sold to the responder can't be withdrawn before the
+	// lease expires.
+	LeaseExpiry uint32
+
 	// CommitmentKey is key the initiator of the funding workflow wishes to
 	// use within their versino of the commitment transaction for any
 	// delayed (CSV) or immediate outputs to them.
@@ -68,12 +76,19 @@ type SingleFundingRequest struct {
 	// supported: P2PKH, P2WKH, P2SH, and P2WSH.
 	DeliveryPkScript PkScript
 
+	// UpfrontShutdown indicates that the initiator commits to
+	// DeliveryPkScript as the destination of their balance upon a
+	// cooperative close. If set, the initiator won't request a different
+	// delivery script when the channel is closed, and the responder MUST
+	// reject any close request which does so.
+	UpfrontShutdown bool
+
 	// TODO(roasbeef): confirmation depth
 }
 
 // NewSingleFundingRequest creates, and returns a new empty SingleFundingRequest.
 func NewSingleFundingRequest(chanID uint64, chanType uint8, coinType uint64,
-	fee btcutil.Amount, amt btcutil.Amount, delay uint32, ck,
+	fee btcutil.Amount, amt btcutil.Amount, delay, leaseExpiry uint32, ck,
 	cdp *btcec.PublicKey, deliveryScript PkScript) *SingleFundingRequest {
 
 	return &SingleFundingRequest{
@@ -83,6 +98,7 @@ func NewSingleFundingRequest(chanID uint64, chanType uint8, coinType uint64,
 		FeePerKb:               fee,
 		FundingAmount:          amt,
 		CsvDelay:               delay,
+		LeaseExpiry:            leaseExpiry,
 		CommitmentKey:          ck,
 		ChannelDerivationPoint: cdp,
 		DeliveryPkScript:       deliveryScript,
@@ -101,9 +117,11 @@ func (c *SingleFundingRequest) Decode(r io.Reader, pver uint32) error {
 	// FeePerKb (8)
 	// PaymentAmount (8)
 	// Delay (4)
+	// LeaseExpiry (4)
 	// Pubkey (33)
 	// Pubkey (33)
 	// DeliveryPkScript (final delivery)
+	// UpfrontShutdown (1)
 	err := readElements(r,
 		&c.ChannelID,
 		&c.ChannelType,
@@ -111,9 +129,11 @@ func (c *SingleFundingRequest) Decode(r io.Reader, pver uint32) error {
 		&c.FeePerKb,
 		&c.FundingAmount,
 		&c.CsvDelay,
+		&c.LeaseExpiry,
 		&c.CommitmentKey,
 		&c.ChannelDerivationPoint,
-		&c.DeliveryPkScript)
+		&c.DeliveryPkScript,
+		&c.UpfrontShutdown)
 	if err != nil {
 		return err
 	}
@@ -133,9 +153,11 @@ func (c *SingleFundingRequest) Encode(w io.Writer, pver uint32) error {
 	// FeePerKb (8)
 	// PaymentAmount (8)
 	// Delay (4)
+	// LeaseExpiry (4)
 	// Pubkey (33)
 	// Pubkey (33)
 	// DeliveryPkScript (final delivery)
+	// UpfrontShutdown (1)
 	err := writeElements(w,
 		c.ChannelID,
 		c.ChannelType,
@@ -143,9 +165,11 @@ func (c *SingleFundingRequest) Encode(w io.Writer, pver uint32) error {
 		c.FeePerKb,
 		c.FundingAmount,
 		c.CsvDelay,
+		c.LeaseExpiry,
 		c.CommitmentKey,
 		c.ChannelDerivationPoint,
-		c.DeliveryPkScript)
+		c.DeliveryPkScript,
+		c.UpfrontShutdown)
 	if err != nil {
 		return err
 	}
@@ -165,11 +189,12 @@ func (c *SingleFundingRequest) Command() uint32 {
 // SingleFundingRequest. This is calculated by summing the max length of all
 // the fields within a SingleFundingRequest. To enforce a maximum
 // DeliveryPkScript size, the size of a P2PKH public key script is used.
-// Therefore, the final breakdown is: 8 + 1 + 8 + 8 + 8 + 4 + 33 + 33 + 25 = 158.
+// Therefore, the final breakdown is:
+// 8 + 1 + 8 + 8 + 8 + 4 + 4 + 33 + 33 + 25 + 1 = 163.
 //
 // This is part of the lnwire.Message interface.
 func (c *SingleFundingRequest) MaxPayloadLength(uint32) uint32 {
-	return 158
+	return 163
 }
 
 // Validate examines each populated field within the SingleFundingRequest for
@@ -232,7 +257,9 @@ func (c *SingleFundingRequest) String() string {
 		fmt.Sprintf("FeePerKb:\t\t\t%s\n", c.FeePerKb.String()) +
 		fmt.Sprintf("FundingAmount:\t\t\t%s\n", c.FundingAmount.String()) +
 		fmt.Sprintf("CsvDelay\t\t\t%d\n", c.CsvDelay) +
+		fmt.Sprintf("LeaseExpiry\t\t\t%d\n", c.LeaseExpiry) +
 		fmt.Sprintf("ChannelDerivationPoint\t\t\t\t%x\n", serializedPubkey) +
 		fmt.Sprintf("DeliveryPkScript\t\t%x\n", c.DeliveryPkScript) +
+		fmt.Sprintf("UpfrontShutdown\t\t%v\n", c.UpfrontShutdown) +
 		fmt.Sprintf("--- End SingleFundingRequest ---\n")
 }
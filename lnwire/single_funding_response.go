@@ -45,6 +45,13 @@ type SingleFundingResponse struct {
 	// cooperative close. Only the following script templates are
 	// supported: P2PKH, P2WKH, P2SH, and P2WSH.
 	DeliveryPkScript PkScript
+
+	// UpfrontShutdown indicates that the responder commits to
+	// DeliveryPkScript as the destination of their balance upon a
+	// cooperative close. If set, the responder won't request a different
+	// delivery script when the channel is closed, and the initiator MUST
+	// reject any close request which does so.
+	UpfrontShutdown bool
 }
 
 // NewSingleFundingResponse creates, and returns a new empty
@@ -78,13 +85,15 @@ func (c *SingleFundingResponse) Decode(r io.Reader, pver uint32) error {
 	// RevocationKey (33)
 	// CsvDelay (4)
 	// DeliveryPkScript (final delivery)
+	// UpfrontShutdown (1)
 	err := readElements(r,
 		&c.ChannelID,
 		&c.ChannelDerivationPoint,
 		&c.CommitmentKey,
 		&c.RevocationKey,
 		&c.CsvDelay,
-		&c.DeliveryPkScript)
+		&c.DeliveryPkScript,
+		&c.UpfrontShutdown)
 	if err != nil {
 		return err
 	}
@@ -104,13 +113,15 @@ func (c *SingleFundingResponse) Encode(w io.Writer, pver uint32) error {
 	// RevocationKey (33)
 	// CsvDelay (4)
 	// DeliveryPkScript (final delivery)
+	// UpfrontShutdown (1)
 	err := writeElements(w,
 		c.ChannelID,
 		c.ChannelDerivationPoint,
 		c.CommitmentKey,
 		c.RevocationKey,
 		c.CsvDelay,
-		c.DeliveryPkScript)
+		c.DeliveryPkScript,
+		c.UpfrontShutdown)
 	if err != nil {
 		return err
 	}
@@ -130,11 +141,11 @@ func (c *SingleFundingResponse) Command() uint32 {
 // SingleFundingResponse. This is calculated by summing the max length of all
 // the fields within a SingleFundingResponse. To enforce a maximum
 // DeliveryPkScript size, the size of a P2PKH public key script is used.
-// Therefore, the final breakdown is: 8 + (33 * 3) + 8 + 25
+// Therefore, the final breakdown is: 8 + (33 * 3) + 8 + 25 + 1
 //
 // This is part of the lnwire.Message interface.
 func (c *SingleFundingResponse) MaxPayloadLength(uint32) uint32 {
-	return 140
+	return 141
 }
 
 // Validate examines each populated field within the SingleFundingResponse for
@@ -188,5 +199,6 @@ func (c *SingleFundingResponse) String() string {
 		fmt.Sprintf("RevocationKey\t\t\t\t%x\n", rk) +
 		fmt.Sprintf("CsvDelay\t\t%d\n", c.CsvDelay) +
 		fmt.Sprintf("DeliveryPkScript\t\t%x\n", c.DeliveryPkScript) +
+		fmt.Sprintf("UpfrontShutdown\t\t%v\n", c.UpfrontShutdown) +
 		fmt.Sprintf("--- End SingleFundingResponse ---\n")
 }
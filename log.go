@@ -27,6 +27,7 @@ var (
 	chdbLog    = btclog.Disabled
 	hswcLog    = btclog.Disabled
 	utxnLog    = btclog.Disabled
+	cnctLog    = btclog.Disabled
 )
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -41,6 +42,7 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"FNDG": fndgLog,
 	"HSWC": hswcLog,
 	"UTXN": utxnLog,
+	"CNCT": cnctLog,
 }
 
 // useLogger updates the logger references for subsystemID to logger.  Invalid
@@ -83,6 +85,9 @@ func useLogger(subsystemID string, logger btclog.Logger) {
 		hswcLog = logger
 	case "UTXN":
 		utxnLog = logger
+
+	case "CNCT":
+		cnctLog = logger
 	}
 }
 
@@ -0,0 +1,144 @@
+package macaroons
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// timeBeforePrefix is the prefix of the caveat which restricts the use
+	// of a macaroon to requests made before a timestamp.
+	timeBeforePrefix = "time-before "
+
+	// ipRangePrefix is the prefix of the caveat which restricts the use
+	// of a macaroon to requests made from within a range of IP addresses.
+	ipRangePrefix = "ip-range "
+
+	// allowURIsPrefix is the prefix of the caveat which restricts the use
+	// of a macaroon to calls of a set of RPC methods.
+	allowURIsPrefix = "allow-uris "
+
+	// denyURIsPrefix is the prefix of the caveat which forbids the use of
+	// a macaroon to call any of a set of RPC methods.
+	denyURIsPrefix = "deny-uris "
+)
+
+// TimeBeforeCaveat returns a caveat which restricts the use of a macaroon to
+// requests made before the passed time.
+func TimeBeforeCaveat(expiry time.Time) string {
+	return timeBeforePrefix + expiry.UTC().Format(time.RFC3339)
+}
+
+// IPRangeCaveat returns a caveat which restricts the use of a macaroon to
+// requests made from an IP address within the passed range.
+func IPRangeCaveat(ipRange *net.IPNet) string {
+	return ipRangePrefix + ipRange.String()
+}
+
+// AllowURIsCaveat returns a caveat which restricts the use of a macaroon to
+// calls of the passed RPC methods, each given by its full URI such as
+// /lnrpc.Lightning/NewAddress.
+func AllowURIsCaveat(uris ...string) string {
+	return allowURIsPrefix + strings.Join(uris, ",")
+}
+
+// DenyURIsCaveat returns a caveat which forbids the use of a macaroon to call
+// any of the passed RPC methods, each given by its full URI.
+func DenyURIsCaveat(uris ...string) string {
+	return denyURIsPrefix + strings.Join(uris, ",")
+}
+
+// containsURI returns true if the passed comma separated list of URIs
+// contains the target URI.
+func containsURI(uris, uri string) bool {
+	for _, u := range strings.Split(uris, ",") {
+		if u == uri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// request describes an RPC request a macaroon is presented with, against
+// which the macaroon's caveats are checked.
+type request struct {
+	// uri is the full URI of the requested method.
+	uri string
+
+	// permission is the permission required by the requested method.
+	permission string
+
+	// now is the time the request was made.
+	now time.Time
+
+	// ip is the IP address the request was made from, or nil if unknown.
+	ip net.IP
+}
+
+// checkCaveat ensures that the passed caveat is satisfied by the request,
+// returning an error if not, or if the caveat isn't understood. The returned
+// boolean is true if the caveat is a permissions caveat granting the
+// request's permission.
+func checkCaveat(caveat string, req *request) (bool, error) {
+	switch {
+	case strings.HasPrefix(caveat, permissionsPrefix):
+		perms := strings.TrimPrefix(caveat, permissionsPrefix)
+		for _, perm := range strings.Split(perms, ",") {
+			if perm == req.permission {
+				return true, nil
+			}
+		}
+
+		return false, fmt.Errorf("permission %v denied", req.permission)
+
+	case strings.HasPrefix(caveat, timeBeforePrefix):
+		expiry, err := time.Parse(time.RFC3339,
+			strings.TrimPrefix(caveat, timeBeforePrefix))
+		if err != nil {
+			return false, fmt.Errorf("invalid caveat %v: %v", caveat,
+				err)
+		}
+		if !req.now.Before(expiry) {
+			return false, fmt.Errorf("macaroon expired at %v",
+				expiry)
+		}
+
+		return false, nil
+
+	case strings.HasPrefix(caveat, ipRangePrefix):
+		_, ipRange, err := net.ParseCIDR(
+			strings.TrimPrefix(caveat, ipRangePrefix))
+		if err != nil {
+			return false, fmt.Errorf("invalid caveat %v: %v", caveat,
+				err)
+		}
+		if req.ip == nil || !ipRange.Contains(req.ip) {
+			return false, fmt.Errorf("macaroon may only be used "+
+				"from %v", ipRange)
+		}
+
+		return false, nil
+
+	case strings.HasPrefix(caveat, allowURIsPrefix):
+		uris := strings.TrimPrefix(caveat, allowURIsPrefix)
+		if !containsURI(uris, req.uri) {
+			return false, fmt.Errorf("method %v not allowed", req.uri)
+		}
+
+		return false, nil
+
+	case strings.HasPrefix(caveat, denyURIsPrefix):
+		uris := strings.TrimPrefix(caveat, denyURIsPrefix)
+		if containsURI(uris, req.uri) {
+			return false, fmt.Errorf("method %v denied", req.uri)
+		}
+
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown caveat: %v", caveat)
+	}
+}
@@ -0,0 +1,106 @@
+package macaroons
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+const (
+	newAddressURI = "/lnrpc.Lightning/NewAddress"
+	getInfoURI    = "/lnrpc.Lightning/GetInfo"
+	sendCoinsURI  = "/lnrpc.Lightning/SendCoins"
+)
+
+// TestCheckCaveat tests that each type of caveat is only satisfied by the
+// requests it permits.
+func TestCheckCaveat(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	_, ipRange, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("unable to parse ip range: %v", err)
+	}
+
+	tests := []struct {
+		caveat  string
+		req     request
+		grants  bool
+		allowed bool
+	}{
+		{
+			caveat:  permissionsPrefix + "signer,rescue",
+			req:     request{permission: PermissionRescue},
+			grants:  true,
+			allowed: true,
+		},
+		{
+			caveat: permissionsPrefix + "signer",
+			req:    request{permission: PermissionRescue},
+		},
+		{
+			caveat:  TimeBeforeCaveat(now.Add(time.Minute)),
+			req:     request{now: now},
+			allowed: true,
+		},
+		{
+			caveat: TimeBeforeCaveat(now),
+			req:    request{now: now},
+		},
+		{
+			caveat:  IPRangeCaveat(ipRange),
+			req:     request{ip: net.ParseIP("10.1.2.3")},
+			allowed: true,
+		},
+		{
+			caveat: IPRangeCaveat(ipRange),
+			req:    request{ip: net.ParseIP("192.168.1.1")},
+		},
+		{
+			// A request whose origin is unknown must never satisfy
+			// an IP range caveat.
+			caveat: IPRangeCaveat(ipRange),
+			req:    request{},
+		},
+		{
+			caveat: AllowURIsCaveat(newAddressURI,
+				getInfoURI),
+			req:     request{uri: getInfoURI},
+			allowed: true,
+		},
+		{
+			caveat: AllowURIsCaveat(newAddressURI,
+				getInfoURI),
+			req: request{uri: sendCoinsURI},
+		},
+		{
+			caveat:  DenyURIsCaveat(sendCoinsURI),
+			req:     request{uri: newAddressURI},
+			allowed: true,
+		},
+		{
+			caveat: DenyURIsCaveat(sendCoinsURI),
+			req:    request{uri: sendCoinsURI},
+		},
+		{
+			caveat: timeBeforePrefix + "tomorrow",
+			req:    request{now: now},
+		},
+		{
+			caveat: "unknown-caveat",
+			req:    request{},
+		},
+	}
+
+	for i, test := range tests {
+		grants, err := checkCaveat(test.caveat, &test.req)
+		if test.allowed != (err == nil) {
+			t.Fatalf("test #%v: caveat %q allowed=%v, expected %v: "+
+				"%v", i, test.caveat, err == nil, test.allowed,
+				err)
+		}
+		if grants != test.grants {
+			t.Fatalf("test #%v: caveat %q grants=%v, expected %v", i,
+				test.caveat, grants, test.grants)
+		}
+	}
+}
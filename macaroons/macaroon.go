@@ -0,0 +1,179 @@
+package macaroons
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature of a macaroon
+	// doesn't match the one derived from the root key and its caveats,
+	// indicating the macaroon was either forged, or tampered with.
+	ErrInvalidSignature = errors.New("macaroon signature is invalid")
+
+	// ErrMalformedMacaroon is returned when a serialized macaroon can't
+	// be decoded.
+	ErrMalformedMacaroon = errors.New("macaroon is malformed")
+)
+
+// Macaroon is a bearer credential which can be attenuated by its holder. The
+// credential is constructed as a chain of HMACs: the initial signature is the
+// HMAC of the macaroon's ID under a root key known only to the issuer, and
+// each added caveat replaces the signature with the HMAC of the caveat keyed
+// by the prior signature. As a result, anyone holding a macaroon may add
+// further caveats, restricting its use, but caveats can never be removed
+// without knowledge of the root key.
+//
+// NOTE: Only first-party caveats, which are checked directly by the issuer,
+// are supported.
+type Macaroon struct {
+	id      []byte
+	caveats []string
+	sig     [sha256.Size]byte
+}
+
+// New creates a new macaroon with the given ID, signed under rootKey.
+func New(rootKey, id []byte) *Macaroon {
+	m := &Macaroon{
+		id: append([]byte(nil), id...),
+	}
+	copy(m.sig[:], keyedHash(rootKey, id))
+
+	return m
+}
+
+// keyedHash returns the HMAC-SHA256 of data under key.
+func keyedHash(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// ID returns the identifier of the macaroon, chosen by its issuer.
+func (m *Macaroon) ID() []byte {
+	return m.id
+}
+
+// Caveats returns the caveats which restrict the use of the macaroon, in the
+// order they were added.
+func (m *Macaroon) Caveats() []string {
+	return m.caveats
+}
+
+// AddFirstPartyCaveat restricts the use of the macaroon by the passed caveat,
+// which will be checked by the issuer upon verification.
+func (m *Macaroon) AddFirstPartyCaveat(caveat string) {
+	m.caveats = append(m.caveats, caveat)
+	copy(m.sig[:], keyedHash(m.sig[:], []byte(caveat)))
+}
+
+// Verify ensures that the macaroon was issued under rootKey and hasn't since
+// been tampered with, and that each of its caveats is satisfied according to
+// the passed check function.
+func (m *Macaroon) Verify(rootKey []byte, check func(caveat string) error) error {
+	sig := keyedHash(rootKey, m.id)
+	for _, caveat := range m.caveats {
+		sig = keyedHash(sig, []byte(caveat))
+	}
+	if !hmac.Equal(sig, m.sig[:]) {
+		return ErrInvalidSignature
+	}
+
+	for _, caveat := range m.caveats {
+		if err := check(caveat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Serialize encodes the macaroon into its binary representation. The ID and
+// each caveat are prefixed by their 2-byte length, and followed by the
+// signature.
+func (m *Macaroon) Serialize() ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeElement(&b, m.id); err != nil {
+		return nil, err
+	}
+
+	var scratch [2]byte
+	binary.BigEndian.PutUint16(scratch[:], uint16(len(m.caveats)))
+	b.Write(scratch[:])
+	for _, caveat := range m.caveats {
+		if err := writeElement(&b, []byte(caveat)); err != nil {
+			return nil, err
+		}
+	}
+
+	b.Write(m.sig[:])
+
+	return b.Bytes(), nil
+}
+
+// Deserialize decodes a macaroon from its binary representation.
+func Deserialize(serialized []byte) (*Macaroon, error) {
+	r := bytes.NewReader(serialized)
+
+	id, err := readElement(r)
+	if err != nil {
+		return nil, err
+	}
+	m := &Macaroon{id: id}
+
+	var scratch [2]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, ErrMalformedMacaroon
+	}
+	numCaveats := binary.BigEndian.Uint16(scratch[:])
+	for i := uint16(0); i < numCaveats; i++ {
+		caveat, err := readElement(r)
+		if err != nil {
+			return nil, err
+		}
+		m.caveats = append(m.caveats, string(caveat))
+	}
+
+	if _, err := io.ReadFull(r, m.sig[:]); err != nil {
+		return nil, ErrMalformedMacaroon
+	}
+	if r.Len() != 0 {
+		return nil, ErrMalformedMacaroon
+	}
+
+	return m, nil
+}
+
+// writeElement writes the passed element to w, prefixed by its length.
+func writeElement(w io.Writer, element []byte) error {
+	if len(element) > 0xffff {
+		return errors.New("macaroon element exceeds max length")
+	}
+
+	var scratch [2]byte
+	binary.BigEndian.PutUint16(scratch[:], uint16(len(element)))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(element)
+	return err
+}
+
+// readElement reads a length prefixed element from r.
+func readElement(r io.Reader) ([]byte, error) {
+	var scratch [2]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, ErrMalformedMacaroon
+	}
+
+	element := make([]byte, binary.BigEndian.Uint16(scratch[:]))
+	if _, err := io.ReadFull(r, element); err != nil {
+		return nil, ErrMalformedMacaroon
+	}
+
+	return element, nil
+}
@@ -0,0 +1,94 @@
+package macaroons
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+var (
+	testRootKey = bytes.Repeat([]byte{0x1}, 32)
+	testID      = []byte("test-macaroon")
+)
+
+// allowAll is a caveat checker which accepts every caveat.
+func allowAll(string) error { return nil }
+
+// TestMacaroonSerialization tests that a macaroon, along with its caveats,
+// survives a round trip through its binary encoding and remains valid.
+func TestMacaroonSerialization(t *testing.T) {
+	m := New(testRootKey, testID)
+	m.AddFirstPartyCaveat("permissions=signer")
+	m.AddFirstPartyCaveat("time-before 2020-01-01T00:00:00Z")
+
+	serialized, err := m.Serialize()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+	m2, err := Deserialize(serialized)
+	if err != nil {
+		t.Fatalf("unable to deserialize macaroon: %v", err)
+	}
+
+	if !bytes.Equal(m.ID(), m2.ID()) {
+		t.Fatalf("id mismatch: expected %x, got %x", m.ID(), m2.ID())
+	}
+	if len(m2.Caveats()) != 2 {
+		t.Fatalf("expected 2 caveats, got %v", len(m2.Caveats()))
+	}
+	for i, caveat := range m.Caveats() {
+		if m2.Caveats()[i] != caveat {
+			t.Fatalf("caveat mismatch: expected %v, got %v",
+				caveat, m2.Caveats()[i])
+		}
+	}
+	if err := m2.Verify(testRootKey, allowAll); err != nil {
+		t.Fatalf("deserialized macaroon invalid: %v", err)
+	}
+
+	// Trailing or missing bytes should cause decoding to fail.
+	if _, err := Deserialize(append(serialized, 0)); err == nil {
+		t.Fatalf("macaroon with trailing bytes was decoded")
+	}
+	if _, err := Deserialize(serialized[:len(serialized)-1]); err == nil {
+		t.Fatalf("truncated macaroon was decoded")
+	}
+}
+
+// TestMacaroonVerify tests that macaroons are only deemed valid under the
+// root key they were issued with, and that caveats can neither be removed
+// nor modified once added.
+func TestMacaroonVerify(t *testing.T) {
+	m := New(testRootKey, testID)
+	m.AddFirstPartyCaveat("permissions=signer")
+
+	if err := m.Verify(testRootKey, allowAll); err != nil {
+		t.Fatalf("valid macaroon rejected: %v", err)
+	}
+
+	wrongKey := bytes.Repeat([]byte{0x2}, 32)
+	if err := m.Verify(wrongKey, allowAll); err != ErrInvalidSignature {
+		t.Fatalf("macaroon accepted under the wrong root key")
+	}
+
+	// Attempt to widen the permissions of the macaroon by modifying its
+	// caveat, this should invalidate its signature.
+	tampered := *m
+	tampered.caveats = []string{"permissions=signer,admin"}
+	if err := tampered.Verify(testRootKey, allowAll); err != ErrInvalidSignature {
+		t.Fatalf("tampered macaroon accepted")
+	}
+
+	// Stripping the caveat entirely should also invalidate it.
+	tampered.caveats = nil
+	if err := tampered.Verify(testRootKey, allowAll); err != ErrInvalidSignature {
+		t.Fatalf("macaroon with stripped caveats accepted")
+	}
+
+	// Finally, an unsatisfied caveat should cause verification to fail.
+	errDenied := fmt.Errorf("denied")
+	err := m.Verify(testRootKey, func(string) error { return errDenied })
+	if err != errDenied {
+		t.Fatalf("expected caveat check failure, got: %v", err)
+	}
+}
@@ -0,0 +1,487 @@
+package macaroons
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// dbFilename is the name of the database within the service's
+	// directory which stores the root keys macaroons are issued under.
+	dbFilename = "macaroons.db"
+
+	// legacyRootKeyFilename is the name of the file within the service's
+	// directory which stored the single root key all macaroons were
+	// issued under, prior to the introduction of per-ID root keys.
+	legacyRootKeyFilename = "macaroons.key"
+
+	// rootKeySize is the size of the root key in bytes.
+	rootKeySize = 32
+
+	// rootKeyIDSize is the size of the root key ID which prefixes the ID
+	// of each macaroon.
+	rootKeyIDSize = 8
+
+	// nonceSize is the size of the random nonce which follows the root key
+	// ID within the ID of each macaroon.
+	nonceSize = 16
+
+	// DefaultRootKeyID is the ID of the root key macaroons are issued
+	// under unless told otherwise. Macaroons issued prior to the
+	// introduction of per-ID root keys are verified under it.
+	DefaultRootKeyID uint64 = 0
+
+	// MetadataKey is the gRPC metadata key under which the hex encoded
+	// macaroon is sent along with each request.
+	MetadataKey = "macaroon"
+
+	// permissionsPrefix is the prefix of the caveat which lists the
+	// permissions granted by a macaroon.
+	permissionsPrefix = "permissions="
+
+	// PermissionSigner grants access to the low-level signing RPCs of the
+	// signrpc service.
+	PermissionSigner = "signer"
+
+	// PermissionRemoteSigner grants a watch-only node access to the
+	// signrpc service served on the signerlisten address, including the
+	// methods handing out revocation secrets.
+	PermissionRemoteSigner = "remotesigner"
+
+	// PermissionRescue grants access to the fund recovery RPCs of the
+	// rescuerpc service.
+	PermissionRescue = "rescue"
+
+	// PermissionLightning grants access to the lnrpc.Lightning service,
+	// should macaroons be required to call it.
+	PermissionLightning = "lightning"
+
+	// PermissionAdmin grants access to the RPCs which bake and revoke
+	// macaroons. It's always required to call them, as a macaroon granting
+	// any other permission could otherwise be used to mint a macaroon of
+	// any scope, or to revoke the macaroons of others.
+	PermissionAdmin = "admin"
+)
+
+var (
+	// rootKeyBucket is the name of the bucket which stores the root keys,
+	// keyed by their big-endian encoded ID.
+	rootKeyBucket = []byte("macrootkeys")
+
+	// ErrRootKeyNotFound is returned when a macaroon was issued under a
+	// root key which doesn't exist, most likely as it has been deleted in
+	// order to revoke the macaroon.
+	ErrRootKeyNotFound = errors.New("macaroon root key not found, the " +
+		"macaroon may have been revoked")
+)
+
+// Service issues and validates macaroons. Each macaroon is issued under one
+// of several root keys, identified by an ID embedded within the ID of the
+// macaroon. Deleting a root key revokes all macaroons issued under it, while
+// leaving those issued under other root keys valid. The root keys are
+// persisted to disk, so that macaroons remain valid across restarts.
+type Service struct {
+	db *bolt.DB
+}
+
+// NewService creates a new macaroon service, storing its root keys within
+// the passed directory. A root key which was stored by a prior version of
+// the service is migrated to the DefaultRootKeyID.
+func NewService(dir string) (*Service, error) {
+	db, err := bolt.Open(filepath.Join(dir, dbFilename), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(rootKeyBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Service{db: db}
+	if err := s.migrateLegacyRootKey(dir); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// migrateLegacyRootKey moves the single root key stored by a prior version of
+// the service into the database under the DefaultRootKeyID, so the
+// macaroons issued under it remain valid.
+func (s *Service) migrateLegacyRootKey(dir string) error {
+	keyPath := filepath.Join(dir, legacyRootKeyFilename)
+	rootKey, err := ioutil.ReadFile(keyPath)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if len(rootKey) != rootKeySize {
+		return fmt.Errorf("macaroon root key in %v has invalid "+
+			"length %v", keyPath, len(rootKey))
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		rootKeys := tx.Bucket(rootKeyBucket)
+		id := rootKeyIDBytes(DefaultRootKeyID)
+		if rootKeys.Get(id) != nil {
+			return nil
+		}
+
+		return rootKeys.Put(id, rootKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(keyPath)
+}
+
+// Close closes the database storing the root keys.
+func (s *Service) Close() error {
+	return s.db.Close()
+}
+
+// rootKeyIDBytes returns the big-endian encoding of the passed root key ID.
+func rootKeyIDBytes(id uint64) []byte {
+	var b [rootKeyIDSize]byte
+	binary.BigEndian.PutUint64(b[:], id)
+	return b[:]
+}
+
+// rootKeyID extracts the ID of the root key the passed macaroon was issued
+// under from its ID.
+func rootKeyID(m *Macaroon) (uint64, error) {
+	switch len(m.ID()) {
+	// Macaroons issued prior to the introduction of per-ID root keys
+	// have IDs made up of only a nonce.
+	case nonceSize:
+		return DefaultRootKeyID, nil
+
+	case rootKeyIDSize + nonceSize:
+		return binary.BigEndian.Uint64(m.ID()[:rootKeyIDSize]), nil
+
+	default:
+		return 0, fmt.Errorf("macaroon has unknown id format")
+	}
+}
+
+// fetchRootKey returns the root key with the passed ID. If no such key exists,
+// then a fresh one is generated and stored if create is true, otherwise
+// ErrRootKeyNotFound is returned.
+func (s *Service) fetchRootKey(id uint64, create bool) ([]byte, error) {
+	var rootKey []byte
+	fetch := func(tx *bolt.Tx) error {
+		rootKeys := tx.Bucket(rootKeyBucket)
+		if key := rootKeys.Get(rootKeyIDBytes(id)); key != nil {
+			rootKey = append([]byte(nil), key...)
+			return nil
+		}
+		if !create {
+			return ErrRootKeyNotFound
+		}
+
+		rootKey = make([]byte, rootKeySize)
+		if _, err := rand.Read(rootKey); err != nil {
+			return err
+		}
+		return rootKeys.Put(rootKeyIDBytes(id), rootKey)
+	}
+
+	var err error
+	if create {
+		err = s.db.Update(fetch)
+	} else {
+		err = s.db.View(fetch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rootKey, nil
+}
+
+// NewMacaroon issues a new macaroon granting the passed permissions under the
+// root key with the passed ID, generating the root key if it doesn't exist
+// yet. Issuing macaroons handed to different parties under different root
+// keys allows each to be revoked independently.
+func (s *Service) NewMacaroon(rootKeyID uint64,
+	permissions ...string) (*Macaroon, error) {
+
+	rootKey, err := s.fetchRootKey(rootKeyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, rootKeyIDSize+nonceSize)
+	copy(id, rootKeyIDBytes(rootKeyID))
+	if _, err := rand.Read(id[rootKeyIDSize:]); err != nil {
+		return nil, err
+	}
+
+	m := New(rootKey, id)
+	m.AddFirstPartyCaveat(permissionsPrefix +
+		strings.Join(permissions, ","))
+
+	return m, nil
+}
+
+// RootKeyIDs returns the IDs of all root keys, in ascending order.
+func (s *Service) RootKeyIDs() ([]uint64, error) {
+	var ids []uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		rootKeys := tx.Bucket(rootKeyBucket)
+		return rootKeys.ForEach(func(id, _ []byte) error {
+			ids = append(ids, binary.BigEndian.Uint64(id))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// DeleteRootKey deletes the root key with the passed ID, revoking all
+// macaroons issued under it. The returned boolean is false if no such root
+// key existed.
+func (s *Service) DeleteRootKey(id uint64) (bool, error) {
+	var deleted bool
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		rootKeys := tx.Bucket(rootKeyBucket)
+		if rootKeys.Get(rootKeyIDBytes(id)) == nil {
+			return nil
+		}
+
+		deleted = true
+		return rootKeys.Delete(rootKeyIDBytes(id))
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+// RotateRootKeys deletes every root key other than those with the passed IDs,
+// revoking all macaroons issued under them. Fresh root keys are generated as
+// new macaroons are issued.
+func (s *Service) RotateRootKeys(keep ...uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		rootKeys := tx.Bucket(rootKeyBucket)
+		kept := make(map[uint64][]byte, len(keep))
+		for _, id := range keep {
+			if key := rootKeys.Get(rootKeyIDBytes(id)); key != nil {
+				kept[id] = append([]byte(nil), key...)
+			}
+		}
+
+		if err := tx.DeleteBucket(rootKeyBucket); err != nil {
+			return err
+		}
+		rootKeys, err := tx.CreateBucket(rootKeyBucket)
+		if err != nil {
+			return err
+		}
+
+		for id, key := range kept {
+			if err := rootKeys.Put(rootKeyIDBytes(id), key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// verify ensures that the passed macaroon was issued by this service under a
+// root key which still exists, and hasn't since been tampered with. Each of
+// the macaroon's caveats is passed to the check function.
+func (s *Service) verify(m *Macaroon, check func(caveat string) error) error {
+	id, err := rootKeyID(m)
+	if err != nil {
+		return err
+	}
+	rootKey, err := s.fetchRootKey(id, false)
+	if err != nil {
+		return err
+	}
+
+	return m.Verify(rootKey, check)
+}
+
+// CheckMacaroon ensures that the passed macaroon is still valid: it was issued
+// by this service under a root key which hasn't since been deleted. Its
+// caveats aren't checked, as they restrict individual requests.
+func (s *Service) CheckMacaroon(m *Macaroon) error {
+	return s.verify(m, func(string) error { return nil })
+}
+
+// ValidateMacaroon ensures that the request for the method with the passed
+// URI carries a valid macaroon issued by this service which grants the
+// required permission. Every permissions caveat within the macaroon must
+// grant the permission, and at least one must be present. Any expiry, IP
+// range or URI caveats must also be satisfied by the request.
+func (s *Service) ValidateMacaroon(ctx context.Context, uri,
+	required string) error {
+
+	md, ok := metadata.FromContext(ctx)
+	if !ok || len(md[MetadataKey]) != 1 {
+		return fmt.Errorf("expected 1 macaroon")
+	}
+
+	rawMacaroon, err := hex.DecodeString(md[MetadataKey][0])
+	if err != nil {
+		return err
+	}
+	m, err := Deserialize(rawMacaroon)
+	if err != nil {
+		return err
+	}
+
+	req := &request{
+		uri:        uri,
+		permission: required,
+		now:        time.Now(),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := p.Addr.(*net.TCPAddr); ok {
+			req.ip = addr.IP
+		}
+	}
+
+	var granted bool
+	err = s.verify(m, func(caveat string) error {
+		grants, err := checkCaveat(caveat, req)
+		if grants {
+			granted = true
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return fmt.Errorf("permission %v denied", required)
+	}
+
+	return nil
+}
+
+// requiredPermission returns the permission the passed map requires to call
+// the method with the given full name. A method absent from the map is gated
+// by the entry of its service, keyed by the service's name enclosed in
+// slashes, such as /lnrpc.Lightning/, if one exists.
+func requiredPermission(requiredPerms map[string]string,
+	method string) (string, bool) {
+
+	if perm, ok := requiredPerms[method]; ok {
+		return perm, true
+	}
+
+	service := method[:strings.LastIndex(method, "/")+1]
+	perm, ok := requiredPerms[service]
+	return perm, ok
+}
+
+// UnaryServerInterceptor returns a gRPC interceptor which enforces that
+// callers of the gated methods hold a macaroon granting the required
+// permission. The passed map pairs the full name of each gated method, or of
+// each gated service, with the permission required to call it. Methods
+// absent from the map are left ungated.
+func (s *Service) UnaryServerInterceptor(
+	requiredPerms map[string]string) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		perm, ok := requiredPermission(requiredPerms, info.FullMethod)
+		if ok {
+			err := s.ValidateMacaroon(ctx, info.FullMethod, perm)
+			if err != nil {
+				return nil, grpc.Errorf(codes.PermissionDenied,
+					"%v: %v", info.FullMethod, err)
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC interceptor which gates streaming
+// methods in the same manner as UnaryServerInterceptor gates unary methods.
+func (s *Service) StreamServerInterceptor(
+	requiredPerms map[string]string) grpc.StreamServerInterceptor {
+
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		perm, ok := requiredPermission(requiredPerms, info.FullMethod)
+		if ok {
+			err := s.ValidateMacaroon(ss.Context(), info.FullMethod,
+				perm)
+			if err != nil {
+				return grpc.Errorf(codes.PermissionDenied,
+					"%v: %v", info.FullMethod, err)
+			}
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// Credential wraps a macaroon, implementing the grpc
+// credentials.PerRPCCredentials interface so the macaroon is attached to each
+// request made over a client connection.
+type Credential struct {
+	*Macaroon
+}
+
+// GetRequestMetadata returns the hex encoded macaroon as request metadata.
+//
+// Part of the credentials.PerRPCCredentials interface.
+func (c Credential) GetRequestMetadata(ctx context.Context,
+	uri ...string) (map[string]string, error) {
+
+	serialized, err := c.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		MetadataKey: hex.EncodeToString(serialized),
+	}, nil
+}
+
+// RequireTransportSecurity returns false, as the RPC server is only served
+// over TLS when reachable beyond localhost.
+//
+// Part of the credentials.PerRPCCredentials interface.
+func (c Credential) RequireTransportSecurity() bool {
+	return false
+}
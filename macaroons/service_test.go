@@ -0,0 +1,171 @@
+package macaroons
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestServiceRootKeys tests that macaroons are only valid while the root key
+// they were issued under exists, and that deleting or rotating root keys
+// revokes exactly the expected macaroons.
+func TestServiceRootKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "macaroons")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A root key stored by a prior version of the service should be
+	// migrated, with the macaroons issued under it remaining valid.
+	legacyKey := bytes.Repeat([]byte{0x3}, rootKeySize)
+	legacyPath := filepath.Join(dir, legacyRootKeyFilename)
+	if err := ioutil.WriteFile(legacyPath, legacyKey, 0600); err != nil {
+		t.Fatalf("unable to write legacy root key: %v", err)
+	}
+	legacyMac := New(legacyKey, bytes.Repeat([]byte{0x4}, nonceSize))
+	legacyMac.AddFirstPartyCaveat(permissionsPrefix + PermissionSigner)
+
+	service, err := NewService(dir)
+	if err != nil {
+		t.Fatalf("unable to create service: %v", err)
+	}
+	defer service.Close()
+
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Fatalf("legacy root key wasn't removed")
+	}
+	if err := service.CheckMacaroon(legacyMac); err != nil {
+		t.Fatalf("legacy macaroon rejected: %v", err)
+	}
+
+	// Issue two macaroons under separate root keys.
+	mac1, err := service.NewMacaroon(1, PermissionSigner)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	mac2, err := service.NewMacaroon(2, PermissionRescue)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	for _, m := range []*Macaroon{mac1, mac2} {
+		if err := service.CheckMacaroon(m); err != nil {
+			t.Fatalf("macaroon rejected: %v", err)
+		}
+	}
+
+	ids, err := service.RootKeyIDs()
+	if err != nil {
+		t.Fatalf("unable to list root key ids: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []uint64{DefaultRootKeyID, 1, 2}) {
+		t.Fatalf("unexpected root key ids: %v", ids)
+	}
+
+	// Deleting the first root key should revoke only the first macaroon.
+	deleted, err := service.DeleteRootKey(1)
+	if err != nil {
+		t.Fatalf("unable to delete root key: %v", err)
+	}
+	if !deleted {
+		t.Fatalf("root key wasn't deleted")
+	}
+	if err := service.CheckMacaroon(mac1); err != ErrRootKeyNotFound {
+		t.Fatalf("expected ErrRootKeyNotFound, instead got: %v", err)
+	}
+	if err := service.CheckMacaroon(mac2); err != nil {
+		t.Fatalf("macaroon rejected: %v", err)
+	}
+
+	deleted, err = service.DeleteRootKey(1)
+	if err != nil {
+		t.Fatalf("unable to delete root key: %v", err)
+	}
+	if deleted {
+		t.Fatalf("missing root key reported as deleted")
+	}
+
+	// A macaroon issued under the deleted ID is issued under a fresh
+	// root key, so the revoked macaroon must remain invalid.
+	mac3, err := service.NewMacaroon(1, PermissionSigner)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	if err := service.CheckMacaroon(mac3); err != nil {
+		t.Fatalf("macaroon rejected: %v", err)
+	}
+	if err := service.CheckMacaroon(mac1); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature, instead got: %v", err)
+	}
+
+	// Rotating the root keys while keeping the second should revoke every
+	// macaroon other than those issued under it.
+	if err := service.RotateRootKeys(2); err != nil {
+		t.Fatalf("unable to rotate root keys: %v", err)
+	}
+	for _, m := range []*Macaroon{legacyMac, mac3} {
+		if err := service.CheckMacaroon(m); err != ErrRootKeyNotFound {
+			t.Fatalf("expected ErrRootKeyNotFound, instead got: "+
+				"%v", err)
+		}
+	}
+	if err := service.CheckMacaroon(mac2); err != nil {
+		t.Fatalf("macaroon under kept root key rejected: %v", err)
+	}
+	ids, err = service.RootKeyIDs()
+	if err != nil {
+		t.Fatalf("unable to list root key ids: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []uint64{2}) {
+		t.Fatalf("unexpected root key ids: %v", ids)
+	}
+
+	// Finally, rotating the root keys without keeping any should revoke
+	// every macaroon.
+	if err := service.RotateRootKeys(); err != nil {
+		t.Fatalf("unable to rotate root keys: %v", err)
+	}
+	if err := service.CheckMacaroon(mac2); err != ErrRootKeyNotFound {
+		t.Fatalf("expected ErrRootKeyNotFound, instead got: %v", err)
+	}
+	ids, err = service.RootKeyIDs()
+	if err != nil {
+		t.Fatalf("unable to list root key ids: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("expected no root keys, instead have %v", ids)
+	}
+}
+
+// TestRequiredPermission tests that methods are gated by their own entry
+// within the permissions map, falling back to that of their service.
+func TestRequiredPermission(t *testing.T) {
+	requiredPerms := map[string]string{
+		"/signrpc.Signer/SignOutputRaw": PermissionSigner,
+		"/lnrpc.Lightning/":             PermissionLightning,
+		"/lnrpc.Lightning/BakeMacaroon": PermissionAdmin,
+	}
+
+	tests := []struct {
+		method string
+		perm   string
+		gated  bool
+	}{
+		{"/signrpc.Signer/SignOutputRaw", PermissionSigner, true},
+		{"/signrpc.Signer/DeriveKey", "", false},
+		{"/lnrpc.Lightning/NewAddress", PermissionLightning, true},
+		{"/lnrpc.Lightning/BakeMacaroon", PermissionAdmin, true},
+		{"/chainrpc.ChainNotifier/RegisterBlockEpochNtfn", "", false},
+	}
+
+	for _, test := range tests {
+		perm, gated := requiredPermission(requiredPerms, test.method)
+		if gated != test.gated || perm != test.perm {
+			t.Fatalf("method %v: expected (%q, %v), got (%q, %v)",
+				test.method, test.perm, test.gated, perm, gated)
+		}
+	}
+}
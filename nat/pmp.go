@@ -0,0 +1,126 @@
+package nat
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/jackpal/gateway"
+	natpmp "github.com/jackpal/go-nat-pmp"
+)
+
+// Compile-time check to ensure PMP implements the Traversal interface.
+var _ Traversal = (*PMP)(nil)
+
+// PMP is a concrete implementation of the Traversal interface that uses the
+// NAT-PMP technique.
+type PMP struct {
+	client *natpmp.Client
+
+	forwardedPortsMtx sync.Mutex
+	forwardedPorts    map[uint16]struct{}
+}
+
+// DiscoverPMP attempts to locate the local network's gateway, and verifies
+// that it supports NAT-PMP by querying it for its external IP address.
+func DiscoverPMP() (*PMP, error) {
+	gatewayIP, err := gateway.DiscoverGateway()
+	if err != nil {
+		return nil, err
+	}
+
+	client := natpmp.NewClient(gatewayIP)
+	if _, err := client.GetExternalAddress(); err != nil {
+		return nil, err
+	}
+
+	return &PMP{
+		client:         client,
+		forwardedPorts: make(map[uint16]struct{}),
+	}, nil
+}
+
+// ExternalIP returns the external IP address of the NAT-PMP enabled device.
+//
+// NOTE: This is part of the Traversal interface.
+func (p *PMP) ExternalIP() (net.IP, error) {
+	res, err := p.client.GetExternalAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	ip := res.ExternalIPAddress
+	return net.IPv4(ip[0], ip[1], ip[2], ip[3]), nil
+}
+
+// AddPortMapping adds a TCP port mapping for the given port, or refreshes the
+// lease of an existing one.
+//
+// NOTE: This is part of the Traversal interface.
+func (p *PMP) AddPortMapping(port uint16) error {
+	res, err := p.client.AddPortMapping(
+		"tcp", int(port), int(port), int(MappingLifetime.Seconds()),
+	)
+	if err != nil {
+		return err
+	}
+
+	// The gateway is free to map a different external port than the one
+	// we requested, in which case we're unable to advertise it.
+	if res.MappedExternalPort != port {
+		p.client.AddPortMapping("tcp", int(port), 0, 0)
+		return fmt.Errorf("gateway mapped external port %d rather "+
+			"than requested port %d", res.MappedExternalPort, port)
+	}
+
+	p.forwardedPortsMtx.Lock()
+	p.forwardedPorts[port] = struct{}{}
+	p.forwardedPortsMtx.Unlock()
+
+	return nil
+}
+
+// DeletePortMapping removes the TCP port mapping for the given port.
+//
+// NOTE: This is part of the Traversal interface.
+func (p *PMP) DeletePortMapping(port uint16) error {
+	p.forwardedPortsMtx.Lock()
+	defer p.forwardedPortsMtx.Unlock()
+
+	if _, ok := p.forwardedPorts[port]; !ok {
+		return fmt.Errorf("port %d is not being forwarded", port)
+	}
+
+	// A mapping is deleted by requesting a lifetime of zero, along with
+	// an external port of zero.
+	if _, err := p.client.AddPortMapping("tcp", int(port), 0, 0); err != nil {
+		return err
+	}
+
+	delete(p.forwardedPorts, port)
+
+	return nil
+}
+
+// ForwardedPorts returns the ports currently forwarded by the NAT-PMP enabled
+// device.
+//
+// NOTE: This is part of the Traversal interface.
+func (p *PMP) ForwardedPorts() []uint16 {
+	p.forwardedPortsMtx.Lock()
+	defer p.forwardedPortsMtx.Unlock()
+
+	ports := make([]uint16, 0, len(p.forwardedPorts))
+	for port := range p.forwardedPorts {
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// Name returns the name of the specific NAT traversal technique used.
+//
+// NOTE: This is part of the Traversal interface.
+func (p *PMP) Name() string {
+	return "NAT-PMP"
+}
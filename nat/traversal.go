@@ -0,0 +1,36 @@
+package nat
+
+import (
+	"net"
+	"time"
+)
+
+// MappingLifetime is the lifetime requested for each port mapping added to
+// the local router. Mappings must be refreshed before this duration elapses
+// in order to remain active.
+const MappingLifetime = 20 * time.Minute
+
+// Traversal is an interface that brings together the different NAT traversal
+// techniques used to make a node reachable from outside of its local network.
+type Traversal interface {
+	// ExternalIP returns the external IP address of the router the node
+	// is located behind.
+	ExternalIP() (net.IP, error)
+
+	// AddPortMapping adds a TCP port mapping for the given port, or
+	// refreshes the lease of an existing mapping, such that connections
+	// to the same port of the router's external IP address are forwarded
+	// to this node.
+	AddPortMapping(port uint16) error
+
+	// DeletePortMapping removes a previously added TCP port mapping for
+	// the given port.
+	DeletePortMapping(port uint16) error
+
+	// ForwardedPorts returns the set of ports currently forwarded by the
+	// router to this node.
+	ForwardedPorts() []uint16
+
+	// Name returns the name of the NAT traversal technique.
+	Name() string
+}
@@ -0,0 +1,160 @@
+package nat
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/huin/goupnp/dcps/internetgateway1"
+)
+
+// Compile-time check to ensure UPnP implements the Traversal interface.
+var _ Traversal = (*UPnP)(nil)
+
+// UPnP is a concrete implementation of the Traversal interface that uses the
+// UPnP technique.
+type UPnP struct {
+	device *internetgateway1.WANIPConnection1
+
+	// internalIP is the IP address of this node within the local
+	// network, which port mappings are directed towards.
+	internalIP net.IP
+
+	forwardedPortsMtx sync.Mutex
+	forwardedPorts    map[uint16]struct{}
+}
+
+// DiscoverUPnP scans the local network for a UPnP enabled device. The first
+// internet gateway device found which is able to report its external IP
+// address is used.
+func DiscoverUPnP() (*UPnP, error) {
+	clients, errs, err := internetgateway1.NewWANIPConnection1Clients()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, client := range clients {
+		if _, err := client.GetExternalIPAddress(); err != nil {
+			continue
+		}
+
+		internalIP, err := localIPFor(client.Location.Host)
+		if err != nil {
+			continue
+		}
+
+		return &UPnP{
+			device:         client,
+			internalIP:     internalIP,
+			forwardedPorts: make(map[uint16]struct{}),
+		}, nil
+	}
+
+	// If none of the devices were usable, then we'll return the first
+	// error encountered during discovery, if any, to aid in debugging.
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("no usable UPnP enabled internet gateway "+
+		"device found out of %v discovered", len(clients))
+}
+
+// localIPFor returns the local IP address used to reach the passed host. No
+// packets are actually sent, as the UDP "connection" only selects a route.
+func localIPFor(host string) (net.IP, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "80")
+	}
+
+	conn, err := net.Dial("udp", host)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// ExternalIP returns the external IP address of the UPnP enabled device.
+//
+// NOTE: This is part of the Traversal interface.
+func (u *UPnP) ExternalIP() (net.IP, error) {
+	ip, err := u.device.GetExternalIPAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	externalIP := net.ParseIP(ip)
+	if externalIP == nil {
+		return nil, fmt.Errorf("device returned invalid external "+
+			"IP address: %v", ip)
+	}
+
+	return externalIP, nil
+}
+
+// AddPortMapping adds a TCP port mapping for the given port, or refreshes the
+// lease of an existing one.
+//
+// NOTE: This is part of the Traversal interface.
+func (u *UPnP) AddPortMapping(port uint16) error {
+	err := u.device.AddPortMapping(
+		"", port, "TCP", port, u.internalIP.String(), true,
+		"lnd", uint32(MappingLifetime.Seconds()),
+	)
+	if err != nil {
+		return err
+	}
+
+	u.forwardedPortsMtx.Lock()
+	u.forwardedPorts[port] = struct{}{}
+	u.forwardedPortsMtx.Unlock()
+
+	return nil
+}
+
+// DeletePortMapping removes the TCP port mapping for the given port.
+//
+// NOTE: This is part of the Traversal interface.
+func (u *UPnP) DeletePortMapping(port uint16) error {
+	u.forwardedPortsMtx.Lock()
+	defer u.forwardedPortsMtx.Unlock()
+
+	if _, ok := u.forwardedPorts[port]; !ok {
+		return fmt.Errorf("port %d is not being forwarded", port)
+	}
+
+	if err := u.device.DeletePortMapping("", port, "TCP"); err != nil {
+		return err
+	}
+
+	delete(u.forwardedPorts, port)
+
+	return nil
+}
+
+// ForwardedPorts returns the ports currently forwarded by the UPnP enabled
+// device.
+//
+// NOTE: This is part of the Traversal interface.
+func (u *UPnP) ForwardedPorts() []uint16 {
+	u.forwardedPortsMtx.Lock()
+	defer u.forwardedPortsMtx.Unlock()
+
+	ports := make([]uint16, 0, len(u.forwardedPorts))
+	for port := range u.forwardedPorts {
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// Name returns the name of the specific NAT traversal technique used.
+//
+// NOTE: This is part of the Traversal interface.
+func (u *UPnP) Name() string {
+	return "UPnP"
+}
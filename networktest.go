@@ -392,7 +392,7 @@ func (n *networkHarness) SetUp() error {
 	}
 	req := &lnrpc.ConnectPeerRequest{
 		Addr: &lnrpc.LightningAddress{
-			PubKeyHash: bobInfo.IdentityAddress,
+			PubKeyHash: bobInfo.IdentityPubkey,
 			Host:       n.Bob.p2pAddr,
 		},
 	}
@@ -565,9 +565,13 @@ func (n *networkHarness) CloseChannel(ctx context.Context,
 	lnNode *lightningNode, cp *lnrpc.ChannelPoint,
 	force bool) (lnrpc.Lightning_CloseChannelClient, error) {
 
+	// The harness only force closes channels while both nodes are
+	// online, so the check guarding against needless force closes is
+	// overridden.
 	closeReq := &lnrpc.CloseChannelRequest{
-		ChannelPoint: cp,
-		Force:        force,
+		ChannelPoint:    cp,
+		Force:           force,
+		AllowOnlinePeer: force,
 	}
 	closeRespStream, err := lnNode.CloseChannel(ctx, closeReq)
 	if err != nil {
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"math"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// maxEigenvectorIterations is the maximum number of rounds of power
+	// iteration carried out when computing eigenvector centrality.
+	maxEigenvectorIterations = 100
+
+	// eigenvectorTolerance is the change in the centrality vector between
+	// rounds of power iteration below which it's considered converged.
+	eigenvectorTolerance = 1e-6
+)
+
+// centralityGraph is a compact, undirected view of the channel graph used to
+// compute centrality metrics. Nodes are referred to by their index within
+// nodes, and multiple channels between the same pair of nodes are collapsed
+// into a single edge.
+type centralityGraph struct {
+	nodes []wire.ShaHash
+	adj   [][]int
+}
+
+// newCentralityGraph builds a centralityGraph from all the edges within the
+// passed channel graph. Nodes without any channels don't affect the
+// centrality of others, and so are left out.
+func newCentralityGraph(graph *channeldb.ChannelGraph) (*centralityGraph, error) {
+	g := &centralityGraph{}
+	index := make(map[wire.ShaHash]int)
+	neighbours := make(map[int]map[int]struct{})
+
+	nodeIndex := func(id wire.ShaHash) int {
+		i, ok := index[id]
+		if !ok {
+			i = len(g.nodes)
+			index[id] = i
+			g.nodes = append(g.nodes, id)
+			neighbours[i] = make(map[int]struct{})
+		}
+		return i
+	}
+
+	err := graph.ForEachChannel(func(edge *channeldb.ChannelEdge) error {
+		if edge.Node1 == edge.Node2 {
+			return nil
+		}
+
+		n1, n2 := nodeIndex(edge.Node1), nodeIndex(edge.Node2)
+		neighbours[n1][n2] = struct{}{}
+		neighbours[n2][n1] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.adj = make([][]int, len(g.nodes))
+	for i := range g.nodes {
+		for j := range neighbours[i] {
+			g.adj[i] = append(g.adj[i], j)
+		}
+	}
+
+	return g, nil
+}
+
+// betweenness computes the betweenness centrality of every node using
+// Brandes' algorithm: the number of shortest paths between all other pairs of
+// nodes which pass through the node, with each path weighted by the inverse
+// of the number of shortest paths between its endpoints. The raw centrality
+// of each node is returned, along with the centrality normalized by the
+// number of pairs of other nodes, which falls between 0 and 1.
+func (g *centralityGraph) betweenness() ([]float64, []float64) {
+	n := len(g.nodes)
+	centrality := make([]float64, n)
+
+	var (
+		stack = make([]int, 0, n)
+		queue = make([]int, 0, n)
+		preds = make([][]int, n)
+		sigma = make([]float64, n)
+		dist  = make([]int, n)
+		delta = make([]float64, n)
+	)
+	for s := 0; s < n; s++ {
+		stack = stack[:0]
+		for i := 0; i < n; i++ {
+			preds[i] = preds[i][:0]
+			sigma[i] = 0
+			dist[i] = -1
+			delta[i] = 0
+		}
+		sigma[s] = 1
+		dist[s] = 0
+
+		// Count the shortest paths from s to every other node with a
+		// breadth first search, recording the predecessors of each
+		// node along those paths.
+		queue = append(queue[:0], s)
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			stack = append(stack, v)
+
+			for _, w := range g.adj[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+
+		// Then accumulate the dependency of s on each node, visiting
+		// the nodes in order of non-increasing distance from s.
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+
+	// As the graph is undirected, each shortest path has been counted
+	// once from either of its endpoints.
+	normalized := make([]float64, n)
+	numPairs := float64(n-1) * float64(n-2) / 2
+	for i := range centrality {
+		centrality[i] /= 2
+		if numPairs > 0 {
+			normalized[i] = centrality[i] / numPairs
+		}
+	}
+
+	return centrality, normalized
+}
+
+// eigenvector computes the eigenvector centrality of every node by power
+// iteration, where the centrality of a node is proportional to the sum of the
+// centrality of its neighbours. The returned vector has unit length, and is
+// returned along with the centrality of each node relative to that of the
+// most central node.
+func (g *centralityGraph) eigenvector() ([]float64, []float64) {
+	n := len(g.nodes)
+	centrality := make([]float64, n)
+	for i := range centrality {
+		centrality[i] = 1 / math.Sqrt(float64(n))
+	}
+
+	next := make([]float64, n)
+	for iter := 0; iter < maxEigenvectorIterations; iter++ {
+		// Each node keeps its own centrality in addition to the sum of
+		// its neighbours'. This shifts every eigenvalue by one without
+		// changing the eigenvectors, ensuring the iteration converges
+		// even on bipartite graphs, such as those shaped like a star.
+		var norm float64
+		for v := range next {
+			next[v] = centrality[v]
+			for _, w := range g.adj[v] {
+				next[v] += centrality[w]
+			}
+			norm += next[v] * next[v]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			break
+		}
+
+		var change float64
+		for v := range next {
+			next[v] /= norm
+			change += math.Abs(next[v] - centrality[v])
+		}
+		centrality, next = next, centrality
+
+		if change < eigenvectorTolerance*float64(n) {
+			break
+		}
+	}
+
+	var max float64
+	for _, c := range centrality {
+		max = math.Max(max, c)
+	}
+	relative := make([]float64, n)
+	for i, c := range centrality {
+		if max > 0 {
+			relative[i] = c / max
+		}
+	}
+
+	return centrality, relative
+}
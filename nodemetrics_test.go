@@ -0,0 +1,132 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestCentrality tests the betweenness and eigenvector centrality computed
+// for small graphs whose centrality is known.
+func TestCentrality(t *testing.T) {
+	var (
+		a = wire.ShaHash{1}
+		b = wire.ShaHash{2}
+		c = wire.ShaHash{3}
+		d = wire.ShaHash{4}
+	)
+
+	// The eigenvector of a path of four nodes is proportional to
+	// sin(k*pi/5) for the k-th node along the path.
+	var (
+		pathEnd = math.Sin(math.Pi/5) / math.Sqrt(2.5)
+		pathMid = math.Sin(2*math.Pi/5) / math.Sqrt(2.5)
+	)
+
+	type centrality struct {
+		betweenness, normBetweenness float64
+		eigenvector, relEigenvector  float64
+	}
+	tests := []struct {
+		name     string
+		channels []testChannel
+		expected map[wire.ShaHash]centrality
+	}{
+		{
+			// a - b - c - d
+			name: "path",
+			channels: []testChannel{
+				{node1: a, node2: b},
+				{node1: b, node2: c},
+				{node1: c, node2: d},
+			},
+			expected: map[wire.ShaHash]centrality{
+				a: {0, 0, pathEnd, pathEnd / pathMid},
+				b: {2, 2.0 / 3, pathMid, 1},
+				c: {2, 2.0 / 3, pathMid, 1},
+				d: {0, 0, pathEnd, pathEnd / pathMid},
+			},
+		},
+		{
+			// a at the center, connected to each of b, c and d.
+			// Parallel channels are collapsed into a single edge.
+			name: "star",
+			channels: []testChannel{
+				{node1: a, node2: b},
+				{node1: a, node2: c},
+				{node1: d, node2: a},
+				{node1: a, node2: d},
+			},
+			expected: map[wire.ShaHash]centrality{
+				a: {3, 1, 1 / math.Sqrt2, 1},
+				b: {0, 0, 1 / math.Sqrt(6), 1 / math.Sqrt(3)},
+				c: {0, 0, 1 / math.Sqrt(6), 1 / math.Sqrt(3)},
+				d: {0, 0, 1 / math.Sqrt(6), 1 / math.Sqrt(3)},
+			},
+		},
+		{
+			// a - b - c - d - a, where each pair of opposite nodes
+			// is joined by two shortest paths.
+			name: "cycle",
+			channels: []testChannel{
+				{node1: a, node2: b},
+				{node1: b, node2: c},
+				{node1: c, node2: d},
+				{node1: d, node2: a},
+			},
+			expected: map[wire.ShaHash]centrality{
+				a: {0.5, 1.0 / 6, 0.5, 1},
+				b: {0.5, 1.0 / 6, 0.5, 1},
+				c: {0.5, 1.0 / 6, 0.5, 1},
+				d: {0.5, 1.0 / 6, 0.5, 1},
+			},
+		},
+	}
+
+	// assertClose asserts that a computed metric is within the tolerance
+	// of power iteration of its expected value.
+	assertClose := func(test, metric string, node wire.ShaHash,
+		expected, actual float64) {
+
+		if math.Abs(expected-actual) > 1e-4 {
+			t.Fatalf("%s: expected %s of node %x to be %v, got %v",
+				test, metric, node[:1], expected, actual)
+		}
+	}
+
+	for _, test := range tests {
+		graph, cleanUp, err := makeTestGraph()
+		if err != nil {
+			t.Fatalf("unable to create graph: %v", err)
+		}
+		if _, err := addTestChannels(graph, test.channels); err != nil {
+			cleanUp()
+			t.Fatalf("%s: unable to add channels: %v", test.name, err)
+		}
+		g, err := newCentralityGraph(graph)
+		cleanUp()
+		if err != nil {
+			t.Fatalf("%s: unable to build graph: %v", test.name, err)
+		}
+
+		if len(g.nodes) != len(test.expected) {
+			t.Fatalf("%s: expected %v nodes, got %v", test.name,
+				len(test.expected), len(g.nodes))
+		}
+
+		betweenness, normBetweenness := g.betweenness()
+		eigenvector, relEigenvector := g.eigenvector()
+		for i, node := range g.nodes {
+			expected := test.expected[node]
+			assertClose(test.name, "betweenness", node,
+				expected.betweenness, betweenness[i])
+			assertClose(test.name, "normalized betweenness", node,
+				expected.normBetweenness, normBetweenness[i])
+			assertClose(test.name, "eigenvector", node,
+				expected.eigenvector, eigenvector[i])
+			assertClose(test.name, "relative eigenvector", node,
+				expected.relEigenvector, relEigenvector[i])
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/codahale/chacha20"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// failureMessageLen is the length of the padded plaintext failure
+	// message. All failures are padded to the same length so the
+	// intermediate hops are unable to distinguish between failure types.
+	failureMessageLen = 128
+
+	// encryptedFailureLen is the total length of an encrypted failure,
+	// consisting of a 32-byte HMAC followed by the padded failure.
+	encryptedFailureLen = sha256.Size + failureMessageLen
+)
+
+var (
+	// errFailureUnreadable is returned when an encrypted failure cannot
+	// be attributed to any of the hops within the route.
+	errFailureUnreadable = fmt.Errorf("unable to decrypt failure from " +
+		"any hop within the route")
+)
+
+// generateFailureKey derives a key of the given type from the shared secret
+// established between the origin of a payment and a hop within its route.
+func generateFailureKey(keyType string, sharedSecret [32]byte) []byte {
+	mac := hmac.New(sha256.New, []byte(keyType))
+	mac.Write(sharedSecret[:])
+	return mac.Sum(nil)
+}
+
+// xorFailureStream encrypts, or decrypts, the passed failure in place using a
+// ChaCha20 stream keyed by the "ammag" key of the shared secret.
+func xorFailureStream(sharedSecret [32]byte, failure []byte) error {
+	var nonce [8]byte
+	stream, err := chacha20.New(generateFailureKey("ammag", sharedSecret),
+		nonce[:])
+	if err != nil {
+		return err
+	}
+
+	stream.XORKeyStream(failure, failure)
+	return nil
+}
+
+// hopFailureSecret derives the shared secret a hop encrypts its failures with
+// from the failure secret chosen by the origin of a payment, and the lightning
+// ID of the hop. The origin carries its failure secret within the HopPayload
+// of the HTLC, allowing each hop to derive its own shared secret, while the
+// origin derives those of every hop within the route.
+//
+// TODO(roasbeef): derive via ECDH with the sphinx session key once the
+// payload is wrapped within a sphinx packet. Until then, any hop able to read
+// the payload is able to forge failures from the other hops.
+func hopFailureSecret(failureSecret [32]byte, nodeID wire.ShaHash) [32]byte {
+	h := sha256.New()
+	h.Write(failureSecret[:])
+	h.Write(nodeID[:])
+
+	var sharedSecret [32]byte
+	copy(sharedSecret[:], h.Sum(nil))
+	return sharedSecret
+}
+
+// failureEncrypter is used by a hop within a route to encrypt failures sent
+// back towards the origin of a payment. Each hop holds the shared secret
+// derived from the payload of the HTLC it's rejecting.
+//
+// TODO(roasbeef): re-encrypt failures received from downstream hops once
+// multi-hop forwarding is in place.
+type failureEncrypter struct {
+	sharedSecret [32]byte
+}
+
+// encryptFailure serializes, authenticates, then encrypts a failure
+// originating from this hop. The returned reason is suitable for inclusion
+// within an HTLCAddReject message.
+func (f *failureEncrypter) encryptFailure(failure *lnwire.FailureMessage) ([]byte, error) {
+	var b bytes.Buffer
+	if err := failure.Encode(&b); err != nil {
+		return nil, err
+	}
+	if b.Len() > failureMessageLen {
+		return nil, fmt.Errorf("failure message too long: %v bytes",
+			b.Len())
+	}
+
+	// Pad the failure such that all failures are of identical length,
+	// then prefix it with an HMAC keyed by our "um" key. The HMAC allows
+	// the origin to identify us as the source of the failure.
+	padded := make([]byte, failureMessageLen)
+	copy(padded, b.Bytes())
+
+	mac := hmac.New(sha256.New, generateFailureKey("um", f.sharedSecret))
+	mac.Write(padded)
+
+	reason := make([]byte, 0, encryptedFailureLen)
+	reason = append(reason, mac.Sum(nil)...)
+	reason = append(reason, padded...)
+
+	if err := xorFailureStream(f.sharedSecret, reason); err != nil {
+		return nil, err
+	}
+
+	return reason, nil
+}
+
+// decryptFailure is used by the origin of a payment to decrypt a failure
+// received in response to one of its HTLC's. The shared secrets of each hop
+// within the route are passed in order. Each layer of encryption is peeled
+// off in turn until the HMAC of a hop verifies, identifying that hop as the
+// source of the failure. The returned index is the position of the failing
+// hop within the route, starting at 1 for the first hop, as index 0 is
+// reserved for failures which originate from our own node.
+func decryptFailure(sharedSecrets [][32]byte,
+	reason []byte) (uint32, *lnwire.FailureMessage, error) {
+
+	if len(reason) != encryptedFailureLen {
+		return 0, nil, fmt.Errorf("invalid failure length: %v bytes",
+			len(reason))
+	}
+
+	plaintext := make([]byte, len(reason))
+	copy(plaintext, reason)
+
+	for i, sharedSecret := range sharedSecrets {
+		if err := xorFailureStream(sharedSecret, plaintext); err != nil {
+			return 0, nil, err
+		}
+
+		expectedMac := plaintext[:sha256.Size]
+		padded := plaintext[sha256.Size:]
+
+		mac := hmac.New(sha256.New, generateFailureKey("um", sharedSecret))
+		mac.Write(padded)
+		if !hmac.Equal(mac.Sum(nil), expectedMac) {
+			continue
+		}
+
+		failure := &lnwire.FailureMessage{}
+		if err := failure.Decode(bytes.NewReader(padded)); err != nil {
+			return 0, nil, err
+		}
+
+		return uint32(i + 1), failure, nil
+	}
+
+	return 0, nil, errFailureUnreadable
+}
+
+// encryptedFailure is an error returned by a channel link once the remote
+// peer rejects one of our outgoing HTLC's. The failure is still encrypted,
+// and must be decrypted using the circuit of the payment it pertains to.
+type encryptedFailure []byte
+
+// Error returns a human readable description of the encrypted failure.
+//
+// NOTE: Part of the error interface.
+func (e encryptedFailure) Error() string {
+	return fmt.Sprintf("encrypted failure: %x", []byte(e))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestFailureEncryption tests that a failure encrypted by a hop using the
+// failure secret within its payload, then carried back within an
+// HTLCAddReject, is attributed to that hop by the origin of the payment.
+func TestFailureEncryption(t *testing.T) {
+	route := []wire.ShaHash{{1}, {2}, {3}}
+	failureSecret := [32]byte{9}
+	sharedSecrets := make([][32]byte, len(route))
+	for i, nodeID := range route {
+		sharedSecrets[i] = hopFailureSecret(failureSecret, nodeID)
+	}
+
+	var payload bytes.Buffer
+	hopPayload := &lnwire.HopPayload{FailureSecret: failureSecret}
+	if err := hopPayload.Encode(&payload); err != nil {
+		t.Fatalf("unable to encode payload: %v", err)
+	}
+
+	// The first hop decodes its payload, then rejects the HTLC with a
+	// failure encrypted using its own shared secret.
+	decodedPayload := &lnwire.HopPayload{}
+	if err := decodedPayload.Decode(&payload); err != nil {
+		t.Fatalf("unable to decode payload: %v", err)
+	}
+	encrypter := &failureEncrypter{
+		sharedSecret: hopFailureSecret(decodedPayload.FailureSecret,
+			route[0]),
+	}
+	failure := &lnwire.FailureMessage{
+		Code:   lnwire.CodeIncorrectPaymentDetails,
+		Amount: 1000,
+	}
+	reason, err := encrypter.encryptFailure(failure)
+	if err != nil {
+		t.Fatalf("unable to encrypt failure: %v", err)
+	}
+
+	var b bytes.Buffer
+	reject := &lnwire.HTLCAddReject{
+		ChannelPoint: &wire.OutPoint{},
+		HTLCKey:      1,
+		Reason:       reason,
+	}
+	if err := reject.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode reject: %v", err)
+	}
+	reject = &lnwire.HTLCAddReject{}
+	if err := reject.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode reject: %v", err)
+	}
+
+	// The origin should attribute the failure to the first hop.
+	paymentErr, ok := decodeSendFailure(encryptedFailure(reject.Reason),
+		sharedSecrets)
+	if !ok {
+		t.Fatalf("unable to decode failure")
+	}
+	if paymentErr.sourceIndex != 1 {
+		t.Fatalf("expected failure from hop 1, got %v",
+			paymentErr.sourceIndex)
+	}
+	if !reflect.DeepEqual(paymentErr.failure, failure) {
+		t.Fatalf("expected failure %v, got %v", failure,
+			paymentErr.failure)
+	}
+
+	// A failure encrypted by a hop outside of the route can't be
+	// attributed to any hop.
+	encrypter.sharedSecret = hopFailureSecret(failureSecret,
+		wire.ShaHash{4})
+	reason, err = encrypter.encryptFailure(failure)
+	if err != nil {
+		t.Fatalf("unable to encrypt failure: %v", err)
+	}
+	_, _, err = decryptFailure(sharedSecrets, reason)
+	if err != errFailureUnreadable {
+		t.Fatalf("expected errFailureUnreadable, got %v", err)
+	}
+}
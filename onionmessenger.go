@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/onionmsg"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// onionMessageBurst is the number of onion messages a peer may send us
+	// in quick succession before it's rate limited.
+	onionMessageBurst = 20
+
+	// onionMessageInterval is the interval at which a rate limited peer is
+	// permitted to send us another onion message.
+	onionMessageInterval = 100 * time.Millisecond
+)
+
+// onionMessageLimiter rate limits the onion messages received from a single
+// peer, using a token bucket which holds up to onionMessageBurst tokens, and
+// gains a token each onionMessageInterval.
+type onionMessageLimiter struct {
+	tokens     int
+	lastRefill time.Time
+}
+
+// allow consumes a token if one is available at the passed time, returning
+// false if the peer has exhausted its allowance.
+func (l *onionMessageLimiter) allow(now time.Time) bool {
+	refills := int(now.Sub(l.lastRefill) / onionMessageInterval)
+	if refills > 0 {
+		l.tokens += refills
+		if l.tokens > onionMessageBurst {
+			l.tokens = onionMessageBurst
+		}
+		l.lastRefill = l.lastRefill.Add(
+			time.Duration(refills) * onionMessageInterval,
+		)
+	}
+
+	if l.tokens == 0 {
+		return false
+	}
+	l.tokens--
+
+	return true
+}
+
+// onionMessagePeer is a connected peer which relays onion messages, along
+// with the limiter applied to the onion messages it sends us.
+type onionMessagePeer struct {
+	peer    *peer
+	limiter *onionMessageLimiter
+}
+
+// onionMessageSubscription is a client subscription to all onion messages
+// addressed to us. Each message is delivered over the messages channel.
+type onionMessageSubscription struct {
+	id uint64
+
+	messages chan *onionmsg.Message
+
+	cancel func()
+}
+
+// onionMessenger relays onion messages between our peers, sends the onion
+// messages we originate, and delivers those addressed to us to all
+// subscribed clients. Onion messages are only exchanged with peers which
+// advertise support for them, and are delivered on a best-effort basis:
+// messages are dropped rather than queued whenever a peer sends them too
+// quickly, the next hop isn't connected, or a subscriber is slow.
+type onionMessenger struct {
+	// nodeKey is our node's identity key, with which we peel off our
+	// layer of each onion message we receive.
+	nodeKey brontide.SingleKeyECDH
+
+	peerMtx sync.Mutex
+	peers   map[wire.ShaHash]*onionMessagePeer
+
+	subscribers   map[uint64]*onionMessageSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newOnionMessenger creates a new onionMessenger which processes onion
+// messages using the passed identity key.
+func newOnionMessenger(nodeKey brontide.SingleKeyECDH) *onionMessenger {
+	return &onionMessenger{
+		nodeKey:     nodeKey,
+		peers:       make(map[wire.ShaHash]*onionMessagePeer),
+		subscribers: make(map[uint64]*onionMessageSubscription),
+	}
+}
+
+// supportsOnionMessages returns true if the passed peer advertised support
+// for onion messages within its Init message.
+func supportsOnionMessages(p *peer) bool {
+	return p.remoteFeatures != nil &&
+		(p.remoteFeatures.HasFeature(lnwire.OnionMessagesOptional) ||
+			p.remoteFeatures.HasFeature(lnwire.OnionMessagesRequired))
+}
+
+// addPeer registers a newly connected peer, allowing onion messages to be
+// exchanged with it if it supports them.
+func (m *onionMessenger) addPeer(p *peer) {
+	if !supportsOnionMessages(p) {
+		return
+	}
+
+	m.peerMtx.Lock()
+	m.peers[p.lightningID] = &onionMessagePeer{
+		peer: p,
+		limiter: &onionMessageLimiter{
+			tokens:     onionMessageBurst,
+			lastRefill: time.Now(),
+		},
+	}
+	m.peerMtx.Unlock()
+}
+
+// removePeer unregisters a peer whose connection has been torn down.
+func (m *onionMessenger) removePeer(p *peer) {
+	m.peerMtx.Lock()
+	if omPeer, ok := m.peers[p.lightningID]; ok && omPeer.peer == p {
+		delete(m.peers, p.lightningID)
+	}
+	m.peerMtx.Unlock()
+}
+
+// sendPacket sends the passed onion packet to the connected peer with the
+// given identity key.
+func (m *onionMessenger) sendPacket(nodeKey *btcec.PublicKey,
+	packet *onionmsg.Packet) error {
+
+	lightningID := wire.ShaHash(fastsha256.Sum256(
+		nodeKey.SerializeCompressed(),
+	))
+
+	m.peerMtx.Lock()
+	omPeer, ok := m.peers[lightningID]
+	m.peerMtx.Unlock()
+	if !ok {
+		return fmt.Errorf("peer %x isn't connected, or doesn't "+
+			"support onion messages", nodeKey.SerializeCompressed())
+	}
+
+	var b bytes.Buffer
+	if err := packet.Encode(&b); err != nil {
+		return err
+	}
+	omPeer.peer.queueMsg(&lnwire.OnionMessage{Onion: b.Bytes()}, nil)
+
+	return nil
+}
+
+// sendMessage sends the passed message along the given route, the first node
+// of which must be a connected peer, and the last the message's recipient.
+func (m *onionMessenger) sendMessage(route []*btcec.PublicKey,
+	msg *onionmsg.Message) error {
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return err
+	}
+	packet, err := onionmsg.NewPacket(sessionKey, route, msg)
+	if err != nil {
+		return err
+	}
+
+	return m.sendPacket(route[0], packet)
+}
+
+// handleMessage processes an onion message received from the passed peer.
+// The message is either forwarded to the next hop, or if addressed to us,
+// delivered to all subscribers.
+func (m *onionMessenger) handleMessage(p *peer, msg *lnwire.OnionMessage) {
+	m.peerMtx.Lock()
+	omPeer, ok := m.peers[p.lightningID]
+	allowed := ok && omPeer.limiter.allow(time.Now())
+	m.peerMtx.Unlock()
+	if !ok {
+		peerLog.Debugf("Ignoring onion message from %v, which didn't "+
+			"advertise support for onion messages", p)
+		return
+	} else if !allowed {
+		peerLog.Debugf("Dropping onion message from %v, rate limit "+
+			"exceeded", p)
+		return
+	}
+
+	packet := &onionmsg.Packet{}
+	if err := packet.Decode(bytes.NewReader(msg.Onion)); err != nil {
+		peerLog.Debugf("Unable to decode onion message from %v: %v",
+			p, err)
+		return
+	}
+	processed, err := onionmsg.ProcessPacket(m.nodeKey, packet)
+	if err != nil {
+		peerLog.Debugf("Unable to process onion message from %v: %v",
+			p, err)
+		return
+	}
+
+	if processed.NextPacket == nil {
+		m.notify(processed.Payload.Message)
+		return
+	}
+
+	err = m.sendPacket(processed.Payload.NextNode, processed.NextPacket)
+	if err != nil {
+		peerLog.Debugf("Unable to forward onion message from %v: %v",
+			p, err)
+	}
+}
+
+// subscribe creates a new subscription to all onion messages addressed to
+// us which arrive after this call returns.
+func (m *onionMessenger) subscribe() *onionMessageSubscription {
+	m.subscriberMtx.Lock()
+	defer m.subscriberMtx.Unlock()
+
+	clientID := m.nextClientID
+	m.nextClientID++
+
+	sub := &onionMessageSubscription{
+		id:       clientID,
+		messages: make(chan *onionmsg.Message, 20),
+	}
+	sub.cancel = func() {
+		m.subscriberMtx.Lock()
+		delete(m.subscribers, clientID)
+		m.subscriberMtx.Unlock()
+	}
+	m.subscribers[clientID] = sub
+
+	return sub
+}
+
+// notify delivers the passed message to all current subscribers.
+func (m *onionMessenger) notify(msg *onionmsg.Message) {
+	m.subscriberMtx.Lock()
+	defer m.subscriberMtx.Unlock()
+
+	if len(m.subscribers) == 0 {
+		peerLog.Debugf("Dropping onion message of type %v, no "+
+			"subscribers", msg.Type)
+		return
+	}
+
+	for _, sub := range m.subscribers {
+		select {
+		case sub.messages <- msg:
+		default:
+			peerLog.Warnf("Dropping onion message for slow "+
+				"subscriber %v", sub.id)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// Package onionmsg implements the onion packets which carry messages between
+// nodes independently of HTLCs. A packet is constructed by the sender in the
+// manner of the Sphinx mix-format of BOLT 4: each hop along the route peels
+// off one layer of encryption, revealing only its own payload, which either
+// names the next node to forward the packet to, or carries the message if the
+// hop is the final recipient. No hop learns its position within the route,
+// nor the identity of the sender.
+package onionmsg
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/codahale/chacha20"
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// Version is the version of the packet format.
+	Version byte = 0
+
+	// RoutingInfoSize is the size of the encrypted routing information
+	// within a packet, which holds the payloads of all hops.
+	RoutingInfoSize = 1300
+
+	// hmacSize is the size of the HMAC authenticating each layer of the
+	// packet.
+	hmacSize = sha256.Size
+
+	// PacketSize is the size of a serialized packet: the version byte,
+	// the ephemeral key, the routing information, and its HMAC.
+	PacketSize = 1 + btcec.PubKeyBytesLenCompressed + RoutingInfoSize +
+		hmacSize
+)
+
+var (
+	// ErrInvalidHMAC is returned when the HMAC of a packet doesn't match
+	// its routing information, meaning the packet was either corrupted or
+	// isn't intended for us.
+	ErrInvalidHMAC = errors.New("onion message hmac mismatch")
+
+	// ErrRouteTooLong is returned when the payloads of the hops along a
+	// route don't fit within a single packet.
+	ErrRouteTooLong = errors.New("onion message route payloads exceed " +
+		"the packet size")
+)
+
+// Packet is a single layer of an onion message, as sent to a hop along its
+// route.
+type Packet struct {
+	// Version is the version of the packet format.
+	Version byte
+
+	// EphemeralKey is the key the receiving hop performs ECDH with to
+	// derive the secret shared with the sender.
+	EphemeralKey *btcec.PublicKey
+
+	// RoutingInfo is the encrypted payloads of the receiving hop, and all
+	// hops following it.
+	RoutingInfo [RoutingInfoSize]byte
+
+	// HMAC authenticates the routing information to the receiving hop.
+	HMAC [hmacSize]byte
+}
+
+// Encode serializes the packet into the passed io.Writer.
+func (p *Packet) Encode(w io.Writer) error {
+	if _, err := w.Write([]byte{p.Version}); err != nil {
+		return err
+	}
+	if _, err := w.Write(p.EphemeralKey.SerializeCompressed()); err != nil {
+		return err
+	}
+	if _, err := w.Write(p.RoutingInfo[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(p.HMAC[:])
+	return err
+}
+
+// Decode deserializes a packet from the passed io.Reader.
+func (p *Packet) Decode(r io.Reader) error {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return err
+	}
+	p.Version = version[0]
+	if p.Version != Version {
+		return fmt.Errorf("unknown onion message version %v", p.Version)
+	}
+
+	var ephemeralKey [btcec.PubKeyBytesLenCompressed]byte
+	if _, err := io.ReadFull(r, ephemeralKey[:]); err != nil {
+		return err
+	}
+	var err error
+	p.EphemeralKey, err = btcec.ParsePubKey(ephemeralKey[:], btcec.S256())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.ReadFull(r, p.RoutingInfo[:]); err != nil {
+		return err
+	}
+
+	_, err = io.ReadFull(r, p.HMAC[:])
+	return err
+}
+
+// hop is the encoded payload of a single hop along the route of a packet
+// under construction, along with the secret the hop shares with the sender.
+type hop struct {
+	sharedSecret []byte
+	payload      []byte
+}
+
+// frameSize returns the number of bytes the hop occupies within the routing
+// information: its length prefixed payload, followed by the HMAC of the next
+// layer.
+func (h *hop) frameSize() int {
+	return bigSizeLen(uint64(len(h.payload))) + len(h.payload) + hmacSize
+}
+
+// NewPacket constructs a packet carrying the passed message along the given
+// route, the last node of which is the message's recipient. The session key
+// must be freshly generated for each packet, as it determines the secrets the
+// sender shares with each hop.
+func NewPacket(sessionKey *btcec.PrivateKey, route []*btcec.PublicKey,
+	msg *Message) (*Packet, error) {
+
+	if len(route) == 0 {
+		return nil, fmt.Errorf("onion message route is empty")
+	}
+
+	// First, we'll encode the payload of each hop. Each hop but the last
+	// is told the next node to forward the packet to, while the last hop
+	// receives the message itself.
+	hops := make([]hop, len(route))
+	var totalSize int
+	for i := range route {
+		payload := &Payload{Message: msg}
+		if i < len(route)-1 {
+			payload = &Payload{NextNode: route[i+1]}
+		}
+
+		var b bytes.Buffer
+		if err := payload.Encode(&b); err != nil {
+			return nil, err
+		}
+		hops[i].payload = b.Bytes()
+		totalSize += hops[i].frameSize()
+	}
+	if totalSize > RoutingInfoSize {
+		return nil, ErrRouteTooLong
+	}
+
+	// Next, we'll derive the secret shared with each hop. The ephemeral
+	// key presented to each hop is blinded by the previous hop, so the
+	// keys seen by the hops can't be linked to one another.
+	curve := btcec.S256()
+	ephemeralPriv := new(big.Int).Set(sessionKey.D)
+	ephemeralKey := sessionKey.PubKey()
+	for i, nodeKey := range route {
+		x, y := curve.ScalarMult(nodeKey.X, nodeKey.Y,
+			ephemeralPriv.Bytes())
+		hops[i].sharedSecret = sharedSecret(&btcec.PublicKey{
+			Curve: curve, X: x, Y: y,
+		})
+
+		blindingFactor := computeBlindingFactor(ephemeralKey,
+			hops[i].sharedSecret)
+		ephemeralPriv.Mul(ephemeralPriv, blindingFactor)
+		ephemeralPriv.Mod(ephemeralPriv, curve.N)
+
+		x, y = curve.ScalarBaseMult(ephemeralPriv.Bytes())
+		ephemeralKey = &btcec.PublicKey{Curve: curve, X: x, Y: y}
+	}
+
+	// The routing information is initially filled with pseudo-random
+	// bytes, so the unused tail of the packet seen by the final hop gives
+	// away nothing about the length of the route.
+	var routingInfo [RoutingInfoSize]byte
+	padKey := generateKey("pad", sessionKey.Serialize())
+	if err := xorCipherStream(padKey, routingInfo[:]); err != nil {
+		return nil, err
+	}
+
+	filler, err := generateFiller(hops)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, we'll wrap the payloads in layers of encryption, starting
+	// from the final hop. Each layer is shifted right to make room for
+	// the payload of its hop, then encrypted, then authenticated by an
+	// HMAC which is handed to the previous hop within its own payload.
+	var nextHMAC [hmacSize]byte
+	for i := len(hops) - 1; i >= 0; i-- {
+		shift := hops[i].frameSize()
+		copy(routingInfo[shift:], routingInfo[:RoutingInfoSize-shift])
+
+		var frame bytes.Buffer
+		if err := writeBigSize(&frame, uint64(len(hops[i].payload))); err != nil {
+			return nil, err
+		}
+		frame.Write(hops[i].payload)
+		frame.Write(nextHMAC[:])
+		copy(routingInfo[:], frame.Bytes())
+
+		rhoKey := generateKey("rho", hops[i].sharedSecret)
+		if err := xorCipherStream(rhoKey, routingInfo[:]); err != nil {
+			return nil, err
+		}
+
+		// The tail of the outermost layer of the final hop is replaced
+		// with the filler, such that the HMACs computed by the prior
+		// hops cover the bytes they'll shift into the packet.
+		if i == len(hops)-1 {
+			copy(routingInfo[RoutingInfoSize-len(filler):], filler)
+		}
+
+		muKey := generateKey("mu", hops[i].sharedSecret)
+		copy(nextHMAC[:], computeHMAC(muKey, routingInfo[:]))
+	}
+
+	return &Packet{
+		Version:      Version,
+		EphemeralKey: sessionKey.PubKey(),
+		RoutingInfo:  routingInfo,
+		HMAC:         nextHMAC,
+	}, nil
+}
+
+// generateFiller generates the bytes which each hop but the last appends to
+// the routing information when peeling off its layer, as encrypted by the
+// hops along the route.
+func generateFiller(hops []hop) ([]byte, error) {
+	var fillerSize int
+	for _, h := range hops[:len(hops)-1] {
+		fillerSize += h.frameSize()
+	}
+	filler := make([]byte, fillerSize)
+
+	// The filler accumulated by the time the packet reaches a hop is
+	// shifted left by the frame of the hop, and extended by the bytes of
+	// the hop's cipher stream beyond the end of the routing information.
+	fillerStart := RoutingInfoSize
+	for _, h := range hops[:len(hops)-1] {
+		fillerEnd := RoutingInfoSize + h.frameSize()
+
+		stream := make([]byte, 2*RoutingInfoSize)
+		rhoKey := generateKey("rho", h.sharedSecret)
+		if err := xorCipherStream(rhoKey, stream); err != nil {
+			return nil, err
+		}
+
+		xor(filler, stream[fillerStart:fillerEnd])
+		fillerStart -= h.frameSize()
+	}
+
+	return filler, nil
+}
+
+// ProcessedPacket is the result of a hop peeling off its layer of a packet.
+type ProcessedPacket struct {
+	// Payload is the payload intended for the hop.
+	Payload *Payload
+
+	// NextPacket is the packet to forward to the node named within the
+	// payload. It's nil if the hop is the final recipient.
+	NextPacket *Packet
+}
+
+// ProcessPacket peels off the layer of the passed packet intended for the
+// node with the given key, authenticating it, then returns the node's payload
+// along with the packet to forward to the next hop, if any.
+func ProcessPacket(nodeKey brontide.SingleKeyECDH,
+	packet *Packet) (*ProcessedPacket, error) {
+
+	secret, err := nodeKey.ECDH(packet.EphemeralKey)
+	if err != nil {
+		return nil, err
+	}
+
+	muKey := generateKey("mu", secret)
+	if !hmac.Equal(computeHMAC(muKey, packet.RoutingInfo[:]),
+		packet.HMAC[:]) {
+
+		return nil, ErrInvalidHMAC
+	}
+
+	// With the packet authenticated, we'll decrypt our layer. The routing
+	// information is first extended by zeroes, such that the packet keeps
+	// its size once our payload is removed from the front.
+	hopInfo := make([]byte, 2*RoutingInfoSize)
+	copy(hopInfo, packet.RoutingInfo[:])
+	rhoKey := generateKey("rho", secret)
+	if err := xorCipherStream(rhoKey, hopInfo); err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(hopInfo)
+	payloadLen, err := readBigSize(r)
+	if err != nil {
+		return nil, err
+	}
+	if payloadLen > RoutingInfoSize {
+		return nil, ErrInvalidPayload
+	}
+	frameSize := bigSizeLen(payloadLen) + int(payloadLen) + hmacSize
+	if frameSize > RoutingInfoSize {
+		return nil, ErrInvalidPayload
+	}
+	payloadStart := bigSizeLen(payloadLen)
+	payloadEnd := payloadStart + int(payloadLen)
+
+	payload := &Payload{}
+	if err := payload.Decode(hopInfo[payloadStart:payloadEnd]); err != nil {
+		return nil, err
+	}
+
+	// An HMAC of all zeroes signals that we're the final hop, in which
+	// case our payload must carry a message, rather than the next node.
+	var nextHMAC [hmacSize]byte
+	copy(nextHMAC[:], hopInfo[payloadEnd:frameSize])
+	if nextHMAC == [hmacSize]byte{} {
+		if payload.NextNode != nil || payload.Message == nil {
+			return nil, ErrInvalidPayload
+		}
+
+		return &ProcessedPacket{Payload: payload}, nil
+	}
+	if payload.NextNode == nil || payload.Message != nil {
+		return nil, ErrInvalidPayload
+	}
+
+	// The ephemeral key of the next hop is our own, blinded by a factor
+	// only we and the sender know.
+	blindingFactor := computeBlindingFactor(packet.EphemeralKey, secret)
+	curve := btcec.S256()
+	x, y := curve.ScalarMult(packet.EphemeralKey.X, packet.EphemeralKey.Y,
+		blindingFactor.Bytes())
+
+	nextPacket := &Packet{
+		Version:      Version,
+		EphemeralKey: &btcec.PublicKey{Curve: curve, X: x, Y: y},
+		HMAC:         nextHMAC,
+	}
+	copy(nextPacket.RoutingInfo[:], hopInfo[frameSize:])
+
+	return &ProcessedPacket{
+		Payload:    payload,
+		NextPacket: nextPacket,
+	}, nil
+}
+
+// sharedSecret returns the secret derived from the passed ECDH point: the
+// sha256 of its compressed serialization.
+func sharedSecret(point *btcec.PublicKey) []byte {
+	secret := sha256.Sum256(point.SerializeCompressed())
+	return secret[:]
+}
+
+// computeBlindingFactor computes the factor the ephemeral key presented to a
+// hop is blinded by for the next hop: the sha256 of the ephemeral key and the
+// secret shared with the hop.
+func computeBlindingFactor(ephemeralKey *btcec.PublicKey,
+	secret []byte) *big.Int {
+
+	h := sha256.New()
+	h.Write(ephemeralKey.SerializeCompressed())
+	h.Write(secret)
+
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// generateKey derives a key of the given type from the passed secret.
+func generateKey(keyType string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(keyType))
+	mac.Write(secret)
+	return mac.Sum(nil)
+}
+
+// computeHMAC returns the HMAC-SHA256 of the passed data under the given key.
+func computeHMAC(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// xorCipherStream encrypts, or decrypts, the passed bytes in place using a
+// ChaCha20 stream with the given key and a zero nonce.
+func xorCipherStream(key, b []byte) error {
+	var nonce [8]byte
+	stream, err := chacha20.New(key, nonce[:])
+	if err != nil {
+		return err
+	}
+
+	stream.XORKeyStream(b, b)
+	return nil
+}
+
+// xor sets each byte of dst to its XOR with the byte at the same position
+// within src, up to the length of the shorter of the two.
+func xor(dst, src []byte) {
+	for i := 0; i < len(dst) && i < len(src); i++ {
+		dst[i] ^= src[i]
+	}
+}
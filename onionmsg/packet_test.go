@@ -0,0 +1,172 @@
+package onionmsg
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// newRoute generates the keys of the nodes along a route of the passed
+// length.
+func newRoute(t *testing.T, numHops int) ([]*brontide.PrivKeyECDH,
+	[]*btcec.PublicKey) {
+
+	nodes := make([]*brontide.PrivKeyECDH, numHops)
+	route := make([]*btcec.PublicKey, numHops)
+	for i := range nodes {
+		privKey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		nodes[i] = &brontide.PrivKeyECDH{PrivKey: privKey}
+		route[i] = privKey.PubKey()
+	}
+
+	return nodes, route
+}
+
+// TestOnionMessageRoute tests that a message sent along a route is forwarded
+// by each hop to the next, and is only revealed to the final hop.
+func TestOnionMessageRoute(t *testing.T) {
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+
+	for numHops := 1; numHops <= 5; numHops++ {
+		nodes, route := newRoute(t, numHops)
+		msg := &Message{
+			Type: MinMessageType + 1,
+			Data: bytes.Repeat([]byte{byte(numHops)}, 100),
+		}
+
+		packet, err := NewPacket(sessionKey, route, msg)
+		if err != nil {
+			t.Fatalf("unable to create packet: %v", err)
+		}
+
+		for i, node := range nodes {
+			// Each hop receives the packet over the wire.
+			var b bytes.Buffer
+			if err := packet.Encode(&b); err != nil {
+				t.Fatalf("unable to encode packet: %v", err)
+			}
+			if b.Len() != PacketSize {
+				t.Fatalf("packet is %v bytes, expected %v",
+					b.Len(), PacketSize)
+			}
+			packet = &Packet{}
+			if err := packet.Decode(&b); err != nil {
+				t.Fatalf("unable to decode packet: %v", err)
+			}
+
+			processed, err := ProcessPacket(node, packet)
+			if err != nil {
+				t.Fatalf("hop %v of %v unable to process "+
+					"packet: %v", i, numHops, err)
+			}
+
+			if i == numHops-1 {
+				if processed.NextPacket != nil {
+					t.Fatalf("final hop told to forward " +
+						"packet")
+				}
+				if !reflect.DeepEqual(processed.Payload.Message,
+					msg) {
+
+					t.Fatalf("final hop received %v, "+
+						"expected %v",
+						processed.Payload.Message, msg)
+				}
+				break
+			}
+
+			if processed.Payload.Message != nil {
+				t.Fatalf("hop %v received message", i)
+			}
+			if !processed.Payload.NextNode.IsEqual(route[i+1]) {
+				t.Fatalf("hop %v told to forward to wrong "+
+					"node", i)
+			}
+			packet = processed.NextPacket
+		}
+	}
+}
+
+// TestOnionMessageInvalid tests that packets which are tampered with, sent to
+// the wrong node, or too large to construct are rejected.
+func TestOnionMessageInvalid(t *testing.T) {
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+	nodes, route := newRoute(t, 3)
+	msg := &Message{Type: MinMessageType, Data: []byte("hello")}
+
+	packet, err := NewPacket(sessionKey, route, msg)
+	if err != nil {
+		t.Fatalf("unable to create packet: %v", err)
+	}
+
+	// Only the first hop is able to process the packet.
+	if _, err := ProcessPacket(nodes[1], packet); err != ErrInvalidHMAC {
+		t.Fatalf("expected ErrInvalidHMAC, instead got %v", err)
+	}
+
+	// Flipping a single bit of the routing information invalidates it.
+	tampered := *packet
+	tampered.RoutingInfo[RoutingInfoSize-1] ^= 1
+	if _, err := ProcessPacket(nodes[0], &tampered); err != ErrInvalidHMAC {
+		t.Fatalf("expected ErrInvalidHMAC, instead got %v", err)
+	}
+
+	// A message too large to fit within the packet can't be sent.
+	msg.Data = make([]byte, RoutingInfoSize)
+	if _, err := NewPacket(sessionKey, route, msg); err != ErrRouteTooLong {
+		t.Fatalf("expected ErrRouteTooLong, instead got %v", err)
+	}
+
+	// Messages must be sent under an application message type.
+	msg = &Message{Type: nextNodeType, Data: []byte("hello")}
+	if _, err := NewPacket(sessionKey, route, msg); err == nil {
+		t.Fatalf("message with reserved type was accepted")
+	}
+}
+
+// TestPayloadDecode tests the decoding of payloads, including the rejection
+// of malformed TLV streams.
+func TestPayloadDecode(t *testing.T) {
+	tests := []struct {
+		stream []byte
+		valid  bool
+	}{
+		// A message, preceded by an unknown odd record.
+		{[]byte{0x05, 0x00, 0x41, 0x01, 0xaa}, true},
+		// More than one message.
+		{[]byte{0x41, 0x01, 0xaa, 0x43, 0x00}, false},
+		// An unknown even record.
+		{[]byte{0x06, 0x00}, false},
+		// Records out of order.
+		{[]byte{0x41, 0x00, 0x05, 0x00}, false},
+		// A length exceeding the stream.
+		{[]byte{0x41, 0x05, 0xaa}, false},
+		// A non-canonical length.
+		{[]byte{0x41, 0xfd, 0x00, 0x01, 0xaa}, false},
+		// A next node record which isn't a public key.
+		{[]byte{0x04, 0x01, 0x02}, false},
+	}
+
+	for i, test := range tests {
+		err := (&Payload{}).Decode(test.stream)
+		if test.valid && err != nil {
+			t.Fatalf("test #%v: unable to decode payload: %v", i,
+				err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("test #%v: invalid payload accepted", i)
+		}
+	}
+}
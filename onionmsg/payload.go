@@ -0,0 +1,215 @@
+package onionmsg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// nextNodeType is the TLV type of the record carrying the public key
+	// of the node an onion message is to be forwarded to.
+	nextNodeType uint64 = 4
+
+	// MinMessageType is the lowest TLV type an application message may
+	// be sent under. Lower types are reserved for forwarding
+	// instructions.
+	MinMessageType uint64 = 64
+)
+
+// ErrInvalidPayload is returned when the payload of a hop is malformed, or
+// inconsistent with the hop's position within the route.
+var ErrInvalidPayload = errors.New("invalid onion message payload")
+
+// Message is an application message carried by an onion message to its final
+// recipient.
+type Message struct {
+	// Type is the TLV type of the message, which must be at least
+	// MinMessageType. It identifies the application protocol the message
+	// belongs to.
+	Type uint64
+
+	// Data is the content of the message.
+	Data []byte
+}
+
+// Payload is the set of instructions intended for a single hop within the
+// route of an onion message. Each payload is encoded as a TLV stream.
+type Payload struct {
+	// NextNode is the node the onion message is to be forwarded to. It's
+	// nil within the payload of the final hop.
+	NextNode *btcec.PublicKey
+
+	// Message is the message carried to the final hop. It's nil within
+	// the payloads of all other hops.
+	Message *Message
+}
+
+// Encode serializes the payload as a TLV stream into the passed io.Writer.
+func (p *Payload) Encode(w io.Writer) error {
+	if p.NextNode != nil {
+		err := writeRecord(w, nextNodeType,
+			p.NextNode.SerializeCompressed())
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.Message != nil {
+		if p.Message.Type < MinMessageType {
+			return fmt.Errorf("message type %v is below %v",
+				p.Message.Type, MinMessageType)
+		}
+		if err := writeRecord(w, p.Message.Type, p.Message.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode deserializes a payload from the passed TLV stream. Records must
+// appear in strictly increasing order of type. Unknown records of odd type
+// are ignored, while those of even type are rejected, as is a payload
+// carrying more than one message.
+func (p *Payload) Decode(stream []byte) error {
+	r := bytes.NewReader(stream)
+
+	var lastType uint64
+	for i := 0; r.Len() > 0; i++ {
+		recordType, err := readBigSize(r)
+		if err != nil {
+			return err
+		}
+		if i > 0 && recordType <= lastType {
+			return ErrInvalidPayload
+		}
+		lastType = recordType
+
+		length, err := readBigSize(r)
+		if err != nil {
+			return err
+		}
+		if length > uint64(r.Len()) {
+			return ErrInvalidPayload
+		}
+		value := make([]byte, length)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return err
+		}
+
+		switch {
+		case recordType == nextNodeType:
+			p.NextNode, err = btcec.ParsePubKey(value, btcec.S256())
+			if err != nil {
+				return err
+			}
+
+		case recordType >= MinMessageType:
+			if p.Message != nil {
+				return ErrInvalidPayload
+			}
+			p.Message = &Message{
+				Type: recordType,
+				Data: value,
+			}
+
+		case recordType%2 == 0:
+			return fmt.Errorf("unknown required record type %v",
+				recordType)
+		}
+	}
+
+	return nil
+}
+
+// writeRecord writes a single TLV record to the passed io.Writer.
+func writeRecord(w io.Writer, recordType uint64, value []byte) error {
+	if err := writeBigSize(w, recordType); err != nil {
+		return err
+	}
+	if err := writeBigSize(w, uint64(len(value))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(value)
+	return err
+}
+
+// bigSizeLen returns the number of bytes occupied by the BigSize encoding of
+// the passed integer.
+func bigSizeLen(n uint64) int {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// writeBigSize writes the passed integer to w using the BigSize encoding of
+// BOLT 1: a single byte for values below 0xfd, otherwise a discriminant byte
+// followed by the value as a big-endian uint16, uint32 or uint64.
+func writeBigSize(w io.Writer, n uint64) error {
+	var b [9]byte
+	switch bigSizeLen(n) {
+	case 1:
+		b[0] = byte(n)
+	case 3:
+		b[0] = 0xfd
+		binary.BigEndian.PutUint16(b[1:3], uint16(n))
+	case 5:
+		b[0] = 0xfe
+		binary.BigEndian.PutUint32(b[1:5], uint32(n))
+	default:
+		b[0] = 0xff
+		binary.BigEndian.PutUint64(b[1:9], n)
+	}
+
+	_, err := w.Write(b[:bigSizeLen(n)])
+	return err
+}
+
+// readBigSize reads a BigSize encoded integer from r, rejecting any encoding
+// which isn't minimal.
+func readBigSize(r io.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:1]); err != nil {
+		return 0, err
+	}
+
+	var n, min uint64
+	switch b[0] {
+	case 0xfd:
+		if _, err := io.ReadFull(r, b[:2]); err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.BigEndian.Uint16(b[:2])), 0xfd
+	case 0xfe:
+		if _, err := io.ReadFull(r, b[:4]); err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.BigEndian.Uint32(b[:4])), 0x10000
+	case 0xff:
+		if _, err := io.ReadFull(r, b[:8]); err != nil {
+			return 0, err
+		}
+		n, min = binary.BigEndian.Uint64(b[:8]), 0x100000000
+	default:
+		return uint64(b[0]), nil
+	}
+
+	if n < min {
+		return 0, fmt.Errorf("non-canonical bigsize encoding of %v", n)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+var (
+	// errNoPathFound is returned when a path to the target destination
+	// with sufficient capacity does not exist within the channel graph.
+	errNoPathFound = fmt.Errorf("unable to find a path to destination")
+
+	// errCltvLimitExceeded is returned when every route to the target
+	// destination would time lock the payment for longer than its CLTV
+	// limit allows.
+	errCltvLimitExceeded = fmt.Errorf("route time lock exceeds cltv limit")
+)
+
+// hop represents a single hop within a route. Each hop is a channel edge
+// leading to the next node within the route.
+type hop struct {
+	// nodeID is the lightning ID of the node at the far end of the hop.
+	nodeID wire.ShaHash
+
+	// channel is the edge traversed by this hop.
+	channel *channeldb.ChannelEdge
+
+	// amtToForward is the amount which is to be sent across this hop.
+	amtToForward btcutil.Amount
+}
+
+// route represents a path through the channel graph from a source node to a
+// target destination.
+type route struct {
+	// totalAmt is the total amount to be sent along this route.
+	totalAmt btcutil.Amount
+
+	// totalTimeLock is the absolute block height at which the HTLC sent
+	// along this route expires.
+	totalTimeLock uint32
+
+	// hops is the ordered list of hops which make up the route, starting
+	// from the hop leaving the source node.
+	hops []*hop
+}
+
+// routeRestrictions constrains the channels a route found by findRoute may
+// traverse at either end.
+type routeRestrictions struct {
+	// outgoingChans is the set of our own channels the route may depart
+	// from. If empty, any of our channels may be used.
+	outgoingChans map[wire.OutPoint]struct{}
+
+	// lastHop is the lightning ID of the node the route must pass through
+	// immediately before reaching the destination. If nil, the route may
+	// arrive from any node.
+	lastHop *wire.ShaHash
+}
+
+// permitsEdge returns whether the restrictions allow a route to traverse the
+// passed edge from the node from to the node to, given the source and target
+// of the route. A nil set of restrictions permits every edge.
+func (r *routeRestrictions) permitsEdge(edge *channeldb.ChannelEdge, from,
+	to, source, target wire.ShaHash) bool {
+
+	if r == nil {
+		return true
+	}
+
+	if from == source && len(r.outgoingChans) != 0 {
+		if _, ok := r.outgoingChans[edge.ChannelPoint]; !ok {
+			return false
+		}
+	}
+	if to == target && r.lastHop != nil && from != *r.lastHop {
+		return false
+	}
+
+	return true
+}
+
+// nodeWithDist is an entry within the priority queue of nodes reached by
+// findRoute, along with the distance of the best route to the node found so
+// far.
+type nodeWithDist struct {
+	nodeID wire.ShaHash
+
+	// cost is the negative logarithm of the probability that the route
+	// from the source to the node succeeds, such that the costs of
+	// consecutive hops are summed.
+	cost float64
+
+	// numHops is the number of hops within the route.
+	numHops int
+
+	// timeLockDelta is the sum of the CLTV deltas charged by each
+	// intermediate node along the route.
+	timeLockDelta uint32
+}
+
+// distanceHeap is a min-heap of nodes, sorted first by the cost of the route
+// to each node, then by its number of hops. It implements heap.Interface.
+type distanceHeap []*nodeWithDist
+
+// Len returns the number of nodes in the heap. It is part of the
+// heap.Interface implementation.
+func (d distanceHeap) Len() int { return len(d) }
+
+// Less returns whether the route to the node at index i is preferred over the
+// route to the node at index j. It is part of the heap.Interface
+// implementation.
+func (d distanceHeap) Less(i, j int) bool {
+	return d[i].preferredOver(d[j])
+}
+
+// Swap swaps the nodes at the passed indices. It is part of the
+// heap.Interface implementation.
+func (d distanceHeap) Swap(i, j int) { d[i], d[j] = d[j], d[i] }
+
+// Push pushes the passed node onto the heap. It is part of the
+// heap.Interface implementation.
+func (d *distanceHeap) Push(x interface{}) {
+	*d = append(*d, x.(*nodeWithDist))
+}
+
+// Pop removes the last node from the heap and returns it. It is part of the
+// heap.Interface implementation.
+func (d *distanceHeap) Pop() interface{} {
+	n := len(*d)
+	x := (*d)[n-1]
+	(*d)[n-1] = nil
+	*d = (*d)[:n-1]
+	return x
+}
+
+// preferredOver returns true if the route to n is more likely to succeed than
+// the route to other, or equally likely yet shorter.
+func (n *nodeWithDist) preferredOver(other *nodeWithDist) bool {
+	if n.cost != other.cost {
+		return n.cost < other.cost
+	}
+
+	return n.numHops < other.numHops
+}
+
+// findRoute attempts to find a path from the source node to the target node
+// within the channel graph, such that each channel along the path is able to
+// carry the payment amount. The probability that each channel carries the
+// amount is estimated by the passed liquidity model, and the route returned
+// is the one most likely to succeed as a whole, with ties broken in favor of
+// fewer hops. If the liquidity model is nil, then the liquidity of each
+// channel is assumed to follow the model's bimodal distribution over its
+// capacity. Any channels present within the set of ignored edges are skipped,
+// as are any edges barred by the passed restrictions, which may be nil. The
+// time lock of the route is set such that the HTLC arrives at the destination
+// with at least finalCltvDelta blocks remaining until expiry, and each
+// intermediate node receives the CLTV delta it charges for forwarding the
+// HTLC. Edges which would time lock the payment for more than cltvLimit blocks
+// are pruned. All edges are read from the graph's in-memory cache, so no
+// database transactions are required.
+func findRoute(graph *channeldb.ChannelGraph, source, target wire.ShaHash,
+	amt btcutil.Amount, ignoredEdges map[wire.OutPoint]struct{},
+	restrictions *routeRestrictions, liquidity *liquidityModel,
+	currentHeight, finalCltvDelta, cltvLimit uint32) (*route, error) {
+
+	if finalCltvDelta > cltvLimit {
+		return nil, errCltvLimitExceeded
+	}
+
+	// Each intermediate node adds its own CLTV delta to the time lock of
+	// the route, so the search is bounded by what remains of the limit
+	// once the final CLTV delta is accounted for.
+	hops, _, err := findPath(graph, source, target, amt, ignoredEdges,
+		restrictions, liquidity, cltvLimit-finalCltvDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &route{
+		totalAmt: applyRouteFees(hops, amt),
+		totalTimeLock: currentHeight + finalCltvDelta +
+			routeTimeLockDelta(hops),
+		hops: hops,
+	}, nil
+}
+
+// edgePolicy returns the routing policy advertised by the passed node for
+// HTLC's forwarded out over the channel edge, or nil if the node hasn't yet
+// advertised one.
+func edgePolicy(edge *channeldb.ChannelEdge,
+	nodeID wire.ShaHash) *channeldb.ChannelEdgePolicy {
+
+	if edge.Node1 == nodeID {
+		return edge.Node1Policy
+	}
+
+	return edge.Node2Policy
+}
+
+// forwardingFee returns the fee charged under the passed routing policy for
+// forwarding amt. A node which hasn't yet advertised a policy is assumed to
+// forward HTLC's free of charge.
+func forwardingFee(policy *channeldb.ChannelEdgePolicy,
+	amt btcutil.Amount) btcutil.Amount {
+
+	if policy == nil {
+		return 0
+	}
+
+	return policy.FeeBase + amt*btcutil.Amount(policy.FeeRate)/1000000
+}
+
+// applyRouteFees sets the amount sent across each hop of the route such that
+// the destination receives amt, and each intermediate node receives the fee
+// it charges for forwarding the HTLC over its outgoing channel. As each fee
+// depends on the amount forwarded, the fees are accumulated backwards from
+// the destination. No fee is paid for the first hop, as it departs from our
+// own node. The total amount to be sent along the route is returned.
+func applyRouteFees(hops []*hop, amt btcutil.Amount) btcutil.Amount {
+	for i := len(hops) - 1; i >= 0; i-- {
+		hops[i].amtToForward = amt
+		if i == 0 {
+			break
+		}
+
+		// The node at the near end of this hop is the one reached by
+		// the previous hop.
+		policy := edgePolicy(hops[i].channel, hops[i-1].nodeID)
+		amt += forwardingFee(policy, amt)
+	}
+
+	return amt
+}
+
+// routeTimeLockDelta returns the sum of the CLTV deltas charged by each
+// intermediate node along the route for forwarding the HTLC over its outgoing
+// channel. No delta is added for the first hop, as it departs from our own
+// node. A node which hasn't yet advertised a policy is assumed to charge no
+// delta.
+func routeTimeLockDelta(hops []*hop) uint32 {
+	var delta uint32
+	for i := 1; i < len(hops); i++ {
+		// The node at the near end of this hop is the one reached by
+		// the previous hop.
+		policy := edgePolicy(hops[i].channel, hops[i-1].nodeID)
+		if policy != nil {
+			delta += policy.TimeLockDelta
+		}
+	}
+
+	return delta
+}
+
+// findPath returns the hops of the best path from the source node to a
+// distinct target node, along with the distance of the path, as described by
+// findRoute. Edges which would raise the sum of the CLTV deltas of the
+// intermediate nodes along the path above maxTimeLockDelta are pruned. If no
+// path remains due to such edges, then errCltvLimitExceeded is returned.
+func findPath(graph *channeldb.ChannelGraph, source, target wire.ShaHash,
+	amt btcutil.Amount, ignoredEdges map[wire.OutPoint]struct{},
+	restrictions *routeRestrictions, liquidity *liquidityModel,
+	maxTimeLockDelta uint32) ([]*hop, *nodeWithDist, error) {
+
+	// prevHop maps each node reached to the edge used to reach it along
+	// the best route found so far, while dist holds the distance of that
+	// route.
+	prevHop := make(map[wire.ShaHash]*channeldb.ChannelEdge)
+	dist := make(map[wire.ShaHash]*nodeWithDist)
+	visited := make(map[wire.ShaHash]struct{})
+
+	// exceededCltvLimit records whether any edge was pruned as it would
+	// time lock the payment for too long.
+	var exceededCltvLimit bool
+
+	dist[source] = &nodeWithDist{nodeID: source}
+	queue := &distanceHeap{dist[source]}
+	for queue.Len() != 0 {
+		current := heap.Pop(queue).(*nodeWithDist)
+		nodeID := current.nodeID
+		if _, ok := visited[nodeID]; ok {
+			continue
+		}
+		visited[nodeID] = struct{}{}
+
+		// As the nodes are visited in order of distance, the first
+		// route found to the target is the best.
+		if nodeID == target {
+			break
+		}
+
+		err := graph.ForEachNodeChannel(&nodeID, func(edge *channeldb.ChannelEdge) error {
+			if _, ok := ignoredEdges[edge.ChannelPoint]; ok {
+				return nil
+			}
+
+			neighbor := edge.Node1
+			if neighbor == nodeID {
+				neighbor = edge.Node2
+			}
+			if _, ok := visited[neighbor]; ok {
+				return nil
+			}
+			if !restrictions.permitsEdge(edge, nodeID, neighbor,
+				source, target) {
+
+				return nil
+			}
+
+			prob := liquidity.successProbability(edge, nodeID, amt)
+			if prob == 0 {
+				return nil
+			}
+
+			// Unless the edge departs from our own node, the node
+			// forwarding over it adds its CLTV delta.
+			timeLockDelta := current.timeLockDelta
+			policy := edgePolicy(edge, nodeID)
+			if nodeID != source && policy != nil {
+				timeLockDelta += policy.TimeLockDelta
+			}
+			if timeLockDelta > maxTimeLockDelta {
+				exceededCltvLimit = true
+				return nil
+			}
+
+			candidate := &nodeWithDist{
+				nodeID:        neighbor,
+				cost:          current.cost - math.Log(prob),
+				numHops:       current.numHops + 1,
+				timeLockDelta: timeLockDelta,
+			}
+			best, ok := dist[neighbor]
+			if ok && !candidate.preferredOver(best) {
+				return nil
+			}
+
+			dist[neighbor] = candidate
+			prevHop[neighbor] = edge
+			heap.Push(queue, candidate)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if prevHop[target] == nil {
+		if exceededCltvLimit {
+			return nil, nil, errCltvLimitExceeded
+		}
+		return nil, nil, errNoPathFound
+	}
+
+	// Walk backwards from the target node to the source in order to
+	// construct the final path.
+	var hops []*hop
+	for nodeID := target; nodeID != source; {
+		edge := prevHop[nodeID]
+		hops = append([]*hop{{
+			nodeID:       nodeID,
+			channel:      edge,
+			amtToForward: amt,
+		}}, hops...)
+
+		if edge.Node1 == nodeID {
+			nodeID = edge.Node2
+		} else {
+			nodeID = edge.Node1
+		}
+	}
+
+	return hops, dist[target], nil
+}
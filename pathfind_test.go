@@ -0,0 +1,278 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// makeTestGraph creates an empty channel graph within a temporary directory.
+// The returned function tears down the graph once the test has finished.
+func makeTestGraph() (*channeldb.ChannelGraph, func(), error) {
+	tempDirName, err := ioutil.TempDir("", "pathfind")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graph, err := channeldb.OpenGraph(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		os.RemoveAll(tempDirName)
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		graph.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	return graph, cleanUp, nil
+}
+
+// routeNodes returns the lightning ID of the node reached by each hop of the
+// passed route.
+func routeNodes(r *route) []wire.ShaHash {
+	nodes := make([]wire.ShaHash, len(r.hops))
+	for i, hop := range r.hops {
+		nodes[i] = hop.nodeID
+	}
+
+	return nodes
+}
+
+// TestFindRoute tests that findRoute returns the route most likely to carry a
+// payment, breaking ties in favor of fewer hops, while honoring the ignored
+// edges and route restrictions.
+func TestFindRoute(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		bob   = wire.ShaHash{3}
+		dest  = wire.ShaHash{4}
+	)
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+	defer cleanUp()
+
+	// The direct channel to the destination is small, so a payment of
+	// half its capacity is about as likely to fail as succeed over it,
+	// while the large channels via alice and bob are nearly certain to
+	// carry the payment.
+	chanPoints, err := addTestChannels(graph, []testChannel{
+		{node1: self, node2: dest, capacity: 2000},
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: dest, capacity: 100000},
+		{node1: self, node2: bob, capacity: 100000},
+		{node1: bob, node2: dest, capacity: 50000},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+	var (
+		directChan = chanPoints[0]
+		selfAlice  = chanPoints[1]
+		aliceDest  = chanPoints[2]
+		selfBob    = chanPoints[3]
+		bobDest    = chanPoints[4]
+	)
+	const currentHeight = 100
+
+	// Once every channel is known to carry the payment, each route is
+	// certain to succeed, so the shortest is preferred.
+	certain := newLiquidityModel()
+	certain.reportSuccess(directChan, self, 1000)
+	certain.reportSuccess(selfAlice, self, 1000)
+	certain.reportSuccess(aliceDest, alice, 1000)
+	certain.reportSuccess(selfBob, self, 1000)
+	certain.reportSuccess(bobDest, bob, 1000)
+
+	tests := []struct {
+		name         string
+		amt          btcutil.Amount
+		ignored      map[wire.OutPoint]struct{}
+		restrictions *routeRestrictions
+		liquidity    *liquidityModel
+		expected     []wire.ShaHash
+		err          error
+	}{
+		{
+			name:     "most likely route",
+			amt:      1000,
+			expected: []wire.ShaHash{alice, dest},
+		},
+		{
+			name:      "ties broken by fewer hops",
+			amt:       1000,
+			liquidity: certain,
+			expected:  []wire.ShaHash{dest},
+		},
+		{
+			name: "ignored edge",
+			amt:  1000,
+			ignored: map[wire.OutPoint]struct{}{
+				aliceDest: {},
+			},
+			expected: []wire.ShaHash{bob, dest},
+		},
+		{
+			name: "restricted outgoing channel",
+			amt:  1000,
+			restrictions: &routeRestrictions{
+				outgoingChans: map[wire.OutPoint]struct{}{
+					directChan: {},
+				},
+			},
+			expected: []wire.ShaHash{dest},
+		},
+		{
+			name: "restricted last hop",
+			amt:  1000,
+			restrictions: &routeRestrictions{
+				lastHop: &bob,
+			},
+			expected: []wire.ShaHash{bob, dest},
+		},
+		{
+			name: "amount exceeds capacity",
+			amt:  100001,
+			err:  errNoPathFound,
+		},
+		{
+			name: "remaining route lacks capacity",
+			amt:  60000,
+			ignored: map[wire.OutPoint]struct{}{
+				aliceDest: {},
+			},
+			err: errNoPathFound,
+		},
+	}
+
+	for _, test := range tests {
+		r, err := findRoute(graph, self, dest, test.amt, test.ignored,
+			test.restrictions, test.liquidity, currentHeight, 9, 1000)
+		if err != test.err {
+			t.Fatalf("%s: expected error %v, got %v", test.name,
+				test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		nodes := routeNodes(r)
+		if len(nodes) != len(test.expected) {
+			t.Fatalf("%s: expected route %x, got %x", test.name,
+				test.expected, nodes)
+		}
+		for i := range nodes {
+			if nodes[i] != test.expected[i] {
+				t.Fatalf("%s: expected route %x, got %x",
+					test.name, test.expected, nodes)
+			}
+		}
+		if r.totalTimeLock != currentHeight+9 {
+			t.Fatalf("%s: expected time lock %v, got %v",
+				test.name, currentHeight+9, r.totalTimeLock)
+		}
+	}
+
+	// A final CLTV delta beyond the limit leaves no route.
+	_, err = findRoute(graph, self, dest, 1000, nil, nil, nil,
+		currentHeight, 9, 8)
+	if err != errCltvLimitExceeded {
+		t.Fatalf("expected errCltvLimitExceeded, got %v", err)
+	}
+}
+
+// TestFindRouteCltvLimit tests that the time lock of a route includes the CLTV
+// delta of each intermediate node, and that edges which would exceed the CLTV
+// limit are pruned from the search.
+func TestFindRouteCltvLimit(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		bob   = wire.ShaHash{3}
+		dest  = wire.ShaHash{4}
+	)
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+	defer cleanUp()
+
+	// The route via alice is the most likely to succeed, yet alice
+	// charges a larger CLTV delta than bob.
+	_, err = addTestChannels(graph, []testChannel{
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: dest, capacity: 100000,
+			timeLockDelta: 40},
+		{node1: self, node2: bob, capacity: 100000},
+		{node1: bob, node2: dest, capacity: 2000, timeLockDelta: 10},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+	const currentHeight = 100
+
+	tests := []struct {
+		name     string
+		limit    uint32
+		expected []wire.ShaHash
+		timeLock uint32
+		err      error
+	}{
+		{
+			name:     "within limit",
+			limit:    1000,
+			expected: []wire.ShaHash{alice, dest},
+			timeLock: currentHeight + 9 + 40,
+		},
+		{
+			name:     "likely route exceeds limit",
+			limit:    30,
+			expected: []wire.ShaHash{bob, dest},
+			timeLock: currentHeight + 9 + 10,
+		},
+		{
+			name:  "every route exceeds limit",
+			limit: 15,
+			err:   errCltvLimitExceeded,
+		},
+		{
+			name:  "final delta exceeds limit",
+			limit: 8,
+			err:   errCltvLimitExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		r, err := findRoute(graph, self, dest, 1000, nil, nil, nil,
+			currentHeight, 9, test.limit)
+		if err != test.err {
+			t.Fatalf("%s: expected error %v, got %v", test.name,
+				test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		nodes := routeNodes(r)
+		if len(nodes) != len(test.expected) ||
+			nodes[0] != test.expected[0] {
+
+			t.Fatalf("%s: expected route %x, got %x", test.name,
+				test.expected, nodes)
+		}
+		if r.totalTimeLock != test.timeLock {
+			t.Fatalf("%s: expected time lock %v, got %v",
+				test.name, test.timeLock, r.totalTimeLock)
+		}
+	}
+}
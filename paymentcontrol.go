@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// paymentSubscription is a client subscription to the state of a particular
+// payment. Each time the state of the payment changes, the latest version of
+// the payment is sent over the updates channel.
+type paymentSubscription struct {
+	id          uint64
+	paymentHash [32]byte
+
+	updates chan *channeldb.OutgoingPayment
+
+	cancel func()
+}
+
+// htlcSender is the interface the payment controller uses to dispatch the
+// HTLC of each payment attempt. It's implemented by the htlcSwitch.
+type htlcSender interface {
+	// SendHTLC sends the passed HTLC packet, blocking until the HTLC is
+	// either settled or rejected.
+	SendHTLC(htlcPkt *htlcPacket) error
+}
+
+// paymentController drives each outgoing payment through its lifecycle. Every
+// payment is persisted in the InFlight state before its HTLC is handed to the
+// switch, and is later transitioned into either the Succeeded or Failed
+// state. As the state of each payment is persisted, payments which were in
+// flight when the daemon shut down can be reconciled once it restarts.
+type paymentController struct {
+	db    *channeldb.DB
+	graph *channeldb.ChannelGraph
+	bio   lnwallet.BlockChainIO
+
+	// selfID is our own lightning ID, used as the source of all routes.
+	selfID wire.ShaHash
+
+	htlcSwitch htlcSender
+
+	// maxCltvExpiry is the maximum number of blocks the HTLC of a payment
+	// may be time locked for.
+	maxCltvExpiry uint32
+
+	// liquidity models the liquidity of the channels within the graph,
+	// learned from the outcomes of our payment attempts.
+	liquidity *liquidityModel
+
+	// subscribers is the set of all clients currently tracking the state
+	// of a payment, indexed by payment hash.
+	subscribers   map[[32]byte]map[uint64]*paymentSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newPaymentController creates a new paymentController backed by the passed
+// database which dispatches payments using the target htlcSender. No payment
+// is routed such that its HTLC is time locked for more than maxCltvExpiry
+// blocks.
+func newPaymentController(db *channeldb.DB, graph *channeldb.ChannelGraph,
+	bio lnwallet.BlockChainIO, selfID wire.ShaHash, s htlcSender,
+	maxCltvExpiry uint32) *paymentController {
+
+	return &paymentController{
+		db:            db,
+		graph:         graph,
+		bio:           bio,
+		selfID:        selfID,
+		htlcSwitch:    s,
+		maxCltvExpiry: maxCltvExpiry,
+		liquidity:     newLiquidityModel(),
+		subscribers:   make(map[[32]byte]map[uint64]*paymentSubscription),
+	}
+}
+
+// cltvLimit returns the maximum number of blocks the HTLC of a payment may be
+// time locked for, given the limit requested for the payment. A requested
+// limit of zero, or one above the configured maximum, yields the configured
+// maximum.
+func (p *paymentController) cltvLimit(requested uint32) uint32 {
+	if requested == 0 || requested > p.maxCltvExpiry {
+		return p.maxCltvExpiry
+	}
+
+	return requested
+}
+
+// defaultPaymentTimeout is the default duration for which the payment
+// controller will continue to retry a payment along alternate routes before
+// giving up.
+const defaultPaymentTimeout = 60 * time.Second
+
+var (
+	// errPaymentTimeout is returned when a payment couldn't be completed
+	// within its time budget.
+	errPaymentTimeout = fmt.Errorf("payment attempt timed out")
+
+	// errFeeLimitExceeded is returned when the only route found for a
+	// payment would require paying more in fees than the payment's fee
+	// limit allows.
+	errFeeLimitExceeded = fmt.Errorf("route fee exceeds payment fee limit")
+
+	// errPaymentAddrRequired is returned when the destination of a
+	// payment requires a payment secret, yet none was provided.
+	errPaymentAddrRequired = fmt.Errorf("destination requires a payment " +
+		"address")
+)
+
+// paymentFailure describes a failed attempt to route an HTLC, attributing the
+// failure to the hop which generated it.
+type paymentFailure struct {
+	// sourceIndex is the index of the hop which generated the failure.
+	// An index of 0 denotes a failure generated by our own node, while an
+	// index of i denotes the i-th hop within the route.
+	sourceIndex uint32
+
+	// failure is the decoded failure message.
+	failure *lnwire.FailureMessage
+}
+
+// Error returns a human readable description of the payment failure.
+//
+// NOTE: Part of the error interface.
+func (p *paymentFailure) Error() string {
+	return fmt.Sprintf("payment failed at hop %v: %v", p.sourceIndex,
+		p.failure)
+}
+
+// lightningPayment describes a payment to be sent by the payment controller.
+type lightningPayment struct {
+	// dest is the lightning ID of the payment's final destination.
+	dest wire.ShaHash
+
+	// amt is the amount to be delivered to the destination.
+	amt btcutil.Amount
+
+	// paymentHash is the hash the HTLC's of the payment are locked to.
+	paymentHash [32]byte
+
+	// feeLimit is the maximum total fee the sender is willing to pay in
+	// order to route the payment. A value of zero denotes no limit.
+	feeLimit btcutil.Amount
+
+	// timeout is the duration after which no further attempts are made
+	// for the payment. If zero, the defaultPaymentTimeout is used.
+	timeout time.Duration
+
+	// finalCltvDelta is the minimum number of blocks the receiver
+	// requires to remain until the HTLC expires once it arrives. If zero,
+	// the defaultFinalCltvDelta is used.
+	finalCltvDelta uint32
+
+	// paymentAddr is the payment secret contained within the invoice
+	// being paid, which is presented to the final hop.
+	paymentAddr [32]byte
+
+	// customRecords is a set of records presented to the final hop within
+	// its payload, keyed by their type.
+	customRecords map[uint64][]byte
+
+	// cltvLimit is the maximum number of blocks the HTLC of the payment
+	// may be time locked for. If zero, the controller's maximum is used.
+	cltvLimit uint32
+
+	// restrictions constrains the channels the payment may depart from
+	// and arrive over. If nil, any route may be used.
+	restrictions *routeRestrictions
+}
+
+// sendPayment persists a new outgoing payment, then dispatches an HTLC for the
+// payment to the switch along a route found within the channel graph. If an
+// attempt fails with a temporary failure, then the offending channel is pruned
+// for the remainder of the payment session and the payment is retried along
+// an alternate route, until either the payment succeeds, no further routes
+// remain, or the payment's time budget is exhausted. This method blocks until
+// the payment has either been settled by the destination or failed.
+func (p *paymentController) sendPayment(payment *lightningPayment) error {
+	rHash := payment.paymentHash
+
+	// Payments sent without a payment hash all share the debug hash, so
+	// any number of them may be in flight at once. As they can't be told
+	// apart within the database, they're dispatched without being
+	// persisted.
+	// TODO(roasbeef): remove along with the debug payment hash
+	if !isDebugPayment(rHash) {
+		dbPayment := &channeldb.OutgoingPayment{
+			PaymentHash:  rHash,
+			Dest:         payment.dest,
+			Amt:          payment.amt,
+			CreationDate: time.Now(),
+		}
+		if err := p.db.InitPayment(dbPayment); err != nil {
+			return err
+		}
+		p.notifySubscribers(rHash)
+	}
+
+	// If the destination requires a payment secret, then we must have
+	// obtained one from its invoice in order to pay it.
+	destNode, err := p.graph.FetchLightningNode(&payment.dest)
+	switch {
+	case err == channeldb.ErrGraphNodeNotFound:
+	case err != nil:
+		p.failPayment(rHash)
+		return err
+	case destNode.Features != nil &&
+		destNode.Features.IsSet(lnwire.PaymentAddrRequired) &&
+		payment.paymentAddr == zeroPaymentAddr:
+
+		p.failPayment(rHash)
+		return errPaymentAddrRequired
+	}
+
+	timeout := payment.timeout
+	if timeout == 0 {
+		timeout = defaultPaymentTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	finalCltvDelta := payment.finalCltvDelta
+	if finalCltvDelta == 0 {
+		finalCltvDelta = defaultFinalCltvDelta
+	}
+	cltvLimit := p.cltvLimit(payment.cltvLimit)
+
+	// ignoredEdges is the set of channels which have reported a temporary
+	// failure during this payment session, and are therefore excluded
+	// from all further route searches.
+	ignoredEdges := make(map[wire.OutPoint]struct{})
+
+	for {
+		if time.Now().After(deadline) {
+			p.failPayment(rHash)
+			return errPaymentTimeout
+		}
+
+		currentHeight, err := p.bio.GetCurrentHeight()
+		if err != nil {
+			p.failPayment(rHash)
+			return err
+		}
+
+		path, err := findRoute(p.graph, p.selfID, payment.dest,
+			payment.amt, ignoredEdges, payment.restrictions,
+			p.liquidity, uint32(currentHeight), finalCltvDelta,
+			cltvLimit)
+		if err != nil {
+			p.failPayment(rHash)
+			return err
+		}
+
+		fee := path.totalAmt - payment.amt
+		if payment.feeLimit != 0 && fee > payment.feeLimit {
+			p.failPayment(rHash)
+			return errFeeLimitExceeded
+		}
+
+		sendErr := p.sendAttempt(rHash, path, payment.paymentAddr,
+			payment.customRecords)
+		if sendErr == nil {
+			p.recordOutcome(path, nil)
+			return nil
+		}
+
+		// Only temporary failures which can be attributed to a
+		// particular channel are eligible to be retried along an
+		// alternate route.
+		failure, ok := sendErr.(*paymentFailure)
+		if ok {
+			p.recordOutcome(path, failure)
+		}
+		if !ok || failure.failure.Code&lnwire.FlagPerm != 0 {
+			p.failPayment(rHash)
+			return sendErr
+		}
+		chanPoint, ok := failedChannel(path, failure.sourceIndex)
+		if !ok {
+			p.failPayment(rHash)
+			return sendErr
+		}
+
+		srvrLog.Infof("Attempt for payment(%x) failed: %v, pruning "+
+			"ChannelPoint(%v) and retrying", rHash[:], failure,
+			chanPoint)
+
+		ignoredEdges[chanPoint] = struct{}{}
+	}
+}
+
+// recordOutcome updates the liquidity model with the outcome of an attempt
+// along the passed route. Each hop prior to the one which generated the
+// failure successfully forwarded the HTLC, as did every hop if the failure is
+// nil. If the failing hop reported a temporary channel failure, then it lacked
+// the liquidity to forward the HTLC.
+func (p *paymentController) recordOutcome(path *route, failure *paymentFailure) {
+	numSucceeded := len(path.hops)
+	if failure != nil && int(failure.sourceIndex) < len(path.hops) {
+		numSucceeded = int(failure.sourceIndex)
+	}
+
+	from := p.selfID
+	for _, hop := range path.hops[:numSucceeded] {
+		p.liquidity.reportSuccess(hop.channel.ChannelPoint, from,
+			hop.amtToForward)
+		from = hop.nodeID
+	}
+
+	if failure == nil || numSucceeded == len(path.hops) ||
+		failure.failure.Code != lnwire.CodeTemporaryChannelFailure {
+
+		return
+	}
+
+	failedHop := path.hops[numSucceeded]
+	p.liquidity.reportFailure(failedHop.channel.ChannelPoint, from,
+		failedHop.amtToForward)
+}
+
+// sendAttempt records a new attempt for the target payment along the passed
+// route, then dispatches the HTLC for the attempt to the switch. If the
+// attempt fails, then the failure is recorded within the database. Failures
+// which can be attributed to a particular hop are returned as a
+// paymentFailure.
+func (p *paymentController) sendAttempt(rHash [32]byte, path *route,
+	paymentAddr [32]byte, customRecords map[uint64][]byte) error {
+
+	attempt := &channeldb.PaymentAttempt{
+		Hops: make([]channeldb.PaymentHop, len(path.hops)),
+	}
+	for i, hop := range path.hops {
+		attempt.Hops[i] = channeldb.PaymentHop{
+			NodeID:       hop.nodeID,
+			ChannelPoint: hop.channel.ChannelPoint,
+			AmtToForward: hop.amtToForward,
+		}
+	}
+	if !isDebugPayment(rHash) {
+		if err := p.db.RegisterAttempt(rHash, attempt); err != nil {
+			return err
+		}
+		p.notifySubscribers(rHash)
+	}
+
+	// Each hop encrypts any failure it sends back to us with a secret
+	// derived from a failure secret fresh to this attempt, allowing us to
+	// attribute the failure to the hop.
+	var failureSecret [32]byte
+	if _, err := rand.Read(failureSecret[:]); err != nil {
+		return err
+	}
+	sharedSecrets := make([][32]byte, len(path.hops))
+	for i, hop := range path.hops {
+		sharedSecrets[i] = hopFailureSecret(failureSecret, hop.nodeID)
+	}
+
+	// The payment secret is handed to the final hop within its payload,
+	// allowing it to verify that we've obtained the invoice itself, along
+	// with the failure secret and any custom records attached to the
+	// payment.
+	// TODO(roasbeef): wrap within a sphinx packet for multi-hop routes.
+	var payload bytes.Buffer
+	finalPayload := &lnwire.HopPayload{
+		PaymentSecret: paymentAddr,
+		FailureSecret: failureSecret,
+		CustomRecords: customRecords,
+	}
+	if err := finalPayload.Encode(&payload); err != nil {
+		return err
+	}
+
+	htlcAdd := &lnwire.HTLCAddRequest{
+		Expiry:           path.totalTimeLock,
+		Amount:           lnwire.CreditsAmount(path.totalAmt),
+		RedemptionHashes: [][32]byte{rHash},
+		OnionBlob:        payload.Bytes(),
+	}
+	htlcPkt := &htlcPacket{
+		dest:      path.hops[0].nodeID,
+		chanPoint: &path.hops[0].channel.ChannelPoint,
+		msg:       htlcAdd,
+	}
+
+	// Any error returned by the switch is either encountered before the
+	// HTLC is added to a channel, or after the HTLC has been failed back
+	// to us, therefore it's safe to mark the attempt as failed.
+	sendErr := p.htlcSwitch.SendHTLC(htlcPkt)
+	if sendErr == nil {
+		return nil
+	}
+
+	var (
+		sourceIndex uint32
+		failureCode uint16
+		reason      = sendErr.Error()
+	)
+
+	// If the failure can be attributed to a particular hop, then the
+	// structured failure is recorded and returned to the caller in place
+	// of the raw error.
+	failure, ok := decodeSendFailure(sendErr, sharedSecrets)
+	if ok {
+		sourceIndex = failure.sourceIndex
+		failureCode = uint16(failure.failure.Code)
+		reason = failure.failure.Error()
+		sendErr = failure
+	}
+
+	if isDebugPayment(rHash) {
+		return sendErr
+	}
+
+	err := p.db.FailAttempt(rHash, attempt.AttemptID, sourceIndex,
+		failureCode, reason)
+	if err != nil {
+		srvrLog.Errorf("unable to fail attempt for payment(%x): %v",
+			rHash[:], err)
+	}
+	p.notifySubscribers(rHash)
+
+	return sendErr
+}
+
+// failedChannel returns the channel which caused a failure reported by the
+// hop at the given index within the route. A failure reported by a hop
+// concerns its outgoing channel, so a failure from our own node (index 0)
+// maps to the first channel of the route. False is returned if the failure
+// was reported by the final hop, as there's no outgoing channel to prune.
+func failedChannel(path *route, sourceIndex uint32) (wire.OutPoint, bool) {
+	if int(sourceIndex) >= len(path.hops) {
+		return wire.OutPoint{}, false
+	}
+
+	return path.hops[sourceIndex].channel.ChannelPoint, true
+}
+
+// decodeSendFailure attempts to attribute an error returned by the switch to
+// a particular hop within the route of the payment. Failures generated by our
+// own node are attributed to index 0, while encrypted failures returned by a
+// remote hop are decrypted using the shared secrets of the hops within the
+// route. If the error isn't a recognized failure, then false is returned.
+func decodeSendFailure(sendErr error,
+	sharedSecrets [][32]byte) (*paymentFailure, bool) {
+
+	switch e := sendErr.(type) {
+	case *lnwire.FailureMessage:
+		return &paymentFailure{
+			sourceIndex: 0,
+			failure:     e,
+		}, true
+
+	case encryptedFailure:
+		sourceIndex, failure, err := decryptFailure(sharedSecrets, e)
+		if err != nil {
+			srvrLog.Errorf("unable to decrypt payment failure: %v",
+				err)
+			return nil, false
+		}
+
+		return &paymentFailure{
+			sourceIndex: sourceIndex,
+			failure:     failure,
+		}, true
+
+	default:
+		return nil, false
+	}
+}
+
+// isDebugPayment returns true if the passed payment hash is the debug hash,
+// used by payments sent without a payment hash. Such payments aren't
+// persisted within the database.
+func isDebugPayment(rHash [32]byte) bool {
+	return rHash == [32]byte(debugHash)
+}
+
+// failPayment marks the in flight payment for the passed payment hash as
+// failed, notifying all subscribers of the final state.
+func (p *paymentController) failPayment(rHash [32]byte) {
+	if isDebugPayment(rHash) {
+		return
+	}
+
+	if err := p.db.FailPayment(rHash); err != nil {
+		srvrLog.Errorf("unable to fail payment(%x): %v", rHash[:], err)
+		return
+	}
+
+	p.notifySubscribers(rHash)
+}
+
+// settlePayment marks the in flight payment for the passed preimage as
+// succeeded. This method is called once a settle for one of our outgoing
+// HTLC's has been received, regardless of whether the payment was initiated
+// before or after the last restart.
+func (p *paymentController) settlePayment(preimage [32]byte) {
+	err := p.db.SucceedPayment(preimage)
+	switch {
+	case err == channeldb.ErrPaymentNotFound:
+		return
+	case err == channeldb.ErrPaymentNotInFlight:
+		return
+	case err != nil:
+		srvrLog.Errorf("unable to mark payment as succeeded: %v", err)
+		return
+	}
+
+	p.notifySubscribers(fastsha256.Sum256(preimage[:]))
+}
+
+// subscribePayment creates a new subscription to the state of the payment
+// identified by the passed payment hash. The current state of the payment is
+// immediately delivered to the subscriber, followed by each subsequent state
+// change.
+func (p *paymentController) subscribePayment(rHash [32]byte) (*paymentSubscription, error) {
+	p.subscriberMtx.Lock()
+	defer p.subscriberMtx.Unlock()
+
+	// The payment is fetched only once the subscriber is registered, and
+	// while the subscriber mutex is held. Any state change persisted
+	// after the fetch is therefore also delivered by notifySubscribers,
+	// so no update can fall in between.
+	clientID := p.nextClientID
+	p.nextClientID++
+
+	sub := &paymentSubscription{
+		id:          clientID,
+		paymentHash: rHash,
+		updates:     make(chan *channeldb.OutgoingPayment, 20),
+	}
+	sub.cancel = func() {
+		p.subscriberMtx.Lock()
+		p.removeSubscriber(rHash, clientID)
+		p.subscriberMtx.Unlock()
+	}
+
+	if _, ok := p.subscribers[rHash]; !ok {
+		p.subscribers[rHash] = make(map[uint64]*paymentSubscription)
+	}
+	p.subscribers[rHash][clientID] = sub
+
+	payment, err := p.db.FetchPayment(rHash)
+	if err != nil {
+		p.removeSubscriber(rHash, clientID)
+		return nil, err
+	}
+
+	sub.updates <- payment
+
+	return sub, nil
+}
+
+// removeSubscriber removes the target client's subscription to the payment
+// with the passed payment hash.
+//
+// NOTE: The subscriber mutex MUST be held when calling this method.
+func (p *paymentController) removeSubscriber(rHash [32]byte, clientID uint64) {
+	delete(p.subscribers[rHash], clientID)
+	if len(p.subscribers[rHash]) == 0 {
+		delete(p.subscribers, rHash)
+	}
+}
+
+// notifySubscribers sends the latest state of the target payment to all
+// clients currently subscribed to it.
+func (p *paymentController) notifySubscribers(rHash [32]byte) {
+	p.subscriberMtx.Lock()
+	defer p.subscriberMtx.Unlock()
+
+	if len(p.subscribers[rHash]) == 0 {
+		return
+	}
+
+	payment, err := p.db.FetchPayment(rHash)
+	if err != nil {
+		srvrLog.Errorf("unable to fetch payment(%x): %v", rHash[:], err)
+		return
+	}
+
+	for _, sub := range p.subscribers[rHash] {
+		select {
+		case sub.updates <- payment:
+			continue
+		default:
+		}
+
+		// Each update carries the full state of the payment, so if the
+		// subscriber has fallen behind, its oldest pending update is
+		// discarded in favour of the latest one. As updates are only
+		// sent while the subscriber mutex is held, there's then room
+		// for the latest state, ensuring the final state of the
+		// payment is always delivered.
+		srvrLog.Warnf("Dropping stale payment update for slow "+
+			"subscriber %v", sub.id)
+		select {
+		case <-sub.updates:
+		default:
+		}
+		sub.updates <- payment
+	}
+}
+
+// resumePayments reconciles the state of all payments which were in flight
+// when the daemon last shut down. If the HTLC for a payment is still present
+// within one of our channels, then the payment remains in flight until the
+// HTLC is settled. Otherwise, the HTLC was never locked in, so the payment is
+// safely marked as failed.
+func (p *paymentController) resumePayments() error {
+	inFlight, err := p.db.FetchInFlightPayments()
+	if err != nil {
+		return err
+	}
+	if len(inFlight) == 0 {
+		return nil
+	}
+
+	channels, err := p.db.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return err
+	}
+
+	// Gather the payment hashes of all the outgoing HTLC's currently
+	// locked into any of our channels.
+	pendingHTLCs := make(map[[32]byte]struct{})
+	for _, channel := range channels {
+		for _, htlc := range channel.Htlcs {
+			if htlc.Incoming {
+				continue
+			}
+
+			pendingHTLCs[htlc.RHash] = struct{}{}
+		}
+	}
+
+	for _, payment := range inFlight {
+		if _, ok := pendingHTLCs[payment.PaymentHash]; ok {
+			srvrLog.Infof("Resuming payment(%x), HTLC still "+
+				"outstanding", payment.PaymentHash[:])
+			continue
+		}
+
+		srvrLog.Infof("Payment(%x) was never locked in, marking as "+
+			"failed", payment.PaymentHash[:])
+		if err := p.db.FailPayment(payment.PaymentHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
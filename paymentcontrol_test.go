@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockChainIO is a mock implementation of the BlockChainIO interface which
+// only reports a fixed chain height.
+type mockChainIO struct {
+	lnwallet.BlockChainIO
+
+	height int32
+}
+
+func (m *mockChainIO) GetCurrentHeight() (int32, error) {
+	return m.height, nil
+}
+
+// mockHTLCSender is a mock implementation of the htlcSender interface which
+// records each HTLC packet sent, and responds to each with the next of its
+// queued errors. Once the queue is exhausted, every HTLC succeeds.
+type mockHTLCSender struct {
+	sent []*htlcPacket
+	errs []error
+}
+
+func (m *mockHTLCSender) SendHTLC(htlcPkt *htlcPacket) error {
+	m.sent = append(m.sent, htlcPkt)
+	if len(m.errs) == 0 {
+		return nil
+	}
+
+	err := m.errs[0]
+	m.errs = m.errs[1:]
+	return err
+}
+
+// mockRemoteFailSender is a mock implementation of the htlcSender interface
+// which rejects the first HTLC sent with the passed failure, encrypted by the
+// failing hop using the failure secret within the HTLC's payload. Every later
+// HTLC succeeds.
+type mockRemoteFailSender struct {
+	sent []*htlcPacket
+
+	failingHop wire.ShaHash
+	failure    *lnwire.FailureMessage
+}
+
+func (m *mockRemoteFailSender) SendHTLC(htlcPkt *htlcPacket) error {
+	m.sent = append(m.sent, htlcPkt)
+	if len(m.sent) > 1 {
+		return nil
+	}
+
+	htlc := htlcPkt.msg.(*lnwire.HTLCAddRequest)
+	payload := &lnwire.HopPayload{}
+	if err := payload.Decode(bytes.NewReader(htlc.OnionBlob)); err != nil {
+		return err
+	}
+
+	encrypter := &failureEncrypter{
+		sharedSecret: hopFailureSecret(payload.FailureSecret,
+			m.failingHop),
+	}
+	reason, err := encrypter.encryptFailure(m.failure)
+	if err != nil {
+		return err
+	}
+
+	return encryptedFailure(reason)
+}
+
+// makeTestPaymentController creates a paymentController backed by a fresh
+// database and channel graph within a temporary directory, dispatching
+// payments from selfID using the passed htlcSender. The returned function
+// tears down the database once the test has finished.
+func makeTestPaymentController(selfID wire.ShaHash,
+	sender htlcSender) (*paymentController, func(), error) {
+
+	tempDirName, err := ioutil.TempDir("", "paymentcontrol")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := channeldb.Open(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		os.RemoveAll(tempDirName)
+		return nil, nil, err
+	}
+	graph, err := channeldb.OpenGraph(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		db.Close()
+		os.RemoveAll(tempDirName)
+		return nil, nil, err
+	}
+
+	cleanUp := func() {
+		graph.Close()
+		db.Close()
+		os.RemoveAll(tempDirName)
+	}
+
+	p := newPaymentController(db, graph, &mockChainIO{height: 100},
+		selfID, sender, 1000)
+	return p, cleanUp, nil
+}
+
+// testChannel describes a channel to be added to the channel graph by
+// addTestChannels. The fee and CLTV delta charged by node1 for forwarding
+// over the channel are given by feeBase and timeLockDelta.
+type testChannel struct {
+	node1, node2  wire.ShaHash
+	capacity      btcutil.Amount
+	feeBase       btcutil.Amount
+	timeLockDelta uint32
+}
+
+// addTestChannels adds an edge to the graph for each of the passed channels,
+// returning the channel point of each.
+func addTestChannels(graph *channeldb.ChannelGraph,
+	channels []testChannel) ([]wire.OutPoint, error) {
+
+	chanPoints := make([]wire.OutPoint, len(channels))
+	for i, c := range channels {
+		chanPoints[i] = wire.OutPoint{
+			Hash:  wire.ShaHash{byte(i + 1)},
+			Index: uint32(i),
+		}
+		edge := &channeldb.ChannelEdge{
+			ChannelPoint: chanPoints[i],
+			Node1:        c.node1,
+			Node2:        c.node2,
+			Capacity:     c.capacity,
+			Node1Policy: &channeldb.ChannelEdgePolicy{
+				TimeLockDelta: c.timeLockDelta,
+				FeeBase:       c.feeBase,
+				LastUpdate:    time.Unix(1, 0),
+			},
+			LastUpdate: time.Unix(1, 0),
+		}
+		if err := graph.AddChannelEdge(edge); err != nil {
+			return nil, err
+		}
+	}
+
+	return chanPoints, nil
+}
+
+// TestPaymentSubscriptionFinalState tests that a subscriber which has fallen
+// behind always receives the final state of a payment, and that subscribing
+// to a payment which has already completed yields its final state.
+func TestPaymentSubscriptionFinalState(t *testing.T) {
+	p, cleanUp, err := makeTestPaymentController(wire.ShaHash{},
+		&mockHTLCSender{})
+	if err != nil {
+		t.Fatalf("unable to create payment controller: %v", err)
+	}
+	defer cleanUp()
+
+	rHash := [32]byte{1}
+	err = p.db.InitPayment(&channeldb.OutgoingPayment{
+		PaymentHash:  rHash,
+		Amt:          btcutil.Amount(1000),
+		CreationDate: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	sub, err := p.subscribePayment(rHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to payment: %v", err)
+	}
+	defer sub.cancel()
+
+	// Register enough attempts to overflow the subscriber's buffer
+	// without reading any of the updates, then fail the payment.
+	numAttempts := cap(sub.updates) * 2
+	for i := 0; i < numAttempts; i++ {
+		err := p.db.RegisterAttempt(rHash, &channeldb.PaymentAttempt{})
+		if err != nil {
+			t.Fatalf("unable to register attempt: %v", err)
+		}
+		p.notifySubscribers(rHash)
+	}
+	p.failPayment(rHash)
+
+	// The final update within the buffer should carry the failed state.
+	var last *channeldb.OutgoingPayment
+	for len(sub.updates) > 0 {
+		last = <-sub.updates
+	}
+	if last == nil || last.Status != channeldb.StatusFailed {
+		t.Fatalf("final state of payment not delivered, got %v", last)
+	}
+
+	// A new subscriber should immediately receive the final state.
+	lateSub, err := p.subscribePayment(rHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe to payment: %v", err)
+	}
+	defer lateSub.cancel()
+
+	select {
+	case payment := <-lateSub.updates:
+		if payment.Status != channeldb.StatusFailed {
+			t.Fatalf("expected failed payment, got %v",
+				payment.Status)
+		}
+	default:
+		t.Fatalf("no update delivered to new subscriber")
+	}
+
+	// Subscribing to an unknown payment should fail without leaving the
+	// subscriber registered.
+	if _, err := p.subscribePayment([32]byte{2}); err == nil {
+		t.Fatalf("subscription to unknown payment should fail")
+	}
+	if _, ok := p.subscribers[[32]byte{2}]; ok {
+		t.Fatalf("failed subscription left registered")
+	}
+}
+
+// TestSendPaymentRetry tests that a payment which fails with a temporary
+// channel failure is retried along an alternate route, with the failing
+// channel pruned, while a permanent failure ends the payment.
+func TestSendPaymentRetry(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		bob   = wire.ShaHash{3}
+		dest  = wire.ShaHash{4}
+	)
+
+	sender := &mockHTLCSender{}
+	p, cleanUp, err := makeTestPaymentController(self, sender)
+	if err != nil {
+		t.Fatalf("unable to create payment controller: %v", err)
+	}
+	defer cleanUp()
+
+	// Both routes to the destination are equally likely to succeed, so
+	// whichever route is attempted first, the other remains.
+	_, err = addTestChannels(p.graph, []testChannel{
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: dest, capacity: 100000},
+		{node1: self, node2: bob, capacity: 100000},
+		{node1: bob, node2: dest, capacity: 100000},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+
+	// The first attempt fails with a temporary failure, so the payment
+	// should be retried via the other peer.
+	sender.errs = []error{&lnwire.FailureMessage{
+		Code: lnwire.CodeTemporaryChannelFailure,
+	}}
+	payment := &lightningPayment{
+		dest:        dest,
+		amt:         1000,
+		paymentHash: [32]byte{1},
+	}
+	if err := p.sendPayment(payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if len(sender.sent) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", len(sender.sent))
+	}
+	if sender.sent[0].dest == sender.sent[1].dest {
+		t.Fatalf("payment retried via the same peer %x",
+			sender.sent[0].dest[:])
+	}
+
+	dbPayment, err := p.db.FetchPayment(payment.paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if len(dbPayment.Attempts) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %v",
+			len(dbPayment.Attempts))
+	}
+	if dbPayment.Attempts[0].Status != channeldb.StatusFailed {
+		t.Fatalf("first attempt should have failed, instead %v",
+			dbPayment.Attempts[0].Status)
+	}
+
+	// A permanent failure shouldn't be retried, and should fail the
+	// payment as a whole.
+	sender.sent = nil
+	sender.errs = []error{&lnwire.FailureMessage{
+		Code: lnwire.CodeUnknownNextPeer,
+	}}
+	payment.paymentHash = [32]byte{2}
+	if err := p.sendPayment(payment); err == nil {
+		t.Fatalf("payment should have failed")
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected 1 attempt, got %v", len(sender.sent))
+	}
+	dbPayment, err = p.db.FetchPayment(payment.paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if dbPayment.Status != channeldb.StatusFailed {
+		t.Fatalf("payment should have failed, instead %v",
+			dbPayment.Status)
+	}
+
+	// Once both routes have failed, no route remains. The liquidity
+	// learned from the prior payments is forgotten, so both routes are
+	// attempted.
+	p.liquidity = newLiquidityModel()
+	sender.sent = nil
+	sender.errs = []error{
+		&lnwire.FailureMessage{Code: lnwire.CodeTemporaryChannelFailure},
+		&lnwire.FailureMessage{Code: lnwire.CodeTemporaryChannelFailure},
+	}
+	payment.paymentHash = [32]byte{3}
+	if err := p.sendPayment(payment); err != errNoPathFound {
+		t.Fatalf("expected errNoPathFound, got %v", err)
+	}
+	if len(sender.sent) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", len(sender.sent))
+	}
+}
+
+// TestSendPaymentRemoteFailure tests that a temporary failure reported by a
+// remote hop prunes the outgoing channel of that hop, rather than our own
+// channel to it, before the payment is retried.
+func TestSendPaymentRemoteFailure(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		bob   = wire.ShaHash{3}
+		dest  = wire.ShaHash{4}
+	)
+
+	sender := &mockRemoteFailSender{
+		failingHop: alice,
+		failure: &lnwire.FailureMessage{
+			Code: lnwire.CodeTemporaryChannelFailure,
+		},
+	}
+	p, cleanUp, err := makeTestPaymentController(self, sender)
+	if err != nil {
+		t.Fatalf("unable to create payment controller: %v", err)
+	}
+	defer cleanUp()
+
+	// Our only channel is to Alice, who is able to reach the destination
+	// either directly, or via Bob.
+	chanPoints, err := addTestChannels(p.graph, []testChannel{
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: dest, capacity: 100000},
+		{node1: alice, node2: bob, capacity: 100000},
+		{node1: bob, node2: dest, capacity: 100000},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+
+	// Alice fails the first attempt over her channel to the destination.
+	// Only that channel should be pruned, so the payment is retried via
+	// Bob, still through our channel to Alice.
+	payment := &lightningPayment{
+		dest:        dest,
+		amt:         1000,
+		paymentHash: [32]byte{1},
+	}
+	if err := p.sendPayment(payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if len(sender.sent) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", len(sender.sent))
+	}
+
+	dbPayment, err := p.db.FetchPayment(payment.paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if len(dbPayment.Attempts) != 2 {
+		t.Fatalf("expected 2 recorded attempts, got %v",
+			len(dbPayment.Attempts))
+	}
+
+	failed := dbPayment.Attempts[0]
+	if failed.Status != channeldb.StatusFailed ||
+		failed.FailureSourceIndex != 1 ||
+		failed.FailureCode != uint16(lnwire.CodeTemporaryChannelFailure) {
+
+		t.Fatalf("first attempt should have failed at hop 1 with "+
+			"a temporary channel failure, instead %v at hop %v "+
+			"with code %v", failed.Status, failed.FailureSourceIndex,
+			failed.FailureCode)
+	}
+	if failed.Hops[1].ChannelPoint != chanPoints[1] {
+		t.Fatalf("first attempt should have used alice's channel " +
+			"to the destination")
+	}
+
+	retried := dbPayment.Attempts[1].Hops
+	if len(retried) != 3 || retried[0].ChannelPoint != chanPoints[0] ||
+		retried[1].NodeID != bob {
+
+		t.Fatalf("payment should have been retried via alice and bob, "+
+			"instead via %v", retried)
+	}
+}
+
+// TestCltvLimit tests that the CLTV limit requested for a payment is clamped
+// to the configured maximum, and that a payment whose only route exceeds the
+// maximum is never dispatched.
+func TestCltvLimit(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		dest  = wire.ShaHash{3}
+	)
+
+	sender := &mockHTLCSender{}
+	p, cleanUp, err := makeTestPaymentController(self, sender)
+	if err != nil {
+		t.Fatalf("unable to create payment controller: %v", err)
+	}
+	defer cleanUp()
+
+	tests := []struct {
+		requested uint32
+		expected  uint32
+	}{
+		{requested: 0, expected: 1000},
+		{requested: 500, expected: 500},
+		{requested: 1000, expected: 1000},
+		{requested: 2000, expected: 1000},
+	}
+	for _, test := range tests {
+		limit := p.cltvLimit(test.requested)
+		if limit != test.expected {
+			t.Fatalf("expected limit %v for requested limit %v, "+
+				"got %v", test.expected, test.requested, limit)
+		}
+	}
+
+	// Alice charges a CLTV delta beyond the configured maximum, so even
+	// a payment requesting a higher limit has no route.
+	_, err = addTestChannels(p.graph, []testChannel{
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: dest, capacity: 100000,
+			timeLockDelta: 1500},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+	payment := &lightningPayment{
+		dest:        dest,
+		amt:         1000,
+		paymentHash: [32]byte{1},
+		cltvLimit:   2000,
+	}
+	if err := p.sendPayment(payment); err != errCltvLimitExceeded {
+		t.Fatalf("expected errCltvLimitExceeded, got %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("htlc sent along route exceeding cltv limit")
+	}
+}
+
+// TestSendPaymentFees tests that the HTLC of a payment carries the fees of
+// each intermediate node, and that a payment whose fees exceed its fee limit
+// is never dispatched.
+func TestSendPaymentFees(t *testing.T) {
+	var (
+		self  = wire.ShaHash{1}
+		alice = wire.ShaHash{2}
+		bob   = wire.ShaHash{3}
+		dest  = wire.ShaHash{4}
+	)
+
+	sender := &mockHTLCSender{}
+	p, cleanUp, err := makeTestPaymentController(self, sender)
+	if err != nil {
+		t.Fatalf("unable to create payment controller: %v", err)
+	}
+	defer cleanUp()
+
+	// Alice and Bob each charge a fee to forward over their channel
+	// leading towards the destination.
+	_, err = addTestChannels(p.graph, []testChannel{
+		{node1: self, node2: alice, capacity: 100000},
+		{node1: alice, node2: bob, capacity: 100000, feeBase: 10},
+		{node1: bob, node2: dest, capacity: 100000, feeBase: 5},
+	})
+	if err != nil {
+		t.Fatalf("unable to add channels: %v", err)
+	}
+
+	payment := &lightningPayment{
+		dest:        dest,
+		amt:         1000,
+		paymentHash: [32]byte{1},
+		feeLimit:    15,
+	}
+	if err := p.sendPayment(payment); err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected 1 attempt, got %v", len(sender.sent))
+	}
+	htlc := sender.sent[0].msg.(*lnwire.HTLCAddRequest)
+	if htlc.Amount != lnwire.CreditsAmount(1015) {
+		t.Fatalf("expected htlc of 1015, got %v", htlc.Amount)
+	}
+
+	// With a lower fee limit, the only route is too expensive.
+	sender.sent = nil
+	payment.paymentHash = [32]byte{2}
+	payment.feeLimit = 14
+	if err := p.sendPayment(payment); err != errFeeLimitExceeded {
+		t.Fatalf("expected errFeeLimitExceeded, got %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("payment exceeding fee limit was dispatched")
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"container/list"
+	"crypto/subtle"
 	"fmt"
 	"net"
 	"sync"
@@ -11,6 +13,7 @@ import (
 	"github.com/BitfuryLightning/tools/rt/graph"
 	"github.com/btcsuite/fastsha256"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lndc"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -24,6 +27,10 @@ import (
 
 var (
 	numNodes int32
+
+	// zeroRevocation is the revocation sent to extend the revocation
+	// window, rather than to revoke a prior commitment.
+	zeroRevocation [32]byte
 )
 
 const (
@@ -34,6 +41,18 @@ const (
 	// messages to be sent across the wire, requested by objects outside
 	// this struct.
 	outgoingQueueLen = 50
+
+	// initTimeout is the duration we'll wait for the remote peer to send
+	// its Init message before disconnecting.
+	initTimeout = 15 * time.Second
+
+	// uptimeFlushInterval is the interval at which the time the remote
+	// peer has been online is recorded as uptime for each active channel.
+	uptimeFlushInterval = time.Minute
+
+	// stallCheckInterval is the interval at which each active channel is
+	// checked for a commitment update which has stalled.
+	stallCheckInterval = 5 * time.Second
 )
 
 // outgoinMsg packages an lnwire.Message to be sent out on the wire, along with
@@ -59,6 +78,7 @@ type peer struct {
 	started    int32
 	connected  int32
 	disconnect int32
+	stalled    int32
 
 	conn net.Conn
 
@@ -68,6 +88,10 @@ type peer struct {
 	inbound bool
 	id      int32
 
+	// remoteFeatures is the set of features advertised by the remote
+	// peer within its Init message.
+	remoteFeatures *lnwire.FeatureVector
+
 	// For purposes of detecting retransmits, etc.
 	lastNMessages map[lnwire.Message]struct{}
 
@@ -146,8 +170,7 @@ type peer struct {
 // newPeer creates a new peer from an establish connection object, and a
 // pointer to the main server.
 func newPeer(conn net.Conn, server *server, btcNet wire.BitcoinNet, inbound bool) (*peer, error) {
-	lndcConn := conn.(*lndc.LNDConn)
-	nodePub := lndcConn.RemotePub
+	nodePub := conn.(*brontide.Conn).RemotePub()
 
 	p := &peer{
 		conn:        conn,
@@ -181,7 +204,7 @@ func newPeer(conn net.Conn, server *server, btcNet wire.BitcoinNet, inbound bool
 	// TODO(roasbeef): re-write after lnaddr revamp, shouldn't need to use
 	// type assertions
 	var err error
-	tcpAddr := lndcConn.Conn.(*net.TCPConn).RemoteAddr().(*net.TCPAddr)
+	tcpAddr := conn.RemoteAddr().(*net.TCPAddr)
 	p.lightningAddr, err = lndc.NewLnAdr(tcpAddr, nodePub, activeNetParams.Params)
 	if err != nil {
 		return nil, err
@@ -219,7 +242,8 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 	for _, dbChan := range chans {
 		chanID := dbChan.ChanID
 		lnChan, err := lnwallet.NewLightningChannel(p.server.lnwallet.Signer,
-			p.server.bio, p.server.chainNotifier, dbChan)
+			p.server.lnwallet.SigPool, p.server.bio,
+			p.server.chainNotifier, dbChan)
 		if err != nil {
 			return err
 		}
@@ -257,6 +281,12 @@ func (p *peer) Start() error {
 
 	peerLog.Tracef("peer %v starting", p)
 
+	// Before any other messages are exchanged, both sides must advertise
+	// their features within an Init message.
+	if err := p.exchangeInit(); err != nil {
+		return err
+	}
+
 	p.wg.Add(4)
 	go p.readHandler()
 	go p.queueHandler()
@@ -266,6 +296,41 @@ func (p *peer) Start() error {
 	return nil
 }
 
+// exchangeInit sends our Init message to the remote peer, then waits for the
+// remote peer's Init message in return. An error is returned if the remote
+// peer fails to respond in time, or requires features unknown to us.
+func (p *peer) exchangeInit() error {
+	localFeatures := p.server.featureMgr.get(featureSetInit)
+	if err := p.writeMessage(lnwire.NewInitMessage(localFeatures)); err != nil {
+		return err
+	}
+
+	// Ensure that we don't wait for the remote peer's Init message
+	// indefinitely.
+	p.conn.SetReadDeadline(time.Now().Add(initTimeout))
+	msg, _, err := p.readNextMessage()
+	p.conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return fmt.Errorf("unable to read init message: %v", err)
+	}
+
+	remoteInit, ok := msg.(*lnwire.Init)
+	if !ok {
+		return fmt.Errorf("expected init message, instead received "+
+			"command %v", msg.Command())
+	}
+	if err := validateRemoteFeatures(remoteInit.Features); err != nil {
+		return err
+	}
+
+	peerLog.Debugf("Peer %v advertised features: %v", p,
+		remoteInit.Features)
+
+	p.remoteFeatures = remoteInit.Features
+
+	return nil
+}
+
 // Stop signals the peer for a graceful shutdown. All active goroutines will be
 // signaled to wrap up any final actions. This function will also block until
 // all goroutines have exited.
@@ -366,6 +431,16 @@ out:
 			p.server.fundingMgr.processFundingOpenProof(msg, p)
 		case *lnwire.CloseRequest:
 			p.remoteCloseChanReqs <- msg
+		case *lnwire.ErrorGeneric:
+			switch lnwire.ErrorCode(msg.ErrorID) {
+			case lnwire.ErrChanTooSmall, lnwire.ErrChanTooLarge,
+				lnwire.ErrMaxPendingChannels:
+
+				p.server.fundingMgr.processErrorGeneric(msg, p)
+			default:
+				peerLog.Warnf("Received error(%v) from %v: %v",
+					msg.ErrorID, p, msg.Problem)
+			}
 		// TODO(roasbeef): interface for htlc update msgs
 		//  * .(CommitmentUpdater)
 		case *lnwire.HTLCAddRequest:
@@ -374,6 +449,9 @@ out:
 		case *lnwire.HTLCSettleRequest:
 			isChanUpate = true
 			targetChan = msg.ChannelPoint
+		case *lnwire.HTLCAddReject:
+			isChanUpate = true
+			targetChan = msg.ChannelPoint
 		case *lnwire.CommitRevocation:
 			isChanUpate = true
 			targetChan = msg.ChannelPoint
@@ -386,8 +464,17 @@ out:
 			*lnwire.NeighborUpdMessage,
 			*lnwire.RoutingTableRequestMessage,
 			*lnwire.RoutingTableTransferMessage:
+			// Drop any channels too small to be of interest before
+			// they reach the routing table.
+			filterRoutingMessage(msg, cfg.GossipMinCapacity)
+
 			// Convert to base routing message and set sender and receiver
 			p.server.routingMgr.ReceiveRoutingMessage(msg, graph.NewID(([32]byte)(p.lightningID)))
+			p.server.rpcCache.invalidate()
+		case *lnwire.OnionMessage:
+			p.server.onionMessenger.handleMessage(p, msg)
+		case *lnwire.Custom:
+			p.server.customMsgNotifier.notify(p, msg)
 		}
 
 		if isChanUpate {
@@ -555,15 +642,52 @@ func (p *peer) ChannelSnapshots() []*channeldb.ChannelSnapshot {
 	return <-resp
 }
 
+// flushUptime records the time elapsed since the last uptime checkpoint of
+// each active channel within the database, then advances each checkpoint to
+// the current time. Checkpoints of channels which are no longer active are
+// discarded.
+func (p *peer) flushUptime(checkpoints map[wire.OutPoint]time.Time) {
+	now := time.Now()
+	for chanPoint, lastCheckpoint := range checkpoints {
+		if _, ok := p.activeChannels[chanPoint]; !ok {
+			delete(checkpoints, chanPoint)
+			continue
+		}
+
+		online := now.Sub(lastCheckpoint)
+		err := p.server.chanDB.AddChannelUptime(&chanPoint, online)
+		if err != nil {
+			peerLog.Errorf("unable to record uptime for "+
+				"ChannelPoint(%v): %v", chanPoint, err)
+		}
+
+		checkpoints[chanPoint] = now
+	}
+}
+
 // channelManager is goroutine dedicated to handling all requests/signals
 // pertaining to the opening, cooperative closing, and force closing of all
-// channels maintained with the remote peer.
+// channels maintained with the remote peer. Additionally, the time the remote
+// peer is online is periodically recorded as uptime for each active channel.
 //
 // NOTE: This method MUST be run as a goroutine.
 func (p *peer) channelManager() {
+	// Begin tracking the uptime of all channels loaded from disk. Any
+	// channels opened while the peer is connected are added below.
+	uptimeCheckpoints := make(map[wire.OutPoint]time.Time)
+	for chanPoint := range p.activeChannels {
+		uptimeCheckpoints[chanPoint] = time.Now()
+	}
+
+	uptimeTicker := time.NewTicker(uptimeFlushInterval)
+	defer uptimeTicker.Stop()
+
 out:
 	for {
 		select {
+		case <-uptimeTicker.C:
+			p.flushUptime(uptimeCheckpoints)
+
 		case req := <-p.chanSnapshotReqs:
 			snapshots := make([]*channeldb.ChannelSnapshot, 0, len(p.activeChannels))
 			for _, activeChan := range p.activeChannels {
@@ -582,6 +706,7 @@ out:
 		case newChan := <-p.newChannels:
 			chanPoint := *newChan.ChannelPoint()
 			p.activeChannels[chanPoint] = newChan
+			uptimeCheckpoints[chanPoint] = time.Now()
 
 			peerLog.Infof("New channel active ChannelPoint(%v) "+
 				"with peerId(%v)", chanPoint, p.id)
@@ -621,53 +746,37 @@ out:
 		}
 	}
 
-	p.wg.Done()
-}
-
-// executeForceClose executes a unilateral close of the target channel by
-// broadcasting the current commitment state directly on-chain. Once the
-// commitment transaction has been broadcast, a struct describing the final
-// state of the channel is sent to the utxoNursery in order to ultimatley sweep
-// the immature outputs.
-func (p *peer) executeForceClose(channel *lnwallet.LightningChannel) (*wire.ShaHash, error) {
-	// Execute a unilateral close shutting down all further channel
-	// operation.
-	closeSummary, err := channel.ForceClose()
-	if err != nil {
-		return nil, err
-	}
-
-	closeTx := closeSummary.CloseTx
-	txid := closeTx.TxSha()
+	// Record any uptime accumulated since the last checkpoint before we
+	// exit, as the remote peer is now offline.
+	p.flushUptime(uptimeCheckpoints)
 
-	// With the close transaction in hand, broadcast the transaction to the
-	// network, thereby entering the psot channel resolution state.
-	peerLog.Infof("Broadcasting force close transaction: %v",
-		channel.ChannelPoint(), newLogClosure(func() string {
-			return spew.Sdump(closeTx)
-		}))
-	if err := p.server.lnwallet.PublishTransaction(closeTx); err != nil {
-		return nil, err
-	}
-
-	// Send the closed channel sumary over to the utxoNursery in order to
-	// have its outputs sweeped back into the wallet once they're mature.
-	p.server.utxoNursery.incubateOutputs(closeSummary)
-
-	return &txid, nil
+	p.wg.Done()
 }
 
 // executeCooperativeClose executes the initial phase of a user-executed
 // cooperative channel close. The channel state machine is transitioned to the
 // closing phase, then our half of the closing witness is sent over to the
-// remote peer.
-func (p *peer) executeCooperativeClose(channel *lnwallet.LightningChannel) (*wire.ShaHash, error) {
+// remote peer. If non-nil, our balance is delivered to the passed delivery
+// script rather than the one negotiated during funding.
+func (p *peer) executeCooperativeClose(channel *lnwallet.LightningChannel,
+	deliveryScript []byte) (*wire.ShaHash, error) {
+
+	// Unless we've committed to an upfront shutdown script, our balance
+	// is delivered to the configured close address by default.
+	if deliveryScript == nil && !channel.UpfrontShutdown() {
+		var err error
+		deliveryScript, err = deliveryAddrScript(p.server.closeAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Shift the channel state machine into a 'closing' state. This
 	// generates a signature for the closing tx, as well as a txid of the
 	// closing tx itself, allowing us to watch the network to determine
 	// when the remote node broadcasts the fully signed closing
 	// transaction.
-	sig, txid, err := channel.InitCooperativeClose()
+	sig, txid, err := channel.InitCooperativeClose(deliveryScript)
 	if err != nil {
 		return nil, err
 	}
@@ -685,6 +794,7 @@ func (p *peer) executeCooperativeClose(channel *lnwallet.LightningChannel) (*wir
 		return nil, err
 	}
 	closeReq := lnwire.NewCloseRequest(chanPoint, closeSig)
+	closeReq.DeliveryPkScript = deliveryScript
 	p.queueMsg(closeReq, nil)
 
 	return txid, nil
@@ -696,16 +806,20 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	var (
 		err         error
 		closingTxid *wire.ShaHash
+		csvDelay    uint32
 	)
 
 	channel := p.activeChannels[*req.chanPoint]
 
 	if req.forceClose {
-		closingTxid, err = p.executeForceClose(channel)
+		// The chain arbitrator takes over the resolution of the
+		// channel's contracts once the commitment is broadcast.
+		closingTxid, csvDelay, err = p.server.chainArb.ForceClose(channel)
 		peerLog.Infof("Force closing ChannelPoint(%v) with txid: %v",
 			req.chanPoint, closingTxid)
 	} else {
-		closingTxid, err = p.executeCooperativeClose(channel)
+		closingTxid, err = p.executeCooperativeClose(channel,
+			req.deliveryScript)
 		peerLog.Infof("Attempting cooperative close of "+
 			"ChannelPoint(%v) with txid: %v", req.chanPoint,
 			closingTxid)
@@ -719,7 +833,8 @@ func (p *peer) handleLocalClose(req *closeLinkReq) {
 	req.updates <- &lnrpc.CloseStatusUpdate{
 		Update: &lnrpc.CloseStatusUpdate_ClosePending{
 			ClosePending: &lnrpc.PendingUpdate{
-				Txid: closingTxid[:],
+				Txid:     closingTxid[:],
+				CsvDelay: csvDelay,
 			},
 		},
 	}
@@ -786,8 +901,23 @@ func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
 	// signature.
 	sig := req.RequesterCloseSig
 	closeSig := append(sig.Serialize(), byte(txscript.SigHashAll))
-	closeTx, err := channel.CompleteCooperativeClose(closeSig)
-	if err != nil {
+	closeTx, err := channel.CompleteCooperativeClose(closeSig,
+		req.DeliveryPkScript)
+	if err == lnwallet.ErrUpfrontShutdown {
+		// The remote node committed to an upfront shutdown script, yet
+		// requested their balance be delivered elsewhere, so we refuse
+		// to sign the closing transaction.
+		peerLog.Errorf("Refusing cooperative close of "+
+			"ChannelPoint(%v), delivery script %x doesn't match "+
+			"upfront shutdown script", chanPoint,
+			req.DeliveryPkScript)
+		p.queueMsg(&lnwire.ErrorGeneric{
+			ChannelPoint: chanPoint,
+			ErrorID:      uint16(lnwire.ErrUpfrontShutdownViolation),
+			Problem:      err.Error(),
+		}, nil)
+		return
+	} else if err != nil {
 		peerLog.Errorf("unable to complete cooperative "+
 			"close for ChannelPoint(%v): %v",
 			chanPoint, err)
@@ -806,6 +936,13 @@ func (p *peer) handleRemoteClose(req *lnwire.CloseRequest) {
 		// TODO(roasbeef): send ErrorGeneric to other side
 		return
 	}
+	closeTxid := closeTx.TxSha()
+	label := lnwallet.CooperativeCloseTxLabel(&key)
+	err = p.server.lnwallet.LabelTransaction(closeTxid, label, false)
+	if err != nil {
+		peerLog.Warnf("unable to label cooperative close tx %v: %v",
+			closeTxid, err)
+	}
 
 	// TODO(roasbeef): also wait for confs before removing state
 	peerLog.Infof("ChannelPoint(%v) is now "+
@@ -837,6 +974,15 @@ func wipeChannel(p *peer, channel *lnwallet.LightningChannel) error {
 		return err
 	}
 
+	// The channel is no longer usable for routing, so remove its edge from
+	// the channel graph.
+	err := p.server.chanGraph.DeleteChannelEdge(chanID)
+	if err != nil && err != channeldb.ErrEdgeNotFound {
+		peerLog.Errorf("Unable to remove ChannelPoint(%v) "+
+			"from graph: %v", chanID, err)
+	}
+	p.server.rpcCache.invalidate()
+
 	return nil
 }
 
@@ -860,6 +1006,16 @@ type commitmentState struct {
 	// many of the pending HTLC's we've received from the upstream peer.
 	htlcsToSettle map[uint32]invoice
 
+	// htlcsToFail is the set of HTLC's we've received from the upstream
+	// peer which we've refused to settle, mapped to the encrypted reason
+	// we'll fail them back with once they've been locked in.
+	htlcsToFail map[uint32][]byte
+
+	// failedHTLCs is the set of outgoing HTLC's the upstream peer has
+	// failed, mapped to the encrypted reason for the failure. The failure
+	// is returned to the requester once the removal has been locked in.
+	failedHTLCs map[uint32]encryptedFailure
+
 	// TODO(roasbeef): use once trickle+batch logic is in
 	pendingBatch []*pendingPayment
 
@@ -878,12 +1034,21 @@ type commitmentState struct {
 	// TODO(roasbeef): timer should be >> then RTT
 	logCommitTimer <-chan time.Time
 
+	// pendingSigs holds the time at which each commitment we've signed
+	// for the remote peer, that has yet to be revoked, was sent. The
+	// entries are ordered from oldest to newest.
+	pendingSigs []time.Time
+
 	// switchChan is a channel used to send packets to the htlc switch for
 	// fowarding.
 	switchChan chan<- *htlcPacket
 
 	channel   *lnwallet.LightningChannel
 	chanPoint *wire.OutPoint
+
+	// shortChanID is the location of the channel's funding output within
+	// the chain, used to identify the channel within HTLC events.
+	shortChanID lnwire.ShortChannelID
 }
 
 // htlcManager is the primary goroutine which drives a channel's commitment
@@ -919,11 +1084,29 @@ func (p *peer) htlcManager(channel *lnwallet.LightningChannel,
 	state := &commitmentState{
 		channel:       channel,
 		chanPoint:     channel.ChannelPoint(),
+		shortChanID:   chanStats.ShortChanID,
 		clearedHTCLs:  make(map[uint32]*pendingPayment),
 		htlcsToSettle: make(map[uint32]invoice),
+		htlcsToFail:   make(map[uint32][]byte),
+		failedHTLCs:   make(map[uint32]encryptedFailure),
 		switchChan:    htlcPlex,
 	}
 
+	// Have the chain arbitrator watch over the channel while it's active,
+	// so it's able to go on-chain should one of its HTLCs require it.
+	p.server.chainArb.WatchChannel(channel)
+
+	// If stall detection is enabled, we'll periodically check if the
+	// remote peer has failed to revoke a commitment we've signed for
+	// longer than the stall timeout.
+	var stallTicker <-chan time.Time
+	if cfg.StallTimeout > 0 {
+		ticker := time.NewTicker(stallCheckInterval)
+		defer ticker.Stop()
+		stallTicker = ticker.C
+	}
+	stallTimeout := time.Duration(cfg.StallTimeout) * time.Second
+
 	batchTimer := time.Tick(10 * time.Millisecond)
 out:
 	for {
@@ -983,6 +1166,26 @@ out:
 			}
 
 			state.numUnAcked += 1
+		case <-stallTicker:
+			if len(state.pendingSigs) == 0 {
+				continue
+			}
+
+			// If the remote peer has sat on our oldest signature
+			// for too long, then the commitment dance has stalled.
+			// A fresh connection resets the session, causing
+			// the revocation window and any pending updates to be
+			// re-sent.
+			sinceSig := time.Since(state.pendingSigs[0])
+			if sinceSig < stallTimeout {
+				continue
+			}
+
+			peerLog.Warnf("ChannelPoint(%v) has stalled, commitment "+
+				"sent %v ago remains unrevoked, reconnecting "+
+				"to peerID(%v)", state.chanPoint, sinceSig, p.id)
+			p.server.reconnectStalledPeer(p)
+			break out
 		case pkt := <-downstreamLink:
 			p.handleDownStreamPkt(state, pkt)
 		case msg, ok := <-upstreamLink:
@@ -999,6 +1202,8 @@ out:
 		}
 	}
 
+	p.server.chainArb.UnwatchChannel(state.chanPoint)
+
 	p.wg.Done()
 	peerLog.Tracef("htlcManager for peer %v done", p)
 }
@@ -1013,10 +1218,37 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 		// A new payment has been initiated via the
 		// downstream channel, so we add the new HTLC
 		// to our local log, then update the commitment
-		// chains.
-		index := state.channel.AddHTLC(htlc)
+		// chains. If the HTLC would exceed the channel's limits
+		// on HTLC's in flight, then we fail it back to the
+		// switch.
+		index, err := state.channel.AddHTLC(htlc)
+		if err != nil {
+			peerLog.Errorf("unable to add htlc: %v", err)
+			pkt.err <- &lnwire.FailureMessage{
+				Code:   lnwire.CodeTemporaryChannelFailure,
+				Amount: htlc.Amount,
+			}
+			p.server.htlcNotifier.notify(&htlcEvent{
+				eventType:           htlcEventLinkFail,
+				outgoingChanPoint:   state.chanPoint,
+				outgoingShortChanID: state.shortChanID,
+				amt:                 btcutil.Amount(htlc.Amount),
+				paymentHash:         htlc.RedemptionHashes[0],
+				failureCode:         lnwire.CodeTemporaryChannelFailure,
+				failureReason:       err.Error(),
+			})
+			return
+		}
 		p.queueMsg(htlc, nil)
 
+		p.server.htlcNotifier.notify(&htlcEvent{
+			eventType:           htlcEventForward,
+			outgoingChanPoint:   state.chanPoint,
+			outgoingShortChanID: state.shortChanID,
+			amt:                 btcutil.Amount(htlc.Amount),
+			paymentHash:         htlc.RedemptionHashes[0],
+		})
+
 		state.pendingBatch = append(state.pendingBatch, &pendingPayment{
 			htlc:  htlc,
 			index: index,
@@ -1025,7 +1257,6 @@ func (p *peer) handleDownStreamPkt(state *commitmentState, pkt *htlcPacket) {
 
 		// If this newly added update exceeds the max batch size, the
 		// initiate an update.
-		// TODO(roasbeef): enforce max HTLC's in flight limit
 		if len(state.pendingBatch) >= 10 {
 			if sent, err := p.updateCommitTx(state); err != nil {
 				peerLog.Errorf("unable to update "+
@@ -1051,18 +1282,120 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 	case *lnwire.HTLCAddRequest:
 		// We just received an add request from an upstream peer, so we
 		// add it to our state machine, then add the HTLC to our
-		// "settle" list in the event that we know the pre-image
-		index := state.channel.ReceiveHTLC(htlcPkt)
+		// "settle" list in the event that we know the pre-image. If
+		// the remote peer has exceeded the limits on HTLC's in flight
+		// within the channel, then it has violated the protocol.
+		index, err := state.channel.ReceiveHTLC(htlcPkt)
+		if err != nil {
+			peerLog.Errorf("unable to receive htlc: %v", err)
+			p.Disconnect()
+			return
+		}
 
 		rHash := htlcPkt.RedemptionHashes[0]
-		if invoice, found := p.server.invoices.lookupInvoice(rHash); found {
-			// TODO(roasbeef): check value
-			//  * onion layer strip should also be before invoice lookup
-			//  * also can immediately send the settle msg
-			invCopy := *invoice
-			invCopy.value = btcutil.Amount(htlcPkt.Amount)
-			state.htlcsToSettle[index] = invCopy
+		p.server.htlcNotifier.notify(&htlcEvent{
+			eventType:           htlcEventForward,
+			incomingChanPoint:   state.chanPoint,
+			incomingShortChanID: state.shortChanID,
+			amt:                 btcutil.Amount(htlcPkt.Amount),
+			paymentHash:         rHash,
+		})
+
+		// Our payload carries the payment secret, the secret we
+		// encrypt any failure sent back to the sender with, along with
+		// any custom records attached to the payment.
+		payload := &lnwire.HopPayload{}
+		blob := bytes.NewReader(htlcPkt.OnionBlob)
+		payloadErr := payload.Decode(blob)
+
+		// refuseHTLC marks the HTLC to be failed back to the remote
+		// peer with the passed failure once it has been locked in.
+		refuseHTLC := func(failure *lnwire.FailureMessage, reason string) {
+			peerLog.Warnf("Refusing to settle HTLC(%x): %v", rHash[:],
+				reason)
+			p.notifyIncomingLinkFail(state, htlcPkt, failure.Code,
+				reason)
+
+			// Without a payload, we lack the secret to encrypt
+			// the failure with, so the HTLC is failed without a
+			// reason.
+			var encrypted []byte
+			if payloadErr == nil {
+				encrypter := &failureEncrypter{
+					sharedSecret: hopFailureSecret(
+						payload.FailureSecret,
+						p.server.lightningID,
+					),
+				}
+
+				var err error
+				encrypted, err = encrypter.encryptFailure(failure)
+				if err != nil {
+					peerLog.Errorf("unable to encrypt "+
+						"failure: %v", err)
+				}
+			}
+			state.htlcsToFail[index] = encrypted
+		}
+
+		// TODO(roasbeef): fail with UnknownNextPeer rather than
+		// treating us as the final hop once multi-hop forwarding is in
+		// place.
+		invoice, found := p.server.invoices.lookupInvoice(rHash)
+		if !found {
+			refuseHTLC(&lnwire.FailureMessage{
+				Code:   lnwire.CodeIncorrectPaymentDetails,
+				Amount: htlcPkt.Amount,
+			}, "unknown payment hash")
+			return
+		}
+
+		// TODO(roasbeef): check value
+		//  * onion layer strip should also be before invoice lookup
+		//  * also can immediately send the settle msg
+
+		// As we're the final destination of this HTLC, we must ensure
+		// that enough time remains until it expires for us to safely
+		// claim it on-chain if necessary, yet that it doesn't time
+		// lock funds for longer than we tolerate. Otherwise, we refuse
+		// to reveal the preimage.
+		currentHeight, err := p.server.bio.GetCurrentHeight()
+		if err != nil {
+			peerLog.Errorf("unable to get current height: %v", err)
+			return
 		}
+		failure, reason := validateIncomingExpiry(htlcPkt.Expiry,
+			uint32(currentHeight), invoice.finalCltvDelta,
+			uint32(cfg.MaxCltvExpiry))
+		if failure != nil {
+			refuseHTLC(failure, reason)
+			return
+		}
+
+		// If the invoice requires a payment secret, then the HTLC must
+		// carry a matching secret within its payload, otherwise the
+		// sender may merely be probing whether we know of the payment
+		// hash.
+		if invoice.paymentAddr != zeroPaymentAddr {
+			validSecret := payloadErr == nil &&
+				subtle.ConstantTimeCompare(
+					payload.PaymentSecret[:],
+					invoice.paymentAddr[:]) == 1
+			if !validSecret {
+				refuseHTLC(&lnwire.FailureMessage{
+					Code:   lnwire.CodeIncorrectPaymentDetails,
+					Amount: htlcPkt.Amount,
+				}, "invalid payment secret")
+				return
+			}
+		}
+
+		invCopy := *invoice
+		invCopy.value = btcutil.Amount(htlcPkt.Amount)
+		if payloadErr == nil {
+			invCopy.customRecords = payload.CustomRecords
+		}
+		state.htlcsToSettle[index] = invCopy
 	case *lnwire.HTLCSettleRequest:
 		// TODO(roasbeef): this assumes no "multi-sig"
 		pre := htlcPkt.RedemptionProofs[0]
@@ -1073,6 +1406,38 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			p.Disconnect()
 			return
 		}
+
+		settleEvent := &htlcEvent{
+			eventType:           htlcEventSettle,
+			outgoingChanPoint:   state.chanPoint,
+			outgoingShortChanID: state.shortChanID,
+			paymentHash:         fastsha256.Sum256(pre[:]),
+		}
+		if payment, ok := state.clearedHTCLs[idx]; ok {
+			settleEvent.amt = btcutil.Amount(payment.htlc.Amount)
+		}
+		p.server.htlcNotifier.notify(settleEvent)
+
+		// Record the preimage with the beacon, so it's available to
+		// the on-chain resolution of the HTLC should it be needed.
+		if err := p.server.beacon.AddPreimage(pre); err != nil {
+			peerLog.Errorf("unable to add preimage: %v", err)
+		}
+
+		// The destination has revealed the preimage for one of our
+		// outgoing payments, so we can mark it as succeeded.
+		p.server.paymentCtrl.settlePayment(pre)
+	case *lnwire.HTLCAddReject:
+		// The remote peer has refused to settle one of our outgoing
+		// HTLC's, so we remove it from our state machine, holding onto
+		// the encrypted reason until the removal has been locked in.
+		idx := uint32(htlcPkt.HTLCKey)
+		if err := state.channel.ReceiveFailHTLC(idx); err != nil {
+			peerLog.Errorf("fail for outgoing HTLC rejected: %v", err)
+			p.Disconnect()
+			return
+		}
+		state.failedHTLCs[idx] = encryptedFailure(htlcPkt.Reason)
 	case *lnwire.CommitSignature:
 		// We just received a new update to our local commitment chain,
 		// validate this new commitment, closing the link if invalid.
@@ -1117,6 +1482,14 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			return
 		}
 
+		// Unless this revocation merely extends our revocation window,
+		// it revokes the oldest of the commitments we've signed.
+		if htlcPkt.Revocation != zeroRevocation &&
+			len(state.pendingSigs) > 0 {
+
+			state.pendingSigs = state.pendingSigs[1:]
+		}
+
 		// We perform the HTLC forwarding to the switch in a distinct
 		// goroutine in order not to block the post-processing of
 		// HTLC's that are eligble for forwarding.
@@ -1134,18 +1507,47 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 		// If any of the htlc's eligible for forwarding are pending
 		// settling or timeing out previous outgoing payments, then we
 		// can them from the pending set, and signal the requster (if
-		// existing) that the payment has been fully fulfilled.
+		// existing) that the payment has been fully fulfilled, or
+		// failed. The value of a failed HTLC returns to our available
+		// bandwidth.
 		var bandwidthUpdate btcutil.Amount
-		numSettled := 0
+		numSettled, numFailed := 0, 0
 		for _, htlc := range htlcsToForward {
-			if p, ok := state.clearedHTCLs[htlc.ParentIndex]; ok {
-				p.err <- nil
-				delete(state.clearedHTCLs, htlc.ParentIndex)
-			}
-
 			// TODO(roasbeef): rework log entries to a shared
 			// interface.
 			if htlc.EntryType != lnwallet.Add {
+				var paymentErr error
+				if htlc.EntryType == lnwallet.Timeout {
+					paymentErr = state.failedHTLCs[htlc.ParentIndex]
+					delete(state.failedHTLCs, htlc.ParentIndex)
+					bandwidthUpdate += htlc.Amount
+				}
+
+				if p, ok := state.clearedHTCLs[htlc.ParentIndex]; ok {
+					p.err <- paymentErr
+					delete(state.clearedHTCLs, htlc.ParentIndex)
+				}
+				continue
+			}
+
+			// If we've refused to settle this HTLC, then we fail
+			// it within our local state update log, then send the
+			// failure to the remote party.
+			if reason, ok := state.htlcsToFail[htlc.Index]; ok {
+				if err := state.channel.FailHTLC(htlc.Index); err != nil {
+					peerLog.Errorf("unable to fail htlc: %v", err)
+					p.Disconnect()
+					continue
+				}
+
+				p.queueMsg(&lnwire.HTLCAddReject{
+					ChannelPoint: state.chanPoint,
+					HTLCKey:      lnwire.HTLCKey(htlc.Index),
+					Reason:       reason,
+				}, nil)
+				delete(state.htlcsToFail, htlc.Index)
+
+				numFailed++
 				continue
 			}
 
@@ -1174,15 +1576,20 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 			p.queueMsg(settleMsg, nil)
 			delete(state.htlcsToSettle, htlc.Index)
 
+			p.server.htlcNotifier.notify(&htlcEvent{
+				eventType:           htlcEventSettle,
+				incomingChanPoint:   state.chanPoint,
+				incomingShortChanID: state.shortChanID,
+				amt:                 invoice.value,
+				paymentHash:         [32]byte(htlc.RHash),
+				customRecords:       invoice.customRecords,
+			})
+
 			bandwidthUpdate += invoice.value
 
 			numSettled++
 		}
 
-		if numSettled == 0 {
-			return
-		}
-
 		// Send an update to the htlc switch of our newly available
 		// payment bandwidth.
 		// TODO(roasbeef): ideally should wait for next state update.
@@ -1191,8 +1598,12 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 				bandwidthUpdate)
 		}
 
-		// With all the settle updates added to the local and remote
-		// HTLC logs, initiate a state transition by updating the
+		if numSettled == 0 && numFailed == 0 {
+			return
+		}
+
+		// With all the settle and fail updates added to the local and
+		// remote HTLC logs, initiate a state transition by updating the
 		// remote commitment chain.
 		if sent, err := p.updateCommitTx(state); err != nil {
 			peerLog.Errorf("unable to update commitment: %v", err)
@@ -1205,6 +1616,45 @@ func (p *peer) handleUpstreamMsg(state *commitmentState, msg lnwire.Message) {
 	}
 }
 
+// validateIncomingExpiry returns the failure an HTLC offered to us with the
+// passed expiry should be refused with, along with the reason for the
+// failure, or nil if the expiry is acceptable. At least finalCltvDelta blocks
+// must remain until the HTLC expires, yet it may not expire more than
+// maxCltvExpiry blocks from now.
+func validateIncomingExpiry(expiry, currentHeight, finalCltvDelta,
+	maxCltvExpiry uint32) (*lnwire.FailureMessage, string) {
+
+	if maxExpiry := currentHeight + maxCltvExpiry; expiry > maxExpiry {
+		return &lnwire.FailureMessage{Code: lnwire.CodeExpiryTooFar},
+			fmt.Sprintf("expiry of %v is too far, allow at most %v",
+				expiry, maxExpiry)
+	}
+
+	if minExpiry := currentHeight + finalCltvDelta; expiry < minExpiry {
+		return &lnwire.FailureMessage{Code: lnwire.CodeFinalExpiryTooSoon},
+			fmt.Sprintf("expiry of %v is too soon, require at "+
+				"least %v", expiry, minExpiry)
+	}
+
+	return nil, ""
+}
+
+// notifyIncomingLinkFail notifies HTLC event subscribers that we've refused to
+// settle an HTLC offered to us by the remote peer.
+func (p *peer) notifyIncomingLinkFail(state *commitmentState,
+	htlc *lnwire.HTLCAddRequest, code lnwire.FailCode, reason string) {
+
+	p.server.htlcNotifier.notify(&htlcEvent{
+		eventType:           htlcEventLinkFail,
+		incomingChanPoint:   state.chanPoint,
+		incomingShortChanID: state.shortChanID,
+		amt:                 btcutil.Amount(htlc.Amount),
+		paymentHash:         htlc.RedemptionHashes[0],
+		failureCode:         code,
+		failureReason:       reason,
+	})
+}
+
 // updateCommitTx signs, then sends an update to the remote peer adding a new
 // commitment to their commitment chain which includes all the latest updates
 // we've received+processed up to this point.
@@ -1229,6 +1679,7 @@ func (p *peer) updateCommitTx(state *commitmentState) (bool, error) {
 		LogIndex:     uint64(logIndexTheirs),
 	}
 	p.queueMsg(commitSig, nil)
+	state.pendingSigs = append(state.pendingSigs, time.Now())
 
 	// Move all pending updates to the map of cleared HTLC's, clearing out
 	// the set of pending updates.
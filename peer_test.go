@@ -1 +1,42 @@
 package main
+
+import (
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestValidateIncomingExpiry tests that an HTLC offered to us is refused if
+// it expires too soon for us to claim it, or too far in the future.
+func TestValidateIncomingExpiry(t *testing.T) {
+	const (
+		currentHeight  = 100
+		finalCltvDelta = 9
+		maxCltvExpiry  = 1000
+	)
+
+	tests := []struct {
+		expiry uint32
+		code   lnwire.FailCode
+	}{
+		{expiry: currentHeight + finalCltvDelta - 1,
+			code: lnwire.CodeFinalExpiryTooSoon},
+		{expiry: currentHeight + finalCltvDelta},
+		{expiry: currentHeight + maxCltvExpiry},
+		{expiry: currentHeight + maxCltvExpiry + 1,
+			code: lnwire.CodeExpiryTooFar},
+	}
+	for _, test := range tests {
+		failure, _ := validateIncomingExpiry(test.expiry,
+			currentHeight, finalCltvDelta, maxCltvExpiry)
+
+		code := lnwire.CodeNone
+		if failure != nil {
+			code = failure.Code
+		}
+		if code != test.code {
+			t.Fatalf("expected %v for expiry %v, got %v",
+				test.code, test.expiry, code)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// peerEventType denotes whether a peerEvent signals a peer coming online or
+// going offline.
+type peerEventType uint8
+
+const (
+	// peerEventOnline indicates that a connection to the peer has been
+	// established.
+	peerEventOnline peerEventType = iota
+
+	// peerEventOffline indicates that our connection to the peer has been
+	// torn down.
+	peerEventOffline
+)
+
+// peerEvent describes a peer connecting to, or disconnecting from the daemon.
+type peerEvent struct {
+	eventType peerEventType
+
+	lightningID wire.ShaHash
+	peerID      int32
+	address     string
+
+	timestamp time.Time
+}
+
+// peerEventSubscription is a client subscription to all peer events. Each
+// event is delivered over the events channel.
+type peerEventSubscription struct {
+	id uint64
+
+	events chan *peerEvent
+
+	cancel func()
+}
+
+// peerNotifier dispatches peer events from the server to all subscribed
+// clients. Events are delivered on a best-effort basis: slow subscribers miss
+// events rather than stalling the server.
+type peerNotifier struct {
+	subscribers   map[uint64]*peerEventSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newPeerNotifier creates a new peerNotifier without any subscribers.
+func newPeerNotifier() *peerNotifier {
+	return &peerNotifier{
+		subscribers: make(map[uint64]*peerEventSubscription),
+	}
+}
+
+// subscribe creates a new subscription to all peer events which occur after
+// this call returns.
+func (n *peerNotifier) subscribe() *peerEventSubscription {
+	n.subscriberMtx.Lock()
+	defer n.subscriberMtx.Unlock()
+
+	clientID := n.nextClientID
+	n.nextClientID++
+
+	sub := &peerEventSubscription{
+		id:     clientID,
+		events: make(chan *peerEvent, 20),
+	}
+	sub.cancel = func() {
+		n.subscriberMtx.Lock()
+		delete(n.subscribers, clientID)
+		n.subscriberMtx.Unlock()
+	}
+	n.subscribers[clientID] = sub
+
+	return sub
+}
+
+// notify sends an event of the passed type for the target peer to all
+// current subscribers.
+func (n *peerNotifier) notify(eventType peerEventType, p *peer) {
+	n.subscriberMtx.Lock()
+	defer n.subscriberMtx.Unlock()
+
+	if len(n.subscribers) == 0 {
+		return
+	}
+
+	event := &peerEvent{
+		eventType:   eventType,
+		lightningID: p.lightningID,
+		peerID:      p.id,
+		address:     p.conn.RemoteAddr().String(),
+		timestamp:   time.Now(),
+	}
+	for _, sub := range n.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			srvrLog.Warnf("Dropping peer event for slow "+
+				"subscriber %v", sub.id)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lndc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestPeerEvents tests that subscribers are notified once of each peer the
+// server adds and removes, and that cancelled subscribers aren't notified at
+// all.
+func TestPeerEvents(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "peernotifier")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	graph, err := channeldb.OpenGraph(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to open graph: %v", err)
+	}
+	defer graph.Close()
+
+	s := &server{
+		chanGraph:       graph,
+		peerNotifier:    newPeerNotifier(),
+		onionMessenger:  newOnionMessenger(nil),
+		peers:           make(map[int32]*peer),
+		persistentPeers: make(map[wire.ShaHash]*lndc.LNAdr),
+	}
+
+	sub := s.peerNotifier.subscribe()
+	defer sub.cancel()
+	cancelledSub := s.peerNotifier.subscribe()
+	cancelledSub.cancel()
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	p := &peer{
+		conn:           &mockConn{remoteAddr: addr},
+		server:         s,
+		id:             1,
+		lightningID:    wire.ShaHash{1},
+		remoteFeatures: lnwire.NewFeatureVector(),
+		quit:           make(chan struct{}),
+	}
+
+	// assertEvent asserts that the next event describes the peer going
+	// online or offline, as expected.
+	assertEvent := func(eventType peerEventType) {
+		select {
+		case event := <-sub.events:
+			if event.eventType != eventType {
+				t.Fatalf("expected event type %v, got %v",
+					eventType, event.eventType)
+			}
+			if event.lightningID != p.lightningID ||
+				event.peerID != p.id {
+
+				t.Fatalf("event for the wrong peer")
+			}
+			if event.address != addr.String() {
+				t.Fatalf("expected address %v, got %v", addr,
+					event.address)
+			}
+		default:
+			t.Fatalf("no event of type %v", eventType)
+		}
+	}
+
+	s.addPeer(p)
+	assertEvent(peerEventOnline)
+	s.removePeer(p)
+	assertEvent(peerEventOffline)
+
+	// Removing the peer again must not signal that it went offline twice.
+	s.removePeer(p)
+	select {
+	case <-sub.events:
+		t.Fatalf("duplicate offline event")
+	default:
+	}
+
+	if len(cancelledSub.events) != 0 {
+		t.Fatalf("events sent to cancelled subscriber")
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/lndc"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// persistentPeerBackoff is the time we wait after a failed attempt to
+	// connect to a persistent peer before trying again. The delay doubles
+	// after each consecutive failure.
+	persistentPeerBackoff = 5 * time.Second
+
+	// maxPersistentPeerBackoff is the longest we'll wait between attempts
+	// to connect to a persistent peer.
+	maxPersistentPeerBackoff = 5 * time.Minute
+)
+
+// parsePersistentPeers parses the addresses of the peers passed via the
+// configuration, each given as <pubkey>@<host>, into a table keyed by the
+// lightning ID of each peer.
+func parsePersistentPeers(addrs []string) (map[wire.ShaHash]*lndc.LNAdr, error) {
+	table := make(map[wire.ShaHash]*lndc.LNAdr)
+	for _, s := range addrs {
+		addr, err := lndc.LnAddrFromString(s, activeNetParams.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer address %v: %v", s,
+				err)
+		}
+
+		// The brontide handshake is encrypted to the static public key
+		// of the remote node, so the key hash alone isn't enough to
+		// dial the peer.
+		if addr.PubKey == nil {
+			return nil, fmt.Errorf("peer address %v must identify "+
+				"the peer by its public key", s)
+		}
+
+		peerID := wire.ShaHash(fastsha256.Sum256(
+			addr.PubKey.SerializeCompressed(),
+		))
+		if _, ok := table[peerID]; ok {
+			return nil, fmt.Errorf("duplicate peer address for %x",
+				peerID[:])
+		}
+
+		table[peerID] = addr
+	}
+
+	return table, nil
+}
+
+// connectPersistentPeer dials the persistent peer with the passed lightning ID
+// until a connection is established, either by us or by the peer itself,
+// backing off between failed attempts. Only a single dialer runs for each
+// peer at a time.
+func (s *server) connectPersistentPeer(peerID wire.ShaHash) {
+	addr, ok := s.persistentPeers[peerID]
+	if !ok {
+		return
+	}
+
+	s.persistentMtx.Lock()
+	if _, ok := s.persistentDials[peerID]; ok {
+		s.persistentMtx.Unlock()
+		return
+	}
+	s.persistentDials[peerID] = struct{}{}
+	s.persistentMtx.Unlock()
+
+	go func() {
+		defer func() {
+			s.persistentMtx.Lock()
+			delete(s.persistentDials, peerID)
+			s.persistentMtx.Unlock()
+		}()
+
+		backoff := persistentPeerBackoff
+		for {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+
+			// The peer may have connected to us while we were
+			// backing off, in which case there's nothing left to
+			// do.
+			for _, p := range s.Peers() {
+				if p.lightningID == peerID {
+					return
+				}
+			}
+
+			_, err := s.ConnectToPeer(addr)
+			if err == nil {
+				return
+			}
+
+			srvrLog.Errorf("unable to connect to persistent peer "+
+				"%v: %v, retrying in %v", addr, err, backoff)
+
+			select {
+			case <-time.After(backoff):
+			case <-s.quit:
+				return
+			}
+
+			backoff *= 2
+			if backoff > maxPersistentPeerBackoff {
+				backoff = maxPersistentPeerBackoff
+			}
+		}
+	}()
+}
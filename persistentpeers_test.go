@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/lndc"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestParsePersistentPeers tests that persistent peers are keyed by their
+// lightning ID, and that malformed and duplicate addresses, as well as those
+// lacking the peer's public key, are refused.
+func TestParsePersistentPeers(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	peerID := wire.ShaHash(fastsha256.Sum256(pubKey))
+	peerAddr := hex.EncodeToString(pubKey) + "@127.0.0.1:10011"
+
+	pkhAddr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(pubKey),
+		activeNetParams.Params)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	table, err := parsePersistentPeers([]string{peerAddr})
+	if err != nil {
+		t.Fatalf("unable to parse peers: %v", err)
+	}
+	if len(table) != 1 {
+		t.Fatalf("expected 1 peer, got %v", len(table))
+	}
+	addr, ok := table[peerID]
+	if !ok {
+		t.Fatalf("peer %x not found", peerID[:])
+	}
+	if addr.NetAddr.String() != "127.0.0.1:10011" {
+		t.Fatalf("expected host 127.0.0.1:10011, got %v", addr.NetAddr)
+	}
+
+	invalidAddrs := [][]string{
+		{"127.0.0.1:10011"},
+		{hex.EncodeToString(pubKey)},
+		{pkhAddr.EncodeAddress() + "@127.0.0.1:10011"},
+		{peerAddr, hex.EncodeToString(pubKey) + "@127.0.0.1:10012"},
+	}
+	for _, addrs := range invalidAddrs {
+		if _, err := parsePersistentPeers(addrs); err == nil {
+			t.Fatalf("invalid peer addresses %v accepted", addrs)
+		}
+	}
+}
+
+// TestConnectPersistentPeer tests that a persistent peer is only dialed if
+// it's not already connected, and that a single dialer runs for each peer at
+// a time.
+func TestConnectPersistentPeer(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	peerID := wire.ShaHash(fastsha256.Sum256(pubKey))
+	addr, err := lndc.LnAddrFromString(hex.EncodeToString(pubKey)+
+		"@127.0.0.1:10011", activeNetParams.Params)
+	if err != nil {
+		t.Fatalf("unable to parse address: %v", err)
+	}
+
+	s := &server{
+		persistentPeers: map[wire.ShaHash]*lndc.LNAdr{peerID: addr},
+		persistentDials: make(map[wire.ShaHash]struct{}),
+		queries:         make(chan interface{}),
+		quit:            make(chan struct{}),
+	}
+	defer close(s.quit)
+
+	// nextQuery returns the next query sent to the server by the dialer.
+	nextQuery := func() interface{} {
+		select {
+		case msg := <-s.queries:
+			return msg
+		case <-time.After(time.Second * 5):
+			t.Fatalf("dialer sent no query")
+		}
+		return nil
+	}
+	assertNoQuery := func() {
+		select {
+		case msg := <-s.queries:
+			t.Fatalf("unexpected query %T", msg)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	waitForDialer := func() {
+		for i := 0; i < 50; i++ {
+			s.persistentMtx.Lock()
+			numDials := len(s.persistentDials)
+			s.persistentMtx.Unlock()
+			if numDials == 0 {
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		t.Fatalf("dialer didn't exit")
+	}
+
+	// A peer we're not to remain connected to should never be dialed.
+	s.connectPersistentPeer(wire.ShaHash{1})
+	assertNoQuery()
+
+	// With the peer offline, the dialer should connect to its address.
+	s.connectPersistentPeer(peerID)
+	nextQuery().(*listPeersMsg).resp <- nil
+	connectMsg := nextQuery().(*connectPeerMsg)
+	if connectMsg.addr != addr {
+		t.Fatalf("expected dial to %v, got %v", addr, connectMsg.addr)
+	}
+
+	// While the first dial is in flight, no other dialer should start.
+	s.connectPersistentPeer(peerID)
+	assertNoQuery()
+
+	connectMsg.resp <- 1
+	connectMsg.err <- nil
+	waitForDialer()
+	assertNoQuery()
+
+	// Once the peer has connected to us, it shouldn't be dialed at all.
+	s.connectPersistentPeer(peerID)
+	nextQuery().(*listPeersMsg).resp <- []*peer{{lightningID: peerID}}
+	waitForDialer()
+	assertNoQuery()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// parsePeerPolicy parses a single per-peer forwarding policy of the form
+// <lightning_id>,<fee_base>,<fee_rate>,<time_lock_delta>, returning the
+// lightning ID of the peer along with the policy to apply to new channels
+// opened with them.
+func parsePeerPolicy(s string) (wire.ShaHash, *channeldb.ChannelEdgePolicy, error) {
+	var peerID wire.ShaHash
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return peerID, nil, fmt.Errorf("invalid peer policy %q, "+
+			"expected <lightning_id>,<fee_base>,<fee_rate>,"+
+			"<time_lock_delta>", s)
+	}
+
+	id, err := hex.DecodeString(parts[0])
+	if err != nil || len(id) != len(peerID) {
+		return peerID, nil, fmt.Errorf("invalid lightning id %q in "+
+			"peer policy", parts[0])
+	}
+	copy(peerID[:], id)
+
+	feeBase, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || feeBase < 0 {
+		return peerID, nil, fmt.Errorf("invalid fee base %q in peer "+
+			"policy", parts[1])
+	}
+	feeRate, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return peerID, nil, fmt.Errorf("invalid fee rate %q in peer "+
+			"policy", parts[2])
+	}
+	timeLockDelta, err := strconv.ParseUint(parts[3], 10, 32)
+	if err != nil || timeLockDelta == 0 {
+		return peerID, nil, fmt.Errorf("invalid time lock delta %q "+
+			"in peer policy", parts[3])
+	}
+
+	return peerID, &channeldb.ChannelEdgePolicy{
+		TimeLockDelta: uint32(timeLockDelta),
+		MinHTLC:       defaultMinHTLC,
+		FeeBase:       btcutil.Amount(feeBase),
+		FeeRate:       uint32(feeRate),
+	}, nil
+}
+
+// parsePeerPolicies parses the set of per-peer forwarding policies passed
+// via the configuration into a table keyed by the lightning ID of each peer.
+func parsePeerPolicies(policies []string) (map[wire.ShaHash]*channeldb.ChannelEdgePolicy, error) {
+	table := make(map[wire.ShaHash]*channeldb.ChannelEdgePolicy)
+	for _, s := range policies {
+		peerID, policy, err := parsePeerPolicy(s)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := table[peerID]; ok {
+			return nil, fmt.Errorf("duplicate peer policy for %x",
+				peerID[:])
+		}
+
+		table[peerID] = policy
+	}
+
+	return table, nil
+}
+
+// channelPolicy returns the forwarding policy to apply to a new channel with
+// the target peer. A policy specified when opening the channel takes
+// precedence, followed by the peer's entry within the per-peer policy table,
+// falling back to the node's default policy.
+func (s *server) channelPolicy(peerID wire.ShaHash,
+	override *channeldb.ChannelEdgePolicy) *channeldb.ChannelEdgePolicy {
+
+	s.policyMtx.RLock()
+	peerPolicy, ok := s.peerPolicies[peerID]
+	s.policyMtx.RUnlock()
+
+	var policy channeldb.ChannelEdgePolicy
+	switch {
+	case override != nil:
+		policy = *override
+	case ok:
+		policy = *peerPolicy
+	default:
+		policy = *defaultChannelPolicy()
+	}
+	policy.LastUpdate = time.Now()
+
+	return &policy
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// preimageSubscription is a client's subscription to the preimage of a
+// single payment hash. The preimage is sent over the preimage channel once
+// it's learned.
+type preimageSubscription struct {
+	id          uint64
+	paymentHash [32]byte
+
+	preimage chan [32]byte
+
+	cancel func()
+}
+
+// preimageBeacon is the single source of HTLC preimages shared between the
+// off-chain and on-chain parts of the daemon. Preimages learned off-chain,
+// as the remote peer settles one of our HTLCs, are made available to the
+// on-chain resolution of incoming HTLCs, while preimages revealed on-chain
+// by the remote party claiming one of our HTLC outputs are made available
+// off-chain. All learned preimages are persisted, so a force close in the
+// midst of a payment never loses an HTLC we're able to claim.
+type preimageBeacon struct {
+	invoices *invoiceRegistry
+	db       *channeldb.DB
+
+	subscribers   map[[32]byte]map[uint64]*preimageSubscription
+	nextClientID  uint64
+	subscriberMtx sync.Mutex
+}
+
+// newPreimageBeacon creates a new preimageBeacon which looks up preimages
+// within the passed invoice registry, along with those persisted within the
+// database.
+func newPreimageBeacon(invoices *invoiceRegistry,
+	db *channeldb.DB) *preimageBeacon {
+
+	return &preimageBeacon{
+		invoices:    invoices,
+		db:          db,
+		subscribers: make(map[[32]byte]map[uint64]*preimageSubscription),
+	}
+}
+
+// LookupPreimage returns the preimage of the passed payment hash if it's
+// known to the daemon, either as the preimage of one of our invoices, or as
+// a preimage learned from the network.
+func (p *preimageBeacon) LookupPreimage(paymentHash wire.ShaHash) ([32]byte, bool) {
+	if invoice, ok := p.invoices.lookupInvoice(paymentHash); ok {
+		return invoice.paymentPreimage, true
+	}
+
+	preimage, err := p.db.LookupPreimage(paymentHash)
+	switch {
+	case err == channeldb.ErrPreimageNotFound:
+		return preimage, false
+	case err != nil:
+		srvrLog.Errorf("unable to lookup preimage of %x: %v",
+			paymentHash[:], err)
+		return preimage, false
+	}
+
+	return preimage, true
+}
+
+// AddPreimage persists a newly learned preimage, then delivers it to each
+// client subscribed to its payment hash.
+func (p *preimageBeacon) AddPreimage(preimage [32]byte) error {
+	if err := p.db.AddPreimage(preimage); err != nil {
+		return err
+	}
+
+	paymentHash := fastsha256.Sum256(preimage[:])
+
+	p.subscriberMtx.Lock()
+	defer p.subscriberMtx.Unlock()
+
+	for _, sub := range p.subscribers[paymentHash] {
+		select {
+		case sub.preimage <- preimage:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// SubscribePreimage creates a new subscription to the preimage of the passed
+// payment hash. If the preimage is already known, then it's delivered
+// immediately. The preimage is delivered at most once.
+func (p *preimageBeacon) SubscribePreimage(paymentHash [32]byte) *preimageSubscription {
+	p.subscriberMtx.Lock()
+
+	clientID := p.nextClientID
+	p.nextClientID++
+
+	sub := &preimageSubscription{
+		id:          clientID,
+		paymentHash: paymentHash,
+		preimage:    make(chan [32]byte, 1),
+	}
+	sub.cancel = func() {
+		p.subscriberMtx.Lock()
+		delete(p.subscribers[paymentHash], clientID)
+		if len(p.subscribers[paymentHash]) == 0 {
+			delete(p.subscribers, paymentHash)
+		}
+		p.subscriberMtx.Unlock()
+	}
+
+	if _, ok := p.subscribers[paymentHash]; !ok {
+		p.subscribers[paymentHash] = make(map[uint64]*preimageSubscription)
+	}
+	p.subscribers[paymentHash][clientID] = sub
+
+	p.subscriberMtx.Unlock()
+
+	// The preimage may have been learned before the subscription was
+	// registered, in which case we deliver it right away.
+	if preimage, ok := p.LookupPreimage(paymentHash); ok {
+		select {
+		case sub.preimage <- preimage:
+		default:
+		}
+	}
+
+	return sub
+}
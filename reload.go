@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	flags "github.com/btcsuite/go-flags"
+	"github.com/roasbeef/btcutil"
+)
+
+// readReloadableConfig reads the passed configuration file, followed by the
+// command line options which take precedence as they do at startup. As at
+// startup, a missing configuration file is ignored. Only the options which may
+// be changed while the daemon is running are to be consulted within the
+// returned configuration.
+func readReloadableConfig(configFile string, args []string) (*config, error) {
+	newCfg := config{
+		ConfigFile: configFile,
+		DebugLevel: defaultLogLevel,
+	}
+	err := flags.IniParse(configFile, &newCfg)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if _, err := flags.ParseArgs(&newCfg, args); err != nil {
+		return nil, err
+	}
+
+	return &newCfg, nil
+}
+
+// reloadConfig re-reads the configuration, applying the options which may be
+// changed without restarting the daemon. The configuration now in effect is
+// returned.
+func (s *server) reloadConfig() (*config, error) {
+	s.reloadMtx.Lock()
+	defer s.reloadMtx.Unlock()
+
+	newCfg, err := readReloadableConfig(cfg.ConfigFile, os.Args[1:])
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyConfig(newCfg); err != nil {
+		return nil, err
+	}
+
+	return newCfg, nil
+}
+
+// applyConfig applies the options of the passed configuration which may be
+// changed while the daemon is running: the debug levels, the per-peer
+// forwarding policies of new channels, and the range of sizes of the channels
+// we accept. If any of the options is invalid, then an error is returned and
+// the running configuration is left untouched.
+//
+// NOTE: reloadMtx MUST be held when calling this method.
+func (s *server) applyConfig(newCfg *config) error {
+	peerPolicies, err := parsePeerPolicies(newCfg.PeerPolicies)
+	if err != nil {
+		return err
+	}
+	err = validateChanSizes(newCfg.MinChanSize, newCfg.MaxChanSize)
+	if err != nil {
+		return err
+	}
+
+	// As with startup, all subsystems are reset to the default level
+	// before the new debug levels are applied, so a subsystem removed
+	// from the debug level no longer keeps its old level. Should the new
+	// debug level be invalid, then the current one is restored.
+	setLogLevels(defaultLogLevel)
+	if err := parseAndSetDebugLevels(newCfg.DebugLevel); err != nil {
+		setLogLevels(defaultLogLevel)
+		parseAndSetDebugLevels(s.debugLevel)
+		return err
+	}
+	s.debugLevel = newCfg.DebugLevel
+
+	s.policyMtx.Lock()
+	s.peerPolicies = peerPolicies
+	s.policyMtx.Unlock()
+
+	s.fundingMgr.setChanSizes(btcutil.Amount(newCfg.MinChanSize),
+		btcutil.Amount(newCfg.MaxChanSize))
+
+	srvrLog.Infof("Reloaded configuration: debuglevel=%v, %v peer "+
+		"policies, minchansize=%v, maxchansize=%v", newCfg.DebugLevel,
+		len(peerPolicies), newCfg.MinChanSize, newCfg.MaxChanSize)
+
+	return nil
+}
+
+// reloadHandler reloads the configuration each time a SIGHUP is received.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) reloadHandler() {
+	defer s.wg.Done()
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-hangup:
+			srvrLog.Infof("Received SIGHUP, reloading configuration")
+
+			if _, err := s.reloadConfig(); err != nil {
+				srvrLog.Errorf("unable to reload configuration: %v",
+					err)
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
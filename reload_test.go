@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestReadReloadableConfig tests that the reloadable options are read from
+// the configuration file, with those on the command line taking precedence,
+// and that a missing configuration file is tolerated as it is at startup.
+func TestReadReloadableConfig(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "reload")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	// Without a configuration file, the defaults should be returned.
+	missingFile := filepath.Join(tempDirName, "missing.conf")
+	newCfg, err := readReloadableConfig(missingFile, nil)
+	if err != nil {
+		t.Fatalf("missing config file refused: %v", err)
+	}
+	if newCfg.DebugLevel != defaultLogLevel {
+		t.Fatalf("expected debug level %v, got %v", defaultLogLevel,
+			newCfg.DebugLevel)
+	}
+
+	configFile := filepath.Join(tempDirName, "lnd.conf")
+	err = ioutil.WriteFile(configFile,
+		[]byte("debuglevel=debug\nminchansize=1000\n"), 0600)
+	if err != nil {
+		t.Fatalf("unable to write config file: %v", err)
+	}
+	newCfg, err = readReloadableConfig(configFile,
+		[]string{"--minchansize=2000", "--maxchansize=5000"})
+	if err != nil {
+		t.Fatalf("unable to read config: %v", err)
+	}
+	if newCfg.DebugLevel != "debug" {
+		t.Fatalf("expected debug level debug, got %v",
+			newCfg.DebugLevel)
+	}
+	if newCfg.MinChanSize != 2000 || newCfg.MaxChanSize != 5000 {
+		t.Fatalf("expected channel sizes 2000-5000, got %v-%v",
+			newCfg.MinChanSize, newCfg.MaxChanSize)
+	}
+
+	// An unknown option within the file must fail the reload.
+	err = ioutil.WriteFile(configFile, []byte("bogus=1\n"), 0600)
+	if err != nil {
+		t.Fatalf("unable to write config file: %v", err)
+	}
+	if _, err := readReloadableConfig(configFile, nil); err == nil {
+		t.Fatalf("invalid option accepted")
+	}
+}
+
+// TestApplyConfig tests that the reloadable options are applied to the
+// running daemon, and that should any of them be invalid, none of them are.
+func TestApplyConfig(t *testing.T) {
+	peerID := wire.ShaHash{1}
+	peerPolicy := hex.EncodeToString(peerID[:]) + ",1000,1,144"
+
+	s := &server{
+		fundingMgr: &fundingManager{
+			minChanSize: 100,
+			maxChanSize: 200,
+		},
+		peerPolicies: make(map[wire.ShaHash]*channeldb.ChannelEdgePolicy),
+		debugLevel:   defaultLogLevel,
+	}
+
+	// assertConfig asserts the options in effect within the daemon.
+	assertConfig := func(test, debugLevel string, numPolicies int,
+		minChanSize, maxChanSize btcutil.Amount) {
+
+		if s.debugLevel != debugLevel {
+			t.Fatalf("%s: expected debug level %v, got %v", test,
+				debugLevel, s.debugLevel)
+		}
+		if len(s.peerPolicies) != numPolicies {
+			t.Fatalf("%s: expected %v peer policies, got %v", test,
+				numPolicies, len(s.peerPolicies))
+		}
+		if s.fundingMgr.minChanSize != minChanSize ||
+			s.fundingMgr.maxChanSize != maxChanSize {
+
+			t.Fatalf("%s: expected channel sizes %v-%v, got %v-%v",
+				test, minChanSize, maxChanSize,
+				s.fundingMgr.minChanSize, s.fundingMgr.maxChanSize)
+		}
+	}
+
+	err := s.applyConfig(&config{
+		DebugLevel:   "debug",
+		PeerPolicies: []string{peerPolicy},
+		MinChanSize:  1000,
+		MaxChanSize:  5000,
+	})
+	if err != nil {
+		t.Fatalf("unable to apply config: %v", err)
+	}
+	assertConfig("valid", "debug", 1, 1000, 5000)
+	if _, ok := s.peerPolicies[peerID]; !ok {
+		t.Fatalf("peer policy for %x not applied", peerID[:])
+	}
+
+	// Each configuration below carries a single invalid option, so the
+	// configuration applied above must remain in effect.
+	tests := []struct {
+		name string
+		cfg  config
+	}{
+		{
+			name: "invalid debug level",
+			cfg: config{
+				DebugLevel:  "bogus",
+				MinChanSize: 2000,
+			},
+		},
+		{
+			name: "invalid peer policy",
+			cfg: config{
+				DebugLevel:   "trace",
+				PeerPolicies: []string{"bogus"},
+			},
+		},
+		{
+			name: "negative channel size",
+			cfg: config{
+				DebugLevel:  "trace",
+				MinChanSize: -1,
+			},
+		},
+		{
+			name: "empty channel size range",
+			cfg: config{
+				DebugLevel:  "trace",
+				MinChanSize: 2000,
+				MaxChanSize: 1000,
+			},
+		},
+	}
+	for _, test := range tests {
+		if err := s.applyConfig(&test.cfg); err == nil {
+			t.Fatalf("%s: invalid config applied", test.name)
+		}
+		assertConfig(test.name, "debug", 1, 1000, 5000)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/lnrpc/rescuerpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+	"golang.org/x/net/context"
+)
+
+// rescuePermissions maps each method of the rescuerpc service to the
+// macaroon permission required to call it via the main RPC server.
+var rescuePermissions = map[string]string{
+	"/rescuerpc.Rescue/RescueChannel":   macaroons.PermissionRescue,
+	"/rescuerpc.Rescue/SweepForceClose": macaroons.PermissionRescue,
+}
+
+// rescueRPCServer is a gRPC front end to the fund recovery capabilities of
+// the wallet. It allows the funds of force closed channels to be swept once
+// the channel database has been lost, using the wallet's seed along with the
+// static parameters of each channel.
+type rescueRPCServer struct {
+	wallet *lnwallet.LightningWallet
+	bio    lnwallet.BlockChainIO
+
+	// newSweepAddr returns the address rescued funds are swept to when
+	// the request doesn't specify one.
+	newSweepAddr sweepAddrSource
+}
+
+// A compile time check to ensure that rescueRPCServer fully implements the
+// RescueServer gRPC service.
+var _ rescuerpc.RescueServer = (*rescueRPCServer)(nil)
+
+// newRescueRPCServer creates a new instance of the rescueRPCServer backed by
+// the passed wallet and chain backend. Unless a request specifies otherwise,
+// funds are swept to the addresses returned by the passed source.
+func newRescueRPCServer(wallet *lnwallet.LightningWallet,
+	bio lnwallet.BlockChainIO,
+	newSweepAddr sweepAddrSource) *rescueRPCServer {
+
+	return &rescueRPCServer{
+		wallet:       wallet,
+		bio:          bio,
+		newSweepAddr: newSweepAddr,
+	}
+}
+
+// parseOutPoint parses an outpoint given in the form txid:index.
+func parseOutPoint(s string) (*wire.OutPoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("outpoint %q must be of the form "+
+			"txid:index", s)
+	}
+
+	txid, err := wire.NewShaHashFromStr(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return wire.NewOutPoint(txid, uint32(index)), nil
+}
+
+// rescueChannel parses the passed channel parameters and commitment
+// transaction, then locates our output within the transaction.
+func (r *rescueRPCServer) rescueChannel(in *rescuerpc.ChannelParams,
+	rawCloseTx []byte) (*lnwallet.RescuedOutput, error) {
+
+	if in == nil {
+		return nil, fmt.Errorf("channel params must be specified")
+	}
+	chanPoint, err := parseOutPoint(in.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	localCommitKey, err := btcec.ParsePubKey(in.LocalCommitKey,
+		btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid local commit key: %v", err)
+	}
+	remoteCommitKey, err := btcec.ParsePubKey(in.RemoteCommitKey,
+		btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote commit key: %v", err)
+	}
+
+	closeTx := wire.NewMsgTx()
+	if err := closeTx.Deserialize(bytes.NewReader(rawCloseTx)); err != nil {
+		return nil, fmt.Errorf("unable to decode close tx: %v", err)
+	}
+
+	return r.wallet.RescueChannel(closeTx, &lnwallet.ChannelRescueParams{
+		ChanPoint:       *chanPoint,
+		LocalCommitKey:  localCommitKey,
+		RemoteCommitKey: remoteCommitKey,
+		CsvDelay:        in.CsvDelay,
+		LeaseExpiry:     in.LeaseExpiry,
+		MaxStateNum:     in.MaxStateNum,
+	})
+}
+
+// RescueChannel locates our output within a commitment transaction of the
+// target channel, re-deriving the keys and revocation key of the channel
+// from the wallet's seed. Nothing is swept.
+func (r *rescueRPCServer) RescueChannel(ctx context.Context,
+	in *rescuerpc.RescueRequest) (*rescuerpc.RescueResponse, error) {
+
+	rescued, err := r.rescueChannel(in.Params, in.RawCloseTx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[rescuechannel] found output %v within close of "+
+		"channel %v", rescued.OutPoint, in.Params.ChanPoint)
+
+	resp := &rescuerpc.RescueResponse{
+		LocalMultisigKey:  rescued.LocalMultiSigKey.SerializeCompressed(),
+		RemoteMultisigKey: rescued.RemoteMultiSigKey.SerializeCompressed(),
+		LocalCommit:       rescued.LocalCommit,
+		StateNum:          rescued.StateNum,
+		Outpoint:          rescued.OutPoint.String(),
+		Amount:            rescued.SignDesc.Output.Value,
+		CsvDelay:          rescued.CsvDelay,
+		LeaseExpiry:       rescued.LeaseExpiry,
+	}
+	if rescued.RevocationKey != nil {
+		resp.RevocationKey = rescued.RevocationKey.SerializeCompressed()
+	}
+
+	return resp, nil
+}
+
+// SweepForceClose sweeps our output within a confirmed commitment
+// transaction of the target channel, once any delay has passed. The sweep
+// transaction is only broadcast if requested.
+func (r *rescueRPCServer) SweepForceClose(ctx context.Context,
+	in *rescuerpc.SweepRequest) (*rescuerpc.SweepResponse, error) {
+
+	if in.SatPerByte <= 0 {
+		return nil, fmt.Errorf("sat_per_byte must be positive")
+	}
+
+	var (
+		sweepAddr btcutil.Address
+		err       error
+	)
+	if in.SweepAddr != "" {
+		sweepAddr, err = btcutil.DecodeAddress(in.SweepAddr,
+			activeNetParams.Params)
+	} else {
+		sweepAddr, err = r.newSweepAddr()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rescued, err := r.rescueChannel(in.Params, in.RawCloseTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only outputs of a confirmed commitment transaction are swept, as an
+	// unconfirmed one may still be replaced by another state. As the
+	// chain backend excludes the mempool, this also ensures the output
+	// hasn't been swept already.
+	_, err = r.bio.GetUtxo(&rescued.OutPoint.Hash, rescued.OutPoint.Index)
+	if err != nil {
+		return nil, fmt.Errorf("output %v isn't a confirmed unspent "+
+			"output: %v", rescued.OutPoint, err)
+	}
+
+	sweepTx, err := r.wallet.CreateRescueSweepTx(rescued, sweepAddr,
+		btcutil.Amount(in.SatPerByte))
+	if err != nil {
+		return nil, err
+	}
+
+	var rawTx bytes.Buffer
+	if err := sweepTx.Serialize(&rawTx); err != nil {
+		return nil, err
+	}
+	txid := sweepTx.TxSha()
+
+	if in.Publish {
+		rpcsLog.Infof("[sweepforceclose] broadcasting sweep tx %v of "+
+			"output %v", txid, rescued.OutPoint)
+
+		if err := r.wallet.PublishTransaction(sweepTx); err != nil {
+			return nil, err
+		}
+		err := r.wallet.LabelTransaction(txid, lnwallet.SweepTxLabel,
+			false)
+		if err != nil {
+			rpcsLog.Warnf("unable to label sweep tx: %v", err)
+		}
+	}
+
+	return &rescuerpc.SweepResponse{
+		RawSweepTx: rawTx.Bytes(),
+		SweepTxid:  txid[:],
+	}, nil
+}
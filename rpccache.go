@@ -0,0 +1,90 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// cachedResponse is a single cached RPC response, along with the time it
+// expires and the cache generation it was computed within.
+type cachedResponse struct {
+	sync.Mutex
+
+	resp       interface{}
+	expiry     time.Time
+	generation uint64
+}
+
+// rpcCache caches the responses of read-only RPCs which are expensive to
+// compute, such as those serializing the entire channel graph. Clients
+// polling these RPCs are then served the cached response rather than each
+// call recomputing it. A cached response is served until its TTL has passed,
+// or the graph it was computed from is updated, whichever comes first.
+//
+// NOTE: Cached responses are shared between callers, and so MUST NOT be
+// modified.
+type rpcCache struct {
+	ttl time.Duration
+
+	mtx        sync.Mutex
+	generation uint64
+	responses  map[string]*cachedResponse
+}
+
+// newRPCCache creates a new rpcCache whose responses expire after the passed
+// TTL. A TTL of zero disables caching.
+func newRPCCache(ttl time.Duration) *rpcCache {
+	return &rpcCache{
+		ttl:       ttl,
+		responses: make(map[string]*cachedResponse),
+	}
+}
+
+// fetch returns the cached response stored under the passed key if it's
+// still fresh. Otherwise, the response is recomputed using the passed fill
+// closure, then cached. Concurrent fetches of a stale response wait for a
+// single call to fill, rather than each recomputing the response.
+func (c *rpcCache) fetch(key string,
+	fill func() (interface{}, error)) (interface{}, error) {
+
+	if c.ttl == 0 {
+		return fill()
+	}
+
+	c.mtx.Lock()
+	cached, ok := c.responses[key]
+	if !ok {
+		cached = &cachedResponse{}
+		c.responses[key] = cached
+	}
+	generation := c.generation
+	c.mtx.Unlock()
+
+	cached.Lock()
+	defer cached.Unlock()
+
+	if cached.resp != nil && cached.generation == generation &&
+		time.Now().Before(cached.expiry) {
+
+		return cached.resp, nil
+	}
+
+	resp, err := fill()
+	if err != nil {
+		return nil, err
+	}
+
+	cached.resp = resp
+	cached.expiry = time.Now().Add(c.ttl)
+	cached.generation = generation
+
+	return resp, nil
+}
+
+// invalidate marks every cached response as stale. This should be called
+// each time the channel graph is updated.
+func (c *rpcCache) invalidate() {
+	c.mtx.Lock()
+	c.generation++
+	c.mtx.Unlock()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRPCCache tests that a cached response is served until either its TTL
+// passes or the cache is invalidated, that failed fills aren't cached, and
+// that a TTL of zero disables caching.
+func TestRPCCache(t *testing.T) {
+	c := newRPCCache(time.Hour)
+
+	var numFills int
+	fill := func() (interface{}, error) {
+		numFills++
+		return numFills, nil
+	}
+	assertFetch := func(expected int) {
+		resp, err := c.fetch("graph", fill)
+		if err != nil {
+			t.Fatalf("unable to fetch response: %v", err)
+		}
+		if resp.(int) != expected {
+			t.Fatalf("expected response %v, got %v", expected, resp)
+		}
+	}
+
+	// The first fetch fills the response, which is then served to the
+	// next fetch.
+	assertFetch(1)
+	assertFetch(1)
+
+	// Responses are cached under their own key.
+	if resp, _ := c.fetch("other", fill); resp.(int) != 2 {
+		t.Fatalf("response of other key not filled")
+	}
+
+	// Once the cache is invalidated, the response must be recomputed.
+	c.invalidate()
+	assertFetch(3)
+	assertFetch(3)
+
+	// The same applies once the TTL has passed.
+	c.responses["graph"].expiry = time.Now().Add(-time.Second)
+	assertFetch(4)
+
+	// A failed fill must not replace the cached response, nor be cached
+	// itself.
+	c.invalidate()
+	_, err := c.fetch("graph", func() (interface{}, error) {
+		return nil, errors.New("unable to fill")
+	})
+	if err == nil {
+		t.Fatalf("fill error not returned")
+	}
+	assertFetch(5)
+
+	// Without a TTL, every fetch fills the response.
+	c = newRPCCache(0)
+	assertFetch(6)
+	assertFetch(7)
+}
+
+// TestRPCCacheConcurrentFetch tests that concurrent fetches of a stale
+// response wait for a single fill.
+func TestRPCCacheConcurrentFetch(t *testing.T) {
+	c := newRPCCache(time.Hour)
+
+	var (
+		mtx      sync.Mutex
+		numFills int
+	)
+	release := make(chan struct{})
+	fill := func() (interface{}, error) {
+		mtx.Lock()
+		numFills++
+		mtx.Unlock()
+
+		<-release
+		return struct{}{}, nil
+	}
+
+	const numFetches = 10
+	var wg sync.WaitGroup
+	wg.Add(numFetches)
+	for i := 0; i < numFetches; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := c.fetch("graph", fill); err != nil {
+				t.Errorf("unable to fetch response: %v", err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if numFills != 1 {
+		t.Fatalf("expected a single fill, got %v", numFills)
+	}
+}
@@ -1,17 +1,28 @@
 package main
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
+	"net"
+	"strings"
 
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lndc"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/onionmsg"
+	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
@@ -88,27 +99,45 @@ func addrPairsToOutputs(addrPairs map[string]int64) ([]*wire.TxOut, error) {
 	return outputs, nil
 }
 
+// defaultSendFeeRate is the fee rate, in sat/byte, used for on-chain sends and
+// output consolidation when the caller doesn't specify one.
+// TODO(roasbeef): consult a fee estimator instead
+const defaultSendFeeRate = 10
+
 // sendCoinsOnChain makes an on-chain transaction in or to send coins to one or
 // more addresses specified in the passed payment map. The payment map maps an
-// address to a specified output value to be sent to that address.
-func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64) (*wire.ShaHash, error) {
+// address to a specified output value to be sent to that address. Inputs are
+// selected according to the wallet's configured coin selection strategy.
+func (r *rpcServer) sendCoinsOnChain(paymentMap map[string]int64,
+	satPerByte int64) (*wire.ShaHash, error) {
+
 	outputs, err := addrPairsToOutputs(paymentMap)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.server.lnwallet.SendOutputs(outputs)
+	if satPerByte == 0 {
+		satPerByte = defaultSendFeeRate
+	}
+
+	return r.server.lnwallet.SendCoins(outputs, btcutil.Amount(satPerByte))
 }
 
 // SendCoins executes a request to send coins to a particular address. Unlike
-// SendMany, this RPC call only allows creating a single output at a time.
+// SendMany, this RPC call only allows creating a single output at a time. If
+// SendAll is set, then all of the wallet's confirmed funds are swept to the
+// address instead.
 func (r *rpcServer) SendCoins(ctx context.Context,
 	in *lnrpc.SendCoinsRequest) (*lnrpc.SendCoinsResponse, error) {
 
+	if in.SendAll {
+		return r.sweepCoins(in)
+	}
+
 	rpcsLog.Infof("[sendcoins] addr=%v, amt=%v", in.Addr, btcutil.Amount(in.Amount))
 
 	paymentMap := map[string]int64{in.Addr: in.Amount}
-	txid, err := r.sendCoinsOnChain(paymentMap)
+	txid, err := r.sendCoinsOnChain(paymentMap, in.SatPerByte)
 	if err != nil {
 		return nil, err
 	}
@@ -118,12 +147,48 @@ func (r *rpcServer) SendCoins(ctx context.Context,
 	return &lnrpc.SendCoinsResponse{Txid: txid.String()}, nil
 }
 
+// sweepCoins handles a SendCoins request to sweep all of the wallet's
+// confirmed funds to a single address.
+func (r *rpcServer) sweepCoins(
+	in *lnrpc.SendCoinsRequest) (*lnrpc.SendCoinsResponse, error) {
+
+	if in.Amount != 0 {
+		return nil, fmt.Errorf("amount must not be set when sending " +
+			"all funds")
+	}
+
+	addr, err := btcutil.DecodeAddress(in.Addr, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	satPerByte := in.SatPerByte
+	if satPerByte == 0 {
+		satPerByte = defaultSendFeeRate
+	}
+
+	txid, amt, err := r.server.lnwallet.SweepCoins(pkScript,
+		btcutil.Amount(satPerByte))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[sendcoins] swept %v to addr=%v with txid: %v", amt,
+		in.Addr, txid)
+
+	return &lnrpc.SendCoinsResponse{Txid: txid.String()}, nil
+}
+
 // SendMany handles a request for a transaction create multiple specified
 // outputs in parallel.
 func (r *rpcServer) SendMany(ctx context.Context,
 	in *lnrpc.SendManyRequest) (*lnrpc.SendManyResponse, error) {
 
-	txid, err := r.sendCoinsOnChain(in.AddrToAmount)
+	txid, err := r.sendCoinsOnChain(in.AddrToAmount, in.SatPerByte)
 	if err != nil {
 		return nil, err
 	}
@@ -137,18 +202,7 @@ func (r *rpcServer) SendMany(ctx context.Context,
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
 
-	// Translate the gRPC proto address type to the wallet controller's
-	// available address types.
-	var addrType lnwallet.AddressType
-	switch in.Type {
-	case lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH:
-		addrType = lnwallet.WitnessPubKey
-	case lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH:
-		addrType = lnwallet.NestedWitnessPubKey
-	case lnrpc.NewAddressRequest_PUBKEY_HASH:
-		addrType = lnwallet.PubKeyHash
-	}
-
+	addrType := toWalletAddressType(in.Type)
 	addr, err := r.server.lnwallet.NewAddress(addrType, false)
 	if err != nil {
 		return nil, err
@@ -158,364 +212,2366 @@ func (r *rpcServer) NewAddress(ctx context.Context,
 	return &lnrpc.NewAddressResponse{Address: addr.String()}, nil
 }
 
-// ConnectPeer attempts to establish a connection to a remote peer.
-func (r *rpcServer) ConnectPeer(ctx context.Context,
-	in *lnrpc.ConnectPeerRequest) (*lnrpc.ConnectPeerResponse, error) {
+// pkScriptAddress returns the encoded address the passed pkScript pays to, or
+// an empty string if it doesn't pay to a single standard address.
+func pkScriptAddress(pkScript []byte) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
+		activeNetParams.Params)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
 
-	if in.Addr == nil {
-		return nil, fmt.Errorf("need: lnc pubkeyhash@hostname")
+	return addrs[0].String()
+}
+
+// toRPCAddressType maps the passed wallet address to its RPC address type.
+func toRPCAddressType(addr btcutil.Address) lnrpc.NewAddressRequest_AddressType {
+	switch addr.(type) {
+	case *btcutil.AddressScriptHash:
+		return lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH
+	case *btcutil.AddressPubKeyHash:
+		return lnrpc.NewAddressRequest_PUBKEY_HASH
+	default:
+		return lnrpc.NewAddressRequest_WITNESS_PUBKEY_HASH
 	}
+}
 
-	idAtHost := fmt.Sprintf("%v@%v", in.Addr.PubKeyHash, in.Addr.Host)
-	rpcsLog.Debugf("[connectpeer] peer=%v", idAtHost)
+// ListAddresses returns every address derived by the wallet, along with the
+// balance of the unspent outputs paying to each.
+func (r *rpcServer) ListAddresses(ctx context.Context,
+	in *lnrpc.ListAddressesRequest) (*lnrpc.ListAddressesResponse, error) {
 
-	peerAddr, err := lndc.LnAddrFromString(idAtHost, activeNetParams.Params)
+	addrs, err := r.server.lnwallet.ListAddresses()
 	if err != nil {
-		rpcsLog.Errorf("(connectpeer): error parsing ln addr: %v", err)
 		return nil, err
 	}
 
-	peerID, err := r.server.ConnectToPeer(peerAddr)
+	utxos, err := r.server.lnwallet.ListUnspentWitness(0)
 	if err != nil {
-		rpcsLog.Errorf("(connectpeer): error connecting to peer: %v", err)
 		return nil, err
 	}
+	balances := make(map[string]int64)
+	for _, utxo := range utxos {
+		balances[pkScriptAddress(utxo.PkScript)] += int64(utxo.Value)
+	}
 
-	rpcsLog.Debugf("Connected to peer: %v", peerAddr.String())
-	return &lnrpc.ConnectPeerResponse{peerID}, nil
-}
+	resp := &lnrpc.ListAddressesResponse{
+		Addresses: make([]*lnrpc.WalletAddress, len(addrs)),
+	}
+	for i, addr := range addrs {
+		encodedAddr := addr.Address.String()
+		resp.Addresses[i] = &lnrpc.WalletAddress{
+			Address: encodedAddr,
+			Type:    toRPCAddressType(addr.Address),
+			Change:  addr.Change,
+			Balance: balances[encodedAddr],
+		}
+	}
 
-// OpenChannel attempts to open a singly funded channel specified in the
-// request to a remote peer.
-func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
-	updateStream lnrpc.Lightning_OpenChannelServer) error {
+	rpcsLog.Debugf("[listaddresses] yielded %v addresses", len(addrs))
 
-	rpcsLog.Tracef("[openchannel] request to peerid(%v) "+
-		"allocation(us=%v, them=%v) numconfs=%v", in.TargetPeerId,
-		in.LocalFundingAmount, in.RemoteFundingAmount, in.NumConfs)
+	return resp, nil
+}
 
-	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
-	remoteFundingAmt := btcutil.Amount(in.RemoteFundingAmount)
-	updateChan, errChan := r.server.OpenChannel(in.TargetPeerId,
-		in.TargetNode, localFundingAmt, remoteFundingAmt, in.NumConfs)
+// ListUnspent returns the wallet's unspent outputs whose number of
+// confirmations falls within the requested range.
+func (r *rpcServer) ListUnspent(ctx context.Context,
+	in *lnrpc.ListUnspentRequest) (*lnrpc.ListUnspentResponse, error) {
 
-	var outpoint wire.OutPoint
-out:
-	for {
-		select {
-		case err := <-errChan:
-			rpcsLog.Errorf("unable to open channel to "+
-				"lightningID(%v) nor peerID(%v): %v",
-				in.TargetNode, in.TargetPeerId, err)
-			return err
-		case fundingUpdate := <-updateChan:
-			rpcsLog.Tracef("[openchannel] sending update: %v",
-				fundingUpdate)
-			if err := updateStream.Send(fundingUpdate); err != nil {
-				return err
-			}
+	if in.MinConfs < 0 || in.MaxConfs < 0 {
+		return nil, fmt.Errorf("min_confs and max_confs must not be " +
+			"negative")
+	}
+	if in.MaxConfs != 0 && in.MaxConfs < in.MinConfs {
+		return nil, fmt.Errorf("max_confs must be greater than or " +
+			"equal to min_confs")
+	}
 
-			// If a final channel open update is being sent, then
-			// we can break out of our recv loop as we no longer
-			// need to process any further updates.
-			switch update := fundingUpdate.Update.(type) {
-			case *lnrpc.OpenStatusUpdate_ChanOpen:
-				chanPoint := update.ChanOpen.ChannelPoint
-				h, _ := wire.NewShaHash(chanPoint.FundingTxid)
-				outpoint = wire.OutPoint{
-					Hash:  *h,
-					Index: chanPoint.OutputIndex,
-				}
+	utxos, err := r.server.lnwallet.ListUnspentWitness(in.MinConfs)
+	if err != nil {
+		return nil, err
+	}
 
-				break out
-			}
-		case <-r.quit:
-			return nil
+	resp := &lnrpc.ListUnspentResponse{
+		Utxos: make([]*lnrpc.Utxo, 0, len(utxos)),
+	}
+	for _, utxo := range utxos {
+		if in.MaxConfs != 0 && utxo.Confirmations > int64(in.MaxConfs) {
+			continue
 		}
+
+		resp.Utxos = append(resp.Utxos, &lnrpc.Utxo{
+			Outpoint: &lnrpc.OutPoint{
+				Txid:        utxo.Hash.String(),
+				OutputIndex: utxo.Index,
+			},
+			Address:       pkScriptAddress(utxo.PkScript),
+			Amount:        int64(utxo.Value),
+			PkScript:      hex.EncodeToString(utxo.PkScript),
+			Confirmations: utxo.Confirmations,
+		})
 	}
 
-	rpcsLog.Tracef("[openchannel] success peerid(%v), ChannelPoint(%v)",
-		in.TargetPeerId, outpoint)
-	return nil
+	rpcsLog.Debugf("[listunspent] min_confs=%v, max_confs=%v yielded %v "+
+		"outputs", in.MinConfs, in.MaxConfs, len(resp.Utxos))
+
+	return resp, nil
 }
 
-// CloseChannel attempts to close an active channel identified by its channel
-// point. The actions of this method can additionally be augmented to attempt
-// a force close after a timeout period in the case of an inactive peer.
-func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
-	updateStream lnrpc.Lightning_CloseChannelServer) error {
+// ConsolidateUtxos merges the wallet's small unspent outputs into a single
+// output. This is best done while fees are low, in order to reduce the cost of
+// spending the merged funds later.
+func (r *rpcServer) ConsolidateUtxos(ctx context.Context,
+	in *lnrpc.ConsolidateUtxosRequest) (*lnrpc.ConsolidateUtxosResponse, error) {
 
-	force := in.Force
-	index := in.ChannelPoint.OutputIndex
-	txid, err := wire.NewShaHash(in.ChannelPoint.FundingTxid)
+	if in.MaxUtxoValue <= 0 {
+		return nil, fmt.Errorf("max_utxo_value must be positive")
+	}
+	satPerByte := in.SatPerByte
+	if satPerByte == 0 {
+		satPerByte = defaultSendFeeRate
+	}
+
+	rpcsLog.Infof("[consolidateutxos] max_value=%v, sat_per_byte=%v, "+
+		"min_utxos=%v", btcutil.Amount(in.MaxUtxoValue), satPerByte,
+		in.MinUtxos)
+
+	txid, numInputs, err := r.server.lnwallet.ConsolidateOutputs(
+		btcutil.Amount(in.MaxUtxoValue), btcutil.Amount(satPerByte),
+		int(in.MinUtxos))
 	if err != nil {
-		rpcsLog.Errorf("[closechannel] invalid txid: %v", err)
-		return err
+		return nil, err
 	}
-	targetChannelPoint := wire.NewOutPoint(txid, index)
 
-	rpcsLog.Tracef("[closechannel] request for ChannelPoint(%v)",
-		targetChannelPoint)
+	rpcsLog.Infof("[consolidateutxos] merged %v outputs with txid: %v",
+		numInputs, txid)
 
-	updateChan, errChan := r.server.htlcSwitch.CloseLink(targetChannelPoint, force)
+	return &lnrpc.ConsolidateUtxosResponse{
+		Txid:            txid.String(),
+		NumConsolidated: uint32(numInputs),
+	}, nil
+}
 
-out:
-	for {
-		select {
-		case err := <-errChan:
-			rpcsLog.Errorf("[closechannel] unable to close "+
-				"ChannelPoint(%v): %v", targetChannelPoint, err)
-			return err
-		case closingUpdate := <-updateChan:
-			rpcsLog.Tracef("[closechannel] sending update: %v",
-				closingUpdate)
-			if err := updateStream.Send(closingUpdate); err != nil {
-				return err
-			}
+// parseLeaseRequest decodes the lease ID and target outpoint of a lease
+// related RPC request.
+func parseLeaseRequest(id []byte,
+	op *lnrpc.OutPoint) (lnwallet.LeaseID, *wire.OutPoint, error) {
 
-			// If a final channel closing updates is being sent,
-			// then we can break out of our dispatch loop as we no
-			// longer need to process any further updates.
-			switch closeUpdate := closingUpdate.Update.(type) {
-			case *lnrpc.CloseStatusUpdate_ChanClose:
-				h, _ := wire.NewShaHash(closeUpdate.ChanClose.ClosingTxid)
-				rpcsLog.Infof("[closechannel] close completed: "+
-					"txid(%v)", h)
-				break out
-			}
-		case <-r.quit:
-			return nil
-		}
+	var leaseID lnwallet.LeaseID
+	if len(id) != len(leaseID) {
+		return leaseID, nil, fmt.Errorf("lease id must be exactly %v "+
+			"bytes", len(leaseID))
 	}
+	copy(leaseID[:], id)
 
-	return nil
+	if op == nil {
+		return leaseID, nil, fmt.Errorf("outpoint must be specified")
+	}
+	txid, err := wire.NewShaHashFromStr(op.Txid)
+	if err != nil {
+		return leaseID, nil, err
+	}
+
+	return leaseID, wire.NewOutPoint(txid, op.OutputIndex), nil
 }
 
-// GetInfo serves a request to the "getinfo" RPC call. This call returns
-// general information concerning the lightning node including it's LN ID,
-// identity address, and information concerning the number of open+pending
-// channels.
-func (r *rpcServer) GetInfo(ctx context.Context,
-	in *lnrpc.GetInfoRequest) (*lnrpc.GetInfoResponse, error) {
+// LeaseOutput locks an output controlled by the wallet for a period of time,
+// excluding it from coin selection. This allows external tools to reserve
+// outputs without them being double spent by lnd's own funding flows.
+func (r *rpcServer) LeaseOutput(ctx context.Context,
+	in *lnrpc.LeaseOutputRequest) (*lnrpc.LeaseOutputResponse, error) {
 
-	var activeChannels uint32
-	serverPeers := r.server.Peers()
-	for _, serverPeer := range serverPeers {
-		activeChannels += uint32(len(serverPeer.ChannelSnapshots()))
+	leaseID, outPoint, err := parseLeaseRequest(in.Id, in.Outpoint)
+	if err != nil {
+		return nil, err
 	}
 
-	pendingChannels := r.server.fundingMgr.NumPendingChannels()
-
-	idPub := r.server.identityPriv.PubKey().SerializeCompressed()
-	idAddr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(idPub), activeNetParams.Params)
+	duration := time.Duration(in.ExpirationSeconds) * time.Second
+	expiration, err := r.server.lnwallet.LeaseOutput(leaseID, *outPoint,
+		duration)
 	if err != nil {
 		return nil, err
 	}
 
-	return &lnrpc.GetInfoResponse{
-		LightningId:        hex.EncodeToString(r.server.lightningID[:]),
-		IdentityAddress:    idAddr.String(),
-		NumPendingChannels: pendingChannels,
-		NumActiveChannels:  activeChannels,
-		NumPeers:           uint32(len(serverPeers)),
+	rpcsLog.Debugf("[leaseoutput] outpoint=%v, id=%x, expiration=%v",
+		outPoint, leaseID[:], expiration)
+
+	return &lnrpc.LeaseOutputResponse{
+		Expiration: expiration.Unix(),
 	}, nil
 }
 
-// ListPeers returns a verbose listing of all currently active peers.
-func (r *rpcServer) ListPeers(ctx context.Context,
-	in *lnrpc.ListPeersRequest) (*lnrpc.ListPeersResponse, error) {
+// ReleaseOutput releases an output previously leased via LeaseOutput.
+func (r *rpcServer) ReleaseOutput(ctx context.Context,
+	in *lnrpc.ReleaseOutputRequest) (*lnrpc.ReleaseOutputResponse, error) {
 
-	rpcsLog.Tracef("[listpeers] request")
+	leaseID, outPoint, err := parseLeaseRequest(in.Id, in.Outpoint)
+	if err != nil {
+		return nil, err
+	}
 
-	serverPeers := r.server.Peers()
-	resp := &lnrpc.ListPeersResponse{
-		Peers: make([]*lnrpc.Peer, 0, len(serverPeers)),
+	if err := r.server.lnwallet.ReleaseOutput(leaseID, *outPoint); err != nil {
+		return nil, err
 	}
 
-	for _, serverPeer := range serverPeers {
-		// TODO(roasbeef): add a snapshot method which grabs peer read mtx
+	rpcsLog.Debugf("[releaseoutput] outpoint=%v, id=%x", outPoint,
+		leaseID[:])
 
-		lnID := hex.EncodeToString(serverPeer.lightningID[:])
-		peer := &lnrpc.Peer{
-			LightningId: lnID,
-			PeerId:      serverPeer.id,
-			Address:     serverPeer.conn.RemoteAddr().String(),
-			Inbound:     serverPeer.inbound,
-			BytesRecv:   atomic.LoadUint64(&serverPeer.bytesReceived),
-			BytesSent:   atomic.LoadUint64(&serverPeer.bytesSent),
-		}
+	return &lnrpc.ReleaseOutputResponse{}, nil
+}
 
-		chanSnapshots := serverPeer.ChannelSnapshots()
-		peer.Channels = make([]*lnrpc.ActiveChannel, 0, len(chanSnapshots))
-		for _, chanSnapshot := range chanSnapshots {
-			channel := &lnrpc.ActiveChannel{
-				RemoteId:      lnID,
-				ChannelPoint:  chanSnapshot.ChannelPoint.String(),
-				Capacity:      int64(chanSnapshot.Capacity),
-				LocalBalance:  int64(chanSnapshot.LocalBalance),
-				RemoteBalance: int64(chanSnapshot.RemoteBalance),
-				NumUpdates:    chanSnapshot.NumUpdates,
-			}
-			peer.Channels = append(peer.Channels, channel)
-		}
+// ListLeases returns all outputs currently leased via LeaseOutput.
+func (r *rpcServer) ListLeases(ctx context.Context,
+	in *lnrpc.ListLeasesRequest) (*lnrpc.ListLeasesResponse, error) {
 
-		resp.Peers = append(resp.Peers, peer)
-	}
+	leases := r.server.lnwallet.ListLeases()
 
-	rpcsLog.Debugf("[listpeers] yielded %v peers", serverPeers)
+	resp := &lnrpc.ListLeasesResponse{
+		Leases: make([]*lnrpc.OutputLease, len(leases)),
+	}
+	for i, lease := range leases {
+		resp.Leases[i] = &lnrpc.OutputLease{
+			Id: lease.ID[:],
+			Outpoint: &lnrpc.OutPoint{
+				Txid:        lease.OutPoint.Hash.String(),
+				OutputIndex: lease.OutPoint.Index,
+			},
+			Expiration: lease.Expiration.Unix(),
+		}
+	}
 
 	return resp, nil
 }
 
-// WalletBalance returns the sum of all confirmed unspent outputs under control
-// by the wallet. This method can be modified by having the request specify
-// only witness outputs should be factored into the final output sum.
-// TODO(roasbeef): split into total and confirmed/unconfirmed
-// TODO(roasbeef): add async hooks into wallet balance changes
-func (r *rpcServer) WalletBalance(ctx context.Context,
-	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
+// marshallTransaction converts a wallet transaction detail into the RPC
+// representation returned by GetTransactions and SubscribeTransactions.
+func marshallTransaction(tx *lnwallet.TransactionDetail) *lnrpc.Transaction {
+	var blockHash string
+	if tx.BlockHash != nil {
+		blockHash = tx.BlockHash.String()
+	}
 
-	balance, err := r.server.lnwallet.ConfirmedBalance(1, in.WitnessOnly)
+	return &lnrpc.Transaction{
+		TxHash:           tx.Hash.String(),
+		Amount:           int64(tx.Value),
+		NumConfirmations: tx.NumConfirmations,
+		BlockHash:        blockHash,
+		BlockHeight:      tx.BlockHeight,
+		TimeStamp:        tx.Timestamp,
+		TotalFees:        tx.TotalFees,
+		Label:            tx.Label,
+		WatchOnly:        tx.WatchOnly,
+	}
+}
+
+// GetTransactions returns a list of describing all the known transactions
+// relevant to the wallet.
+func (r *rpcServer) GetTransactions(ctx context.Context,
+	_ *lnrpc.GetTransactionsRequest) (*lnrpc.TransactionDetails, error) {
+
+	// TODO(roasbeef): add pagination support
+	transactions, err := r.server.lnwallet.ListTransactionDetails()
 	if err != nil {
 		return nil, err
 	}
 
-	rpcsLog.Debugf("[walletbalance] balance=%v", balance)
+	txDetails := &lnrpc.TransactionDetails{
+		Transactions: make([]*lnrpc.Transaction, len(transactions)),
+	}
+	for i, tx := range transactions {
+		txDetails.Transactions[i] = marshallTransaction(tx)
+	}
+
+	rpcsLog.Debugf("[gettransactions] num_txns=%v", len(transactions))
 
-	return &lnrpc.WalletBalanceResponse{balance.ToBTC()}, nil
+	return txDetails, nil
 }
 
-// ChannelBalance returns the total available channel flow across all open
-// channels in satoshis.
-func (r *rpcServer) ChannelBalance(ctx context.Context,
-	in *lnrpc.ChannelBalanceRequest) (*lnrpc.ChannelBalanceResponse, error) {
+// LabelTransaction attaches a free-form label to a transaction relevant to the
+// wallet. The label is subsequently returned alongside the transaction by
+// GetTransactions and SubscribeTransactions.
+func (r *rpcServer) LabelTransaction(ctx context.Context,
+	in *lnrpc.LabelTransactionRequest) (*lnrpc.LabelTransactionResponse, error) {
 
-	var balance btcutil.Amount
-	for _, peer := range r.server.Peers() {
-		for _, snapshot := range peer.ChannelSnapshots() {
-			balance += snapshot.LocalBalance
-		}
+	txid, err := wire.NewShaHashFromStr(in.Txid)
+	if err != nil {
+		return nil, err
 	}
 
-	return &lnrpc.ChannelBalanceResponse{Balance: int64(balance)}, nil
-}
+	rpcsLog.Debugf("[labeltransaction] txid=%v, label=%q, overwrite=%v",
+		txid, in.Label, in.Overwrite)
 
-// PendingChannels returns a list of all the channels that are currently
-// considered "pending". A channel is pending if it has finished the funding
-// workflow and is waiting for confirmations for the funding txn, or is in the
-// process of closure, either initiated cooperatively or non-coopertively.
-func (r *rpcServer) PendingChannels(ctx context.Context,
-	in *lnrpc.PendingChannelRequest) (*lnrpc.PendingChannelResponse, error) {
+	err = r.server.lnwallet.LabelTransaction(*txid, in.Label, in.Overwrite)
+	if err != nil {
+		return nil, err
+	}
 
-	both := in.Status == lnrpc.ChannelStatus_ALL
-	includeOpen := (in.Status == lnrpc.ChannelStatus_OPENING) || both
-	includeClose := (in.Status == lnrpc.ChannelStatus_CLOSING) || both
-	rpcsLog.Debugf("[pendingchannels] %v", in.Status)
+	return &lnrpc.LabelTransactionResponse{}, nil
+}
 
-	var pendingChannels []*lnrpc.PendingChannelResponse_PendingChannel
-	if includeOpen {
-		pendingOpenChans := r.server.fundingMgr.PendingChannels()
-		for _, pendingOpen := range pendingOpenChans {
-			// TODO(roasbeef): add confirmation progress
-			pendingChan := &lnrpc.PendingChannelResponse_PendingChannel{
-				PeerId:        pendingOpen.peerId,
-				LightningId:   hex.EncodeToString(pendingOpen.lightningID[:]),
-				ChannelPoint:  pendingOpen.channelPoint.String(),
-				Capacity:      int64(pendingOpen.capacity),
-				LocalBalance:  int64(pendingOpen.localBalance),
-				RemoteBalance: int64(pendingOpen.remoteBalance),
-				Status:        lnrpc.ChannelStatus_OPENING,
+// SubscribeTransactions creates a uni-directional stream (server -> client) in
+// which any newly discovered transactions relevant to the wallet are sent
+// over. Unconfirmed transactions are sent once they're seen within the
+// mempool, and again once they've been included in a block.
+func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
+	updateStream lnrpc.Lightning_SubscribeTransactionsServer) error {
+
+	txClient, err := r.server.lnwallet.SubscribeTransactions()
+	if err != nil {
+		rpcsLog.Errorf("[subscribetransactions] unable to subscribe "+
+			"to wallet transactions: %v", err)
+		return err
+	}
+	defer txClient.Cancel()
+
+	for {
+		select {
+		case tx := <-txClient.ConfirmedTransactions():
+			if err := updateStream.Send(marshallTransaction(tx)); err != nil {
+				return err
 			}
-			pendingChannels = append(pendingChannels, pendingChan)
+		case tx := <-txClient.UnconfirmedTransactions():
+			if err := updateStream.Send(marshallTransaction(tx)); err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
 		}
 	}
-	if includeClose {
+}
+
+// toWalletAddressType translates the gRPC proto address type to the wallet
+// controller's available address types.
+func toWalletAddressType(t lnrpc.NewAddressRequest_AddressType) lnwallet.AddressType {
+	switch t {
+	case lnrpc.NewAddressRequest_NESTED_PUBKEY_HASH:
+		return lnwallet.NestedWitnessPubKey
+	case lnrpc.NewAddressRequest_PUBKEY_HASH:
+		return lnwallet.PubKeyHash
+	default:
+		return lnwallet.WitnessPubKey
+	}
+}
+
+// ImportPublicKey imports a public key into the wallet as watch-only. The
+// balance of, and transactions related to, the address of the requested type
+// corresponding to the key are then tracked by the wallet.
+func (r *rpcServer) ImportPublicKey(ctx context.Context,
+	in *lnrpc.ImportPublicKeyRequest) (*lnrpc.ImportPublicKeyResponse, error) {
+
+	pubKey, err := btcec.ParsePubKey(in.PublicKey, btcec.S256())
+	if err != nil {
+		return nil, err
 	}
 
-	return &lnrpc.PendingChannelResponse{
-		PendingChannels: pendingChannels,
-	}, nil
+	addr, err := r.server.lnwallet.ImportPublicKey(pubKey,
+		toWalletAddressType(in.AddressType))
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[importpublickey] watching addr=%v", addr)
+
+	return &lnrpc.ImportPublicKeyResponse{Address: addr.String()}, nil
 }
 
-// SendPayment dispatches a bi-directional streaming RPC for sending payments
-// through the Lightning Network. A single RPC invocation creates a persistent
-// bi-directional stream allowing clients to rapidly send payments through the
-// Lightning Network with a single persistent connection.
-func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer) error {
-	errChan := make(chan error, 1)
+// ImportAddress imports an address into the wallet as watch-only.
+func (r *rpcServer) ImportAddress(ctx context.Context,
+	in *lnrpc.ImportAddressRequest) (*lnrpc.ImportAddressResponse, error) {
+
+	addr, err := btcutil.DecodeAddress(in.Address, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.server.lnwallet.ImportAddress(addr); err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[importaddress] watching addr=%v", addr)
+
+	return &lnrpc.ImportAddressResponse{}, nil
+}
+
+// RescanWallet scans the chain for transactions relevant to the wallet,
+// including those related to watch-only addresses, starting from either the
+// requested height or the wallet's birthday. An update is streamed to the
+// client as each batch of blocks is scanned, with a final update once the
+// rescan reaches the tip of the chain.
+func (r *rpcServer) RescanWallet(in *lnrpc.RescanRequest,
+	updateStream lnrpc.Lightning_RescanWalletServer) error {
+
+	if in.StartHeight != 0 && in.BirthdayTimestamp != 0 {
+		return fmt.Errorf("only one of start_height and " +
+			"birthday_timestamp may be specified")
+	}
+	if in.StartHeight < 0 || in.BirthdayTimestamp < 0 {
+		return fmt.Errorf("start_height and birthday_timestamp must " +
+			"not be negative")
+	}
+
+	startHeight := in.StartHeight
+	if in.BirthdayTimestamp != 0 {
+		birthday := time.Unix(in.BirthdayTimestamp, 0)
+		var err error
+		startHeight, err = r.server.lnwallet.BirthdayHeight(birthday)
+		if err != nil {
+			return err
+		}
+	}
+
+	targetHeight, err := r.server.bio.GetCurrentHeight()
+	if err != nil {
+		return err
+	}
+
+	rpcsLog.Infof("[rescanwallet] rescanning from height=%v to "+
+		"height=%v", startHeight, targetHeight)
+
+	// The rescan is carried out in a distinct goroutine, with progress
+	// updates relayed to the client as they arrive. Updates are dropped
+	// rather than stalling the rescan if the client falls behind or
+	// disconnects.
+	progress := make(chan int32, 1)
+	rescanErr := make(chan error, 1)
+	go func() {
+		rescanErr <- r.server.lnwallet.Rescan(startHeight,
+			func(height int32) {
+				select {
+				case progress <- height:
+				default:
+				}
+			})
+	}()
+
 	for {
 		select {
-		case err := <-errChan:
-			return err
-		default:
-			// Receive the next pending payment within the stream sent by
-			// the client. If we read the EOF sentinel, then the client has
-			// closed the stream, and we can exit normally.
-			nextPayment, err := paymentStream.Recv()
-			if err == io.EOF {
-				return nil
-			} else if err != nil {
+		case height := <-progress:
+			err := updateStream.Send(&lnrpc.RescanUpdate{
+				StartHeight:   startHeight,
+				ScannedHeight: height,
+				TargetHeight:  targetHeight,
+			})
+			if err != nil {
 				return err
 			}
-
-			// Craft an HTLC packet to send to the routing sub-system. The
-			// meta-data within this packet will be used to route the
-			// payment through the network.
-			htlcAdd := &lnwire.HTLCAddRequest{
-				Amount:           lnwire.CreditsAmount(nextPayment.Amt),
-				RedemptionHashes: [][32]byte{debugHash},
-			}
-			destAddr, err := wire.NewShaHash(nextPayment.Dest)
+		case err := <-rescanErr:
 			if err != nil {
+				rpcsLog.Errorf("[rescanwallet] rescan failed: %v",
+					err)
 				return err
 			}
-			htlcPkt := &htlcPacket{
-				dest: *destAddr,
-				msg:  htlcAdd,
+
+			rpcsLog.Infof("[rescanwallet] rescan complete")
+
+			return updateStream.Send(&lnrpc.RescanUpdate{
+				StartHeight:   startHeight,
+				ScannedHeight: targetHeight,
+				TargetHeight:  targetHeight,
+				Complete:      true,
+			})
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// GetRecoveryInfo reports the progress of the scan for the funds of a wallet
+// restored from an existing seed.
+func (r *rpcServer) GetRecoveryInfo(ctx context.Context,
+	in *lnrpc.GetRecoveryInfoRequest) (*lnrpc.GetRecoveryInfoResponse, error) {
+
+	inRecovery, finished, progress, err :=
+		r.server.lnwallet.RecoveryProgress()
+	if err != nil {
+		rpcsLog.Errorf("[getrecoveryinfo] recovery failed: %v", err)
+		return nil, fmt.Errorf("wallet recovery failed: %v", err)
+	}
+
+	return &lnrpc.GetRecoveryInfoResponse{
+		RecoveryMode:     inRecovery,
+		RecoveryFinished: finished,
+		Progress:         progress,
+	}, nil
+}
+
+// ConnectPeer attempts to establish a connection to a remote peer.
+func (r *rpcServer) ConnectPeer(ctx context.Context,
+	in *lnrpc.ConnectPeerRequest) (*lnrpc.ConnectPeerResponse, error) {
+
+	if in.Addr == nil {
+		return nil, fmt.Errorf("need: lnc pubkey@hostname")
+	}
+
+	idAtHost := fmt.Sprintf("%v@%v", in.Addr.PubKeyHash, in.Addr.Host)
+	rpcsLog.Debugf("[connectpeer] peer=%v", idAtHost)
+
+	peerAddr, err := lndc.LnAddrFromString(idAtHost, activeNetParams.Params)
+	if err != nil {
+		rpcsLog.Errorf("(connectpeer): error parsing ln addr: %v", err)
+		return nil, err
+	}
+
+	peerID, err := r.server.ConnectToPeer(peerAddr)
+	if err != nil {
+		rpcsLog.Errorf("(connectpeer): error connecting to peer: %v", err)
+		return nil, err
+	}
+
+	rpcsLog.Debugf("Connected to peer: %v", peerAddr.String())
+	return &lnrpc.ConnectPeerResponse{peerID}, nil
+}
+
+// OpenChannel attempts to open a singly funded channel specified in the
+// request to a remote peer.
+func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
+	updateStream lnrpc.Lightning_OpenChannelServer) error {
+
+	rpcsLog.Tracef("[openchannel] request to peerid(%v) "+
+		"allocation(us=%v, them=%v) numconfs=%v private=%v "+
+		"lease_expiry=%v", in.TargetPeerId, in.LocalFundingAmount,
+		in.RemoteFundingAmount, in.NumConfs, in.Private, in.LeaseExpiry)
+
+	// A lease which has already expired would provide no guarantees to
+	// the remote peer, so we reject it outright.
+	if in.LeaseExpiry != 0 {
+		currentHeight, err := r.server.bio.GetCurrentHeight()
+		if err != nil {
+			return err
+		}
+		if in.LeaseExpiry <= uint32(currentHeight) {
+			return fmt.Errorf("lease expiry %v must be above the "+
+				"current height %v", in.LeaseExpiry,
+				currentHeight)
+		}
+	}
+
+	var policy *channeldb.ChannelEdgePolicy
+	if in.Policy != nil {
+		var err error
+		policy, err = unmarshallRoutingPolicy(in.Policy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if in.MaxPendingAmt < 0 {
+		return fmt.Errorf("max pending amount must not be negative")
+	}
+	if in.MaxAcceptedHtlcs > math.MaxUint16 {
+		return fmt.Errorf("max accepted htlcs must not exceed %v",
+			math.MaxUint16)
+	}
+
+	var closeAddr btcutil.Address
+	if in.CloseAddress != "" {
+		var err error
+		closeAddr, err = parseDeliveryAddress(in.CloseAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	localFundingAmt := btcutil.Amount(in.LocalFundingAmount)
+	remoteFundingAmt := btcutil.Amount(in.RemoteFundingAmount)
+
+	// Channels above the maximum channel size must be explicitly opted
+	// into, as a bug or breach would put more funds at risk.
+	capacity := localFundingAmt + remoteFundingAmt
+	if capacity > maxFundingAmount {
+		if !cfg.WumboChannels {
+			return fmt.Errorf("channel size of %v is above the "+
+				"maximum of %v, --protocol.wumbo-channels must "+
+				"be set to open larger channels", capacity,
+				maxFundingAmount)
+		}
+
+		rpcsLog.Warnf("[openchannel] channel size of %v is above %v, "+
+			"a bug or breach would put more funds at risk",
+			capacity, maxFundingAmount)
+	}
+
+	updateChan, errChan := r.server.OpenChannel(in.TargetPeerId,
+		in.TargetNode, localFundingAmt, remoteFundingAmt, in.NumConfs,
+		in.Private, in.LeaseExpiry, policy,
+		btcutil.Amount(in.MaxPendingAmt), uint16(in.MaxAcceptedHtlcs),
+		closeAddr)
+
+	var outpoint wire.OutPoint
+out:
+	for {
+		select {
+		case err := <-errChan:
+			rpcsLog.Errorf("unable to open channel to "+
+				"lightningID(%v) nor peerID(%v): %v",
+				in.TargetNode, in.TargetPeerId, err)
+			return err
+		case fundingUpdate := <-updateChan:
+			rpcsLog.Tracef("[openchannel] sending update: %v",
+				fundingUpdate)
+			if err := updateStream.Send(fundingUpdate); err != nil {
+				return err
 			}
 
-			// TODO(roasbeef): semaphore to limit num outstanding
-			// goroutines.
-			go func() {
-				// Finally, send this next packet to the routing layer in order
-				// to complete the next payment.
-				// TODO(roasbeef): this should go through the L3 router once
-				// multi-hop is in place.
-				if err := r.server.htlcSwitch.SendHTLC(htlcPkt); err != nil {
-					errChan <- err
-					return
+			// If a final channel open update is being sent, then
+			// we can break out of our recv loop as we no longer
+			// need to process any further updates.
+			switch update := fundingUpdate.Update.(type) {
+			case *lnrpc.OpenStatusUpdate_ChanOpen:
+				chanPoint := update.ChanOpen.ChannelPoint
+				h, _ := wire.NewShaHash(chanPoint.FundingTxid)
+				outpoint = wire.OutPoint{
+					Hash:  *h,
+					Index: chanPoint.OutputIndex,
 				}
 
-				// TODO(roasbeef): proper responses
-				resp := &lnrpc.SendResponse{}
-				if err := paymentStream.Send(resp); err != nil {
-					errChan <- err
-					return
-				}
-			}()
+				break out
+			}
+		case <-r.quit:
+			return nil
 		}
 	}
 
+	rpcsLog.Tracef("[openchannel] success peerid(%v), ChannelPoint(%v)",
+		in.TargetPeerId, outpoint)
 	return nil
 }
 
-func (r *rpcServer) ShowRoutingTable(ctx context.Context,
-	in *lnrpc.ShowRoutingTableRequest) (*lnrpc.ShowRoutingTableResponse, error) {
-	rpcsLog.Debugf("[ShowRoutingTable]")
-	rtCopy := r.server.routingMgr.GetRTCopy()
-	channels := make([]*lnrpc.RoutingTableLink, 0)
-	for _, channel := range rtCopy.AllChannels() {
-		channels = append(channels,
-			&lnrpc.RoutingTableLink{
-				Id1:      channel.Id1.String(),
-				Id2:      channel.Id2.String(),
-				Outpoint: channel.EdgeID.String(),
-				Capacity: channel.Info.Capacity(),
-				Weight:   channel.Info.Weight(),
-			},
-		)
+// CloseChannel attempts to close an active channel identified by its channel
+// point. The actions of this method can additionally be augmented to attempt
+// a force close after a timeout period in the case of an inactive peer.
+func (r *rpcServer) CloseChannel(in *lnrpc.CloseChannelRequest,
+	updateStream lnrpc.Lightning_CloseChannelServer) error {
+
+	force := in.Force
+	targetChannelPoint, err := r.resolveChannelPoint(in.ChannelPoint,
+		in.ChanId, in.Scid)
+	if err != nil {
+		rpcsLog.Errorf("[closechannel] invalid channel: %v", err)
+		return err
+	}
+
+	rpcsLog.Tracef("[closechannel] request for ChannelPoint(%v)",
+		targetChannelPoint)
+
+	if force {
+		err := r.checkForceClose(targetChannelPoint,
+			in.AllowOnlinePeer, in.AllowPendingHtlcs)
+		if err != nil {
+			rpcsLog.Errorf("[closechannel] refusing to force close "+
+				"ChannelPoint(%v): %v", targetChannelPoint, err)
+			return err
+		}
+	}
+
+	// Our balance is only delivered to the requested address within a
+	// cooperative close, as a force close pays to the commitment scripts.
+	var deliveryScript []byte
+	if in.DeliveryAddress != "" {
+		if force {
+			return fmt.Errorf("a delivery address can't be " +
+				"specified for a force close")
+		}
+
+		deliveryAddr, err := parseDeliveryAddress(in.DeliveryAddress)
+		if err != nil {
+			return err
+		}
+		deliveryScript, err = deliveryAddrScript(deliveryAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	updateChan, errChan := r.server.htlcSwitch.CloseLink(targetChannelPoint,
+		force, deliveryScript)
+
+out:
+	for {
+		select {
+		case err := <-errChan:
+			rpcsLog.Errorf("[closechannel] unable to close "+
+				"ChannelPoint(%v): %v", targetChannelPoint, err)
+			return err
+		case closingUpdate := <-updateChan:
+			rpcsLog.Tracef("[closechannel] sending update: %v",
+				closingUpdate)
+			if err := updateStream.Send(closingUpdate); err != nil {
+				return err
+			}
+
+			// If a final channel closing updates is being sent,
+			// then we can break out of our dispatch loop as we no
+			// longer need to process any further updates.
+			switch closeUpdate := closingUpdate.Update.(type) {
+			case *lnrpc.CloseStatusUpdate_ChanClose:
+				h, _ := wire.NewShaHash(closeUpdate.ChanClose.ClosingTxid)
+				rpcsLog.Infof("[closechannel] close completed: "+
+					"txid(%v)", h)
+				break out
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// checkForceClose ensures that force closing the target channel is safe, or
+// that the caller has acknowledged the risks. A force close locks our funds
+// for the channel's CSV delay, so it's refused while the peer is online and
+// a cooperative close is possible. It's also refused while HTLCs are in
+// flight, as those must then be resolved on-chain.
+func (r *rpcServer) checkForceClose(chanPoint *wire.OutPoint,
+	allowOnlinePeer, allowPendingHtlcs bool) error {
+
+	// Channels are only active while their peer is connected, so finding
+	// the channel's snapshot tells us the peer is online.
+	var snapshot *channeldb.ChannelSnapshot
+	for _, peer := range r.server.Peers() {
+		for _, s := range peer.ChannelSnapshots() {
+			if *s.ChannelPoint == *chanPoint {
+				snapshot = s
+				break
+			}
+		}
+	}
+	if snapshot == nil {
+		return fmt.Errorf("channel %v isn't active, its peer may be "+
+			"offline", chanPoint)
+	}
+
+	numHtlcs := len(snapshot.Htlcs)
+	switch {
+	case numHtlcs != 0 && !allowPendingHtlcs:
+		return fmt.Errorf("channel has %v HTLCs in flight which must "+
+			"be resolved on-chain, set allow_pending_htlcs to "+
+			"force close anyway", numHtlcs)
+
+	case numHtlcs == 0 && !allowOnlinePeer:
+		return fmt.Errorf("peer is online and the channel can be " +
+			"closed cooperatively, set allow_online_peer to " +
+			"force close anyway")
+	}
+
+	return nil
+}
+
+// GetInfo serves a request to the "getinfo" RPC call. This call returns
+// general information concerning the lightning node including it's LN ID,
+// identity address, identity public key, and information concerning the
+// number of open+pending channels.
+func (r *rpcServer) GetInfo(ctx context.Context,
+	in *lnrpc.GetInfoRequest) (*lnrpc.GetInfoResponse, error) {
+
+	var activeChannels uint32
+	serverPeers := r.server.Peers()
+	for _, serverPeer := range serverPeers {
+		activeChannels += uint32(len(serverPeer.ChannelSnapshots()))
+	}
+
+	pendingChannels := r.server.fundingMgr.NumPendingChannels()
+
+	idPub := r.server.identityECDH.PubKey().SerializeCompressed()
+	idAddr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(idPub), activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each of the addresses we advertise to the network is returned as a
+	// URI of the form pubkey@host:port, which can be passed directly to
+	// ConnectPeer.
+	announceAddrs := r.server.advertisedAddrs()
+	uris := make([]string, len(announceAddrs))
+	for i, addr := range announceAddrs {
+		uris[i] = fmt.Sprintf("%x@%v", idPub, addr)
+	}
+
+	return &lnrpc.GetInfoResponse{
+		LightningId:        hex.EncodeToString(r.server.lightningID[:]),
+		IdentityAddress:    idAddr.String(),
+		NumPendingChannels: pendingChannels,
+		NumActiveChannels:  activeChannels,
+		NumPeers:           uint32(len(serverPeers)),
+		IdentityPubkey:     hex.EncodeToString(idPub),
+		Uris:               uris,
+	}, nil
+}
+
+// UpdateConfig re-reads the configuration file, applying any changes to the
+// options which don't require a restart of the daemon, just as a SIGHUP does.
+func (r *rpcServer) UpdateConfig(ctx context.Context,
+	in *lnrpc.UpdateConfigRequest) (*lnrpc.UpdateConfigResponse, error) {
+
+	rpcsLog.Infof("[updateconfig]")
+
+	newCfg, err := r.server.reloadConfig()
+	if err != nil {
+		rpcsLog.Errorf("unable to reload configuration: %v", err)
+		return nil, err
+	}
+
+	return &lnrpc.UpdateConfigResponse{
+		DebugLevel:   newCfg.DebugLevel,
+		PeerPolicies: newCfg.PeerPolicies,
+		MinChanSize:  newCfg.MinChanSize,
+		MaxChanSize:  newCfg.MaxChanSize,
+	}, nil
+}
+
+// ListPeers returns a verbose listing of all currently active peers.
+func (r *rpcServer) ListPeers(ctx context.Context,
+	in *lnrpc.ListPeersRequest) (*lnrpc.ListPeersResponse, error) {
+
+	rpcsLog.Tracef("[listpeers] request")
+
+	serverPeers := r.server.Peers()
+	resp := &lnrpc.ListPeersResponse{
+		Peers: make([]*lnrpc.Peer, 0, len(serverPeers)),
+	}
+
+	for _, serverPeer := range serverPeers {
+		// TODO(roasbeef): add a snapshot method which grabs peer read mtx
+
+		lnID := hex.EncodeToString(serverPeer.lightningID[:])
+		peer := &lnrpc.Peer{
+			LightningId: lnID,
+			PeerId:      serverPeer.id,
+			Address:     serverPeer.conn.RemoteAddr().String(),
+			Inbound:     serverPeer.inbound,
+			BytesRecv:   atomic.LoadUint64(&serverPeer.bytesReceived),
+			BytesSent:   atomic.LoadUint64(&serverPeer.bytesSent),
+			Features:    marshallFeatures(serverPeer.remoteFeatures),
+
+			StalledReconnects: r.server.NumStalledLinks(
+				serverPeer.lightningID,
+			),
+		}
+
+		chanSnapshots := serverPeer.ChannelSnapshots()
+		peer.Channels = make([]*lnrpc.ActiveChannel, 0, len(chanSnapshots))
+		for _, chanSnapshot := range chanSnapshots {
+			channel := &lnrpc.ActiveChannel{
+				RemoteId:      lnID,
+				ChannelPoint:  chanSnapshot.ChannelPoint.String(),
+				Capacity:      int64(chanSnapshot.Capacity),
+				LocalBalance:  int64(chanSnapshot.LocalBalance),
+				RemoteBalance: int64(chanSnapshot.RemoteBalance),
+				NumUpdates:    chanSnapshot.NumUpdates,
+			}
+			peer.Channels = append(peer.Channels, channel)
+		}
+
+		resp.Peers = append(resp.Peers, peer)
+	}
+
+	rpcsLog.Debugf("[listpeers] yielded %v peers", serverPeers)
+
+	return resp, nil
+}
+
+// ListChannels returns a description of all the open channels we're a
+// participant in. The set of channels returned can be restricted to only the
+// channels which are currently active or inactive, or only the public or
+// private channels.
+func (r *rpcServer) ListChannels(ctx context.Context,
+	in *lnrpc.ListChannelsRequest) (*lnrpc.ListChannelsResponse, error) {
+
+	if in.ActiveOnly && in.InactiveOnly {
+		return nil, fmt.Errorf("either active_only or inactive_only " +
+			"can be set, but not both")
+	}
+	if in.PublicOnly && in.PrivateOnly {
+		return nil, fmt.Errorf("either public_only or private_only " +
+			"can be set, but not both")
+	}
+
+	rpcsLog.Tracef("[listchannels] request")
+
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	// A channel is considered active if the peer it's with is currently
+	// connected, and the channel is usable for payments.
+	activeChans := make(map[wire.OutPoint]struct{})
+	for _, serverPeer := range r.server.Peers() {
+		for _, snapshot := range serverPeer.ChannelSnapshots() {
+			activeChans[*snapshot.ChannelPoint] = struct{}{}
+		}
+	}
+
+	resp := &lnrpc.ListChannelsResponse{}
+	for _, dbChannel := range dbChannels {
+		_, isActive := activeChans[*dbChannel.ChanID]
+
+		switch {
+		case in.ActiveOnly && !isActive:
+			continue
+		case in.InactiveOnly && isActive:
+			continue
+		case in.PublicOnly && dbChannel.IsPrivate:
+			continue
+		case in.PrivateOnly && !dbChannel.IsPrivate:
+			continue
+		}
+
+		resp.Channels = append(resp.Channels,
+			marshallChannel(dbChannel, isActive))
+	}
+
+	rpcsLog.Debugf("[listchannels] yielded %v channels", len(resp.Channels))
+
+	return resp, nil
+}
+
+// marshallChannel converts the persistent state of an open channel into its
+// RPC representation.
+func marshallChannel(dbChannel *channeldb.OpenChannel,
+	isActive bool) *lnrpc.ActiveChannel {
+
+	// The fee paid by the commitment transaction is the portion of the
+	// channel's capacity which isn't allocated to any of its outputs.
+	commitFee := int64(dbChannel.Capacity)
+	if dbChannel.OurCommitTx != nil {
+		for _, txOut := range dbChannel.OurCommitTx.TxOut {
+			commitFee -= txOut.Value
+		}
+	}
+
+	var unsettledBalance btcutil.Amount
+	pendingHtlcs := make([]*lnrpc.HTLC, len(dbChannel.Htlcs))
+	for i, htlc := range dbChannel.Htlcs {
+		unsettledBalance += htlc.Amt
+
+		rHash := htlc.RHash
+		pendingHtlcs[i] = &lnrpc.HTLC{
+			Amount:           int64(htlc.Amt),
+			HashLock:         rHash[:],
+			ToUs:             htlc.Incoming,
+			ExpirationHeight: htlc.RefundTimeout,
+		}
+	}
+
+	var lifetime time.Duration
+	if !dbChannel.CreationTime.IsZero() {
+		lifetime = time.Since(dbChannel.CreationTime)
+	}
+
+	// A zero limit on the HTLC's in flight indicates the default, so we
+	// report the limit actually enforced.
+	maxPendingAmt := dbChannel.MaxPendingAmount
+	if maxPendingAmt == 0 {
+		maxPendingAmt = dbChannel.Capacity
+	}
+	maxAcceptedHtlcs := uint32(dbChannel.MaxAcceptedHtlcs)
+	if maxAcceptedHtlcs == 0 {
+		maxAcceptedHtlcs = lnwallet.MaxPendingPayments
+	}
+
+	return &lnrpc.ActiveChannel{
+		RemoteId:              hex.EncodeToString(dbChannel.TheirLNID[:]),
+		ChannelPoint:          dbChannel.ChanID.String(),
+		Capacity:              int64(dbChannel.Capacity),
+		LocalBalance:          int64(dbChannel.OurBalance),
+		RemoteBalance:         int64(dbChannel.TheirBalance),
+		UnsettledBelance:      int64(unsettledBalance),
+		PendingHtlcs:          pendingHtlcs,
+		NumUpdates:            dbChannel.NumUpdates,
+		Active:                isActive,
+		Private:               dbChannel.IsPrivate,
+		CsvDelay:              dbChannel.LocalCsvDelay,
+		LeaseExpiry:           dbChannel.LeaseExpiry,
+		MaxPendingAmt:         int64(maxPendingAmt),
+		MaxAcceptedHtlcs:      maxAcceptedHtlcs,
+		ChanId:                dbChannel.ShortChanID.ToUint64(),
+		CommitFee:             commitFee,
+		TotalSatoshisSent:     int64(dbChannel.TotalSatoshisSent),
+		TotalSatoshisReceived: int64(dbChannel.TotalSatoshisReceived),
+		Uptime:                int64(dbChannel.Uptime.Seconds()),
+		Lifetime:              int64(lifetime.Seconds()),
+	}
+}
+
+// WalletBalance returns the sum of all confirmed unspent outputs under control
+// by the wallet. This method can be modified by having the request specify
+// only witness outputs should be factored into the final output sum.
+// TODO(roasbeef): split into total and confirmed/unconfirmed
+// TODO(roasbeef): add async hooks into wallet balance changes
+func (r *rpcServer) WalletBalance(ctx context.Context,
+	in *lnrpc.WalletBalanceRequest) (*lnrpc.WalletBalanceResponse, error) {
+
+	balance, err := r.server.lnwallet.ConfirmedBalance(1, in.WitnessOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	watchOnlyBalance, err := r.server.lnwallet.WatchOnlyBalance(1)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[walletbalance] balance=%v, watch_only_balance=%v",
+		balance, watchOnlyBalance)
+
+	return &lnrpc.WalletBalanceResponse{
+		Balance:          balance.ToBTC(),
+		WatchOnlyBalance: watchOnlyBalance.ToBTC(),
+	}, nil
+}
+
+// ChannelBalance returns the total available channel flow across all open
+// channels in satoshis. In addition to the aggregate balances, the balances
+// of each open and pending-open channel are returned individually, along with
+// the value currently locked within unsettled HTLC's.
+func (r *rpcServer) ChannelBalance(ctx context.Context,
+	in *lnrpc.ChannelBalanceRequest) (*lnrpc.ChannelBalanceResponse, error) {
+
+	resp := &lnrpc.ChannelBalanceResponse{}
+	for _, peer := range r.server.Peers() {
+		for _, snapshot := range peer.ChannelSnapshots() {
+			// Tally the value of all HTLC's which have yet to be
+			// settled or timed out, separated by direction.
+			var unsettledLocal, unsettledRemote btcutil.Amount
+			for _, htlc := range snapshot.Htlcs {
+				if htlc.Incoming {
+					unsettledRemote += htlc.Amt
+				} else {
+					unsettledLocal += htlc.Amt
+				}
+			}
+
+			resp.Balance += int64(snapshot.LocalBalance)
+			resp.RemoteBalance += int64(snapshot.RemoteBalance)
+			resp.UnsettledLocalBalance += int64(unsettledLocal)
+			resp.UnsettledRemoteBalance += int64(unsettledRemote)
+
+			chanBalance := &lnrpc.ChannelBalanceResponse_ChannelBalance{
+				ChannelPoint:           snapshot.ChannelPoint.String(),
+				LightningId:            hex.EncodeToString(snapshot.RemoteID[:]),
+				Capacity:               int64(snapshot.Capacity),
+				LocalBalance:           int64(snapshot.LocalBalance),
+				RemoteBalance:          int64(snapshot.RemoteBalance),
+				UnsettledLocalBalance:  int64(unsettledLocal),
+				UnsettledRemoteBalance: int64(unsettledRemote),
+			}
+			resp.Channels = append(resp.Channels, chanBalance)
+		}
+	}
+
+	// Channels which are still awaiting confirmation of their funding
+	// transaction can't yet be used, so their balances are reported
+	// separately.
+	for _, pendingOpen := range r.server.fundingMgr.PendingChannels() {
+		resp.PendingOpenLocalBalance += int64(pendingOpen.localBalance)
+		resp.PendingOpenRemoteBalance += int64(pendingOpen.remoteBalance)
+
+		chanBalance := &lnrpc.ChannelBalanceResponse_ChannelBalance{
+			ChannelPoint:  pendingOpen.channelPoint.String(),
+			LightningId:   hex.EncodeToString(pendingOpen.lightningID[:]),
+			Pending:       true,
+			Capacity:      int64(pendingOpen.capacity),
+			LocalBalance:  int64(pendingOpen.localBalance),
+			RemoteBalance: int64(pendingOpen.remoteBalance),
+		}
+		resp.Channels = append(resp.Channels, chanBalance)
+	}
+
+	rpcsLog.Debugf("[channelbalance] balance=%v, pending_open=%v",
+		btcutil.Amount(resp.Balance),
+		btcutil.Amount(resp.PendingOpenLocalBalance))
+
+	return resp, nil
+}
+
+// PendingChannels returns a list of all the channels that are currently
+// considered "pending". A channel is pending if it has finished the funding
+// workflow and is waiting for confirmations for the funding txn, or is in the
+// process of closure, either initiated cooperatively or non-coopertively.
+func (r *rpcServer) PendingChannels(ctx context.Context,
+	in *lnrpc.PendingChannelRequest) (*lnrpc.PendingChannelResponse, error) {
+
+	both := in.Status == lnrpc.ChannelStatus_ALL
+	includeOpen := (in.Status == lnrpc.ChannelStatus_OPENING) || both
+	includeClose := (in.Status == lnrpc.ChannelStatus_CLOSING) || both
+	rpcsLog.Debugf("[pendingchannels] %v", in.Status)
+
+	var pendingChannels []*lnrpc.PendingChannelResponse_PendingChannel
+	if includeOpen {
+		pendingOpenChans := r.server.fundingMgr.PendingChannels()
+		for _, pendingOpen := range pendingOpenChans {
+			// TODO(roasbeef): add confirmation progress
+			pendingChan := &lnrpc.PendingChannelResponse_PendingChannel{
+				PeerId:        pendingOpen.peerId,
+				LightningId:   hex.EncodeToString(pendingOpen.lightningID[:]),
+				ChannelPoint:  pendingOpen.channelPoint.String(),
+				Capacity:      int64(pendingOpen.capacity),
+				LocalBalance:  int64(pendingOpen.localBalance),
+				RemoteBalance: int64(pendingOpen.remoteBalance),
+				Status:        lnrpc.ChannelStatus_OPENING,
+			}
+			pendingChannels = append(pendingChannels, pendingChan)
+		}
+	}
+	if includeClose {
+		arbLogs, err := r.server.chainArb.PendingResolutions()
+		if err != nil {
+			return nil, err
+		}
+		for _, arbLog := range arbLogs {
+			pendingChan := &lnrpc.PendingChannelResponse_PendingChannel{
+				LightningId:   hex.EncodeToString(arbLog.RemoteID[:]),
+				ChannelPoint:  arbLog.ChanPoint.String(),
+				Capacity:      int64(arbLog.Capacity),
+				LocalBalance:  int64(arbLog.LocalBalance),
+				RemoteBalance: int64(arbLog.RemoteBalance),
+				ClosingTxid:   arbLog.CloseTxid.String(),
+				Status:        lnrpc.ChannelStatus_CLOSING,
+				CloseHeight:   arbLog.CloseHeight,
+			}
+
+			for _, resolution := range arbLog.Resolutions {
+				if !resolution.Resolved {
+					pendingChan.LimboBalance += int64(resolution.Amount)
+				}
+
+				pendingChan.Resolutions = append(pendingChan.Resolutions,
+					&lnrpc.ContractResolution{
+						Outpoint:       resolution.OutPoint.String(),
+						Kind:           resolution.Kind.String(),
+						Amount:         int64(resolution.Amount),
+						MaturityHeight: maturityHeight(arbLog, resolution),
+						Resolved:       resolution.Resolved,
+					})
+			}
+
+			pendingChannels = append(pendingChannels, pendingChan)
+		}
+	}
+
+	return &lnrpc.PendingChannelResponse{
+		PendingChannels: pendingChannels,
+	}, nil
+}
+
+// maturityHeight returns the height from which the passed output of a closed
+// channel can be swept, or zero if the closing transaction has yet to confirm.
+func maturityHeight(arbLog *channeldb.ArbitrationLog,
+	resolution *channeldb.ContractResolution) uint32 {
+
+	if arbLog.CloseHeight == 0 {
+		return 0
+	}
+
+	height := arbLog.CloseHeight + resolution.CsvDelay
+	if resolution.LeaseExpiry > height {
+		height = resolution.LeaseExpiry
+	}
+	if resolution.Kind == channeldb.ContractOutgoingHtlc &&
+		resolution.Expiry > height {
+
+		height = resolution.Expiry
+	}
+
+	return height
+}
+
+// BumpFee raises the fee rate of the unconfirmed funding transaction of a
+// pending channel we initiated, by broadcasting a child transaction which
+// spends the funding transaction's change output.
+func (r *rpcServer) BumpFee(ctx context.Context,
+	in *lnrpc.BumpFeeRequest) (*lnrpc.BumpFeeResponse, error) {
+
+	if in.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel_point must be set")
+	}
+	txid, err := wire.NewShaHash(in.ChannelPoint.FundingTxid)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChannelPoint.OutputIndex)
+
+	satPerByte := in.SatPerByte
+	if satPerByte == 0 {
+		satPerByte = defaultSendFeeRate
+	} else if satPerByte < 0 {
+		return nil, fmt.Errorf("sat_per_byte must be positive")
+	}
+
+	rpcsLog.Infof("[bumpfee] ChannelPoint(%v), sat_per_byte=%v",
+		chanPoint, satPerByte)
+
+	childTxid, err := r.server.fundingMgr.BumpFundingFee(chanPoint,
+		btcutil.Amount(satPerByte))
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BumpFeeResponse{Txid: childTxid.String()}, nil
+}
+
+// SendPayment dispatches a bi-directional streaming RPC for sending payments
+// through the Lightning Network. A single RPC invocation creates a persistent
+// bi-directional stream allowing clients to rapidly send payments through the
+// Lightning Network with a single persistent connection.
+func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer) error {
+	errChan := make(chan error, 1)
+	for {
+		select {
+		case err := <-errChan:
+			return err
+		default:
+			// Receive the next pending payment within the stream sent by
+			// the client. If we read the EOF sentinel, then the client has
+			// closed the stream, and we can exit normally.
+			nextPayment, err := paymentStream.Recv()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			destAddr, err := wire.NewShaHash(nextPayment.Dest)
+			if err != nil {
+				return err
+			}
+
+			// If the caller didn't specify a payment hash, then we
+			// fall back to the debug hash.
+			// TODO(roasbeef): remove debug payment hash
+			rHash := [32]byte(debugHash)
+			if len(nextPayment.PaymentHash) != 0 {
+				if len(nextPayment.PaymentHash) != 32 {
+					return fmt.Errorf("payment hash must be " +
+						"exactly 32 bytes")
+				}
+				copy(rHash[:], nextPayment.PaymentHash)
+			}
+			restrictions, err := r.parseRouteRestrictions(
+				nextPayment.OutgoingChanIds,
+				nextPayment.LastHopPubkey)
+			if err != nil {
+				return err
+			}
+
+			payment := &lightningPayment{
+				dest:        *destAddr,
+				amt:         btcutil.Amount(nextPayment.Amt),
+				paymentHash: rHash,
+				feeLimit:    btcutil.Amount(nextPayment.FeeLimit),
+				timeout: time.Duration(nextPayment.TimeoutSeconds) *
+					time.Second,
+				finalCltvDelta: nextPayment.FinalCltvDelta,
+				cltvLimit:      nextPayment.CltvLimit,
+				restrictions:   restrictions,
+			}
+			if len(nextPayment.PaymentAddr) != 0 {
+				if len(nextPayment.PaymentAddr) != 32 {
+					return fmt.Errorf("payment addr must be " +
+						"exactly 32 bytes")
+				}
+				copy(payment.paymentAddr[:], nextPayment.PaymentAddr)
+			}
+
+			// Ensure the custom records can be encoded within the
+			// payload of the final hop before dispatching the
+			// payment.
+			customRecords := nextPayment.DestCustomRecords
+			finalPayload := &lnwire.HopPayload{
+				CustomRecords: customRecords,
+			}
+			if err := finalPayload.Encode(ioutil.Discard); err != nil {
+				return err
+			}
+			payment.customRecords = customRecords
+
+			// TODO(roasbeef): semaphore to limit num outstanding
+			// goroutines.
+			go func() {
+				// Finally, dispatch this payment, driving it
+				// through its lifecycle until it either
+				// succeeds or fails.
+				// TODO(roasbeef): this should go through the L3 router once
+				// multi-hop is in place.
+				resp := &lnrpc.SendResponse{}
+				err := r.server.paymentCtrl.sendPayment(payment)
+				switch e := err.(type) {
+				case nil:
+
+				// If the failure was attributed to a particular
+				// hop, then the details are returned to the
+				// client rather than closing the stream.
+				case *paymentFailure:
+					resp.Failure = &lnrpc.PaymentFailure{
+						SourceIndex: e.sourceIndex,
+						Code:        lnrpc.FailureCode(e.failure.Code),
+						Amount:      e.failure.Amount.ToSatoshi(),
+					}
+
+				default:
+					errChan <- err
+					return
+				}
+
+				// TODO(roasbeef): proper responses
+				if err := paymentStream.Send(resp); err != nil {
+					errChan <- err
+					return
+				}
+			}()
+		}
+	}
+
+	return nil
+}
+
+// ShowRoutingTable returns every channel within the routing table. As the
+// routing table spans the entire network, the response is cached.
+func (r *rpcServer) ShowRoutingTable(ctx context.Context,
+	in *lnrpc.ShowRoutingTableRequest) (*lnrpc.ShowRoutingTableResponse, error) {
+	rpcsLog.Debugf("[ShowRoutingTable]")
+
+	resp, err := r.server.rpcCache.fetch("showroutingtable",
+		func() (interface{}, error) {
+			rtCopy := r.server.routingMgr.GetRTCopy()
+			channels := make([]*lnrpc.RoutingTableLink, 0)
+			for _, channel := range rtCopy.AllChannels() {
+				channels = append(channels,
+					&lnrpc.RoutingTableLink{
+						Id1:      channel.Id1.String(),
+						Id2:      channel.Id2.String(),
+						Outpoint: channel.EdgeID.String(),
+						Capacity: channel.Info.Capacity(),
+						Weight:   channel.Info.Weight(),
+					},
+				)
+			}
+			return &lnrpc.ShowRoutingTableResponse{
+				Channels: channels,
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*lnrpc.ShowRoutingTableResponse), nil
+}
+
+// DropGraph wipes the entire channel graph, then rebuilds it from our own set
+// of open channels. As the graph is stored separately from the channel state,
+// this never endangers any funds within active channels.
+func (r *rpcServer) DropGraph(ctx context.Context,
+	in *lnrpc.DropGraphRequest) (*lnrpc.DropGraphResponse, error) {
+
+	rpcsLog.Infof("[dropgraph]")
+
+	numEdges, err := r.server.rebuildGraph()
+	if err != nil {
+		rpcsLog.Errorf("unable to rebuild channel graph: %v", err)
+		return nil, err
+	}
+
+	return &lnrpc.DropGraphResponse{
+		NumEdges: int64(numEdges),
+	}, nil
+}
+
+// ExportGraphSnapshot returns a compact binary snapshot of the entire channel
+// graph, which another node on the same network can import in order to skip
+// the initial sync of the graph.
+func (r *rpcServer) ExportGraphSnapshot(ctx context.Context,
+	in *lnrpc.ExportGraphSnapshotRequest) (*lnrpc.ExportGraphSnapshotResponse, error) {
+
+	rpcsLog.Infof("[exportgraphsnapshot]")
+
+	var snapshot bytes.Buffer
+	numNodes, numEdges, err := r.server.chanGraph.ExportSnapshot(&snapshot)
+	if err != nil {
+		rpcsLog.Errorf("unable to export graph snapshot: %v", err)
+		return nil, err
+	}
+
+	return &lnrpc.ExportGraphSnapshotResponse{
+		Snapshot: snapshot.Bytes(),
+		NumNodes: uint32(numNodes),
+		NumEdges: uint32(numEdges),
+	}, nil
+}
+
+// ImportGraphSnapshot adds the nodes and edges within a snapshot exported by
+// ExportGraphSnapshot to the channel graph. Nodes and edges already known with
+// a more recent update are left untouched, as are the edges of our own
+// channels.
+func (r *rpcServer) ImportGraphSnapshot(ctx context.Context,
+	in *lnrpc.ImportGraphSnapshotRequest) (*lnrpc.ImportGraphSnapshotResponse, error) {
+
+	rpcsLog.Infof("[importgraphsnapshot] size=%v", len(in.Snapshot))
+
+	numNodes, numEdges, err := r.server.importGraphSnapshot(
+		bytes.NewReader(in.Snapshot),
+	)
+	if err != nil {
+		rpcsLog.Errorf("unable to import graph snapshot: %v", err)
+		return nil, err
+	}
+
+	return &lnrpc.ImportGraphSnapshotResponse{
+		NumNodes: uint32(numNodes),
+		NumEdges: uint32(numEdges),
+	}, nil
+}
+
+// QueryRoutes attempts to find a route from our node to the target
+// destination which is able to carry the specified amount. The path finding
+// is carried out entirely using the in-memory channel graph cache.
+func (r *rpcServer) QueryRoutes(ctx context.Context,
+	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
+
+	rpcsLog.Debugf("[queryroutes] dest=%x, amt=%v", in.Dest, in.Amt)
+
+	dest, err := wire.NewShaHash(in.Dest)
+	if err != nil {
+		return nil, err
+	}
+
+	currentHeight, err := r.server.bio.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	finalCltvDelta := in.FinalCltvDelta
+	if finalCltvDelta == 0 {
+		finalCltvDelta = defaultFinalCltvDelta
+	}
+
+	restrictions, err := r.parseRouteRestrictions(in.OutgoingChanIds,
+		in.LastHopPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	source := wire.ShaHash(r.server.lightningID)
+	path, err := findRoute(r.server.chanGraph, source, *dest,
+		btcutil.Amount(in.Amt), nil, restrictions,
+		r.server.paymentCtrl.liquidity, uint32(currentHeight),
+		finalCltvDelta, r.server.paymentCtrl.cltvLimit(in.CltvLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.QueryRoutesResponse{
+		Route: marshallRoute(path),
+	}, nil
+}
+
+// parseRouteRestrictions converts the outgoing channels and last hop requested
+// for a route into a set of routeRestrictions. Each outgoing channel must be
+// one of our own open channels. If neither is requested, nil is returned.
+func (r *rpcServer) parseRouteRestrictions(outgoingChanIDs []uint64,
+	lastHopPubKey []byte) (*routeRestrictions, error) {
+
+	if len(outgoingChanIDs) == 0 && len(lastHopPubKey) == 0 {
+		return nil, nil
+	}
+
+	restrictions := &routeRestrictions{
+		outgoingChans: make(map[wire.OutPoint]struct{}),
+	}
+	for _, chanID := range outgoingChanIDs {
+		shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+		channel, err := r.server.chanDB.FetchChannelByShortID(shortChanID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find outgoing channel "+
+				"with short channel id %v: %v", shortChanID, err)
+		}
+
+		restrictions.outgoingChans[*channel.ChanID] = struct{}{}
+	}
+
+	if len(lastHopPubKey) != 0 {
+		lastHop, err := parseNodeID(lastHopPubKey)
+		if err != nil {
+			return nil, err
+		}
+		restrictions.lastHop = &lastHop
+	}
+
+	return restrictions, nil
+}
+
+// parseNodeID returns the lightning ID of a node identified by either its
+// 33-byte compressed public key, or its 32-byte lightning ID.
+func parseNodeID(id []byte) (wire.ShaHash, error) {
+	var nodeID wire.ShaHash
+	switch len(id) {
+	case 33:
+		nodeID = wire.ShaHash(fastsha256.Sum256(id))
+	case 32:
+		copy(nodeID[:], id)
+	default:
+		return nodeID, fmt.Errorf("node must be identified by a 33-byte "+
+			"public key or a 32-byte lightning ID, got %v bytes",
+			len(id))
+	}
+
+	return nodeID, nil
+}
+
+// marshallRoute converts a route found within the channel graph into its
+// RPC representation.
+func marshallRoute(path *route) *lnrpc.Route {
+	hops := make([]*lnrpc.Hop, len(path.hops))
+	for i, hop := range path.hops {
+		hops[i] = &lnrpc.Hop{
+			LightningId:  hex.EncodeToString(hop.nodeID[:]),
+			ChannelPoint: hop.channel.ChannelPoint.String(),
+			ChanCapacity: int64(hop.channel.Capacity),
+			AmtToForward: int64(hop.amtToForward),
+		}
+	}
+
+	return &lnrpc.Route{
+		TotalAmt:      int64(path.totalAmt),
+		TotalTimeLock: path.totalTimeLock,
+		Hops:          hops,
+	}
+}
+
+// TrackPayment returns a stream of updates detailing the state of the payment
+// identified by the passed payment hash. An update is sent each time a new
+// HTLC attempt is made for the payment, or the state of an existing attempt
+// changes. The stream is closed once the payment reaches a terminal state.
+func (r *rpcServer) TrackPayment(in *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	if len(in.PaymentHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes")
+	}
+	var rHash [32]byte
+	copy(rHash[:], in.PaymentHash)
+
+	rpcsLog.Debugf("[trackpayment] payment_hash=%x", rHash[:])
+
+	sub, err := r.server.paymentCtrl.subscribePayment(rHash)
+	if err != nil {
+		rpcsLog.Errorf("[trackpayment] unable to track payment(%x): %v",
+			rHash[:], err)
+		return err
+	}
+	defer sub.cancel()
+
+	for {
+		select {
+		case payment := <-sub.updates:
+			update := marshallPaymentUpdate(payment)
+			rpcsLog.Tracef("[trackpayment] sending update: %v", update)
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+			// Once the payment has either succeeded or failed, no
+			// further updates will be sent, so we can exit.
+			if payment.Status != channeldb.StatusInFlight {
+				return nil
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SubscribeHtlcEvents streams an event to the client each time an HTLC is
+// added to, settled within, or rejected by one of our channels, and each time
+// the switch is unable to forward an HTLC.
+func (r *rpcServer) SubscribeHtlcEvents(in *lnrpc.SubscribeHtlcEventsRequest,
+	updateStream lnrpc.Lightning_SubscribeHtlcEventsServer) error {
+
+	sub := r.server.htlcNotifier.subscribe()
+	defer sub.cancel()
+
+	for {
+		select {
+		case event := <-sub.events:
+			if err := updateStream.Send(marshallHtlcEvent(event)); err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SubscribePeerEvents streams an event to the client each time a peer
+// connects to, or disconnects from the daemon.
+func (r *rpcServer) SubscribePeerEvents(in *lnrpc.PeerEventSubscription,
+	updateStream lnrpc.Lightning_SubscribePeerEventsServer) error {
+
+	sub := r.server.peerNotifier.subscribe()
+	defer sub.cancel()
+
+	for {
+		select {
+		case event := <-sub.events:
+			eventType := lnrpc.PeerEvent_PEER_ONLINE
+			if event.eventType == peerEventOffline {
+				eventType = lnrpc.PeerEvent_PEER_OFFLINE
+			}
+
+			err := updateStream.Send(&lnrpc.PeerEvent{
+				Type:        eventType,
+				LightningId: hex.EncodeToString(event.lightningID[:]),
+				PeerId:      event.peerID,
+				Address:     event.address,
+				Timestamp:   event.timestamp.Unix(),
+			})
+			if err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallHtlcEvent converts an HTLC event into its RPC representation.
+func marshallHtlcEvent(event *htlcEvent) *lnrpc.HtlcEvent {
+	var eventType lnrpc.HtlcEvent_EventType
+	switch event.eventType {
+	case htlcEventForward:
+		eventType = lnrpc.HtlcEvent_FORWARD
+	case htlcEventSettle:
+		eventType = lnrpc.HtlcEvent_SETTLE
+	case htlcEventForwardFail:
+		eventType = lnrpc.HtlcEvent_FORWARD_FAIL
+	case htlcEventLinkFail:
+		eventType = lnrpc.HtlcEvent_LINK_FAIL
+	}
+
+	rpcEvent := &lnrpc.HtlcEvent{
+		EventType:     eventType,
+		Amt:           int64(event.amt),
+		PaymentHash:   event.paymentHash[:],
+		FailureCode:   lnrpc.FailureCode(event.failureCode),
+		FailureReason: event.failureReason,
+		CustomRecords: event.customRecords,
+		Timestamp:     event.timestamp.Unix(),
+	}
+	if event.incomingChanPoint != nil {
+		rpcEvent.IncomingChannelPoint = event.incomingChanPoint.String()
+		rpcEvent.IncomingChanId = event.incomingShortChanID.ToUint64()
+	}
+	if event.outgoingChanPoint != nil {
+		rpcEvent.OutgoingChannelPoint = event.outgoingChanPoint.String()
+		rpcEvent.OutgoingChanId = event.outgoingShortChanID.ToUint64()
+	}
+
+	return rpcEvent
+}
+
+// marshallPaymentUpdate converts an outgoing payment and its set of HTLC
+// attempts into the RPC representation sent to clients tracking the payment.
+func marshallPaymentUpdate(payment *channeldb.OutgoingPayment) *lnrpc.PaymentUpdate {
+	htlcs := make([]*lnrpc.HTLCAttempt, len(payment.Attempts))
+	for i, attempt := range payment.Attempts {
+		hops := make([]*lnrpc.Hop, len(attempt.Hops))
+		for j, hop := range attempt.Hops {
+			hops[j] = &lnrpc.Hop{
+				LightningId:  hex.EncodeToString(hop.NodeID[:]),
+				ChannelPoint: hop.ChannelPoint.String(),
+				AmtToForward: int64(hop.AmtToForward),
+			}
+		}
+
+		htlcs[i] = &lnrpc.HTLCAttempt{
+			AttemptId: attempt.AttemptID,
+			Status:    marshallPaymentStatus(attempt.Status),
+			Route: &lnrpc.Route{
+				TotalAmt: int64(payment.Amt),
+				Hops:     hops,
+			},
+			FailureSourceIndex: attempt.FailureSourceIndex,
+			FailureCode:        lnrpc.FailureCode(attempt.FailureCode),
+			FailureReason:      attempt.FailureReason,
+		}
+	}
+
+	update := &lnrpc.PaymentUpdate{
+		PaymentHash: payment.PaymentHash[:],
+		Status:      marshallPaymentStatus(payment.Status),
+		Htlcs:       htlcs,
+	}
+	if payment.Status == channeldb.StatusSucceeded {
+		update.Preimage = payment.Preimage[:]
+	}
+
+	return update
+}
+
+// marshallPaymentStatus converts a payment status as stored within the
+// database into its RPC representation.
+func marshallPaymentStatus(status channeldb.PaymentStatus) lnrpc.PaymentStatus {
+	switch status {
+	case channeldb.StatusInFlight:
+		return lnrpc.PaymentStatus_IN_FLIGHT
+	case channeldb.StatusSucceeded:
+		return lnrpc.PaymentStatus_SUCCEEDED
+	case channeldb.StatusFailed:
+		return lnrpc.PaymentStatus_FAILED
+	default:
+		return lnrpc.PaymentStatus_UNKNOWN
+	}
+}
+
+// marshallFeatures converts a feature vector into its RPC representation.
+func marshallFeatures(features *lnwire.FeatureVector) []*lnrpc.Feature {
+	if features == nil {
+		return nil
+	}
+
+	bits := features.Bits()
+	rpcFeatures := make([]*lnrpc.Feature, 0, len(bits))
+	for _, bit := range bits {
+		_, known := lnwire.Features[bit]
+		rpcFeatures = append(rpcFeatures, &lnrpc.Feature{
+			Bit:        uint32(bit),
+			Name:       bit.String(),
+			IsRequired: bit.IsRequired(),
+			IsKnown:    known,
+		})
+	}
+
+	return rpcFeatures
+}
+
+// GetNodeInfo returns the information we have on record for the target node
+// within the channel graph, along with each of its known channels. The node
+// may be identified by either its compressed public key, or its lightning ID.
+func (r *rpcServer) GetNodeInfo(ctx context.Context,
+	in *lnrpc.NodeInfoRequest) (*lnrpc.NodeInfo, error) {
+
+	rpcsLog.Debugf("[getnodeinfo] pub_key=%x", in.PubKey)
+
+	nodeID, err := parseNodeID(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := r.server.chanGraph.FetchLightningNode(&nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		totalCapacity btcutil.Amount
+		channels      []*lnrpc.ChannelEdge
+	)
+	err = r.server.chanGraph.ForEachNodeChannel(&nodeID,
+		func(edge *channeldb.ChannelEdge) error {
+			totalCapacity += edge.Capacity
+			channels = append(channels, marshallChannelEdge(edge))
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.NodeInfo{
+		Node: &lnrpc.LightningNode{
+			LightningId: hex.EncodeToString(node.LightningID[:]),
+			Addresses:   node.Addresses,
+			Alias:       node.Alias,
+			Features:    marshallFeatures(node.Features),
+			LastUpdate:  node.LastUpdate.Unix(),
+		},
+		NumChannels:   uint32(len(channels)),
+		TotalCapacity: int64(totalCapacity),
+		Channels:      channels,
+	}, nil
+}
+
+// GetNodeMetrics computes the centrality of every node within the channel
+// graph, allowing operators to evaluate their position within the network.
+// As the computation grows with the product of the number of nodes and edges,
+// the response is cached.
+func (r *rpcServer) GetNodeMetrics(ctx context.Context,
+	in *lnrpc.NodeMetricsRequest) (*lnrpc.NodeMetricsResponse, error) {
+
+	rpcsLog.Debugf("[getnodemetrics] eigenvector=%v", in.IncludeEigenvector)
+
+	key := "getnodemetrics"
+	if in.IncludeEigenvector {
+		key += "-eigenvector"
+	}
+	resp, err := r.server.rpcCache.fetch(key, func() (interface{}, error) {
+		return r.nodeMetrics(in.IncludeEigenvector)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*lnrpc.NodeMetricsResponse), nil
+}
+
+// nodeMetrics computes the response to a GetNodeMetrics request.
+func (r *rpcServer) nodeMetrics(includeEigenvector bool) (*lnrpc.NodeMetricsResponse, error) {
+	graph, err := newCentralityGraph(r.server.chanGraph)
+	if err != nil {
+		return nil, err
+	}
+
+	marshallMetric := func(values, normalized []float64) map[string]*lnrpc.FloatMetric {
+		metric := make(map[string]*lnrpc.FloatMetric, len(values))
+		for i, node := range graph.nodes {
+			metric[hex.EncodeToString(node[:])] = &lnrpc.FloatMetric{
+				Value:           values[i],
+				NormalizedValue: normalized[i],
+			}
+		}
+		return metric
+	}
+
+	resp := &lnrpc.NodeMetricsResponse{
+		BetweennessCentrality: marshallMetric(graph.betweenness()),
+	}
+	if includeEigenvector {
+		resp.EigenvectorCentrality = marshallMetric(graph.eigenvector())
+	}
+
+	return resp, nil
+}
+
+// GetChanInfo returns the edge within the channel graph identified by the
+// passed channel point, including the routing policies of both endpoints.
+func (r *rpcServer) GetChanInfo(ctx context.Context,
+	in *lnrpc.ChanInfoRequest) (*lnrpc.ChannelEdge, error) {
+
+	if in.ChanPoint == nil {
+		return nil, fmt.Errorf("channel point must be specified")
+	}
+
+	txid, err := wire.NewShaHash(in.ChanPoint.FundingTxid)
+	if err != nil {
+		rpcsLog.Errorf("[getchaninfo] invalid txid: %v", err)
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, in.ChanPoint.OutputIndex)
+
+	rpcsLog.Debugf("[getchaninfo] ChannelPoint(%v)", chanPoint)
+
+	edge, err := r.server.chanGraph.FetchChannelEdge(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshallChannelEdge(edge), nil
+}
+
+// LookupChannelID converts between the representations of one of our
+// channels: its channel point, compact short channel ID, and short channel ID
+// in height:txindex:output form. The short channel ID fields of the response
+// are left empty if the channel's funding transaction hasn't yet confirmed.
+func (r *rpcServer) LookupChannelID(ctx context.Context,
+	in *lnrpc.ChannelIDRequest) (*lnrpc.ChannelIDResponse, error) {
+
+	chanPoint, err := r.resolveChannelPoint(in.ChanPoint, in.ChanId,
+		in.Scid)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[lookupchannelid] ChannelPoint(%v)", chanPoint)
+
+	channel, err := r.server.chanDB.FetchChannel(chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.ChannelIDResponse{
+		ChannelPoint: chanPoint.String(),
+	}
+	if channel.ShortChanID != (lnwire.ShortChannelID{}) {
+		resp.ChanId = channel.ShortChanID.ToUint64()
+		resp.Scid = channel.ShortChanID.String()
+	}
+
+	return resp, nil
+}
+
+// resolveChannelPoint returns the channel point of the channel identified by
+// exactly one of the passed channel point, compact short channel ID, or short
+// channel ID in height:txindex:output form. Short channel IDs are resolved
+// against our confirmed channels.
+func (r *rpcServer) resolveChannelPoint(chanPoint *lnrpc.ChannelPoint,
+	chanID uint64, scid string) (*wire.OutPoint, error) {
+
+	var numSet int
+	if chanPoint != nil {
+		numSet++
+	}
+	if chanID != 0 {
+		numSet++
+	}
+	if scid != "" {
+		numSet++
+	}
+	if numSet != 1 {
+		return nil, fmt.Errorf("exactly one of channel point, chan " +
+			"id, or scid must be specified")
+	}
+
+	if chanPoint != nil {
+		txid, err := wire.NewShaHash(chanPoint.FundingTxid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txid: %v", err)
+		}
+		return wire.NewOutPoint(txid, chanPoint.OutputIndex), nil
+	}
+
+	shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+	if scid != "" {
+		var err error
+		shortChanID, err = lnwire.NewShortChanIDFromString(scid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	channel, err := r.server.chanDB.FetchChannelByShortID(shortChanID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find channel with short "+
+			"channel id %v: %v", shortChanID, err)
+	}
+
+	return channel.ChanID, nil
+}
+
+// marshallChannelEdge converts an edge within the channel graph into its RPC
+// representation.
+func marshallChannelEdge(edge *channeldb.ChannelEdge) *lnrpc.ChannelEdge {
+	return &lnrpc.ChannelEdge{
+		ChannelPoint: edge.ChannelPoint.String(),
+		Node1Id:      hex.EncodeToString(edge.Node1[:]),
+		Node2Id:      hex.EncodeToString(edge.Node2[:]),
+		Capacity:     int64(edge.Capacity),
+		Node1Policy:  marshallRoutingPolicy(edge.Node1Policy),
+		Node2Policy:  marshallRoutingPolicy(edge.Node2Policy),
+		LastUpdate:   edge.LastUpdate.Unix(),
+	}
+}
+
+// unmarshallRoutingPolicy converts an RPC routing policy into the policy
+// we'll advertise for one of our own channels. If no minimum HTLC is set,
+// then the default is used.
+func unmarshallRoutingPolicy(policy *lnrpc.RoutingPolicy) (*channeldb.ChannelEdgePolicy, error) {
+	if policy.TimeLockDelta == 0 {
+		return nil, fmt.Errorf("time lock delta must be positive")
+	}
+	if policy.FeeBase < 0 || policy.MinHtlc < 0 {
+		return nil, fmt.Errorf("fee base and min htlc can't be " +
+			"negative")
+	}
+
+	minHTLC := btcutil.Amount(policy.MinHtlc)
+	if minHTLC == 0 {
+		minHTLC = defaultMinHTLC
+	}
+
+	return &channeldb.ChannelEdgePolicy{
+		TimeLockDelta: policy.TimeLockDelta,
+		MinHTLC:       minHTLC,
+		FeeBase:       btcutil.Amount(policy.FeeBase),
+		FeeRate:       policy.FeeRate,
+	}, nil
+}
+
+// marshallRoutingPolicy converts the routing policy of one endpoint of a
+// channel into its RPC representation.
+func marshallRoutingPolicy(policy *channeldb.ChannelEdgePolicy) *lnrpc.RoutingPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &lnrpc.RoutingPolicy{
+		TimeLockDelta: policy.TimeLockDelta,
+		MinHtlc:       int64(policy.MinHTLC),
+		FeeBase:       int64(policy.FeeBase),
+		FeeRate:       policy.FeeRate,
+		LastUpdate:    policy.LastUpdate.Unix(),
+	}
+}
+
+// FeeReport returns the forwarding policy of each of our open channels, along
+// with the fees earned forwarding HTLCs over each channel within the trailing
+// day, week, and month, as recorded within the forwarding log. The fee of
+// each forward is attributed to the outgoing channel, as it's the policy of
+// that channel which set the fee.
+func (r *rpcServer) FeeReport(ctx context.Context,
+	in *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
+
+	rpcsLog.Tracef("[feereport]")
+
+	resp, err := r.server.rpcCache.fetch("feereport",
+		func() (interface{}, error) {
+			return r.feeReport()
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*lnrpc.FeeReportResponse), nil
+}
+
+// feeReport computes the response to a FeeReport request.
+func (r *rpcServer) feeReport() (*lnrpc.FeeReportResponse, error) {
+	channels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return nil, err
+	}
+
+	// The forwarding events of the past month are fetched in a single
+	// query, then bucketed into each of the trailing windows.
+	now := time.Now()
+	dayAgo := now.Add(-24 * time.Hour)
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	monthAgo := now.Add(-30 * 24 * time.Hour)
+	events, err := r.server.chanDB.FetchForwardingEvents(monthAgo, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.FeeReportResponse{
+		ChannelFees: make([]*lnrpc.ChannelFeeReport, 0, len(channels)),
+	}
+	chanReports := make(map[wire.OutPoint]*lnrpc.ChannelFeeReport,
+		len(channels))
+	for _, channel := range channels {
+		policy := channel.LocalPolicy
+		if policy == nil {
+			policy = defaultChannelPolicy()
+		}
+
+		report := &lnrpc.ChannelFeeReport{
+			ChannelPoint: channel.ChanID.String(),
+			Policy:       marshallRoutingPolicy(policy),
+		}
+		chanReports[*channel.ChanID] = report
+		resp.ChannelFees = append(resp.ChannelFees, report)
+	}
+
+	for _, event := range events {
+		fee := int64(event.Fee())
+
+		resp.MonthFeeSum += fee
+		if event.Timestamp.After(weekAgo) {
+			resp.WeekFeeSum += fee
+		}
+		if event.Timestamp.After(dayAgo) {
+			resp.DayFeeSum += fee
+		}
+
+		// The channel may have since been closed, in which case its
+		// fees only count towards the totals.
+		report, ok := chanReports[event.OutgoingChan]
+		if !ok {
+			continue
+		}
+		report.MonthFeeSum += fee
+		if event.Timestamp.After(weekAgo) {
+			report.WeekFeeSum += fee
+		}
+		if event.Timestamp.After(dayAgo) {
+			report.DayFeeSum += fee
+		}
+	}
+
+	return resp, nil
+}
+
+// adminPermissions maps each of the RPCs which list, bake or revoke macaroons
+// to the macaroon permission required to call it. Unlike the rest of the
+// lnrpc.Lightning service, these RPCs are gated regardless of the
+// requiremacaroons option.
+var adminPermissions = map[string]string{
+	"/lnrpc.Lightning/ListMacaroonIDs":       macaroons.PermissionAdmin,
+	"/lnrpc.Lightning/DeleteMacaroonID":      macaroons.PermissionAdmin,
+	"/lnrpc.Lightning/RotateMacaroonRootKey": macaroons.PermissionAdmin,
+	"/lnrpc.Lightning/BakeMacaroon":          macaroons.PermissionAdmin,
+}
+
+// lightningPermissions maps each of the services gated behind the lightning
+// macaroon once the requiremacaroons option is set to the permission required
+// to call it. The reflection service describes every method of the
+// lnrpc.Lightning service, so it's gated alongside it.
+var lightningPermissions = map[string]string{
+	"/lnrpc.Lightning/":                          macaroons.PermissionLightning,
+	"/grpc.reflection.v1alpha.ServerReflection/": macaroons.PermissionLightning,
+}
+
+// ListMacaroonIDs returns the IDs of the root keys macaroons are issued
+// under.
+func (r *rpcServer) ListMacaroonIDs(ctx context.Context,
+	in *lnrpc.ListMacaroonIDsRequest) (*lnrpc.ListMacaroonIDsResponse, error) {
+
+	rpcsLog.Tracef("[listmacaroonids]")
+
+	ids, err := r.server.macaroonService.RootKeyIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ListMacaroonIDsResponse{RootKeyIds: ids}, nil
+}
+
+// DeleteMacaroonID deletes the root key with the requested ID, revoking every
+// macaroon issued under it, while those issued under other root keys remain
+// valid.
+func (r *rpcServer) DeleteMacaroonID(ctx context.Context,
+	in *lnrpc.DeleteMacaroonIDRequest) (*lnrpc.DeleteMacaroonIDResponse, error) {
+
+	rpcsLog.Infof("[deletemacaroonid] root_key_id=%v", in.RootKeyId)
+
+	if isReservedRootKeyID(in.RootKeyId) {
+		return nil, fmt.Errorf("root key %v is reserved for the "+
+			"macaroons within the data directory", in.RootKeyId)
+	}
+
+	deleted, err := r.server.macaroonService.DeleteRootKey(in.RootKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeleteMacaroonIDResponse{Deleted: deleted}, nil
+}
+
+// RotateMacaroonRootKey deletes every root key other than the reserved ones,
+// revoking all previously baked macaroons. The dedicated macaroons written to
+// the data directory remain valid.
+func (r *rpcServer) RotateMacaroonRootKey(ctx context.Context,
+	in *lnrpc.RotateMacaroonRootKeyRequest) (*lnrpc.RotateMacaroonRootKeyResponse, error) {
+
+	rpcsLog.Infof("[rotatemacaroonrootkey]")
+
+	err := r.server.macaroonService.RotateRootKeys(reservedRootKeyIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.RotateMacaroonRootKeyResponse{}, nil
+}
+
+// BakeMacaroon issues a new macaroon granting the requested permissions under
+// the given root key. The macaroon may optionally be restricted to expire
+// after a timeout, and to be used only from within a range of IP addresses.
+func (r *rpcServer) BakeMacaroon(ctx context.Context,
+	in *lnrpc.BakeMacaroonRequest) (*lnrpc.BakeMacaroonResponse, error) {
+
+	rpcsLog.Infof("[bakemacaroon] permissions=%v, root_key_id=%v, "+
+		"timeout=%v, ip_range=%v, allow_uris=%v, deny_uris=%v",
+		in.Permissions, in.RootKeyId, in.Timeout, in.IpRange,
+		in.AllowUris, in.DenyUris)
+
+	if len(in.Permissions) == 0 {
+		return nil, fmt.Errorf("at least one permission must be granted")
+	}
+	for _, perm := range in.Permissions {
+		switch perm {
+		case macaroons.PermissionSigner, macaroons.PermissionRescue,
+			macaroons.PermissionLightning, macaroons.PermissionAdmin:
+		default:
+			return nil, fmt.Errorf("unknown permission: %v", perm)
+		}
+	}
+	if in.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must be positive")
+	}
+	if isReservedRootKeyID(in.RootKeyId) {
+		return nil, fmt.Errorf("root key %v is reserved for the "+
+			"macaroons within the data directory", in.RootKeyId)
+	}
+	for _, uris := range [][]string{in.AllowUris, in.DenyUris} {
+		for _, uri := range uris {
+			if err := validateMethodURI(uri); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var ipRange *net.IPNet
+	if in.IpRange != "" {
+		var err error
+		_, ipRange, err = net.ParseCIDR(in.IpRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ip range: %v", err)
+		}
+	}
+
+	m, err := r.server.macaroonService.NewMacaroon(in.RootKeyId,
+		in.Permissions...)
+	if err != nil {
+		return nil, err
+	}
+	if in.Timeout > 0 {
+		expiry := time.Now().Add(time.Duration(in.Timeout) * time.Second)
+		m.AddFirstPartyCaveat(macaroons.TimeBeforeCaveat(expiry))
+	}
+	if ipRange != nil {
+		m.AddFirstPartyCaveat(macaroons.IPRangeCaveat(ipRange))
+	}
+	if len(in.AllowUris) > 0 {
+		m.AddFirstPartyCaveat(macaroons.AllowURIsCaveat(in.AllowUris...))
+	}
+	if len(in.DenyUris) > 0 {
+		m.AddFirstPartyCaveat(macaroons.DenyURIsCaveat(in.DenyUris...))
+	}
+
+	serialized, err := m.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.BakeMacaroonResponse{
+		Macaroon: hex.EncodeToString(serialized),
+	}, nil
+}
+
+// validateMethodURI ensures that the passed string is the full URI of an RPC
+// method, of the form /package.Service/Method.
+func validateMethodURI(uri string) error {
+	parts := strings.Split(uri, "/")
+	if len(parts) != 3 || parts[0] != "" || parts[1] == "" ||
+		parts[2] == "" || strings.Contains(uri, ",") {
+
+		return fmt.Errorf("invalid method uri %q, expected the form "+
+			"/package.Service/Method", uri)
+	}
+
+	return nil
+}
+
+// SendOnionMessage sends a message to the last node along the passed path,
+// relayed within an onion message by each of the nodes before it.
+func (r *rpcServer) SendOnionMessage(ctx context.Context,
+	in *lnrpc.SendOnionMessageRequest) (*lnrpc.SendOnionMessageResponse, error) {
+
+	if len(in.Path) == 0 {
+		return nil, fmt.Errorf("onion message path must not be empty")
+	}
+	if in.Type < onionmsg.MinMessageType {
+		return nil, fmt.Errorf("onion message type must be at least %v",
+			onionmsg.MinMessageType)
+	}
+
+	route := make([]*btcec.PublicKey, len(in.Path))
+	for i, hexKey := range in.Path {
+		keyBytes, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, err
+		}
+		route[i], err = btcec.ParsePubKey(keyBytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rpcsLog.Infof("[sendonionmessage] type=%v, len=%v, hops=%v", in.Type,
+		len(in.Data), len(route))
+
+	msg := &onionmsg.Message{
+		Type: in.Type,
+		Data: in.Data,
+	}
+	if err := r.server.onionMessenger.sendMessage(route, msg); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendOnionMessageResponse{}, nil
+}
+
+// SubscribeOnionMessages returns a uni-directional stream which delivers each
+// onion message addressed to us as it's received.
+func (r *rpcServer) SubscribeOnionMessages(
+	in *lnrpc.SubscribeOnionMessagesRequest,
+	updateStream lnrpc.Lightning_SubscribeOnionMessagesServer) error {
+
+	sub := r.server.onionMessenger.subscribe()
+	defer sub.cancel()
+
+	for {
+		select {
+		case msg := <-sub.messages:
+			err := updateStream.Send(&lnrpc.OnionMessageUpdate{
+				Type: msg.Type,
+				Data: msg.Data,
+			})
+			if err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SendCustomMessage sends a custom message of an application protocol to the
+// connected peer with the passed lightning ID.
+func (r *rpcServer) SendCustomMessage(ctx context.Context,
+	in *lnrpc.SendCustomMessageRequest) (*lnrpc.SendCustomMessageResponse, error) {
+
+	if len(in.Data) > lnwire.MaxSliceLength {
+		return nil, fmt.Errorf("custom message data exceeds %v bytes",
+			lnwire.MaxSliceLength)
+	}
+	msg, err := lnwire.NewCustom(in.Type, in.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *peer
+	for _, serverPeer := range r.server.Peers() {
+		if hex.EncodeToString(serverPeer.lightningID[:]) == in.LightningId {
+			target = serverPeer
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("peer %v isn't connected", in.LightningId)
+	}
+
+	rpcsLog.Debugf("[sendcustommessage] peer=%v, type=%v, len=%v", target,
+		in.Type, len(in.Data))
+
+	target.queueMsg(msg, nil)
+
+	return &lnrpc.SendCustomMessageResponse{}, nil
+}
+
+// SubscribeCustomMessages returns a uni-directional stream which delivers each
+// custom message received from our peers.
+func (r *rpcServer) SubscribeCustomMessages(
+	in *lnrpc.SubscribeCustomMessagesRequest,
+	updateStream lnrpc.Lightning_SubscribeCustomMessagesServer) error {
+
+	sub := r.server.customMsgNotifier.subscribe()
+	defer sub.cancel()
+
+	for {
+		select {
+		case customMsg := <-sub.messages:
+			err := updateStream.Send(&lnrpc.CustomMessage{
+				LightningId: hex.EncodeToString(
+					customMsg.lightningID[:],
+				),
+				PeerId: customMsg.peerID,
+				Type:   customMsg.msg.Type,
+				Data:   customMsg.msg.Data,
+			})
+			if err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
 	}
-	return &lnrpc.ShowRoutingTableResponse{
-		Channels: channels,
-	}, nil
 }
@@ -0,0 +1,492 @@
+package main
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// mockServerStream is a server stream which only carries a context.
+type mockServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+// macaroonContext returns a context carrying the passed macaroon, as a
+// request made with it would.
+func macaroonContext(t *testing.T, m *macaroons.Macaroon) context.Context {
+	serialized, err := m.Serialize()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+
+	return metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(macaroons.MetadataKey,
+			hex.EncodeToString(serialized)))
+}
+
+// TestReflectionPermissions tests that once the lnrpc.Lightning service is
+// gated behind the lightning macaroon, the reflection service describing it
+// is gated alongside it.
+func TestReflectionPermissions(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "rpcserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	service, err := macaroons.NewService(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to create macaroon service: %v", err)
+	}
+	defer service.Close()
+
+	interceptor := service.StreamServerInterceptor(lightningPermissions)
+	info := &grpc.StreamServerInfo{
+		FullMethod: "/grpc.reflection.v1alpha.ServerReflection/" +
+			"ServerReflectionInfo",
+	}
+
+	// callReflection calls the reflection service with the passed
+	// macaroon, returning whether the call reached the service.
+	callReflection := func(m *macaroons.Macaroon) (bool, error) {
+		ctx := context.Background()
+		if m != nil {
+			ctx = macaroonContext(t, m)
+		}
+
+		var called bool
+		handler := func(interface{}, grpc.ServerStream) error {
+			called = true
+			return nil
+		}
+		err := interceptor(nil, &mockServerStream{ctx: ctx}, info,
+			handler)
+		return called, err
+	}
+
+	// Without a macaroon, the reflection service should be unreachable.
+	called, err := callReflection(nil)
+	if grpc.Code(err) != codes.PermissionDenied || called {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	// A macaroon for another service should be refused as well.
+	signerMac, err := service.NewMacaroon(signerRootKeyID,
+		macaroons.PermissionSigner)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	called, err = callReflection(signerMac)
+	if grpc.Code(err) != codes.PermissionDenied || called {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+
+	// The lightning macaroon should grant access.
+	lightningMac, err := service.NewMacaroon(lightningRootKeyID,
+		macaroons.PermissionLightning)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	called, err = callReflection(lightningMac)
+	if err != nil || !called {
+		t.Fatalf("lightning macaroon refused: %v", err)
+	}
+}
+
+// TestBakeMacaroonURIs tests that a macaroon baked with allowed or denied
+// URIs is only able to call the permitted methods of the lnrpc.Lightning
+// service.
+func TestBakeMacaroonURIs(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "rpcserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	service, err := macaroons.NewService(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to create macaroon service: %v", err)
+	}
+	defer service.Close()
+
+	r := &rpcServer{server: &server{macaroonService: service}}
+	interceptor := service.UnaryServerInterceptor(lightningPermissions)
+
+	const (
+		getInfo    = "/lnrpc.Lightning/GetInfo"
+		newAddress = "/lnrpc.Lightning/NewAddress"
+		sendCoins  = "/lnrpc.Lightning/SendCoins"
+	)
+
+	// Each of the methods must be served by the lnrpc.Lightning service.
+	lightningServer := reflect.TypeOf((*lnrpc.LightningServer)(nil)).Elem()
+	for _, uri := range []string{getInfo, newAddress, sendCoins} {
+		name := uri[strings.LastIndex(uri, "/")+1:]
+		if _, ok := lightningServer.MethodByName(name); !ok {
+			t.Fatalf("lnrpc.Lightning has no method %v", name)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		req     *lnrpc.BakeMacaroonRequest
+		allowed map[string]bool
+	}{
+		{
+			name: "allowed uris",
+			req: &lnrpc.BakeMacaroonRequest{
+				AllowUris: []string{getInfo, newAddress},
+			},
+			allowed: map[string]bool{
+				getInfo:    true,
+				newAddress: true,
+				sendCoins:  false,
+			},
+		},
+		{
+			name: "denied uris",
+			req: &lnrpc.BakeMacaroonRequest{
+				DenyUris: []string{sendCoins},
+			},
+			allowed: map[string]bool{
+				getInfo:    true,
+				newAddress: true,
+				sendCoins:  false,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test.req.Permissions = []string{macaroons.PermissionLightning}
+		resp, err := r.BakeMacaroon(context.Background(), test.req)
+		if err != nil {
+			t.Fatalf("%s: unable to bake macaroon: %v", test.name,
+				err)
+		}
+		serialized, err := hex.DecodeString(resp.Macaroon)
+		if err != nil {
+			t.Fatalf("%s: unable to decode macaroon: %v", test.name,
+				err)
+		}
+		m, err := macaroons.Deserialize(serialized)
+		if err != nil {
+			t.Fatalf("%s: unable to deserialize macaroon: %v",
+				test.name, err)
+		}
+
+		ctx := macaroonContext(t, m)
+		for uri, allowed := range test.allowed {
+			var called bool
+			handler := func(context.Context,
+				interface{}) (interface{}, error) {
+
+				called = true
+				return nil, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: uri}
+			_, err := interceptor(ctx, nil, info, handler)
+
+			switch {
+			case allowed && (err != nil || !called):
+				t.Fatalf("%s: call to %v refused: %v",
+					test.name, uri, err)
+			case !allowed && (grpc.Code(err) !=
+				codes.PermissionDenied || called):
+
+				t.Fatalf("%s: call to %v permitted",
+					test.name, uri)
+			}
+		}
+	}
+}
+
+// TestReservedRootKeys tests that the root keys of the dedicated macaroons
+// within the data directory can be neither deleted nor baked under, and that
+// they survive a rotation of the root keys.
+func TestReservedRootKeys(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "rpcserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	service, err := macaroons.NewService(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to create macaroon service: %v", err)
+	}
+	defer service.Close()
+
+	r := &rpcServer{server: &server{macaroonService: service}}
+	ctx := context.Background()
+
+	adminMac, err := service.NewMacaroon(adminRootKeyID,
+		macaroons.PermissionAdmin)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+	signerMac, err := service.NewMacaroon(signerRootKeyID,
+		macaroons.PermissionSigner)
+	if err != nil {
+		t.Fatalf("unable to issue macaroon: %v", err)
+	}
+
+	for _, id := range reservedRootKeyIDs {
+		_, err := r.DeleteMacaroonID(ctx,
+			&lnrpc.DeleteMacaroonIDRequest{RootKeyId: id})
+		if err == nil {
+			t.Fatalf("reserved root key %v deleted", id)
+		}
+		_, err = r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+			Permissions: []string{macaroons.PermissionAdmin},
+			RootKeyId:   id,
+		})
+		if err == nil {
+			t.Fatalf("macaroon baked under reserved root key %v", id)
+		}
+	}
+
+	// A macaroon baked under another root key is revoked by a rotation,
+	// while the dedicated macaroons remain valid.
+	resp, err := r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+		Permissions: []string{macaroons.PermissionLightning},
+		RootKeyId:   6,
+	})
+	if err != nil {
+		t.Fatalf("unable to bake macaroon: %v", err)
+	}
+	serialized, err := hex.DecodeString(resp.Macaroon)
+	if err != nil {
+		t.Fatalf("unable to decode macaroon: %v", err)
+	}
+	bakedMac, err := macaroons.Deserialize(serialized)
+	if err != nil {
+		t.Fatalf("unable to deserialize macaroon: %v", err)
+	}
+
+	_, err = r.RotateMacaroonRootKey(ctx,
+		&lnrpc.RotateMacaroonRootKeyRequest{})
+	if err != nil {
+		t.Fatalf("unable to rotate root keys: %v", err)
+	}
+	if err := service.CheckMacaroon(bakedMac); err == nil {
+		t.Fatalf("baked macaroon valid after rotation")
+	}
+	for _, m := range []*macaroons.Macaroon{adminMac, signerMac} {
+		if err := service.CheckMacaroon(m); err != nil {
+			t.Fatalf("dedicated macaroon rejected: %v", err)
+		}
+	}
+
+	// Listing the root key IDs requires the admin macaroon, just as
+	// deleting them does.
+	interceptor := service.UnaryServerInterceptor(adminPermissions)
+	info := &grpc.UnaryServerInfo{
+		FullMethod: "/lnrpc.Lightning/ListMacaroonIDs",
+	}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	_, err = interceptor(ctx, nil, info, handler)
+	if grpc.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+	_, err = interceptor(macaroonContext(t, adminMac), nil, info, handler)
+	if err != nil {
+		t.Fatalf("admin macaroon refused: %v", err)
+	}
+}
+
+// TestRescanWalletInvalidRequest tests that a rescan is refused if both a
+// start height and a birthday are requested, or if either is negative.
+func TestRescanWalletInvalidRequest(t *testing.T) {
+	r := &rpcServer{server: &server{}}
+
+	invalidReqs := []*lnrpc.RescanRequest{
+		{StartHeight: 100, BirthdayTimestamp: 1500000000},
+		{StartHeight: -1},
+		{BirthdayTimestamp: -1},
+	}
+	for _, req := range invalidReqs {
+		if err := r.RescanWallet(req, nil); err == nil {
+			t.Fatalf("invalid request %v accepted", req)
+		}
+	}
+}
+
+// TestCheckForceClose tests that a force close is only permitted once the
+// caller acknowledges that the peer is online, or that HTLCs are in flight,
+// as applicable.
+func TestCheckForceClose(t *testing.T) {
+	quit := make(chan struct{})
+	defer close(quit)
+
+	s := &server{
+		peers:   make(map[int32]*peer),
+		queries: make(chan interface{}),
+	}
+	p := &peer{
+		id:               1,
+		chanSnapshotReqs: make(chan *chanSnapshotReq),
+	}
+	s.peers[p.id] = p
+	r := &rpcServer{server: s}
+
+	idleChan := &wire.OutPoint{Hash: wire.ShaHash{1}}
+	htlcChan := &wire.OutPoint{Hash: wire.ShaHash{2}}
+	snapshots := []*channeldb.ChannelSnapshot{
+		{ChannelPoint: idleChan},
+		{
+			ChannelPoint: htlcChan,
+			Htlcs:        []channeldb.HTLC{{Amt: 1000}},
+		},
+	}
+
+	// Serve the queries of the server and the peer as their goroutines
+	// would.
+	go func() {
+		for {
+			select {
+			case msg := <-s.queries:
+				s.handleListPeers(msg.(*listPeersMsg))
+			case req := <-p.chanSnapshotReqs:
+				req.resp <- snapshots
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	tests := []struct {
+		name              string
+		chanPoint         *wire.OutPoint
+		allowOnlinePeer   bool
+		allowPendingHtlcs bool
+		allowed           bool
+	}{
+		{
+			name:              "inactive channel",
+			chanPoint:         &wire.OutPoint{Hash: wire.ShaHash{3}},
+			allowOnlinePeer:   true,
+			allowPendingHtlcs: true,
+		},
+		{
+			name:      "online peer",
+			chanPoint: idleChan,
+		},
+		{
+			name:            "online peer allowed",
+			chanPoint:       idleChan,
+			allowOnlinePeer: true,
+			allowed:         true,
+		},
+		{
+			name:            "pending htlcs",
+			chanPoint:       htlcChan,
+			allowOnlinePeer: true,
+		},
+		{
+			name:              "pending htlcs allowed",
+			chanPoint:         htlcChan,
+			allowPendingHtlcs: true,
+			allowed:           true,
+		},
+	}
+	for _, test := range tests {
+		err := r.checkForceClose(test.chanPoint, test.allowOnlinePeer,
+			test.allowPendingHtlcs)
+		switch {
+		case test.allowed && err != nil:
+			t.Fatalf("%s: force close refused: %v", test.name, err)
+		case !test.allowed && err == nil:
+			t.Fatalf("%s: force close permitted", test.name)
+		}
+	}
+}
+
+// TestParseRouteRestrictions tests that the last hop of a route may be
+// identified by either its public key or its lightning ID, and that unknown
+// outgoing channels and malformed node IDs are refused.
+func TestParseRouteRestrictions(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "rpcserver")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := channeldb.Open(tempDirName, &chaincfg.SimNetParams)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	r := &rpcServer{server: &server{chanDB: db}}
+
+	// Without any restrictions requested, none should be returned.
+	restrictions, err := r.parseRouteRestrictions(nil, nil)
+	if err != nil {
+		t.Fatalf("unable to parse restrictions: %v", err)
+	}
+	if restrictions != nil {
+		t.Fatalf("expected no restrictions, got %v", restrictions)
+	}
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	pubKey := privKey.PubKey().SerializeCompressed()
+	lightningID := fastsha256.Sum256(pubKey)
+
+	for _, id := range [][]byte{pubKey, lightningID[:]} {
+		restrictions, err := r.parseRouteRestrictions(nil, id)
+		if err != nil {
+			t.Fatalf("unable to parse last hop %x: %v", id, err)
+		}
+		if restrictions.lastHop == nil ||
+			*restrictions.lastHop != wire.ShaHash(lightningID) {
+
+			t.Fatalf("expected last hop %x, got %v", lightningID[:],
+				restrictions.lastHop)
+		}
+		if len(restrictions.outgoingChans) != 0 {
+			t.Fatalf("expected no outgoing channels, got %v",
+				restrictions.outgoingChans)
+		}
+	}
+
+	// A last hop of any other length must be refused.
+	if _, err := r.parseRouteRestrictions(nil, pubKey[:20]); err == nil {
+		t.Fatalf("malformed last hop accepted")
+	}
+
+	// An outgoing channel we don't have must be refused, rather than
+	// leaving the route unrestricted.
+	shortChanID := lnwire.ShortChannelID{BlockHeight: 1000, TxIndex: 1}
+	_, err = r.parseRouteRestrictions([]uint64{shortChanID.ToUint64()}, nil)
+	if err == nil {
+		t.Fatalf("unknown outgoing channel accepted")
+	}
+}
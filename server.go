@@ -2,19 +2,24 @@ package main
 
 import (
 	"bytes"
-	"encoding/hex"
 	"fmt"
+	"io"
 	"net"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/btcsuite/fastsha256"
+	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/chainntnfs"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lndc"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
-	"github.com/roasbeef/btcd/btcec"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/nat"
+	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 
 	"github.com/BitfuryLightning/tools/routing"
@@ -29,9 +34,10 @@ type server struct {
 	started  int32 // atomic
 	shutdown int32 // atomic
 
-	// identityPriv is the private key used to authenticate any incoming
-	// connections.
-	identityPriv *btcec.PrivateKey
+	// identityECDH is the node's long-term identity key, used to
+	// authenticate any incoming connections. The private key itself may
+	// reside within a remote signer.
+	identityECDH brontide.SingleKeyECDH
 
 	// lightningID is the sha256 of the public key corresponding to our
 	// long-term identity private key.
@@ -40,24 +46,113 @@ type server struct {
 	listeners []net.Listener
 	peers     map[int32]*peer
 
+	// announceAddrs is the set of addresses we advertise to the rest of
+	// the network as being reachable at within our node announcement.
+	announceMtx   sync.RWMutex
+	announceAddrs []string
+
+	// natTraversal is the NAT traversal technique used to discover our
+	// external IP address, and forward our listening ports from the local
+	// router, if NAT traversal was enabled.
+	natTraversal nat.Traversal
+
+	// externalIP is the last known external IP address of the local
+	// router, as reported by natTraversal.
+	externalIP net.IP
+
 	rpcServer *rpcServer
 
+	// rpcCache caches the responses of expensive read-only RPCs. It MUST
+	// be invalidated each time the channel graph is updated.
+	rpcCache *rpcCache
+
 	chainNotifier chainntnfs.ChainNotifier
 
 	bio      lnwallet.BlockChainIO
 	lnwallet *lnwallet.LightningWallet
 
+	// featureMgr is the source of all feature vectors we advertise.
+	featureMgr *featureManager
+
 	// TODO(roasbeef): add to constructor
 	fundingMgr *fundingManager
 	chanDB     *channeldb.DB
 
+	// chanGraph is the persistent view of the channel graph. It's stored
+	// separately from chanDB so it can be dropped and rebuilt without
+	// touching any channel state.
+	chanGraph *channeldb.ChannelGraph
+
+	// peerPolicies maps the lightning ID of a peer to the forwarding
+	// policy applied by default to new channels opened with them. The
+	// table is replaced whenever the configuration is reloaded.
+	peerPolicies map[wire.ShaHash]*channeldb.ChannelEdgePolicy
+	policyMtx    sync.RWMutex
+
+	// reloadMtx serializes reloads of the configuration. debugLevel is
+	// the debug level currently in effect.
+	reloadMtx  sync.Mutex
+	debugLevel string
+
+	// persistentPeers maps the lightning ID of each peer we keep a
+	// connection to at all times to its address. persistentDials is the
+	// set of those peers we're currently dialing.
+	persistentPeers map[wire.ShaHash]*lndc.LNAdr
+	persistentMtx   sync.Mutex
+	persistentDials map[wire.ShaHash]struct{}
+
+	// closeAddress, if non-nil, is the address our balance is delivered
+	// to upon the cooperative close of a channel, unless another is
+	// specified when the channel is opened or closed.
+	closeAddress btcutil.Address
+
+	// stalledLinks counts, for each lightning ID, the number of times a
+	// channel with the peer stalled mid commitment update, forcing the
+	// peer to be reconnected. The count survives the reconnection.
+	stalledMtx   sync.Mutex
+	stalledLinks map[wire.ShaHash]uint32
+
 	htlcSwitch *htlcSwitch
 	invoices   *invoiceRegistry
 
+	// beacon is the store of all HTLC preimages known to the daemon,
+	// shared between the switch and the on-chain resolution of HTLCs.
+	beacon *preimageBeacon
+
+	// htlcNotifier dispatches HTLC events to all subscribed clients.
+	htlcNotifier *htlcNotifier
+
+	// peerNotifier dispatches peer online/offline events to all
+	// subscribed clients.
+	peerNotifier *peerNotifier
+
+	// onionMessenger relays onion messages between our peers, and
+	// dispatches those addressed to us to all subscribed clients.
+	onionMessenger *onionMessenger
+
+	// customMsgNotifier dispatches the custom messages received from our
+	// peers to all subscribed clients.
+	customMsgNotifier *customMessageNotifier
+
+	// paymentCtrl drives all outgoing payments through their persisted
+	// lifecycle.
+	paymentCtrl *paymentController
+
 	routingMgr *routing.RoutingManager
 
 	utxoNursery *utxoNursery
 
+	// macaroonService manages the root keys macaroons are issued under.
+	macaroonService *macaroons.Service
+
+	// newSweepAddr returns the address the funds recovered from force
+	// closed channels are swept to.
+	newSweepAddr sweepAddrSource
+
+	// chainArb arbitrates the on-chain resolution of the contracts within
+	// our channels.
+	chainArb *chainArbitrator
+
 	newPeers  chan *peer
 	donePeers chan *peer
 	queries   chan interface{}
@@ -67,57 +162,265 @@ type server struct {
 }
 
 // newServer creates a new instance of the server which is to listen using the
-// passed listener address.
+// passed listener address. All connections are authenticated using the passed
+// identity key.
 func newServer(listenAddrs []string, notifier chainntnfs.ChainNotifier,
 	bio lnwallet.BlockChainIO, wallet *lnwallet.LightningWallet,
-	chanDB *channeldb.DB) (*server, error) {
+	identity brontide.SingleKeyECDH, chanDB *channeldb.DB,
+	chanGraph *channeldb.ChannelGraph,
+	macaroonService *macaroons.Service) (*server, error) {
 
-	privKey, err := wallet.GetIdentitykey()
+	peerPolicies, err := parsePeerPolicies(cfg.PeerPolicies)
 	if err != nil {
 		return nil, err
 	}
 
-	listeners := make([]net.Listener, len(listenAddrs))
-	for i, addr := range listenAddrs {
-		listeners[i], err = lndc.NewListener(privKey, addr)
+	persistentPeers, err := parsePersistentPeers(cfg.AddPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	var closeAddress btcutil.Address
+	if cfg.CloseAddress != "" {
+		closeAddress, err = parseDeliveryAddress(cfg.CloseAddress)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	serializedPubKey := privKey.PubKey().SerializeCompressed()
+	serializedPubKey := identity.PubKey().SerializeCompressed()
+	htlcNotifier := newHtlcNotifier()
 	s := &server{
 		bio:           bio,
 		chainNotifier: notifier,
 		chanDB:        chanDB,
-		fundingMgr:    newFundingManager(wallet),
-		htlcSwitch:    newHtlcSwitch(),
+		chanGraph:     chanGraph,
+		peerPolicies:  peerPolicies,
+		closeAddress:  closeAddress,
+		featureMgr:    newFeatureManager(cfg.WumboChannels),
+		fundingMgr:    newFundingManager(wallet, notifier, bio),
+		htlcSwitch:    newHtlcSwitch(htlcNotifier),
+		htlcNotifier:  htlcNotifier,
+		peerNotifier:  newPeerNotifier(),
 		invoices:      newInvoiceRegistry(),
 		lnwallet:      wallet,
-		identityPriv:  privKey,
+		identityECDH:  identity,
 		lightningID:   fastsha256.Sum256(serializedPubKey),
-		listeners:     listeners,
 		peers:         make(map[int32]*peer),
+		stalledLinks:  make(map[wire.ShaHash]uint32),
+		rpcCache:      newRPCCache(time.Duration(cfg.RPCCacheTTL) * time.Second),
 		newPeers:      make(chan *peer, 100),
 		donePeers:     make(chan *peer, 100),
 		queries:       make(chan interface{}),
 		quit:          make(chan struct{}),
 	}
+	s.persistentPeers = persistentPeers
+	s.persistentDials = make(map[wire.ShaHash]struct{})
+
+	// Each listener refuses connections from an IP address which has
+	// already reached the per-IP limit before carrying out the handshake.
+	s.listeners = make([]net.Listener, len(listenAddrs))
+	for i, addr := range listenAddrs {
+		s.listeners[i], err = brontide.NewListener(identity, addr,
+			s.filterInboundConn)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.debugLevel = cfg.DebugLevel
+
+	// Advertise all the externally reachable addresses we were configured
+	// with. If none were specified, and NAT traversal is enabled, then
+	// we'll attempt to discover our external IP from the local router.
+	s.announceAddrs = cfg.ExternalIPs
+	if cfg.NAT && len(s.announceAddrs) == 0 {
+		if err := s.discoverExternalAddrs(); err != nil {
+			srvrLog.Errorf("unable to discover external IP via "+
+				"NAT traversal: %v", err)
+		}
+	}
 
 	// TODO(roasbeef): remove
-	s.invoices.addInvoice(1000*1e8, *debugPre)
+	s.invoices.addInvoice(1000*1e8, *debugPre, defaultFinalCltvDelta,
+		[32]byte{})
+
+	s.beacon = newPreimageBeacon(s.invoices, chanDB)
+	s.newSweepAddr, err = newSweepAddrSource(wallet, chanDB)
+	if err != nil {
+		return nil, err
+	}
+	s.utxoNursery = newUtxoNursery(notifier, wallet, s.beacon.LookupPreimage,
+		s.newSweepAddr)
+
+	s.paymentCtrl = newPaymentController(chanDB, chanGraph, bio,
+		s.lightningID, s.htlcSwitch, uint32(cfg.MaxCltvExpiry))
 
-	s.utxoNursery = newUtxoNursery(notifier, wallet)
+	s.chainArb = newChainArbitrator(notifier, wallet, chanDB,
+		s.utxoNursery, s.htlcSwitch, s.beacon, s.paymentCtrl,
+		uint32(cfg.IncomingBroadcastDelta),
+		uint32(cfg.OutgoingBroadcastDelta))
 
 	// Create a new routing manager with ourself as the sole node within
 	// the graph.
 	s.routingMgr = routing.NewRoutingManager(graph.NewID(s.lightningID), nil)
 
+	s.onionMessenger = newOnionMessenger(identity)
+	s.customMsgNotifier = newCustomMessageNotifier()
+	s.macaroonService = macaroonService
 	s.rpcServer = newRpcServer(s)
 
 	return s, nil
 }
 
+// discoverExternalAddrs attempts to locate a UPnP or NAT-PMP enabled device on
+// the local network in order to learn our external IP address. If found, the
+// external IP is advertised using the port of each of our listeners.
+func (s *server) discoverExternalAddrs() error {
+	srvrLog.Infof("Scanning local network for a UPnP enabled device")
+	natTraversal, err := nat.DiscoverUPnP()
+	if err != nil {
+		srvrLog.Infof("Unable to discover a UPnP enabled device: %v, "+
+			"falling back to NAT-PMP", err)
+
+		natTraversal, err = nat.DiscoverPMP()
+		if err != nil {
+			return err
+		}
+	}
+
+	externalIP, err := natTraversal.ExternalIP()
+	if err != nil {
+		return err
+	}
+
+	srvrLog.Infof("Discovered external IP %v using %v", externalIP,
+		natTraversal.Name())
+
+	s.natTraversal = natTraversal
+	s.setExternalIP(externalIP)
+
+	return nil
+}
+
+// listenPorts returns the set of unique ports our listeners are bound to.
+func (s *server) listenPorts() []uint16 {
+	var ports []uint16
+	seen := make(map[uint16]struct{})
+	for _, l := range s.listeners {
+		port := uint16(l.Addr().(*net.TCPAddr).Port)
+		if _, ok := seen[port]; ok {
+			continue
+		}
+		seen[port] = struct{}{}
+
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// setExternalIP replaces the set of addresses we advertise with the passed
+// external IP address combined with the port of each of our listeners.
+func (s *server) setExternalIP(externalIP net.IP) {
+	var addrs []string
+	for _, port := range s.listenPorts() {
+		addr := net.JoinHostPort(externalIP.String(),
+			strconv.Itoa(int(port)))
+		addrs = append(addrs, addr)
+	}
+
+	s.externalIP = externalIP
+
+	s.announceMtx.Lock()
+	s.announceAddrs = addrs
+	s.announceMtx.Unlock()
+}
+
+// advertisedAddrs returns the set of addresses we currently advertise to the
+// rest of the network.
+func (s *server) advertisedAddrs() []string {
+	s.announceMtx.RLock()
+	defer s.announceMtx.RUnlock()
+
+	addrs := make([]string, len(s.announceAddrs))
+	copy(addrs, s.announceAddrs)
+	return addrs
+}
+
+// addSelfNode adds our own node, along with the set of addresses we currently
+// advertise, to the channel graph. If our node is already present, then its
+// entry is updated.
+func (s *server) addSelfNode() error {
+	selfNode := &channeldb.LightningNode{
+		LightningID: s.lightningID,
+		Addresses:   s.advertisedAddrs(),
+		Alias:       cfg.Alias,
+		Features:    s.featureMgr.get(featureSetNodeAnn),
+		LastUpdate:  time.Now(),
+	}
+
+	return s.chanGraph.AddLightningNode(selfNode)
+}
+
+// forwardPorts requests a port mapping from the local router for each of the
+// ports we're listening on.
+func (s *server) forwardPorts() {
+	for _, port := range s.listenPorts() {
+		if err := s.natTraversal.AddPortMapping(port); err != nil {
+			srvrLog.Errorf("unable to forward port %d using %v: %v",
+				port, s.natTraversal.Name(), err)
+			continue
+		}
+
+		srvrLog.Debugf("Forwarded port %d using %v", port,
+			s.natTraversal.Name())
+	}
+}
+
+// natMaintenance is a goroutine which periodically refreshes the leases of
+// our port mappings before they expire. Additionally, if the external IP of
+// the local router changes, then the set of addresses we advertise to the
+// network is updated to reflect the new IP.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) natMaintenance() {
+	defer s.wg.Done()
+
+	refreshTicker := time.NewTicker(nat.MappingLifetime / 2)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-refreshTicker.C:
+			s.forwardPorts()
+
+			externalIP, err := s.natTraversal.ExternalIP()
+			if err != nil {
+				srvrLog.Errorf("unable to query external IP "+
+					"using %v: %v", s.natTraversal.Name(), err)
+				continue
+			}
+			if externalIP.Equal(s.externalIP) {
+				continue
+			}
+
+			srvrLog.Infof("External IP changed from %v to %v, "+
+				"updating advertised addresses", s.externalIP,
+				externalIP)
+
+			s.setExternalIP(externalIP)
+			if err := s.addSelfNode(); err != nil {
+				srvrLog.Errorf("unable to update self node: %v",
+					err)
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
 // Start starts the main daemon server, all requested listeners, and any helper
 // goroutines.
 func (s *server) Start() error {
@@ -153,11 +456,49 @@ func (s *server) Start() error {
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
+	if err := s.chainArb.Start(); err != nil {
+		return err
+	}
+
+	// Ensure our own node, along with all of our own channels, is
+	// present within the channel graph so they can be used as the first
+	// hop of any outgoing payment.
+	if err := s.addSelfNode(); err != nil {
+		return err
+	}
+	if _, err := s.addOwnChannels(); err != nil {
+		return err
+	}
+
+	// Reconcile the state of any payments which were in flight when we
+	// last shut down.
+	if err := s.paymentCtrl.resumePayments(); err != nil {
+		return err
+	}
 	s.routingMgr.Start()
 
+	// If we discovered a NAT device on the local network, then forward
+	// each of our listening ports so peers are able to reach us, and
+	// keep the mappings alive for as long as we're running.
+	if s.natTraversal != nil {
+		s.forwardPorts()
+
+		s.wg.Add(1)
+		go s.natMaintenance()
+	}
+
 	s.wg.Add(1)
 	go s.queryHandler()
 
+	s.wg.Add(1)
+	go s.reloadHandler()
+
+	// With the query handler running, dial each of the peers we're to
+	// remain connected to.
+	for peerID := range s.persistentPeers {
+		s.connectPersistentPeer(peerID)
+	}
+
 	return nil
 }
 
@@ -183,6 +524,7 @@ func (s *server) Stop() error {
 	s.fundingMgr.Stop()
 	s.routingMgr.Stop()
 	s.htlcSwitch.Stop()
+	s.chainArb.Stop()
 	s.utxoNursery.Stop()
 
 	s.lnwallet.Shutdown()
@@ -191,6 +533,18 @@ func (s *server) Stop() error {
 	close(s.quit)
 	s.wg.Wait()
 
+	// Now that our NAT maintenance goroutine has exited, remove any port
+	// mappings we added to the local router.
+	if s.natTraversal != nil {
+		for _, port := range s.natTraversal.ForwardedPorts() {
+			err := s.natTraversal.DeletePortMapping(port)
+			if err != nil {
+				srvrLog.Errorf("unable to remove forwarding "+
+					"for port %d: %v", port, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -212,7 +566,153 @@ func (s *server) addPeer(p *peer) {
 		return
 	}
 
+	// Inbound peers are only admitted while a connection slot is free.
+	if p.inbound && !s.admitInboundPeer(p) {
+		p.Disconnect()
+		return
+	}
+
 	s.peers[p.id] = p
+	s.peerNotifier.notify(peerEventOnline, p)
+	s.onionMessenger.addPeer(p)
+
+	// Record the newly connected peer within the channel graph, or update
+	// its address if we've already seen it.
+	node := &channeldb.LightningNode{
+		LightningID: p.lightningID,
+		Addresses:   []string{p.conn.RemoteAddr().String()},
+		Features:    p.remoteFeatures,
+		LastUpdate:  time.Now(),
+	}
+	if err := s.chanGraph.AddLightningNode(node); err != nil {
+		srvrLog.Errorf("unable to add node to graph: %v", err)
+	}
+}
+
+// rebuildGraph drops the entire contents of the channel graph, then re-seeds
+// it using our own set of open channels as recorded within the channel
+// database. The number of edges restored to the graph is returned.
+func (s *server) rebuildGraph() (int, error) {
+	defer s.rpcCache.invalidate()
+
+	if err := s.chanGraph.Drop(); err != nil {
+		return 0, err
+	}
+
+	numEdges, err := s.addOwnChannels()
+	if err != nil {
+		return 0, err
+	}
+
+	srvrLog.Infof("Channel graph rebuilt with %v edges", numEdges)
+
+	return numEdges, nil
+}
+
+// importGraphSnapshot adds the nodes and edges within the passed graph
+// snapshot to the channel graph, returning the number of each added. The edges
+// of our own channels are re-added afterwards, so our view of them is never
+// replaced by that of the snapshot.
+func (s *server) importGraphSnapshot(r io.Reader) (int, int, error) {
+	defer s.rpcCache.invalidate()
+
+	numNodes, numEdges, err := s.chanGraph.ImportSnapshot(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := s.addOwnChannels(); err != nil {
+		return 0, 0, err
+	}
+
+	srvrLog.Infof("Imported %v nodes and %v edges from graph snapshot",
+		numNodes, numEdges)
+
+	return numNodes, numEdges, nil
+}
+
+// addOwnChannels adds an edge to the channel graph for each of our open
+// channels as recorded within the channel database. Any existing edges for
+// these channels are overwritten. The number of edges added is returned.
+func (s *server) addOwnChannels() (int, error) {
+	channels, err := s.chanDB.FetchAllChannels()
+	if err != nil && err != channeldb.ErrNoActiveChannels {
+		return 0, err
+	}
+
+	for _, channel := range channels {
+		// Channels opened before per-channel policies were supported
+		// don't have a policy of their own, and use the default.
+		policy := channel.LocalPolicy
+		if policy == nil {
+			policy = defaultChannelPolicy()
+		}
+
+		edge := &channeldb.ChannelEdge{
+			ChannelPoint: *channel.ChanID,
+			Node1:        s.lightningID,
+			Node2:        channel.TheirLNID,
+			Capacity:     channel.Capacity,
+			Node1Policy:  policy,
+			LastUpdate:   time.Now(),
+		}
+		if err := s.chanGraph.AddChannelEdge(edge); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(channels), nil
+}
+
+const (
+	// defaultTimeLockDelta is the number of blocks we require between the
+	// expiry of an incoming HTLC and the HTLC we forward out over one of
+	// our channels.
+	defaultTimeLockDelta = 144
+
+	// defaultMinHTLC is the smallest HTLC we'll forward over one of our
+	// channels.
+	defaultMinHTLC = btcutil.Amount(1)
+
+	// defaultFeeBase is the fixed fee we charge for each HTLC forwarded
+	// over one of our channels.
+	defaultFeeBase = btcutil.Amount(1)
+
+	// defaultFeeRate is the proportional fee, in millionths, we charge for
+	// each HTLC forwarded over one of our channels.
+	defaultFeeRate = 1
+)
+
+// defaultChannelPolicy returns the routing policy we advertise for each of
+// our own channels.
+func defaultChannelPolicy() *channeldb.ChannelEdgePolicy {
+	return &channeldb.ChannelEdgePolicy{
+		TimeLockDelta: defaultTimeLockDelta,
+		MinHTLC:       defaultMinHTLC,
+		FeeBase:       defaultFeeBase,
+		FeeRate:       defaultFeeRate,
+		LastUpdate:    time.Now(),
+	}
+}
+
+// addChannelEdge records a newly opened channel between ourselves and the
+// target node within the channel graph, advertising the passed forwarding
+// policy for the channel.
+func (s *server) addChannelEdge(chanPoint *wire.OutPoint, remoteID wire.ShaHash,
+	capacity btcutil.Amount, policy *channeldb.ChannelEdgePolicy) {
+
+	edge := &channeldb.ChannelEdge{
+		ChannelPoint: *chanPoint,
+		Node1:        s.lightningID,
+		Node2:        remoteID,
+		Capacity:     capacity,
+		Node1Policy:  policy,
+		LastUpdate:   time.Now(),
+	}
+	if err := s.chanGraph.AddChannelEdge(edge); err != nil {
+		srvrLog.Errorf("unable to add ChannelPoint(%v) to graph: %v",
+			chanPoint, err)
+	}
+	s.rpcCache.invalidate()
 }
 
 // removePeer removes the passed peer from the server's state of all active
@@ -230,7 +730,16 @@ func (s *server) removePeer(p *peer) {
 		return
 	}
 
+	if _, ok := s.peers[p.id]; !ok {
+		return
+	}
+
 	delete(s.peers, p.id)
+	s.peerNotifier.notify(peerEventOffline, p)
+	s.onionMessenger.removePeer(p)
+
+	// If we're to remain connected to the peer, then re-dial it.
+	s.connectPersistentPeer(p.lightningID)
 }
 
 // connectPeerMsg is a message requesting the server to open a connection to a
@@ -264,6 +773,29 @@ type openChanReq struct {
 
 	numConfs uint32
 
+	// private indicates that the channel shouldn't be announced to the
+	// rest of the network.
+	private bool
+
+	// leaseExpiry, if non-zero, is the absolute block height until which
+	// our funds are locked within the channel.
+	leaseExpiry uint32
+
+	// policy, if non-nil, is the forwarding policy to advertise for the
+	// channel, overriding any per-peer or default policy.
+	policy *channeldb.ChannelEdgePolicy
+
+	// maxPendingAmt and maxAcceptedHtlcs, if non-zero, override the
+	// configured limits on the total value and number of HTLC's in flight
+	// within the channel.
+	maxPendingAmt    btcutil.Amount
+	maxAcceptedHtlcs uint16
+
+	// closeAddress, if non-nil, is the address our balance is delivered
+	// to upon a cooperative close, which we commit to as an upfront
+	// shutdown script. It overrides the configured close address.
+	closeAddress btcutil.Address
+
 	updates chan *lnrpc.OpenStatusUpdate
 	err     chan error
 }
@@ -274,6 +806,14 @@ type openChanReq struct {
 //
 // NOTE: This MUST be run as a goroutine.
 func (s *server) queryHandler() {
+	// Routing messages are accumulated within the batch, then released
+	// to their recipients in a single trickle broadcast each interval.
+	gossipBatch := newAnnouncementBatch()
+	trickleTicker := time.NewTicker(
+		time.Duration(cfg.TrickleDelay) * time.Millisecond,
+	)
+	defer trickleTicker.Stop()
+
 out:
 	for {
 		select {
@@ -294,6 +834,8 @@ out:
 				s.handleListPeers(msg)
 			case *openChanReq:
 				s.handleOpenChanReq(msg)
+			case *inboundConnMsg:
+				s.handleInboundConn(msg)
 			}
 		case msg := <-s.routingMgr.ChOut:
 			msg1 := msg.(*routing.RoutingMessage)
@@ -301,21 +843,13 @@ out:
 				peerLog.Critical("msg1.GetReceiverID() == nil")
 			}
 			receiverID := msg1.ReceiverID.ToByte32()
-			var targetPeer *peer
-			for _, peer := range s.peers { // TODO: threadsafe api
-				// We found the the target
-				if peer.lightningID == receiverID {
-					targetPeer = peer
-					break
-				}
-			}
-			if targetPeer != nil {
-				fndgLog.Info("Peer found. Sending message")
-				done := make(chan struct{}, 1)
-				targetPeer.queueMsg(msg1.Msg, done)
-			} else {
-				srvrLog.Errorf("Can't find peer to send message %v", receiverID)
+			if _, err := gossipBatch.add(receiverID, msg1.Msg); err != nil {
+				srvrLog.Errorf("unable to queue routing message: %v",
+					err)
 			}
+		case <-trickleTicker.C:
+			s.broadcastBatch(gossipBatch)
+			gossipBatch.reset()
 		case <-s.quit:
 			break out
 		}
@@ -324,6 +858,38 @@ out:
 	s.wg.Done()
 }
 
+// broadcastBatch sends each message within the passed batch to its target
+// peer. Messages destined to peers which are no longer connected are dropped.
+func (s *server) broadcastBatch(batch *announcementBatch) {
+	numMsgs := batch.len()
+	if numMsgs == 0 {
+		return
+	}
+
+	srvrLog.Debugf("Broadcasting batch of %v routing messages, %v "+
+		"duplicates suppressed", numMsgs, batch.numDuplicates)
+
+	// Index the peers by their lightning ID up front, rather than
+	// scanning the set of peers for each message.
+	peersByID := make(map[[32]byte]*peer, len(s.peers))
+	for _, peer := range s.peers {
+		peersByID[peer.lightningID] = peer
+	}
+
+	for receiverID, msgs := range batch.msgs {
+		targetPeer, ok := peersByID[receiverID]
+		if !ok {
+			srvrLog.Errorf("Can't find peer %x to send %v routing "+
+				"messages", receiverID[:], len(msgs))
+			continue
+		}
+
+		for _, msg := range msgs {
+			targetPeer.queueMsg(msg, nil)
+		}
+	}
+}
+
 // handleListPeers sends a lice of all currently active peers to the original
 // caller.
 func (s *server) handleListPeers(msg *listPeersMsg) {
@@ -359,26 +925,27 @@ func (s *server) handleConnectPeer(msg *connectPeerMsg) {
 	// TODO(roasbeef): semaphore to limit the number of goroutines for
 	// async requests.
 	go func() {
-		// For the lndc crypto handshake, we
-		// either need a compressed pubkey, or a
-		// 20-byte pkh.
-		var remoteId []byte
+		// The brontide handshake requires that we know the static
+		// public key of the remote node up front, as the very first
+		// act is encrypted to it.
 		if addr.PubKey == nil {
-			remoteId = addr.Base58Adr.ScriptAddress()
-		} else {
-			remoteId = addr.PubKey.SerializeCompressed()
+			msg.err <- fmt.Errorf("the remote node's public key " +
+				"is required to establish a connection")
+			msg.resp <- -1
+			return
 		}
 
-		srvrLog.Debugf("connecting to %v", hex.EncodeToString(remoteId))
+		srvrLog.Debugf("connecting to %x",
+			addr.PubKey.SerializeCompressed())
+
 		// Attempt to connect to the remote
 		// node. If the we can't make the
 		// connection, or the crypto negotation
 		// breaks down, then return an error to the
 		// caller.
 		ipAddr := addr.NetAddr.String()
-		conn := lndc.NewConn(nil)
-		if err := conn.Dial(
-			s.identityPriv, ipAddr, remoteId); err != nil {
+		conn, err := brontide.Dial(s.identityECDH, addr.PubKey, ipAddr)
+		if err != nil {
 			msg.err <- err
 			msg.resp <- -1
 			return
@@ -396,7 +963,13 @@ func (s *server) handleConnectPeer(msg *connectPeerMsg) {
 			return
 		}
 
-		peer.Start()
+		if err := peer.Start(); err != nil {
+			srvrLog.Errorf("unable to start peer: %v", err)
+			conn.Close()
+			msg.resp <- -1
+			msg.err <- err
+			return
+		}
 		s.newPeers <- peer
 
 		msg.resp <- peer.id
@@ -446,10 +1019,76 @@ func (s *server) ConnectToPeer(addr *lndc.LNAdr) (int32, error) {
 	return <-reply, <-errChan
 }
 
+const (
+	// stallReconnectAttempts is the number of times we'll attempt to
+	// reconnect to a peer after disconnecting due to a stalled channel.
+	stallReconnectAttempts = 3
+
+	// stallReconnectBackoff is the time we wait before each attempt to
+	// reconnect to a stalled peer.
+	stallReconnectBackoff = 5 * time.Second
+)
+
+// reconnectStalledPeer disconnects a peer with which one of our channels has
+// stalled in the midst of a commitment update. If we initiated the connection
+// to the peer, then it's re-established, otherwise it's left to the remote
+// peer to reconnect. The new session causes both sides to re-send their
+// revocation window, and any pending updates to be committed afresh.
+func (s *server) reconnectStalledPeer(p *peer) {
+	// Several of the peer's channels may stall at once, though the peer
+	// is only reconnected, and the event recorded, a single time.
+	if !atomic.CompareAndSwapInt32(&p.stalled, 0, 1) {
+		return
+	}
+
+	s.stalledMtx.Lock()
+	s.stalledLinks[p.lightningID]++
+	s.stalledMtx.Unlock()
+
+	p.Disconnect()
+
+	if p.inbound {
+		return
+	}
+
+	go func() {
+		addr := p.lightningAddr
+		for i := 0; i < stallReconnectAttempts; i++ {
+			// Back off before each attempt, giving the server a
+			// chance to remove the stalled peer.
+			select {
+			case <-time.After(stallReconnectBackoff):
+			case <-s.quit:
+				return
+			}
+
+			_, err := s.ConnectToPeer(addr)
+			if err == nil {
+				return
+			}
+
+			srvrLog.Errorf("unable to reconnect to stalled peer "+
+				"%v: %v", addr, err)
+		}
+	}()
+}
+
+// NumStalledLinks returns the number of times a channel with the peer
+// identified by the passed lightning ID has stalled, forcing a reconnection.
+func (s *server) NumStalledLinks(lightningID wire.ShaHash) uint32 {
+	s.stalledMtx.Lock()
+	defer s.stalledMtx.Unlock()
+
+	return s.stalledLinks[lightningID]
+}
+
 // OpenChannel sends a request to the server to open a channel to the specified
 // peer identified by ID with the passed channel funding paramters.
 func (s *server) OpenChannel(peerID int32, nodeID []byte, localAmt, remoteAmt btcutil.Amount,
-	numConfs uint32) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	numConfs uint32, private bool, leaseExpiry uint32,
+	policy *channeldb.ChannelEdgePolicy, maxPendingAmt btcutil.Amount,
+	maxAcceptedHtlcs uint16,
+	closeAddress btcutil.Address) (chan *lnrpc.OpenStatusUpdate, chan error) {
 
 	errChan := make(chan error, 1)
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
@@ -459,6 +1098,12 @@ func (s *server) OpenChannel(peerID int32, nodeID []byte, localAmt, remoteAmt bt
 		localFundingAmt:  localAmt,
 		remoteFundingAmt: remoteAmt,
 		numConfs:         numConfs,
+		private:          private,
+		leaseExpiry:      leaseExpiry,
+		policy:           policy,
+		maxPendingAmt:    maxPendingAmt,
+		maxAcceptedHtlcs: maxAcceptedHtlcs,
+		closeAddress:     closeAddress,
 		updates:          updateChan,
 		err:              errChan,
 	}
@@ -478,12 +1123,21 @@ func (s *server) Peers() []*peer {
 	return <-resp
 }
 
+// maxPendingInbound is the maximum number of inbound peers which may be
+// started concurrently, each awaiting the remote peer's Init message.
+const maxPendingInbound = 100
+
 // listener is a goroutine dedicated to accepting in coming peer connections
 // from the passed listener.
 //
 // NOTE: This MUST be run as a goroutine.
 func (s *server) listener(l net.Listener) {
 	srvrLog.Infof("Server listening on %s", l.Addr())
+
+	// pending bounds the number of inbound peers which are in the process
+	// of being started.
+	pending := make(chan struct{}, maxPendingInbound)
+
 	for atomic.LoadInt32(&s.shutdown) == 0 {
 		conn, err := l.Accept()
 		if err != nil {
@@ -495,16 +1149,36 @@ func (s *server) listener(l net.Listener) {
 			continue
 		}
 
-		srvrLog.Tracef("New inbound connection from %v", conn.RemoteAddr())
-		peer, err := newPeer(conn, s, activeNetParams.Net, true)
-		if err != nil {
-			srvrLog.Errorf("unable to create peer: %v", err)
+		// Starting the peer waits for the remote peer's Init message,
+		// so each peer is started within its own goroutine, ensuring
+		// a slow or silent peer doesn't block the connections behind
+		// it. Once maxPendingInbound peers are starting, we wait for
+		// one of them to finish before starting another.
+		select {
+		case pending <- struct{}{}:
+		case <-s.quit:
 			conn.Close()
 			continue
 		}
 
-		peer.Start()
-		s.newPeers <- peer
+		srvrLog.Tracef("New inbound connection from %v", conn.RemoteAddr())
+		go func(conn net.Conn) {
+			defer func() { <-pending }()
+
+			peer, err := newPeer(conn, s, activeNetParams.Net, true)
+			if err != nil {
+				srvrLog.Errorf("unable to create peer: %v", err)
+				conn.Close()
+				return
+			}
+
+			if err := peer.Start(); err != nil {
+				srvrLog.Errorf("unable to start peer: %v", err)
+				conn.Close()
+				return
+			}
+			s.newPeers <- peer
+		}(conn)
 	}
 
 	s.wg.Done()
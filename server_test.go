@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestReconnectStalledPeer tests that a peer whose channels stall is
+// disconnected and counted only once per session, and that the count for the
+// node survives across sessions.
+func TestReconnectStalledPeer(t *testing.T) {
+	s := &server{
+		htlcSwitch:   newHtlcSwitch(newHtlcNotifier()),
+		stalledLinks: make(map[wire.ShaHash]uint32),
+		donePeers:    make(chan *peer, 10),
+		quit:         make(chan struct{}),
+	}
+	defer close(s.quit)
+	if err := s.htlcSwitch.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.htlcSwitch.Stop()
+
+	// The peers are inbound, so they're left to reconnect to us rather
+	// than redialed.
+	nodeID := wire.ShaHash{1}
+	newSession := func(id int32) *peer {
+		return &peer{
+			id:          id,
+			lightningID: nodeID,
+			server:      s,
+			inbound:     true,
+			quit:        make(chan struct{}),
+		}
+	}
+	assertStalls := func(numStalls uint32) {
+		if n := s.NumStalledLinks(nodeID); n != numStalls {
+			t.Fatalf("expected %v stalls, got %v", numStalls, n)
+		}
+	}
+	assertDisconnected := func(p *peer) {
+		select {
+		case done := <-s.donePeers:
+			if done != p {
+				t.Fatalf("wrong peer disconnected")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("stalled peer not disconnected")
+		}
+	}
+
+	// Several channels of the same session stalling only count once.
+	p := newSession(1)
+	s.reconnectStalledPeer(p)
+	s.reconnectStalledPeer(p)
+	assertDisconnected(p)
+	assertStalls(1)
+
+	// A stall within the next session of the same node adds to the count.
+	p = newSession(2)
+	s.reconnectStalledPeer(p)
+	assertDisconnected(p)
+	assertStalls(2)
+
+	if n := s.NumStalledLinks(wire.ShaHash{2}); n != 0 {
+		t.Fatalf("expected no stalls for other node, got %v", n)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcutil"
+)
+
+// parseDeliveryAddress decodes an address our balance may be delivered to
+// upon the cooperative close of a channel. Only addresses whose public key
+// scripts are permitted within the delivery script of the funding and close
+// messages are accepted: P2PKH, P2SH, and P2WKH.
+func parseDeliveryAddress(addr string) (btcutil.Address, error) {
+	deliveryAddr, err := btcutil.DecodeAddress(addr, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery address %q: %v", addr,
+			err)
+	}
+	if !deliveryAddr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("delivery address %v isn't for the %v "+
+			"network", addr, activeNetParams.Name)
+	}
+
+	switch deliveryAddr.(type) {
+	case *btcutil.AddressPubKeyHash, *btcutil.AddressScriptHash,
+		*btcutil.AddressWitnessPubKeyHash:
+	default:
+		return nil, fmt.Errorf("unsupported delivery address %v, only "+
+			"P2PKH, P2SH, and P2WKH addresses may be used", addr)
+	}
+
+	return deliveryAddr, nil
+}
+
+// deliveryAddrScript returns the public key script paying to the passed
+// delivery address, or nil if no address is passed, indicating the delivery
+// script negotiated during funding is to be used.
+func deliveryAddrScript(addr btcutil.Address) ([]byte, error) {
+	if addr == nil {
+		return nil, nil
+	}
+
+	return txscript.PayToAddrScript(addr)
+}
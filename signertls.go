@@ -0,0 +1,165 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"time"
+)
+
+const (
+	// signerCertFilename is the name of the file within the data directory
+	// holding the self-signed certificate the signerlisten address is
+	// served with. It's copied to the watch-only node, which pins it.
+	signerCertFilename = "signer.cert"
+
+	// signerKeyFilename is the name of the file within the data directory
+	// holding the private key of the signer certificate.
+	signerKeyFilename = "signer.key"
+
+	// signerTLSServerName is the name the signer certificate is issued
+	// for. As the watch-only node pins the certificate itself, rather than
+	// trusting any certificate authority, it verifies the certificate
+	// against this name regardless of the address it dials.
+	signerTLSServerName = "lnd-signer"
+
+	// signerCertValidity is the period the signer certificate is valid
+	// for once generated.
+	signerCertValidity = 14 * 30 * 24 * time.Hour
+
+	// signerCertRenewal is the period before the signer certificate
+	// expires within which it's renewed at startup.
+	signerCertRenewal = 60 * 24 * time.Hour
+)
+
+// genSignerCertPair generates a self-signed certificate and private key for
+// the signerlisten address, writing them to the passed paths. If both already
+// exist, then the certificate is only renewed should it be close to expiring.
+func genSignerCertPair(certPath, keyPath string) error {
+	_, certErr := os.Stat(certPath)
+	_, keyErr := os.Stat(keyPath)
+	if certErr == nil && keyErr == nil {
+		return renewSignerCert(certPath, keyPath, time.Now())
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	certPEM, err := signerCert(priv, nil, time.Now())
+	if err != nil {
+		return err
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: keyBytes,
+	})
+
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		os.Remove(certPath)
+		return err
+	}
+
+	return nil
+}
+
+// renewSignerCert replaces the signer certificate at certPath with a new one
+// should it expire within signerCertRenewal of the passed time. The new
+// certificate is issued by the old one under the same key, so a watch-only
+// node pinning the old certificate continues to accept it until the old
+// certificate expires, leaving time to copy the new one over.
+func renewSignerCert(certPath, keyPath string, now time.Time) error {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return err
+	}
+	if now.Add(signerCertRenewal).Before(cert.NotAfter) {
+		return nil
+	}
+
+	priv, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("signer key %v is not an ECDSA key", keyPath)
+	}
+	certPEM, err := signerCert(priv, cert, now)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(certPath, certPEM, 0644); err != nil {
+		return err
+	}
+
+	ltndLog.Warnf("Renewed the signer certificate expiring at %v, %v "+
+		"must be copied to the watch-only node before then",
+		cert.NotAfter, certPath)
+
+	return nil
+}
+
+// signerCert returns a PEM encoded certificate for the signerlisten address
+// under the passed key, valid for signerCertValidity from the passed time. The
+// certificate is issued by the passed parent certificate under the same key,
+// or is self-signed if the parent is nil. Each certificate's subject carries
+// its serial number, as otherwise a certificate and its parent would be
+// taken for the same certificate when verifying the chain between them.
+func signerCert(priv *ecdsa.PrivateKey, parent *x509.Certificate,
+	now time.Time) ([]byte, error) {
+
+	serialLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, serialLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"lnd remote signer"},
+			CommonName:   signerTLSServerName,
+			SerialNumber: serial.String(),
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(signerCertValidity),
+
+		KeyUsage: x509.KeyUsageKeyEncipherment |
+			x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{signerTLSServerName},
+	}
+
+	if parent == nil {
+		parent = &template
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template,
+		parent, &priv.PublicKey, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: derBytes,
+	}), nil
+}
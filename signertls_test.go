@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readSignerCert reads and parses the signer certificate at the passed path.
+func readSignerCert(t *testing.T, certPath string) (*x509.Certificate, []byte) {
+	certPEM, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		t.Fatalf("unable to read certificate: %v", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatalf("unable to decode certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	return cert, certPEM
+}
+
+// TestRenewSignerCert tests that the signer certificate is only renewed once
+// it's close to expiring, and that the renewed certificate is accepted by a
+// watch-only node still pinning the old one.
+func TestRenewSignerCert(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "signertls")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	certPath := filepath.Join(tempDirName, signerCertFilename)
+	keyPath := filepath.Join(tempDirName, signerKeyFilename)
+	if err := genSignerCertPair(certPath, keyPath); err != nil {
+		t.Fatalf("unable to generate certificate: %v", err)
+	}
+	oldCert, oldPEM := readSignerCert(t, certPath)
+	oldKey, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("unable to read key: %v", err)
+	}
+
+	// A certificate far from expiring should be left untouched.
+	if err := genSignerCertPair(certPath, keyPath); err != nil {
+		t.Fatalf("unable to generate certificate: %v", err)
+	}
+	if _, certPEM := readSignerCert(t, certPath); !bytes.Equal(certPEM,
+		oldPEM) {
+
+		t.Fatalf("certificate renewed before nearing expiry")
+	}
+
+	// Once within the renewal period, a new certificate should be issued
+	// under the same key.
+	now := oldCert.NotAfter.Add(-signerCertRenewal / 2)
+	if err := renewSignerCert(certPath, keyPath, now); err != nil {
+		t.Fatalf("unable to renew certificate: %v", err)
+	}
+	newCert, _ := readSignerCert(t, certPath)
+	if !newCert.NotAfter.After(oldCert.NotAfter) {
+		t.Fatalf("renewed certificate expires at %v, before the old "+
+			"one at %v", newCert.NotAfter, oldCert.NotAfter)
+	}
+	key, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("unable to read key: %v", err)
+	}
+	if !bytes.Equal(key, oldKey) {
+		t.Fatalf("key replaced by renewal")
+	}
+
+	// A watch-only node pinning the old certificate should accept the
+	// new one until the old one expires.
+	pinned := x509.NewCertPool()
+	pinned.AddCert(oldCert)
+	opts := x509.VerifyOptions{
+		DNSName:     signerTLSServerName,
+		Roots:       pinned,
+		CurrentTime: now,
+	}
+	if _, err := newCert.Verify(opts); err != nil {
+		t.Fatalf("renewed certificate rejected: %v", err)
+	}
+	opts.CurrentTime = oldCert.NotAfter.Add(time.Hour)
+	if _, err := newCert.Verify(opts); err == nil {
+		t.Fatalf("renewed certificate accepted after old one expired")
+	}
+
+	// Once copied over, the new certificate should be accepted on its
+	// own.
+	opts.Roots = x509.NewCertPool()
+	opts.Roots.AddCert(newCert)
+	if _, err := newCert.Verify(opts); err != nil {
+		t.Fatalf("renewed certificate rejected: %v", err)
+	}
+}
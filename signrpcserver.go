@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/brontide"
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnrpc/signrpc"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/txscript"
+	"github.com/roasbeef/btcd/wire"
+	"golang.org/x/net/context"
+)
+
+// signerPermissions maps each method of the signrpc service to the macaroon
+// permission required to call it via the main RPC server.
+var signerPermissions = map[string]string{
+	"/signrpc.Signer/SignOutputRaw":        macaroons.PermissionSigner,
+	"/signrpc.Signer/ComputeInputScript":   macaroons.PermissionSigner,
+	"/signrpc.Signer/DeriveSharedKey":      macaroons.PermissionSigner,
+	"/signrpc.Signer/GetIdentityPubKey":    macaroons.PermissionSigner,
+	"/signrpc.Signer/DeriveRevocationRoot": macaroons.PermissionSigner,
+	"/signrpc.Signer/DeriveKey":            macaroons.PermissionSigner,
+}
+
+// remoteSignerPermissions maps each method of the signrpc service to the
+// macaroon permission required to call it via the signerlisten address. It's
+// distinct from the signer permission, as a companion tool must never be able
+// to obtain the revocation secrets handed out to a watch-only node.
+var remoteSignerPermissions = map[string]string{
+	"/signrpc.Signer/SignOutputRaw":        macaroons.PermissionRemoteSigner,
+	"/signrpc.Signer/ComputeInputScript":   macaroons.PermissionRemoteSigner,
+	"/signrpc.Signer/DeriveSharedKey":      macaroons.PermissionRemoteSigner,
+	"/signrpc.Signer/GetIdentityPubKey":    macaroons.PermissionRemoteSigner,
+	"/signrpc.Signer/DeriveRevocationRoot": macaroons.PermissionRemoteSigner,
+	"/signrpc.Signer/DeriveKey":            macaroons.PermissionRemoteSigner,
+}
+
+// signRPCServer is a gRPC front end to the signing capabilities of the
+// wallet. It serves two kinds of clients: companion tools which build custom
+// transactions using keys controlled by lnd, and a watch-only lnd node which
+// forwards all of its signing requests to this node.
+type signRPCServer struct {
+	wallet *lnwallet.LightningWallet
+
+	// identity is the node's long-term identity key, used to carry out
+	// ECDH operations on behalf of the watch-only node. It's only set if
+	// remoteSigning is.
+	identity brontide.SingleKeyECDH
+
+	// remoteSigning indicates that the server backs a watch-only node, and
+	// may therefore hand out the revocation root and carry out ECDH with
+	// the identity key. Neither is ever exposed to companion tools.
+	//
+	// NOTE: The revocation root is a secret, so a server with
+	// remoteSigning set MUST only be reachable by the watch-only node it
+	// serves.
+	remoteSigning bool
+}
+
+// errRemoteSigningOnly is returned when a method reserved for a watch-only
+// node is called by any other client.
+var errRemoteSigningOnly = errors.New("method is only available to a " +
+	"watch-only node via the signerlisten address")
+
+// A compile time check to ensure that signRPCServer fully implements the
+// SignerServer gRPC service.
+var _ signrpc.SignerServer = (*signRPCServer)(nil)
+
+// newSignRPCServer creates a new instance of the signRPCServer backed by the
+// passed wallet.
+func newSignRPCServer(wallet *lnwallet.LightningWallet,
+	remoteSigning bool) (*signRPCServer, error) {
+
+	s := &signRPCServer{
+		wallet:        wallet,
+		remoteSigning: remoteSigning,
+	}
+
+	// Only a server backing a watch-only node carries out ECDH with the
+	// identity key, so only then do we require its private key.
+	if remoteSigning {
+		identityKey, err := wallet.GetIdentitykey()
+		if err != nil {
+			return nil, err
+		}
+		s.identity = &brontide.PrivKeyECDH{PrivKey: identityKey}
+	}
+
+	return s, nil
+}
+
+// parseSignReq parses the transaction and set of sign descriptors within the
+// passed request. The sighash midstate is computed locally, rather than
+// trusting the requester to provide it.
+func parseSignReq(in *signrpc.SignReq) (*wire.MsgTx,
+	[]*lnwallet.SignDescriptor, error) {
+
+	tx := wire.NewMsgTx()
+	if err := tx.Deserialize(bytes.NewReader(in.RawTxBytes)); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode tx: %v", err)
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx)
+	signDescs := make([]*lnwallet.SignDescriptor, 0, len(in.SignDescs))
+	for _, desc := range in.SignDescs {
+		if desc.Output == nil {
+			return nil, nil, fmt.Errorf("sign descriptor is " +
+				"missing the output to sign")
+		}
+		if int(desc.InputIndex) >= len(tx.TxIn) || desc.InputIndex < 0 {
+			return nil, nil, fmt.Errorf("invalid input index %v",
+				desc.InputIndex)
+		}
+
+		var pubKey *btcec.PublicKey
+		if len(desc.PubKey) != 0 {
+			var err error
+			pubKey, err = btcec.ParsePubKey(desc.PubKey,
+				btcec.S256())
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		signDescs = append(signDescs, &lnwallet.SignDescriptor{
+			PubKey:       pubKey,
+			RedeemScript: desc.RedeemScript,
+			Output: &wire.TxOut{
+				Value:    desc.Output.Value,
+				PkScript: desc.Output.PkScript,
+			},
+			HashType:   txscript.SigHashType(desc.Sighash),
+			SigHashes:  sigHashes,
+			InputIndex: int(desc.InputIndex),
+		})
+	}
+
+	return tx, signDescs, nil
+}
+
+// SignOutputRaw generates a signature for each of the sign descriptors within
+// the passed request.
+func (s *signRPCServer) SignOutputRaw(ctx context.Context,
+	in *signrpc.SignReq) (*signrpc.SignResp, error) {
+
+	tx, signDescs, err := parseSignReq(in)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[signoutputraw] txid=%v, num_descs=%v", tx.TxSha(),
+		len(signDescs))
+
+	sigs := make([][]byte, 0, len(signDescs))
+	for _, signDesc := range signDescs {
+		if signDesc.PubKey == nil {
+			return nil, fmt.Errorf("sign descriptor is missing " +
+				"the public key to sign with")
+		}
+
+		sig, err := s.wallet.Signer.SignOutputRaw(tx, signDesc)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return &signrpc.SignResp{RawSigs: sigs}, nil
+}
+
+// ComputeInputScript generates a complete input script for each of the sign
+// descriptors within the passed request.
+func (s *signRPCServer) ComputeInputScript(ctx context.Context,
+	in *signrpc.SignReq) (*signrpc.InputScriptResp, error) {
+
+	tx, signDescs, err := parseSignReq(in)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[computeinputscript] txid=%v, num_descs=%v",
+		tx.TxSha(), len(signDescs))
+
+	inputScripts := make([]*signrpc.InputScript, 0, len(signDescs))
+	for _, signDesc := range signDescs {
+		inputScript, err := s.wallet.Signer.ComputeInputScript(tx,
+			signDesc)
+		if err != nil {
+			return nil, err
+		}
+		if inputScript == nil {
+			return nil, fmt.Errorf("output %x isn't controlled by "+
+				"the signer", signDesc.Output.PkScript)
+		}
+
+		inputScripts = append(inputScripts, &signrpc.InputScript{
+			Witness:   inputScript.Witness,
+			SigScript: inputScript.ScriptSig,
+		})
+	}
+
+	return &signrpc.InputScriptResp{InputScripts: inputScripts}, nil
+}
+
+// DeriveSharedKey performs an ECDH operation between the passed ephemeral key
+// and the node's identity key, allowing the watch-only node to carry out the
+// brontide handshake.
+func (s *signRPCServer) DeriveSharedKey(ctx context.Context,
+	in *signrpc.SharedKeyRequest) (*signrpc.SharedKeyResponse, error) {
+
+	if !s.remoteSigning {
+		return nil, errRemoteSigningOnly
+	}
+
+	pub, err := btcec.ParsePubKey(in.EphemeralPubkey, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	sharedKey, err := s.identity.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signrpc.SharedKeyResponse{SharedKey: sharedKey}, nil
+}
+
+// GetIdentityPubKey returns the public key of the node's identity key.
+func (s *signRPCServer) GetIdentityPubKey(ctx context.Context,
+	in *signrpc.IdentityPubKeyRequest) (*signrpc.IdentityPubKeyResponse, error) {
+
+	identityPub, err := s.wallet.IdentityPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &signrpc.IdentityPubKeyResponse{
+		IdentityPubkey: identityPub.SerializeCompressed(),
+	}, nil
+}
+
+// DeriveRevocationRoot returns the master elkrem root from which the
+// revocation secrets of all channels are derived.
+func (s *signRPCServer) DeriveRevocationRoot(ctx context.Context,
+	in *signrpc.RevocationRootRequest) (*signrpc.RevocationRootResponse, error) {
+
+	if !s.remoteSigning {
+		return nil, errRemoteSigningOnly
+	}
+
+	rootKey, err := s.wallet.MasterElkremRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[deriverevocationroot] handing out revocation root")
+
+	return &signrpc.RevocationRootResponse{
+		RootKey: rootKey.Serialize(),
+	}, nil
+}
+
+// DeriveKey returns the public key found at the target locator within the
+// wallet's keychain.
+func (s *signRPCServer) DeriveKey(ctx context.Context,
+	in *signrpc.KeyLocator) (*signrpc.KeyDescriptor, error) {
+
+	if in.KeyFamily < 0 || in.KeyIndex < 0 {
+		return nil, fmt.Errorf("key family and index must be " +
+			"non-negative")
+	}
+
+	keyDesc, err := s.wallet.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamily(in.KeyFamily),
+		Index:  uint32(in.KeyIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Debugf("[derivekey] family=%v, index=%v, pubkey=%x",
+		keyDesc.Family, keyDesc.Index,
+		keyDesc.PubKey.SerializeCompressed())
+
+	return &signrpc.KeyDescriptor{
+		RawKeyBytes: keyDesc.PubKey.SerializeCompressed(),
+		KeyLoc:      in,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcutil/hdkeychain"
+)
+
+// sweepAddrSource returns the address the funds recovered from a force
+// closed channel are to be swept to.
+type sweepAddrSource func() (btcutil.Address, error)
+
+// parseSweepAddress decodes the address funds recovered from force closed
+// channels are swept to.
+func parseSweepAddress(addr string) (btcutil.Address, error) {
+	sweepAddr, err := btcutil.DecodeAddress(addr, activeNetParams.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sweep address %q: %v", addr, err)
+	}
+	if !sweepAddr.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("sweep address %v isn't for the %v "+
+			"network", addr, activeNetParams.Name)
+	}
+
+	return sweepAddr, nil
+}
+
+// parseSweepXPub decodes the extended public key the addresses funds
+// recovered from force closed channels are swept to are derived from. Only a
+// public key is accepted, as no private keys are needed to derive addresses.
+func parseSweepXPub(xpub string) (*hdkeychain.ExtendedKey, error) {
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sweep extended public key: %v",
+			err)
+	}
+	if key.IsPrivate() {
+		return nil, fmt.Errorf("sweep extended key must be public, " +
+			"never pass a private key")
+	}
+	if !key.IsForNet(activeNetParams.Params) {
+		return nil, fmt.Errorf("sweep extended public key isn't for "+
+			"the %v network", activeNetParams.Name)
+	}
+
+	return key, nil
+}
+
+// newSweepAddrSource returns the source of the addresses funds recovered
+// from force closed channels are swept to. If a sweep address is configured,
+// every sweep pays to it. If a sweep extended public key is configured, each
+// sweep pays to a fresh P2WKH address derived from its external branch:
+// xpub/0/i, with the index i persisted within the passed database so
+// addresses are never reused. Otherwise, funds are swept into the wallet.
+func newSweepAddrSource(wallet *lnwallet.LightningWallet,
+	chanDB *channeldb.DB) (sweepAddrSource, error) {
+
+	switch {
+	case cfg.SweepAddress != "":
+		sweepAddr, err := parseSweepAddress(cfg.SweepAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (btcutil.Address, error) {
+			return sweepAddr, nil
+		}, nil
+
+	case cfg.SweepXPub != "":
+		xpub, err := parseSweepXPub(cfg.SweepXPub)
+		if err != nil {
+			return nil, err
+		}
+		externalBranch, err := xpub.Child(0)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (btcutil.Address, error) {
+			return deriveSweepAddr(externalBranch, chanDB)
+		}, nil
+
+	default:
+		return func() (btcutil.Address, error) {
+			return wallet.NewAddress(lnwallet.WitnessPubKey, false)
+		}, nil
+	}
+}
+
+// deriveSweepAddr derives the next unused P2WKH address from the passed
+// branch of the sweep extended public key.
+func deriveSweepAddr(branch *hdkeychain.ExtendedKey,
+	chanDB *channeldb.DB) (btcutil.Address, error) {
+
+	for {
+		index, err := chanDB.NextSweepAddrIndex()
+		if err != nil {
+			return nil, err
+		}
+
+		// A small fraction of indexes don't produce a valid child
+		// key, in which case we skip to the next index, as other
+		// wallets deriving from the same key do.
+		child, err := branch.Child(index)
+		if err == hdkeychain.ErrInvalidChild {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		pubKey, err := child.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+		sweepAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+			pubKeyHash, activeNetParams.Params,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		utxnLog.Infof("Sweeping to address %v derived from sweep "+
+			"extended public key at index %v", sweepAddr, index)
+
+		return sweepAddr, nil
+	}
+}
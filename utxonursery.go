@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/davecgh/go-spew/spew"
@@ -12,6 +13,10 @@ import (
 	"github.com/roasbeef/btcutil"
 )
 
+// preimageLookup returns the preimage of the passed payment hash, if it's
+// known to the daemon.
+type preimageLookup func(paymentHash wire.ShaHash) ([32]byte, bool)
+
 // utxoNursery is a system dedicated to incubating time-locked outputs created
 // by the broadcast of a commitment transaction either by us, or the remote
 // peer. The nursery accepts outputs and "incubates" them until they've reached
@@ -19,13 +24,22 @@ import (
 // considered mature after the relative time-lock within the pkScript has
 // passed. As outputs reach their maturity age, they're sweeped in batches into
 // the source wallet, returning the outputs so they can be used within future
-// channels, or regular Bitcoin transactions.
+// channels, or regular Bitcoin transactions. Alternatively, the outputs may be
+// swept to an external address, such as one of a cold wallet.
 type utxoNursery struct {
 	sync.RWMutex
 
 	notifier chainntnfs.ChainNotifier
 	wallet   *lnwallet.LightningWallet
 
+	// lookupPreimage is used to obtain the preimages required to sweep
+	// incoming HTLCs.
+	lookupPreimage preimageLookup
+
+	// newSweepAddr returns the address each batch of mature outputs is
+	// swept to.
+	newSweepAddr sweepAddrSource
+
 	db channeldb.DB
 
 	requests chan *incubationRequest
@@ -41,13 +55,18 @@ type utxoNursery struct {
 }
 
 // newUtxoNursery creates a new instance of the utxoNursery from a
-// ChainNotifier and LightningWallet instance.
+// ChainNotifier and LightningWallet instance. The passed lookup function is
+// used to obtain the preimages of incoming HTLCs, and the passed sweep address
+// source the destination of each sweep.
 func newUtxoNursery(notifier chainntnfs.ChainNotifier,
-	wallet *lnwallet.LightningWallet) *utxoNursery {
+	wallet *lnwallet.LightningWallet, lookupPreimage preimageLookup,
+	newSweepAddr sweepAddrSource) *utxoNursery {
 
 	return &utxoNursery{
 		notifier:        notifier,
 		wallet:          wallet,
+		lookupPreimage:  lookupPreimage,
+		newSweepAddr:    newSweepAddr,
 		requests:        make(chan *incubationRequest),
 		unstagedOutputs: make(map[wire.OutPoint]*immatureOutput),
 		stagedOutputs:   make(map[uint32][]*immatureOutput),
@@ -93,6 +112,11 @@ func (u *utxoNursery) incubator() {
 	// Outputs that are transitioning from early to mid-stage are sent over
 	// this channel by each output's dedicated watcher goroutine.
 	midStageOutputs := make(chan *immatureOutput)
+
+	// bestHeight is the height of the latest block we've been notified
+	// of, used to ensure outputs are never staged at a height which has
+	// already passed.
+	var bestHeight uint32
 out:
 	for {
 		select {
@@ -101,6 +125,15 @@ out:
 				len(earlyStagers.outputs))
 
 			for _, immatureUtxo := range earlyStagers.outputs {
+				// If the transaction creating the output is
+				// already known to be confirmed, then the
+				// output skips straight to the mid stage.
+				if earlyStagers.confHeight != 0 {
+					immatureUtxo.confHeight = earlyStagers.confHeight
+					u.stageOutput(immatureUtxo, bestHeight)
+					continue
+				}
+
 				outpoint := immatureUtxo.outPoint
 				sourceTXID := outpoint.Hash
 
@@ -139,19 +172,13 @@ out:
 			// created, so we move it from early stage to
 			// mid-stage.
 			delete(u.unstagedOutputs, midUtxo.outPoint)
-
-			// TODO(roasbeef): your off-by-one sense are tingling...
-			maturityHeight := midUtxo.confHeight + midUtxo.blocksToMaturity
-			u.stagedOutputs[maturityHeight] = append(u.stagedOutputs[maturityHeight], midUtxo)
-
-			utxnLog.Infof("Outpoint %v now mid-stage, will mature "+
-				"at height %v (delay of %v)", midUtxo.outPoint,
-				maturityHeight, midUtxo.blocksToMaturity)
+			u.stageOutput(midUtxo, bestHeight)
 		case epoch := <-newBlocks.Epochs:
 			// A new block has just been connected, check to see if
 			// we have any new outputs that can be swept into the
 			// wallet.
 			newHeight := uint32(epoch.Height)
+			bestHeight = newHeight
 			matureOutputs, ok := u.stagedOutputs[newHeight]
 			if !ok {
 				continue
@@ -189,6 +216,11 @@ out:
 					err, spew.Sdump(sweepTx))
 				continue
 			}
+			err = u.wallet.LabelTransaction(sweepTx.TxSha(),
+				lnwallet.SweepTxLabel, false)
+			if err != nil {
+				utxnLog.Warnf("unable to label sweep tx: %v", err)
+			}
 			delete(u.stagedOutputs, newHeight)
 		case <-u.quit:
 			break out
@@ -198,11 +230,37 @@ out:
 	u.wg.Done()
 }
 
+// stageOutput moves the passed mid-stage output into the set of outputs to be
+// swept once the height at which it matures is reached. An output which has
+// already matured is staged to be swept within the next block.
+func (u *utxoNursery) stageOutput(midUtxo *immatureOutput, bestHeight uint32) {
+	// TODO(roasbeef): your off-by-one sense are tingling...
+	maturityHeight := midUtxo.confHeight + midUtxo.blocksToMaturity
+
+	// Outputs locked by a channel lease, or by the expiry of an outgoing
+	// HTLC can't be swept until the absolute timelock has passed, even if
+	// the relative delay has already passed.
+	if midUtxo.leaseExpiry > maturityHeight {
+		maturityHeight = midUtxo.leaseExpiry
+	}
+	if midUtxo.cltvExpiry > maturityHeight {
+		maturityHeight = midUtxo.cltvExpiry
+	}
+	if maturityHeight <= bestHeight {
+		maturityHeight = bestHeight + 1
+	}
+	u.stagedOutputs[maturityHeight] = append(u.stagedOutputs[maturityHeight], midUtxo)
+
+	utxnLog.Infof("Outpoint %v now mid-stage, will mature "+
+		"at height %v (delay of %v)", midUtxo.outPoint,
+		maturityHeight, midUtxo.blocksToMaturity)
+}
+
 // createSweepTx creates a final sweeping transaction with all witnesses
 // inplace for all inputs. The created transaction has a single output sending
-// all the funds back to the source wallet.
+// all the funds back to the source wallet, or the configured sweep address.
 func (u *utxoNursery) createSweepTx(matureOutputs []*immatureOutput) (*wire.MsgTx, error) {
-	sweepAddr, err := u.wallet.NewAddress(lnwallet.WitnessPubKey, false)
+	sweepAddr, err := u.newSweepAddr()
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +286,16 @@ func (u *utxoNursery) createSweepTx(matureOutputs []*immatureOutput) (*wire.MsgT
 			// TODO(roasbeef): assumes pure block delays
 			Sequence: utxo.blocksToMaturity,
 		})
+
+		// The lock time of the sweep transaction must satisfy the
+		// lease of every leased output, and the expiry of every HTLC
+		// output being swept.
+		if utxo.leaseExpiry > sweepTx.LockTime {
+			sweepTx.LockTime = utxo.leaseExpiry
+		}
+		if utxo.cltvExpiry > sweepTx.LockTime {
+			sweepTx.LockTime = utxo.cltvExpiry
+		}
 	}
 
 	// TODO(roasbeef): insert fee calculation
@@ -268,6 +336,16 @@ type immatureOutput struct {
 	// to modify logic later to account for MTP based timeouts.
 	blocksToMaturity uint32
 	confHeight       uint32
+
+	// leaseExpiry is the absolute block height before which the output
+	// can't be swept due to a channel lease. A value of zero indicates
+	// that the output isn't leased.
+	leaseExpiry uint32
+
+	// cltvExpiry is the absolute block height before which an outgoing
+	// HTLC output can't be swept as the HTLC has yet to expire. A value of
+	// zero indicates that the output isn't an outgoing HTLC.
+	cltvExpiry uint32
 }
 
 // incubationRequest is a request to the utxoNursery to incubate a set of
@@ -275,31 +353,124 @@ type immatureOutput struct {
 // available.
 type incubationRequest struct {
 	outputs []*immatureOutput
+
+	// confHeight is the height at which the transaction creating the
+	// outputs confirmed. A value of zero indicates that the transaction
+	// has yet to confirm.
+	confHeight uint32
 }
 
 // incubateOutputs sends a request to utxoNursery to incubate the outputs
-// defined within the summary of a closed channel. Induvidually, as all outputs
-// reach maturity they'll be sweeped back into the wallet.
-func (u *utxoNursery) incubateOutputs(closeSummary *lnwallet.ForceCloseSummary) {
+// described by the passed contract resolutions of a closed channel.
+// Induvidually, as all outputs reach maturity they'll be sweeped back into the
+// wallet. Outgoing HTLCs are swept once they've expired, while incoming HTLCs
+// are only swept if their preimage is known, as they'd otherwise be claimed by
+// the remote party once they expire. Resolutions which have already been
+// resolved are skipped. If the commitment transaction is already known to be
+// confirmed, then confHeight should be set to its confirmation height,
+// otherwise it should be zero.
+func (u *utxoNursery) incubateOutputs(resolutions []*channeldb.ContractResolution,
+	confHeight uint32) {
+
+	var outputs []*immatureOutput
+	for _, resolution := range resolutions {
+		if resolution.Resolved {
+			continue
+		}
+
+		output, err := u.immatureOutput(resolution)
+		if err != nil {
+			utxnLog.Warnf("Unable to sweep output %v: %v",
+				resolution.OutPoint, err)
+			continue
+		}
+		outputs = append(outputs, output)
+	}
+
+	if len(outputs) == 0 {
+		return
+	}
+
+	select {
+	case u.requests <- &incubationRequest{
+		outputs:    outputs,
+		confHeight: confHeight,
+	}:
+	case <-u.quit:
+	}
+}
+
+// immatureOutput creates an immatureOutput sweeping the output described by
+// the passed resolution. Our commitment output is swept via its delay clause,
+// an outgoing HTLC via its timeout clause, and an incoming HTLC via its success
+// clause. An error is returned if the preimage of an incoming HTLC isn't
+// known.
+func (u *utxoNursery) immatureOutput(resolution *channeldb.ContractResolution) (*immatureOutput, error) {
+	signDesc := &lnwallet.SignDescriptor{
+		PubKey:       resolution.PubKey,
+		RedeemScript: resolution.WitnessScript,
+		Output: &wire.TxOut{
+			Value:    int64(resolution.Amount),
+			PkScript: resolution.PkScript,
+		},
+		HashType: txscript.SigHashAll,
+	}
+
+	output := &immatureOutput{
+		amt:              resolution.Amount,
+		outPoint:         resolution.OutPoint,
+		blocksToMaturity: resolution.CsvDelay,
+	}
+
 	// TODO(roasbeef): should use factory func here based on an interface
 	//  * spend here also assumes delay is blocked bsaed, and in range
-	witnessFunc := func(tx *wire.MsgTx, hc *txscript.TxSigHashes, inputIndex int) ([][]byte, error) {
-		desc := closeSummary.SelfOutputSignDesc
-		desc.SigHashes = hc
-		desc.InputIndex = inputIndex
+	var spend func(*lnwallet.SignDescriptor, *wire.MsgTx) (wire.TxWitness, error)
+	switch resolution.Kind {
+	case channeldb.ContractCommitOutput:
+		output.leaseExpiry = resolution.LeaseExpiry
+		spend = func(desc *lnwallet.SignDescriptor,
+			tx *wire.MsgTx) (wire.TxWitness, error) {
+
+			return lnwallet.CommitSpendTimeout(u.wallet.Signer,
+				desc, tx)
+		}
 
-		return lnwallet.CommitSpendTimeout(u.wallet.Signer, desc, tx)
-	}
+	case channeldb.ContractOutgoingHtlc:
+		output.cltvExpiry = resolution.Expiry
+		spend = func(desc *lnwallet.SignDescriptor,
+			tx *wire.MsgTx) (wire.TxWitness, error) {
+
+			return lnwallet.HtlcSpendTimeout(u.wallet.Signer,
+				desc, tx)
+		}
 
-	outputAmt := btcutil.Amount(closeSummary.SelfOutputSignDesc.Output.Value)
-	selfOutput := &immatureOutput{
-		amt:              outputAmt,
-		outPoint:         closeSummary.SelfOutpoint,
-		witnessFunc:      witnessFunc,
-		blocksToMaturity: closeSummary.SelfOutputMaturity,
+	case channeldb.ContractIncomingHtlc:
+		preimage, ok := u.lookupPreimage(resolution.PaymentHash)
+		if !ok {
+			return nil, fmt.Errorf("preimage of incoming HTLC %x "+
+				"is unknown", resolution.PaymentHash)
+		}
+		spend = func(desc *lnwallet.SignDescriptor,
+			tx *wire.MsgTx) (wire.TxWitness, error) {
+
+			return lnwallet.HtlcSpendSuccess(u.wallet.Signer,
+				desc, tx, preimage[:])
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown contract kind %v",
+			resolution.Kind)
 	}
 
-	u.requests <- &incubationRequest{
-		outputs: []*immatureOutput{selfOutput},
+	output.witnessFunc = func(tx *wire.MsgTx, hc *txscript.TxSigHashes,
+		inputIndex int) ([][]byte, error) {
+
+		desc := *signDesc
+		desc.SigHashes = hc
+		desc.InputIndex = inputIndex
+
+		return spend(&desc, tx)
 	}
+
+	return output, nil
 }